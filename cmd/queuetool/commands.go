@@ -0,0 +1,188 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// selection selects the queues to operate on.
+type selection struct {
+	Directory string
+	Exporters []string
+	Signals   []string
+}
+
+func (s *selection) flags(fs *pflag.FlagSet) {
+	fs.StringVar(&s.Directory, "directory", "", "Directory of the file_storage extension storing the queues")
+	fs.StringSliceVar(&s.Exporters, "exporter", nil, "IDs of the exporters whose queues are selected, e.g. otlp/backend (default all)")
+	fs.StringSliceVar(&s.Signals, "signal", nil, "Signals whose queues are selected: logs, metrics or traces (default all)")
+}
+
+func (s *selection) queues() ([]queue, error) {
+	if s.Directory == "" {
+		return nil, errors.New("--directory is required")
+	}
+	for _, signal := range s.Signals {
+		if !slices.Contains(signals, signal) {
+			return nil, fmt.Errorf("unknown signal %q", signal)
+		}
+	}
+	all, err := findQueues(s.Directory)
+	if err != nil {
+		return nil, err
+	}
+	var selected []queue
+	for _, q := range all {
+		if len(s.Exporters) > 0 && !slices.Contains(s.Exporters, q.exporter) {
+			continue
+		}
+		if len(s.Signals) > 0 && !slices.Contains(s.Signals, q.signal) {
+			continue
+		}
+		selected = append(selected, q)
+	}
+	return selected, nil
+}
+
+// readRequests returns the requests of a queue. Requests that cannot be
+// unmarshaled are reported to w and skipped.
+func readRequests(q queue, w io.Writer) ([]request, error) {
+	items, err := readQueue(q.path)
+	if err != nil {
+		return nil, err
+	}
+	requests := make([]request, 0, len(items))
+	for _, item := range items {
+		req, err := unmarshalRequest(q.signal, item.data)
+		if err != nil {
+			fmt.Fprintf(w, "%s %s: skipping request %d: %v\n", q.exporter, q.signal, item.index, err)
+			continue
+		}
+		requests = append(requests, req)
+	}
+	return requests, nil
+}
+
+func newListCommand() *cobra.Command {
+	var sel selection
+	cmd := &cobra.Command{
+		Use:     "list",
+		Short:   "Lists the persistent queues and the number of requests and items they contain",
+		Example: "queuetool list --directory /var/lib/otelcol/file_storage",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runList(&sel, cmd.OutOrStdout(), cmd.ErrOrStderr())
+		},
+	}
+	sel.flags(cmd.Flags())
+	return cmd
+}
+
+func runList(sel *selection, stdout, stderr io.Writer) error {
+	queues, err := sel.queues()
+	if err != nil {
+		return err
+	}
+	tw := tabwriter.NewWriter(stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "EXPORTER\tSIGNAL\tREQUESTS\tITEMS\tFILE")
+	for _, q := range queues {
+		requests, err := readRequests(q, stderr)
+		if err != nil {
+			return err
+		}
+		items := 0
+		for _, req := range requests {
+			items += req.itemCount()
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%d\t%s\n", q.exporter, q.signal, len(requests), items, filepath.Base(q.path))
+	}
+	return tw.Flush()
+}
+
+func newDumpCommand() *cobra.Command {
+	var sel selection
+	var output string
+	cmd := &cobra.Command{
+		Use: "dump",
+		Short: "Dumps the requests of the persistent queues as OTLP/JSON files, one file per queue " +
+			"with one export request per line",
+		Example: "queuetool dump --directory /var/lib/otelcol/file_storage --output ./queues",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runDump(&sel, output, cmd.OutOrStdout(), cmd.ErrOrStderr())
+		},
+	}
+	sel.flags(cmd.Flags())
+	cmd.Flags().StringVar(&output, "output", ".", "Directory the OTLP/JSON files are written to")
+	return cmd
+}
+
+func runDump(sel *selection, output string, stdout, stderr io.Writer) error {
+	queues, err := sel.queues()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(output, 0o755); err != nil {
+		return err
+	}
+	for _, q := range queues {
+		requests, err := readRequests(q, stderr)
+		if err != nil {
+			return err
+		}
+		path := filepath.Join(output, filepath.Base(q.path)+".json")
+		if err := writeJSONLines(path, requests); err != nil {
+			return err
+		}
+		fmt.Fprintf(stdout, "%s %s: dumped %d requests to %s\n", q.exporter, q.signal, len(requests), path)
+	}
+	return nil
+}
+
+func writeJSONLines(path string, requests []request) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	for _, req := range requests {
+		b, err := req.marshalJSON()
+		if err != nil {
+			_ = f.Close()
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+		if _, err := f.Write(append(b, '\n')); err != nil {
+			_ = f.Close()
+			return err
+		}
+	}
+	return f.Close()
+}
+
+func newReplayCommand() *cobra.Command {
+	var sel selection
+	var cfg replayConfig
+	cmd := &cobra.Command{
+		Use: "replay",
+		Short: "Replays the requests of the persistent queues to an OTLP endpoint. " +
+			"The queues are not modified",
+		Example: "queuetool replay --directory /var/lib/otelcol/file_storage --endpoint otlp.example.com:4317",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runReplay(cmd.Context(), &sel, &cfg, cmd.OutOrStdout(), cmd.ErrOrStderr())
+		},
+	}
+	sel.flags(cmd.Flags())
+	cfg.flags(cmd.Flags())
+	return cmd
+}
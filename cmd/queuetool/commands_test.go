@@ -0,0 +1,196 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const defaultTestTimeout = 5 * time.Second
+
+func newTestDirectory(t *testing.T) string {
+	dir := t.TempDir()
+	writeQueue(t, filepath.Join(dir, "exporter_otlp__logs"), 3, logsRequestBytes(t, "a", "b"), logsRequestBytes(t, "c"))
+	writeQueue(t, filepath.Join(dir, "exporter_otlp_backend_traces"), 0, tracesRequestBytes(t, "span"))
+	// Not a queue of an exporter.
+	writeQueue(t, filepath.Join(dir, "receiver_filelog__"), 0)
+	return dir
+}
+
+func TestList(t *testing.T) {
+	dir := newTestDirectory(t)
+	var stdout, stderr bytes.Buffer
+	require.NoError(t, runList(&selection{Directory: dir}, &stdout, &stderr))
+	assert.Equal(t, `EXPORTER      SIGNAL  REQUESTS  ITEMS  FILE
+otlp          logs    2         3      exporter_otlp__logs
+otlp/backend  traces  1         1      exporter_otlp_backend_traces
+`, stdout.String())
+	assert.Empty(t, stderr.String())
+
+	stdout.Reset()
+	require.NoError(t, runList(&selection{Directory: dir, Signals: []string{"traces"}}, &stdout, &stderr))
+	assert.Equal(t, 2, strings.Count(stdout.String(), "\n"))
+
+	assert.EqualError(t, runList(&selection{Directory: dir, Signals: []string{"profiles"}}, &stdout, &stderr), `unknown signal "profiles"`)
+	assert.EqualError(t, runList(&selection{}, &stdout, &stderr), "--directory is required")
+}
+
+func TestListSkipsInvalidRequests(t *testing.T) {
+	dir := t.TempDir()
+	writeQueue(t, filepath.Join(dir, "exporter_otlp__logs"), 0, []byte("invalid"), logsRequestBytes(t, "a"))
+	var stdout, stderr bytes.Buffer
+	require.NoError(t, runList(&selection{Directory: dir}, &stdout, &stderr))
+	assert.Contains(t, stdout.String(), "otlp      logs    1         1")
+	assert.Contains(t, stderr.String(), "otlp logs: skipping request 0")
+}
+
+func TestDump(t *testing.T) {
+	dir := newTestDirectory(t)
+	output := filepath.Join(t.TempDir(), "dump")
+	var stdout, stderr bytes.Buffer
+	require.NoError(t, runDump(&selection{Directory: dir, Exporters: []string{"otlp"}}, output, &stdout, &stderr))
+
+	entries, err := os.ReadDir(output)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	b, err := os.ReadFile(filepath.Join(output, "exporter_otlp__logs.json"))
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSuffix(string(b), "\n"), "\n")
+	require.Len(t, lines, 2)
+	var bodies []string
+	for _, line := range lines {
+		req := plogotlp.NewExportRequest()
+		require.NoError(t, req.UnmarshalJSON([]byte(line)))
+		records := req.Logs().ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+		for i := 0; i < records.Len(); i++ {
+			bodies = append(bodies, records.At(i).Body().Str())
+		}
+	}
+	assert.Equal(t, []string{"a", "b", "c"}, bodies)
+}
+
+type testLogsServer struct {
+	plogotlp.UnimplementedGRPCServer
+	mu      sync.Mutex
+	logs    []plog.Logs
+	headers []string
+}
+
+func (s *testLogsServer) Export(ctx context.Context, req plogotlp.ExportRequest) (plogotlp.ExportResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.logs = append(s.logs, req.Logs())
+	md, _ := metadata.FromIncomingContext(ctx)
+	s.headers = append(s.headers, md.Get("x-tenant")...)
+	return plogotlp.NewExportResponse(), nil
+}
+
+type testTracesServer struct {
+	ptraceotlp.UnimplementedGRPCServer
+}
+
+func (s *testTracesServer) Export(context.Context, ptraceotlp.ExportRequest) (ptraceotlp.ExportResponse, error) {
+	resp := ptraceotlp.NewExportResponse()
+	resp.PartialSuccess().SetRejectedSpans(1)
+	return resp, nil
+}
+
+func TestReplayGRPC(t *testing.T) {
+	lis, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	srv := grpc.NewServer()
+	logsServer := &testLogsServer{}
+	plogotlp.RegisterGRPCServer(srv, logsServer)
+	ptraceotlp.RegisterGRPCServer(srv, &testTracesServer{})
+	go func() { _ = srv.Serve(lis) }()
+	defer srv.Stop()
+
+	dir := newTestDirectory(t)
+	cfg := &replayConfig{
+		Endpoint: lis.Addr().String(),
+		Protocol: protocolGRPC,
+		Insecure: true,
+		Headers:  map[string]string{"x-tenant": "tenant1"},
+		Timeout:  defaultTestTimeout,
+	}
+	var stdout, stderr bytes.Buffer
+	require.NoError(t, runReplay(context.Background(), &selection{Directory: dir}, cfg, &stdout, &stderr))
+	assert.Equal(t, `otlp logs: replayed 2 of 2 requests, 3 items, 0 rejected by the endpoint
+otlp/backend traces: replayed 1 of 1 requests, 1 items, 1 rejected by the endpoint
+`, stdout.String())
+
+	logsServer.mu.Lock()
+	defer logsServer.mu.Unlock()
+	require.Len(t, logsServer.logs, 2)
+	assert.Equal(t, 2, logsServer.logs[0].LogRecordCount())
+	assert.Equal(t, 1, logsServer.logs[1].LogRecordCount())
+	assert.Equal(t, []string{"tenant1", "tenant1"}, logsServer.headers)
+}
+
+func TestReplayHTTP(t *testing.T) {
+	var mu sync.Mutex
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths = append(paths, r.URL.Path)
+		mu.Unlock()
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		if r.URL.Path == "/v1/traces" {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		req := plogotlp.NewExportRequest()
+		assert.NoError(t, req.UnmarshalProto(body))
+		assert.Equal(t, "application/x-protobuf", r.Header.Get("Content-Type"))
+	}))
+	defer srv.Close()
+
+	dir := newTestDirectory(t)
+	cfg := &replayConfig{
+		Endpoint: strings.TrimPrefix(srv.URL, "http://"),
+		Protocol: protocolHTTP,
+		Insecure: true,
+		Timeout:  defaultTestTimeout,
+	}
+	var stdout, stderr bytes.Buffer
+	err := runReplay(context.Background(), &selection{Directory: dir}, cfg, &stdout, &stderr)
+	assert.EqualError(t, err, "failed to replay 1 requests")
+	assert.Contains(t, stdout.String(), "otlp logs: replayed 2 of 2 requests, 3 items")
+	assert.Contains(t, stdout.String(), "otlp/backend traces: replayed 0 of 1 requests")
+	assert.Contains(t, stderr.String(), "otlp/backend traces: failed to replay request 1 of 1: 503 Service Unavailable")
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"/v1/logs", "/v1/logs", "/v1/traces"}, paths)
+}
+
+func TestReplayInvalidConfig(t *testing.T) {
+	dir := newTestDirectory(t)
+	var stdout, stderr bytes.Buffer
+	assert.EqualError(t, runReplay(context.Background(), &selection{Directory: dir}, &replayConfig{}, &stdout, &stderr),
+		"--endpoint is required")
+	assert.EqualError(t, runReplay(context.Background(), &selection{Directory: dir}, &replayConfig{Endpoint: "localhost:4317", Protocol: "udp"}, &stdout, &stderr),
+		`unknown protocol "udp", must be "grpc" or "http"`)
+}
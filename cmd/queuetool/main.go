@@ -0,0 +1,36 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// queuetool inspects, dumps and replays the persistent sending queues that
+// the exporters of a stopped collector stored with the file_storage extension.
+package main // import "github.com/open-telemetry/opentelemetry-collector-contrib/cmd/queuetool"
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// rootCmd is the root command on which will be run children commands
+var rootCmd = &cobra.Command{
+	Use:   "queuetool",
+	Short: "Queuetool inspects, dumps and replays the persistent sending queues of a stopped collector",
+	Example: "queuetool list --directory /var/lib/otelcol/file_storage\n" +
+		"queuetool dump --directory /var/lib/otelcol/file_storage --output ./queues\n" +
+		"queuetool replay --directory /var/lib/otelcol/file_storage --endpoint otlp.example.com:4317",
+	SilenceUsage: true,
+}
+
+func init() {
+	rootCmd.AddCommand(newListCommand(), newDumpCommand(), newReplayCommand())
+
+	// Disabling completion command for end user
+	// https://github.com/spf13/cobra/blob/master/shell_completions.md
+	rootCmd.CompletionOptions.DisableDefaultCmd = true
+}
+
+func main() {
+	if err := rootCmd.Execute(); err != nil {
+		os.Exit(1)
+	}
+}
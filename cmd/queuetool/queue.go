@@ -0,0 +1,176 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.etcd.io/bbolt"
+)
+
+// The file_storage extension stores the persistent queue of each exporter
+// and signal in its own bbolt database, in the default bucket. The queued
+// requests are stored under their decimal index in the queue, and the state
+// of the queue under non-numeric keys such as "ri" and "wi".
+//
+// See go.opentelemetry.io/collector/exporter/internal/queue/persistent_queue.go
+// and extension/storage/filestorage.
+var defaultBucket = []byte("default")
+
+const (
+	exporterFilePrefix = "exporter_"
+	lockTimeout        = time.Second
+)
+
+var signals = []string{"logs", "metrics", "traces"}
+
+// queue is the persistent sending queue of an exporter for a signal.
+type queue struct {
+	// path of the database storing the queue.
+	path string
+	// exporter is the ID of the exporter, e.g. otlp/backend.
+	exporter string
+	// signal is one of logs, metrics and traces.
+	signal string
+}
+
+// queueItem is a request in a persistent queue.
+type queueItem struct {
+	index uint64
+	data  []byte
+}
+
+// findQueues returns the persistent queues stored in a file_storage directory,
+// sorted by exporter and signal.
+func findQueues(directory string) ([]queue, error) {
+	entries, err := os.ReadDir(directory)
+	if err != nil {
+		return nil, err
+	}
+	var queues []queue
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		exporter, signal, ok := parseQueueFileName(entry.Name())
+		if !ok {
+			continue
+		}
+		queues = append(queues, queue{
+			path:     filepath.Join(directory, entry.Name()),
+			exporter: exporter,
+			signal:   signal,
+		})
+	}
+	slices.SortFunc(queues, func(a, b queue) int {
+		if c := strings.Compare(a.exporter, b.exporter); c != 0 {
+			return c
+		}
+		return strings.Compare(a.signal, b.signal)
+	})
+	return queues, nil
+}
+
+// parseQueueFileName parses the names of the files created by the
+// file_storage extension for the exporters' queues: exporter_<type>_<name>_<signal>,
+// where the name may be empty and is sanitized.
+func parseQueueFileName(fileName string) (exporter string, signal string, ok bool) {
+	rest, ok := strings.CutPrefix(fileName, exporterFilePrefix)
+	if !ok {
+		return "", "", false
+	}
+	for _, s := range signals {
+		if id, found := strings.CutSuffix(rest, "_"+s); found {
+			rest, signal = id, s
+			break
+		}
+	}
+	if signal == "" {
+		return "", "", false
+	}
+	typ, name, ok := strings.Cut(rest, "_")
+	if !ok || typ == "" {
+		return "", "", false
+	}
+	name, err := unsanitize(name)
+	if err != nil {
+		return "", "", false
+	}
+	if name == "" {
+		return typ, signal, true
+	}
+	return typ + "/" + name, signal, true
+}
+
+// unsanitize reverses the sanitization of the file names by the
+// file_storage extension, which replaces the unsafe characters by a tilde
+// followed by their 4 digit hexadecimal Unicode code point.
+func unsanitize(s string) (string, error) {
+	var b strings.Builder
+	for {
+		i := strings.IndexByte(s, '~')
+		if i < 0 {
+			b.WriteString(s)
+			return b.String(), nil
+		}
+		if len(s) < i+5 {
+			return "", fmt.Errorf("invalid escape sequence in %q", s)
+		}
+		r, err := strconv.ParseUint(s[i+1:i+5], 16, 32)
+		if err != nil {
+			return "", fmt.Errorf("invalid escape sequence in %q: %w", s, err)
+		}
+		b.WriteString(s[:i])
+		b.WriteRune(rune(r))
+		s = s[i+5:]
+	}
+}
+
+// readQueue returns the requests of a persistent queue in queue order,
+// including the requests that were being sent when the collector stopped.
+//
+// The database is opened read-only, so readQueue fails if a collector is
+// still using it.
+func readQueue(path string) ([]queueItem, error) {
+	db, err := bbolt.Open(path, 0o600, &bbolt.Options{ReadOnly: true, Timeout: lockTimeout})
+	if err != nil {
+		if errors.Is(err, bbolt.ErrTimeout) {
+			return nil, fmt.Errorf("failed to open %s, is a collector still running? %w", path, err)
+		}
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer db.Close()
+
+	var items []queueItem
+	err = db.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(defaultBucket)
+		if bucket == nil {
+			return nil
+		}
+		return bucket.ForEach(func(k, v []byte) error {
+			index, err := strconv.ParseUint(string(k), 10, 64)
+			if err != nil {
+				// Not a request, e.g. the read and write indexes.
+				return nil
+			}
+			items = append(items, queueItem{index: index, data: slices.Clone(v)})
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	slices.SortFunc(items, func(a, b queueItem) int {
+		return cmp.Compare(a.index, b.index)
+	})
+	return items, nil
+}
@@ -0,0 +1,118 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"encoding/binary"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/bbolt"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// writeQueue writes a persistent queue the way the exporterhelper persistent
+// queue stores it with the file_storage extension.
+func writeQueue(t *testing.T, path string, firstIndex uint64, requests ...[]byte) {
+	db, err := bbolt.Open(path, 0o600, nil)
+	require.NoError(t, err)
+	defer db.Close()
+	require.NoError(t, db.Update(func(tx *bbolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists(defaultBucket)
+		if err != nil {
+			return err
+		}
+		index := firstIndex
+		for _, req := range requests {
+			if err := bucket.Put([]byte(strconv.FormatUint(index, 10)), req); err != nil {
+				return err
+			}
+			index++
+		}
+		if err := bucket.Put([]byte("ri"), binary.LittleEndian.AppendUint64(nil, firstIndex)); err != nil {
+			return err
+		}
+		return bucket.Put([]byte("wi"), binary.LittleEndian.AppendUint64(nil, index))
+	}))
+}
+
+func logsRequestBytes(t *testing.T, bodies ...string) []byte {
+	ld := plog.NewLogs()
+	records := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	for _, body := range bodies {
+		records.AppendEmpty().Body().SetStr(body)
+	}
+	b, err := (&plog.ProtoMarshaler{}).MarshalLogs(ld)
+	require.NoError(t, err)
+	return b
+}
+
+func tracesRequestBytes(t *testing.T, names ...string) []byte {
+	td := ptrace.NewTraces()
+	spans := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	for _, name := range names {
+		spans.AppendEmpty().SetName(name)
+	}
+	b, err := (&ptrace.ProtoMarshaler{}).MarshalTraces(td)
+	require.NoError(t, err)
+	return b
+}
+
+func TestParseQueueFileName(t *testing.T) {
+	tests := []struct {
+		fileName string
+		exporter string
+		signal   string
+		ok       bool
+	}{
+		{fileName: "exporter_otlp__logs", exporter: "otlp", signal: "logs", ok: true},
+		{fileName: "exporter_otlp_backend_traces", exporter: "otlp/backend", signal: "traces", ok: true},
+		{fileName: "exporter_otlphttp_a~002Fb_metrics", exporter: "otlphttp/a/b", signal: "metrics", ok: true},
+		{fileName: "exporter_otlp_backend_profiles"},
+		{fileName: "receiver_filelog__"},
+		{fileName: "exporter_otlp_a~00_logs"},
+		{fileName: "tempdb123"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fileName, func(t *testing.T) {
+			exporter, signal, ok := parseQueueFileName(tt.fileName)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.exporter, exporter)
+			assert.Equal(t, tt.signal, signal)
+		})
+	}
+}
+
+func TestReadQueue(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "exporter_otlp__logs")
+	// Indexes crossing a power of ten check that the items are in queue
+	// order, not in key order.
+	writeQueue(t, path, 8, logsRequestBytes(t, "a"), logsRequestBytes(t, "b"), logsRequestBytes(t, "c"))
+
+	items, err := readQueue(path)
+	require.NoError(t, err)
+	require.Len(t, items, 3)
+	for i, want := range []string{"a", "b", "c"} {
+		assert.Equal(t, uint64(8+i), items[i].index)
+		req, err := unmarshalRequest("logs", items[i].data)
+		require.NoError(t, err)
+		assert.Equal(t, want, req.(logsRequest).ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().Str())
+	}
+}
+
+func TestReadQueueLocked(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "exporter_otlp__logs")
+	writeQueue(t, path, 0, logsRequestBytes(t, "a"))
+
+	db, err := bbolt.Open(path, 0o600, nil)
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = readQueue(path)
+	assert.ErrorContains(t, err, "is a collector still running?")
+}
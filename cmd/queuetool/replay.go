@@ -0,0 +1,180 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/spf13/pflag"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	protocolGRPC = "grpc"
+	protocolHTTP = "http"
+)
+
+type replayConfig struct {
+	Endpoint string
+	Protocol string
+	Insecure bool
+	CAFile   string
+	Headers  map[string]string
+	Timeout  time.Duration
+}
+
+func (c *replayConfig) flags(fs *pflag.FlagSet) {
+	fs.StringVar(&c.Endpoint, "endpoint", "", "Host and port of the OTLP endpoint the requests are replayed to, e.g. otlp.example.com:4317")
+	fs.StringVar(&c.Protocol, "protocol", protocolGRPC, "OTLP protocol: grpc or http")
+	fs.BoolVar(&c.Insecure, "insecure", false, "Whether to connect to the endpoint without TLS")
+	fs.StringVar(&c.CAFile, "ca-file", "", "CA certificate verifying the endpoint (default the system certificates)")
+	fs.StringToStringVar(&c.Headers, "header", nil, "Headers sent with the requests, e.g. --header authorization=\"Bearer token\"")
+	fs.DurationVar(&c.Timeout, "timeout", 10*time.Second, "Timeout of each request")
+}
+
+func (c *replayConfig) tlsConfig() (*tls.Config, error) {
+	if c.CAFile == "" {
+		return &tls.Config{MinVersion: tls.VersionTLS12}, nil
+	}
+	pem, err := os.ReadFile(c.CAFile)
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificate found in %s", c.CAFile)
+	}
+	return &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}, nil
+}
+
+// exporter sends requests to an OTLP endpoint, and returns the number of
+// items rejected by the endpoint.
+type exporter func(ctx context.Context, req request) (int64, error)
+
+func newExporter(cfg *replayConfig) (exporter, func() error, error) {
+	if cfg.Endpoint == "" {
+		return nil, nil, errors.New("--endpoint is required")
+	}
+	tlsCfg, err := cfg.tlsConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+	switch cfg.Protocol {
+	case protocolGRPC:
+		creds := credentials.NewTLS(tlsCfg)
+		if cfg.Insecure {
+			creds = insecure.NewCredentials()
+		}
+		conn, err := grpc.NewClient(cfg.Endpoint, grpc.WithTransportCredentials(creds))
+		if err != nil {
+			return nil, nil, err
+		}
+		export := func(ctx context.Context, req request) (int64, error) {
+			for k, v := range cfg.Headers {
+				ctx = metadata.AppendToOutgoingContext(ctx, k, v)
+			}
+			return req.exportGRPC(ctx, conn)
+		}
+		return export, conn.Close, nil
+	case protocolHTTP:
+		scheme := "https"
+		if cfg.Insecure {
+			scheme = "http"
+		}
+		client := &http.Client{Transport: &http.Transport{TLSClientConfig: tlsCfg}}
+		export := func(ctx context.Context, req request) (int64, error) {
+			return exportHTTP(ctx, client, scheme+"://"+cfg.Endpoint+req.httpPath(), cfg.Headers, req)
+		}
+		return export, func() error { client.CloseIdleConnections(); return nil }, nil
+	}
+	return nil, nil, fmt.Errorf("unknown protocol %q, must be %q or %q", cfg.Protocol, protocolGRPC, protocolHTTP)
+}
+
+func exportHTTP(ctx context.Context, client *http.Client, url string, headers map[string]string, req request) (int64, error) {
+	body, err := req.marshalProto()
+	if err != nil {
+		return 0, err
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	httpReq.Header.Set("Content-Type", "application/x-protobuf")
+	for k, v := range headers {
+		httpReq.Header.Set(k, v)
+	}
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<10))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return 0, fmt.Errorf("%s: %s", resp.Status, respBody)
+	}
+	// The partial success of the response isn't decoded: the endpoint
+	// accepted the request.
+	return 0, nil
+}
+
+// runReplay replays the requests of the selected queues. A request that
+// fails is reported and skipped; runReplay then returns an error once all
+// the requests were replayed.
+func runReplay(ctx context.Context, sel *selection, cfg *replayConfig, stdout, stderr io.Writer) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	queues, err := sel.queues()
+	if err != nil {
+		return err
+	}
+	export, closeExporter, err := newExporter(cfg)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = closeExporter() }()
+
+	var failed int
+	for _, q := range queues {
+		requests, err := readRequests(q, stderr)
+		if err != nil {
+			return err
+		}
+		var sent, items, rejected int64
+		for i, req := range requests {
+			reqCtx, cancel := context.WithTimeout(ctx, cfg.Timeout)
+			n, err := export(reqCtx, req)
+			cancel()
+			if err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				failed++
+				fmt.Fprintf(stderr, "%s %s: failed to replay request %d of %d: %v\n", q.exporter, q.signal, i+1, len(requests), err)
+				continue
+			}
+			sent++
+			items += int64(req.itemCount())
+			rejected += n
+		}
+		fmt.Fprintf(stdout, "%s %s: replayed %d of %d requests, %d items, %d rejected by the endpoint\n",
+			q.exporter, q.signal, sent, len(requests), items, rejected)
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to replay %d requests", failed)
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+	"google.golang.org/grpc"
+)
+
+// request is a request of a persistent queue. The exporterhelper queues
+// store the requests as OTLP protobuf.
+type request interface {
+	// itemCount returns the number of log records, data points or spans.
+	itemCount() int
+	// marshalJSON marshals the request as an OTLP/JSON export request.
+	marshalJSON() ([]byte, error)
+	// marshalProto marshals the request as an OTLP/protobuf export request.
+	marshalProto() ([]byte, error)
+	// httpPath returns the path of the OTLP/HTTP endpoint receiving the request.
+	httpPath() string
+	// exportGRPC exports the request with OTLP/gRPC, and returns the
+	// number of items rejected by the server.
+	exportGRPC(ctx context.Context, conn *grpc.ClientConn) (int64, error)
+}
+
+func unmarshalRequest(signal string, data []byte) (request, error) {
+	switch signal {
+	case "logs":
+		ld, err := (&plog.ProtoUnmarshaler{}).UnmarshalLogs(data)
+		return logsRequest{ld}, err
+	case "metrics":
+		md, err := (&pmetric.ProtoUnmarshaler{}).UnmarshalMetrics(data)
+		return metricsRequest{md}, err
+	case "traces":
+		td, err := (&ptrace.ProtoUnmarshaler{}).UnmarshalTraces(data)
+		return tracesRequest{td}, err
+	}
+	return nil, fmt.Errorf("unknown signal %q", signal)
+}
+
+type logsRequest struct {
+	plog.Logs
+}
+
+func (r logsRequest) itemCount() int {
+	return r.LogRecordCount()
+}
+
+func (r logsRequest) marshalJSON() ([]byte, error) {
+	return plogotlp.NewExportRequestFromLogs(r.Logs).MarshalJSON()
+}
+
+func (r logsRequest) marshalProto() ([]byte, error) {
+	return plogotlp.NewExportRequestFromLogs(r.Logs).MarshalProto()
+}
+
+func (logsRequest) httpPath() string {
+	return "/v1/logs"
+}
+
+func (r logsRequest) exportGRPC(ctx context.Context, conn *grpc.ClientConn) (int64, error) {
+	resp, err := plogotlp.NewGRPCClient(conn).Export(ctx, plogotlp.NewExportRequestFromLogs(r.Logs))
+	if err != nil {
+		return 0, err
+	}
+	return resp.PartialSuccess().RejectedLogRecords(), nil
+}
+
+type metricsRequest struct {
+	pmetric.Metrics
+}
+
+func (r metricsRequest) itemCount() int {
+	return r.DataPointCount()
+}
+
+func (r metricsRequest) marshalJSON() ([]byte, error) {
+	return pmetricotlp.NewExportRequestFromMetrics(r.Metrics).MarshalJSON()
+}
+
+func (r metricsRequest) marshalProto() ([]byte, error) {
+	return pmetricotlp.NewExportRequestFromMetrics(r.Metrics).MarshalProto()
+}
+
+func (metricsRequest) httpPath() string {
+	return "/v1/metrics"
+}
+
+func (r metricsRequest) exportGRPC(ctx context.Context, conn *grpc.ClientConn) (int64, error) {
+	resp, err := pmetricotlp.NewGRPCClient(conn).Export(ctx, pmetricotlp.NewExportRequestFromMetrics(r.Metrics))
+	if err != nil {
+		return 0, err
+	}
+	return resp.PartialSuccess().RejectedDataPoints(), nil
+}
+
+type tracesRequest struct {
+	ptrace.Traces
+}
+
+func (r tracesRequest) itemCount() int {
+	return r.SpanCount()
+}
+
+func (r tracesRequest) marshalJSON() ([]byte, error) {
+	return ptraceotlp.NewExportRequestFromTraces(r.Traces).MarshalJSON()
+}
+
+func (r tracesRequest) marshalProto() ([]byte, error) {
+	return ptraceotlp.NewExportRequestFromTraces(r.Traces).MarshalProto()
+}
+
+func (tracesRequest) httpPath() string {
+	return "/v1/traces"
+}
+
+func (r tracesRequest) exportGRPC(ctx context.Context, conn *grpc.ClientConn) (int64, error) {
+	resp, err := ptraceotlp.NewGRPCClient(conn).Export(ctx, ptraceotlp.NewExportRequestFromTraces(r.Traces))
+	if err != nil {
+		return 0, err
+	}
+	return resp.PartialSuccess().RejectedSpans(), nil
+}
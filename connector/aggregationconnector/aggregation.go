@@ -0,0 +1,346 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package aggregationconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/aggregationconnector"
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/lightstep/go-expohisto/structure"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/expr"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoint"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanevent"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+)
+
+// histogramMaxSize is the maximum number of buckets of the histograms backing the
+// percentile aggregation and the exponential histogram output.
+const histogramMaxSize = 160
+
+// metricSpec describes how the values collected for a metric are turned into a data point.
+type metricSpec struct {
+	desc        string
+	unit        string
+	aggregation AggregationType
+	percentile  float64
+	output      OutputType
+	maxDistinct int
+}
+
+func newMetricSpec(info AggregationInfo) metricSpec {
+	output := info.Output
+	if output == "" {
+		output = OutputGauge
+	}
+	maxDistinct := info.MaxDistinctValues
+	if maxDistinct == 0 {
+		maxDistinct = defaultMaxDistinctValues
+	}
+	return metricSpec{
+		desc:        info.Description,
+		unit:        info.Unit,
+		aggregation: info.Aggregation,
+		percentile:  info.Percentile,
+		output:      output,
+		maxDistinct: maxDistinct,
+	}
+}
+
+// needsHistogram reports whether the values need to be recorded into an exponential histogram.
+func (s metricSpec) needsHistogram() bool {
+	return s.aggregation == AggregationPercentile || s.output == OutputExponentialHistogram
+}
+
+type aggregationDef[K any] struct {
+	name      string
+	spec      metricSpec
+	condition expr.BoolExpr[K]
+	value     *ottl.ValueExpression[K]
+	attrs     []AttributeConfig
+}
+
+func newValueExpression[K any](value string, parser ottl.Parser[K], err error) (*ottl.ValueExpression[K], error) {
+	if err != nil || value == "" {
+		return nil, err
+	}
+	return parser.ParseValueExpression(value)
+}
+
+func newSpanValueExpression(value string, set component.TelemetrySettings) (*ottl.ValueExpression[ottlspan.TransformContext], error) {
+	parser, err := ottlspan.NewParser(ottlfuncs.StandardConverters[ottlspan.TransformContext](), set)
+	return newValueExpression(value, parser, err)
+}
+
+func newSpanEventValueExpression(value string, set component.TelemetrySettings) (*ottl.ValueExpression[ottlspanevent.TransformContext], error) {
+	parser, err := ottlspanevent.NewParser(ottlfuncs.StandardConverters[ottlspanevent.TransformContext](), set)
+	return newValueExpression(value, parser, err)
+}
+
+func newDataPointValueExpression(value string, set component.TelemetrySettings) (*ottl.ValueExpression[ottldatapoint.TransformContext], error) {
+	parser, err := ottldatapoint.NewParser(ottlfuncs.StandardConverters[ottldatapoint.TransformContext](), set)
+	return newValueExpression(value, parser, err)
+}
+
+func newLogValueExpression(value string, set component.TelemetrySettings) (*ottl.ValueExpression[ottllog.TransformContext], error) {
+	parser, err := ottllog.NewParser(ottlfuncs.StandardConverters[ottllog.TransformContext](), set)
+	return newValueExpression(value, parser, err)
+}
+
+// update evaluates the definition against the telemetry item and records the resulting value into the bucket.
+func (d *aggregationDef[K]) update(ctx context.Context, b *bucket, resource resourceInfo, attrs pcommon.Map, tCtx K) error {
+	groupAttrs := pcommon.NewMap()
+	for _, attr := range d.attrs {
+		if attrVal, ok := attrs.Get(attr.Key); ok {
+			attrVal.CopyTo(groupAttrs.PutEmpty(attr.Key))
+		} else if attr.DefaultValue != nil {
+			switch v := attr.DefaultValue.(type) {
+			case string:
+				groupAttrs.PutStr(attr.Key, v)
+			case int:
+				groupAttrs.PutInt(attr.Key, int64(v))
+			case float64:
+				groupAttrs.PutDouble(attr.Key, v)
+			case bool:
+				groupAttrs.PutBool(attr.Key, v)
+			}
+		}
+	}
+
+	// Missing necessary attributes to be grouped by
+	if groupAttrs.Len() != len(d.attrs) {
+		return nil
+	}
+
+	if d.condition != nil {
+		match, err := d.condition.Eval(ctx, tCtx)
+		if err != nil || !match {
+			return err
+		}
+	}
+
+	var value any
+	if d.value != nil {
+		var err error
+		if value, err = d.value.Eval(ctx, tCtx); err != nil {
+			return fmt.Errorf("metric %q: failed to evaluate value: %w", d.name, err)
+		}
+		if value == nil {
+			// Items without a value are not part of the aggregation.
+			return nil
+		}
+	}
+
+	acc := b.accumulator(d.name, d.spec, resource, groupAttrs)
+	switch {
+	case d.spec.aggregation == AggregationCount:
+		acc.count++
+	case d.spec.aggregation == AggregationDistinctCount:
+		acc.observeDistinct(valueToString(value))
+	default:
+		v, ok := valueToFloat64(value)
+		if !ok {
+			return fmt.Errorf("metric %q: value %v cannot be converted to a number", d.name, value)
+		}
+		acc.observe(v)
+	}
+	return nil
+}
+
+func valueToFloat64(value any) (float64, bool) {
+	var v float64
+	switch val := value.(type) {
+	case int64:
+		v = float64(val)
+	case float64:
+		v = val
+	case string:
+		f, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return 0, false
+		}
+		v = f
+	case pcommon.Value:
+		switch val.Type() {
+		case pcommon.ValueTypeInt:
+			v = float64(val.Int())
+		case pcommon.ValueTypeDouble:
+			v = val.Double()
+		case pcommon.ValueTypeStr:
+			return valueToFloat64(val.Str())
+		default:
+			return 0, false
+		}
+	default:
+		return 0, false
+	}
+	// Non finite values cannot be meaningfully aggregated.
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0, false
+	}
+	return v, true
+}
+
+func valueToString(value any) string {
+	switch val := value.(type) {
+	case string:
+		return val
+	case pcommon.Value:
+		return val.AsString()
+	default:
+		return fmt.Sprint(val)
+	}
+}
+
+// accumulator holds the state of a single series within a window bucket.
+type accumulator struct {
+	count     uint64
+	sum       float64
+	min       float64
+	max       float64
+	histogram *structure.Histogram[float64]
+	distinct  map[string]struct{}
+	// maxDistinct caps the size of distinct.
+	maxDistinct int
+}
+
+func newAccumulator(spec metricSpec) *accumulator {
+	acc := &accumulator{}
+	if spec.needsHistogram() {
+		acc.histogram = new(structure.Histogram[float64])
+		acc.histogram.Init(structure.NewConfig(structure.WithMaxSize(histogramMaxSize)))
+	}
+	if spec.aggregation == AggregationDistinctCount {
+		acc.distinct = make(map[string]struct{})
+		acc.maxDistinct = spec.maxDistinct
+	}
+	return acc
+}
+
+func (a *accumulator) observe(v float64) {
+	if a.count == 0 || v < a.min {
+		a.min = v
+	}
+	if a.count == 0 || v > a.max {
+		a.max = v
+	}
+	a.count++
+	a.sum += v
+	if a.histogram != nil {
+		a.histogram.Update(v)
+	}
+}
+
+func (a *accumulator) observeDistinct(v string) {
+	a.count++
+	a.addDistinct(v)
+}
+
+// addDistinct records v unless the cap on distinct values is reached.
+func (a *accumulator) addDistinct(v string) {
+	if _, ok := a.distinct[v]; ok || len(a.distinct) >= a.maxDistinct {
+		return
+	}
+	a.distinct[v] = struct{}{}
+}
+
+// merge adds the state of o to a.
+func (a *accumulator) merge(o *accumulator) {
+	if o.count == 0 {
+		return
+	}
+	if a.count == 0 || o.min < a.min {
+		a.min = o.min
+	}
+	if a.count == 0 || o.max > a.max {
+		a.max = o.max
+	}
+	a.count += o.count
+	a.sum += o.sum
+	if a.histogram != nil && o.histogram != nil {
+		a.histogram.MergeFrom(o.histogram)
+	}
+	for v := range o.distinct {
+		a.addDistinct(v)
+	}
+}
+
+// value returns the result of the aggregation.
+func (a *accumulator) value(spec metricSpec) float64 {
+	switch spec.aggregation {
+	case AggregationCount:
+		return float64(a.count)
+	case AggregationSum:
+		return a.sum
+	case AggregationMin:
+		return a.min
+	case AggregationMax:
+		return a.max
+	case AggregationAvg:
+		if a.count == 0 {
+			return 0
+		}
+		return a.sum / float64(a.count)
+	case AggregationPercentile:
+		return a.percentile(spec.percentile)
+	case AggregationDistinctCount:
+		return float64(len(a.distinct))
+	}
+	return 0
+}
+
+// percentile estimates the p-th percentile from the histogram. The estimate is the midpoint of
+// the bucket holding the value of that rank, bounded by the observed minimum and maximum.
+func (a *accumulator) percentile(p float64) float64 {
+	total := a.histogram.Count()
+	if total == 0 {
+		return 0
+	}
+	rank := uint64(math.Ceil(p / 100 * float64(total)))
+	switch {
+	case rank <= 1:
+		return a.min
+	case rank >= total:
+		return a.max
+	}
+
+	scale := a.histogram.Scale()
+	var seen uint64
+	neg := a.histogram.Negative()
+	for i := int(neg.Len()) - 1; i >= 0; i-- {
+		if seen += neg.At(uint32(i)); seen >= rank {
+			return a.clamp(-bucketMidpoint(scale, neg.Offset()+int32(i)))
+		}
+	}
+	if seen += a.histogram.ZeroCount(); seen >= rank {
+		return a.clamp(0)
+	}
+	pos := a.histogram.Positive()
+	for i := uint32(0); i < pos.Len(); i++ {
+		if seen += pos.At(i); seen >= rank {
+			return a.clamp(bucketMidpoint(scale, pos.Offset()+int32(i)))
+		}
+	}
+	return a.max
+}
+
+func (a *accumulator) clamp(v float64) float64 {
+	return math.Max(a.min, math.Min(a.max, v))
+}
+
+// bucketMidpoint returns the midpoint of the exponential histogram bucket with the given
+// index, which covers the range (base^index, base^(index+1)] with base = 2^(2^-scale).
+func bucketMidpoint(scale, index int32) float64 {
+	exp := math.Exp2(-float64(scale))
+	lower := math.Exp2(float64(index) * exp)
+	upper := math.Exp2(float64(index+1) * exp)
+	return (lower + upper) / 2
+}
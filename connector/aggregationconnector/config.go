@@ -0,0 +1,254 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package aggregationconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/aggregationconnector"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+// WindowType defines how consecutive aggregation windows relate to each other.
+type WindowType string
+
+const (
+	// WindowTypeTumbling windows are fixed-size, non-overlapping and contiguous.
+	WindowTypeTumbling WindowType = "tumbling"
+	// WindowTypeSliding windows are fixed-size and advance by a slide smaller than their size,
+	// so that consecutive windows overlap.
+	WindowTypeSliding WindowType = "sliding"
+)
+
+// AggregationType defines the function applied to the values collected in a window.
+type AggregationType string
+
+const (
+	AggregationCount         AggregationType = "count"
+	AggregationSum           AggregationType = "sum"
+	AggregationMin           AggregationType = "min"
+	AggregationMax           AggregationType = "max"
+	AggregationAvg           AggregationType = "avg"
+	AggregationPercentile    AggregationType = "percentile"
+	AggregationDistinctCount AggregationType = "distinct_count"
+)
+
+// OutputType defines the type of the metric emitted when a window closes.
+type OutputType string
+
+const (
+	OutputGauge                OutputType = "gauge"
+	OutputSum                  OutputType = "sum"
+	OutputExponentialHistogram OutputType = "exponential_histogram"
+)
+
+const (
+	defaultWindowSize        = 60 * time.Second
+	defaultMaxDistinctValues = 10000
+)
+
+// Config for the connector
+type Config struct {
+	Window WindowConfig `mapstructure:"window"`
+	// StorageID is the optional storage extension used to persist open windows across restarts.
+	StorageID *component.ID `mapstructure:"storage"`
+
+	Spans      map[string]AggregationInfo `mapstructure:"spans"`
+	SpanEvents map[string]AggregationInfo `mapstructure:"spanevents"`
+	DataPoints map[string]AggregationInfo `mapstructure:"datapoints"`
+	Logs       map[string]AggregationInfo `mapstructure:"logs"`
+}
+
+// WindowConfig defines the windows over which values are aggregated.
+type WindowConfig struct {
+	Type WindowType `mapstructure:"type"`
+	// Size is the duration covered by a window.
+	Size time.Duration `mapstructure:"size"`
+	// Slide is the interval at which sliding windows advance. It must evenly divide Size.
+	Slide time.Duration `mapstructure:"slide"`
+	// AllowedLateness is how long a window is kept open after its end for telemetry whose timestamp falls
+	// within it. Telemetry arriving after its windows closed is dropped.
+	AllowedLateness time.Duration `mapstructure:"allowed_lateness"`
+}
+
+// AggregationInfo defines a metric computed by aggregating telemetry.
+type AggregationInfo struct {
+	Description string   `mapstructure:"description"`
+	Unit        string   `mapstructure:"unit"`
+	Conditions  []string `mapstructure:"conditions"`
+	// Value is an OTTL value expression providing the value to aggregate, e.g. attributes["http.response.body.size"].
+	Value       string          `mapstructure:"value"`
+	Aggregation AggregationType `mapstructure:"aggregation"`
+	// Percentile is the percentile, in the range (0, 100], computed by the percentile aggregation.
+	Percentile float64    `mapstructure:"percentile"`
+	Output     OutputType `mapstructure:"output"`
+	// MaxDistinctValues caps the number of distinct values tracked per series by the distinct_count
+	// aggregation. Values beyond the cap are not counted. Defaults to 10000.
+	MaxDistinctValues int `mapstructure:"max_distinct_values"`
+	// Attributes are the attributes the values are grouped by.
+	Attributes []AttributeConfig `mapstructure:"attributes"`
+}
+
+type AttributeConfig struct {
+	Key          string `mapstructure:"key"`
+	DefaultValue any    `mapstructure:"default_value"`
+}
+
+// slide returns the interval at which windows close.
+func (w WindowConfig) slide() time.Duration {
+	if w.Type == WindowTypeSliding {
+		return w.Slide
+	}
+	return w.Size
+}
+
+func (c *Config) Validate() (combinedErrors error) {
+	if err := c.Window.validate(); err != nil {
+		combinedErrors = errors.Join(combinedErrors, err)
+	}
+
+	set := component.TelemetrySettings{Logger: zap.NewNop()}
+	names := make(map[string]struct{})
+	validateNames := func(signal string, infos map[string]AggregationInfo) {
+		for name, info := range infos {
+			if name == "" {
+				combinedErrors = errors.Join(combinedErrors, fmt.Errorf("%s: metric name missing", signal))
+			}
+			if _, ok := names[name]; ok {
+				combinedErrors = errors.Join(combinedErrors, fmt.Errorf("%s: duplicate metric name %q", signal, name))
+			}
+			names[name] = struct{}{}
+			if err := info.validate(c.Window.Type); err != nil {
+				combinedErrors = errors.Join(combinedErrors, fmt.Errorf("%s: metric %q: %w", signal, name, err))
+			}
+		}
+	}
+
+	validateNames("spans", c.Spans)
+	for name, info := range c.Spans {
+		if _, err := filterottl.NewBoolExprForSpan(info.Conditions, filterottl.StandardSpanFuncs(), ottl.PropagateError, set); err != nil {
+			combinedErrors = errors.Join(combinedErrors, fmt.Errorf("spans condition: metric %q: %w", name, err))
+		}
+		if _, err := newSpanValueExpression(info.Value, set); err != nil {
+			combinedErrors = errors.Join(combinedErrors, fmt.Errorf("spans value: metric %q: %w", name, err))
+		}
+	}
+
+	validateNames("spanevents", c.SpanEvents)
+	for name, info := range c.SpanEvents {
+		if _, err := filterottl.NewBoolExprForSpanEvent(info.Conditions, filterottl.StandardSpanEventFuncs(), ottl.PropagateError, set); err != nil {
+			combinedErrors = errors.Join(combinedErrors, fmt.Errorf("spanevents condition: metric %q: %w", name, err))
+		}
+		if _, err := newSpanEventValueExpression(info.Value, set); err != nil {
+			combinedErrors = errors.Join(combinedErrors, fmt.Errorf("spanevents value: metric %q: %w", name, err))
+		}
+	}
+
+	validateNames("datapoints", c.DataPoints)
+	for name, info := range c.DataPoints {
+		if _, err := filterottl.NewBoolExprForDataPoint(info.Conditions, filterottl.StandardDataPointFuncs(), ottl.PropagateError, set); err != nil {
+			combinedErrors = errors.Join(combinedErrors, fmt.Errorf("datapoints condition: metric %q: %w", name, err))
+		}
+		if _, err := newDataPointValueExpression(info.Value, set); err != nil {
+			combinedErrors = errors.Join(combinedErrors, fmt.Errorf("datapoints value: metric %q: %w", name, err))
+		}
+	}
+
+	validateNames("logs", c.Logs)
+	for name, info := range c.Logs {
+		if _, err := filterottl.NewBoolExprForLog(info.Conditions, filterottl.StandardLogFuncs(), ottl.PropagateError, set); err != nil {
+			combinedErrors = errors.Join(combinedErrors, fmt.Errorf("logs condition: metric %q: %w", name, err))
+		}
+		if _, err := newLogValueExpression(info.Value, set); err != nil {
+			combinedErrors = errors.Join(combinedErrors, fmt.Errorf("logs value: metric %q: %w", name, err))
+		}
+	}
+	return combinedErrors
+}
+
+func (w WindowConfig) validate() error {
+	if w.Size <= 0 {
+		return fmt.Errorf("window size must be positive, got %v", w.Size)
+	}
+	if w.AllowedLateness < 0 {
+		return fmt.Errorf("allowed lateness must not be negative, got %v", w.AllowedLateness)
+	}
+	switch w.Type {
+	case WindowTypeTumbling:
+		if w.Slide != 0 {
+			return errors.New("window slide is only supported for sliding windows")
+		}
+	case WindowTypeSliding:
+		if w.Slide <= 0 || w.Slide >= w.Size {
+			return fmt.Errorf("window slide must be positive and smaller than the window size, got %v", w.Slide)
+		}
+		if w.Size%w.Slide != 0 {
+			return fmt.Errorf("window size %v must be a multiple of the window slide %v", w.Size, w.Slide)
+		}
+	default:
+		return fmt.Errorf("unsupported window type %q", w.Type)
+	}
+	return nil
+}
+
+func (i *AggregationInfo) validate(windowType WindowType) error {
+	for _, attr := range i.Attributes {
+		if attr.Key == "" {
+			return errors.New("attribute key missing")
+		}
+	}
+	if i.MaxDistinctValues < 0 {
+		return fmt.Errorf("max distinct values must not be negative, got %d", i.MaxDistinctValues)
+	}
+
+	switch i.Output {
+	case "", OutputGauge:
+	case OutputSum:
+		if i.Aggregation != AggregationCount && i.Aggregation != AggregationSum {
+			return fmt.Errorf("output %q requires the %q or %q aggregation", i.Output, AggregationCount, AggregationSum)
+		}
+	case OutputExponentialHistogram:
+		if i.Aggregation != "" {
+			return fmt.Errorf("output %q does not support an aggregation, the distribution of values is emitted", i.Output)
+		}
+		if i.Value == "" {
+			return fmt.Errorf("output %q requires a value", i.Output)
+		}
+	default:
+		return fmt.Errorf("unsupported output %q", i.Output)
+	}
+	if windowType == WindowTypeSliding && (i.Output == OutputSum || i.Output == OutputExponentialHistogram) {
+		// Overlapping windows would report the same values more than once as delta points.
+		return fmt.Errorf("output %q is not supported with sliding windows", i.Output)
+	}
+
+	switch i.Aggregation {
+	case AggregationCount:
+	case AggregationSum, AggregationMin, AggregationMax, AggregationAvg, AggregationDistinctCount:
+		if i.Value == "" {
+			return fmt.Errorf("aggregation %q requires a value", i.Aggregation)
+		}
+	case AggregationPercentile:
+		if i.Value == "" {
+			return fmt.Errorf("aggregation %q requires a value", i.Aggregation)
+		}
+		if i.Percentile <= 0 || i.Percentile > 100 {
+			return fmt.Errorf("percentile must be in the range (0, 100], got %v", i.Percentile)
+		}
+	case "":
+		if i.Output != OutputExponentialHistogram {
+			return errors.New("aggregation missing")
+		}
+	default:
+		return fmt.Errorf("unsupported aggregation %q", i.Aggregation)
+	}
+	return nil
+}
+
+var _ component.ConfigValidator = (*Config)(nil)
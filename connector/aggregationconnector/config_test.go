@@ -0,0 +1,154 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package aggregationconnector
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/aggregationconnector/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	storageID := component.MustNewID("file_storage")
+	tests := []struct {
+		id           component.ID
+		expected     component.Config
+		errorMessage string
+	}{
+		{
+			id:       component.NewID(metadata.Type),
+			expected: createDefaultConfig(),
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "full"),
+			expected: &Config{
+				Window:    WindowConfig{Type: WindowTypeSliding, Size: 60 * time.Second, Slide: 10 * time.Second, AllowedLateness: 30 * time.Second},
+				StorageID: &storageID,
+				Spans: map[string]AggregationInfo{
+					"span.duration.max": {
+						Description: "Maximum span duration.",
+						Unit:        "ns",
+						Value:       "end_time_unix_nano - start_time_unix_nano",
+						Aggregation: AggregationMax,
+						Attributes:  []AttributeConfig{{Key: "http.route", DefaultValue: "unknown"}},
+					},
+				},
+				SpanEvents: map[string]AggregationInfo{
+					"exception.count": {
+						Conditions:  []string{`name == "exception"`},
+						Aggregation: AggregationCount,
+					},
+				},
+				DataPoints: map[string]AggregationInfo{
+					"cpu.utilization.avg": {
+						Conditions:  []string{`metric.name == "system.cpu.utilization"`},
+						Value:       "value_double",
+						Aggregation: AggregationAvg,
+					},
+				},
+				Logs: map[string]AggregationInfo{
+					"http.response.body.size.p99": {
+						Description: "99th percentile of the response body size.",
+						Unit:        "By",
+						Value:       `attributes["http.response.body.size"]`,
+						Aggregation: AggregationPercentile,
+						Percentile:  99,
+						Attributes:  []AttributeConfig{{Key: "http.route"}},
+					},
+				},
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "tumbling_outputs"),
+			expected: &Config{
+				Window: WindowConfig{Type: WindowTypeTumbling, Size: 30 * time.Second},
+				Logs: map[string]AggregationInfo{
+					"log.count": {
+						Aggregation: AggregationCount,
+						Output:      OutputSum,
+					},
+					"user.distinct": {
+						Value:             `attributes["user.id"]`,
+						Aggregation:       AggregationDistinctCount,
+						MaxDistinctValues: 500,
+					},
+					"http.response.body.size": {
+						Value:  `attributes["http.response.body.size"]`,
+						Output: OutputExponentialHistogram,
+					},
+				},
+			},
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_window_type"),
+			errorMessage: `unsupported window type "hopping"`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_slide"),
+			errorMessage: "window size 1m0s must be a multiple of the window slide 25s",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "negative_lateness"),
+			errorMessage: "allowed lateness must not be negative, got -1s",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "missing_value"),
+			errorMessage: `logs: metric "body.size.max": aggregation "max" requires a value`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_percentile"),
+			errorMessage: `logs: metric "body.size.p0": percentile must be in the range (0, 100], got 0`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_output"),
+			errorMessage: `logs: metric "body.size.max": output "sum" requires the "count" or "sum" aggregation`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "sliding_sum"),
+			errorMessage: `logs: metric "log.count": output "sum" is not supported with sliding windows`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_value"),
+			errorMessage: `logs value: metric "body.size.max": value expression has invalid syntax`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_condition"),
+			errorMessage: `spans condition: metric "span.count": unable to parse OTTL condition "invalid condition"`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "duplicate_name"),
+			errorMessage: `spanevents: duplicate metric name "event.count"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			err = sub.Unmarshal(cfg)
+
+			if tt.expected == nil {
+				err = errors.Join(err, component.ValidateConfig(cfg))
+				assert.ErrorContains(t, err, tt.errorMessage)
+				return
+			}
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
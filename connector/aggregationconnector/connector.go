@@ -0,0 +1,283 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package aggregationconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/aggregationconnector"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoint"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanevent"
+)
+
+// aggregator aggregates values from spans, span events, data points, or log records over
+// time windows and emits the results onto a metrics pipeline when the windows close.
+type aggregator struct {
+	logger          *zap.Logger
+	metricsConsumer consumer.Metrics
+
+	id        component.ID
+	signal    string
+	storageID *component.ID
+	client    storage.Client
+
+	spansDefs      []aggregationDef[ottlspan.TransformContext]
+	spanEventsDefs []aggregationDef[ottlspanevent.TransformContext]
+	dataPointsDefs []aggregationDef[ottldatapoint.TransformContext]
+	logsDefs       []aggregationDef[ottllog.TransformContext]
+
+	lock    sync.Mutex
+	windows *windows
+	// lateness is how long windows are kept open after their end.
+	lateness time.Duration
+	now      func() time.Time
+
+	shutdownCh chan struct{}
+	done       chan struct{}
+}
+
+func (a *aggregator) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (a *aggregator) Start(ctx context.Context, host component.Host) error {
+	client, err := getStorageClient(ctx, host, a.storageID, a.id, a.signal)
+	if err != nil {
+		return err
+	}
+	a.client = client
+
+	data, err := a.client.Get(ctx, stateKey)
+	if err != nil {
+		return fmt.Errorf("failed to load window state: %w", err)
+	}
+	if data != nil {
+		a.lock.Lock()
+		err = a.windows.unmarshal(data)
+		a.lock.Unlock()
+		if err != nil {
+			a.logger.Warn("Discarding invalid window state", zap.Error(err))
+		}
+	}
+
+	// Emit the windows that closed while the collector was not running.
+	a.catchUp(ctx, a.now())
+
+	a.shutdownCh = make(chan struct{})
+	a.done = make(chan struct{})
+	go a.run()
+	return nil
+}
+
+func (a *aggregator) Shutdown(ctx context.Context) error {
+	if a.shutdownCh != nil {
+		close(a.shutdownCh)
+		<-a.done
+	}
+	if a.client == nil {
+		return nil
+	}
+	return errors.Join(a.persist(ctx), a.client.Close(ctx))
+}
+
+// run closes windows at every slide boundary until shutdown.
+func (a *aggregator) run() {
+	defer close(a.done)
+	timer := time.NewTimer(a.untilNextBoundary())
+	defer timer.Stop()
+	for {
+		select {
+		case <-a.shutdownCh:
+			return
+		case <-timer.C:
+			ctx := context.Background()
+			a.catchUp(ctx, a.now())
+			if err := a.persist(ctx); err != nil {
+				a.logger.Warn("Failed to persist window state", zap.Error(err))
+			}
+			timer.Reset(a.untilNextBoundary())
+		}
+	}
+}
+
+func (a *aggregator) untilNextBoundary() time.Duration {
+	now := a.now().Add(-a.lateness)
+	return now.Truncate(a.windows.slide).Add(a.windows.slide).Sub(now)
+}
+
+// catchUp closes, in order, all windows that ended more than the allowed lateness before now and
+// were not closed yet.
+func (a *aggregator) catchUp(ctx context.Context, now time.Time) {
+	last := now.Add(-a.lateness).Truncate(a.windows.slide)
+	md := pmetric.NewMetrics()
+	a.lock.Lock()
+	for {
+		end, ok := a.windows.nextEnd()
+		if !ok || end.After(last) {
+			break
+		}
+		a.windows.close(end, md)
+	}
+	a.lock.Unlock()
+
+	if md.ResourceMetrics().Len() == 0 {
+		return
+	}
+	if err := a.metricsConsumer.ConsumeMetrics(ctx, md); err != nil {
+		a.logger.Error("Failed to emit aggregated metrics", zap.Error(err))
+	}
+}
+
+func (a *aggregator) persist(ctx context.Context) error {
+	a.lock.Lock()
+	data, err := a.windows.marshal()
+	a.lock.Unlock()
+	if err != nil {
+		return err
+	}
+	return a.client.Set(ctx, stateKey, data)
+}
+
+// aggregate records a telemetry item into the bucket matching its timestamp for each definition. Items
+// outside of the open windows, and items a definition fails to aggregate, are logged and skipped.
+func aggregate[K any](ctx context.Context, a *aggregator, defs []aggregationDef[K], ts pcommon.Timestamp, now time.Time, resource resourceInfo, attrs pcommon.Map, tCtx K) {
+	if len(defs) == 0 {
+		return
+	}
+	b, ok := a.windows.bucketForRecord(ts, now)
+	if !ok {
+		a.logger.Debug("Dropping telemetry outside of the open windows", zap.Time("timestamp", ts.AsTime()))
+		return
+	}
+	for _, def := range defs {
+		if err := def.update(ctx, b, resource, attrs, tCtx); err != nil {
+			a.logger.Debug("Failed to aggregate telemetry", zap.Error(err))
+		}
+	}
+}
+
+func (a *aggregator) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	now := a.now()
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		resourceSpan := td.ResourceSpans().At(i)
+		resource := newResourceInfo(resourceSpan.Resource())
+
+		for j := 0; j < resourceSpan.ScopeSpans().Len(); j++ {
+			scopeSpan := resourceSpan.ScopeSpans().At(j)
+
+			for k := 0; k < scopeSpan.Spans().Len(); k++ {
+				span := scopeSpan.Spans().At(k)
+				sCtx := ottlspan.NewTransformContext(span, scopeSpan.Scope(), resourceSpan.Resource(), scopeSpan, resourceSpan)
+				aggregate(ctx, a, a.spansDefs, span.EndTimestamp(), now, resource, span.Attributes(), sCtx)
+
+				if len(a.spanEventsDefs) == 0 {
+					continue
+				}
+				for l := 0; l < span.Events().Len(); l++ {
+					event := span.Events().At(l)
+					eCtx := ottlspanevent.NewTransformContext(event, span, scopeSpan.Scope(), resourceSpan.Resource(), scopeSpan, resourceSpan)
+					aggregate(ctx, a, a.spanEventsDefs, event.Timestamp(), now, resource, event.Attributes(), eCtx)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (a *aggregator) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	now := a.now()
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		resourceMetric := md.ResourceMetrics().At(i)
+		resource := newResourceInfo(resourceMetric.Resource())
+
+		for j := 0; j < resourceMetric.ScopeMetrics().Len(); j++ {
+			scopeMetrics := resourceMetric.ScopeMetrics().At(j)
+
+			for k := 0; k < scopeMetrics.Metrics().Len(); k++ {
+				metric := scopeMetrics.Metrics().At(k)
+				//exhaustive:enforce
+				//  For metric types each must be handled in exactly the same way
+				//  Switch case required because each type calls DataPoints() differently
+				switch metric.Type() {
+				case pmetric.MetricTypeGauge:
+					dps := metric.Gauge().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						dCtx := ottldatapoint.NewTransformContext(dps.At(l), metric, scopeMetrics.Metrics(), scopeMetrics.Scope(), resourceMetric.Resource(), scopeMetrics, resourceMetric)
+						aggregate(ctx, a, a.dataPointsDefs, dps.At(l).Timestamp(), now, resource, dps.At(l).Attributes(), dCtx)
+					}
+				case pmetric.MetricTypeSum:
+					dps := metric.Sum().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						dCtx := ottldatapoint.NewTransformContext(dps.At(l), metric, scopeMetrics.Metrics(), scopeMetrics.Scope(), resourceMetric.Resource(), scopeMetrics, resourceMetric)
+						aggregate(ctx, a, a.dataPointsDefs, dps.At(l).Timestamp(), now, resource, dps.At(l).Attributes(), dCtx)
+					}
+				case pmetric.MetricTypeSummary:
+					dps := metric.Summary().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						dCtx := ottldatapoint.NewTransformContext(dps.At(l), metric, scopeMetrics.Metrics(), scopeMetrics.Scope(), resourceMetric.Resource(), scopeMetrics, resourceMetric)
+						aggregate(ctx, a, a.dataPointsDefs, dps.At(l).Timestamp(), now, resource, dps.At(l).Attributes(), dCtx)
+					}
+				case pmetric.MetricTypeHistogram:
+					dps := metric.Histogram().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						dCtx := ottldatapoint.NewTransformContext(dps.At(l), metric, scopeMetrics.Metrics(), scopeMetrics.Scope(), resourceMetric.Resource(), scopeMetrics, resourceMetric)
+						aggregate(ctx, a, a.dataPointsDefs, dps.At(l).Timestamp(), now, resource, dps.At(l).Attributes(), dCtx)
+					}
+				case pmetric.MetricTypeExponentialHistogram:
+					dps := metric.ExponentialHistogram().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						dCtx := ottldatapoint.NewTransformContext(dps.At(l), metric, scopeMetrics.Metrics(), scopeMetrics.Scope(), resourceMetric.Resource(), scopeMetrics, resourceMetric)
+						aggregate(ctx, a, a.dataPointsDefs, dps.At(l).Timestamp(), now, resource, dps.At(l).Attributes(), dCtx)
+					}
+				case pmetric.MetricTypeEmpty:
+					a.logger.Debug("Skipping metric with an invalid type", zap.String("metric", metric.Name()))
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (a *aggregator) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	now := a.now()
+	for i := 0; i < ld.ResourceLogs().Len(); i++ {
+		resourceLog := ld.ResourceLogs().At(i)
+		resource := newResourceInfo(resourceLog.Resource())
+
+		for j := 0; j < resourceLog.ScopeLogs().Len(); j++ {
+			scopeLogs := resourceLog.ScopeLogs().At(j)
+
+			for k := 0; k < scopeLogs.LogRecords().Len(); k++ {
+				logRecord := scopeLogs.LogRecords().At(k)
+				lCtx := ottllog.NewTransformContext(logRecord, scopeLogs.Scope(), resourceLog.Resource(), scopeLogs, resourceLog)
+				ts := logRecord.Timestamp()
+				if ts == 0 {
+					ts = logRecord.ObservedTimestamp()
+				}
+				aggregate(ctx, a, a.logsDefs, ts, now, resource, logRecord.Attributes(), lCtx)
+			}
+		}
+	}
+	return nil
+}
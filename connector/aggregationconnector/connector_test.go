@@ -0,0 +1,425 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package aggregationconnector
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/connector/connectortest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/aggregationconnector/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+)
+
+var testStart = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func newLogs(service string, records ...map[string]any) plog.Logs {
+	logs := plog.NewLogs()
+	rl := logs.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("service.name", service)
+	sl := rl.ScopeLogs().AppendEmpty()
+	for _, attrs := range records {
+		lr := sl.LogRecords().AppendEmpty()
+		_ = lr.Attributes().FromRaw(attrs)
+	}
+	return logs
+}
+
+func createLogsAggregator(t *testing.T, cfg *Config, sink *consumertest.MetricsSink, clock *fakeClock) *aggregator {
+	require.NoError(t, cfg.Validate())
+	set := connectortest.NewNopSettings()
+	// The storage client is keyed by the component ID, keep it stable across restarts
+	set.ID = component.NewID(metadata.Type)
+	conn, err := NewFactory().CreateLogsToMetrics(context.Background(), set, cfg, sink)
+	require.NoError(t, err)
+	a := conn.(*aggregator)
+	a.now = clock.Now
+	return a
+}
+
+// findDataPoint returns the gauge or sum data point of the named metric with the given route attribute.
+func findDataPoint(t *testing.T, md pmetric.Metrics, name, route string) pmetric.NumberDataPoint {
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		sms := md.ResourceMetrics().At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			ms := sms.At(j).Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				m := ms.At(k)
+				if m.Name() != name {
+					continue
+				}
+				var dps pmetric.NumberDataPointSlice
+				if m.Type() == pmetric.MetricTypeSum {
+					dps = m.Sum().DataPoints()
+				} else {
+					dps = m.Gauge().DataPoints()
+				}
+				for l := 0; l < dps.Len(); l++ {
+					if v, ok := dps.At(l).Attributes().Get("http.route"); route == "" || ok && v.Str() == route {
+						return dps.At(l)
+					}
+				}
+			}
+		}
+	}
+	require.Failf(t, "data point not found", "metric %q, route %q", name, route)
+	return pmetric.NewNumberDataPoint()
+}
+
+func TestLogsToMetricsTumblingWindow(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	routeAttr := []AttributeConfig{{Key: "http.route"}}
+	bodySize := `attributes["http.response.body.size"]`
+	cfg.Logs = map[string]AggregationInfo{
+		"body.size.count": {Aggregation: AggregationCount, Output: OutputSum, Attributes: routeAttr},
+		"body.size.sum":   {Value: bodySize, Aggregation: AggregationSum, Attributes: routeAttr},
+		"body.size.min":   {Value: bodySize, Aggregation: AggregationMin, Attributes: routeAttr},
+		"body.size.max":   {Value: bodySize, Aggregation: AggregationMax, Attributes: routeAttr},
+		"body.size.avg":   {Value: bodySize, Aggregation: AggregationAvg, Attributes: routeAttr},
+		"body.size.p99":   {Value: bodySize, Aggregation: AggregationPercentile, Percentile: 99, Attributes: routeAttr},
+		"users":           {Value: `attributes["user.id"]`, Aggregation: AggregationDistinctCount},
+	}
+
+	sink := &consumertest.MetricsSink{}
+	clock := &fakeClock{now: testStart.Add(5 * time.Second)}
+	a := createLogsAggregator(t, cfg, sink, clock)
+
+	var records []map[string]any
+	for i := 1; i <= 100; i++ {
+		records = append(records, map[string]any{"http.route": "/api", "http.response.body.size": int64(i), "user.id": i % 3})
+	}
+	records = append(records,
+		map[string]any{"http.route": "/health", "http.response.body.size": "2.5", "user.id": 1},
+		// Not grouped, the route attribute is missing
+		map[string]any{"http.response.body.size": int64(1000)},
+	)
+	require.NoError(t, a.ConsumeLogs(context.Background(), newLogs("frontend", records...)))
+
+	// The window is still open
+	a.catchUp(context.Background(), testStart.Add(59*time.Second))
+	assert.Empty(t, sink.AllMetrics())
+
+	a.catchUp(context.Background(), testStart.Add(61*time.Second))
+	require.Len(t, sink.AllMetrics(), 1)
+	md := sink.AllMetrics()[0]
+	require.Equal(t, 1, md.ResourceMetrics().Len())
+	serviceName, _ := md.ResourceMetrics().At(0).Resource().Attributes().Get("service.name")
+	assert.Equal(t, "frontend", serviceName.Str())
+
+	count := findDataPoint(t, md, "body.size.count", "/api")
+	assert.Equal(t, int64(100), count.IntValue())
+	assert.Equal(t, pcommon.NewTimestampFromTime(testStart), count.StartTimestamp())
+	assert.Equal(t, pcommon.NewTimestampFromTime(testStart.Add(time.Minute)), count.Timestamp())
+	assert.Equal(t, 5050.0, findDataPoint(t, md, "body.size.sum", "/api").DoubleValue())
+	assert.Equal(t, 1.0, findDataPoint(t, md, "body.size.min", "/api").DoubleValue())
+	assert.Equal(t, 100.0, findDataPoint(t, md, "body.size.max", "/api").DoubleValue())
+	assert.Equal(t, 50.5, findDataPoint(t, md, "body.size.avg", "/api").DoubleValue())
+	assert.InEpsilon(t, 99.0, findDataPoint(t, md, "body.size.p99", "/api").DoubleValue(), 0.02)
+	assert.Equal(t, 2.5, findDataPoint(t, md, "body.size.max", "/health").DoubleValue())
+	assert.Equal(t, int64(3), findDataPoint(t, md, "users", "").IntValue())
+
+	// The closed window is not emitted again and its buckets are released
+	a.catchUp(context.Background(), testStart.Add(2*time.Minute))
+	assert.Len(t, sink.AllMetrics(), 1)
+	assert.Empty(t, a.windows.buckets)
+}
+
+func TestLogsToMetricsSlidingWindow(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Window = WindowConfig{Type: WindowTypeSliding, Size: 30 * time.Second, Slide: 10 * time.Second}
+	cfg.Logs = map[string]AggregationInfo{
+		"body.size.max": {Value: `attributes["http.response.body.size"]`, Aggregation: AggregationMax},
+	}
+
+	sink := &consumertest.MetricsSink{}
+	clock := &fakeClock{}
+	a := createLogsAggregator(t, cfg, sink, clock)
+
+	for i, size := range []int64{30, 10, 20} {
+		clock.now = testStart.Add(time.Duration(i)*10*time.Second + time.Second)
+		require.NoError(t, a.ConsumeLogs(context.Background(), newLogs("frontend", map[string]any{"http.response.body.size": size})))
+	}
+	a.catchUp(context.Background(), testStart.Add(time.Minute))
+
+	// Each window ending at a slide boundary covers the last three buckets.
+	var got []float64
+	var ends []time.Time
+	require.Len(t, sink.AllMetrics(), 1)
+	rms := sink.AllMetrics()[0].ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		dp := rms.At(i).ScopeMetrics().At(0).Metrics().At(0).Gauge().DataPoints().At(0)
+		got = append(got, dp.DoubleValue())
+		ends = append(ends, dp.Timestamp().AsTime())
+		assert.Equal(t, 30*time.Second, dp.Timestamp().AsTime().Sub(dp.StartTimestamp().AsTime()))
+	}
+	assert.Equal(t, []float64{30, 30, 30, 20, 20}, got)
+	assert.Equal(t, testStart.Add(10*time.Second), ends[0].UTC())
+	assert.Equal(t, testStart.Add(50*time.Second), ends[len(ends)-1].UTC())
+	assert.Empty(t, a.windows.buckets)
+}
+
+func TestLogsToMetricsExponentialHistogram(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Logs = map[string]AggregationInfo{
+		"body.size": {Value: `attributes["http.response.body.size"]`, Output: OutputExponentialHistogram, Unit: "By"},
+	}
+
+	sink := &consumertest.MetricsSink{}
+	clock := &fakeClock{now: testStart}
+	a := createLogsAggregator(t, cfg, sink, clock)
+
+	require.NoError(t, a.ConsumeLogs(context.Background(), newLogs("frontend",
+		map[string]any{"http.response.body.size": 0.0},
+		map[string]any{"http.response.body.size": 4.0},
+		map[string]any{"http.response.body.size": 1024.0},
+	)))
+	a.catchUp(context.Background(), testStart.Add(time.Minute))
+
+	require.Len(t, sink.AllMetrics(), 1)
+	m := sink.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, "By", m.Unit())
+	require.Equal(t, pmetric.MetricTypeExponentialHistogram, m.Type())
+	assert.Equal(t, pmetric.AggregationTemporalityDelta, m.ExponentialHistogram().AggregationTemporality())
+	dp := m.ExponentialHistogram().DataPoints().At(0)
+	assert.Equal(t, uint64(3), dp.Count())
+	assert.Equal(t, uint64(1), dp.ZeroCount())
+	assert.Equal(t, 1028.0, dp.Sum())
+	assert.Equal(t, 0.0, dp.Min())
+	assert.Equal(t, 1024.0, dp.Max())
+}
+
+func TestLogsToMetricsInvalidValue(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Logs = map[string]AggregationInfo{
+		"body.size.max": {Value: `attributes["http.response.body.size"]`, Aggregation: AggregationMax},
+	}
+
+	sink := &consumertest.MetricsSink{}
+	a := createLogsAggregator(t, cfg, sink, &fakeClock{now: testStart})
+
+	// Records that cannot be aggregated are skipped without failing the others
+	require.NoError(t, a.ConsumeLogs(context.Background(), newLogs("frontend",
+		map[string]any{"http.response.body.size": "large"},
+		// Records without a value are skipped
+		map[string]any{},
+		map[string]any{"http.response.body.size": int64(7)},
+	)))
+
+	a.catchUp(context.Background(), testStart.Add(time.Minute))
+	require.Len(t, sink.AllMetrics(), 1)
+	assert.Equal(t, 7.0, findDataPoint(t, sink.AllMetrics()[0], "body.size.max", "").DoubleValue())
+}
+
+func TestLogsToMetricsRecordTimestamps(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Window.AllowedLateness = 30 * time.Second
+	cfg.Logs = map[string]AggregationInfo{
+		"body.size.max": {Value: `attributes["http.response.body.size"]`, Aggregation: AggregationMax},
+	}
+
+	sink := &consumertest.MetricsSink{}
+	clock := &fakeClock{now: testStart.Add(70 * time.Second)}
+	a := createLogsAggregator(t, cfg, sink, clock)
+
+	logs := newLogs("frontend",
+		map[string]any{"http.response.body.size": int64(10)},
+		map[string]any{"http.response.body.size": int64(20)},
+		map[string]any{"http.response.body.size": int64(30)},
+		// Too far ahead of the clock
+		map[string]any{"http.response.body.size": int64(40)},
+	)
+	records := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	records.At(0).SetTimestamp(pcommon.NewTimestampFromTime(testStart.Add(50 * time.Second)))
+	// Records without a timestamp fall back to the observed timestamp
+	records.At(1).SetObservedTimestamp(pcommon.NewTimestampFromTime(testStart.Add(10 * time.Second)))
+	records.At(2).SetTimestamp(pcommon.NewTimestampFromTime(testStart.Add(65 * time.Second)))
+	records.At(3).SetTimestamp(pcommon.NewTimestampFromTime(testStart.Add(3 * time.Minute)))
+	require.NoError(t, a.ConsumeLogs(context.Background(), logs))
+
+	// The first window stays open for the allowed lateness
+	a.catchUp(context.Background(), testStart.Add(89*time.Second))
+	assert.Empty(t, sink.AllMetrics())
+	a.catchUp(context.Background(), testStart.Add(91*time.Second))
+	require.Len(t, sink.AllMetrics(), 1)
+	dp := findDataPoint(t, sink.AllMetrics()[0], "body.size.max", "")
+	assert.Equal(t, 20.0, dp.DoubleValue())
+	assert.Equal(t, pcommon.NewTimestampFromTime(testStart.Add(time.Minute)), dp.Timestamp())
+
+	// Records for the closed window are dropped
+	late := newLogs("frontend", map[string]any{"http.response.body.size": int64(50)})
+	late.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).SetTimestamp(pcommon.NewTimestampFromTime(testStart.Add(time.Second)))
+	require.NoError(t, a.ConsumeLogs(context.Background(), late))
+
+	a.catchUp(context.Background(), testStart.Add(151*time.Second))
+	require.Len(t, sink.AllMetrics(), 2)
+	assert.Equal(t, 30.0, findDataPoint(t, sink.AllMetrics()[1], "body.size.max", "").DoubleValue())
+	assert.Empty(t, a.windows.buckets)
+}
+
+func TestLogsToMetricsMaxDistinctValues(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Logs = map[string]AggregationInfo{
+		"users": {Value: `attributes["user.id"]`, Aggregation: AggregationDistinctCount, MaxDistinctValues: 5},
+	}
+
+	sink := &consumertest.MetricsSink{}
+	a := createLogsAggregator(t, cfg, sink, &fakeClock{now: testStart})
+
+	var records []map[string]any
+	for i := 0; i < 20; i++ {
+		records = append(records, map[string]any{"user.id": i})
+	}
+	require.NoError(t, a.ConsumeLogs(context.Background(), newLogs("frontend", records...)))
+
+	a.catchUp(context.Background(), testStart.Add(time.Minute))
+	require.Len(t, sink.AllMetrics(), 1)
+	assert.Equal(t, int64(5), findDataPoint(t, sink.AllMetrics()[0], "users", "").IntValue())
+}
+
+func TestTracesToMetrics(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Spans = map[string]AggregationInfo{
+		"span.duration.max": {
+			Value:       "end_time_unix_nano - start_time_unix_nano",
+			Aggregation: AggregationMax,
+			Attributes:  []AttributeConfig{{Key: "http.route", DefaultValue: "unknown"}},
+		},
+	}
+	cfg.SpanEvents = map[string]AggregationInfo{
+		"exception.count": {Conditions: []string{`name == "exception"`}, Aggregation: AggregationCount},
+	}
+	require.NoError(t, cfg.Validate())
+
+	sink := &consumertest.MetricsSink{}
+	conn, err := NewFactory().CreateTracesToMetrics(context.Background(), connectortest.NewNopSettings(), cfg, sink)
+	require.NoError(t, err)
+	a := conn.(*aggregator)
+	a.now = (&fakeClock{now: testStart}).Now
+
+	traces := ptrace.NewTraces()
+	spans := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	for i, d := range []time.Duration{time.Second, 3 * time.Second} {
+		span := spans.AppendEmpty()
+		span.SetStartTimestamp(pcommon.NewTimestampFromTime(testStart))
+		span.SetEndTimestamp(pcommon.NewTimestampFromTime(testStart.Add(d)))
+		if i == 0 {
+			span.Attributes().PutStr("http.route", "/api")
+		}
+		span.Events().AppendEmpty().SetName("exception")
+		span.Events().AppendEmpty().SetName("retry")
+	}
+	require.NoError(t, a.ConsumeTraces(context.Background(), traces))
+	a.catchUp(context.Background(), testStart.Add(time.Minute))
+
+	require.Len(t, sink.AllMetrics(), 1)
+	md := sink.AllMetrics()[0]
+	assert.Equal(t, float64(time.Second), findDataPoint(t, md, "span.duration.max", "/api").DoubleValue())
+	assert.Equal(t, float64(3*time.Second), findDataPoint(t, md, "span.duration.max", "unknown").DoubleValue())
+	assert.Equal(t, int64(2), findDataPoint(t, md, "exception.count", "").IntValue())
+}
+
+func TestMetricsToMetrics(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.DataPoints = map[string]AggregationInfo{
+		"cpu.utilization.avg": {
+			Conditions:  []string{`metric.name == "system.cpu.utilization"`},
+			Value:       "value_double",
+			Aggregation: AggregationAvg,
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	sink := &consumertest.MetricsSink{}
+	conn, err := NewFactory().CreateMetricsToMetrics(context.Background(), connectortest.NewNopSettings(), cfg, sink)
+	require.NoError(t, err)
+	a := conn.(*aggregator)
+	a.now = (&fakeClock{now: testStart}).Now
+
+	md := pmetric.NewMetrics()
+	ms := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	cpu := ms.AppendEmpty()
+	cpu.SetName("system.cpu.utilization")
+	for _, v := range []float64{0.2, 0.4, 0.9} {
+		cpu.SetEmptyGauge()
+		cpu.Gauge().DataPoints().AppendEmpty().SetDoubleValue(v)
+	}
+	other := ms.AppendEmpty()
+	other.SetName("system.memory.utilization")
+	other.SetEmptyGauge().DataPoints().AppendEmpty().SetDoubleValue(1)
+	require.NoError(t, a.ConsumeMetrics(context.Background(), md))
+	a.catchUp(context.Background(), testStart.Add(time.Minute))
+
+	require.Len(t, sink.AllMetrics(), 1)
+	assert.InDelta(t, 0.9, findDataPoint(t, sink.AllMetrics()[0], "cpu.utilization.avg", "").DoubleValue(), 1e-9)
+}
+
+func TestStorageRestoresOpenWindows(t *testing.T) {
+	storageID := storagetest.NewStorageID("test")
+	host := storagetest.NewStorageHost().WithFileBackedStorageExtension("test", t.TempDir())
+	cfg := createDefaultConfig().(*Config)
+	cfg.StorageID = &storageID
+	cfg.Logs = map[string]AggregationInfo{
+		"body.size.p50": {
+			Value:       `attributes["http.response.body.size"]`,
+			Aggregation: AggregationPercentile,
+			Percentile:  50,
+			Attributes:  []AttributeConfig{{Key: "http.route"}},
+		},
+		"users": {Value: `attributes["user.id"]`, Aggregation: AggregationDistinctCount},
+	}
+
+	clock := &fakeClock{now: testStart.Add(10 * time.Second)}
+	sink := &consumertest.MetricsSink{}
+	a := createLogsAggregator(t, cfg, sink, clock)
+	require.NoError(t, a.Start(context.Background(), host))
+	require.NoError(t, a.ConsumeLogs(context.Background(), newLogs("frontend",
+		map[string]any{"http.route": "/api", "http.response.body.size": int64(10), "user.id": "a"},
+		map[string]any{"http.route": "/api", "http.response.body.size": int64(20), "user.id": "b"},
+		map[string]any{"http.route": "/api", "http.response.body.size": int64(30), "user.id": "a"},
+	)))
+	require.NoError(t, a.Shutdown(context.Background()))
+	assert.Empty(t, sink.AllMetrics())
+
+	// The collector restarts after the window closed
+	clock.now = testStart.Add(90 * time.Second)
+	restarted := createLogsAggregator(t, cfg, sink, clock)
+	require.NoError(t, restarted.Start(context.Background(), host))
+	require.NoError(t, restarted.Shutdown(context.Background()))
+
+	require.Len(t, sink.AllMetrics(), 1)
+	md := sink.AllMetrics()[0]
+	assert.InEpsilon(t, 20.0, findDataPoint(t, md, "body.size.p50", "/api").DoubleValue(), 0.02)
+	assert.Equal(t, int64(2), findDataPoint(t, md, "users", "").IntValue())
+	dp := findDataPoint(t, md, "users", "")
+	assert.Equal(t, pcommon.NewTimestampFromTime(testStart.Add(time.Minute)), dp.Timestamp())
+}
+
+func TestStorageExtensionNotFound(t *testing.T) {
+	storageID := component.MustNewID("missing")
+	cfg := createDefaultConfig().(*Config)
+	cfg.StorageID = &storageID
+
+	a := createLogsAggregator(t, cfg, &consumertest.MetricsSink{}, &fakeClock{now: testStart})
+	assert.EqualError(t, a.Start(context.Background(), componenttest.NewNopHost()), "storage extension 'missing' not found")
+	assert.NoError(t, a.Shutdown(context.Background()))
+}
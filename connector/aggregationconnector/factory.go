@@ -0,0 +1,166 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+package aggregationconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/aggregationconnector"
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pipeline"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/aggregationconnector/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoint"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanevent"
+)
+
+// NewFactory returns a ConnectorFactory.
+func NewFactory() connector.Factory {
+	return connector.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		connector.WithTracesToMetrics(createTracesToMetrics, metadata.TracesToMetricsStability),
+		connector.WithMetricsToMetrics(createMetricsToMetrics, metadata.MetricsToMetricsStability),
+		connector.WithLogsToMetrics(createLogsToMetrics, metadata.LogsToMetricsStability),
+	)
+}
+
+// createDefaultConfig creates the default configuration.
+func createDefaultConfig() component.Config {
+	return &Config{
+		Window: WindowConfig{
+			Type: WindowTypeTumbling,
+			Size: defaultWindowSize,
+		},
+	}
+}
+
+func newAggregator(set connector.Settings, c *Config, signal pipeline.Signal, nextConsumer consumer.Metrics, infos ...map[string]AggregationInfo) *aggregator {
+	specs := make(map[string]metricSpec)
+	for _, info := range infos {
+		for name, i := range info {
+			specs[name] = newMetricSpec(i)
+		}
+	}
+	return &aggregator{
+		logger:          set.Logger,
+		metricsConsumer: nextConsumer,
+		id:              set.ID,
+		signal:          signal.String(),
+		storageID:       c.StorageID,
+		windows:         newWindows(c.Window.Size, c.Window.slide(), specs),
+		lateness:        c.Window.AllowedLateness,
+		now:             time.Now,
+	}
+}
+
+// sortedNames returns the metric names in a stable order, so that definitions are always evaluated the same way.
+func sortedNames(infos map[string]AggregationInfo) []string {
+	names := make([]string, 0, len(infos))
+	for name := range infos {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// createTracesToMetrics creates a traces to metrics connector based on provided config.
+func createTracesToMetrics(
+	_ context.Context,
+	set connector.Settings,
+	cfg component.Config,
+	nextConsumer consumer.Metrics,
+) (connector.Traces, error) {
+	c := cfg.(*Config)
+	a := newAggregator(set, c, pipeline.SignalTraces, nextConsumer, c.Spans, c.SpanEvents)
+
+	for _, name := range sortedNames(c.Spans) {
+		info := c.Spans[name]
+		def := aggregationDef[ottlspan.TransformContext]{name: name, spec: newMetricSpec(info), attrs: info.Attributes}
+		if len(info.Conditions) > 0 {
+			// Error checked in Config.Validate()
+			condition, _ := filterottl.NewBoolExprForSpan(info.Conditions, filterottl.StandardSpanFuncs(), ottl.PropagateError, set.TelemetrySettings)
+			def.condition = condition
+		}
+		// Error checked in Config.Validate()
+		def.value, _ = newSpanValueExpression(info.Value, set.TelemetrySettings)
+		a.spansDefs = append(a.spansDefs, def)
+	}
+
+	for _, name := range sortedNames(c.SpanEvents) {
+		info := c.SpanEvents[name]
+		def := aggregationDef[ottlspanevent.TransformContext]{name: name, spec: newMetricSpec(info), attrs: info.Attributes}
+		if len(info.Conditions) > 0 {
+			// Error checked in Config.Validate()
+			condition, _ := filterottl.NewBoolExprForSpanEvent(info.Conditions, filterottl.StandardSpanEventFuncs(), ottl.PropagateError, set.TelemetrySettings)
+			def.condition = condition
+		}
+		// Error checked in Config.Validate()
+		def.value, _ = newSpanEventValueExpression(info.Value, set.TelemetrySettings)
+		a.spanEventsDefs = append(a.spanEventsDefs, def)
+	}
+
+	return a, nil
+}
+
+// createMetricsToMetrics creates a metrics to metrics connector based on provided config.
+func createMetricsToMetrics(
+	_ context.Context,
+	set connector.Settings,
+	cfg component.Config,
+	nextConsumer consumer.Metrics,
+) (connector.Metrics, error) {
+	c := cfg.(*Config)
+	a := newAggregator(set, c, pipeline.SignalMetrics, nextConsumer, c.DataPoints)
+
+	for _, name := range sortedNames(c.DataPoints) {
+		info := c.DataPoints[name]
+		def := aggregationDef[ottldatapoint.TransformContext]{name: name, spec: newMetricSpec(info), attrs: info.Attributes}
+		if len(info.Conditions) > 0 {
+			// Error checked in Config.Validate()
+			condition, _ := filterottl.NewBoolExprForDataPoint(info.Conditions, filterottl.StandardDataPointFuncs(), ottl.PropagateError, set.TelemetrySettings)
+			def.condition = condition
+		}
+		// Error checked in Config.Validate()
+		def.value, _ = newDataPointValueExpression(info.Value, set.TelemetrySettings)
+		a.dataPointsDefs = append(a.dataPointsDefs, def)
+	}
+
+	return a, nil
+}
+
+// createLogsToMetrics creates a logs to metrics connector based on provided config.
+func createLogsToMetrics(
+	_ context.Context,
+	set connector.Settings,
+	cfg component.Config,
+	nextConsumer consumer.Metrics,
+) (connector.Logs, error) {
+	c := cfg.(*Config)
+	a := newAggregator(set, c, pipeline.SignalLogs, nextConsumer, c.Logs)
+
+	for _, name := range sortedNames(c.Logs) {
+		info := c.Logs[name]
+		def := aggregationDef[ottllog.TransformContext]{name: name, spec: newMetricSpec(info), attrs: info.Attributes}
+		if len(info.Conditions) > 0 {
+			// Error checked in Config.Validate()
+			condition, _ := filterottl.NewBoolExprForLog(info.Conditions, filterottl.StandardLogFuncs(), ottl.PropagateError, set.TelemetrySettings)
+			def.condition = condition
+		}
+		// Error checked in Config.Validate()
+		def.value, _ = newLogValueExpression(info.Value, set.TelemetrySettings)
+		a.logsDefs = append(a.logsDefs, def)
+	}
+
+	return a, nil
+}
@@ -0,0 +1,214 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package aggregationconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/aggregationconnector"
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatautil"
+)
+
+const stateKey = "windows"
+
+func getStorageClient(ctx context.Context, host component.Host, storageID *component.ID, componentID component.ID, signal string) (storage.Client, error) {
+	if storageID == nil {
+		return storage.NewNopClient(), nil
+	}
+
+	extension, ok := host.GetExtensions()[*storageID]
+	if !ok {
+		return nil, fmt.Errorf("storage extension '%s' not found", storageID)
+	}
+
+	storageExtension, ok := extension.(storage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("non-storage extension '%s' found", storageID)
+	}
+
+	return storageExtension.GetClient(ctx, component.KindConnector, componentID, signal)
+}
+
+type persistedState struct {
+	LastEnd int64             `json:"last_end"`
+	Buckets []persistedBucket `json:"buckets"`
+}
+
+type persistedBucket struct {
+	Start  int64             `json:"start"`
+	Series []persistedSeries `json:"series"`
+}
+
+type persistedSeries struct {
+	Metric     string              `json:"metric"`
+	Resource   map[string]any      `json:"resource,omitempty"`
+	Attributes map[string]any      `json:"attributes,omitempty"`
+	Count      uint64              `json:"count"`
+	Sum        float64             `json:"sum"`
+	Min        float64             `json:"min"`
+	Max        float64             `json:"max"`
+	Distinct   []string            `json:"distinct,omitempty"`
+	Histogram  *persistedHistogram `json:"histogram,omitempty"`
+}
+
+type persistedHistogram struct {
+	Scale          int32    `json:"scale"`
+	ZeroCount      uint64   `json:"zero_count"`
+	PositiveOffset int32    `json:"positive_offset"`
+	Positive       []uint64 `json:"positive,omitempty"`
+	NegativeOffset int32    `json:"negative_offset"`
+	Negative       []uint64 `json:"negative,omitempty"`
+}
+
+// marshal encodes the open windows.
+func (w *windows) marshal() ([]byte, error) {
+	state := persistedState{Buckets: make([]persistedBucket, 0, len(w.buckets))}
+	if !w.lastEnd.IsZero() {
+		state.LastEnd = w.lastEnd.UnixNano()
+	}
+	for start, b := range w.buckets {
+		pb := persistedBucket{Start: start}
+		for name, metricSeries := range b.metrics {
+			for _, s := range metricSeries {
+				pb.Series = append(pb.Series, persistSeries(name, s))
+			}
+		}
+		state.Buckets = append(state.Buckets, pb)
+	}
+	return json.Marshal(state)
+}
+
+func persistSeries(name string, s *series) persistedSeries {
+	ps := persistedSeries{
+		Metric:     name,
+		Resource:   s.resource.AsRaw(),
+		Attributes: s.attrs.AsRaw(),
+		Count:      s.acc.count,
+		Sum:        s.acc.sum,
+		Min:        s.acc.min,
+		Max:        s.acc.max,
+	}
+	for v := range s.acc.distinct {
+		ps.Distinct = append(ps.Distinct, v)
+	}
+	if h := s.acc.histogram; h != nil {
+		ph := &persistedHistogram{
+			Scale:          h.Scale(),
+			ZeroCount:      h.ZeroCount(),
+			PositiveOffset: h.Positive().Offset(),
+			NegativeOffset: h.Negative().Offset(),
+		}
+		for i := uint32(0); i < h.Positive().Len(); i++ {
+			ph.Positive = append(ph.Positive, h.Positive().At(i))
+		}
+		for i := uint32(0); i < h.Negative().Len(); i++ {
+			ph.Negative = append(ph.Negative, h.Negative().At(i))
+		}
+		ps.Histogram = ph
+	}
+	return ps
+}
+
+// unmarshal restores the open windows from data. Series of metrics that are no longer
+// configured are dropped.
+func (w *windows) unmarshal(data []byte) error {
+	var state persistedState
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&state); err != nil {
+		return err
+	}
+
+	if state.LastEnd != 0 {
+		w.lastEnd = time.Unix(0, state.LastEnd)
+	}
+	for _, pb := range state.Buckets {
+		b := w.bucketFor(time.Unix(0, pb.Start))
+		for _, ps := range pb.Series {
+			spec, ok := w.specs[ps.Metric]
+			if !ok {
+				continue
+			}
+			resource := pcommon.NewMap()
+			if err := resource.FromRaw(normalizeNumbers(ps.Resource).(map[string]any)); err != nil {
+				return err
+			}
+			attrs := pcommon.NewMap()
+			if err := attrs.FromRaw(normalizeNumbers(ps.Attributes).(map[string]any)); err != nil {
+				return err
+			}
+			acc := b.accumulator(ps.Metric, spec, resourceInfo{key: pdatautil.MapHash(resource), attrs: resource}, attrs)
+			acc.merge(restoreAccumulator(spec, ps))
+		}
+	}
+	return nil
+}
+
+func restoreAccumulator(spec metricSpec, ps persistedSeries) *accumulator {
+	acc := newAccumulator(spec)
+	acc.count = ps.Count
+	acc.sum = ps.Sum
+	acc.min = ps.Min
+	acc.max = ps.Max
+	if acc.distinct != nil {
+		for _, v := range ps.Distinct {
+			acc.addDistinct(v)
+		}
+	}
+	if acc.histogram != nil && ps.Histogram != nil {
+		// The buckets are replayed through their midpoints, which map back to the same
+		// buckets as long as the histogram does not need a coarser scale.
+		ph := ps.Histogram
+		if ph.ZeroCount > 0 {
+			acc.histogram.UpdateByIncr(0, ph.ZeroCount)
+		}
+		for i, count := range ph.Positive {
+			if count > 0 {
+				acc.histogram.UpdateByIncr(bucketMidpoint(ph.Scale, ph.PositiveOffset+int32(i)), count)
+			}
+		}
+		for i, count := range ph.Negative {
+			if count > 0 {
+				acc.histogram.UpdateByIncr(-bucketMidpoint(ph.Scale, ph.NegativeOffset+int32(i)), count)
+			}
+		}
+	}
+	return acc
+}
+
+// normalizeNumbers converts the json.Number values produced by the decoder into int64 or
+// float64. Doubles with an integral value are restored as integers.
+func normalizeNumbers(v any) any {
+	switch val := v.(type) {
+	case json.Number:
+		if i, err := val.Int64(); err == nil {
+			return i
+		}
+		f, _ := val.Float64()
+		return f
+	case map[string]any:
+		for k, item := range val {
+			if item != nil {
+				val[k] = normalizeNumbers(item)
+			}
+		}
+		return val
+	case []any:
+		for i, item := range val {
+			if item != nil {
+				val[i] = normalizeNumbers(item)
+			}
+		}
+		return val
+	default:
+		return val
+	}
+}
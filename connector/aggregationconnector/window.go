@@ -0,0 +1,281 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package aggregationconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/aggregationconnector"
+
+import (
+	"sort"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatautil"
+)
+
+// resourceInfo identifies the resource the aggregated telemetry originates from.
+type resourceInfo struct {
+	key   [16]byte
+	attrs pcommon.Map
+}
+
+func newResourceInfo(resource pcommon.Resource) resourceInfo {
+	return resourceInfo{
+		key:   pdatautil.MapHash(resource.Attributes()),
+		attrs: resource.Attributes(),
+	}
+}
+
+type seriesKey struct {
+	resource [16]byte
+	attrs    [16]byte
+}
+
+type series struct {
+	resource pcommon.Map
+	attrs    pcommon.Map
+	acc      *accumulator
+}
+
+// bucket holds the series recorded during one slide interval. Windows are assembled from
+// consecutive buckets when they close.
+type bucket struct {
+	metrics map[string]map[seriesKey]*series
+}
+
+func newBucket() *bucket {
+	return &bucket{metrics: make(map[string]map[seriesKey]*series)}
+}
+
+func (b *bucket) accumulator(name string, spec metricSpec, resource resourceInfo, attrs pcommon.Map) *accumulator {
+	metricSeries, ok := b.metrics[name]
+	if !ok {
+		metricSeries = make(map[seriesKey]*series)
+		b.metrics[name] = metricSeries
+	}
+	key := seriesKey{resource: resource.key, attrs: pdatautil.MapHash(attrs)}
+	s, ok := metricSeries[key]
+	if !ok {
+		// The resource attributes belong to the incoming telemetry, keep a copy.
+		resourceAttrs := pcommon.NewMap()
+		resource.attrs.CopyTo(resourceAttrs)
+		s = &series{resource: resourceAttrs, attrs: attrs, acc: newAccumulator(spec)}
+		metricSeries[key] = s
+	}
+	return s.acc
+}
+
+// windows tracks the buckets of the open windows.
+type windows struct {
+	size    time.Duration
+	slide   time.Duration
+	specs   map[string]metricSpec
+	buckets map[int64]*bucket
+	// lastEnd is the end of the last closed window.
+	lastEnd time.Time
+}
+
+func newWindows(size, slide time.Duration, specs map[string]metricSpec) *windows {
+	return &windows{
+		size:    size,
+		slide:   slide,
+		specs:   specs,
+		buckets: make(map[int64]*bucket),
+	}
+}
+
+// bucketFor returns the bucket recording the telemetry received at the given time.
+func (w *windows) bucketFor(now time.Time) *bucket {
+	start := now.Truncate(w.slide).UnixNano()
+	b, ok := w.buckets[start]
+	if !ok {
+		b = newBucket()
+		w.buckets[start] = b
+	}
+	return b
+}
+
+// bucketForRecord returns the bucket recording telemetry with the given timestamp, using now for
+// telemetry without one. It returns false for telemetry whose windows all closed already, and for
+// telemetry more than a window size ahead of now.
+func (w *windows) bucketForRecord(ts pcommon.Timestamp, now time.Time) (*bucket, bool) {
+	t := now
+	if ts != 0 {
+		t = ts.AsTime()
+	}
+	if t.After(now.Add(w.size)) {
+		return nil, false
+	}
+	if !w.lastEnd.IsZero() && !t.Truncate(w.slide).Add(w.size).After(w.lastEnd) {
+		return nil, false
+	}
+	return w.bucketFor(t), true
+}
+
+// nextEnd returns the end of the next window to close, and false if there are no buckets.
+// It is the end of the first window containing the oldest bucket, unless that window was
+// already closed.
+func (w *windows) nextEnd() (time.Time, bool) {
+	if len(w.buckets) == 0 {
+		return time.Time{}, false
+	}
+	var oldest int64
+	first := true
+	for start := range w.buckets {
+		if first || start < oldest {
+			oldest = start
+			first = false
+		}
+	}
+	end := time.Unix(0, oldest).Add(w.slide)
+	if !w.lastEnd.IsZero() && !end.After(w.lastEnd) {
+		end = w.lastEnd.Add(w.slide)
+	}
+	return end, true
+}
+
+// close closes the window ending at end, appends the resulting metrics to md and drops
+// the buckets that are not part of any later window.
+func (w *windows) close(end time.Time, md pmetric.Metrics) {
+	start := end.Add(-w.size)
+	merged := make(map[string]map[seriesKey]*series)
+	for bucketStart, b := range w.buckets {
+		if bucketStart < start.UnixNano() || bucketStart >= end.UnixNano() {
+			continue
+		}
+		for name, metricSeries := range b.metrics {
+			spec, ok := w.specs[name]
+			if !ok {
+				continue
+			}
+			if _, ok := merged[name]; !ok {
+				merged[name] = make(map[seriesKey]*series)
+			}
+			for key, s := range metricSeries {
+				m, ok := merged[name][key]
+				if !ok {
+					m = &series{resource: s.resource, attrs: s.attrs, acc: newAccumulator(spec)}
+					merged[name][key] = m
+				}
+				m.acc.merge(s.acc)
+			}
+		}
+	}
+
+	expired := start.Add(w.slide).UnixNano()
+	for bucketStart := range w.buckets {
+		if bucketStart < expired {
+			delete(w.buckets, bucketStart)
+		}
+	}
+
+	w.lastEnd = end
+	appendMetrics(merged, w.specs, start, end, md)
+}
+
+func appendMetrics(merged map[string]map[seriesKey]*series, specs map[string]metricSpec, start, end time.Time, md pmetric.Metrics) {
+	names := make([]string, 0, len(merged))
+	for name := range merged {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	startTimestamp := pcommon.NewTimestampFromTime(start)
+	timestamp := pcommon.NewTimestampFromTime(end)
+	scopes := make(map[[16]byte]pmetric.ScopeMetrics)
+	metrics := make(map[[16]byte]map[string]pmetric.Metric)
+	for _, name := range names {
+		spec := specs[name]
+		for key, s := range merged[name] {
+			scope, ok := scopes[key.resource]
+			if !ok {
+				rm := md.ResourceMetrics().AppendEmpty()
+				s.resource.CopyTo(rm.Resource().Attributes())
+				scope = rm.ScopeMetrics().AppendEmpty()
+				scopes[key.resource] = scope
+				metrics[key.resource] = make(map[string]pmetric.Metric)
+			}
+			metric, ok := metrics[key.resource][name]
+			if !ok {
+				metric = newMetric(scope.Metrics(), name, spec)
+				metrics[key.resource][name] = metric
+			}
+			appendDataPoint(metric, spec, s, startTimestamp, timestamp)
+		}
+	}
+}
+
+func newMetric(metrics pmetric.MetricSlice, name string, spec metricSpec) pmetric.Metric {
+	metric := metrics.AppendEmpty()
+	metric.SetName(name)
+	metric.SetDescription(spec.desc)
+	metric.SetUnit(spec.unit)
+	switch spec.output {
+	case OutputSum:
+		sum := metric.SetEmptySum()
+		// Counts are never negative, so a value accumulated downstream is monotonic
+		sum.SetIsMonotonic(spec.aggregation == AggregationCount)
+		sum.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	case OutputExponentialHistogram:
+		metric.SetEmptyExponentialHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	default:
+		metric.SetEmptyGauge()
+	}
+	return metric
+}
+
+func appendDataPoint(metric pmetric.Metric, spec metricSpec, s *series, start, timestamp pcommon.Timestamp) {
+	switch spec.output {
+	case OutputSum:
+		dp := metric.Sum().DataPoints().AppendEmpty()
+		setNumberDataPoint(dp, spec, s, start, timestamp)
+	case OutputExponentialHistogram:
+		dp := metric.ExponentialHistogram().DataPoints().AppendEmpty()
+		s.attrs.CopyTo(dp.Attributes())
+		dp.SetStartTimestamp(start)
+		dp.SetTimestamp(timestamp)
+		histogramToDataPoint(s.acc, dp)
+	default:
+		dp := metric.Gauge().DataPoints().AppendEmpty()
+		setNumberDataPoint(dp, spec, s, start, timestamp)
+	}
+}
+
+func setNumberDataPoint(dp pmetric.NumberDataPoint, spec metricSpec, s *series, start, timestamp pcommon.Timestamp) {
+	s.attrs.CopyTo(dp.Attributes())
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(timestamp)
+	switch spec.aggregation {
+	case AggregationCount:
+		dp.SetIntValue(int64(s.acc.count))
+	case AggregationDistinctCount:
+		dp.SetIntValue(int64(len(s.acc.distinct)))
+	default:
+		dp.SetDoubleValue(s.acc.value(spec))
+	}
+}
+
+// histogramToDataPoint copies the accumulated distribution to dp. Sum, min and max are taken
+// from the accumulator, which tracks them exactly.
+func histogramToDataPoint(acc *accumulator, dp pmetric.ExponentialHistogramDataPoint) {
+	agg := acc.histogram
+	dp.SetCount(agg.Count())
+	dp.SetSum(acc.sum)
+	if agg.Count() != 0 {
+		dp.SetMin(acc.min)
+		dp.SetMax(acc.max)
+	}
+	dp.SetZeroCount(agg.ZeroCount())
+	dp.SetScale(agg.Scale())
+
+	copyBuckets(agg.Positive().Offset(), agg.Positive().Len(), agg.Positive().At, dp.Positive())
+	copyBuckets(agg.Negative().Offset(), agg.Negative().Len(), agg.Negative().At, dp.Negative())
+}
+
+func copyBuckets(offset int32, length uint32, at func(uint32) uint64, out pmetric.ExponentialHistogramDataPointBuckets) {
+	out.SetOffset(offset)
+	out.BucketCounts().EnsureCapacity(int(length))
+	for i := uint32(0); i < length; i++ {
+		out.BucketCounts().Append(at(i))
+	}
+}
@@ -19,6 +19,21 @@ type Exemplars struct {
 	Enabled bool `mapstructure:"enabled"`
 }
 
+// Fingerprint defines the configuration for grouping exceptions by fingerprint.
+type Fingerprint struct {
+	// Enabled adds the exception.fingerprint dimension to metrics and logs.
+	Enabled bool `mapstructure:"enabled"`
+	// MaxFrames is the number of in-app stack frames that are part of the fingerprint.
+	MaxFrames int `mapstructure:"max_frames"`
+	// InAppPrefixes lists the module prefixes (Java or .NET namespaces, Go packages, Python
+	// or JavaScript file paths) of the frames that belong to the application. When empty,
+	// frames of well known standard libraries and third party dependencies are skipped.
+	InAppPrefixes []string `mapstructure:"in_app_prefixes"`
+	// CacheSize is the maximum number of fingerprints for which the first and last
+	// occurrences are tracked.
+	CacheSize int `mapstructure:"cache_size"`
+}
+
 // Config defines the configuration options for exceptionsconnector
 type Config struct {
 	// Dimensions defines the list of additional dimensions on top of the provided:
@@ -31,25 +46,38 @@ type Config struct {
 	Dimensions []Dimension `mapstructure:"dimensions"`
 	// Exemplars defines the configuration for exemplars.
 	Exemplars Exemplars `mapstructure:"exemplars"`
+	// Fingerprint defines the configuration for grouping exceptions by fingerprint.
+	Fingerprint Fingerprint `mapstructure:"fingerprint"`
 }
 
 var _ component.ConfigValidator = (*Config)(nil)
 
 // Validate checks if the connector configuration is valid
 func (c Config) Validate() error {
-	err := validateDimensions(c.Dimensions)
+	err := validateDimensions(c.Dimensions, c.Fingerprint.Enabled)
 	if err != nil {
 		return err
 	}
+	if c.Fingerprint.Enabled {
+		if c.Fingerprint.MaxFrames <= 0 {
+			return fmt.Errorf("fingerprint max_frames must be positive, got %d", c.Fingerprint.MaxFrames)
+		}
+		if c.Fingerprint.CacheSize <= 0 {
+			return fmt.Errorf("fingerprint cache_size must be positive, got %d", c.Fingerprint.CacheSize)
+		}
+	}
 	return nil
 }
 
 // validateDimensions checks duplicates for reserved dimensions and additional dimensions.
-func validateDimensions(dimensions []Dimension) error {
+func validateDimensions(dimensions []Dimension, fingerprint bool) error {
 	labelNames := make(map[string]struct{})
 	for _, key := range []string{serviceNameKey, spanKindKey, spanNameKey, statusCodeKey} {
 		labelNames[key] = struct{}{}
 	}
+	if fingerprint {
+		labelNames[exceptionFingerprintKey] = struct{}{}
+	}
 
 	for _, key := range dimensions {
 		if _, ok := labelNames[key.Name]; ok {
@@ -39,6 +39,12 @@ func TestLoadConfig(t *testing.T) {
 				Exemplars: Exemplars{
 					Enabled: false,
 				},
+				Fingerprint: Fingerprint{
+					Enabled:       true,
+					MaxFrames:     3,
+					InAppPrefixes: []string{"com.example."},
+					CacheSize:     1000,
+				},
 			},
 		},
 	}
@@ -92,7 +98,7 @@ func TestValidateDimensions(t *testing.T) {
 		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
-			err := validateDimensions(tc.dimensions)
+			err := validateDimensions(tc.dimensions, false)
 			if tc.expectedErr != "" {
 				assert.EqualError(t, err, tc.expectedErr)
 			} else {
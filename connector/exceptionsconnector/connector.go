@@ -20,6 +20,10 @@ const (
 	spanNameKey   = "span.name"   // OpenTelemetry non-standard constant.
 	statusCodeKey = "status.code" // OpenTelemetry non-standard constant.
 	eventNameExc  = "exception"   // OpenTelemetry non-standard constant.
+
+	exceptionFingerprintKey          = "exception.fingerprint"
+	exceptionFingerprintFirstSeenKey = "exception.fingerprint.first_seen"
+	exceptionFingerprintLastSeenKey  = "exception.fingerprint.last_seen"
 )
 
 func newDimensions(cfgDims []Dimension) []pdatautil.Dimension {
@@ -5,6 +5,7 @@ package exceptionsconnector // import "github.com/open-telemetry/opentelemetry-c
 
 import (
 	"context"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
@@ -24,6 +25,9 @@ type logsConnector struct {
 	// Additional dimensions to add to logs.
 	dimensions []pdatautil.Dimension
 
+	fingerprinter    *fingerprinter
+	fingerprintCache *fingerprintCache
+
 	logsConsumer consumer.Logs
 	component.StartFunc
 	component.ShutdownFunc
@@ -34,11 +38,16 @@ type logsConnector struct {
 func newLogsConnector(logger *zap.Logger, config component.Config) *logsConnector {
 	cfg := config.(*Config)
 
-	return &logsConnector{
+	c := &logsConnector{
 		logger:     logger,
 		config:     *cfg,
 		dimensions: newDimensions(cfg.Dimensions),
 	}
+	if cfg.Fingerprint.Enabled {
+		c.fingerprinter = newFingerprinter(cfg.Fingerprint)
+		c.fingerprintCache = newFingerprintCache(cfg.Fingerprint.CacheSize)
+	}
+	return c
 }
 
 // Capabilities implements the consumer interface.
@@ -122,5 +131,27 @@ func (c *logsConnector) attrToLogRecord(sl plog.ScopeLogs, serviceName string, s
 	// Add stacktrace to the log record.
 	attrVal, _ := pdatautil.GetAttributeValue(exceptionStacktraceKey, eventAttrs)
 	logRecord.Attributes().PutStr(exceptionStacktraceKey, attrVal)
+
+	if c.fingerprinter != nil {
+		c.addFingerprint(logRecord, eventAttrs, attrVal)
+	}
 	return logRecord
 }
+
+// addFingerprint adds the exception fingerprint and the first and last occurrences of exceptions
+// with the same fingerprint to the log record.
+func (c *logsConnector) addFingerprint(logRecord plog.LogRecord, eventAttrs pcommon.Map, stacktrace string) {
+	excType, _ := pdatautil.GetAttributeValue(exceptionTypeKey, eventAttrs)
+	message, _ := pdatautil.GetAttributeValue(exceptionMessageKey, eventAttrs)
+	fingerprint, _ := c.fingerprinter.fingerprint(excType, message, stacktrace)
+
+	timestamp := logRecord.Timestamp()
+	if timestamp == 0 {
+		timestamp = pcommon.NewTimestampFromTime(time.Now())
+	}
+	firstSeen, lastSeen := c.fingerprintCache.observe(fingerprint, timestamp)
+
+	logRecord.Attributes().PutStr(exceptionFingerprintKey, fingerprint)
+	logRecord.Attributes().PutStr(exceptionFingerprintFirstSeenKey, firstSeen.AsTime().Format(time.RFC3339Nano))
+	logRecord.Attributes().PutStr(exceptionFingerprintLastSeenKey, lastSeen.AsTime().Format(time.RFC3339Nano))
+}
@@ -6,12 +6,14 @@ package exceptionsconnector
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest"
@@ -46,6 +48,46 @@ func TestConnectorLogConsumeTraces(t *testing.T) {
 	}
 }
 
+func TestConnectorLogConsumeTracesWithFingerprint(t *testing.T) {
+	lsink := new(consumertest.LogsSink)
+	cfg := createDefaultConfig().(*Config)
+	cfg.Fingerprint.Enabled = true
+	p := newLogsConnector(zaptest.NewLogger(t), cfg)
+	p.logsConsumer = lsink
+
+	traces := ptrace.NewTraces()
+	rs := traces.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr(serviceNameKey, "service-a")
+	span := rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	first := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+	for i, message := range []string{"order 1234 not found", "order 5678 not found"} {
+		e := span.Events().AppendEmpty()
+		e.SetName(eventNameExc)
+		e.SetTimestamp(pcommon.NewTimestampFromTime(first.Add(time.Duration(i) * time.Minute)))
+		e.Attributes().PutStr(exceptionTypeKey, "IllegalStateException")
+		e.Attributes().PutStr(exceptionMessageKey, message)
+		e.Attributes().PutStr(exceptionStacktraceKey, javaStacktrace)
+	}
+
+	ctx := context.Background()
+	require.NoError(t, p.ConsumeTraces(ctx, traces))
+
+	logs := lsink.AllLogs()
+	require.Len(t, logs, 1)
+	records := logs[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	require.Equal(t, 2, records.Len())
+
+	fingerprint, _ := newFingerprinter(cfg.Fingerprint).fingerprint("IllegalStateException", "order 1234 not found", javaStacktrace)
+	for i, lastSeen := range []string{"2024-10-01T12:00:00Z", "2024-10-01T12:01:00Z"} {
+		attrs := records.At(i).Attributes().AsRaw()
+		// The exception message is kept as is on log records.
+		assert.Contains(t, []any{"order 1234 not found", "order 5678 not found"}, attrs[exceptionMessageKey])
+		assert.Equal(t, fingerprint, attrs[exceptionFingerprintKey])
+		assert.Equal(t, "2024-10-01T12:00:00Z", attrs[exceptionFingerprintFirstSeenKey])
+		assert.Equal(t, lastSeen, attrs[exceptionFingerprintLastSeenKey])
+	}
+}
+
 func newTestLogsConnector(lcon consumer.Logs, logger *zap.Logger) *logsConnector {
 	cfg := &Config{
 		Dimensions: []Dimension{
@@ -32,6 +32,8 @@ type metricsConnector struct {
 	// Additional dimensions to add to metrics.
 	dimensions []pdatautil.Dimension
 
+	fingerprinter *fingerprinter
+
 	keyBuf *bytes.Buffer
 
 	metricsConsumer consumer.Metrics
@@ -55,7 +57,7 @@ type exception struct {
 func newMetricsConnector(logger *zap.Logger, config component.Config) *metricsConnector {
 	cfg := config.(*Config)
 
-	return &metricsConnector{
+	c := &metricsConnector{
 		logger:         logger,
 		config:         *cfg,
 		dimensions:     newDimensions(cfg.Dimensions),
@@ -63,6 +65,11 @@ func newMetricsConnector(logger *zap.Logger, config component.Config) *metricsCo
 		startTimestamp: pcommon.NewTimestampFromTime(time.Now()),
 		exceptions:     make(map[string]*exception),
 	}
+	if cfg.Fingerprint.Enabled {
+		c.fingerprinter = newFingerprinter(cfg.Fingerprint)
+		c.dimensions = append(c.dimensions, pdatautil.Dimension{Name: exceptionFingerprintKey})
+	}
+	return c
 }
 
 // Capabilities implements the consumer interface.
@@ -91,6 +98,9 @@ func (c *metricsConnector) ConsumeTraces(ctx context.Context, traces ptrace.Trac
 					event := span.Events().At(l)
 					if event.Name() == eventNameExc {
 						eventAttrs := event.Attributes()
+						if c.fingerprinter != nil {
+							eventAttrs = c.fingerprintAttrs(eventAttrs)
+						}
 
 						c.keyBuf.Reset()
 						buildKey(c.keyBuf, serviceName, span, c.dimensions, eventAttrs, resourceAttr)
@@ -107,6 +117,24 @@ func (c *metricsConnector) ConsumeTraces(ctx context.Context, traces ptrace.Trac
 	return c.exportMetrics(ctx)
 }
 
+// fingerprintAttrs returns a copy of the event attributes with the exception fingerprint added.
+// The exception message is replaced by its normalized form, so that variable parts of the message
+// do not result in a new series for every occurrence.
+func (c *metricsConnector) fingerprintAttrs(eventAttrs pcommon.Map) pcommon.Map {
+	excType, _ := pdatautil.GetAttributeValue(exceptionTypeKey, eventAttrs)
+	message, _ := pdatautil.GetAttributeValue(exceptionMessageKey, eventAttrs)
+	stacktrace, _ := pdatautil.GetAttributeValue(exceptionStacktraceKey, eventAttrs)
+	fingerprint, normalized := c.fingerprinter.fingerprint(excType, message, stacktrace)
+
+	attrs := pcommon.NewMap()
+	eventAttrs.CopyTo(attrs)
+	if _, ok := attrs.Get(exceptionMessageKey); ok {
+		attrs.PutStr(exceptionMessageKey, normalized)
+	}
+	attrs.PutStr(exceptionFingerprintKey, fingerprint)
+	return attrs
+}
+
 func (c *metricsConnector) exportMetrics(ctx context.Context) error {
 	c.lock.Lock()
 	m := pmetric.NewMetrics()
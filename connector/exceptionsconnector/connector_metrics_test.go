@@ -111,6 +111,48 @@ func TestConnectorConsumeTraces(t *testing.T) {
 
 }
 
+func TestConnectorConsumeTracesWithFingerprint(t *testing.T) {
+	msink := &consumertest.MetricsSink{}
+	cfg := createDefaultConfig().(*Config)
+	cfg.Fingerprint.Enabled = true
+	p := newMetricsConnector(zaptest.NewLogger(t), cfg)
+	p.metricsConsumer = msink
+
+	traces := ptrace.NewTraces()
+	rs := traces.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr(serviceNameKey, "service-a")
+	span := rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetName("GET /orders")
+	for _, message := range []string{"order 1234 not found", "order 5678 not found"} {
+		e := span.Events().AppendEmpty()
+		e.SetName(eventNameExc)
+		e.Attributes().PutStr(exceptionTypeKey, "IllegalStateException")
+		e.Attributes().PutStr(exceptionMessageKey, message)
+		e.Attributes().PutStr(exceptionStacktraceKey, javaStacktrace)
+	}
+
+	ctx := context.Background()
+	require.NoError(t, p.ConsumeTraces(ctx, traces))
+
+	metrics := msink.AllMetrics()
+	require.Len(t, metrics, 1)
+	dps := metrics[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints()
+	require.Equal(t, 1, dps.Len())
+	dp := dps.At(0)
+	assert.Equal(t, int64(2), dp.IntValue())
+
+	fingerprint, _ := newFingerprinter(cfg.Fingerprint).fingerprint("IllegalStateException", "order 1234 not found", javaStacktrace)
+	assert.Equal(t, map[string]any{
+		serviceNameKey:          "service-a",
+		spanNameKey:             "GET /orders",
+		spanKindKey:             "SPAN_KIND_UNSPECIFIED",
+		statusCodeKey:           "STATUS_CODE_UNSET",
+		exceptionTypeKey:        "IllegalStateException",
+		exceptionMessageKey:     "order <num> not found",
+		exceptionFingerprintKey: fingerprint,
+	}, dp.Attributes().AsRaw())
+}
+
 func BenchmarkConnectorConsumeTraces(b *testing.B) {
 	msink := &consumertest.MetricsSink{}
 
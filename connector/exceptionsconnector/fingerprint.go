@@ -0,0 +1,256 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exceptionsconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/exceptionsconnector"
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/golang-lru/v2/simplelru"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+const (
+	defaultMaxFrames            = 5
+	defaultFingerprintCacheSize = 10000
+)
+
+var (
+	uuidRegexp   = regexp.MustCompile(`(?i)\b[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}\b`)
+	hexRegexp    = regexp.MustCompile(`(?i)\b(?:0x[0-9a-f]+|[0-9a-f]{8,})\b`)
+	numberRegexp = regexp.MustCompile(`\d+(?:\.\d+)?`)
+
+	// File "/app/handler.py", line 12, in handle
+	pythonFrameRegexp = regexp.MustCompile(`^\s*File "([^"]+)", line \d+, in (\S+)`)
+	// at handle (/app/handler.js:12:5) or at /app/handler.js:12:5
+	jsFrameRegexp = regexp.MustCompile(`^\s*at (?:(.+?) \()?(\S+?):\d+:\d+\)?\s*$`)
+	// at com.example.Handler.handle(Handler.java:12) or at Example.Handler.Handle() in Handler.cs:line 12
+	atFrameRegexp = regexp.MustCompile(`^\s*at ([^\s(]+)\(`)
+	// /app/handler.go:12 +0x1d, following the line with the function name
+	goFileRegexp = regexp.MustCompile(`^\s+\S+\.go:\d+`)
+)
+
+// Module prefixes and path fragments of frames that belong to standard libraries or
+// third party dependencies rather than the application.
+var (
+	libraryModulePrefixes = []string{
+		"java.", "javax.", "jdk.", "sun.", "com.sun.", "kotlin.", "kotlinx.", "scala.",
+		"System.", "Microsoft.",
+		"node:", "internal/",
+	}
+	libraryPathFragments = []string{
+		"site-packages/", "dist-packages/", "/lib/python", "node_modules/",
+	}
+)
+
+type platform int
+
+const (
+	platformAt platform = iota // Java and .NET
+	platformPython
+	platformJavaScript
+	platformGo
+)
+
+type stackFrame struct {
+	platform platform
+	// module is the class or namespace for Java and .NET, the package for Go and the file
+	// path for Python and JavaScript.
+	module   string
+	function string
+}
+
+// fingerprinter groups exceptions that share a type, a message modulo variable parts
+// and the innermost in-app stack frames.
+type fingerprinter struct {
+	maxFrames     int
+	inAppPrefixes []string
+}
+
+func newFingerprinter(cfg Fingerprint) *fingerprinter {
+	return &fingerprinter{
+		maxFrames:     cfg.MaxFrames,
+		inAppPrefixes: cfg.InAppPrefixes,
+	}
+}
+
+// fingerprint returns the fingerprint of the exception together with its normalized message.
+func (f *fingerprinter) fingerprint(excType, message, stacktrace string) (string, string) {
+	normalized := normalizeMessage(message)
+
+	h := sha256.New()
+	h.Write([]byte(excType))
+	h.Write([]byte{0})
+	h.Write([]byte(normalized))
+	for _, frame := range f.topFrames(parseStacktrace(stacktrace)) {
+		h.Write([]byte{0})
+		h.Write([]byte(frame.module))
+		h.Write([]byte{0})
+		h.Write([]byte(frame.function))
+	}
+	return hex.EncodeToString(h.Sum(nil)[:8]), normalized
+}
+
+// topFrames returns the innermost in-app frames, or the innermost frames if none of them
+// belongs to the application.
+func (f *fingerprinter) topFrames(frames []stackFrame) []stackFrame {
+	inApp := make([]stackFrame, 0, f.maxFrames)
+	for _, frame := range frames {
+		if len(inApp) == f.maxFrames {
+			break
+		}
+		if f.isInApp(frame) {
+			inApp = append(inApp, frame)
+		}
+	}
+	if len(inApp) > 0 {
+		return inApp
+	}
+	if len(frames) > f.maxFrames {
+		return frames[:f.maxFrames]
+	}
+	return frames
+}
+
+func (f *fingerprinter) isInApp(frame stackFrame) bool {
+	if len(f.inAppPrefixes) > 0 {
+		for _, prefix := range f.inAppPrefixes {
+			if strings.HasPrefix(frame.module, prefix) {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, prefix := range libraryModulePrefixes {
+		if strings.HasPrefix(frame.module, prefix) {
+			return false
+		}
+	}
+	for _, fragment := range libraryPathFragments {
+		if strings.Contains(frame.module, fragment) {
+			return false
+		}
+	}
+	if frame.platform == platformGo {
+		// Standard library packages have no domain in their first path element.
+		first, _, _ := strings.Cut(frame.module, "/")
+		return frame.module == "main" || strings.Contains(first, ".")
+	}
+	return true
+}
+
+// normalizeMessage masks the UUIDs, hexadecimal values and numbers of an exception message.
+func normalizeMessage(message string) string {
+	message = uuidRegexp.ReplaceAllString(message, "<uuid>")
+	message = hexRegexp.ReplaceAllStringFunc(message, func(s string) string {
+		if strings.Trim(s, "0123456789") == "" {
+			// Plain numbers are masked below.
+			return s
+		}
+		return "<hex>"
+	})
+	return numberRegexp.ReplaceAllString(message, "<num>")
+}
+
+// parseStacktrace extracts the frames of a Java, .NET, Python, JavaScript or Go stack trace,
+// innermost first. Line numbers are left out, so that unrelated changes to the source code
+// do not change the fingerprint.
+func parseStacktrace(stacktrace string) []stackFrame {
+	var frames, pythonFrames []stackFrame
+	var previous string
+	for _, line := range strings.Split(stacktrace, "\n") {
+		line = strings.TrimRight(line, "\r")
+		switch {
+		case pythonFrameRegexp.MatchString(line):
+			m := pythonFrameRegexp.FindStringSubmatch(line)
+			pythonFrames = append(pythonFrames, stackFrame{platform: platformPython, module: m[1], function: m[2]})
+		case jsFrameRegexp.MatchString(line):
+			m := jsFrameRegexp.FindStringSubmatch(line)
+			frames = append(frames, stackFrame{platform: platformJavaScript, module: m[2], function: m[1]})
+		case atFrameRegexp.MatchString(line):
+			name := atFrameRegexp.FindStringSubmatch(line)[1]
+			// Drop the Java module, e.g. java.base/java.lang.Thread.run
+			if i := strings.LastIndexByte(name, '/'); i >= 0 {
+				name = name[i+1:]
+			}
+			frame := stackFrame{platform: platformAt, function: name}
+			if i := strings.LastIndexByte(name, '.'); i >= 0 {
+				frame.module, frame.function = name[:i], name[i+1:]
+			}
+			frames = append(frames, frame)
+		case goFileRegexp.MatchString(line) && previous != "":
+			frames = append(frames, parseGoFunction(previous))
+		}
+		previous = line
+	}
+
+	// Python tracebacks list the innermost frame last.
+	for i := len(pythonFrames) - 1; i >= 0; i-- {
+		frames = append(frames, pythonFrames[i])
+	}
+	return frames
+}
+
+// parseGoFunction parses the function line of a goroutine stack trace, e.g.
+// github.com/example/app/db.(*Client).Query(0xc000010000, ...)
+func parseGoFunction(line string) stackFrame {
+	name := strings.TrimPrefix(strings.TrimSpace(line), "created by ")
+	if i := strings.Index(name, " in goroutine "); i >= 0 {
+		name = name[:i]
+	}
+	if strings.HasSuffix(name, ")") {
+		if i := strings.LastIndexByte(name, '('); i > 0 {
+			name = name[:i]
+		}
+	}
+
+	frame := stackFrame{platform: platformGo, function: name}
+	pkgStart := strings.LastIndexByte(name, '/') + 1
+	if i := strings.IndexByte(name[pkgStart:], '.'); i >= 0 {
+		frame.module, frame.function = name[:pkgStart+i], name[pkgStart+i+1:]
+	}
+	return frame
+}
+
+type occurrences struct {
+	firstSeen pcommon.Timestamp
+	lastSeen  pcommon.Timestamp
+}
+
+// fingerprintCache tracks the first and last occurrences of the most recently seen fingerprints.
+type fingerprintCache struct {
+	lock  sync.Mutex
+	cache *simplelru.LRU[string, *occurrences]
+}
+
+func newFingerprintCache(size int) *fingerprintCache {
+	// Error checked in Config.Validate(), the size is positive.
+	cache, _ := simplelru.NewLRU[string, *occurrences](size, nil)
+	return &fingerprintCache{cache: cache}
+}
+
+// observe records an occurrence of the fingerprint and returns the first and last occurrences
+// known so far, including this one.
+func (c *fingerprintCache) observe(fingerprint string, timestamp pcommon.Timestamp) (pcommon.Timestamp, pcommon.Timestamp) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	seen, ok := c.cache.Get(fingerprint)
+	if !ok {
+		seen = &occurrences{firstSeen: timestamp, lastSeen: timestamp}
+		c.cache.Add(fingerprint, seen)
+		return seen.firstSeen, seen.lastSeen
+	}
+	if timestamp < seen.firstSeen {
+		seen.firstSeen = timestamp
+	}
+	if timestamp > seen.lastSeen {
+		seen.lastSeen = timestamp
+	}
+	return seen.firstSeen, seen.lastSeen
+}
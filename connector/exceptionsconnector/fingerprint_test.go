@@ -0,0 +1,205 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exceptionsconnector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+const (
+	javaStacktrace = `java.lang.IllegalStateException: order 1234 not found
+	at com.example.orders.OrderService.find(OrderService.java:42)
+	at com.example.orders.OrderController.get(OrderController.java:17)
+	at java.base/jdk.internal.reflect.NativeMethodAccessorImpl.invoke0(Native Method)
+	at org.springframework.web.servlet.FrameworkServlet.service(FrameworkServlet.java:897)
+	... 23 more`
+	dotnetStacktrace = `System.InvalidOperationException: order 1234 not found
+   at Example.Orders.OrderService.Find(Int32 id) in /src/OrderService.cs:line 42
+   at Example.Orders.OrderController.Get(Int32 id) in /src/OrderController.cs:line 17
+   at System.Threading.Tasks.Task.Execute()`
+	pythonStacktrace = `Traceback (most recent call last):
+  File "/usr/lib/python3.12/site-packages/flask/app.py", line 880, in full_dispatch_request
+    rv = self.dispatch_request()
+  File "/app/orders/views.py", line 17, in get
+    return service.find(order_id)
+  File "/app/orders/service.py", line 42, in find
+    raise LookupError(f"order {order_id} not found")
+LookupError: order 1234 not found`
+	jsStacktrace = `Error: order 1234 not found
+    at OrderService.find (/app/src/orders/service.js:42:11)
+    at /app/src/orders/controller.js:17:5
+    at Layer.handle [as handle_request] (/app/node_modules/express/lib/router/layer.js:95:5)
+    at process.processTicksAndRejections (node:internal/process/task_queues:95:5)`
+	goStacktrace = `goroutine 1 [running]:
+github.com/example/orders.(*Service).Find(0xc000012345, 0x4d2)
+	/app/orders/service.go:42 +0x1d
+main.handler(...)
+	/app/main.go:17
+net/http.HandlerFunc.ServeHTTP(0xc0000a2000?, {0x7f8, 0xc0000b4000}, 0xc0000c6000)
+	/usr/local/go/src/net/http/server.go:2171 +0x29
+created by net/http.(*Server).Serve in goroutine 1
+	/usr/local/go/src/net/http/server.go:3285 +0x4b4`
+)
+
+func TestNormalizeMessage(t *testing.T) {
+	for _, tc := range []struct {
+		message string
+		want    string
+	}{
+		{message: "order 1234 not found", want: "order <num> not found"},
+		{message: "took 1.5s", want: "took <num>s"},
+		{message: "user 3f2504e0-4f89-11d3-9a0c-0305e82c3301 is locked", want: "user <uuid> is locked"},
+		{message: "nil pointer at 0xc000012345", want: "nil pointer at <hex>"},
+		{message: "commit 9fceb02d0ae598e95dc970b74767f19372d61af8 missing", want: "commit <hex> missing"},
+		{message: "request 12345678 failed", want: "request <num> failed"},
+		{message: "connection refused", want: "connection refused"},
+	} {
+		t.Run(tc.message, func(t *testing.T) {
+			assert.Equal(t, tc.want, normalizeMessage(tc.message))
+		})
+	}
+}
+
+func TestParseStacktrace(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		stacktrace string
+		want       []stackFrame
+	}{
+		{
+			name:       "java",
+			stacktrace: javaStacktrace,
+			want: []stackFrame{
+				{platform: platformAt, module: "com.example.orders.OrderService", function: "find"},
+				{platform: platformAt, module: "com.example.orders.OrderController", function: "get"},
+				{platform: platformAt, module: "jdk.internal.reflect.NativeMethodAccessorImpl", function: "invoke0"},
+				{platform: platformAt, module: "org.springframework.web.servlet.FrameworkServlet", function: "service"},
+			},
+		},
+		{
+			name:       "dotnet",
+			stacktrace: dotnetStacktrace,
+			want: []stackFrame{
+				{platform: platformAt, module: "Example.Orders.OrderService", function: "Find"},
+				{platform: platformAt, module: "Example.Orders.OrderController", function: "Get"},
+				{platform: platformAt, module: "System.Threading.Tasks.Task", function: "Execute"},
+			},
+		},
+		{
+			name:       "python",
+			stacktrace: pythonStacktrace,
+			want: []stackFrame{
+				{platform: platformPython, module: "/app/orders/service.py", function: "find"},
+				{platform: platformPython, module: "/app/orders/views.py", function: "get"},
+				{platform: platformPython, module: "/usr/lib/python3.12/site-packages/flask/app.py", function: "full_dispatch_request"},
+			},
+		},
+		{
+			name:       "javascript",
+			stacktrace: jsStacktrace,
+			want: []stackFrame{
+				{platform: platformJavaScript, module: "/app/src/orders/service.js", function: "OrderService.find"},
+				{platform: platformJavaScript, module: "/app/src/orders/controller.js"},
+				{platform: platformJavaScript, module: "/app/node_modules/express/lib/router/layer.js", function: "Layer.handle [as handle_request]"},
+				{platform: platformJavaScript, module: "node:internal/process/task_queues", function: "process.processTicksAndRejections"},
+			},
+		},
+		{
+			name:       "go",
+			stacktrace: goStacktrace,
+			want: []stackFrame{
+				{platform: platformGo, module: "github.com/example/orders", function: "(*Service).Find"},
+				{platform: platformGo, module: "main", function: "handler"},
+				{platform: platformGo, module: "net/http", function: "HandlerFunc.ServeHTTP"},
+				{platform: platformGo, module: "net/http", function: "(*Server).Serve"},
+			},
+		},
+		{
+			name:       "unknown",
+			stacktrace: "Exception stacktrace",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, parseStacktrace(tc.stacktrace))
+		})
+	}
+}
+
+func TestTopFrames(t *testing.T) {
+	f := newFingerprinter(Fingerprint{MaxFrames: 1})
+	assert.Equal(t, []stackFrame{
+		{platform: platformPython, module: "/app/orders/service.py", function: "find"},
+	}, f.topFrames(parseStacktrace(pythonStacktrace)))
+
+	f = newFingerprinter(Fingerprint{MaxFrames: 5})
+	assert.Equal(t, []stackFrame{
+		{platform: platformJavaScript, module: "/app/src/orders/service.js", function: "OrderService.find"},
+		{platform: platformJavaScript, module: "/app/src/orders/controller.js"},
+	}, f.topFrames(parseStacktrace(jsStacktrace)))
+	assert.Equal(t, []stackFrame{
+		{platform: platformGo, module: "github.com/example/orders", function: "(*Service).Find"},
+		{platform: platformGo, module: "main", function: "handler"},
+	}, f.topFrames(parseStacktrace(goStacktrace)))
+
+	f = newFingerprinter(Fingerprint{MaxFrames: 5, InAppPrefixes: []string{"com.example.orders.OrderController"}})
+	assert.Equal(t, []stackFrame{
+		{platform: platformAt, module: "com.example.orders.OrderController", function: "get"},
+	}, f.topFrames(parseStacktrace(javaStacktrace)))
+
+	// Without in-app frames, the innermost frames are used.
+	f = newFingerprinter(Fingerprint{MaxFrames: 1, InAppPrefixes: []string{"org.example."}})
+	assert.Equal(t, []stackFrame{
+		{platform: platformAt, module: "com.example.orders.OrderService", function: "find"},
+	}, f.topFrames(parseStacktrace(javaStacktrace)))
+}
+
+func TestFingerprint(t *testing.T) {
+	f := newFingerprinter(Fingerprint{MaxFrames: defaultMaxFrames})
+
+	fingerprint, normalized := f.fingerprint("IllegalStateException", "order 1234 not found", javaStacktrace)
+	assert.Len(t, fingerprint, 16)
+	assert.Equal(t, "order <num> not found", normalized)
+
+	// Variable parts of the message, line numbers and library frames do not change the fingerprint.
+	other, _ := f.fingerprint("IllegalStateException", "order 5678 not found", `java.lang.IllegalStateException: order 5678 not found
+	at com.example.orders.OrderService.find(OrderService.java:45)
+	at com.example.orders.OrderController.get(OrderController.java:20)
+	at java.base/java.lang.reflect.Method.invoke(Method.java:568)
+	at org.springframework.web.servlet.FrameworkServlet.service(FrameworkServlet.java:900)`)
+	assert.Equal(t, fingerprint, other)
+
+	other, _ = f.fingerprint("IllegalArgumentException", "order 1234 not found", javaStacktrace)
+	assert.NotEqual(t, fingerprint, other)
+
+	other, _ = f.fingerprint("IllegalStateException", "order 1234 not found", `java.lang.IllegalStateException: order 1234 not found
+	at com.example.orders.OrderService.findAll(OrderService.java:42)
+	at com.example.orders.OrderController.get(OrderController.java:17)`)
+	assert.NotEqual(t, fingerprint, other)
+}
+
+func TestFingerprintCache(t *testing.T) {
+	c := newFingerprintCache(1)
+
+	first, last := c.observe("a", 20)
+	assert.Equal(t, pcommon.Timestamp(20), first)
+	assert.Equal(t, pcommon.Timestamp(20), last)
+
+	first, last = c.observe("a", 30)
+	assert.Equal(t, pcommon.Timestamp(20), first)
+	assert.Equal(t, pcommon.Timestamp(30), last)
+
+	// Out of order occurrences
+	first, last = c.observe("a", 10)
+	assert.Equal(t, pcommon.Timestamp(10), first)
+	assert.Equal(t, pcommon.Timestamp(30), last)
+
+	// "b" evicts "a", which is seen for the first time again afterwards.
+	c.observe("b", 40)
+	first, last = c.observe("a", 50)
+	assert.Equal(t, pcommon.Timestamp(50), first)
+	assert.Equal(t, pcommon.Timestamp(50), last)
+}
@@ -3,6 +3,7 @@ module github.com/open-telemetry/opentelemetry-collector-contrib/connector/excep
 go 1.22.0
 
 require (
+	github.com/hashicorp/golang-lru/v2 v2.0.7
 	github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal v0.111.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/internal/pdatautil v0.111.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/pkg/golden v0.111.0
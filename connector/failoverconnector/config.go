@@ -7,12 +7,14 @@ import (
 	"errors"
 	"time"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pipeline"
 )
 
 var (
 	errNoPipelinePriority    = errors.New("No pipelines are defined in the priority list")
 	errInvalidRetryIntervals = errors.New("Retry interval must be positive, and retry_interval must be greater than retry_gap times the length of the priority list")
+	errInvalidProbePercent   = errors.New("Probe percentage must be between 1 and 100")
 )
 
 type Config struct {
@@ -33,6 +35,15 @@ type Config struct {
 	// MaxRetry is the maximum retries per level, once this limit is hit for a level, even if the next pipeline level fails,
 	// it will not try to recover the level that exceeded the maximum retries
 	MaxRetries int `mapstructure:"max_retries"`
+
+	// StatusExtension is the extension relaying the component status events, e.g. healthcheckv2. When set, a
+	// level fails over as soon as a component in one of its pipelines reports an error status
+	StatusExtension *component.ID `mapstructure:"status_extension"`
+
+	// ProbePercentage is the percentage of data routed to a higher priority level while it is being retried, the
+	// remaining data keeps being routed to the stable level until the retried level proves to be healthy. It
+	// cannot be 0, since a retried level is only confirmed healthy by data it consumes successfully
+	ProbePercentage int `mapstructure:"probe_percentage"`
 }
 
 // Validate needs to ensure RetryInterval > # elements in PriorityList * RetryGap
@@ -44,5 +55,8 @@ func (c *Config) Validate() error {
 	if c.RetryGap <= 0 || c.RetryInterval <= 0 || c.RetryInterval <= retryTime {
 		return errInvalidRetryIntervals
 	}
+	if c.ProbePercentage <= 0 || c.ProbePercentage > 100 {
+		return errInvalidProbePercent
+	}
 	return nil
 }
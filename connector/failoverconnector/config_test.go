@@ -30,9 +30,10 @@ func TestLoadConfig(t *testing.T) {
 						pipeline.NewIDWithName(pipeline.SignalTraces, ""),
 					},
 				},
-				RetryInterval: 10 * time.Minute,
-				RetryGap:      30 * time.Second,
-				MaxRetries:    10,
+				RetryInterval:   10 * time.Minute,
+				RetryGap:        30 * time.Second,
+				MaxRetries:      10,
+				ProbePercentage: 100,
 			},
 		},
 		{
@@ -53,9 +54,14 @@ func TestLoadConfig(t *testing.T) {
 						pipeline.NewIDWithName(pipeline.SignalTraces, "fourth"),
 					},
 				},
-				RetryInterval: 5 * time.Minute,
-				RetryGap:      time.Minute,
-				MaxRetries:    10,
+				RetryInterval:   5 * time.Minute,
+				RetryGap:        time.Minute,
+				MaxRetries:      10,
+				ProbePercentage: 25,
+				StatusExtension: func() *component.ID {
+					id := component.MustNewID("healthcheckv2")
+					return &id
+				}(),
 			},
 		},
 	}
@@ -94,6 +100,16 @@ func TestValidateConfig(t *testing.T) {
 			id:   component.NewIDWithName(metadata.Type, "invalid"),
 			err:  errInvalidRetryIntervals,
 		},
+		{
+			name: "probe percentage out of range",
+			id:   component.NewIDWithName(metadata.Type, "invalid_probe_percentage"),
+			err:  errInvalidProbePercent,
+		},
+		{
+			name: "zero probe percentage",
+			id:   component.NewIDWithName(metadata.Type, "zero_probe_percentage"),
+			err:  errInvalidProbePercent,
+		},
 	}
 
 	for _, tc := range testcases {
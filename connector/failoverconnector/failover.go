@@ -4,11 +4,20 @@
 package failoverconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/failoverconnector"
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"math/rand/v2"
 	"sync"
 
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
 	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/failoverconnector/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/failoverconnector/internal/state"
 )
 
@@ -20,16 +29,37 @@ type failoverRouter[C any] struct {
 	pS               *state.PipelineSelector
 	wg               *sync.WaitGroup
 	consumers        []C
+	telemetry        *metadata.TelemetryBuilder
+	signalAttr       metric.RecordOption
+	logger           *zap.Logger
+
+	// statusLock guards failingComponents, which tracks per priority level the components that
+	// reported an error status
+	statusLock        sync.Mutex
+	failingComponents []map[componentKey]struct{}
+	unsubscribe       func()
 
 	done chan struct{}
 }
 
+type componentKey struct {
+	id   component.ID
+	kind component.Kind
+}
+
+// statusSource is implemented by extensions relaying the component status events they watch, like the
+// healthcheckv2 extension
+type statusSource interface {
+	SubscribeStatus(func(*componentstatus.InstanceID, *componentstatus.Event)) func()
+}
+
 var (
 	errNoValidPipeline = errors.New("All provided pipelines return errors")
 	errConsumer        = errors.New("Error registering consumer")
+	errNoStatusSource  = errors.New("extension does not relay component status events")
 )
 
-func newFailoverRouter[C any](provider consumerProvider[C], cfg *Config) *failoverRouter[C] {
+func newFailoverRouter[C any](provider consumerProvider[C], cfg *Config, set component.TelemetrySettings, signal pipeline.Signal) (*failoverRouter[C], error) {
 	var wg sync.WaitGroup
 	done := make(chan struct{})
 	pSConstants := state.PSConstants{
@@ -38,26 +68,120 @@ func newFailoverRouter[C any](provider consumerProvider[C], cfg *Config) *failov
 		MaxRetries:    cfg.MaxRetries,
 	}
 
+	telemetry, err := metadata.NewTelemetryBuilder(set)
+	if err != nil {
+		return nil, err
+	}
+
+	failingComponents := make([]map[componentKey]struct{}, len(cfg.PipelinePriority))
+	for i := range failingComponents {
+		failingComponents[i] = make(map[componentKey]struct{})
+	}
+
 	selector := state.NewPipelineSelector(len(cfg.PipelinePriority), pSConstants)
 	selector.Start(done, &wg)
 	return &failoverRouter[C]{
-		consumerProvider: provider,
-		cfg:              cfg,
-		pS:               selector,
-		done:             done,
-		wg:               &wg,
+		consumerProvider:  provider,
+		cfg:               cfg,
+		pS:                selector,
+		telemetry:         telemetry,
+		signalAttr:        metric.WithAttributeSet(attribute.NewSet(attribute.String("signal", signal.String()))),
+		logger:            set.Logger,
+		failingComponents: failingComponents,
+		done:              done,
+		wg:                &wg,
+	}, nil
+}
+
+// Start subscribes to the component status events relayed by the configured status extension
+func (f *failoverRouter[C]) Start(host component.Host) error {
+	if f.cfg.StatusExtension == nil {
+		return nil
+	}
+	ext, ok := host.GetExtensions()[*f.cfg.StatusExtension]
+	if !ok {
+		return fmt.Errorf("status extension %q not found", f.cfg.StatusExtension)
+	}
+	source, ok := ext.(statusSource)
+	if !ok {
+		return fmt.Errorf("status extension %q: %w", f.cfg.StatusExtension, errNoStatusSource)
+	}
+	f.unsubscribe = source.SubscribeStatus(f.componentStatusChanged)
+	return nil
+}
+
+// componentStatusChanged updates the health of the priority levels whose pipelines contain the
+// component that reported the status event
+func (f *failoverRouter[C]) componentStatusChanged(source *componentstatus.InstanceID, event *componentstatus.Event) {
+	switch {
+	case componentstatus.StatusIsError(event.Status()), event.Status() == componentstatus.StatusOK:
+	default:
+		return
+	}
+
+	key := componentKey{id: source.ComponentID(), kind: source.Kind()}
+	f.statusLock.Lock()
+	defer f.statusLock.Unlock()
+	for idx, pipelines := range f.cfg.PipelinePriority {
+		if !containsAnyPipeline(source, pipelines) {
+			continue
+		}
+		if event.Status() == componentstatus.StatusOK {
+			delete(f.failingComponents[idx], key)
+		} else {
+			f.failingComponents[idx][key] = struct{}{}
+		}
+		f.pS.ReportHealth(idx, len(f.failingComponents[idx]) == 0)
 	}
 }
 
-func (f *failoverRouter[C]) getCurrentConsumer() (C, chan bool, bool) {
+func containsAnyPipeline(source *componentstatus.InstanceID, pipelines []pipeline.ID) bool {
+	found := false
+	source.AllPipelineIDs(func(id pipeline.ID) bool {
+		for _, pipelineID := range pipelines {
+			if id == pipelineID {
+				found = true
+				return false
+			}
+		}
+		return true
+	})
+	return found
+}
+
+func (f *failoverRouter[C]) getCurrentConsumer(ctx context.Context) (C, chan bool, bool) {
 	var nilConsumer C
 	pl, ch := f.pS.SelectedPipeline()
 	if pl >= len(f.cfg.PipelinePriority) {
 		return nilConsumer, nil, false
 	}
+	if f.shouldRouteToStable(pl) {
+		pl, ch = f.pS.StablePipeline()
+	}
+	f.telemetry.ConnectorFailoverActiveLevel.Record(ctx, int64(pl), f.signalAttr)
 	return f.consumers[pl], ch, true
 }
 
+// isLastLevel reports whether ch belongs to the lowest priority level, which is kept once it fails rather
+// than failing over past it
+func (f *failoverRouter[C]) isLastLevel(ch chan bool) bool {
+	_, last := f.pS.LastPipeline()
+	return ch == last
+}
+
+// shouldRouteToStable decides whether data should keep going to the stable level while the selected
+// level is a higher priority level being retried, based on the configured probe percentage
+func (f *failoverRouter[C]) shouldRouteToStable(selected int) bool {
+	if f.cfg.ProbePercentage <= 0 || f.cfg.ProbePercentage >= 100 {
+		return false
+	}
+	stable, _ := f.pS.StablePipeline()
+	if selected >= stable || stable >= len(f.cfg.PipelinePriority) {
+		return false
+	}
+	return rand.IntN(100) >= f.cfg.ProbePercentage
+}
+
 func (f *failoverRouter[C]) registerConsumers() error {
 	consumers := make([]C, 0)
 	for _, pipelines := range f.cfg.PipelinePriority {
@@ -72,10 +196,14 @@ func (f *failoverRouter[C]) registerConsumers() error {
 }
 
 func (f *failoverRouter[C]) Shutdown() {
-	f.pS.RS.InvokeCancel()
+	if f.unsubscribe != nil {
+		f.unsubscribe()
+	}
 
+	// Stop handling pipeline errors first, so that no retry is started after it is canceled
 	close(f.done)
 	f.wg.Wait()
+	f.pS.RS.InvokeCancel()
 }
 
 // For Testing
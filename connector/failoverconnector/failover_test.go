@@ -9,11 +9,16 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/connector"
 	"go.opentelemetry.io/collector/connector/connectortest"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 )
 
 func TestFailoverRecovery(t *testing.T) {
@@ -217,6 +222,244 @@ func TestFailoverRecovery_MaxRetries(t *testing.T) {
 
 }
 
+type statusExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+	watcher func(*componentstatus.InstanceID, *componentstatus.Event)
+}
+
+func (e *statusExtension) SubscribeStatus(fn func(*componentstatus.InstanceID, *componentstatus.Event)) func() {
+	e.watcher = fn
+	return func() { e.watcher = nil }
+}
+
+type statusHost struct {
+	component.Host
+	extensions map[component.ID]component.Component
+}
+
+func (h *statusHost) GetExtensions() map[component.ID]component.Component {
+	return h.extensions
+}
+
+func TestFailoverComponentStatus(t *testing.T) {
+	var sinkFirst, sinkSecond consumertest.TracesSink
+	tracesFirst := pipeline.NewIDWithName(pipeline.SignalTraces, "first")
+	tracesSecond := pipeline.NewIDWithName(pipeline.SignalTraces, "second")
+	extID := component.MustNewID("healthcheckv2")
+
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.PipelinePriority = [][]pipeline.ID{{tracesFirst}, {tracesSecond}}
+	cfg.RetryInterval = 50 * time.Millisecond
+	cfg.RetryGap = 10 * time.Millisecond
+	cfg.MaxRetries = 10000
+	cfg.StatusExtension = &extID
+
+	router := connector.NewTracesRouter(map[pipeline.ID]consumer.Traces{
+		tracesFirst:  &sinkFirst,
+		tracesSecond: &sinkSecond,
+	})
+
+	conn, err := NewFactory().CreateTracesToTraces(context.Background(),
+		connectortest.NewNopSettings(), cfg, router.(consumer.Traces))
+	require.NoError(t, err)
+
+	ext := &statusExtension{}
+	host := &statusHost{Host: componenttest.NewNopHost(), extensions: map[component.ID]component.Component{extID: ext}}
+	failoverConnector := conn.(*tracesFailover)
+	require.NoError(t, failoverConnector.Start(context.Background(), host))
+	require.NotNil(t, ext.watcher)
+
+	tr := sampleTrace()
+	exporterFirst := componentstatus.NewInstanceID(component.MustNewID("otlp"), component.KindExporter, tracesFirst)
+	exporterUnrelated := componentstatus.NewInstanceID(component.MustNewID("debug"), component.KindExporter, pipeline.NewID(pipeline.SignalLogs))
+
+	ext.watcher(exporterUnrelated, componentstatus.NewRecoverableErrorEvent(errTracesConsumer))
+	require.True(t, consumeTracesAndCheckStable(failoverConnector, 0, tr))
+
+	ext.watcher(exporterFirst, componentstatus.NewRecoverableErrorEvent(errTracesConsumer))
+	require.Eventually(t, func() bool {
+		return consumeTracesAndCheckStable(failoverConnector, 1, tr)
+	}, 3*time.Second, 5*time.Millisecond)
+
+	// The level keeps being skipped by retries while its exporter reports an error.
+	require.Never(t, func() bool {
+		idx, _ := failoverConnector.failover.pS.SelectedPipeline()
+		return idx == 0
+	}, 200*time.Millisecond, 5*time.Millisecond)
+
+	ext.watcher(exporterFirst, componentstatus.NewEvent(componentstatus.StatusOK))
+	require.Eventually(t, func() bool {
+		return consumeTracesAndCheckStable(failoverConnector, 0, tr)
+	}, 3*time.Second, 5*time.Millisecond)
+
+	assert.NoError(t, failoverConnector.Shutdown(context.Background()))
+	assert.Nil(t, ext.watcher)
+}
+
+func TestFailoverStatusExtensionNotFound(t *testing.T) {
+	extID := component.MustNewID("healthcheckv2")
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.PipelinePriority = [][]pipeline.ID{{pipeline.NewID(pipeline.SignalTraces)}}
+	cfg.StatusExtension = &extID
+
+	router := connector.NewTracesRouter(map[pipeline.ID]consumer.Traces{
+		pipeline.NewID(pipeline.SignalTraces): consumertest.NewNop(),
+	})
+
+	conn, err := NewFactory().CreateTracesToTraces(context.Background(),
+		connectortest.NewNopSettings(), cfg, router.(consumer.Traces))
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, conn.Shutdown(context.Background()))
+	}()
+
+	assert.EqualError(t, conn.Start(context.Background(), componenttest.NewNopHost()), `status extension "healthcheckv2" not found`)
+
+	nopExtension := struct {
+		component.StartFunc
+		component.ShutdownFunc
+	}{}
+	host := &statusHost{Host: componenttest.NewNopHost(), extensions: map[component.ID]component.Component{extID: nopExtension}}
+	assert.ErrorIs(t, conn.Start(context.Background(), host), errNoStatusSource)
+}
+
+func TestFailoverStaysOnLastLevel(t *testing.T) {
+	var sinkSecond consumertest.TracesSink
+	tracesFirst := pipeline.NewIDWithName(pipeline.SignalTraces, "first")
+	tracesSecond := pipeline.NewIDWithName(pipeline.SignalTraces, "second")
+
+	cfg := &Config{
+		PipelinePriority: [][]pipeline.ID{{tracesFirst}, {tracesSecond}},
+		RetryInterval:    5 * time.Minute,
+		RetryGap:         time.Minute,
+		MaxRetries:       10000,
+	}
+
+	router := connector.NewTracesRouter(map[pipeline.ID]consumer.Traces{
+		tracesFirst:  consumertest.NewErr(errTracesConsumer),
+		tracesSecond: consumertest.NewErr(errTracesConsumer),
+	})
+
+	conn, err := NewFactory().CreateTracesToTraces(context.Background(),
+		connectortest.NewNopSettings(), cfg, router.(consumer.Traces))
+	require.NoError(t, err)
+
+	failoverConnector := conn.(*tracesFailover)
+	defer func() {
+		assert.NoError(t, failoverConnector.Shutdown(context.Background()))
+	}()
+
+	tr := sampleTrace()
+	for i := 0; i < 5; i++ {
+		assert.ErrorIs(t, conn.ConsumeTraces(context.Background(), tr), errNoValidPipeline)
+	}
+	require.Eventually(t, func() bool {
+		idx, _ := failoverConnector.failover.pS.SelectedPipeline()
+		return idx == 1
+	}, 3*time.Second, 5*time.Millisecond)
+
+	// Data is routed to the last level again as soon as it recovers.
+	failoverConnector.failover.ModifyConsumerAtIndex(1, &sinkSecond)
+	require.NoError(t, conn.ConsumeTraces(context.Background(), tr))
+	assert.Equal(t, 1, sinkSecond.SpanCount())
+}
+
+func TestFailoverProbePercentage(t *testing.T) {
+	tracesFirst := pipeline.NewIDWithName(pipeline.SignalTraces, "traces/first")
+	tracesSecond := pipeline.NewIDWithName(pipeline.SignalTraces, "traces/second")
+
+	cfg := &Config{
+		PipelinePriority: [][]pipeline.ID{{tracesFirst}, {tracesSecond}},
+		RetryInterval:    50 * time.Millisecond,
+		RetryGap:         10 * time.Millisecond,
+		MaxRetries:       10000,
+		ProbePercentage:  50,
+	}
+
+	router := connector.NewTracesRouter(map[pipeline.ID]consumer.Traces{
+		tracesFirst:  consumertest.NewNop(),
+		tracesSecond: consumertest.NewNop(),
+	})
+
+	conn, err := NewFactory().CreateTracesToTraces(context.Background(),
+		connectortest.NewNopSettings(), cfg, router.(consumer.Traces))
+	require.NoError(t, err)
+
+	failoverConnector := conn.(*tracesFailover)
+	defer func() {
+		assert.NoError(t, failoverConnector.Shutdown(context.Background()))
+	}()
+
+	// Level 0 is being retried while level 1 is stable.
+	failoverConnector.failover.pS.TestSetStableIndex(1)
+
+	var toStable, toProbe int
+	for i := 0; i < 1000; i++ {
+		if failoverConnector.failover.shouldRouteToStable(0) {
+			toStable++
+		} else {
+			toProbe++
+		}
+	}
+	assert.Positive(t, toStable)
+	assert.Positive(t, toProbe)
+	assert.False(t, failoverConnector.failover.shouldRouteToStable(1))
+
+	failoverConnector.failover.cfg.ProbePercentage = 100
+	assert.False(t, failoverConnector.failover.shouldRouteToStable(0))
+}
+
+func TestFailoverActiveLevelTelemetry(t *testing.T) {
+	tt := setupTestTelemetry()
+	defer func() {
+		assert.NoError(t, tt.Shutdown(context.Background()))
+	}()
+
+	tracesFirst := pipeline.NewIDWithName(pipeline.SignalTraces, "traces/first")
+	tracesSecond := pipeline.NewIDWithName(pipeline.SignalTraces, "traces/second")
+
+	cfg := &Config{
+		PipelinePriority: [][]pipeline.ID{{tracesFirst}, {tracesSecond}},
+		RetryInterval:    5 * time.Minute,
+		RetryGap:         time.Minute,
+		MaxRetries:       10000,
+	}
+
+	router := connector.NewTracesRouter(map[pipeline.ID]consumer.Traces{
+		tracesFirst:  consumertest.NewErr(errTracesConsumer),
+		tracesSecond: consumertest.NewNop(),
+	})
+
+	conn, err := NewFactory().CreateTracesToTraces(context.Background(), tt.NewSettings(), cfg, router.(consumer.Traces))
+	require.NoError(t, err)
+
+	failoverConnector := conn.(*tracesFailover)
+	defer func() {
+		assert.NoError(t, failoverConnector.Shutdown(context.Background()))
+	}()
+
+	require.Eventually(t, func() bool {
+		return consumeTracesAndCheckStable(failoverConnector, 1, sampleTrace())
+	}, 3*time.Second, 5*time.Millisecond)
+
+	tt.assertMetrics(t, []metricdata.Metrics{
+		{
+			Name:        "otelcol_connector_failover_active_level",
+			Description: "Index of the priority level data is currently routed to, starting from 0 for the highest priority level",
+			Unit:        "1",
+			Data: metricdata.Gauge[int64]{
+				DataPoints: []metricdata.DataPoint[int64]{
+					{
+						Value:      1,
+						Attributes: attribute.NewSet(attribute.String("signal", "traces")),
+					},
+				},
+			},
+		},
+	})
+}
+
 func resetConsumers(conn *tracesFailover, consumers ...consumer.Traces) {
 	for i, sink := range consumers {
 
@@ -16,6 +16,7 @@ type PipelineSelector struct {
 	currentIndex    atomic.Int32
 	stableIndex     atomic.Int32
 	pipelineRetries []atomic.Int32
+	unhealthy       []atomic.Bool
 	constants       PSConstants
 	RS              *RetryState
 
@@ -76,9 +77,11 @@ func (p *PipelineSelector) updatePipelineIndex(idx int) {
 	p.setToStableIndex(idx)
 }
 
-// NextPipeline skips through any lower priority pipelines that have exceeded their maxRetries
+// NextPipeline skips through any lower priority pipelines that have exceeded their maxRetries or are reported unhealthy,
+// but never past the last priority level, which keeps being selected even when it fails
 func (p *PipelineSelector) setToNextPriorityPipeline(idx int) {
-	for ok := true; ok; ok = p.exceededMaxRetries(idx) {
+	last := len(p.chans) - 1
+	for ok := idx < last; ok; ok = idx < last && (p.exceededMaxRetries(idx) || p.isUnhealthy(idx)) {
 		idx++
 	}
 	p.stableIndex.Store(int32(idx))
@@ -93,7 +96,7 @@ func (p *PipelineSelector) retryHighPriorityPipelines(ctx context.Context, retry
 	defer ticker.Stop()
 
 	for i := 0; i < len(p.pipelineRetries); i++ {
-		if p.maxRetriesUsed(i) {
+		if p.maxRetriesUsed(i) || p.isUnhealthy(i) {
 			continue
 		}
 		select {
@@ -152,6 +155,10 @@ func (p *PipelineSelector) loadCurrent() int {
 	return int(p.currentIndex.Load())
 }
 
+func (p *PipelineSelector) isUnhealthy(idx int) bool {
+	return idx < len(p.unhealthy) && p.unhealthy[idx].Load()
+}
+
 func (p *PipelineSelector) loadRetryCount(idx int) int {
 	return int(p.pipelineRetries[idx].Load())
 }
@@ -181,6 +188,7 @@ func NewPipelineSelector(lenPriority int, consts PSConstants) *PipelineSelector
 
 	ps := &PipelineSelector{
 		pipelineRetries: make([]atomic.Int32, lenPriority),
+		unhealthy:       make([]atomic.Bool, lenPriority),
 		constants:       consts,
 		RS:              &RetryState{},
 		errTryLock:      NewTryLock(),
@@ -217,13 +225,40 @@ func (p *PipelineSelector) ListenToChannels(done chan struct{}, wg *sync.WaitGro
 }
 
 func (p *PipelineSelector) SelectedPipeline() (int, chan bool) {
-	idx := p.loadCurrent()
+	return p.pipelineAt(p.loadCurrent())
+}
+
+// StablePipeline returns the stable priority level, which differs from the selected one while a higher
+// priority level is being retried
+func (p *PipelineSelector) StablePipeline() (int, chan bool) {
+	return p.pipelineAt(p.loadStable())
+}
+
+func (p *PipelineSelector) pipelineAt(idx int) (int, chan bool) {
 	if idx < len(p.chans) {
 		return idx, p.chans[idx]
 	}
 	return idx, nil
 }
 
+// LastPipeline returns the lowest priority level, there is no level left to fail over to once it fails
+func (p *PipelineSelector) LastPipeline() (int, chan bool) {
+	return p.pipelineAt(len(p.chans) - 1)
+}
+
+// ReportHealth records the health of a priority level as reported by a signal other than the result of
+// consuming data, e.g. component status events. An unhealthy level is treated as failed and is skipped
+// by retries until it is reported healthy again
+func (p *PipelineSelector) ReportHealth(idx int, healthy bool) {
+	if idx >= len(p.unhealthy) {
+		return
+	}
+	if p.unhealthy[idx].Swap(!healthy) == !healthy || healthy {
+		return
+	}
+	p.errTryLock.Execute(p.handlePipelineError, idx)
+}
+
 // For Testing
 func (p *PipelineSelector) ChannelIndex(ch chan bool) int {
 	for i, ch1 := range p.chans {
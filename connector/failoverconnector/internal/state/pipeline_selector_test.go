@@ -53,6 +53,28 @@ func TestHandlePipelineError(t *testing.T) {
 	}, 3*time.Minute, 5*time.Millisecond)
 }
 
+func TestNextPipelineStopsAtLastPipeline(t *testing.T) {
+	constants := PSConstants{
+		RetryInterval: 50 * time.Millisecond,
+		RetryGap:      10 * time.Millisecond,
+		MaxRetries:    1000,
+	}
+	pS := NewPipelineSelector(3, constants)
+
+	pS.unhealthy[1].Store(true)
+	pS.unhealthy[2].Store(true)
+	pS.setToNextPriorityPipeline(0)
+	require.Equal(t, 2, pS.TestCurrentIndex())
+
+	pS.setToNextPriorityPipeline(2)
+	require.Equal(t, 2, pS.TestCurrentIndex())
+	require.Equal(t, 2, pS.TestStableIndex())
+
+	idx, ch := pS.LastPipeline()
+	require.Equal(t, 2, idx)
+	require.Equal(t, 2, pS.ChannelIndex(ch))
+}
+
 func TestCurrentPipelineWithRetry(t *testing.T) {
 	constants := PSConstants{
 		RetryInterval: 50 * time.Millisecond,
@@ -26,6 +26,13 @@ func (t *TryLock) TryExecute(fn func(int), arg int) {
 	}
 }
 
+// Execute waits for the lock to be available, unlike TryExecute which skips fn if the lock is held
+func (t *TryLock) Execute(fn func(int), arg int) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	fn(arg)
+}
+
 func NewTryLock() *TryLock {
 	return &TryLock{}
 }
@@ -11,11 +11,11 @@ import (
 	"go.opentelemetry.io/collector/connector"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pipeline"
 	"go.uber.org/zap"
 )
 
 type logsFailover struct {
-	component.StartFunc
 	component.ShutdownFunc
 
 	config   *Config
@@ -29,7 +29,7 @@ func (f *logsFailover) Capabilities() consumer.Capabilities {
 
 // ConsumeLogs will try to export to the current set priority level and handle failover in the case of an error
 func (f *logsFailover) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
-	tc, ch, ok := f.failover.getCurrentConsumer()
+	tc, ch, ok := f.failover.getCurrentConsumer(ctx)
 	if !ok {
 		return errNoValidPipeline
 	}
@@ -43,10 +43,13 @@ func (f *logsFailover) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
 
 // FailoverLogs is the function responsible for handling errors returned by the nextConsumer
 func (f *logsFailover) FailoverLogs(ctx context.Context, ld plog.Logs) error {
-	for tc, ch, ok := f.failover.getCurrentConsumer(); ok; tc, ch, ok = f.failover.getCurrentConsumer() {
+	for tc, ch, ok := f.failover.getCurrentConsumer(ctx); ok; tc, ch, ok = f.failover.getCurrentConsumer(ctx) {
 		err := tc.ConsumeLogs(ctx, ld)
 		if err != nil {
 			ch <- false
+			if f.failover.isLastLevel(ch) {
+				break
+			}
 			continue
 		}
 		ch <- true
@@ -56,6 +59,10 @@ func (f *logsFailover) FailoverLogs(ctx context.Context, ld plog.Logs) error {
 	return errNoValidPipeline
 }
 
+func (f *logsFailover) Start(_ context.Context, host component.Host) error {
+	return f.failover.Start(host)
+}
+
 func (f *logsFailover) Shutdown(_ context.Context) error {
 	if f.failover != nil {
 		f.failover.Shutdown()
@@ -70,8 +77,11 @@ func newLogsToLogs(set connector.Settings, cfg component.Config, logs consumer.L
 		return nil, errors.New("consumer is not of type LogsRouter")
 	}
 
-	failover := newFailoverRouter[consumer.Logs](lr.Consumer, config)
-	err := failover.registerConsumers()
+	failover, err := newFailoverRouter[consumer.Logs](lr.Consumer, config, set.TelemetrySettings, pipeline.SignalLogs)
+	if err != nil {
+		return nil, err
+	}
+	err = failover.registerConsumers()
 	if err != nil {
 		return nil, err
 	}
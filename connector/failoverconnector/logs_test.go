@@ -50,7 +50,7 @@ func TestLogsRegisterConsumers(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, conn)
 
-	lc, _, ok := failoverConnector.failover.getCurrentConsumer()
+	lc, _, ok := failoverConnector.failover.getCurrentConsumer(context.Background())
 	lc1 := failoverConnector.failover.GetConsumerAtIndex(1)
 	lc2 := failoverConnector.failover.GetConsumerAtIndex(2)
 
@@ -11,11 +11,11 @@ import (
 	"go.opentelemetry.io/collector/connector"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pipeline"
 	"go.uber.org/zap"
 )
 
 type metricsFailover struct {
-	component.StartFunc
 	component.ShutdownFunc
 
 	config   *Config
@@ -29,7 +29,7 @@ func (f *metricsFailover) Capabilities() consumer.Capabilities {
 
 // ConsumeMetrics will try to export to the current set priority level and handle failover in the case of an error
 func (f *metricsFailover) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
-	tc, ch, ok := f.failover.getCurrentConsumer()
+	tc, ch, ok := f.failover.getCurrentConsumer(ctx)
 	if !ok {
 		return errNoValidPipeline
 	}
@@ -43,10 +43,13 @@ func (f *metricsFailover) ConsumeMetrics(ctx context.Context, md pmetric.Metrics
 
 // FailoverMetrics is the function responsible for handling errors returned by the nextConsumer
 func (f *metricsFailover) FailoverMetrics(ctx context.Context, md pmetric.Metrics) error {
-	for tc, ch, ok := f.failover.getCurrentConsumer(); ok; tc, ch, ok = f.failover.getCurrentConsumer() {
+	for tc, ch, ok := f.failover.getCurrentConsumer(ctx); ok; tc, ch, ok = f.failover.getCurrentConsumer(ctx) {
 		err := tc.ConsumeMetrics(ctx, md)
 		if err != nil {
 			ch <- false
+			if f.failover.isLastLevel(ch) {
+				break
+			}
 			continue
 		}
 		ch <- true
@@ -56,6 +59,10 @@ func (f *metricsFailover) FailoverMetrics(ctx context.Context, md pmetric.Metric
 	return errNoValidPipeline
 }
 
+func (f *metricsFailover) Start(_ context.Context, host component.Host) error {
+	return f.failover.Start(host)
+}
+
 func (f *metricsFailover) Shutdown(_ context.Context) error {
 	if f.failover != nil {
 		f.failover.Shutdown()
@@ -70,8 +77,11 @@ func newMetricsToMetrics(set connector.Settings, cfg component.Config, metrics c
 		return nil, errors.New("consumer is not of type MetricsRouter")
 	}
 
-	failover := newFailoverRouter[consumer.Metrics](mr.Consumer, config)
-	err := failover.registerConsumers()
+	failover, err := newFailoverRouter[consumer.Metrics](mr.Consumer, config, set.TelemetrySettings, pipeline.SignalMetrics)
+	if err != nil {
+		return nil, err
+	}
+	err = failover.registerConsumers()
 	if err != nil {
 		return nil, err
 	}
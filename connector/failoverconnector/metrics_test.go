@@ -50,7 +50,7 @@ func TestMetricsRegisterConsumers(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, conn)
 
-	mc, _, ok := failoverConnector.failover.getCurrentConsumer()
+	mc, _, ok := failoverConnector.failover.getCurrentConsumer(context.Background())
 	mc1 := failoverConnector.failover.GetConsumerAtIndex(1)
 	mc2 := failoverConnector.failover.GetConsumerAtIndex(2)
 
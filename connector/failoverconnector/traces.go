@@ -11,11 +11,11 @@ import (
 	"go.opentelemetry.io/collector/connector"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pipeline"
 	"go.uber.org/zap"
 )
 
 type tracesFailover struct {
-	component.StartFunc
 	component.ShutdownFunc
 
 	config   *Config
@@ -29,7 +29,7 @@ func (f *tracesFailover) Capabilities() consumer.Capabilities {
 
 // ConsumeTraces will try to export to the current set priority level and handle failover in the case of an error
 func (f *tracesFailover) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
-	tc, ch, ok := f.failover.getCurrentConsumer()
+	tc, ch, ok := f.failover.getCurrentConsumer(ctx)
 	if !ok {
 		return errNoValidPipeline
 	}
@@ -43,10 +43,13 @@ func (f *tracesFailover) ConsumeTraces(ctx context.Context, td ptrace.Traces) er
 
 // FailoverTraces is the function responsible for handling errors returned by the nextConsumer
 func (f *tracesFailover) FailoverTraces(ctx context.Context, td ptrace.Traces) error {
-	for tc, ch, ok := f.failover.getCurrentConsumer(); ok; tc, ch, ok = f.failover.getCurrentConsumer() {
+	for tc, ch, ok := f.failover.getCurrentConsumer(ctx); ok; tc, ch, ok = f.failover.getCurrentConsumer(ctx) {
 		err := tc.ConsumeTraces(ctx, td)
 		if err != nil {
 			ch <- false
+			if f.failover.isLastLevel(ch) {
+				break
+			}
 			continue
 		}
 		ch <- true
@@ -56,6 +59,10 @@ func (f *tracesFailover) FailoverTraces(ctx context.Context, td ptrace.Traces) e
 	return errNoValidPipeline
 }
 
+func (f *tracesFailover) Start(_ context.Context, host component.Host) error {
+	return f.failover.Start(host)
+}
+
 func (f *tracesFailover) Shutdown(_ context.Context) error {
 	if f.failover != nil {
 		f.failover.Shutdown()
@@ -70,8 +77,11 @@ func newTracesToTraces(set connector.Settings, cfg component.Config, traces cons
 		return nil, errors.New("consumer is not of type TracesRouter")
 	}
 
-	failover := newFailoverRouter[consumer.Traces](tr.Consumer, config)
-	err := failover.registerConsumers()
+	failover, err := newFailoverRouter[consumer.Traces](tr.Consumer, config, set.TelemetrySettings, pipeline.SignalTraces)
+	if err != nil {
+		return nil, err
+	}
+	err = failover.registerConsumers()
 	if err != nil {
 		return nil, err
 	}
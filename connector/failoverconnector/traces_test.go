@@ -50,7 +50,7 @@ func TestTracesRegisterConsumers(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, conn)
 
-	tc, _, ok := failoverConnector.failover.getCurrentConsumer()
+	tc, _, ok := failoverConnector.failover.getCurrentConsumer(context.Background())
 	tc1 := failoverConnector.failover.GetConsumerAtIndex(1)
 	tc2 := failoverConnector.failover.GetConsumerAtIndex(2)
 
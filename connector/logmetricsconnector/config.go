@@ -0,0 +1,143 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logmetricsconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/logmetricsconnector"
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+// MetricType defines the type of the metric the extracted values are recorded into.
+type MetricType string
+
+const (
+	MetricTypeGauge                MetricType = "gauge"
+	MetricTypeSum                  MetricType = "sum"
+	MetricTypeHistogram            MetricType = "histogram"
+	MetricTypeExponentialHistogram MetricType = "exponential_histogram"
+)
+
+const (
+	// minExponentialHistogramMaxSize is the smallest number of buckets supported by exponential histograms.
+	minExponentialHistogramMaxSize = 2
+	// defaultExponentialHistogramMaxSize is the default number of buckets of exponential histograms.
+	defaultExponentialHistogramMaxSize = 160
+)
+
+// defaultHistogramBuckets are the default bounds of histograms, suited to latencies in milliseconds.
+var defaultHistogramBuckets = []float64{2, 4, 6, 8, 10, 50, 100, 200, 400, 800, 1000, 1400, 2000, 5000, 10_000, 15_000}
+
+// Config for the connector
+type Config struct {
+	// Metrics are the metrics recorded from the log records, by name.
+	Metrics map[string]MetricInfo `mapstructure:"metrics"`
+}
+
+// MetricInfo defines a metric recording values extracted from log records.
+type MetricInfo struct {
+	Description string `mapstructure:"description"`
+	Unit        string `mapstructure:"unit"`
+	// Conditions are OTTL conditions, any of which must match for a value to be extracted from a log record.
+	Conditions []string `mapstructure:"conditions"`
+	// Value is an OTTL value expression providing the value to record, e.g. attributes["latency_ms"].
+	Value string     `mapstructure:"value"`
+	Type  MetricType `mapstructure:"type"`
+	// Monotonic reports whether the values of a sum only increase.
+	Monotonic bool `mapstructure:"monotonic"`
+	// Buckets are the bounds of the buckets of a histogram.
+	Buckets []float64 `mapstructure:"buckets"`
+	// MaxSize is the maximum number of buckets of an exponential histogram.
+	MaxSize int32 `mapstructure:"max_size"`
+	// Attributes are the attributes of the data points.
+	Attributes []AttributeConfig `mapstructure:"attributes"`
+}
+
+// AttributeConfig defines an attribute of the data points.
+type AttributeConfig struct {
+	Key string `mapstructure:"key"`
+	// Value is an OTTL value expression providing the value of the attribute. When not set,
+	// the value of the log record attribute with the same key is used.
+	Value        string `mapstructure:"value"`
+	DefaultValue any    `mapstructure:"default_value"`
+}
+
+func (c *Config) Validate() (combinedErrors error) {
+	set := component.TelemetrySettings{Logger: zap.NewNop()}
+	for name, info := range c.Metrics {
+		if name == "" {
+			combinedErrors = errors.Join(combinedErrors, errors.New("metrics: metric name missing"))
+		}
+		if err := info.validate(); err != nil {
+			combinedErrors = errors.Join(combinedErrors, fmt.Errorf("metrics: metric %q: %w", name, err))
+		}
+		if _, err := filterottl.NewBoolExprForLog(info.Conditions, filterottl.StandardLogFuncs(), ottl.PropagateError, set); err != nil {
+			combinedErrors = errors.Join(combinedErrors, fmt.Errorf("metrics condition: metric %q: %w", name, err))
+		}
+		if info.Value == "" {
+			combinedErrors = errors.Join(combinedErrors, fmt.Errorf("metrics value: metric %q: value missing", name))
+		} else if _, err := newValueExpression(info.Value, set); err != nil {
+			combinedErrors = errors.Join(combinedErrors, fmt.Errorf("metrics value: metric %q: %w", name, err))
+		}
+		for _, attr := range info.Attributes {
+			if attr.Value == "" {
+				continue
+			}
+			if _, err := newValueExpression(attr.Value, set); err != nil {
+				combinedErrors = errors.Join(combinedErrors, fmt.Errorf("metrics attributes: metric %q: attribute %q: %w", name, attr.Key, err))
+			}
+		}
+	}
+	return combinedErrors
+}
+
+func (i *MetricInfo) validate() error {
+	for _, attr := range i.Attributes {
+		if attr.Key == "" {
+			return errors.New("attribute key missing")
+		}
+	}
+
+	switch i.Type {
+	case MetricTypeGauge, MetricTypeSum, MetricTypeHistogram, MetricTypeExponentialHistogram:
+	case "":
+		return errors.New("type missing")
+	default:
+		return fmt.Errorf("unsupported type %q", i.Type)
+	}
+
+	if i.Monotonic && i.Type != MetricTypeSum {
+		return fmt.Errorf("monotonic is only supported by the %q type", MetricTypeSum)
+	}
+	if len(i.Buckets) > 0 {
+		if i.Type != MetricTypeHistogram {
+			return fmt.Errorf("buckets are only supported by the %q type", MetricTypeHistogram)
+		}
+		if !sort.Float64sAreSorted(i.Buckets) {
+			return errors.New("buckets must be sorted in increasing order")
+		}
+		for j := 1; j < len(i.Buckets); j++ {
+			if i.Buckets[j] == i.Buckets[j-1] {
+				return fmt.Errorf("duplicate bucket %v", i.Buckets[j])
+			}
+		}
+	}
+	if i.MaxSize != 0 {
+		if i.Type != MetricTypeExponentialHistogram {
+			return fmt.Errorf("max_size is only supported by the %q type", MetricTypeExponentialHistogram)
+		}
+		if i.MaxSize < minExponentialHistogramMaxSize {
+			return fmt.Errorf("max_size must be at least %d", minExponentialHistogramMaxSize)
+		}
+	}
+	return nil
+}
+
+var _ component.ConfigValidator = (*Config)(nil)
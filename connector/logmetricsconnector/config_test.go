@@ -0,0 +1,121 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logmetricsconnector
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/logmetricsconnector/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id           component.ID
+		expected     component.Config
+		errorMessage string
+	}{
+		{
+			id:       component.NewID(metadata.Type),
+			expected: createDefaultConfig(),
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "full"),
+			expected: &Config{
+				Metrics: map[string]MetricInfo{
+					"http.server.request.duration": {
+						Description: "Duration of the HTTP requests.",
+						Unit:        "ms",
+						Conditions:  []string{`attributes["latency_ms"] != nil`},
+						Value:       `attributes["latency_ms"]`,
+						Type:        MetricTypeHistogram,
+						Buckets:     []float64{10, 100, 1000},
+						Attributes: []AttributeConfig{
+							{Key: "http.route"},
+							{Key: "http.response.status_code", Value: `attributes["status"]`, DefaultValue: 0},
+						},
+					},
+					"http.server.response.body.size": {
+						Value:   `body["bytes"]`,
+						Type:    MetricTypeExponentialHistogram,
+						MaxSize: 80,
+					},
+					"queue.depth": {
+						Value: `attributes["queue_depth"]`,
+						Type:  MetricTypeGauge,
+					},
+					"http.server.response.bytes": {
+						Value:     `attributes["bytes"]`,
+						Type:      MetricTypeSum,
+						Monotonic: true,
+					},
+				},
+			},
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "missing_type"),
+			errorMessage: `metrics: metric "latency": type missing`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "missing_value"),
+			errorMessage: `metrics value: metric "latency": value missing`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_value"),
+			errorMessage: `metrics value: metric "latency": value expression has invalid syntax`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_condition"),
+			errorMessage: `metrics condition: metric "latency": unable to parse OTTL condition "invalid condition"`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_attribute"),
+			errorMessage: `metrics attributes: metric "latency": attribute "route": value expression has invalid syntax`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "unsorted_buckets"),
+			errorMessage: `metrics: metric "latency": buckets must be sorted in increasing order`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "buckets_not_histogram"),
+			errorMessage: `metrics: metric "latency": buckets are only supported by the "histogram" type`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "monotonic_not_sum"),
+			errorMessage: `metrics: metric "latency": monotonic is only supported by the "sum" type`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "small_max_size"),
+			errorMessage: `metrics: metric "latency": max_size must be at least 2`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			err = sub.Unmarshal(cfg)
+
+			if tt.expected == nil {
+				err = errors.Join(err, component.ValidateConfig(cfg))
+				assert.ErrorContains(t, err, tt.errorMessage)
+				return
+			}
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
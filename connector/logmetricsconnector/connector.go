@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logmetricsconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/logmetricsconnector"
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
+)
+
+// logMetrics records values extracted from log records into metrics
+// and emits them onto a metrics pipeline.
+type logMetrics struct {
+	logger          *zap.Logger
+	metricsConsumer consumer.Metrics
+	component.StartFunc
+	component.ShutdownFunc
+
+	metricDefs []metricDef
+}
+
+func (c *logMetrics) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+// ConsumeLogs records the log records into metrics. The log records whose values cannot be recorded
+// are skipped, and the metrics recorded from the other log records are sent on.
+func (c *logMetrics) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	var multiError error
+	var skipped int
+	logMetrics := pmetric.NewMetrics()
+	logMetrics.ResourceMetrics().EnsureCapacity(ld.ResourceLogs().Len())
+	for i := 0; i < ld.ResourceLogs().Len(); i++ {
+		resourceLog := ld.ResourceLogs().At(i)
+		recorder := newRecorder(c.metricDefs)
+
+		for j := 0; j < resourceLog.ScopeLogs().Len(); j++ {
+			scopeLogs := resourceLog.ScopeLogs().At(j)
+
+			for k := 0; k < scopeLogs.LogRecords().Len(); k++ {
+				logRecord := scopeLogs.LogRecords().At(k)
+
+				lCtx := ottllog.NewTransformContext(logRecord, scopeLogs.Scope(), resourceLog.Resource(), scopeLogs, resourceLog)
+				if err := recorder.update(ctx, lCtx); err != nil {
+					multiError = errors.Join(multiError, err)
+					skipped++
+				}
+			}
+		}
+
+		if len(recorder.series) == 0 {
+			continue // don't add an empty resource
+		}
+
+		metricsResource := logMetrics.ResourceMetrics().AppendEmpty()
+		resourceLog.Resource().Attributes().CopyTo(metricsResource.Resource().Attributes())
+
+		metricsScope := metricsResource.ScopeMetrics().AppendEmpty()
+		recorder.appendMetricsTo(metricsScope.Metrics())
+	}
+	if multiError != nil {
+		c.logger.Warn("Failed to record log records, skipping them",
+			zap.Int("skipped_log_records", skipped),
+			zap.Error(multiError))
+	}
+	return c.metricsConsumer.ConsumeMetrics(ctx, logMetrics)
+}
@@ -0,0 +1,252 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logmetricsconnector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/connector/connectortest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func newLogs(t *testing.T, service string, records ...map[string]any) plog.Logs {
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("service.name", service)
+	lrs := rl.ScopeLogs().AppendEmpty().LogRecords()
+	for _, attrs := range records {
+		require.NoError(t, lrs.AppendEmpty().Attributes().FromRaw(attrs))
+	}
+	return ld
+}
+
+func consumeLogs(t *testing.T, cfg *Config, ld plog.Logs) (pmetric.Metrics, error) {
+	require.NoError(t, cfg.Validate())
+	sink := new(consumertest.MetricsSink)
+	conn, err := NewFactory().CreateLogsToMetrics(context.Background(), connectortest.NewNopSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, conn.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { assert.NoError(t, conn.Shutdown(context.Background())) }()
+
+	if err := conn.ConsumeLogs(context.Background(), ld); err != nil {
+		return pmetric.NewMetrics(), err
+	}
+	require.Len(t, sink.AllMetrics(), 1)
+	return sink.AllMetrics()[0], nil
+}
+
+// findMetric returns the metric with the given name of the first resource.
+func findMetric(t *testing.T, md pmetric.Metrics, name string) pmetric.Metric {
+	metrics := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	for i := 0; i < metrics.Len(); i++ {
+		if metrics.At(i).Name() == name {
+			return metrics.At(i)
+		}
+	}
+	require.Failf(t, "metric not found", "metric %q", name)
+	return pmetric.NewMetric()
+}
+
+func TestHistogram(t *testing.T) {
+	cfg := &Config{Metrics: map[string]MetricInfo{
+		"http.server.request.duration": {
+			Unit:    "ms",
+			Value:   `attributes["latency_ms"]`,
+			Type:    MetricTypeHistogram,
+			Buckets: []float64{10, 100},
+			Attributes: []AttributeConfig{
+				{Key: "http.route"},
+				{Key: "http.response.status_code", Value: `attributes["status"]`},
+			},
+		},
+	}}
+
+	md, err := consumeLogs(t, cfg, newLogs(t, "frontend",
+		map[string]any{"http.route": "/cart", "status": 200, "latency_ms": "5"},
+		map[string]any{"http.route": "/cart", "status": 200, "latency_ms": 10},
+		map[string]any{"http.route": "/cart", "status": 200, "latency_ms": 250.5},
+		map[string]any{"http.route": "/cart", "status": 500, "latency_ms": 50},
+		// Records without a value or one of the attributes are not recorded
+		map[string]any{"http.route": "/cart", "status": 200},
+		map[string]any{"status": 200, "latency_ms": 5},
+	))
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]any{"service.name": "frontend"}, md.ResourceMetrics().At(0).Resource().Attributes().AsRaw())
+	metric := findMetric(t, md, "http.server.request.duration")
+	assert.Equal(t, "ms", metric.Unit())
+	assert.Equal(t, pmetric.AggregationTemporalityDelta, metric.Histogram().AggregationTemporality())
+	dps := metric.Histogram().DataPoints()
+	require.Equal(t, 2, dps.Len())
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		assert.Equal(t, []float64{10, 100}, dp.ExplicitBounds().AsRaw())
+		status, _ := dp.Attributes().Get("http.response.status_code")
+		switch status.Int() {
+		case 200:
+			assert.Equal(t, map[string]any{"http.route": "/cart", "http.response.status_code": int64(200)}, dp.Attributes().AsRaw())
+			assert.Equal(t, uint64(3), dp.Count())
+			assert.Equal(t, 265.5, dp.Sum())
+			assert.Equal(t, 5.0, dp.Min())
+			assert.Equal(t, 250.5, dp.Max())
+			assert.Equal(t, []uint64{2, 0, 1}, dp.BucketCounts().AsRaw())
+		case 500:
+			assert.Equal(t, uint64(1), dp.Count())
+			assert.Equal(t, []uint64{0, 1, 0}, dp.BucketCounts().AsRaw())
+		default:
+			assert.Failf(t, "unexpected status code", "%v", status.AsRaw())
+		}
+	}
+}
+
+func TestDefaultHistogramBuckets(t *testing.T) {
+	cfg := &Config{Metrics: map[string]MetricInfo{
+		"latency": {Value: `attributes["latency_ms"]`, Type: MetricTypeHistogram},
+	}}
+
+	md, err := consumeLogs(t, cfg, newLogs(t, "frontend", map[string]any{"latency_ms": 3}))
+	require.NoError(t, err)
+
+	dp := findMetric(t, md, "latency").Histogram().DataPoints().At(0)
+	assert.Equal(t, defaultHistogramBuckets, dp.ExplicitBounds().AsRaw())
+	assert.Equal(t, uint64(1), dp.BucketCounts().At(1))
+}
+
+func TestExponentialHistogram(t *testing.T) {
+	cfg := &Config{Metrics: map[string]MetricInfo{
+		"http.server.response.body.size": {
+			Value: `body["bytes"]`,
+			Type:  MetricTypeExponentialHistogram,
+		},
+	}}
+
+	ld := plog.NewLogs()
+	lrs := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	for _, size := range []int64{0, 100, 1000, 10000} {
+		lrs.AppendEmpty().Body().SetEmptyMap().PutInt("bytes", size)
+	}
+	lrs.AppendEmpty().Body().SetEmptyMap().PutStr("message", "no size")
+
+	md, err := consumeLogs(t, cfg, ld)
+	require.NoError(t, err)
+
+	metric := findMetric(t, md, "http.server.response.body.size")
+	assert.Equal(t, pmetric.AggregationTemporalityDelta, metric.ExponentialHistogram().AggregationTemporality())
+	dp := metric.ExponentialHistogram().DataPoints().At(0)
+	assert.Equal(t, uint64(4), dp.Count())
+	assert.Equal(t, 11100.0, dp.Sum())
+	assert.Equal(t, 0.0, dp.Min())
+	assert.Equal(t, 10000.0, dp.Max())
+	assert.Equal(t, uint64(1), dp.ZeroCount())
+	var positive uint64
+	for _, count := range dp.Positive().BucketCounts().AsRaw() {
+		positive += count
+	}
+	assert.Equal(t, uint64(3), positive)
+}
+
+func TestGaugeAndSum(t *testing.T) {
+	cfg := &Config{Metrics: map[string]MetricInfo{
+		"queue.depth": {
+			Value: `attributes["queue_depth"]`,
+			Type:  MetricTypeGauge,
+		},
+		"http.server.response.bytes": {
+			Value:      `attributes["bytes"]`,
+			Type:       MetricTypeSum,
+			Monotonic:  true,
+			Attributes: []AttributeConfig{{Key: "http.route", DefaultValue: "unknown"}},
+		},
+	}}
+
+	md, err := consumeLogs(t, cfg, newLogs(t, "frontend",
+		map[string]any{"queue_depth": 3, "bytes": 100, "http.route": "/cart"},
+		map[string]any{"queue_depth": 7, "bytes": 50, "http.route": "/cart"},
+		map[string]any{"bytes": 20},
+	))
+	require.NoError(t, err)
+
+	metrics := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 2, metrics.Len())
+	assert.Equal(t, "http.server.response.bytes", metrics.At(0).Name(), "metrics are emitted by name")
+	assert.Equal(t, "queue.depth", metrics.At(1).Name())
+
+	gauge := findMetric(t, md, "queue.depth").Gauge()
+	require.Equal(t, 1, gauge.DataPoints().Len())
+	assert.Equal(t, 7.0, gauge.DataPoints().At(0).DoubleValue(), "gauges hold the last value")
+
+	sum := findMetric(t, md, "http.server.response.bytes").Sum()
+	assert.True(t, sum.IsMonotonic())
+	assert.Equal(t, pmetric.AggregationTemporalityDelta, sum.AggregationTemporality())
+	sums := map[string]float64{}
+	for i := 0; i < sum.DataPoints().Len(); i++ {
+		dp := sum.DataPoints().At(i)
+		route, _ := dp.Attributes().Get("http.route")
+		sums[route.Str()] = dp.DoubleValue()
+	}
+	assert.Equal(t, map[string]float64{"/cart": 150, "unknown": 20}, sums)
+}
+
+func TestConditions(t *testing.T) {
+	cfg := &Config{Metrics: map[string]MetricInfo{
+		"latency": {
+			Conditions: []string{`attributes["method"] == "GET"`},
+			Value:      `attributes["latency_ms"]`,
+			Type:       MetricTypeSum,
+		},
+	}}
+
+	md, err := consumeLogs(t, cfg, newLogs(t, "frontend",
+		map[string]any{"method": "GET", "latency_ms": 3},
+		map[string]any{"method": "POST", "latency_ms": 7},
+	))
+	require.NoError(t, err)
+	assert.Equal(t, 3.0, findMetric(t, md, "latency").Sum().DataPoints().At(0).DoubleValue())
+}
+
+func TestNoMatchingRecords(t *testing.T) {
+	cfg := &Config{Metrics: map[string]MetricInfo{
+		"latency": {Value: `attributes["latency_ms"]`, Type: MetricTypeGauge},
+	}}
+
+	md, err := consumeLogs(t, cfg, newLogs(t, "frontend", map[string]any{"method": "GET"}))
+	require.NoError(t, err)
+	assert.Equal(t, 0, md.ResourceMetrics().Len())
+}
+
+func TestInvalidValue(t *testing.T) {
+	cfg := &Config{Metrics: map[string]MetricInfo{
+		"latency": {Value: `attributes["latency_ms"]`, Type: MetricTypeSum},
+	}}
+	require.NoError(t, cfg.Validate())
+
+	core, logs := observer.New(zap.WarnLevel)
+	set := connectortest.NewNopSettings()
+	set.Logger = zap.New(core)
+	sink := new(consumertest.MetricsSink)
+	conn, err := NewFactory().CreateLogsToMetrics(context.Background(), set, cfg, sink)
+	require.NoError(t, err)
+
+	require.NoError(t, conn.ConsumeLogs(context.Background(), newLogs(t, "frontend",
+		map[string]any{"latency_ms": 3},
+		map[string]any{"latency_ms": "fast"},
+		map[string]any{"latency_ms": "4"},
+	)))
+
+	require.Len(t, sink.AllMetrics(), 1)
+	assert.Equal(t, 7.0, findMetric(t, sink.AllMetrics()[0], "latency").Sum().DataPoints().At(0).DoubleValue())
+
+	require.Equal(t, 1, logs.Len())
+	entry := logs.All()[0]
+	assert.Equal(t, int64(1), entry.ContextMap()["skipped_log_records"])
+	assert.Equal(t, `metric "latency": value fast cannot be converted to a number`, entry.ContextMap()["error"])
+}
@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+package logmetricsconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/logmetricsconnector"
+
+import (
+	"context"
+	"sort"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/consumer"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/logmetricsconnector/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+// NewFactory returns a ConnectorFactory.
+func NewFactory() connector.Factory {
+	return connector.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		connector.WithLogsToMetrics(createLogsToMetrics, metadata.LogsToMetricsStability),
+	)
+}
+
+// createDefaultConfig creates the default configuration.
+func createDefaultConfig() component.Config {
+	return &Config{}
+}
+
+// createLogsToMetrics creates a logs to metrics connector based on provided config.
+func createLogsToMetrics(
+	_ context.Context,
+	set connector.Settings,
+	cfg component.Config,
+	nextConsumer consumer.Metrics,
+) (connector.Logs, error) {
+	c := cfg.(*Config)
+
+	metricDefs := make([]metricDef, 0, len(c.Metrics))
+	for name, info := range c.Metrics {
+		if info.Type == MetricTypeHistogram && len(info.Buckets) == 0 {
+			info.Buckets = defaultHistogramBuckets
+		}
+		if info.Type == MetricTypeExponentialHistogram && info.MaxSize == 0 {
+			info.MaxSize = defaultExponentialHistogramMaxSize
+		}
+		md := metricDef{
+			name: name,
+			info: info,
+		}
+		// Errors checked in Config.Validate()
+		if len(info.Conditions) > 0 {
+			md.condition, _ = filterottl.NewBoolExprForLog(info.Conditions, filterottl.StandardLogFuncs(), ottl.PropagateError, set.TelemetrySettings)
+		}
+		md.value, _ = newValueExpression(info.Value, set.TelemetrySettings)
+		for _, attr := range info.Attributes {
+			ad := attributeDef{key: attr.Key, defaultValue: attr.DefaultValue}
+			if attr.Value != "" {
+				ad.value, _ = newValueExpression(attr.Value, set.TelemetrySettings)
+			}
+			md.attrs = append(md.attrs, ad)
+		}
+		metricDefs = append(metricDefs, md)
+	}
+	// Emit the metrics in a stable order
+	sort.Slice(metricDefs, func(i, j int) bool { return metricDefs[i].name < metricDefs[j].name })
+
+	return &logMetrics{
+		logger:          set.Logger,
+		metricsConsumer: nextConsumer,
+		metricDefs:      metricDefs,
+	}, nil
+}
@@ -0,0 +1,311 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logmetricsconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/logmetricsconnector"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/lightstep/go-expohisto/structure"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/expr"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatautil"
+)
+
+var noAttributes = [16]byte{}
+
+func newValueExpression(value string, set component.TelemetrySettings) (*ottl.ValueExpression[ottllog.TransformContext], error) {
+	parser, err := ottllog.NewParser(ottlfuncs.StandardConverters[ottllog.TransformContext](), set)
+	if err != nil {
+		return nil, err
+	}
+	return parser.ParseValueExpression(value)
+}
+
+type metricDef struct {
+	name      string
+	info      MetricInfo
+	condition expr.BoolExpr[ottllog.TransformContext]
+	value     *ottl.ValueExpression[ottllog.TransformContext]
+	attrs     []attributeDef
+}
+
+type attributeDef struct {
+	key          string
+	value        *ottl.ValueExpression[ottllog.TransformContext]
+	defaultValue any
+}
+
+// attributes returns the attributes of the data point the log record is recorded into,
+// and false if some of them are missing.
+func (d *metricDef) attributes(ctx context.Context, tCtx ottllog.TransformContext) (pcommon.Map, bool, error) {
+	attrs := pcommon.NewMap()
+	for _, attr := range d.attrs {
+		var val any
+		if attr.value != nil {
+			var err error
+			if val, err = attr.value.Eval(ctx, tCtx); err != nil {
+				return attrs, false, fmt.Errorf("metric %q: failed to evaluate attribute %q: %w", d.name, attr.key, err)
+			}
+		} else if attrVal, ok := tCtx.GetLogRecord().Attributes().Get(attr.key); ok {
+			val = attrVal
+		}
+		if val == nil {
+			val = attr.defaultValue
+		}
+		if !putAttribute(attrs, attr.key, val) {
+			return attrs, false, nil
+		}
+	}
+	return attrs, true, nil
+}
+
+// putAttribute sets the attribute key of attrs to val, and reports whether val is a supported attribute value.
+func putAttribute(attrs pcommon.Map, key string, val any) bool {
+	switch v := val.(type) {
+	case pcommon.Value:
+		v.CopyTo(attrs.PutEmpty(key))
+	case string:
+		attrs.PutStr(key, v)
+	case int:
+		attrs.PutInt(key, int64(v))
+	case int64:
+		attrs.PutInt(key, v)
+	case float64:
+		attrs.PutDouble(key, v)
+	case bool:
+		attrs.PutBool(key, v)
+	default:
+		return false
+	}
+	return true
+}
+
+// recorder records the values extracted from the log records of a resource.
+type recorder struct {
+	metricDefs []metricDef
+	series     map[string]map[[16]byte]*series
+	timestamp  time.Time
+}
+
+func newRecorder(metricDefs []metricDef) *recorder {
+	return &recorder{
+		metricDefs: metricDefs,
+		series:     make(map[string]map[[16]byte]*series, len(metricDefs)),
+		timestamp:  time.Now(),
+	}
+}
+
+// series holds the values recorded into a data point.
+type series struct {
+	attrs        pcommon.Map
+	count        uint64
+	sum          float64
+	min          float64
+	max          float64
+	last         float64
+	bucketCounts []uint64
+	histogram    *structure.Histogram[float64]
+}
+
+func (c *recorder) update(ctx context.Context, tCtx ottllog.TransformContext) error {
+	var multiError error
+	for i := range c.metricDefs {
+		multiError = errors.Join(multiError, c.record(ctx, &c.metricDefs[i], tCtx))
+	}
+	return multiError
+}
+
+func (c *recorder) record(ctx context.Context, md *metricDef, tCtx ottllog.TransformContext) error {
+	if md.condition != nil {
+		match, err := md.condition.Eval(ctx, tCtx)
+		if err != nil || !match {
+			return err
+		}
+	}
+
+	val, err := md.value.Eval(ctx, tCtx)
+	if err != nil {
+		return fmt.Errorf("metric %q: failed to evaluate value: %w", md.name, err)
+	}
+	if val == nil {
+		// Log records without a value are not recorded.
+		return nil
+	}
+	v, ok := valueToFloat64(val)
+	if !ok {
+		return fmt.Errorf("metric %q: value %v cannot be converted to a number", md.name, val)
+	}
+
+	attrs, ok, err := md.attributes(ctx, tCtx)
+	if err != nil || !ok {
+		// Missing necessary attributes
+		return err
+	}
+
+	s := c.getSeries(md, attrs)
+	s.observe(v, md.info.Buckets)
+	return nil
+}
+
+func (c *recorder) getSeries(md *metricDef, attrs pcommon.Map) *series {
+	if _, ok := c.series[md.name]; !ok {
+		c.series[md.name] = make(map[[16]byte]*series)
+	}
+
+	key := noAttributes
+	if attrs.Len() > 0 {
+		key = pdatautil.MapHash(attrs)
+	}
+
+	s, ok := c.series[md.name][key]
+	if !ok {
+		s = &series{attrs: attrs}
+		switch md.info.Type {
+		case MetricTypeHistogram:
+			s.bucketCounts = make([]uint64, len(md.info.Buckets)+1)
+		case MetricTypeExponentialHistogram:
+			s.histogram = new(structure.Histogram[float64])
+			s.histogram.Init(structure.NewConfig(structure.WithMaxSize(md.info.MaxSize)))
+		}
+		c.series[md.name][key] = s
+	}
+	return s
+}
+
+func (s *series) observe(v float64, buckets []float64) {
+	if s.count == 0 || v < s.min {
+		s.min = v
+	}
+	if s.count == 0 || v > s.max {
+		s.max = v
+	}
+	s.count++
+	s.sum += v
+	s.last = v
+	if s.histogram != nil {
+		s.histogram.Update(v)
+	}
+	if s.bucketCounts != nil {
+		s.bucketCounts[sort.SearchFloat64s(buckets, v)]++
+	}
+}
+
+func (c *recorder) appendMetricsTo(metricSlice pmetric.MetricSlice) {
+	timestamp := pcommon.NewTimestampFromTime(c.timestamp)
+	for i := range c.metricDefs {
+		md := &c.metricDefs[i]
+		if len(c.series[md.name]) == 0 {
+			continue
+		}
+		metric := metricSlice.AppendEmpty()
+		metric.SetName(md.name)
+		metric.SetDescription(md.info.Description)
+		metric.SetUnit(md.info.Unit)
+
+		switch md.info.Type {
+		case MetricTypeGauge:
+			dps := metric.SetEmptyGauge().DataPoints()
+			for _, s := range c.series[md.name] {
+				dp := dps.AppendEmpty()
+				s.attrs.CopyTo(dp.Attributes())
+				dp.SetTimestamp(timestamp)
+				dp.SetDoubleValue(s.last)
+			}
+		case MetricTypeSum:
+			sum := metric.SetEmptySum()
+			sum.SetIsMonotonic(md.info.Monotonic)
+			sum.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+			for _, s := range c.series[md.name] {
+				dp := sum.DataPoints().AppendEmpty()
+				s.attrs.CopyTo(dp.Attributes())
+				dp.SetTimestamp(timestamp)
+				dp.SetDoubleValue(s.sum)
+			}
+		case MetricTypeHistogram:
+			histogram := metric.SetEmptyHistogram()
+			histogram.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+			for _, s := range c.series[md.name] {
+				dp := histogram.DataPoints().AppendEmpty()
+				s.attrs.CopyTo(dp.Attributes())
+				dp.SetTimestamp(timestamp)
+				dp.SetCount(s.count)
+				dp.SetSum(s.sum)
+				dp.SetMin(s.min)
+				dp.SetMax(s.max)
+				dp.ExplicitBounds().FromRaw(md.info.Buckets)
+				dp.BucketCounts().FromRaw(s.bucketCounts)
+			}
+		case MetricTypeExponentialHistogram:
+			histogram := metric.SetEmptyExponentialHistogram()
+			histogram.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+			for _, s := range c.series[md.name] {
+				dp := histogram.DataPoints().AppendEmpty()
+				s.attrs.CopyTo(dp.Attributes())
+				dp.SetTimestamp(timestamp)
+				dp.SetCount(s.count)
+				dp.SetSum(s.sum)
+				dp.SetMin(s.min)
+				dp.SetMax(s.max)
+				dp.SetZeroCount(s.histogram.ZeroCount())
+				dp.SetScale(s.histogram.Scale())
+				copyBuckets(s.histogram.Positive(), dp.Positive())
+				copyBuckets(s.histogram.Negative(), dp.Negative())
+			}
+		}
+	}
+}
+
+func copyBuckets(buckets *structure.Buckets, out pmetric.ExponentialHistogramDataPointBuckets) {
+	out.SetOffset(buckets.Offset())
+	out.BucketCounts().EnsureCapacity(int(buckets.Len()))
+	for i := uint32(0); i < buckets.Len(); i++ {
+		out.BucketCounts().Append(buckets.At(i))
+	}
+}
+
+func valueToFloat64(value any) (float64, bool) {
+	var v float64
+	switch val := value.(type) {
+	case int64:
+		v = float64(val)
+	case float64:
+		v = val
+	case string:
+		f, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return 0, false
+		}
+		v = f
+	case pcommon.Value:
+		switch val.Type() {
+		case pcommon.ValueTypeInt:
+			v = float64(val.Int())
+		case pcommon.ValueTypeDouble:
+			v = val.Double()
+		case pcommon.ValueTypeStr:
+			return valueToFloat64(val.Str())
+		default:
+			return 0, false
+		}
+	default:
+		return 0, false
+	}
+	// Non finite values cannot be meaningfully recorded.
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0, false
+	}
+	return v, true
+}
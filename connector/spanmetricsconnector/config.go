@@ -10,6 +10,7 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/spanmetricsconnector/internal/metrics"
 )
@@ -29,6 +30,14 @@ var defaultDeltaTimestampCacheSize = 1000
 type Dimension struct {
 	Name    string  `mapstructure:"name"`
 	Default *string `mapstructure:"default"`
+	// Expression is an optional OTTL value expression used to compute the dimension value instead of
+	// looking up the attribute called Name. Span dimensions are evaluated in the span context and
+	// event dimensions in the spanevent context. If the expression resolves to nil, Default is used.
+	Expression string `mapstructure:"expression"`
+	// CardinalityLimit caps the number of distinct values tracked for this dimension. Once the limit is
+	// reached, new values are replaced by otel.metric.overflow and the otel.metric.overflow attribute is
+	// added, keeping the other dimensions. Zero means no limit.
+	CardinalityLimit int `mapstructure:"cardinality_limit"`
 }
 
 // Config defines the configuration options for spanmetricsconnector.
@@ -124,6 +133,12 @@ func (c Config) Validate() error {
 	if err := validateEventDimensions(c.Events.Enabled, c.Events.Dimensions); err != nil {
 		return fmt.Errorf("failed validating event dimensions: %w", err)
 	}
+	if _, err := newSpanDimensionExpressions(c.Dimensions, component.TelemetrySettings{Logger: zap.NewNop()}); err != nil {
+		return fmt.Errorf("failed parsing dimension expressions: %w", err)
+	}
+	if _, err := newSpanEventDimensionExpressions(c.Events.Dimensions, component.TelemetrySettings{Logger: zap.NewNop()}); err != nil {
+		return fmt.Errorf("failed parsing event dimension expressions: %w", err)
+	}
 
 	if c.DimensionsCacheSize <= 0 {
 		return fmt.Errorf(
@@ -182,6 +197,10 @@ func validateDimensions(dimensions []Dimension) error {
 			return fmt.Errorf("duplicate dimension name %s", key.Name)
 		}
 		labelNames[key.Name] = struct{}{}
+
+		if key.CardinalityLimit < 0 {
+			return fmt.Errorf("invalid cardinality limit %d for dimension %s, the limit should not be negative", key.CardinalityLimit, key.Name)
+		}
 	}
 
 	return nil
@@ -28,6 +28,7 @@ func TestLoadConfig(t *testing.T) {
 	defaultMethod := "GET"
 	defaultMaxPerDatapoint := 5
 	customTimestampCacheSize := 123
+	defaultRoute := "unknown"
 	tests := []struct {
 		id              component.ID
 		expected        component.Config
@@ -49,6 +50,7 @@ func TestLoadConfig(t *testing.T) {
 				Dimensions: []Dimension{
 					{Name: "http.method", Default: &defaultMethod},
 					{Name: "http.status_code", Default: (*string)(nil)},
+					{Name: "http.route", Expression: `attributes["http.route"]`, Default: &defaultRoute, CardinalityLimit: 100},
 				},
 				Namespace:                DefaultNamespace,
 				DimensionsCacheSize:      1500,
@@ -230,6 +232,13 @@ func TestValidateDimensions(t *testing.T) {
 			},
 			expectedErr: "duplicate dimension name service_name",
 		},
+		{
+			name: "negative cardinality limit",
+			dimensions: []Dimension{
+				{Name: "user.id", CardinalityLimit: -1},
+			},
+			expectedErr: "invalid cardinality limit -1 for dimension user.id, the limit should not be negative",
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			err := validateDimensions(tc.dimensions)
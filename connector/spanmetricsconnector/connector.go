@@ -24,6 +24,8 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/spanmetricsconnector/internal/metrics"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/traceutil"
 	utilattri "github.com/open-telemetry/opentelemetry-collector-contrib/internal/pdatautil"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanevent"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatautil"
 )
 
@@ -53,6 +55,10 @@ type connectorImp struct {
 
 	// Additional dimensions to add to metrics.
 	dimensions []utilattri.Dimension
+	// Additional dimensions computed from OTTL expressions.
+	exprDimensions []exprDimension[ottlspan.TransformContext]
+	// Distinct values tracked per dimension with a configured cardinality limit, keyed by dimension name.
+	cardinalityLimits map[string]*cardinalityLimit
 
 	resourceMetrics *cache.Cache[resourceKey, *resourceMetrics]
 
@@ -73,6 +79,10 @@ type connectorImp struct {
 
 	// Event dimensions to add to the events metric.
 	eDimensions []utilattri.Dimension
+	// Event dimensions computed from OTTL expressions.
+	eExprDimensions []exprDimension[ottlspanevent.TransformContext]
+	// Distinct values tracked per event dimension with a configured cardinality limit, keyed by dimension name.
+	eCardinalityLimits map[string]*cardinalityLimit
 
 	events EventsConfig
 
@@ -91,17 +101,20 @@ type resourceMetrics struct {
 	lastSeen time.Time
 }
 
+// newDimensions returns the dimensions looked up by attribute name. Dimensions with an expression are
+// built separately by newSpanDimensionExpressions and newSpanEventDimensionExpressions.
 func newDimensions(cfgDims []Dimension) []utilattri.Dimension {
-	if len(cfgDims) == 0 {
-		return nil
-	}
-	dims := make([]utilattri.Dimension, len(cfgDims))
+	var dims []utilattri.Dimension
 	for i := range cfgDims {
-		dims[i].Name = cfgDims[i].Name
+		if cfgDims[i].Expression != "" {
+			continue
+		}
+		dim := utilattri.Dimension{Name: cfgDims[i].Name}
 		if cfgDims[i].Default != nil {
 			val := pcommon.NewValueStr(*cfgDims[i].Default)
-			dims[i].Value = &val
+			dim.Value = &val
 		}
+		dims = append(dims, dim)
 	}
 	return dims
 }
@@ -126,6 +139,16 @@ func newConnector(logger *zap.Logger, config component.Config, clock clockwork.C
 		resourceMetricsKeyAttributes[attr] = s
 	}
 
+	set := component.TelemetrySettings{Logger: logger}
+	exprDimensions, err := newSpanDimensionExpressions(cfg.Dimensions, set)
+	if err != nil {
+		return nil, err
+	}
+	eExprDimensions, err := newSpanEventDimensionExpressions(cfg.Events.Dimensions, set)
+	if err != nil {
+		return nil, err
+	}
+
 	var lastDeltaTimestamps *simplelru.LRU[metrics.Key, pcommon.Timestamp]
 	if cfg.GetAggregationTemporality() == pmetric.AggregationTemporalityDelta {
 		lastDeltaTimestamps, err = simplelru.NewLRU[metrics.Key, pcommon.Timestamp](cfg.GetDeltaTimestampCacheSize(), func(k metrics.Key, _ pcommon.Timestamp) {
@@ -142,6 +165,8 @@ func newConnector(logger *zap.Logger, config component.Config, clock clockwork.C
 		resourceMetrics:              resourceMetricsCache,
 		resourceMetricsKeyAttributes: resourceMetricsKeyAttributes,
 		dimensions:                   newDimensions(cfg.Dimensions),
+		exprDimensions:               exprDimensions,
+		cardinalityLimits:            newCardinalityLimits(cfg.Dimensions),
 		keyBuf:                       bytes.NewBuffer(make([]byte, 0, 1024)),
 		metricKeyToDimensions:        metricKeyToDimensionsCache,
 		lastDeltaTimestamps:          lastDeltaTimestamps,
@@ -149,6 +174,8 @@ func newConnector(logger *zap.Logger, config component.Config, clock clockwork.C
 		ticker:                       clock.NewTicker(cfg.MetricsFlushInterval),
 		done:                         make(chan struct{}),
 		eDimensions:                  newDimensions(cfg.Events.Dimensions),
+		eExprDimensions:              eExprDimensions,
+		eCardinalityLimits:           newCardinalityLimits(cfg.Events.Dimensions),
 		events:                       cfg.Events,
 	}, nil
 }
@@ -239,9 +266,9 @@ func (p *connectorImp) Capabilities() consumer.Capabilities {
 
 // ConsumeTraces implements the consumer.Traces interface.
 // It aggregates the trace data to generate metrics.
-func (p *connectorImp) ConsumeTraces(_ context.Context, traces ptrace.Traces) error {
+func (p *connectorImp) ConsumeTraces(ctx context.Context, traces ptrace.Traces) error {
 	p.lock.Lock()
-	p.aggregateMetrics(traces)
+	p.aggregateMetrics(ctx, traces)
 	p.lock.Unlock()
 	return nil
 }
@@ -330,6 +357,7 @@ func (p *connectorImp) resetState() {
 	if p.config.GetAggregationTemporality() == pmetric.AggregationTemporalityDelta {
 		p.resourceMetrics.Purge()
 		p.metricKeyToDimensions.Purge()
+		p.resetCardinalityLimits()
 	} else {
 		p.resourceMetrics.RemoveEvictedItems()
 		p.metricKeyToDimensions.RemoveEvictedItems()
@@ -359,6 +387,13 @@ func (p *connectorImp) resetState() {
 			}
 		})
 
+		// Dimension values of expired series no longer count towards the cardinality limits.
+		if p.config.MetricsExpiration > 0 {
+			since := now.Add(-p.config.MetricsExpiration)
+			expireCardinalityLimits(p.cardinalityLimits, since)
+			expireCardinalityLimits(p.eCardinalityLimits, since)
+		}
+
 	}
 }
 
@@ -368,7 +403,7 @@ func (p *connectorImp) resetState() {
 // Each metric is identified by a key that is built from the service name
 // and span metadata such as name, kind, status_code and any additional
 // dimensions the user has configured.
-func (p *connectorImp) aggregateMetrics(traces ptrace.Traces) {
+func (p *connectorImp) aggregateMetrics(ctx context.Context, traces ptrace.Traces) {
 	startTimestamp := pcommon.NewTimestampFromTime(p.clock.Now())
 	for i := 0; i < traces.ResourceSpans().Len(); i++ {
 		rspans := traces.ResourceSpans().At(i)
@@ -398,13 +433,12 @@ func (p *connectorImp) aggregateMetrics(traces ptrace.Traces) {
 				if endTime > startTime {
 					duration = float64(endTime-startTime) / float64(unitDivider)
 				}
-				key := p.buildKey(serviceName, span, p.dimensions, resourceAttr)
-
-				attributes, ok := p.metricKeyToDimensions.Get(key)
-				if !ok {
-					attributes = p.buildAttributes(serviceName, span, resourceAttr, p.dimensions)
-					p.metricKeyToDimensions.Add(key, attributes)
+				exprAttrs := pcommon.NewMap()
+				if len(p.exprDimensions) > 0 || len(p.eExprDimensions) > 0 {
+					tCtx := ottlspan.NewTransformContext(span, ils.Scope(), rspans.Resource(), ils, rspans)
+					evaluateExprDimensions(ctx, p.logger, p.exprDimensions, tCtx, exprAttrs)
 				}
+				key, attributes := p.getOrBuildAttributes(serviceName, span, p.dimensions, resourceAttr, exprAttrs, p.cardinalityLimits)
 				if !p.config.Histogram.Disable {
 					// aggregate histogram metrics
 					h := histograms.GetOrCreate(key, attributes)
@@ -431,12 +465,14 @@ func (p *connectorImp) aggregateMetrics(traces ptrace.Traces) {
 						resourceAttr.CopyTo(rscAndEventAttrs)
 						event.Attributes().CopyTo(rscAndEventAttrs)
 
-						eKey := p.buildKey(serviceName, span, eDimensions, rscAndEventAttrs)
-						eAttributes, ok := p.metricKeyToDimensions.Get(eKey)
-						if !ok {
-							eAttributes = p.buildAttributes(serviceName, span, rscAndEventAttrs, eDimensions)
-							p.metricKeyToDimensions.Add(eKey, eAttributes)
+						eExprAttrs := exprAttrs
+						if len(p.eExprDimensions) > 0 {
+							eExprAttrs = pcommon.NewMap()
+							exprAttrs.CopyTo(eExprAttrs)
+							eCtx := ottlspanevent.NewTransformContext(event, span, ils.Scope(), rspans.Resource(), ils, rspans)
+							evaluateExprDimensions(ctx, p.logger, p.eExprDimensions, eCtx, eExprAttrs)
 						}
+						eKey, eAttributes := p.getOrBuildAttributes(serviceName, span, eDimensions, rscAndEventAttrs, eExprAttrs, p.eCardinalityLimits)
 						e := events.GetOrCreate(eKey, eAttributes)
 						if p.config.Exemplars.Enabled && !span.TraceID().IsEmpty() {
 							e.AddExemplar(span.TraceID(), span.SpanID(), duration)
@@ -507,9 +543,35 @@ func contains(elements []string, value string) bool {
 	return false
 }
 
-func (p *connectorImp) buildAttributes(serviceName string, span ptrace.Span, resourceAttrs pcommon.Map, dimensions []utilattri.Dimension) pcommon.Map {
+// getOrBuildAttributes returns the metric key and attributes for the span, building and caching the attributes
+// if they are not cached yet. Dimension values exceeding their cardinality limit in limits are replaced by the
+// overflow value, and the resulting series are keyed by their attributes.
+func (p *connectorImp) getOrBuildAttributes(serviceName string, span ptrace.Span, dimensions []utilattri.Dimension, resourceOrEventAttrs pcommon.Map, exprAttrs pcommon.Map, limits map[string]*cardinalityLimit) (metrics.Key, pcommon.Map) {
+	key := p.buildKey(serviceName, span, dimensions, resourceOrEventAttrs, exprAttrs)
+	attributes, ok := p.metricKeyToDimensions.Get(key)
+	if ok {
+		if len(limits) == 0 || admitDimensions(limits, attributes, p.clock.Now()) {
+			return key, attributes
+		}
+		// A dimension value of the cached attributes expired, and no longer fits within its limit.
+		p.metricKeyToDimensions.Remove(key)
+	}
+	attributes = p.buildAttributes(serviceName, span, resourceOrEventAttrs, dimensions, exprAttrs)
+	if len(limits) > 0 && limitDimensions(limits, attributes, p.clock.Now()) {
+		// Overflowed series are not cached under the original key, so that its values are checked again
+		// once the limits are reset or expire.
+		key = overflowMetricKey(attributes)
+		if overflowAttrs, ok := p.metricKeyToDimensions.Get(key); ok {
+			return key, overflowAttrs
+		}
+	}
+	p.metricKeyToDimensions.Add(key, attributes)
+	return key, attributes
+}
+
+func (p *connectorImp) buildAttributes(serviceName string, span ptrace.Span, resourceAttrs pcommon.Map, dimensions []utilattri.Dimension, exprAttrs pcommon.Map) pcommon.Map {
 	attr := pcommon.NewMap()
-	attr.EnsureCapacity(4 + len(dimensions))
+	attr.EnsureCapacity(4 + len(dimensions) + exprAttrs.Len())
 	if !contains(p.config.ExcludeDimensions, serviceNameKey) {
 		attr.PutStr(serviceNameKey, serviceName)
 	}
@@ -527,6 +589,10 @@ func (p *connectorImp) buildAttributes(serviceName string, span ptrace.Span, res
 			v.CopyTo(attr.PutEmpty(d.Name))
 		}
 	}
+	exprAttrs.Range(func(k string, v pcommon.Value) bool {
+		v.CopyTo(attr.PutEmpty(k))
+		return true
+	})
 	return attr
 }
 
@@ -540,9 +606,10 @@ func concatDimensionValue(dest *bytes.Buffer, value string, prefixSep bool) {
 // buildKey builds the metric key from the service name and span metadata such as name, kind, status_code and
 // will attempt to add any additional dimensions the user has configured that match the span's attributes
 // or resource/event attributes. If the dimension exists in both, the span's attributes, being the most specific, takes precedence.
+// The values of expression dimensions, already evaluated into exprAttrs, are appended last.
 //
 // The metric key is a simple concatenation of dimension values, delimited by a null character.
-func (p *connectorImp) buildKey(serviceName string, span ptrace.Span, optionalDims []utilattri.Dimension, resourceOrEventAttrs pcommon.Map) metrics.Key {
+func (p *connectorImp) buildKey(serviceName string, span ptrace.Span, optionalDims []utilattri.Dimension, resourceOrEventAttrs pcommon.Map, exprAttrs pcommon.Map) metrics.Key {
 	p.keyBuf.Reset()
 	if !contains(p.config.ExcludeDimensions, serviceNameKey) {
 		concatDimensionValue(p.keyBuf, serviceName, false)
@@ -562,6 +629,10 @@ func (p *connectorImp) buildKey(serviceName string, span ptrace.Span, optionalDi
 			concatDimensionValue(p.keyBuf, v.AsString(), true)
 		}
 	}
+	exprAttrs.Range(func(_ string, v pcommon.Value) bool {
+		concatDimensionValue(p.keyBuf, v.AsString(), true)
+		return true
+	})
 
 	return metrics.Key(p.keyBuf.String())
 }
@@ -465,19 +465,19 @@ func newConnectorImp(defaultNullValue *string, histogramConfig func() HistogramC
 		ResourceMetricsKeyAttributes: resourceMetricsKeyAttributes,
 		Dimensions: []Dimension{
 			// Set nil defaults to force a lookup for the attribute in the span.
-			{stringAttrName, nil},
-			{intAttrName, nil},
-			{doubleAttrName, nil},
-			{boolAttrName, nil},
-			{mapAttrName, nil},
-			{arrayAttrName, nil},
-			{nullAttrName, defaultNullValue},
+			{Name: stringAttrName, Default: nil},
+			{Name: intAttrName, Default: nil},
+			{Name: doubleAttrName, Default: nil},
+			{Name: boolAttrName, Default: nil},
+			{Name: mapAttrName, Default: nil},
+			{Name: arrayAttrName, Default: nil},
+			{Name: nullAttrName, Default: defaultNullValue},
 			// Add a default value for an attribute that doesn't exist in a span
-			{notInSpanAttrName0, stringp("defaultNotInSpanAttrVal")},
+			{Name: notInSpanAttrName0, Default: stringp("defaultNotInSpanAttrVal")},
 			// Leave the default value unset to test that this dimension should not be added to the metric.
-			{notInSpanAttrName1, nil},
+			{Name: notInSpanAttrName1, Default: nil},
 			// Add a resource attribute to test "process" attributes like IP, host, region, cluster, etc.
-			{regionResourceAttrName, nil},
+			{Name: regionResourceAttrName, Default: nil},
 		},
 		Events:               eventsConfig(),
 		MetricsExpiration:    expiration,
@@ -505,11 +505,11 @@ func TestBuildKeySameServiceNameCharSequence(t *testing.T) {
 
 	span0 := ptrace.NewSpan()
 	span0.SetName("c")
-	k0 := c.buildKey("ab", span0, nil, pcommon.NewMap())
+	k0 := c.buildKey("ab", span0, nil, pcommon.NewMap(), pcommon.NewMap())
 
 	span1 := ptrace.NewSpan()
 	span1.SetName("bc")
-	k1 := c.buildKey("a", span1, nil, pcommon.NewMap())
+	k1 := c.buildKey("a", span1, nil, pcommon.NewMap(), pcommon.NewMap())
 
 	assert.NotEqual(t, k0, k1)
 	assert.Equal(t, metrics.Key("ab\u0000c\u0000SPAN_KIND_UNSPECIFIED\u0000STATUS_CODE_UNSET"), k0)
@@ -525,7 +525,7 @@ func TestBuildKeyExcludeDimensionsAll(t *testing.T) {
 
 	span0 := ptrace.NewSpan()
 	span0.SetName("spanName")
-	k0 := c.buildKey("serviceName", span0, nil, pcommon.NewMap())
+	k0 := c.buildKey("serviceName", span0, nil, pcommon.NewMap(), pcommon.NewMap())
 	assert.Equal(t, metrics.Key(""), k0)
 }
 
@@ -538,7 +538,7 @@ func TestBuildKeyExcludeWrongDimensions(t *testing.T) {
 
 	span0 := ptrace.NewSpan()
 	span0.SetName("spanName")
-	k0 := c.buildKey("serviceName", span0, nil, pcommon.NewMap())
+	k0 := c.buildKey("serviceName", span0, nil, pcommon.NewMap(), pcommon.NewMap())
 	assert.Equal(t, metrics.Key("serviceName"), k0)
 }
 
@@ -614,7 +614,7 @@ func TestBuildKeyWithDimensions(t *testing.T) {
 			span0 := ptrace.NewSpan()
 			assert.NoError(t, span0.Attributes().FromRaw(tc.spanAttrMap))
 			span0.SetName("c")
-			key := c.buildKey("ab", span0, tc.optionalDims, resAttr)
+			key := c.buildKey("ab", span0, tc.optionalDims, resAttr, pcommon.NewMap())
 			assert.Equal(t, metrics.Key(tc.wantKey), key)
 		})
 	}
@@ -1551,6 +1551,209 @@ func TestSpanMetrics_Events(t *testing.T) {
 		})
 	}
 }
+func TestSpanMetrics_ExpressionDimensions(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.Dimensions = []Dimension{
+		{Name: "span.route", Expression: `Concat([resource.attributes["service.name"], name], ":")`},
+		{Name: "span.missing", Expression: `attributes["does.not.exist"]`, Default: stringp("none")},
+	}
+	cfg.Events = EventsConfig{
+		Enabled:    true,
+		Dimensions: []Dimension{{Name: "error.class", Expression: `Concat(["error", attributes["exception.type"]], ".")`}},
+	}
+	c, err := newConnector(zaptest.NewLogger(t), cfg, clockwork.NewFakeClock())
+	require.NoError(t, err)
+
+	require.NoError(t, c.ConsumeTraces(context.Background(), buildSampleTrace()))
+	m := c.buildMetrics()
+
+	var seenEvents bool
+	for i := 0; i < m.ResourceMetrics().Len(); i++ {
+		rm := m.ResourceMetrics().At(i)
+		serviceName, ok := rm.Resource().Attributes().Get(conventions.AttributeServiceName)
+		require.True(t, ok)
+		metricSlice := rm.ScopeMetrics().At(0).Metrics()
+		for j := 0; j < metricSlice.Len(); j++ {
+			metric := metricSlice.At(j)
+			if metric.Name() != buildMetricName(DefaultNamespace, metricNameCalls) && metric.Name() != buildMetricName(DefaultNamespace, metricNameEvents) {
+				continue
+			}
+			dps := metric.Sum().DataPoints()
+			for k := 0; k < dps.Len(); k++ {
+				attrs := dps.At(k).Attributes()
+				spanName, ok := attrs.Get(spanNameKey)
+				require.True(t, ok)
+				route, ok := attrs.Get("span.route")
+				require.True(t, ok)
+				assert.Equal(t, serviceName.Str()+":"+spanName.Str(), route.Str())
+				missing, ok := attrs.Get("span.missing")
+				require.True(t, ok)
+				assert.Equal(t, "none", missing.Str())
+				if metric.Name() == buildMetricName(DefaultNamespace, metricNameEvents) {
+					seenEvents = true
+					errorClass, ok := attrs.Get("error.class")
+					require.True(t, ok)
+					assert.Equal(t, "error.NullPointerException", errorClass.Str())
+				}
+			}
+		}
+	}
+	assert.True(t, seenEvents)
+}
+
+// callsByUser returns the calls of the login spans by user ID.
+func callsByUser(t *testing.T, m pmetric.Metrics) map[string]int64 {
+	res := make(map[string]int64)
+	metric := m.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	require.Equal(t, buildMetricName(DefaultNamespace, metricNameCalls), metric.Name())
+	dps := metric.Sum().DataPoints()
+	for i := 0; i < dps.Len(); i++ {
+		attrs := dps.At(i).Attributes()
+		spanName, ok := attrs.Get(spanNameKey)
+		require.True(t, ok)
+		assert.Equal(t, "login", spanName.Str())
+		userID, ok := attrs.Get("user.id")
+		require.True(t, ok)
+		overflow, ok := attrs.Get(overflowKey)
+		assert.Equal(t, userID.Str() == overflowValue, ok)
+		if ok {
+			assert.True(t, overflow.Bool())
+		}
+		_, dup := res[userID.Str()]
+		assert.False(t, dup, "duplicate series for %s", userID.Str())
+		res[userID.Str()] += dps.At(i).IntValue()
+	}
+	return res
+}
+
+func TestSpanMetrics_DimensionCardinalityLimit(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.AggregationTemporality = delta
+	cfg.Dimensions = []Dimension{{Name: "user.id", CardinalityLimit: 2}}
+	c, err := newConnector(zaptest.NewLogger(t), cfg, clockwork.NewFakeClock())
+	require.NoError(t, err)
+
+	buildTrace := func(userIDs ...string) ptrace.Traces {
+		traces := ptrace.NewTraces()
+		rs := traces.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().PutStr(conventions.AttributeServiceName, "service-a")
+		spans := rs.ScopeSpans().AppendEmpty().Spans()
+		for _, userID := range userIDs {
+			span := spans.AppendEmpty()
+			span.SetName("login")
+			span.Attributes().PutStr("user.id", userID)
+		}
+		return traces
+	}
+
+	require.NoError(t, c.ConsumeTraces(context.Background(), buildTrace("a", "b", "c", "a", "d")))
+	assert.Equal(t, map[string]int64{"a": 2, "b": 1, overflowValue: 2}, callsByUser(t, c.buildMetrics()))
+
+	// Delta temporality starts tracking values from scratch after each flush.
+	c.resetState()
+	require.NoError(t, c.ConsumeTraces(context.Background(), buildTrace("c", "d", "a")))
+	assert.Equal(t, map[string]int64{"c": 1, "d": 1, overflowValue: 1}, callsByUser(t, c.buildMetrics()))
+}
+
+func TestSpanMetrics_DimensionCardinalityLimitExpiresWithCumulativeSeries(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.AggregationTemporality = cumulative
+	cfg.MetricsExpiration = time.Minute
+	cfg.Dimensions = []Dimension{{Name: "user.id", CardinalityLimit: 1}}
+	// An event dimension with the same name has its own limit.
+	cfg.Events = EventsConfig{Enabled: true, Dimensions: []Dimension{{Name: "user.id", CardinalityLimit: 5}}}
+	clock := clockwork.NewFakeClock()
+	c, err := newConnector(zaptest.NewLogger(t), cfg, clock)
+	require.NoError(t, err)
+
+	consume := func(userID string) {
+		traces := ptrace.NewTraces()
+		rs := traces.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().PutStr(conventions.AttributeServiceName, "service-a")
+		span := rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+		span.SetName("login")
+		span.Attributes().PutStr("user.id", userID)
+		span.Events().AppendEmpty().SetName("exception")
+		require.NoError(t, c.ConsumeTraces(context.Background(), traces))
+	}
+	userIDs := func(name string) []string {
+		var res []string
+		m := c.buildMetrics()
+		for i := 0; i < m.ResourceMetrics().Len(); i++ {
+			metrics := m.ResourceMetrics().At(i).ScopeMetrics().At(0).Metrics()
+			for j := 0; j < metrics.Len(); j++ {
+				if metrics.At(j).Name() != buildMetricName(DefaultNamespace, name) {
+					continue
+				}
+				dps := metrics.At(j).Sum().DataPoints()
+				for k := 0; k < dps.Len(); k++ {
+					v, _ := dps.At(k).Attributes().Get("user.id")
+					res = append(res, v.Str())
+				}
+			}
+		}
+		return res
+	}
+
+	consume("a")
+	consume("b")
+	assert.ElementsMatch(t, []string{"a", overflowValue}, userIDs(metricNameCalls))
+	assert.ElementsMatch(t, []string{"a", "b"}, userIDs(metricNameEvents))
+
+	// Once the series of "a" expires, its value no longer counts towards the limit.
+	// Expired resource metrics are dropped from the cache on the following flush.
+	clock.Advance(2 * time.Minute)
+	c.resetState()
+	c.resetState()
+	consume("b")
+	assert.ElementsMatch(t, []string{"b"}, userIDs(metricNameCalls))
+}
+
+func TestSpanMetrics_DimensionCardinalityLimitReadmitsCachedAttributes(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.AggregationTemporality = cumulative
+	cfg.MetricsExpiration = time.Minute
+	cfg.Dimensions = []Dimension{{Name: "user.id", CardinalityLimit: 1}}
+	clock := clockwork.NewFakeClock()
+	c, err := newConnector(zaptest.NewLogger(t), cfg, clock)
+	require.NoError(t, err)
+
+	consume := func(userID string) {
+		traces := ptrace.NewTraces()
+		rs := traces.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().PutStr(conventions.AttributeServiceName, "service-a")
+		span := rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+		span.SetName("login")
+		span.Attributes().PutStr("user.id", userID)
+		require.NoError(t, c.ConsumeTraces(context.Background(), traces))
+	}
+
+	consume("a")
+	assert.Equal(t, map[string]int64{"a": 1}, callsByUser(t, c.buildMetrics()))
+
+	// "a" expires while its attributes are still cached, and "b" takes its place within the limit.
+	clock.Advance(2 * time.Minute)
+	c.resetState()
+	c.resetState()
+	consume("b")
+	assert.Equal(t, map[string]int64{"b": 1}, callsByUser(t, c.buildMetrics()))
+
+	// The cached attributes of "a" must be admitted again, and overflow.
+	consume("a")
+	assert.Equal(t, map[string]int64{"b": 1, overflowValue: 1}, callsByUser(t, c.buildMetrics()))
+
+	// Once "b" expires in turn, "a" is admitted again.
+	clock.Advance(2 * time.Minute)
+	c.resetState()
+	c.resetState()
+	consume("a")
+	assert.Equal(t, map[string]int64{"a": 1}, callsByUser(t, c.buildMetrics()))
+}
+
 func TestExemplarsAreDiscardedAfterFlushing(t *testing.T) {
 	tests := []struct {
 		name            string
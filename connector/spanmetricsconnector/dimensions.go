@@ -0,0 +1,197 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package spanmetricsconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/spanmetricsconnector"
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/spanmetricsconnector/internal/metrics"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanevent"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+)
+
+const (
+	overflowKey = "otel.metric.overflow"
+	// overflowValue replaces dimension values exceeding their cardinality limit.
+	overflowValue = "otel.metric.overflow"
+)
+
+// exprDimension is a dimension whose value is computed by evaluating an OTTL value expression.
+type exprDimension[K any] struct {
+	name         string
+	defaultValue *pcommon.Value
+	expr         *ottl.ValueExpression[K]
+}
+
+func newExprDimensions[K any](cfgDims []Dimension, parser ottl.Parser[K]) ([]exprDimension[K], error) {
+	var dims []exprDimension[K]
+	for _, d := range cfgDims {
+		if d.Expression == "" {
+			continue
+		}
+		expr, err := parser.ParseValueExpression(d.Expression)
+		if err != nil {
+			return nil, err
+		}
+		dim := exprDimension[K]{name: d.Name, expr: expr}
+		if d.Default != nil {
+			val := pcommon.NewValueStr(*d.Default)
+			dim.defaultValue = &val
+		}
+		dims = append(dims, dim)
+	}
+	return dims, nil
+}
+
+func newSpanDimensionExpressions(cfgDims []Dimension, set component.TelemetrySettings) ([]exprDimension[ottlspan.TransformContext], error) {
+	parser, err := ottlspan.NewParser(ottlfuncs.StandardConverters[ottlspan.TransformContext](), set)
+	if err != nil {
+		return nil, err
+	}
+	return newExprDimensions(cfgDims, parser)
+}
+
+func newSpanEventDimensionExpressions(cfgDims []Dimension, set component.TelemetrySettings) ([]exprDimension[ottlspanevent.TransformContext], error) {
+	parser, err := ottlspanevent.NewParser(ottlfuncs.StandardConverters[ottlspanevent.TransformContext](), set)
+	if err != nil {
+		return nil, err
+	}
+	return newExprDimensions(cfgDims, parser)
+}
+
+// evaluateExprDimensions evaluates the expression dimensions for the given transform context and puts
+// the resulting values into dest. Expressions resolving to nil or failing to evaluate fall back to the
+// dimension default, if any, and are otherwise omitted.
+func evaluateExprDimensions[K any](ctx context.Context, logger *zap.Logger, dims []exprDimension[K], tCtx K, dest pcommon.Map) {
+	for _, d := range dims {
+		val, err := d.expr.Eval(ctx, tCtx)
+		if err != nil {
+			logger.Debug("Failed to evaluate dimension expression", zap.String("dimension", d.name), zap.Error(err))
+		}
+		if err == nil && val != nil {
+			if fromAnyErr := dest.PutEmpty(d.name).FromRaw(exprValueToRaw(val)); fromAnyErr == nil {
+				continue
+			}
+			dest.Remove(d.name)
+		}
+		if d.defaultValue != nil {
+			d.defaultValue.CopyTo(dest.PutEmpty(d.name))
+		}
+	}
+}
+
+// exprValueToRaw converts pdata values returned by OTTL paths into their raw representation.
+func exprValueToRaw(val any) any {
+	switch v := val.(type) {
+	case pcommon.Value:
+		return v.AsRaw()
+	case pcommon.Map:
+		return v.AsRaw()
+	case pcommon.Slice:
+		return v.AsRaw()
+	default:
+		return v
+	}
+}
+
+// cardinalityLimit tracks the distinct values seen for a dimension up to a maximum, together with the
+// time each value was last seen.
+type cardinalityLimit struct {
+	limit  int
+	values map[string]time.Time
+}
+
+// newCardinalityLimits returns the cardinality limits of one metric family, keyed by dimension name.
+func newCardinalityLimits(dims []Dimension) map[string]*cardinalityLimit {
+	limits := make(map[string]*cardinalityLimit)
+	for _, d := range dims {
+		if d.CardinalityLimit <= 0 {
+			continue
+		}
+		limits[d.Name] = &cardinalityLimit{
+			limit:  d.CardinalityLimit,
+			values: make(map[string]time.Time),
+		}
+	}
+	return limits
+}
+
+// admit records value as seen at now and reports whether it fits within the limit.
+func (c *cardinalityLimit) admit(value string, now time.Time) bool {
+	if _, ok := c.values[value]; !ok && len(c.values) >= c.limit {
+		return false
+	}
+	c.values[value] = now
+	return true
+}
+
+// admitDimensions admits the dimension values in attributes again, refreshing the last seen time of the tracked
+// ones. It reports whether all of them fit within their limits, which may no longer be the case for cached
+// attributes once their values expired.
+func admitDimensions(limits map[string]*cardinalityLimit, attributes pcommon.Map, now time.Time) bool {
+	admitted := true
+	for name, limit := range limits {
+		if v, ok := attributes.Get(name); ok && !limit.admit(v.AsString(), now) {
+			admitted = false
+		}
+	}
+	return admitted
+}
+
+// limitDimensions checks the dimension values in attributes against the given cardinality limits. Each value
+// exceeding its limit is replaced by overflowValue, leaving the other dimensions untouched, and the
+// otel.metric.overflow attribute is added. It reports whether any value overflowed.
+func limitDimensions(limits map[string]*cardinalityLimit, attributes pcommon.Map, now time.Time) bool {
+	overflowed := false
+	for name, limit := range limits {
+		if v, ok := attributes.Get(name); ok && !limit.admit(v.AsString(), now) {
+			v.SetStr(overflowValue)
+			overflowed = true
+		}
+	}
+	if overflowed {
+		attributes.PutBool(overflowKey, true)
+	}
+	return overflowed
+}
+
+// expireCardinalityLimits forgets the dimension values not seen since the given time.
+func expireCardinalityLimits(limits map[string]*cardinalityLimit, since time.Time) {
+	for _, limit := range limits {
+		for v, lastSeen := range limit.values {
+			if lastSeen.Before(since) {
+				delete(limit.values, v)
+			}
+		}
+	}
+}
+
+func (p *connectorImp) resetCardinalityLimits() {
+	for _, limits := range []map[string]*cardinalityLimit{p.cardinalityLimits, p.eCardinalityLimits} {
+		for _, limit := range limits {
+			clear(limit.values)
+		}
+	}
+}
+
+// overflowMetricKey builds the key of a series with overflowed dimension values from its attributes, so that all
+// series collapsing into the same attributes share one key.
+func overflowMetricKey(attributes pcommon.Map) metrics.Key {
+	var b bytes.Buffer
+	b.WriteString(metricKeySeparator + overflowKey)
+	attributes.Range(func(k string, v pcommon.Value) bool {
+		concatDimensionValue(&b, k, true)
+		concatDimensionValue(&b, v.AsString(), true)
+		return true
+	})
+	return metrics.Key(b.String())
+}
@@ -9,6 +9,8 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configcompression"
 	"go.uber.org/multierr"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/parquet"
 )
 
 // S3UploaderConfig contains aws s3 uploader related config to controls things
@@ -33,6 +35,7 @@ const (
 	OtlpJSON     MarshalerType = "otlp_json"
 	SumoIC       MarshalerType = "sumo_ic"
 	Body         MarshalerType = "body"
+	Parquet      MarshalerType = "parquet"
 )
 
 // Config contains the main configuration options for the s3 exporter
@@ -43,6 +46,9 @@ type Config struct {
 	// Encoding to apply. If present, overrides the marshaler configuration option.
 	Encoding              *component.ID `mapstructure:"encoding"`
 	EncodingFileExtension string        `mapstructure:"encoding_file_extension"`
+
+	// Parquet defines the encoding of the objects when the marshaler is parquet.
+	Parquet parquet.Config `mapstructure:"parquet"`
 }
 
 func (c *Config) Validate() error {
@@ -59,7 +65,7 @@ func (c *Config) Validate() error {
 			errs = multierr.Append(errs, errors.New("unknown compression type"))
 		}
 
-		if c.MarshalerName == SumoIC {
+		if c.MarshalerName == SumoIC || c.MarshalerName == Parquet {
 			errs = multierr.Append(errs, errors.New("marshaler does not support compression"))
 		}
 	}
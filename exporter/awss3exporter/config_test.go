@@ -15,6 +15,7 @@ import (
 	"go.uber.org/multierr"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awss3exporter/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/parquet"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -41,6 +42,7 @@ func TestLoadConfig(t *testing.T) {
 			S3Partition: "minute",
 		},
 		MarshalerName: "otlp_json",
+		Parquet:       parquet.NewDefaultConfig(),
 	}, e,
 	)
 }
@@ -70,6 +72,7 @@ func TestConfig(t *testing.T) {
 			Endpoint:    "http://endpoint.com",
 		},
 		MarshalerName: "otlp_json",
+		Parquet:       parquet.NewDefaultConfig(),
 	}, e,
 	)
 }
@@ -101,6 +104,7 @@ func TestConfigForS3CompatibleSystems(t *testing.T) {
 			DisableSSL:       true,
 		},
 		MarshalerName: "otlp_json",
+		Parquet:       parquet.NewDefaultConfig(),
 	}, e,
 	)
 }
@@ -176,6 +180,17 @@ func TestConfig_Validate(t *testing.T) {
 			}(),
 			errExpected: errors.New("region is required"),
 		},
+		{
+			name: "parquet with compression",
+			config: func() *Config {
+				c := createDefaultConfig().(*Config)
+				c.S3Uploader.S3Bucket = "foo"
+				c.S3Uploader.Compression = "gzip"
+				c.MarshalerName = Parquet
+				return c
+			}(),
+			errExpected: errors.New("marshaler does not support compression"),
+		},
 	}
 
 	for _, tt := range tests {
@@ -209,6 +224,7 @@ func TestMarshallerName(t *testing.T) {
 			S3Partition: "minute",
 		},
 		MarshalerName: "sumo_ic",
+		Parquet:       parquet.NewDefaultConfig(),
 	}, e,
 	)
 
@@ -221,9 +237,26 @@ func TestMarshallerName(t *testing.T) {
 			S3Partition: "minute",
 		},
 		MarshalerName: "otlp_proto",
+		Parquet:       parquet.NewDefaultConfig(),
 	}, e,
 	)
 
+	e = cfg.Exporters[component.MustNewIDWithName("awss3", "parquet")].(*Config)
+
+	assert.Equal(t, &Config{
+		S3Uploader: S3UploaderConfig{
+			Region:      "us-east-1",
+			S3Bucket:    "baz",
+			S3Partition: "minute",
+		},
+		MarshalerName: "parquet",
+		Parquet: parquet.Config{
+			Compression:                parquet.CompressionSnappy,
+			RowGroupSize:               10000,
+			PromotedResourceAttributes: []string{"service.name"},
+		},
+	}, e,
+	)
 }
 
 func TestCompressionName(t *testing.T) {
@@ -250,6 +283,7 @@ func TestCompressionName(t *testing.T) {
 			Compression: "gzip",
 		},
 		MarshalerName: "otlp_json",
+		Parquet:       parquet.NewDefaultConfig(),
 	}, e,
 	)
 
@@ -263,6 +297,7 @@ func TestCompressionName(t *testing.T) {
 			Compression: "none",
 		},
 		MarshalerName: "otlp_proto",
+		Parquet:       parquet.NewDefaultConfig(),
 	}, e,
 	)
 
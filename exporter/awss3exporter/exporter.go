@@ -5,6 +5,7 @@ package awss3exporter // import "github.com/open-telemetry/opentelemetry-collect
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"go.opentelemetry.io/collector/component"
@@ -42,6 +43,10 @@ func (e *s3Exporter) start(_ context.Context, host component.Host) error {
 		if m, err = newMarshalerFromEncoding(e.config.Encoding, e.config.EncodingFileExtension, host, e.logger); err != nil {
 			return err
 		}
+	} else if e.config.MarshalerName == Parquet {
+		if m, err = newParquetMarshaler(e.config.Parquet); err != nil {
+			return err
+		}
 	} else {
 		if m, err = newMarshaler(e.config.MarshalerName, e.logger); err != nil {
 			return fmt.Errorf("unknown marshaler %q", e.config.MarshalerName)
@@ -57,6 +62,10 @@ func (e *s3Exporter) Capabilities() consumer.Capabilities {
 }
 
 func (e *s3Exporter) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	if tm, ok := e.marshaler.(metricTablesMarshaler); ok {
+		return e.consumeMetricTables(ctx, tm, md)
+	}
+
 	buf, err := e.marshaler.MarshalMetrics(md)
 
 	if err != nil {
@@ -84,3 +93,17 @@ func (e *s3Exporter) ConsumeTraces(ctx context.Context, traces ptrace.Traces) er
 
 	return e.dataWriter.writeBuffer(ctx, buf, e.config, "traces", e.marshaler.format())
 }
+
+// consumeMetricTables writes one object per table, named after the table.
+func (e *s3Exporter) consumeMetricTables(ctx context.Context, tm metricTablesMarshaler, md pmetric.Metrics) error {
+	tables, err := tm.marshalMetricTables(md)
+	if err != nil {
+		return err
+	}
+
+	var errs error
+	for table, buf := range tables {
+		errs = errors.Join(errs, e.dataWriter.writeBuffer(ctx, buf, e.config, "metrics_"+table, e.marshaler.format()))
+	}
+	return errs
+}
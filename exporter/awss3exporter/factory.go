@@ -12,6 +12,7 @@ import (
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awss3exporter/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/parquet"
 )
 
 // NewFactory creates a factory for S3 exporter.
@@ -32,6 +33,7 @@ func createDefaultConfig() component.Config {
 			S3Partition: "minute",
 		},
 		MarshalerName: "otlp_json",
+		Parquet:       parquet.NewDefaultConfig(),
 	}
 }
 
@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package awss3exporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awss3exporter"
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/parquet"
+)
+
+// metricTablesMarshaler is implemented by the marshalers that split metrics into several
+// objects, keyed by the name of their table.
+type metricTablesMarshaler interface {
+	marshalMetricTables(md pmetric.Metrics) (map[string][]byte, error)
+}
+
+// parquetMarshaler writes each batch as a complete Parquet file. Metrics are split into one
+// file per metric type, as each type has its own schema.
+type parquetMarshaler struct {
+	marshaler *parquet.Marshaler
+}
+
+var _ metricTablesMarshaler = (*parquetMarshaler)(nil)
+
+func newParquetMarshaler(cfg parquet.Config) (*parquetMarshaler, error) {
+	m, err := parquet.NewMarshaler(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &parquetMarshaler{marshaler: m}, nil
+}
+
+func (m *parquetMarshaler) MarshalTraces(td ptrace.Traces) ([]byte, error) {
+	return m.marshaler.MarshalTraces(td)
+}
+
+func (m *parquetMarshaler) MarshalLogs(ld plog.Logs) ([]byte, error) {
+	return m.marshaler.MarshalLogs(ld)
+}
+
+func (m *parquetMarshaler) MarshalMetrics(pmetric.Metrics) ([]byte, error) {
+	return nil, errors.New("parquet metrics are split into one object per metric type")
+}
+
+func (m *parquetMarshaler) marshalMetricTables(md pmetric.Metrics) (map[string][]byte, error) {
+	files, err := m.marshaler.MarshalMetrics(md)
+	if err != nil {
+		return nil, err
+	}
+	tables := make(map[string][]byte, len(files))
+	for table, buf := range files {
+		tables[string(table)] = buf
+	}
+	return tables, nil
+}
+
+func (m *parquetMarshaler) format() string {
+	return "parquet"
+}
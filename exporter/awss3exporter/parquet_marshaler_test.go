@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package awss3exporter
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+// parquetMagic starts and ends every Parquet file.
+var parquetMagic = []byte("PAR1")
+
+type recordingWriter struct {
+	objects map[string][]byte
+	formats []string
+}
+
+func (w *recordingWriter) writeBuffer(_ context.Context, buf []byte, _ *Config, metadata string, format string) error {
+	w.objects[metadata] = buf
+	w.formats = append(w.formats, format)
+	return nil
+}
+
+func newParquetExporter(t *testing.T) (*s3Exporter, *recordingWriter) {
+	config := createDefaultConfig().(*Config)
+	config.MarshalerName = Parquet
+	writer := &recordingWriter{objects: map[string][]byte{}}
+	exporter := &s3Exporter{
+		config:     config,
+		dataWriter: writer,
+		logger:     zap.NewNop(),
+	}
+	require.NoError(t, exporter.start(context.Background(), nil))
+	return exporter, writer
+}
+
+func assertParquetFile(t *testing.T, buf []byte) {
+	assert.True(t, bytes.HasPrefix(buf, parquetMagic))
+	assert.True(t, bytes.HasSuffix(buf, parquetMagic))
+}
+
+func TestParquetMarshaler(t *testing.T) {
+	exporter, writer := newParquetExporter(t)
+	assert.Equal(t, "parquet", exporter.marshaler.format())
+
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("span")
+	require.NoError(t, exporter.ConsumeTraces(context.Background(), td))
+	require.NoError(t, exporter.ConsumeLogs(context.Background(), getTestLogs(t)))
+
+	require.Len(t, writer.objects, 2)
+	assertParquetFile(t, writer.objects["traces"])
+	assertParquetFile(t, writer.objects["logs"])
+	assert.Equal(t, []string{"parquet", "parquet"}, writer.formats)
+}
+
+func TestParquetMarshalerMetricTables(t *testing.T) {
+	exporter, writer := newParquetExporter(t)
+
+	md := pmetric.NewMetrics()
+	metrics := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	metrics.AppendEmpty().SetEmptyGauge().DataPoints().AppendEmpty().SetIntValue(1)
+	metrics.AppendEmpty().SetEmptyHistogram().DataPoints().AppendEmpty().SetCount(2)
+	require.NoError(t, exporter.ConsumeMetrics(context.Background(), md))
+
+	require.Len(t, writer.objects, 2)
+	assertParquetFile(t, writer.objects["metrics_gauge"])
+	assertParquetFile(t, writer.objects["metrics_histogram"])
+
+	_, err := exporter.marshaler.MarshalMetrics(md)
+	assert.Error(t, err)
+}
+
+func TestParquetMarshalerInvalidConfig(t *testing.T) {
+	config := createDefaultConfig().(*Config)
+	config.MarshalerName = Parquet
+	config.Parquet.RowGroupSize = 0
+	exporter := newS3Exporter(config, exportertest.NewNopSettings())
+	assert.EqualError(t, exporter.start(context.Background(), nil), "row_group_size must be positive")
+}
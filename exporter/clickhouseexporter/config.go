@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"regexp"
 	"time"
 
 	"github.com/ClickHouse/clickhouse-go/v2"
@@ -60,6 +61,104 @@ type Config struct {
 	AsyncInsert bool `mapstructure:"async_insert"`
 	// MetricsTables defines the table names for metric types.
 	MetricsTables MetricTablesConfig `mapstructure:"metrics_tables"`
+	// SchemaMigrationsTableName is the table recording the schema migrations applied to the other tables.
+	// default is `otel_schema_migrations`.
+	SchemaMigrationsTableName string `mapstructure:"schema_migrations_table_name"`
+	// ColumnMappings promotes attributes into dedicated materialized columns. The columns are added
+	// when `create_schema` is true.
+	ColumnMappings ColumnMappingsConfig `mapstructure:"column_mappings"`
+}
+
+// ColumnMappingsConfig defines the attributes promoted to columns for each signal.
+type ColumnMappingsConfig struct {
+	// Logs are added to the logs table.
+	Logs []ColumnMapping `mapstructure:"logs"`
+	// Traces are added to the traces table.
+	Traces []ColumnMapping `mapstructure:"traces"`
+	// Metrics are added to all the metrics tables.
+	Metrics []ColumnMapping `mapstructure:"metrics"`
+}
+
+// ColumnMapping defines a column materialized from an attribute.
+type ColumnMapping struct {
+	// Column is the name of the column.
+	Column string `mapstructure:"column"`
+	// Attribute is the key of the attribute.
+	Attribute string `mapstructure:"attribute"`
+	// Source is the attributes the key is looked up in: `resource`, `scope` (logs and metrics only)
+	// or `record` (log, span or data point attributes). default is `record`.
+	Source string `mapstructure:"source"`
+	// Type is the type of the column: `String`, `LowCardinality(String)`, `Int64`, `UInt64`, `Float64` or `Bool`.
+	// Values that cannot be converted are stored as the default value of the type. default is `LowCardinality(String)`.
+	Type string `mapstructure:"type"`
+	// Index is the type of the skip index added on the column, for example `bloom_filter(0.01)`, `set(100)` or `minmax`.
+	// No index is added when empty.
+	Index string `mapstructure:"index"`
+	// IndexGranularity is the granularity of the skip index. default is 1.
+	IndexGranularity int `mapstructure:"index_granularity"`
+}
+
+const (
+	attributeSourceResource = "resource"
+	attributeSourceScope    = "scope"
+	attributeSourceRecord   = "record"
+)
+
+var supportedColumnTypes = map[string]bool{
+	"String":                 true,
+	"LowCardinality(String)": true,
+	"Int64":                  true,
+	"UInt64":                 true,
+	"Float64":                true,
+	"Bool":                   true,
+}
+
+var (
+	columnNameRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	indexTypeRegexp  = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\([0-9., ]*\))?$`)
+)
+
+func (m ColumnMapping) source() string {
+	if m.Source == "" {
+		return attributeSourceRecord
+	}
+	return m.Source
+}
+
+func (m ColumnMapping) columnType() string {
+	if m.Type == "" {
+		return "LowCardinality(String)"
+	}
+	return m.Type
+}
+
+func (m ColumnMapping) granularity() int {
+	if m.IndexGranularity == 0 {
+		return 1
+	}
+	return m.IndexGranularity
+}
+
+func (m ColumnMapping) validate(signal string, sources attributeSources) error {
+	if !columnNameRegexp.MatchString(m.Column) {
+		return fmt.Errorf("column_mappings::%s: invalid column name %q", signal, m.Column)
+	}
+	if m.Attribute == "" {
+		return fmt.Errorf("column_mappings::%s: attribute of column %s must be specified", signal, m.Column)
+	}
+	if _, ok := sources[m.source()]; !ok {
+		return fmt.Errorf("column_mappings::%s: unsupported source %q for column %s", signal, m.Source, m.Column)
+	}
+	if !supportedColumnTypes[m.columnType()] {
+		return fmt.Errorf("column_mappings::%s: unsupported type %q for column %s", signal, m.Type, m.Column)
+	}
+	if m.Index != "" && !indexTypeRegexp.MatchString(m.Index) {
+		return fmt.Errorf("column_mappings::%s: invalid index type %q for column %s", signal, m.Index, m.Column)
+	}
+	if m.IndexGranularity < 0 {
+		return fmt.Errorf("column_mappings::%s: index_granularity of column %s must be positive", signal, m.Column)
+	}
+	return nil
 }
 
 type MetricTablesConfig struct {
@@ -82,6 +181,7 @@ type TableEngine struct {
 }
 
 const defaultDatabase = "default"
+const defaultSchemaMigrationsTableName = "otel_schema_migrations"
 const defaultTableEngineName = "MergeTree"
 const defaultMetricTableName = "otel_metrics"
 const defaultGaugeSuffix = "_gauge"
@@ -93,6 +193,7 @@ const defaultExpHistogramSuffix = "_exponential_histogram"
 var (
 	errConfigNoEndpoint      = errors.New("endpoint must be specified")
 	errConfigInvalidEndpoint = errors.New("endpoint must be url format")
+	errConfigNoMigrations    = errors.New("schema_migrations_table_name must be specified when create_schema is true")
 )
 
 // Validate the ClickHouse server configuration.
@@ -107,6 +208,19 @@ func (cfg *Config) Validate() (err error) {
 
 	cfg.buildMetricTableNames()
 
+	if cfg.CreateSchema && cfg.SchemaMigrationsTableName == "" {
+		err = errors.Join(err, errConfigNoMigrations)
+	}
+	for _, m := range cfg.ColumnMappings.Logs {
+		err = errors.Join(err, m.validate("logs", logsAttributeSources))
+	}
+	for _, m := range cfg.ColumnMappings.Traces {
+		err = errors.Join(err, m.validate("traces", tracesAttributeSources))
+	}
+	for _, m := range cfg.ColumnMappings.Metrics {
+		err = errors.Join(err, m.validate("metrics", metricsAttributeSources))
+	}
+
 	// Validate DSN with clickhouse driver.
 	// Last chance to catch invalid config.
 	if _, e := clickhouse.ParseDSN(dsn); e != nil {
@@ -81,7 +81,27 @@ func TestLoadConfig(t *testing.T) {
 					QueueSize:    100,
 					StorageID:    &storageID,
 				},
-				AsyncInsert: true,
+				AsyncInsert:               true,
+				SchemaMigrationsTableName: "otel_custom_migrations",
+				ColumnMappings: ColumnMappingsConfig{
+					Logs: []ColumnMapping{
+						{
+							Column:    "K8sNamespace",
+							Attribute: "k8s.namespace.name",
+							Source:    "resource",
+							Index:     "bloom_filter(0.01)",
+						},
+					},
+					Traces: []ColumnMapping{
+						{
+							Column:           "HttpStatusCode",
+							Attribute:        "http.response.status_code",
+							Type:             "Int64",
+							Index:            "minmax",
+							IndexGranularity: 4,
+						},
+					},
+				},
 			},
 		},
 	}
@@ -624,3 +644,77 @@ func TestClusterString(t *testing.T) {
 		})
 	}
 }
+
+func TestColumnMappingsValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  *Config
+		wantErr string
+	}{
+		{
+			name: "valid",
+			config: withDefaultConfig(func(cfg *Config) {
+				cfg.Endpoint = defaultEndpoint
+				cfg.ColumnMappings.Metrics = []ColumnMapping{{Column: "HostName", Attribute: "host.name", Source: "resource"}}
+			}),
+		},
+		{
+			name: "invalid column name",
+			config: withDefaultConfig(func(cfg *Config) {
+				cfg.Endpoint = defaultEndpoint
+				cfg.ColumnMappings.Logs = []ColumnMapping{{Column: "Host Name", Attribute: "host.name"}}
+			}),
+			wantErr: `column_mappings::logs: invalid column name "Host Name"`,
+		},
+		{
+			name: "missing attribute",
+			config: withDefaultConfig(func(cfg *Config) {
+				cfg.Endpoint = defaultEndpoint
+				cfg.ColumnMappings.Logs = []ColumnMapping{{Column: "HostName"}}
+			}),
+			wantErr: "column_mappings::logs: attribute of column HostName must be specified",
+		},
+		{
+			name: "scope source on traces",
+			config: withDefaultConfig(func(cfg *Config) {
+				cfg.Endpoint = defaultEndpoint
+				cfg.ColumnMappings.Traces = []ColumnMapping{{Column: "LibName", Attribute: "lib", Source: "scope"}}
+			}),
+			wantErr: `column_mappings::traces: unsupported source "scope" for column LibName`,
+		},
+		{
+			name: "unsupported type",
+			config: withDefaultConfig(func(cfg *Config) {
+				cfg.Endpoint = defaultEndpoint
+				cfg.ColumnMappings.Traces = []ColumnMapping{{Column: "Retries", Attribute: "retries", Type: "Int8"}}
+			}),
+			wantErr: `column_mappings::traces: unsupported type "Int8" for column Retries`,
+		},
+		{
+			name: "invalid index",
+			config: withDefaultConfig(func(cfg *Config) {
+				cfg.Endpoint = defaultEndpoint
+				cfg.ColumnMappings.Metrics = []ColumnMapping{{Column: "HostName", Attribute: "host.name", Index: "set(1); DROP TABLE x"}}
+			}),
+			wantErr: `column_mappings::metrics: invalid index type "set(1); DROP TABLE x" for column HostName`,
+		},
+		{
+			name: "no schema migrations table",
+			config: withDefaultConfig(func(cfg *Config) {
+				cfg.Endpoint = defaultEndpoint
+				cfg.SchemaMigrationsTableName = ""
+			}),
+			wantErr: errConfigNoMigrations.Error(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := component.ValidateConfig(tt.config)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
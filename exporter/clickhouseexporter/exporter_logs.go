@@ -50,7 +50,7 @@ func (e *logsExporter) start(ctx context.Context, _ component.Host) error {
 		return err
 	}
 
-	return createLogsTable(ctx, e.cfg, e.client)
+	return migrateTable(ctx, e.cfg, e.client, e.cfg.LogsTableName, logsMigrations, logsAttributeSources, e.cfg.ColumnMappings.Logs)
 }
 
 // shutdown will shut down the exporter.
@@ -242,11 +242,15 @@ func createDatabase(ctx context.Context, cfg *Config) error {
 	return nil
 }
 
-func createLogsTable(ctx context.Context, cfg *Config, db *sql.DB) error {
-	if _, err := db.ExecContext(ctx, renderCreateLogsTableSQL(cfg)); err != nil {
-		return fmt.Errorf("exec create logs table sql: %w", err)
-	}
-	return nil
+// logsMigrations are the versions of the schema of the logs table.
+var logsMigrations = []migration{
+	{
+		version:     1,
+		description: "create logs table",
+		statements: func(cfg *Config, _ string) []string {
+			return []string{renderCreateLogsTableSQL(cfg)}
+		},
+	},
 }
 
 func renderCreateLogsTableSQL(cfg *Config) string {
@@ -8,6 +8,7 @@ import (
 	"database/sql"
 	"database/sql/driver"
 	"errors"
+	"io"
 	"strings"
 	"testing"
 	"time"
@@ -50,7 +51,7 @@ func TestLogsExporter_New(t *testing.T) {
 	}{
 		"no dsn": {
 			config: withDefaultConfig(),
-			want:   failWithMsg("exec create logs table sql: parse dsn address failed"),
+			want:   failWithMsg("exec create schema migrations table sql: parse dsn address failed"),
 		},
 	}
 
@@ -78,7 +79,7 @@ func TestExporter_pushLogsData(t *testing.T) {
 		var items int
 		initClickhouseTestServer(t, func(query string, values []driver.Value) error {
 			t.Logf("%d, values:%+v", items, values)
-			if strings.HasPrefix(query, "INSERT") {
+			if strings.HasPrefix(query, "INSERT INTO otel_logs") {
 				items++
 			}
 			return nil
@@ -92,7 +93,7 @@ func TestExporter_pushLogsData(t *testing.T) {
 	})
 	t.Run("test check resource metadata", func(t *testing.T) {
 		initClickhouseTestServer(t, func(query string, values []driver.Value) error {
-			if strings.HasPrefix(query, "INSERT") {
+			if strings.HasPrefix(query, "INSERT INTO otel_logs") {
 				require.Equal(t, "https://opentelemetry.io/schemas/1.4.0", values[8])
 				require.Equal(t, map[string]string{
 					"service.name": "test-service",
@@ -105,7 +106,7 @@ func TestExporter_pushLogsData(t *testing.T) {
 	})
 	t.Run("test check scope metadata", func(t *testing.T) {
 		initClickhouseTestServer(t, func(query string, values []driver.Value) error {
-			if strings.HasPrefix(query, "INSERT") {
+			if strings.HasPrefix(query, "INSERT INTO otel_logs") {
 				require.Equal(t, "https://opentelemetry.io/schemas/1.7.0", values[10])
 				require.Equal(t, "io.opentelemetry.contrib.clickhouse", values[11])
 				require.Equal(t, "1.0.0", values[12])
@@ -120,7 +121,7 @@ func TestExporter_pushLogsData(t *testing.T) {
 	})
 	t.Run("test with only observed timestamp", func(t *testing.T) {
 		initClickhouseTestServer(t, func(query string, values []driver.Value) error {
-			if strings.HasPrefix(query, "INSERT") {
+			if strings.HasPrefix(query, "INSERT INTO otel_logs") {
 				require.NotEqual(t, "0", values[0])
 			}
 			return nil
@@ -232,22 +233,26 @@ type recorder func(query string, values []driver.Value) error
 
 type testClickhouseDriver struct {
 	recorder recorder
+	rows     [][]driver.Value
 }
 
 func (t *testClickhouseDriver) Open(_ string) (driver.Conn, error) {
 	return &testClickhouseDriverConn{
 		recorder: t.recorder,
+		rows:     t.rows,
 	}, nil
 }
 
 type testClickhouseDriverConn struct {
 	recorder recorder
+	rows     [][]driver.Value
 }
 
 func (t *testClickhouseDriverConn) Prepare(query string) (driver.Stmt, error) {
 	return &testClickhouseDriverStmt{
 		query:    query,
 		recorder: t.recorder,
+		rows:     t.rows,
 	}, nil
 }
 
@@ -266,6 +271,7 @@ func (*testClickhouseDriverConn) CheckNamedValue(_ *driver.NamedValue) error {
 type testClickhouseDriverStmt struct {
 	query    string
 	recorder recorder
+	rows     [][]driver.Value
 }
 
 func (*testClickhouseDriverStmt) Close() error {
@@ -281,7 +287,28 @@ func (t *testClickhouseDriverStmt) Exec(args []driver.Value) (driver.Result, err
 }
 
 func (t *testClickhouseDriverStmt) Query(_ []driver.Value) (driver.Rows, error) {
-	return nil, nil
+	return &testClickhouseDriverRows{rows: t.rows}, nil
+}
+
+type testClickhouseDriverRows struct {
+	rows [][]driver.Value
+}
+
+func (*testClickhouseDriverRows) Columns() []string {
+	return []string{"Version"}
+}
+
+func (*testClickhouseDriverRows) Close() error {
+	return nil
+}
+
+func (t *testClickhouseDriverRows) Next(dest []driver.Value) error {
+	if len(t.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, t.rows[0])
+	t.rows = t.rows[1:]
+	return nil
 }
 
 type testClickhouseDriverTx struct {
@@ -8,6 +8,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/pmetric"
@@ -51,8 +52,27 @@ func (e *metricsExporter) start(ctx context.Context, _ component.Host) error {
 		return err
 	}
 
-	ttlExpr := generateTTLExpr(e.cfg.TTL, "toDateTime(TimeUnix)")
-	return internal.NewMetricsTable(ctx, e.tablesConfig, e.cfg.clusterString(), e.cfg.tableEngineString(), ttlExpr, e.client)
+	for metricType, tableConfig := range e.tablesConfig {
+		migrations := metricsMigrations(metricType)
+		if err := migrateTable(ctx, e.cfg, e.client, tableConfig.Name, migrations, metricsAttributeSources, e.cfg.ColumnMappings.Metrics); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// metricsMigrations returns the versions of the schema of the table of a metric type.
+func metricsMigrations(metricType pmetric.MetricType) []migration {
+	return []migration{
+		{
+			version:     1,
+			description: "create " + strings.ToLower(metricType.String()) + " metrics table",
+			statements: func(cfg *Config, table string) []string {
+				ttlExpr := generateTTLExpr(cfg.TTL, "toDateTime(TimeUnix)")
+				return []string{internal.CreateMetricsTableSQL(metricType, table, cfg.clusterString(), cfg.tableEngineString(), ttlExpr)}
+			},
+		},
+	}
 }
 
 func generateMetricTablesConfigMapper(cfg *Config) internal.MetricTablesConfigMapper {
@@ -67,6 +67,9 @@ func testClusterConfig(t *testing.T, completion clusterTestCompletion) {
 	for _, tt := range tests {
 		t.Run("test cluster config "+tt.name, func(t *testing.T) {
 			initClickhouseTestServer(t, func(query string, _ []driver.Value) error {
+				if strings.HasPrefix(strings.ToLower(getQueryFirstLine(query)), "insert into") {
+					return nil
+				}
 				if tt.shouldPass {
 					require.NoError(t, checkClusterQueryDefinition(query, tt.cluster))
 				} else {
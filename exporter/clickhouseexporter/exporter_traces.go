@@ -50,7 +50,7 @@ func (e *tracesExporter) start(ctx context.Context, _ component.Host) error {
 		return err
 	}
 
-	return createTracesTable(ctx, e.cfg, e.client)
+	return migrateTable(ctx, e.cfg, e.client, e.cfg.TracesTableName, tracesMigrations, tracesAttributeSources, e.cfg.ColumnMappings.Traces)
 }
 
 // shutdown will shut down the exporter.
@@ -278,17 +278,20 @@ GROUP BY TraceId;
 `
 )
 
-func createTracesTable(ctx context.Context, cfg *Config, db *sql.DB) error {
-	if _, err := db.ExecContext(ctx, renderCreateTracesTableSQL(cfg)); err != nil {
-		return fmt.Errorf("exec create traces table sql: %w", err)
-	}
-	if _, err := db.ExecContext(ctx, renderCreateTraceIDTsTableSQL(cfg)); err != nil {
-		return fmt.Errorf("exec create traceID timestamp table sql: %w", err)
-	}
-	if _, err := db.ExecContext(ctx, renderTraceIDTsMaterializedViewSQL(cfg)); err != nil {
-		return fmt.Errorf("exec create traceID timestamp view sql: %w", err)
-	}
-	return nil
+// tracesMigrations are the versions of the schema of the traces table, and of the
+// trace ID timestamp table and view built from it.
+var tracesMigrations = []migration{
+	{
+		version:     1,
+		description: "create traces table and trace id timestamp view",
+		statements: func(cfg *Config, _ string) []string {
+			return []string{
+				renderCreateTracesTableSQL(cfg),
+				renderCreateTraceIDTsTableSQL(cfg),
+				renderTraceIDTsMaterializedViewSQL(cfg),
+			}
+		},
+	},
 }
 
 func renderInsertTracesSQL(cfg *Config) string {
@@ -49,6 +49,7 @@ func createDefaultConfig() component.Config {
 			Histogram:            internal.MetricTypeConfig{Name: defaultMetricTableName + defaultHistogramSuffix},
 			ExponentialHistogram: internal.MetricTypeConfig{Name: defaultMetricTableName + defaultExpHistogramSuffix},
 		},
+		SchemaMigrationsTableName: defaultSchemaMigrationsTableName,
 	}
 }
 
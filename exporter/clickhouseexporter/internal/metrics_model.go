@@ -56,15 +56,10 @@ func SetLogger(l *zap.Logger) {
 	logger = l
 }
 
-// NewMetricsTable create metric tables with an expiry time to storage metric telemetry data
-func NewMetricsTable(ctx context.Context, tablesConfig MetricTablesConfigMapper, cluster, engine, ttlExpr string, db *sql.DB) error {
-	for key, queryTemplate := range supportedMetricTypes {
-		query := fmt.Sprintf(queryTemplate, tablesConfig[key].Name, cluster, engine, ttlExpr)
-		if _, err := db.ExecContext(ctx, query); err != nil {
-			return fmt.Errorf("exec create metrics table sql: %w", err)
-		}
-	}
-	return nil
+// CreateMetricsTableSQL returns the statement creating the table of a metric type with an
+// expiry time to storage metric telemetry data
+func CreateMetricsTableSQL(metricType pmetric.MetricType, name, cluster, engine, ttlExpr string) string {
+	return fmt.Sprintf(supportedMetricTypes[metricType], name, cluster, engine, ttlExpr)
 }
 
 // NewMetricsModel create a model for contain different metric data
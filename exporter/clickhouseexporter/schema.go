@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clickhouseexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/clickhouseexporter"
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+const (
+	// language=ClickHouse SQL
+	createSchemaMigrationsTableSQL = `
+CREATE TABLE IF NOT EXISTS %s %s (
+	TableName String,
+	Version UInt32,
+	Description String,
+	AppliedAt DateTime DEFAULT now()
+) ENGINE = %s
+ORDER BY (TableName, Version);
+`
+	// language=ClickHouse SQL
+	selectSchemaMigrationsSQL = `SELECT Version FROM %s WHERE TableName = ?`
+	// language=ClickHouse SQL
+	insertSchemaMigrationSQL = `INSERT INTO %s (TableName, Version, Description) VALUES (?, ?, ?)`
+)
+
+// migration is a versioned change of the schema of a table. Its statements must be
+// idempotent, such as CREATE TABLE IF NOT EXISTS or ALTER TABLE ADD COLUMN IF NOT EXISTS,
+// since exporters starting at the same time may apply the same migration.
+type migration struct {
+	version     uint32
+	description string
+	statements  func(cfg *Config, table string) []string
+}
+
+// attributeSources maps the sources of column mappings to the attribute columns of a table.
+type attributeSources map[string]string
+
+var (
+	logsAttributeSources = attributeSources{
+		attributeSourceResource: "ResourceAttributes",
+		attributeSourceScope:    "ScopeAttributes",
+		attributeSourceRecord:   "LogAttributes",
+	}
+	tracesAttributeSources = attributeSources{
+		attributeSourceResource: "ResourceAttributes",
+		attributeSourceRecord:   "SpanAttributes",
+	}
+	metricsAttributeSources = attributeSources{
+		attributeSourceResource: "ResourceAttributes",
+		attributeSourceScope:    "ScopeAttributes",
+		attributeSourceRecord:   "Attributes",
+	}
+)
+
+// migrateTable brings the schema of table up to date. The migrations that are not recorded
+// in the schema migrations table are applied in order, then the mapped columns are added.
+func migrateTable(ctx context.Context, cfg *Config, db *sql.DB, table string, migrations []migration, sources attributeSources, mappings []ColumnMapping) error {
+	if _, err := db.ExecContext(ctx, renderCreateSchemaMigrationsTableSQL(cfg)); err != nil {
+		return fmt.Errorf("exec create schema migrations table sql: %w", err)
+	}
+
+	applied, err := appliedMigrations(ctx, cfg, db, table)
+	if err != nil {
+		return err
+	}
+	for _, m := range migrations {
+		if applied[m.version] {
+			continue
+		}
+		for _, statement := range m.statements(cfg, table) {
+			if _, err := db.ExecContext(ctx, statement); err != nil {
+				return fmt.Errorf("apply migration %d of table %s: %w", m.version, table, err)
+			}
+		}
+		if _, err := db.ExecContext(ctx, fmt.Sprintf(insertSchemaMigrationSQL, cfg.SchemaMigrationsTableName), table, m.version, m.description); err != nil {
+			return fmt.Errorf("record migration %d of table %s: %w", m.version, table, err)
+		}
+	}
+
+	for _, mapping := range mappings {
+		for _, statement := range renderColumnMappingSQL(cfg, table, sources, mapping) {
+			if _, err := db.ExecContext(ctx, statement); err != nil {
+				return fmt.Errorf("add column %s to table %s: %w", mapping.Column, table, err)
+			}
+		}
+	}
+	return nil
+}
+
+func appliedMigrations(ctx context.Context, cfg *Config, db *sql.DB, table string) (map[uint32]bool, error) {
+	rows, err := db.QueryContext(ctx, fmt.Sprintf(selectSchemaMigrationsSQL, cfg.SchemaMigrationsTableName), table)
+	if err != nil {
+		return nil, fmt.Errorf("query schema migrations: %w", err)
+	}
+	defer rows.Close()
+
+	applied := map[uint32]bool{}
+	for rows.Next() {
+		var version uint32
+		if err := rows.Scan(&version); err != nil {
+			return nil, fmt.Errorf("scan schema migrations: %w", err)
+		}
+		applied[version] = true
+	}
+	return applied, rows.Err()
+}
+
+func renderCreateSchemaMigrationsTableSQL(cfg *Config) string {
+	return fmt.Sprintf(createSchemaMigrationsTableSQL, cfg.SchemaMigrationsTableName, cfg.clusterString(), cfg.tableEngineString())
+}
+
+// renderColumnMappingSQL returns the statements adding the materialized column of mapping,
+// and its skip index if any.
+func renderColumnMappingSQL(cfg *Config, table string, sources attributeSources, mapping ColumnMapping) []string {
+	expr := fmt.Sprintf("%s['%s']", sources[mapping.source()], escapeStringLiteral(mapping.Attribute))
+	columnType := mapping.columnType()
+	if !strings.Contains(columnType, "String") {
+		expr = fmt.Sprintf("accurateCastOrDefault(%s, '%s')", expr, columnType)
+	}
+	statements := []string{
+		fmt.Sprintf("ALTER TABLE %s %s ADD COLUMN IF NOT EXISTS %s %s MATERIALIZED %s",
+			table, cfg.clusterString(), mapping.Column, columnType, expr),
+	}
+	if mapping.Index != "" {
+		statements = append(statements, fmt.Sprintf("ALTER TABLE %s %s ADD INDEX IF NOT EXISTS idx_%s %s TYPE %s GRANULARITY %d",
+			table, cfg.clusterString(), strings.ToLower(mapping.Column), mapping.Column, mapping.Index, mapping.granularity()))
+	}
+	return statements
+}
+
+var stringLiteralEscaper = strings.NewReplacer(`\`, `\\`, `'`, `\'`)
+
+func escapeStringLiteral(s string) string {
+	return stringLiteralEscaper.Replace(s)
+}
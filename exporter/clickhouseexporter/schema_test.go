@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clickhouseexporter
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrateTable(t *testing.T) {
+	migrations := []migration{
+		{
+			version:     1,
+			description: "create table",
+			statements: func(_ *Config, table string) []string {
+				return []string{"CREATE TABLE " + table}
+			},
+		},
+		{
+			version:     2,
+			description: "add column",
+			statements: func(_ *Config, table string) []string {
+				return []string{"ALTER TABLE " + table + " ADD COLUMN IF NOT EXISTS Foo String"}
+			},
+		},
+	}
+
+	var queries []string
+	var recorded [][]driver.Value
+	sql.Register(t.Name(), &testClickhouseDriver{
+		recorder: func(query string, values []driver.Value) error {
+			queries = append(queries, query)
+			if len(values) > 0 {
+				recorded = append(recorded, values)
+			}
+			return nil
+		},
+		// Version 1 is already applied.
+		rows: [][]driver.Value{{uint32(1)}},
+	})
+	db, err := sql.Open(t.Name(), "")
+	require.NoError(t, err)
+	defer db.Close()
+
+	cfg := withDefaultConfig()
+	mappings := []ColumnMapping{
+		{Column: "K8sNamespace", Attribute: "k8s.namespace.name", Source: "resource", Index: "bloom_filter(0.01)"},
+		{Column: "HttpStatusCode", Attribute: "http.response.status_code", Type: "Int64"},
+	}
+	require.NoError(t, migrateTable(context.Background(), cfg, db, "otel_logs", migrations, logsAttributeSources, mappings))
+
+	require.Len(t, queries, 6)
+	assert.Contains(t, queries[0], "CREATE TABLE IF NOT EXISTS otel_schema_migrations")
+	assert.Equal(t, "ALTER TABLE otel_logs ADD COLUMN IF NOT EXISTS Foo String", queries[1])
+	assert.Equal(t, "INSERT INTO otel_schema_migrations (TableName, Version, Description) VALUES (?, ?, ?)", queries[2])
+	assert.Equal(t, [][]driver.Value{{"otel_logs", uint32(2), "add column"}}, recorded)
+	assert.Equal(t, "ALTER TABLE otel_logs  ADD COLUMN IF NOT EXISTS K8sNamespace LowCardinality(String) MATERIALIZED ResourceAttributes['k8s.namespace.name']", queries[3])
+	assert.Equal(t, "ALTER TABLE otel_logs  ADD INDEX IF NOT EXISTS idx_k8snamespace K8sNamespace TYPE bloom_filter(0.01) GRANULARITY 1", queries[4])
+	assert.Equal(t, "ALTER TABLE otel_logs  ADD COLUMN IF NOT EXISTS HttpStatusCode Int64 MATERIALIZED accurateCastOrDefault(LogAttributes['http.response.status_code'], 'Int64')", queries[5])
+}
+
+func TestRenderColumnMappingSQL(t *testing.T) {
+	cfg := withDefaultConfig(func(cfg *Config) {
+		cfg.ClusterName = "cluster_a"
+	})
+	statements := renderColumnMappingSQL(cfg, "otel_traces", tracesAttributeSources, ColumnMapping{
+		Column:           "UserId",
+		Attribute:        "user's.id",
+		Type:             "String",
+		Index:            "set(100)",
+		IndexGranularity: 4,
+	})
+	assert.Equal(t, []string{
+		`ALTER TABLE otel_traces ON CLUSTER cluster_a ADD COLUMN IF NOT EXISTS UserId String MATERIALIZED SpanAttributes['user\'s.id']`,
+		`ALTER TABLE otel_traces ON CLUSTER cluster_a ADD INDEX IF NOT EXISTS idx_userid UserId TYPE set(100) GRANULARITY 4`,
+	}, statements)
+}
@@ -0,0 +1,213 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package elasticsearchexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/elasticsearchexporter"
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/elastic/go-docappender/v2"
+	"github.com/elastic/go-elasticsearch/v7/esapi"
+)
+
+// actionBulkIndexer issues bulk requests with the index or update action,
+// which docappender.BulkIndexer doesn't support.
+//
+// Unlike docappender.BulkIndexer, it buffers the documents uncompressed,
+// which keeps the documents to retry readily available.
+// It is NOT safe for concurrent use by multiple goroutines.
+type actionBulkIndexer struct {
+	config docappender.BulkIndexerConfig
+	action BulkAction
+
+	items []actionBulkIndexerItem
+	size  int
+}
+
+type actionBulkIndexerItem struct {
+	index            string
+	documentID       string
+	dynamicTemplates map[string]string
+	body             []byte
+	retries          int
+}
+
+// bulkActionMetadata is the metadata line of a bulk request item.
+type bulkActionMetadata struct {
+	Index            string            `json:"_index,omitempty"`
+	ID               string            `json:"_id,omitempty"`
+	DynamicTemplates map[string]string `json:"dynamic_templates,omitempty"`
+}
+
+// bulkActionResponse is the response of a bulk request, filtered
+// with the same filter_path as docappender.BulkIndexer.
+type bulkActionResponse struct {
+	Items []map[string]struct {
+		Index  string `json:"_index"`
+		Status int    `json:"status"`
+		Error  struct {
+			Type   string `json:"type"`
+			Reason string `json:"reason"`
+		} `json:"error"`
+	} `json:"items"`
+}
+
+func newActionBulkIndexer(config docappender.BulkIndexerConfig, action BulkAction) (*actionBulkIndexer, error) {
+	if config.Client == nil {
+		return nil, errors.New("client is nil")
+	}
+	if len(config.RetryOnDocumentStatus) == 0 {
+		config.RetryOnDocumentStatus = []int{http.StatusTooManyRequests}
+	}
+	return &actionBulkIndexer{config: config, action: action}, nil
+}
+
+// Add buffers an item.
+func (b *actionBulkIndexer) Add(item docappender.BulkIndexerItem) error {
+	var buf bytes.Buffer
+	if _, err := item.Body.WriteTo(&buf); err != nil {
+		return fmt.Errorf("failed to write bulk indexer item: %w", err)
+	}
+	b.items = append(b.items, actionBulkIndexerItem{
+		index:            item.Index,
+		documentID:       item.DocumentID,
+		dynamicTemplates: item.DynamicTemplates,
+		body:             buf.Bytes(),
+	})
+	b.size += buf.Len()
+	return nil
+}
+
+// Len returns the number of buffered bytes.
+func (b *actionBulkIndexer) Len() int {
+	return b.size
+}
+
+// Items returns the number of buffered items.
+func (b *actionBulkIndexer) Items() int {
+	return len(b.items)
+}
+
+// Flush executes a bulk request if there are any items buffered. Items rejected
+// with one of the RetryOnDocumentStatus statuses, and all the items when the
+// request fails because of a network error, a 429 or a 5xx status, remain
+// buffered until they reach MaxDocumentRetries.
+func (b *actionBulkIndexer) Flush(ctx context.Context) (docappender.BulkIndexerResponseStat, error) {
+	var stat docappender.BulkIndexerResponseStat
+	if len(b.items) == 0 {
+		return stat, nil
+	}
+	items := b.items
+	b.items = nil
+	b.size = 0
+
+	var body bytes.Buffer
+	for _, item := range items {
+		if err := b.writeItem(&body, item); err != nil {
+			return stat, err
+		}
+	}
+
+	req := esapi.BulkRequest{
+		Body:       bytes.NewReader(body.Bytes()),
+		Header:     make(http.Header),
+		FilterPath: []string{"items.*._index", "items.*.status", "items.*.error.type", "items.*.error.reason"},
+		Pipeline:   b.config.Pipeline,
+	}
+	res, err := req.Do(ctx, b.config.Client)
+	if err != nil {
+		b.requeue(items, &stat)
+		return stat, fmt.Errorf("failed to execute the request: %w", err)
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		if res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= 500 {
+			b.requeue(items, &stat)
+		}
+		return stat, fmt.Errorf("flush failed (%d): %s", res.StatusCode, res.String())
+	}
+
+	var resp bulkActionResponse
+	if err := json.NewDecoder(res.Body).Decode(&resp); err != nil {
+		return stat, fmt.Errorf("error decoding bulk response: %w", err)
+	}
+	for i, respItem := range resp.Items {
+		if i >= len(items) {
+			break
+		}
+		for _, result := range respItem {
+			if result.Error.Type == "" && result.Status < 300 {
+				stat.Indexed++
+				continue
+			}
+			item := items[i]
+			if slices.Contains(b.config.RetryOnDocumentStatus, result.Status) && item.retries < b.config.MaxDocumentRetries {
+				item.retries++
+				b.items = append(b.items, item)
+				b.size += len(item.body)
+				stat.RetriedDocs++
+				continue
+			}
+			failed := docappender.BulkIndexerResponseItem{
+				Index:    result.Index,
+				Status:   result.Status,
+				Position: i,
+			}
+			failed.Error.Type = result.Error.Type
+			failed.Error.Reason = result.Error.Reason
+			stat.FailedDocs = append(stat.FailedDocs, failed)
+		}
+	}
+	return stat, nil
+}
+
+// requeue buffers the items of a bulk request that failed as a whole again,
+// so that they are retried by the next flush until they reach MaxDocumentRetries.
+func (b *actionBulkIndexer) requeue(items []actionBulkIndexerItem, stat *docappender.BulkIndexerResponseStat) {
+	for _, item := range items {
+		if item.retries >= b.config.MaxDocumentRetries {
+			continue
+		}
+		item.retries++
+		b.items = append(b.items, item)
+		b.size += len(item.body)
+		stat.RetriedDocs++
+	}
+}
+
+func (b *actionBulkIndexer) writeItem(buf *bytes.Buffer, item actionBulkIndexerItem) error {
+	action := b.action
+	if item.documentID == "" {
+		// Documents can't be updated without an ID.
+		action = BulkActionIndex
+	}
+	metadata := bulkActionMetadata{
+		Index: item.index,
+		ID:    item.documentID,
+	}
+	if action != BulkActionUpdate {
+		// Dynamic templates are only supported by the create and index actions.
+		metadata.DynamicTemplates = item.dynamicTemplates
+	}
+	line, err := json.Marshal(map[BulkAction]bulkActionMetadata{action: metadata})
+	if err != nil {
+		return fmt.Errorf("failed to encode bulk action: %w", err)
+	}
+	buf.Write(line)
+	buf.WriteByte('\n')
+	if action == BulkActionUpdate {
+		buf.WriteString(`{"doc":`)
+		buf.Write(item.body)
+		buf.WriteString(`,"doc_as_upsert":true}`)
+	} else {
+		buf.Write(item.body)
+	}
+	buf.WriteByte('\n')
+	return nil
+}
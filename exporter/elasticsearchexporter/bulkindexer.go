@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"io"
+	"net/http"
 	"runtime"
 	"strings"
 	"sync"
@@ -29,7 +30,9 @@ type bulkIndexer interface {
 
 type bulkIndexerSession interface {
 	// Add adds a document to the bulk indexing session.
-	Add(ctx context.Context, index string, document io.WriterTo, dynamicTemplates map[string]string) error
+	//
+	// If documentID is empty, the ID of the document is generated by Elasticsearch.
+	Add(ctx context.Context, index string, documentID string, document io.WriterTo, dynamicTemplates map[string]string) error
 
 	// End must be called on the session object once it is no longer
 	// needed, in order to release any associated resources.
@@ -51,14 +54,82 @@ type bulkIndexerSession interface {
 	Flush(context.Context) error
 }
 
-func newBulkIndexer(logger *zap.Logger, client *elasticsearch.Client, config *Config) (bulkIndexer, error) {
+// docBulkIndexer issues bulk requests to Elasticsearch.
+//
+// It is implemented by docappender.BulkIndexer, which only supports the
+// create action, and by actionBulkIndexer for the index and update actions.
+type docBulkIndexer interface {
+	Add(docappender.BulkIndexerItem) error
+	Len() int
+	Items() int
+	Flush(context.Context) (docappender.BulkIndexerResponseStat, error)
+}
+
+func newDocBulkIndexer(config docappender.BulkIndexerConfig, action BulkAction, dedupConflicts bool, logger *zap.Logger) (docBulkIndexer, error) {
+	newIndexer := func() (docBulkIndexer, error) {
+		if action == BulkActionIndex || action == BulkActionUpdate {
+			return newActionBulkIndexer(config, action)
+		}
+		return docappender.NewBulkIndexer(config)
+	}
+	withoutID, err := newIndexer()
+	if err != nil || !dedupConflicts {
+		return withoutID, err
+	}
+	withID, err := newIndexer()
+	if err != nil {
+		return nil, err
+	}
+	return &dedupBulkIndexer{withID: withID, withoutID: withoutID, logger: logger}, nil
+}
+
+// dedupBulkIndexer issues the documents whose ID is derived by the exporter
+// and the other documents in separate bulk requests, so that the version
+// conflicts of the former only are considered duplicates. Version conflicts
+// of the other documents, e.g. of metric documents whose ID Elasticsearch
+// derives from their dimensions in time series data streams, are failures.
+type dedupBulkIndexer struct {
+	withID    docBulkIndexer
+	withoutID docBulkIndexer
+	logger    *zap.Logger
+}
+
+func (d *dedupBulkIndexer) Add(item docappender.BulkIndexerItem) error {
+	if item.DocumentID != "" {
+		return d.withID.Add(item)
+	}
+	return d.withoutID.Add(item)
+}
+
+func (d *dedupBulkIndexer) Len() int {
+	return d.withID.Len() + d.withoutID.Len()
+}
+
+func (d *dedupBulkIndexer) Items() int {
+	return d.withID.Items() + d.withoutID.Items()
+}
+
+func (d *dedupBulkIndexer) Flush(ctx context.Context) (docappender.BulkIndexerResponseStat, error) {
+	stat, err := d.withoutID.Flush(ctx)
+	withIDStat, withIDErr := d.withID.Flush(ctx)
+	withIDStat = dedupVersionConflicts(withIDStat, d.logger)
+	stat.Indexed += withIDStat.Indexed
+	stat.RetriedDocs += withIDStat.RetriedDocs
+	stat.FailedDocs = append(stat.FailedDocs, withIDStat.FailedDocs...)
+	return stat, errors.Join(err, withIDErr)
+}
+
+// newBulkIndexer returns a bulkIndexer. If dedupConflicts is true, documents
+// with an ID rejected by the create action because their ID already exists are
+// considered successfully indexed duplicates.
+func newBulkIndexer(logger *zap.Logger, client *elasticsearch.Client, config *Config, dedupConflicts bool) (bulkIndexer, error) {
 	if config.Batcher.Enabled != nil {
-		return newSyncBulkIndexer(logger, client, config), nil
+		return newSyncBulkIndexer(logger, client, config, dedupConflicts), nil
 	}
-	return newAsyncBulkIndexer(logger, client, config)
+	return newAsyncBulkIndexer(logger, client, config, dedupConflicts)
 }
 
-func newSyncBulkIndexer(logger *zap.Logger, client *elasticsearch.Client, config *Config) *syncBulkIndexer {
+func newSyncBulkIndexer(logger *zap.Logger, client *elasticsearch.Client, config *Config, dedupConflicts bool) *syncBulkIndexer {
 	var maxDocRetry int
 	if config.Retry.Enabled {
 		// max_requests includes initial attempt
@@ -72,23 +143,27 @@ func newSyncBulkIndexer(logger *zap.Logger, client *elasticsearch.Client, config
 			Pipeline:              config.Pipeline,
 			RetryOnDocumentStatus: config.Retry.RetryOnStatus,
 		},
-		flushTimeout: config.Timeout,
-		retryConfig:  config.Retry,
-		logger:       logger,
+		action:         config.BulkAction,
+		dedupConflicts: dedupConflicts,
+		flushTimeout:   config.Timeout,
+		retryConfig:    config.Retry,
+		logger:         logger,
 	}
 }
 
 type syncBulkIndexer struct {
-	config       docappender.BulkIndexerConfig
-	flushTimeout time.Duration
-	retryConfig  RetrySettings
-	logger       *zap.Logger
+	config         docappender.BulkIndexerConfig
+	action         BulkAction
+	dedupConflicts bool
+	flushTimeout   time.Duration
+	retryConfig    RetrySettings
+	logger         *zap.Logger
 }
 
-// StartSession creates a new docappender.BulkIndexer, and wraps
+// StartSession creates a new docBulkIndexer, and wraps
 // it with a syncBulkIndexerSession.
 func (s *syncBulkIndexer) StartSession(context.Context) (bulkIndexerSession, error) {
-	bi, err := docappender.NewBulkIndexer(s.config)
+	bi, err := newDocBulkIndexer(s.config, s.action, s.dedupConflicts, s.logger)
 	if err != nil {
 		return nil, err
 	}
@@ -105,12 +180,12 @@ func (s *syncBulkIndexer) Close(context.Context) error {
 
 type syncBulkIndexerSession struct {
 	s  *syncBulkIndexer
-	bi *docappender.BulkIndexer
+	bi docBulkIndexer
 }
 
 // Add adds an item to the sync bulk indexer session.
-func (s *syncBulkIndexerSession) Add(_ context.Context, index string, document io.WriterTo, dynamicTemplates map[string]string) error {
-	return s.bi.Add(docappender.BulkIndexerItem{Index: index, Body: document, DynamicTemplates: dynamicTemplates})
+func (s *syncBulkIndexerSession) Add(_ context.Context, index string, documentID string, document io.WriterTo, dynamicTemplates map[string]string) error {
+	return s.bi.Add(docappender.BulkIndexerItem{Index: index, DocumentID: documentID, Body: document, DynamicTemplates: dynamicTemplates})
 }
 
 // End is a no-op.
@@ -149,7 +224,7 @@ func (s *syncBulkIndexerSession) Flush(ctx context.Context) error {
 	}
 }
 
-func newAsyncBulkIndexer(logger *zap.Logger, client *elasticsearch.Client, config *Config) (*asyncBulkIndexer, error) {
+func newAsyncBulkIndexer(logger *zap.Logger, client *elasticsearch.Client, config *Config, dedupConflicts bool) (*asyncBulkIndexer, error) {
 	numWorkers := config.NumWorkers
 	if numWorkers == 0 {
 		numWorkers = runtime.NumCPU()
@@ -180,12 +255,12 @@ func newAsyncBulkIndexer(logger *zap.Logger, client *elasticsearch.Client, confi
 	pool.wg.Add(numWorkers)
 
 	for i := 0; i < numWorkers; i++ {
-		bi, err := docappender.NewBulkIndexer(docappender.BulkIndexerConfig{
+		bi, err := newDocBulkIndexer(docappender.BulkIndexerConfig{
 			Client:                client,
 			MaxDocumentRetries:    maxDocRetry,
 			Pipeline:              config.Pipeline,
 			RetryOnDocumentStatus: config.Retry.RetryOnStatus,
-		})
+		}, config.BulkAction, dedupConflicts, logger)
 		if err != nil {
 			return nil, err
 		}
@@ -244,9 +319,10 @@ func (a *asyncBulkIndexer) Close(ctx context.Context) error {
 // Add adds an item to the async bulk indexer session.
 //
 // Adding an item after a call to Close() will panic.
-func (s asyncBulkIndexerSession) Add(ctx context.Context, index string, document io.WriterTo, dynamicTemplates map[string]string) error {
+func (s asyncBulkIndexerSession) Add(ctx context.Context, index string, documentID string, document io.WriterTo, dynamicTemplates map[string]string) error {
 	item := docappender.BulkIndexerItem{
 		Index:            index,
+		DocumentID:       documentID,
 		Body:             document,
 		DynamicTemplates: dynamicTemplates,
 	}
@@ -268,7 +344,7 @@ func (s asyncBulkIndexerSession) Flush(context.Context) error {
 }
 
 type asyncBulkIndexerWorker struct {
-	indexer       *docappender.BulkIndexer
+	indexer       docBulkIndexer
 	items         <-chan docappender.BulkIndexerItem
 	flushInterval time.Duration
 	flushTimeout  time.Duration
@@ -316,7 +392,7 @@ func (w *asyncBulkIndexerWorker) flush() {
 
 func flushBulkIndexer(
 	ctx context.Context,
-	bi *docappender.BulkIndexer,
+	bi docBulkIndexer,
 	timeout time.Duration,
 	logger *zap.Logger,
 ) (docappender.BulkIndexerResponseStat, error) {
@@ -343,6 +419,23 @@ func flushBulkIndexer(
 	return stat, err
 }
 
+// dedupVersionConflicts considers the documents rejected because a document
+// with the same ID already exists as successfully indexed: they were already
+// indexed, e.g. by a previous attempt of a partially failed bulk request.
+func dedupVersionConflicts(stat docappender.BulkIndexerResponseStat, logger *zap.Logger) docappender.BulkIndexerResponseStat {
+	failedDocs := stat.FailedDocs[:0]
+	for _, resp := range stat.FailedDocs {
+		if resp.Status == http.StatusConflict && resp.Error.Type == "version_conflict_engine_exception" {
+			logger.Debug("document already indexed", zap.String("index", resp.Index))
+			stat.Indexed++
+			continue
+		}
+		failedDocs = append(failedDocs, resp)
+	}
+	stat.FailedDocs = failedDocs
+	return stat
+}
+
 func getErrorHint(index, errorType string) string {
 	if strings.HasPrefix(index, ".ds-metrics-") && errorType == "version_conflict_engine_exception" {
 		return "check the \"Known issues\" section of Elasticsearch Exporter docs"
@@ -12,6 +12,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/elastic/go-docappender/v2"
 	"github.com/elastic/go-elasticsearch/v7"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -62,12 +63,12 @@ func TestAsyncBulkIndexer_flushOnClose(t *testing.T) {
 	}})
 	require.NoError(t, err)
 
-	bulkIndexer, err := newAsyncBulkIndexer(zap.NewNop(), client, &cfg)
+	bulkIndexer, err := newAsyncBulkIndexer(zap.NewNop(), client, &cfg, false)
 	require.NoError(t, err)
 	session, err := bulkIndexer.StartSession(context.Background())
 	require.NoError(t, err)
 
-	assert.NoError(t, session.Add(context.Background(), "foo", strings.NewReader(`{"foo": "bar"}`), nil))
+	assert.NoError(t, session.Add(context.Background(), "foo", "", strings.NewReader(`{"foo": "bar"}`), nil))
 	assert.NoError(t, bulkIndexer.Close(context.Background()))
 	assert.Equal(t, int64(1), bulkIndexer.stats.docsIndexed.Load())
 }
@@ -101,12 +102,12 @@ func TestAsyncBulkIndexer_flush(t *testing.T) {
 			}})
 			require.NoError(t, err)
 
-			bulkIndexer, err := newAsyncBulkIndexer(zap.NewNop(), client, &tt.config)
+			bulkIndexer, err := newAsyncBulkIndexer(zap.NewNop(), client, &tt.config, false)
 			require.NoError(t, err)
 			session, err := bulkIndexer.StartSession(context.Background())
 			require.NoError(t, err)
 
-			assert.NoError(t, session.Add(context.Background(), "foo", strings.NewReader(`{"foo": "bar"}`), nil))
+			assert.NoError(t, session.Add(context.Background(), "foo", "", strings.NewReader(`{"foo": "bar"}`), nil))
 			// should flush
 			time.Sleep(100 * time.Millisecond)
 			assert.Equal(t, int64(1), bulkIndexer.stats.docsIndexed.Load())
@@ -177,12 +178,12 @@ func TestAsyncBulkIndexer_flush_error(t *testing.T) {
 			require.NoError(t, err)
 			core, observed := observer.New(zap.NewAtomicLevelAt(zapcore.DebugLevel))
 
-			bulkIndexer, err := newAsyncBulkIndexer(zap.New(core), client, &cfg)
+			bulkIndexer, err := newAsyncBulkIndexer(zap.New(core), client, &cfg, false)
 			require.NoError(t, err)
 			session, err := bulkIndexer.StartSession(context.Background())
 			require.NoError(t, err)
 
-			assert.NoError(t, session.Add(context.Background(), "foo", strings.NewReader(`{"foo": "bar"}`), nil))
+			assert.NoError(t, session.Add(context.Background(), "foo", "", strings.NewReader(`{"foo": "bar"}`), nil))
 			// should flush
 			time.Sleep(100 * time.Millisecond)
 			assert.Equal(t, int64(0), bulkIndexer.stats.docsIndexed.Load())
@@ -195,3 +196,183 @@ func TestAsyncBulkIndexer_flush_error(t *testing.T) {
 		})
 	}
 }
+
+func TestAsyncBulkIndexer_dedupConflicts(t *testing.T) {
+	cfg := Config{NumWorkers: 1, Flush: FlushSettings{Interval: time.Hour, Bytes: 1}}
+	client, err := elasticsearch.NewClient(elasticsearch.Config{Transport: &mockTransport{
+		RoundTripFunc: func(*http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: 200,
+				Header:     http.Header{"X-Elastic-Product": []string{"Elasticsearch"}},
+				Body: io.NopCloser(strings.NewReader(
+					`{"items":[{"create":{"_index":"foo","status":409,"error":{"type":"version_conflict_engine_exception","reason":""}}}]}`)),
+			}, nil
+		},
+	}})
+	require.NoError(t, err)
+	core, observed := observer.New(zap.NewAtomicLevelAt(zapcore.DebugLevel))
+
+	bulkIndexer, err := newAsyncBulkIndexer(zap.New(core), client, &cfg, true)
+	require.NoError(t, err)
+	session, err := bulkIndexer.StartSession(context.Background())
+	require.NoError(t, err)
+
+	assert.NoError(t, session.Add(context.Background(), "foo", "id1", strings.NewReader(`{"foo": "bar"}`), nil))
+	// The ID of the document is derived by Elasticsearch, e.g. in a time series data stream, the conflict is a failure.
+	assert.NoError(t, session.Add(context.Background(), "foo", "", strings.NewReader(`{"foo": "bar"}`), nil))
+	assert.NoError(t, bulkIndexer.Close(context.Background()))
+	assert.Equal(t, int64(1), bulkIndexer.stats.docsIndexed.Load())
+	assert.Equal(t, 1, observed.FilterMessage("failed to index document").Len())
+	assert.Equal(t, 1, observed.FilterMessage("document already indexed").Len())
+}
+
+func TestSyncBulkIndexer_bulkAction(t *testing.T) {
+	tests := []struct {
+		name       string
+		action     BulkAction
+		documentID string
+		want       string
+	}{
+		{
+			name:       "create",
+			action:     BulkActionCreate,
+			documentID: "id1",
+			want:       "{\"create\":{\"_id\":\"id1\",\"_index\":\"foo\"}}\n{\"foo\":\"bar\"}\n",
+		},
+		{
+			name:       "index",
+			action:     BulkActionIndex,
+			documentID: "id1",
+			want:       "{\"index\":{\"_index\":\"foo\",\"_id\":\"id1\"}}\n{\"foo\":\"bar\"}\n",
+		},
+		{
+			name:       "update",
+			action:     BulkActionUpdate,
+			documentID: "id1",
+			want:       "{\"update\":{\"_index\":\"foo\",\"_id\":\"id1\"}}\n{\"doc\":{\"foo\":\"bar\"},\"doc_as_upsert\":true}\n",
+		},
+		{
+			name:   "update without id",
+			action: BulkActionUpdate,
+			want:   "{\"index\":{\"_index\":\"foo\"}}\n{\"foo\":\"bar\"}\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var bodies []string
+			client, err := elasticsearch.NewClient(elasticsearch.Config{Transport: &mockTransport{
+				RoundTripFunc: func(r *http.Request) (*http.Response, error) {
+					if r.URL.Path == "/_bulk" {
+						body, err := io.ReadAll(r.Body)
+						require.NoError(t, err)
+						bodies = append(bodies, string(body))
+					}
+					return &http.Response{
+						Header: http.Header{"X-Elastic-Product": []string{"Elasticsearch"}},
+						Body:   io.NopCloser(strings.NewReader(successResp)),
+					}, nil
+				},
+			}})
+			require.NoError(t, err)
+
+			bulkIndexer := newSyncBulkIndexer(zap.NewNop(), client, &Config{BulkAction: tt.action}, false)
+			session, err := bulkIndexer.StartSession(context.Background())
+			require.NoError(t, err)
+			assert.NoError(t, session.Add(context.Background(), "foo", tt.documentID, strings.NewReader(`{"foo":"bar"}`), nil))
+			assert.NoError(t, session.Flush(context.Background()))
+			assert.Equal(t, []string{tt.want}, bodies)
+		})
+	}
+}
+
+func TestActionBulkIndexer_retry(t *testing.T) {
+	responses := []string{
+		`{"items":[{"index":{"_index":"foo","status":200}},{"index":{"_index":"foo","status":429,"error":{"type":"es_rejected_execution_exception"}}}]}`,
+		`{"items":[{"index":{"_index":"foo","status":429,"error":{"type":"es_rejected_execution_exception"}}}]}`,
+	}
+	var bodies []string
+	client, err := elasticsearch.NewClient(elasticsearch.Config{Transport: &mockTransport{
+		RoundTripFunc: func(r *http.Request) (*http.Response, error) {
+			if r.URL.Path != "/_bulk" {
+				return &http.Response{
+					Header: http.Header{"X-Elastic-Product": []string{"Elasticsearch"}},
+					Body:   io.NopCloser(strings.NewReader("{}")),
+				}, nil
+			}
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			bodies = append(bodies, string(body))
+			resp := responses[0]
+			responses = responses[1:]
+			return &http.Response{
+				Header: http.Header{"X-Elastic-Product": []string{"Elasticsearch"}},
+				Body:   io.NopCloser(strings.NewReader(resp)),
+			}, nil
+		},
+	}})
+	require.NoError(t, err)
+
+	bi, err := newActionBulkIndexer(docappender.BulkIndexerConfig{Client: client, MaxDocumentRetries: 1}, BulkActionIndex)
+	require.NoError(t, err)
+	require.NoError(t, bi.Add(docappender.BulkIndexerItem{Index: "foo", DocumentID: "id1", Body: strings.NewReader(`{"a":1}`)}))
+	require.NoError(t, bi.Add(docappender.BulkIndexerItem{Index: "foo", DocumentID: "id2", Body: strings.NewReader(`{"a":2}`)}))
+	assert.Equal(t, 2, bi.Items())
+
+	stat, err := bi.Flush(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), stat.Indexed)
+	assert.Equal(t, int64(1), stat.RetriedDocs)
+	assert.Empty(t, stat.FailedDocs)
+	assert.Equal(t, 1, bi.Items())
+
+	// The retry limit is reached.
+	stat, err = bi.Flush(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), stat.Indexed)
+	require.Len(t, stat.FailedDocs, 1)
+	assert.Equal(t, 429, stat.FailedDocs[0].Status)
+	assert.Equal(t, 0, bi.Items())
+
+	assert.Equal(t, []string{
+		"{\"index\":{\"_index\":\"foo\",\"_id\":\"id1\"}}\n{\"a\":1}\n{\"index\":{\"_index\":\"foo\",\"_id\":\"id2\"}}\n{\"a\":2}\n",
+		"{\"index\":{\"_index\":\"foo\",\"_id\":\"id2\"}}\n{\"a\":2}\n",
+	}, bodies)
+}
+
+func TestActionBulkIndexer_requestFailure(t *testing.T) {
+	statuses := []int{http.StatusTooManyRequests, http.StatusOK}
+	client, err := elasticsearch.NewClient(elasticsearch.Config{Transport: &mockTransport{
+		RoundTripFunc: func(r *http.Request) (*http.Response, error) {
+			if r.URL.Path != "/_bulk" {
+				return &http.Response{
+					Header: http.Header{"X-Elastic-Product": []string{"Elasticsearch"}},
+					Body:   io.NopCloser(strings.NewReader("{}")),
+				}, nil
+			}
+			status := statuses[0]
+			statuses = statuses[1:]
+			return &http.Response{
+				StatusCode: status,
+				Header:     http.Header{"X-Elastic-Product": []string{"Elasticsearch"}},
+				Body:       io.NopCloser(strings.NewReader(`{"items":[{"index":{"_index":"foo","status":200}},{"index":{"_index":"foo","status":200}}]}`)),
+			}, nil
+		},
+	}})
+	require.NoError(t, err)
+
+	bi, err := newActionBulkIndexer(docappender.BulkIndexerConfig{Client: client, MaxDocumentRetries: 1}, BulkActionIndex)
+	require.NoError(t, err)
+	require.NoError(t, bi.Add(docappender.BulkIndexerItem{Index: "foo", DocumentID: "id1", Body: strings.NewReader(`{"a":1}`)}))
+	require.NoError(t, bi.Add(docappender.BulkIndexerItem{Index: "foo", DocumentID: "id2", Body: strings.NewReader(`{"a":2}`)}))
+
+	// The items of the failed request are kept for the next flush.
+	stat, err := bi.Flush(context.Background())
+	assert.ErrorContains(t, err, "flush failed (429)")
+	assert.Equal(t, int64(2), stat.RetriedDocs)
+	assert.Equal(t, 2, bi.Items())
+
+	stat, err = bi.Flush(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), stat.Indexed)
+	assert.Equal(t, 0, bi.Items())
+}
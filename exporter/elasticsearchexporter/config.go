@@ -12,6 +12,7 @@ import (
 	"strings"
 	"time"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/exporter/exporterbatcher"
@@ -66,6 +67,19 @@ type Config struct {
 	// https://www.elastic.co/guide/en/elasticsearch/reference/current/ingest.html
 	Pipeline string `mapstructure:"pipeline"`
 
+	// LogsDocumentID configures how the IDs of the log documents are derived.
+	// By default, the IDs are generated by Elasticsearch.
+	LogsDocumentID DocumentIDSettings `mapstructure:"logs_document_id"`
+
+	// TracesDocumentID configures how the IDs of the span documents are derived.
+	// By default, the IDs are generated by Elasticsearch.
+	TracesDocumentID DocumentIDSettings `mapstructure:"traces_document_id"`
+
+	// BulkAction configures the action of the bulk requests: create, index or update.
+	//
+	// https://www.elastic.co/guide/en/elasticsearch/reference/current/docs-bulk.html
+	BulkAction BulkAction `mapstructure:"bulk_action"`
+
 	confighttp.ClientConfig `mapstructure:",squash"`
 	Authentication          AuthenticationSettings `mapstructure:",squash"`
 	Discovery               DiscoverySettings      `mapstructure:"discover"`
@@ -119,6 +133,69 @@ type DynamicIndexSetting struct {
 	Enabled bool `mapstructure:"enabled"`
 }
 
+// DocumentIDSettings defines how the IDs of the documents are derived.
+// At most one of Expression and HashFields may be set.
+type DocumentIDSettings struct {
+	// Expression is an OTTL value expression evaluating to the ID of the
+	// document, e.g. `attributes["log.record.uid"]`. If it evaluates to nil,
+	// the ID is generated by Elasticsearch.
+	Expression string `mapstructure:"expression"`
+
+	// HashFields are OTTL value expressions, e.g. `trace_id` and `span_id`,
+	// whose values are hashed together into the ID of the document.
+	HashFields []string `mapstructure:"hash_fields"`
+}
+
+func (s DocumentIDSettings) enabled() bool {
+	return s.Expression != "" || len(s.HashFields) > 0
+}
+
+// BulkAction is the action of the bulk requests indexing the documents.
+type BulkAction string
+
+const (
+	// BulkActionCreate creates the documents. Documents whose ID already
+	// exists are rejected with a version conflict, and are considered
+	// duplicates when their ID is derived by the exporter.
+	BulkActionCreate BulkAction = "create"
+	// BulkActionIndex creates the documents, or replaces the documents
+	// with the same ID.
+	BulkActionIndex BulkAction = "index"
+	// BulkActionUpdate creates the documents, or merges them into the
+	// documents with the same ID. Documents without ID are indexed.
+	BulkActionUpdate BulkAction = "update"
+)
+
+// validateBulkAction returns an error if the documents of a signal are written
+// to data streams, which only support the create action, and another action is
+// configured.
+func (cfg *Config) validateBulkAction(signal string, index string, dynamicIndex bool) error {
+	if cfg.BulkAction == "" || cfg.BulkAction == BulkActionCreate {
+		return nil
+	}
+	if dynamicIndex {
+		return fmt.Errorf("bulk_action %q is not supported with %s_dynamic_index, which routes documents to data streams that only support %q",
+			cfg.BulkAction, signal, BulkActionCreate)
+	}
+	if isDataStream(index) {
+		return fmt.Errorf("bulk_action %q is not supported by the %s index %q, which is a data stream that only supports %q",
+			cfg.BulkAction, signal, index, BulkActionCreate)
+	}
+	return nil
+}
+
+// isDataStream returns whether an index name matches one of the logs-*-*,
+// metrics-*-* and traces-*-* patterns of the built-in Elasticsearch index
+// templates, which create data streams.
+func isDataStream(index string) bool {
+	for _, typ := range []string{defaultDataStreamTypeLogs, defaultDataStreamTypeMetrics, defaultDataStreamTypeTraces} {
+		if rest, ok := strings.CutPrefix(index, typ+"-"); ok && strings.Contains(rest, "-") {
+			return true
+		}
+	}
+	return false
+}
+
 // AuthenticationSettings defines user authentication related settings.
 type AuthenticationSettings struct {
 	// User is used to configure HTTP Basic Authentication.
@@ -265,6 +342,24 @@ func (cfg *Config) Validate() error {
 		return fmt.Errorf("unknown mapping mode %q", cfg.Mapping.Mode)
 	}
 
+	switch cfg.BulkAction {
+	case BulkActionCreate, BulkActionIndex, BulkActionUpdate:
+	default:
+		return fmt.Errorf("unknown bulk action %q", cfg.BulkAction)
+	}
+	if err := cfg.LogsDocumentID.validate(); err != nil {
+		return fmt.Errorf("logs_document_id: %w", err)
+	}
+	if _, err := newLogsDocumentIDGetter(cfg.LogsDocumentID, component.TelemetrySettings{Logger: zap.NewNop()}); err != nil {
+		return fmt.Errorf("logs_document_id: %w", err)
+	}
+	if err := cfg.TracesDocumentID.validate(); err != nil {
+		return fmt.Errorf("traces_document_id: %w", err)
+	}
+	if _, err := newTracesDocumentIDGetter(cfg.TracesDocumentID, component.TelemetrySettings{Logger: zap.NewNop()}); err != nil {
+		return fmt.Errorf("traces_document_id: %w", err)
+	}
+
 	if cfg.Compression != "" {
 		// TODO support confighttp.ClientConfig.Compression
 		return errors.New("compression is not currently configurable")
@@ -272,6 +367,13 @@ func (cfg *Config) Validate() error {
 	return nil
 }
 
+func (s DocumentIDSettings) validate() error {
+	if s.Expression != "" && len(s.HashFields) > 0 {
+		return errors.New("expression and hash_fields cannot be both set")
+	}
+	return nil
+}
+
 func (cfg *Config) endpoints() ([]string, error) {
 	// Exactly one of endpoint, endpoints, or cloudid must be configured.
 	// If none are set, then $ELASTICSEARCH_URL may be specified instead.
@@ -72,7 +72,8 @@ func TestConfig(t *testing.T) {
 				TracesDynamicIndex: DynamicIndexSetting{
 					Enabled: false,
 				},
-				Pipeline: "mypipeline",
+				Pipeline:   "mypipeline",
+				BulkAction: BulkActionCreate,
 				ClientConfig: withDefaultHTTPClientConfig(func(cfg *confighttp.ClientConfig) {
 					cfg.Timeout = 2 * time.Minute
 					cfg.MaxIdleConns = &defaultMaxIdleConns
@@ -142,7 +143,8 @@ func TestConfig(t *testing.T) {
 				TracesDynamicIndex: DynamicIndexSetting{
 					Enabled: false,
 				},
-				Pipeline: "mypipeline",
+				Pipeline:   "mypipeline",
+				BulkAction: BulkActionCreate,
 				ClientConfig: withDefaultHTTPClientConfig(func(cfg *confighttp.ClientConfig) {
 					cfg.Timeout = 2 * time.Minute
 					cfg.MaxIdleConns = &defaultMaxIdleConns
@@ -212,7 +214,8 @@ func TestConfig(t *testing.T) {
 				TracesDynamicIndex: DynamicIndexSetting{
 					Enabled: false,
 				},
-				Pipeline: "mypipeline",
+				Pipeline:   "mypipeline",
+				BulkAction: BulkActionCreate,
 				ClientConfig: withDefaultHTTPClientConfig(func(cfg *confighttp.ClientConfig) {
 					cfg.Timeout = 2 * time.Minute
 					cfg.MaxIdleConns = &defaultMaxIdleConns
@@ -301,6 +304,16 @@ func TestConfig(t *testing.T) {
 				cfg.Batcher.Enabled = &enabled
 			}),
 		},
+		{
+			id:         component.NewIDWithName(metadata.Type, "document_id"),
+			configFile: "config.yaml",
+			expected: withDefaultConfig(func(cfg *Config) {
+				cfg.Endpoint = "https://elastic.example.com:9200"
+				cfg.BulkAction = BulkActionUpdate
+				cfg.LogsDocumentID.Expression = `attributes["log.record.uid"]`
+				cfg.TracesDocumentID.HashFields = []string{"trace_id", "span_id"}
+			}),
+		},
 	}
 
 	for _, tt := range tests {
@@ -391,6 +404,29 @@ func TestConfig_Validate(t *testing.T) {
 			}),
 			err: `compression is not currently configurable`,
 		},
+		"invalid bulk action": {
+			config: withDefaultConfig(func(cfg *Config) {
+				cfg.Endpoints = []string{"http://test:9200"}
+				cfg.BulkAction = "upsert"
+			}),
+			err: `unknown bulk action "upsert"`,
+		},
+		"document id expression and hash fields": {
+			config: withDefaultConfig(func(cfg *Config) {
+				cfg.Endpoints = []string{"http://test:9200"}
+				cfg.LogsDocumentID.Expression = `attributes["log.record.uid"]`
+				cfg.LogsDocumentID.HashFields = []string{"trace_id"}
+			}),
+			err: `logs_document_id: expression and hash_fields cannot be both set`,
+		},
+		"invalid document id expression": {
+			config: withDefaultConfig(func(cfg *Config) {
+				cfg.Endpoints = []string{"http://test:9200"}
+				cfg.TracesDocumentID.HashFields = []string{"trace_id", "invalid("}
+			}),
+			err: `traces_document_id: failed to parse document ID hash fields: unable to parse OTTL value expression "invalid(": ` +
+				`value expression has invalid syntax: 1:9: unexpected token "<EOF>" (expected ")" Key*)`,
+		},
 	}
 
 	for name, tt := range tests {
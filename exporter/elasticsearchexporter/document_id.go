@@ -0,0 +1,157 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package elasticsearchexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/elasticsearchexporter"
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+)
+
+// documentIDGetter derives the IDs of the documents from the telemetry they encode.
+//
+// A nil *documentIDGetter derives no IDs, leaving Elasticsearch to generate them.
+type documentIDGetter[K any] struct {
+	expression *ottl.ValueExpression[K]
+	hashFields []*ottl.ValueExpression[K]
+}
+
+func newLogsDocumentIDGetter(settings DocumentIDSettings, set component.TelemetrySettings) (*documentIDGetter[ottllog.TransformContext], error) {
+	if !settings.enabled() {
+		return nil, nil
+	}
+	parser, err := ottllog.NewParser(ottlfuncs.StandardConverters[ottllog.TransformContext](), set)
+	if err != nil {
+		return nil, err
+	}
+	return newDocumentIDGetter(settings, parser)
+}
+
+func newTracesDocumentIDGetter(settings DocumentIDSettings, set component.TelemetrySettings) (*documentIDGetter[ottlspan.TransformContext], error) {
+	if !settings.enabled() {
+		return nil, nil
+	}
+	parser, err := ottlspan.NewParser(ottlfuncs.StandardConverters[ottlspan.TransformContext](), set)
+	if err != nil {
+		return nil, err
+	}
+	return newDocumentIDGetter(settings, parser)
+}
+
+func newDocumentIDGetter[K any](settings DocumentIDSettings, parser ottl.Parser[K]) (*documentIDGetter[K], error) {
+	if settings.Expression != "" {
+		expression, err := parser.ParseValueExpression(settings.Expression)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse document ID expression: %w", err)
+		}
+		return &documentIDGetter[K]{expression: expression}, nil
+	}
+	hashFields, err := parser.ParseValueExpressions(settings.HashFields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse document ID hash fields: %w", err)
+	}
+	return &documentIDGetter[K]{hashFields: hashFields}, nil
+}
+
+// id returns the ID of the document encoding tCtx, or an empty string if
+// Elasticsearch should generate it.
+func (g *documentIDGetter[K]) id(ctx context.Context, tCtx K) (string, error) {
+	if g == nil {
+		return "", nil
+	}
+	if g.expression != nil {
+		v, err := g.expression.Eval(ctx, tCtx)
+		if err != nil {
+			return "", fmt.Errorf("failed to evaluate document ID expression: %w", err)
+		}
+		switch v := v.(type) {
+		case nil:
+			return "", nil
+		case string:
+			return v, nil
+		default:
+			return "", fmt.Errorf("document ID expression must evaluate to a string, got %T", v)
+		}
+	}
+
+	h := sha256.New()
+	for _, field := range g.hashFields {
+		v, err := field.Eval(ctx, tCtx)
+		if err != nil {
+			return "", fmt.Errorf("failed to evaluate document ID hash field: %w", err)
+		}
+		b, err := hashFieldBytes(v)
+		if err != nil {
+			return "", err
+		}
+		// Prefix every value with its length so that e.g. ("ab", "c") and
+		// ("a", "bc") don't hash to the same ID.
+		h.Write(binary.AppendUvarint(nil, uint64(len(b))))
+		h.Write(b)
+	}
+	return hashID(h.Sum(nil)), nil
+}
+
+// childID derives the ID of a document nested in the document with the given
+// ID, e.g. a span event in a span, or returns an empty string if parentID is empty.
+func childID(parentID, kind string, index int) string {
+	if parentID == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(parentID + "/" + kind + "/" + strconv.Itoa(index)))
+	return hashID(sum[:])
+}
+
+// hashID encodes the first 128 bits of a hash as a document ID, which is about
+// as long as the IDs generated by Elasticsearch.
+func hashID(sum []byte) string {
+	return base64.RawURLEncoding.EncodeToString(sum[:16])
+}
+
+func hashFieldBytes(v any) ([]byte, error) {
+	switch v := v.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []byte(v), nil
+	case []byte:
+		return v, nil
+	case bool:
+		return []byte(strconv.FormatBool(v)), nil
+	case int64:
+		return binary.BigEndian.AppendUint64(nil, uint64(v)), nil
+	case float64:
+		return binary.BigEndian.AppendUint64(nil, math.Float64bits(v)), nil
+	case time.Time:
+		return binary.BigEndian.AppendUint64(nil, uint64(v.UnixNano())), nil
+	case pcommon.TraceID:
+		return v[:], nil
+	case pcommon.SpanID:
+		return v[:], nil
+	case pcommon.Map:
+		return json.Marshal(v.AsRaw())
+	case pcommon.Slice:
+		return json.Marshal(v.AsRaw())
+	case pcommon.Value:
+		return hashFieldBytes(v.AsRaw())
+	case map[string]any, []any:
+		return json.Marshal(v)
+	default:
+		return nil, fmt.Errorf("unsupported document ID hash field type %T", v)
+	}
+}
@@ -20,6 +20,8 @@ import (
 	"go.opentelemetry.io/collector/pdata/ptrace"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/elasticsearchexporter/internal/objmodel"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
 )
 
 type elasticsearchExporter struct {
@@ -33,6 +35,9 @@ type elasticsearchExporter struct {
 	model          mappingModel
 	otel           bool
 
+	logsDocumentID   *documentIDGetter[ottllog.TransformContext]
+	tracesDocumentID *documentIDGetter[ottlspan.TransformContext]
+
 	wg          sync.WaitGroup // active sessions
 	bulkIndexer bulkIndexer
 }
@@ -76,7 +81,10 @@ func (e *elasticsearchExporter) Start(ctx context.Context, host component.Host)
 	if err != nil {
 		return err
 	}
-	bulkIndexer, err := newBulkIndexer(e.Logger, client, e.config)
+	// Version conflicts of documents whose ID is derived by the exporter are
+	// duplicates of documents already indexed.
+	dedupConflicts := e.logsDocumentID != nil || e.tracesDocumentID != nil
+	bulkIndexer, err := newBulkIndexer(e.Logger, client, e.config, dedupConflicts)
 	if err != nil {
 		return err
 	}
@@ -125,7 +133,12 @@ func (e *elasticsearchExporter) pushLogsData(ctx context.Context, ld plog.Logs)
 			scope := ill.Scope()
 			logs := ill.LogRecords()
 			for k := 0; k < logs.Len(); k++ {
-				if err := e.pushLogRecord(ctx, resource, rl.SchemaUrl(), logs.At(k), scope, ill.SchemaUrl(), session); err != nil {
+				documentID, err := e.logsDocumentID.id(ctx, ottllog.NewTransformContext(logs.At(k), scope, resource, ill, rl))
+				if err != nil {
+					errs = append(errs, err)
+					continue
+				}
+				if err := e.pushLogRecord(ctx, resource, rl.SchemaUrl(), logs.At(k), scope, ill.SchemaUrl(), documentID, session); err != nil {
 					if cerr := ctx.Err(); cerr != nil {
 						return cerr
 					}
@@ -152,6 +165,7 @@ func (e *elasticsearchExporter) pushLogRecord(
 	record plog.LogRecord,
 	scope pcommon.InstrumentationScope,
 	scopeSchemaURL string,
+	documentID string,
 	bulkIndexerSession bulkIndexerSession,
 ) error {
 	fIndex := e.index
@@ -171,7 +185,7 @@ func (e *elasticsearchExporter) pushLogRecord(
 	if err != nil {
 		return fmt.Errorf("failed to encode log event: %w", err)
 	}
-	return bulkIndexerSession.Add(ctx, fIndex, bytes.NewReader(document), nil)
+	return bulkIndexerSession.Add(ctx, fIndex, documentID, bytes.NewReader(document), nil)
 }
 
 func (e *elasticsearchExporter) pushMetricsData(
@@ -287,7 +301,7 @@ func (e *elasticsearchExporter) pushMetricsData(
 					errs = append(errs, err)
 					continue
 				}
-				if err := session.Add(ctx, fIndex, bytes.NewReader(docBytes), doc.DynamicTemplates()); err != nil {
+				if err := session.Add(ctx, fIndex, "", bytes.NewReader(docBytes), doc.DynamicTemplates()); err != nil {
 					if cerr := ctx.Err(); cerr != nil {
 						return cerr
 					}
@@ -351,7 +365,12 @@ func (e *elasticsearchExporter) pushTraceData(
 			spans := scopeSpan.Spans()
 			for k := 0; k < spans.Len(); k++ {
 				span := spans.At(k)
-				if err := e.pushTraceRecord(ctx, resource, il.SchemaUrl(), span, scope, scopeSpan.SchemaUrl(), session); err != nil {
+				documentID, err := e.tracesDocumentID.id(ctx, ottlspan.NewTransformContext(span, scope, resource, scopeSpan, il))
+				if err != nil {
+					errs = append(errs, err)
+					continue
+				}
+				if err := e.pushTraceRecord(ctx, resource, il.SchemaUrl(), span, scope, scopeSpan.SchemaUrl(), documentID, session); err != nil {
 					if cerr := ctx.Err(); cerr != nil {
 						return cerr
 					}
@@ -359,7 +378,7 @@ func (e *elasticsearchExporter) pushTraceData(
 				}
 				for ii := 0; ii < span.Events().Len(); ii++ {
 					spanEvent := span.Events().At(ii)
-					if err := e.pushSpanEvent(ctx, resource, il.SchemaUrl(), span, spanEvent, scope, scopeSpan.SchemaUrl(), session); err != nil {
+					if err := e.pushSpanEvent(ctx, resource, il.SchemaUrl(), span, spanEvent, scope, scopeSpan.SchemaUrl(), childID(documentID, "event", ii), session); err != nil {
 						errs = append(errs, err)
 					}
 				}
@@ -383,6 +402,7 @@ func (e *elasticsearchExporter) pushTraceRecord(
 	span ptrace.Span,
 	scope pcommon.InstrumentationScope,
 	scopeSchemaURL string,
+	documentID string,
 	bulkIndexerSession bulkIndexerSession,
 ) error {
 	fIndex := e.index
@@ -402,7 +422,7 @@ func (e *elasticsearchExporter) pushTraceRecord(
 	if err != nil {
 		return fmt.Errorf("failed to encode trace record: %w", err)
 	}
-	return bulkIndexerSession.Add(ctx, fIndex, bytes.NewReader(document), nil)
+	return bulkIndexerSession.Add(ctx, fIndex, documentID, bytes.NewReader(document), nil)
 }
 
 func (e *elasticsearchExporter) pushSpanEvent(
@@ -413,6 +433,7 @@ func (e *elasticsearchExporter) pushSpanEvent(
 	spanEvent ptrace.SpanEvent,
 	scope pcommon.InstrumentationScope,
 	scopeSchemaURL string,
+	documentID string,
 	bulkIndexerSession bulkIndexerSession,
 ) error {
 	fIndex := e.index
@@ -436,5 +457,5 @@ func (e *elasticsearchExporter) pushSpanEvent(
 	if err != nil {
 		return err
 	}
-	return bulkIndexerSession.Add(ctx, fIndex, bytes.NewReader(docBytes), nil)
+	return bulkIndexerSession.Add(ctx, fIndex, documentID, bytes.NewReader(docBytes), nil)
 }
@@ -48,6 +48,52 @@ func TestExporterLogs(t *testing.T) {
 		rec.WaitItems(2)
 	})
 
+	t.Run("publish with document id expression", func(t *testing.T) {
+		rec := newBulkRecorder()
+		server := newESTestServer(t, func(docs []itemRequest) ([]itemResponse, error) {
+			rec.Record(docs)
+			return itemsAllOK(docs)
+		})
+
+		exporter := newTestLogsExporter(t, server.URL, func(cfg *Config) {
+			cfg.LogsDocumentID.Expression = `attributes["log.record.uid"]`
+		})
+		logs := newLogsWithAttributes(map[string]any{"log.record.uid": "uid1"}, nil, nil)
+		logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().AppendEmpty()
+		mustSendLogs(t, exporter, logs)
+
+		rec.WaitItems(2)
+		docs := rec.Items()
+		var ids []string
+		for _, doc := range docs {
+			ids = append(ids, gjson.GetBytes(doc.Action, "create._id").String())
+		}
+		// The log record without log.record.uid gets an ID generated by Elasticsearch.
+		assert.ElementsMatch(t, []string{"uid1", ""}, ids)
+	})
+
+	t.Run("publish with update bulk action", func(t *testing.T) {
+		rec := newBulkRecorder()
+		server := newESTestServer(t, func(docs []itemRequest) ([]itemResponse, error) {
+			rec.Record(docs)
+			return itemsAllOK(docs)
+		})
+
+		exporter := newTestLogsExporter(t, server.URL, func(cfg *Config) {
+			cfg.BulkAction = BulkActionUpdate
+			// Data streams only support the create action.
+			cfg.LogsIndex = "otel-logs"
+			cfg.LogsDocumentID.Expression = `attributes["log.record.uid"]`
+		})
+		mustSendLogs(t, exporter, newLogsWithAttributes(map[string]any{"log.record.uid": "uid1"}, nil, nil))
+
+		rec.WaitItems(1)
+		docs := rec.Items()
+		assert.Equal(t, "uid1", gjson.GetBytes(docs[0].Action, "update._id").String())
+		assert.True(t, gjson.GetBytes(docs[0].Document, "doc_as_upsert").Bool())
+		assert.True(t, gjson.GetBytes(docs[0].Document, "doc").IsObject())
+	})
+
 	t.Run("publish with ecs encoding", func(t *testing.T) {
 		rec := newBulkRecorder()
 		server := newESTestServer(t, func(docs []itemRequest) ([]itemResponse, error) {
@@ -1170,6 +1216,38 @@ func TestExporterTraces(t *testing.T) {
 		rec.WaitItems(2)
 	})
 
+	t.Run("publish with document id hash fields", func(t *testing.T) {
+		rec := newBulkRecorder()
+		server := newESTestServer(t, func(docs []itemRequest) ([]itemResponse, error) {
+			rec.Record(docs)
+			return itemsAllOK(docs)
+		})
+
+		exporter := newTestTracesExporter(t, server.URL, func(cfg *Config) {
+			cfg.Mapping.Mode = "otel"
+			cfg.TracesDocumentID.HashFields = []string{"trace_id", "span_id"}
+		})
+
+		span := ptrace.NewSpan()
+		span.SetTraceID(pcommon.TraceID([16]byte{1}))
+		span.SetSpanID(pcommon.SpanID([8]byte{1}))
+		span.Events().AppendEmpty().SetName("event")
+		mustSendSpans(t, exporter, span)
+		mustSendSpans(t, exporter, span)
+
+		rec.WaitItems(4)
+		docs := rec.Items()
+		var ids []string
+		for _, doc := range docs {
+			id := gjson.GetBytes(doc.Action, "create._id").String()
+			assert.NotEmpty(t, id)
+			ids = append(ids, id)
+		}
+		// The same span and span event are sent twice with the same IDs.
+		assert.ElementsMatch(t, ids[:2], ids[2:])
+		assert.NotEqual(t, ids[0], ids[1])
+	})
+
 	t.Run("publish with dynamic index, prefix_suffix", func(t *testing.T) {
 
 		rec := newBulkRecorder()
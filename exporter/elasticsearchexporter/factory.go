@@ -61,6 +61,7 @@ func createDefaultConfig() component.Config {
 		TracesDynamicIndex: DynamicIndexSetting{
 			Enabled: false,
 		},
+		BulkAction: BulkActionCreate,
 		Retry: RetrySettings{
 			Enabled:         true,
 			MaxRequests:     3,
@@ -111,8 +112,16 @@ func createLogsExporter(
 		index = cf.Index
 	}
 	logConfigDeprecationWarnings(cf, set.Logger)
+	if err := cf.validateBulkAction("logs", index, cf.LogsDynamicIndex.Enabled); err != nil {
+		return nil, err
+	}
 
 	exporter := newExporter(cf, set, index, cf.LogsDynamicIndex.Enabled)
+	documentID, err := newLogsDocumentIDGetter(cf.LogsDocumentID, set.TelemetrySettings)
+	if err != nil {
+		return nil, err
+	}
+	exporter.logsDocumentID = documentID
 
 	return exporterhelper.NewLogsExporter(
 		ctx,
@@ -130,6 +139,9 @@ func createMetricsExporter(
 ) (exporter.Metrics, error) {
 	cf := cfg.(*Config)
 	logConfigDeprecationWarnings(cf, set.Logger)
+	if err := cf.validateBulkAction("metrics", cf.MetricsIndex, cf.MetricsDynamicIndex.Enabled); err != nil {
+		return nil, err
+	}
 
 	exporter := newExporter(cf, set, cf.MetricsIndex, cf.MetricsDynamicIndex.Enabled)
 
@@ -148,8 +160,16 @@ func createTracesExporter(ctx context.Context,
 ) (exporter.Traces, error) {
 	cf := cfg.(*Config)
 	logConfigDeprecationWarnings(cf, set.Logger)
+	if err := cf.validateBulkAction("traces", cf.TracesIndex, cf.TracesDynamicIndex.Enabled); err != nil {
+		return nil, err
+	}
 
 	exporter := newExporter(cf, set, cf.TracesIndex, cf.TracesDynamicIndex.Enabled)
+	documentID, err := newTracesDocumentIDGetter(cf.TracesDocumentID, set.TelemetrySettings)
+	if err != nil {
+		return nil, err
+	}
+	exporter.tracesDocumentID = documentID
 
 	return exporterhelper.NewTracesExporter(
 		ctx,
@@ -61,6 +61,25 @@ func TestFactory_CreateTracesExporter(t *testing.T) {
 	require.NoError(t, exporter.Shutdown(context.Background()))
 }
 
+func TestFactory_BulkActionDataStream(t *testing.T) {
+	factory := NewFactory()
+	cfg := withDefaultConfig(func(cfg *Config) {
+		cfg.Endpoints = []string{"http://test:9200"}
+		cfg.BulkAction = BulkActionIndex
+	})
+	params := exportertest.NewNopSettings()
+
+	_, err := factory.CreateLogsExporter(context.Background(), params, cfg)
+	assert.EqualError(t, err, `bulk_action "index" is not supported by the logs index "logs-generic-default", which is a data stream that only supports "create"`)
+	_, err = factory.CreateMetricsExporter(context.Background(), params, cfg)
+	assert.EqualError(t, err, `bulk_action "index" is not supported with metrics_dynamic_index, which routes documents to data streams that only support "create"`)
+
+	cfg.TracesIndex = "otel-traces"
+	exporter, err := factory.CreateTracesExporter(context.Background(), params, cfg)
+	require.NoError(t, err)
+	require.NoError(t, exporter.Shutdown(context.Background()))
+}
+
 func TestFactory_CreateLogsAndTracesExporterWithDeprecatedIndexOption(t *testing.T) {
 	factory := NewFactory()
 	cfg := withDefaultConfig(func(cfg *Config) {
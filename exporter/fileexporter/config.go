@@ -11,6 +11,8 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/parquet"
 )
 
 const (
@@ -38,6 +40,7 @@ type Config struct {
 	// Options:
 	// - json[default]:  OTLP json bytes.
 	// - proto:  OTLP binary protobuf bytes.
+	// - parquet:  Apache Parquet files, one directory per table under Path.
 	FormatType string `mapstructure:"format"`
 
 	// Encoding defines the encoding of the telemetry data.
@@ -54,6 +57,9 @@ type Config struct {
 
 	// GroupBy enables writing to separate files based on a resource attribute.
 	GroupBy *GroupBy `mapstructure:"group_by"`
+
+	// Parquet defines the encoding and the rotation of the files when FormatType is parquet.
+	Parquet *Parquet `mapstructure:"parquet"`
 }
 
 // Rotation an option to rolling log files
@@ -94,6 +100,19 @@ type GroupBy struct {
 	MaxOpenFiles int `mapstructure:"max_open_files"`
 }
 
+// Parquet defines how telemetry is written to Parquet files.
+type Parquet struct {
+	parquet.Config `mapstructure:",squash"`
+
+	// MaxFileMegabytes is the size in megabytes after which a file is closed and a new one
+	// is started. Zero means no size limit. Default is 100.
+	MaxFileMegabytes int `mapstructure:"max_file_megabytes"`
+
+	// MaxFileAge is the duration after which a file is closed and a new one is started,
+	// even if it's smaller than MaxFileMegabytes. Zero means no age limit. Default is 1h.
+	MaxFileAge time.Duration `mapstructure:"max_file_age"`
+}
+
 var _ component.Config = (*Config)(nil)
 
 // Validate checks if the exporter configuration is valid
@@ -107,7 +126,7 @@ func (cfg *Config) Validate() error {
 	if cfg.Append && cfg.Rotation != nil {
 		return fmt.Errorf("append and rotation enabled at the same time is not supported")
 	}
-	if cfg.FormatType != formatTypeJSON && cfg.FormatType != formatTypeProto {
+	if cfg.FormatType != formatTypeJSON && cfg.FormatType != formatTypeProto && cfg.FormatType != formatTypeParquet {
 		return errors.New("format type is not supported")
 	}
 	if cfg.Compression != "" && cfg.Compression != compressionZSTD {
@@ -132,6 +151,41 @@ func (cfg *Config) Validate() error {
 		}
 	}
 
+	if cfg.FormatType == formatTypeParquet && cfg.Encoding == nil {
+		return cfg.validateParquet()
+	}
+
+	return nil
+}
+
+func (cfg *Config) validateParquet() error {
+	if cfg.Parquet == nil {
+		return errors.New("parquet must be set when format is parquet")
+	}
+	if cfg.Append {
+		return errors.New("append is not supported with the parquet format")
+	}
+	if cfg.Compression != "" {
+		return errors.New("compression is not supported with the parquet format, use parquet::compression instead")
+	}
+	if cfg.Rotation != nil {
+		return errors.New("rotation is not supported with the parquet format, use parquet::max_file_megabytes and parquet::max_file_age instead")
+	}
+	if cfg.GroupBy != nil && cfg.GroupBy.Enabled {
+		return errors.New("group_by is not supported with the parquet format")
+	}
+	return nil
+}
+
+// Validate checks the rotation settings, the encoding settings are validated by the embedded
+// parquet.Config.
+func (p *Parquet) Validate() error {
+	if p.MaxFileMegabytes < 0 {
+		return errors.New("max_file_megabytes must not be negative")
+	}
+	if p.MaxFileAge < 0 {
+		return errors.New("max_file_age must not be negative")
+	}
 	return nil
 }
 
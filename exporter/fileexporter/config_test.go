@@ -14,6 +14,7 @@ import (
 	"go.opentelemetry.io/collector/confmap/confmaptest"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fileexporter/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/parquet"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -39,6 +40,7 @@ func TestLoadConfig(t *testing.T) {
 				},
 				FormatType:    formatTypeJSON,
 				FlushInterval: time.Second,
+				Parquet:       newDefaultParquetConfig(),
 				GroupBy: &GroupBy{
 					MaxOpenFiles:      defaultMaxOpenFiles,
 					ResourceAttribute: defaultResourceAttribute,
@@ -58,6 +60,7 @@ func TestLoadConfig(t *testing.T) {
 				FormatType:    formatTypeProto,
 				Compression:   compressionZSTD,
 				FlushInterval: time.Second,
+				Parquet:       newDefaultParquetConfig(),
 				GroupBy: &GroupBy{
 					MaxOpenFiles:      defaultMaxOpenFiles,
 					ResourceAttribute: defaultResourceAttribute,
@@ -73,6 +76,7 @@ func TestLoadConfig(t *testing.T) {
 					MaxBackups: defaultMaxBackups,
 				},
 				FlushInterval: time.Second,
+				Parquet:       newDefaultParquetConfig(),
 				GroupBy: &GroupBy{
 					MaxOpenFiles:      defaultMaxOpenFiles,
 					ResourceAttribute: defaultResourceAttribute,
@@ -89,6 +93,7 @@ func TestLoadConfig(t *testing.T) {
 				},
 				FormatType:    formatTypeJSON,
 				FlushInterval: time.Second,
+				Parquet:       newDefaultParquetConfig(),
 				GroupBy: &GroupBy{
 					MaxOpenFiles:      defaultMaxOpenFiles,
 					ResourceAttribute: defaultResourceAttribute,
@@ -109,6 +114,7 @@ func TestLoadConfig(t *testing.T) {
 				Path:          "./flushed",
 				FlushInterval: 5,
 				FormatType:    formatTypeJSON,
+				Parquet:       newDefaultParquetConfig(),
 				GroupBy: &GroupBy{
 					MaxOpenFiles:      defaultMaxOpenFiles,
 					ResourceAttribute: defaultResourceAttribute,
@@ -121,6 +127,7 @@ func TestLoadConfig(t *testing.T) {
 				Path:          "./flushed",
 				FlushInterval: 5 * time.Second,
 				FormatType:    formatTypeJSON,
+				Parquet:       newDefaultParquetConfig(),
 				GroupBy: &GroupBy{
 					MaxOpenFiles:      defaultMaxOpenFiles,
 					ResourceAttribute: defaultResourceAttribute,
@@ -133,6 +140,7 @@ func TestLoadConfig(t *testing.T) {
 				Path:          "./flushed",
 				FlushInterval: 500 * time.Millisecond,
 				FormatType:    formatTypeJSON,
+				Parquet:       newDefaultParquetConfig(),
 				GroupBy: &GroupBy{
 					MaxOpenFiles:      defaultMaxOpenFiles,
 					ResourceAttribute: defaultResourceAttribute,
@@ -153,6 +161,7 @@ func TestLoadConfig(t *testing.T) {
 				Path:          "./group_by/*.json",
 				FlushInterval: time.Second,
 				FormatType:    formatTypeJSON,
+				Parquet:       newDefaultParquetConfig(),
 				GroupBy: &GroupBy{
 					Enabled:           true,
 					MaxOpenFiles:      10,
@@ -166,6 +175,7 @@ func TestLoadConfig(t *testing.T) {
 				Path:          "./group_by/*.json",
 				FlushInterval: time.Second,
 				FormatType:    formatTypeJSON,
+				Parquet:       newDefaultParquetConfig(),
 				GroupBy: &GroupBy{
 					Enabled:           true,
 					MaxOpenFiles:      defaultMaxOpenFiles,
@@ -185,6 +195,40 @@ func TestLoadConfig(t *testing.T) {
 			id:           component.NewIDWithName(metadata.Type, "group_by_empty_resource_attribute"),
 			errorMessage: "resource_attribute must not be empty when group_by is enabled",
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "parquet"),
+			expected: &Config{
+				Path:          "./parquet",
+				FlushInterval: time.Second,
+				FormatType:    formatTypeParquet,
+				Parquet: &Parquet{
+					Config: parquet.Config{
+						Compression:                parquet.CompressionSnappy,
+						RowGroupSize:               1000,
+						PromotedResourceAttributes: []string{"service.name"},
+						PromotedAttributes:         []string{"http.route"},
+					},
+					MaxFileMegabytes: 50,
+					MaxFileAge:       10 * time.Minute,
+				},
+				GroupBy: &GroupBy{
+					MaxOpenFiles:      defaultMaxOpenFiles,
+					ResourceAttribute: defaultResourceAttribute,
+				},
+			},
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "parquet_group_by_error"),
+			errorMessage: "group_by is not supported with the parquet format",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "parquet_compression_error"),
+			errorMessage: "compression is not supported with the parquet format, use parquet::compression instead",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "parquet_invalid_config"),
+			errorMessage: `unsupported compression "lz4", must be one of zstd, snappy, gzip or none`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -21,6 +21,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fileexporter/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sharedcomponent"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/parquet"
 )
 
 const (
@@ -28,8 +29,9 @@ const (
 	defaultMaxBackups = 100
 
 	// the format of encoded telemetry data
-	formatTypeJSON  = "json"
-	formatTypeProto = "proto"
+	formatTypeJSON    = "json"
+	formatTypeProto   = "proto"
+	formatTypeParquet = "parquet"
 
 	// the type of compression codec
 	compressionZSTD = "zstd"
@@ -37,6 +39,9 @@ const (
 	defaultMaxOpenFiles = 100
 
 	defaultResourceAttribute = "fileexporter.path_segment"
+
+	defaultMaxFileMegabytes = 100
+	defaultMaxFileAge       = time.Hour
 )
 
 type FileExporter interface {
@@ -64,6 +69,15 @@ func createDefaultConfig() component.Config {
 			ResourceAttribute: defaultResourceAttribute,
 			MaxOpenFiles:      defaultMaxOpenFiles,
 		},
+		Parquet: newDefaultParquetConfig(),
+	}
+}
+
+func newDefaultParquetConfig() *Parquet {
+	return &Parquet{
+		Config:           parquet.NewDefaultConfig(),
+		MaxFileMegabytes: defaultMaxFileMegabytes,
+		MaxFileAge:       defaultMaxFileAge,
 	}
 }
 
@@ -133,6 +147,13 @@ func getOrCreateFileExporter(cfg component.Config, logger *zap.Logger) FileExpor
 }
 
 func newFileExporter(conf *Config, logger *zap.Logger) FileExporter {
+	if conf.FormatType == formatTypeParquet && conf.Encoding == nil {
+		return &parquetFileExporter{
+			conf:   conf,
+			logger: logger,
+		}
+	}
+
 	if conf.GroupBy == nil || !conf.GroupBy.Enabled {
 		return &fileExporter{
 			conf: conf,
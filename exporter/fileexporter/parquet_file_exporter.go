@@ -0,0 +1,207 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fileexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fileexporter"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/apache/arrow/go/v17/arrow"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/parquet"
+)
+
+const (
+	parquetFileExtension = ".parquet"
+	// inProgressSuffix is appended to the name of the files being written, so that query
+	// engines reading <path>/<table>/*.parquet only see complete files. Files with this suffix
+	// left behind by a crash can't be read, as the footer is written when the file is closed.
+	inProgressSuffix = ".tmp"
+	// maxAgeCheckInterval is the maximum interval between two checks of the age of the files.
+	maxAgeCheckInterval = time.Second
+)
+
+// parquetFileExporter writes telemetry to Parquet files, one directory per table under the
+// configured path. Files are rotated when they reach the maximum size or age.
+type parquetFileExporter struct {
+	conf    *Config
+	logger  *zap.Logger
+	encoder *parquet.Encoder
+	now     func() time.Time
+	// maxBytes is the size in bytes after which files are rotated, zero means no limit.
+	maxBytes int64
+
+	mutex sync.Mutex
+	files map[parquet.Table]*parquetFile
+	seq   int
+
+	stopCh chan struct{}
+	done   chan struct{}
+}
+
+// parquetFile is a Parquet file being written.
+type parquetFile struct {
+	path   string
+	writer *parquet.Writer
+	opened time.Time
+}
+
+func (e *parquetFileExporter) consumeTraces(_ context.Context, td ptrace.Traces) error {
+	rec := e.encoder.EncodeTraces(td)
+	defer rec.Release()
+	return e.write(parquet.TableSpans, rec)
+}
+
+func (e *parquetFileExporter) consumeMetrics(_ context.Context, md pmetric.Metrics) error {
+	var errs error
+	for table, rec := range e.encoder.EncodeMetrics(md) {
+		errs = errors.Join(errs, e.write(table, rec))
+		rec.Release()
+	}
+	return errs
+}
+
+func (e *parquetFileExporter) consumeLogs(_ context.Context, ld plog.Logs) error {
+	rec := e.encoder.EncodeLogs(ld)
+	defer rec.Release()
+	return e.write(parquet.TableLogs, rec)
+}
+
+func (e *parquetFileExporter) write(table parquet.Table, rec arrow.Record) error {
+	if rec.NumRows() == 0 {
+		return nil
+	}
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	f, ok := e.files[table]
+	if !ok {
+		var err error
+		if f, err = e.open(table); err != nil {
+			return err
+		}
+		e.files[table] = f
+	}
+	if err := f.writer.Write(rec); err != nil {
+		delete(e.files, table)
+		return errors.Join(err, f.close())
+	}
+
+	if e.maxBytes > 0 && f.writer.Size() >= e.maxBytes {
+		delete(e.files, table)
+		return f.close()
+	}
+	return nil
+}
+
+func (e *parquetFileExporter) open(table parquet.Table) (*parquetFile, error) {
+	dir := filepath.Join(e.conf.Path, string(table))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	now := e.now()
+	e.seq++
+	name := fmt.Sprintf("%s-%06d%s", now.UTC().Format("20060102T150405.000000000Z"), e.seq, parquetFileExtension)
+	path := filepath.Join(dir, name)
+	file, err := os.OpenFile(path+inProgressSuffix, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return nil, err
+	}
+	writer, err := parquet.NewWriter(file, e.encoder.Schema(table), e.conf.Parquet.Config)
+	if err != nil {
+		return nil, errors.Join(err, file.Close(), os.Remove(file.Name()))
+	}
+	return &parquetFile{path: path, writer: writer, opened: now}, nil
+}
+
+// close writes the footer of the file and gives it its final name.
+func (f *parquetFile) close() error {
+	if err := f.writer.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.path+inProgressSuffix, f.path)
+}
+
+// rotateExpired closes the files that are older than the maximum age.
+func (e *parquetFileExporter) rotateExpired() {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	now := e.now()
+	for table, f := range e.files {
+		if now.Sub(f.opened) < e.conf.Parquet.MaxFileAge {
+			continue
+		}
+		delete(e.files, table)
+		if err := f.close(); err != nil {
+			e.logger.Error("Failed to close Parquet file", zap.String("path", f.path), zap.Error(err))
+		}
+	}
+}
+
+func (e *parquetFileExporter) run(interval time.Duration) {
+	defer close(e.done)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-e.stopCh:
+			return
+		case <-ticker.C:
+			e.rotateExpired()
+		}
+	}
+}
+
+// Start creates the encoder and, if set, starts checking the age of the files.
+func (e *parquetFileExporter) Start(context.Context, component.Host) error {
+	var err error
+	e.encoder, err = parquet.NewEncoder(e.conf.Parquet.Config)
+	if err != nil {
+		return err
+	}
+	if e.now == nil {
+		e.now = time.Now
+	}
+	e.files = make(map[parquet.Table]*parquetFile)
+	e.maxBytes = int64(e.conf.Parquet.MaxFileMegabytes) << 20
+
+	if maxAge := e.conf.Parquet.MaxFileAge; maxAge > 0 {
+		e.stopCh = make(chan struct{})
+		e.done = make(chan struct{})
+		go e.run(min(maxAge, maxAgeCheckInterval))
+	}
+
+	return nil
+}
+
+// Shutdown closes all files.
+func (e *parquetFileExporter) Shutdown(context.Context) error {
+	if e.stopCh != nil {
+		close(e.stopCh)
+		<-e.done
+		e.stopCh = nil
+	}
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	var errs error
+	for table, f := range e.files {
+		delete(e.files, table)
+		errs = errors.Join(errs, f.close())
+	}
+	return errs
+}
@@ -0,0 +1,146 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fileexporter
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/v17/parquet/file"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/testdata"
+)
+
+func newTestParquetFileExporter(t *testing.T) *parquetFileExporter {
+	conf := createDefaultConfig().(*Config)
+	conf.Path = t.TempDir()
+	conf.FormatType = formatTypeParquet
+	conf.Rotation = nil
+	conf.Parquet.MaxFileAge = 0
+	require.NoError(t, conf.Validate())
+
+	fe, ok := newFileExporter(conf, zap.NewNop()).(*parquetFileExporter)
+	require.True(t, ok)
+	require.NoError(t, fe.Start(context.Background(), componenttest.NewNopHost()))
+	return fe
+}
+
+// parquetFiles returns the complete Parquet files of the table, with their number of rows.
+func parquetFiles(t *testing.T, dir, table string) map[string]int64 {
+	matches, err := filepath.Glob(filepath.Join(dir, table, "*.parquet"))
+	require.NoError(t, err)
+	files := make(map[string]int64, len(matches))
+	for _, match := range matches {
+		r, err := file.OpenParquetFile(match, false)
+		require.NoError(t, err)
+		files[filepath.Base(match)] = r.NumRows()
+		require.NoError(t, r.Close())
+	}
+	return files
+}
+
+func sumRows(files map[string]int64) int64 {
+	var rows int64
+	for _, n := range files {
+		rows += n
+	}
+	return rows
+}
+
+func TestParquetFileExporter(t *testing.T) {
+	fe := newTestParquetFileExporter(t)
+	ctx := context.Background()
+
+	require.NoError(t, fe.consumeTraces(ctx, testdata.GenerateTracesTwoSpansSameResource()))
+	require.NoError(t, fe.consumeTraces(ctx, testdata.GenerateTracesOneSpan()))
+	require.NoError(t, fe.consumeLogs(ctx, testdata.GenerateLogsTwoLogRecordsSameResource()))
+	require.NoError(t, fe.consumeMetrics(ctx, testdata.GenerateMetricsAllTypesEmptyDataPoint()))
+	require.NoError(t, fe.consumeLogs(ctx, testdata.GenerateLogsNoLogRecords()))
+
+	// Files are only complete once closed.
+	assert.Empty(t, parquetFiles(t, fe.conf.Path, "spans"))
+	inProgress, err := filepath.Glob(filepath.Join(fe.conf.Path, "spans", "*.parquet.tmp"))
+	require.NoError(t, err)
+	assert.Len(t, inProgress, 1)
+
+	require.NoError(t, fe.Shutdown(ctx))
+
+	spans := parquetFiles(t, fe.conf.Path, "spans")
+	assert.Len(t, spans, 1)
+	assert.EqualValues(t, 3, sumRows(spans))
+	assert.EqualValues(t, 2, sumRows(parquetFiles(t, fe.conf.Path, "logs")))
+	for _, table := range []string{"gauge", "sum", "histogram", "exponential_histogram", "summary"} {
+		assert.NotEmpty(t, parquetFiles(t, fe.conf.Path, table), table)
+	}
+
+	inProgress, err = filepath.Glob(filepath.Join(fe.conf.Path, "*", "*.tmp"))
+	require.NoError(t, err)
+	assert.Empty(t, inProgress)
+}
+
+func TestParquetFileExporterRotatesBySize(t *testing.T) {
+	fe := newTestParquetFileExporter(t)
+	fe.maxBytes = 1
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, fe.consumeLogs(ctx, testdata.GenerateLogsTwoLogRecordsSameResource()))
+	}
+	logs := parquetFiles(t, fe.conf.Path, "logs")
+	assert.Len(t, logs, 3)
+	assert.EqualValues(t, 6, sumRows(logs))
+	require.NoError(t, fe.Shutdown(ctx))
+}
+
+func TestParquetFileExporterRotatesByAge(t *testing.T) {
+	fe := newTestParquetFileExporter(t)
+	fe.conf.Parquet.MaxFileAge = time.Minute
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	fe.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	require.NoError(t, fe.consumeLogs(ctx, testdata.GenerateLogsTwoLogRecordsSameResource()))
+	now = now.Add(30 * time.Second)
+	require.NoError(t, fe.consumeTraces(ctx, testdata.GenerateTracesOneSpan()))
+	fe.rotateExpired()
+	assert.Empty(t, parquetFiles(t, fe.conf.Path, "logs"))
+
+	now = now.Add(30 * time.Second)
+	fe.rotateExpired()
+	assert.Equal(t, map[string]int64{"20240101T000000.000000000Z-000001.parquet": 2}, parquetFiles(t, fe.conf.Path, "logs"))
+	assert.Empty(t, parquetFiles(t, fe.conf.Path, "spans"))
+
+	require.NoError(t, fe.Shutdown(ctx))
+	assert.Equal(t, map[string]int64{"20240101T000030.000000000Z-000002.parquet": 1}, parquetFiles(t, fe.conf.Path, "spans"))
+}
+
+func TestParquetFileExporterAgeTicker(t *testing.T) {
+	fe := newTestParquetFileExporter(t)
+	require.NoError(t, fe.Shutdown(context.Background()))
+
+	fe.conf.Parquet.MaxFileAge = 10 * time.Millisecond
+	require.NoError(t, fe.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, fe.consumeLogs(context.Background(), testdata.GenerateLogsOneLogRecord()))
+	assert.Eventually(t, func() bool {
+		return len(parquetFiles(t, fe.conf.Path, "logs")) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, fe.Shutdown(context.Background()))
+}
+
+func TestParquetFileExporterInvalidPath(t *testing.T) {
+	fe := newTestParquetFileExporter(t)
+	path := filepath.Join(fe.conf.Path, "file")
+	require.NoError(t, os.WriteFile(path, nil, 0600))
+	fe.conf.Path = path
+
+	assert.Error(t, fe.consumeLogs(context.Background(), testdata.GenerateLogsOneLogRecord()))
+	require.NoError(t, fe.Shutdown(context.Background()))
+}
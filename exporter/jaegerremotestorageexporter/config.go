@@ -0,0 +1,28 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package jaegerremotestorageexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/jaegerremotestorageexporter"
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+var errMissingExtension = errors.New("the ID of a jaegerremotestorage extension is required")
+
+// Config defines configuration for the Jaeger remote storage exporter.
+type Config struct {
+	// Extension is the ID of the jaegerremotestorage extension the traces are written to.
+	Extension component.ID `mapstructure:"extension"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the exporter configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.Extension.Type().String() == "" {
+		return errMissingExtension
+	}
+	return nil
+}
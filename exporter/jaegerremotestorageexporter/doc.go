@@ -0,0 +1,7 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package jaegerremotestorageexporter writes traces to the store of a jaegerremotestorage extension.
+package jaegerremotestorageexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/jaegerremotestorageexporter"
@@ -0,0 +1,42 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package jaegerremotestorageexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/jaegerremotestorageexporter"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/jaegerremotestorageextension"
+)
+
+// storageExporter writes the traces it receives to the store of a jaegerremotestorage extension,
+// which serves them to Jaeger Query.
+type storageExporter struct {
+	config *Config
+	store  jaegerremotestorageextension.TracesStore
+}
+
+func newExporter(cfg *Config) *storageExporter {
+	return &storageExporter{config: cfg}
+}
+
+func (e *storageExporter) start(_ context.Context, host component.Host) error {
+	ext, ok := host.GetExtensions()[e.config.Extension]
+	if !ok {
+		return fmt.Errorf("extension '%s' not found", e.config.Extension)
+	}
+	store, ok := ext.(jaegerremotestorageextension.TracesStore)
+	if !ok {
+		return fmt.Errorf("extension '%s' is not a jaegerremotestorage extension", e.config.Extension)
+	}
+	e.store = store
+	return nil
+}
+
+func (e *storageExporter) consumeTraces(ctx context.Context, td ptrace.Traces) error {
+	return e.store.ConsumeTraces(ctx, td)
+}
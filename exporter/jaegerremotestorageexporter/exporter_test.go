@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package jaegerremotestorageexporter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+type testStore struct {
+	component.StartFunc
+	component.ShutdownFunc
+	traces []ptrace.Traces
+}
+
+func (s *testStore) ConsumeTraces(_ context.Context, td ptrace.Traces) error {
+	s.traces = append(s.traces, td)
+	return nil
+}
+
+type nopExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+}
+
+type testHost struct {
+	component.Host
+	extensions map[component.ID]component.Component
+}
+
+func (h testHost) GetExtensions() map[component.ID]component.Component {
+	return h.extensions
+}
+
+func TestExporter(t *testing.T) {
+	store := &testStore{}
+	host := testHost{
+		Host: componenttest.NewNopHost(),
+		extensions: map[component.ID]component.Component{
+			defaultExtension: store,
+		},
+	}
+
+	exp, err := NewFactory().CreateTracesExporter(context.Background(), exportertest.NewNopSettings(), createDefaultConfig())
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), host))
+
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("span")
+	require.NoError(t, exp.ConsumeTraces(context.Background(), td))
+	require.Len(t, store.traces, 1)
+	assert.Equal(t, td, store.traces[0])
+	require.NoError(t, exp.Shutdown(context.Background()))
+}
+
+func TestExporterMissingExtension(t *testing.T) {
+	other := component.MustNewID("other")
+	host := testHost{
+		Host: componenttest.NewNopHost(),
+		extensions: map[component.ID]component.Component{
+			other: &nopExtension{},
+		},
+	}
+	for _, id := range []component.ID{defaultExtension, other} {
+		exp, err := NewFactory().CreateTracesExporter(context.Background(), exportertest.NewNopSettings(), &Config{Extension: id})
+		require.NoError(t, err)
+		assert.Error(t, exp.Start(context.Background(), host))
+		require.NoError(t, exp.Shutdown(context.Background()))
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package jaegerremotestorageexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/jaegerremotestorageexporter"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/jaegerremotestorageexporter/internal/metadata"
+)
+
+// defaultExtension is the ID of the extension in the most common configuration.
+var defaultExtension = component.MustNewID("jaegerremotestorage")
+
+// NewFactory creates a factory for the Jaeger remote storage exporter.
+func NewFactory() exporter.Factory {
+	return exporter.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		exporter.WithTraces(createTracesExporter, metadata.TracesStability))
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		Extension: defaultExtension,
+	}
+}
+
+func createTracesExporter(
+	ctx context.Context,
+	set exporter.Settings,
+	cfg component.Config,
+) (exporter.Traces, error) {
+	e := newExporter(cfg.(*Config))
+	return exporterhelper.NewTracesExporter(
+		ctx,
+		set,
+		cfg,
+		e.consumeTraces,
+		exporterhelper.WithStart(e.start),
+		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
+	)
+}
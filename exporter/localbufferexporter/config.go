@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package localbufferexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/localbufferexporter"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+)
+
+// Config defines configuration for the local buffer exporter.
+type Config struct {
+	// ServerConfig configures the HTTP server serving the query API.
+	confighttp.ServerConfig `mapstructure:",squash"`
+
+	// StorageID is the ID of the storage extension holding the buffered telemetry.
+	// When unset, the telemetry is kept in memory and lost on restart.
+	StorageID *component.ID `mapstructure:"storage"`
+
+	// Traces configures the retention of buffered traces.
+	Traces RetentionConfig `mapstructure:"traces"`
+
+	// Metrics configures the retention of buffered metrics.
+	Metrics RetentionConfig `mapstructure:"metrics"`
+
+	// Logs configures the retention of buffered logs.
+	Logs RetentionConfig `mapstructure:"logs"`
+
+	// MaxResults is the maximum number of log records returned by a single query.
+	MaxResults int `mapstructure:"max_results"`
+}
+
+// RetentionConfig defines how long and how much telemetry of a signal is kept.
+// The oldest batches are evicted first once either limit is exceeded.
+type RetentionConfig struct {
+	// MaxAge is the time a batch is kept after it was received.
+	MaxAge time.Duration `mapstructure:"max_age"`
+
+	// MaxSizeMiB is the maximum total size of the encoded batches, in MiB.
+	MaxSizeMiB int64 `mapstructure:"max_size_mib"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the exporter configuration is valid.
+func (cfg *Config) Validate() error {
+	var errs error
+	if cfg.Endpoint == "" {
+		errs = errors.Join(errs, errors.New("endpoint must be specified"))
+	}
+	if cfg.MaxResults <= 0 {
+		errs = errors.Join(errs, errors.New("max_results must be positive"))
+	}
+	errs = errors.Join(errs, cfg.Traces.validate("traces"))
+	errs = errors.Join(errs, cfg.Metrics.validate("metrics"))
+	errs = errors.Join(errs, cfg.Logs.validate("logs"))
+	return errs
+}
+
+func (rc RetentionConfig) validate(signal string) error {
+	var errs error
+	if rc.MaxAge <= 0 {
+		errs = errors.Join(errs, fmt.Errorf("%s: max_age must be positive", signal))
+	}
+	if rc.MaxSizeMiB <= 0 {
+		errs = errors.Join(errs, fmt.Errorf("%s: max_size_mib must be positive", signal))
+	}
+	return errs
+}
+
+func (rc RetentionConfig) maxBytes() int64 {
+	return rc.MaxSizeMiB << 20
+}
@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package localbufferexporter
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/localbufferexporter/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	storageID := component.MustNewID("file_storage")
+	tests := []struct {
+		id           component.ID
+		expected     component.Config
+		errorMessage string
+	}{
+		{
+			id:       component.NewID(metadata.Type),
+			expected: createDefaultConfig(),
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "full"),
+			expected: &Config{
+				ServerConfig: confighttp.ServerConfig{Endpoint: "0.0.0.0:9000"},
+				StorageID:    &storageID,
+				Traces:       RetentionConfig{MaxAge: 2 * time.Hour, MaxSizeMiB: 64},
+				Metrics:      RetentionConfig{MaxAge: 6 * time.Hour, MaxSizeMiB: 32},
+				Logs:         RetentionConfig{MaxAge: 12 * time.Hour, MaxSizeMiB: 512},
+				MaxResults:   200,
+			},
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "missing_endpoint"),
+			errorMessage: "endpoint must be specified",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_retention"),
+			errorMessage: "logs: max_age must be positive\nlogs: max_size_mib must be positive",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_max_results"),
+			errorMessage: "max_results must be positive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			err = sub.Unmarshal(cfg)
+
+			if tt.expected == nil {
+				err = errors.Join(err, component.ValidateConfig(cfg))
+				assert.ErrorContains(t, err, tt.errorMessage)
+				return
+			}
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
@@ -0,0 +1,7 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package localbufferexporter stores telemetry in a local buffer that can be queried over HTTP.
+package localbufferexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/localbufferexporter"
@@ -0,0 +1,176 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package localbufferexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/localbufferexporter"
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+var (
+	logsMarshaler    = &plog.ProtoMarshaler{}
+	tracesMarshaler  = &ptrace.ProtoMarshaler{}
+	metricsMarshaler = &pmetric.ProtoMarshaler{}
+)
+
+// localBufferExporter stores every signal in its own signalStore and serves the query API.
+// A single instance is shared by the traces, metrics and logs pipelines of a configuration.
+type localBufferExporter struct {
+	config   *Config
+	id       component.ID
+	logger   *zap.Logger
+	settings component.TelemetrySettings
+
+	traces  *signalStore
+	metrics *signalStore
+	logs    *signalStore
+
+	server     *http.Server
+	shutdownWG sync.WaitGroup
+}
+
+func newLocalBufferExporter(cfg *Config, set exporter.Settings) *localBufferExporter {
+	return &localBufferExporter{
+		config:   cfg,
+		id:       set.ID,
+		logger:   set.Logger,
+		settings: set.TelemetrySettings,
+	}
+}
+
+func (e *localBufferExporter) Start(ctx context.Context, host component.Host) error {
+	var err error
+	if e.traces, err = e.newStore(ctx, host, "traces", e.config.Traces, true); err != nil {
+		return err
+	}
+	if e.metrics, err = e.newStore(ctx, host, "metrics", e.config.Metrics, false); err != nil {
+		return err
+	}
+	if e.logs, err = e.newStore(ctx, host, "logs", e.config.Logs, false); err != nil {
+		return err
+	}
+
+	ln, err := e.config.ToListener(ctx)
+	if err != nil {
+		return err
+	}
+	e.server, err = e.config.ToServer(ctx, host, e.settings, e.newQueryHandler())
+	if err != nil {
+		_ = ln.Close()
+		return err
+	}
+
+	e.shutdownWG.Add(1)
+	go func() {
+		defer e.shutdownWG.Done()
+		if errHTTP := e.server.Serve(ln); errHTTP != nil && !errors.Is(errHTTP, http.ErrServerClosed) {
+			e.logger.Error("Query API server failed", zap.Error(errHTTP))
+		}
+	}()
+	return nil
+}
+
+func (e *localBufferExporter) newStore(ctx context.Context, host component.Host, signal string, retention RetentionConfig, indexTraces bool) (*signalStore, error) {
+	client, err := getStorageClient(ctx, host, e.config.StorageID, e.id, signal)
+	if err != nil {
+		return nil, err
+	}
+	return newSignalStore(ctx, client, retention, indexTraces)
+}
+
+func (e *localBufferExporter) Shutdown(ctx context.Context) error {
+	var err error
+	if e.server != nil {
+		err = e.server.Shutdown(ctx)
+		e.shutdownWG.Wait()
+	}
+	for _, s := range []*signalStore{e.traces, e.metrics, e.logs} {
+		if s != nil {
+			err = errors.Join(err, s.close(ctx))
+		}
+	}
+	return err
+}
+
+func (e *localBufferExporter) consumeTraces(ctx context.Context, td ptrace.Traces) error {
+	data, err := tracesMarshaler.MarshalTraces(td)
+	if err != nil {
+		return err
+	}
+	return e.traces.append(ctx, data, tracesMeta(td))
+}
+
+func (e *localBufferExporter) consumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	data, err := metricsMarshaler.MarshalMetrics(md)
+	if err != nil {
+		return err
+	}
+	return e.metrics.append(ctx, data, batchMeta{})
+}
+
+func (e *localBufferExporter) consumeLogs(ctx context.Context, ld plog.Logs) error {
+	data, err := logsMarshaler.MarshalLogs(ld)
+	if err != nil {
+		return err
+	}
+	return e.logs.append(ctx, data, logsMeta(ld))
+}
+
+// tracesMeta returns the trace IDs of the spans of td, and the range of their start and end
+// timestamps.
+func tracesMeta(td ptrace.Traces) batchMeta {
+	var meta batchMeta
+	seen := map[pcommon.TraceID]struct{}{}
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		sss := td.ResourceSpans().At(i).ScopeSpans()
+		for j := 0; j < sss.Len(); j++ {
+			spans := sss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				if _, ok := seen[span.TraceID()]; !ok {
+					seen[span.TraceID()] = struct{}{}
+					meta.traceIDs = append(meta.traceIDs, span.TraceID())
+				}
+				meta.extend(span.StartTimestamp())
+				meta.extend(span.EndTimestamp())
+			}
+		}
+	}
+	return meta
+}
+
+// logsMeta returns the range of the timestamps of the log records of ld, as matched by the
+// logs query.
+func logsMeta(ld plog.Logs) batchMeta {
+	var meta batchMeta
+	for i := 0; i < ld.ResourceLogs().Len(); i++ {
+		sls := ld.ResourceLogs().At(i).ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			records := sls.At(j).LogRecords()
+			for k := 0; k < records.Len(); k++ {
+				lr := records.At(k)
+				ts := lr.Timestamp()
+				if ts == 0 {
+					ts = lr.ObservedTimestamp()
+				}
+				if ts == 0 {
+					// the batch holds a record without timestamp, so time filters can't skip it
+					return batchMeta{}
+				}
+				meta.extend(ts)
+			}
+		}
+	}
+	return meta
+}
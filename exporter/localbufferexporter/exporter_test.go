@@ -0,0 +1,284 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package localbufferexporter
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/testutil"
+)
+
+var (
+	baseTime = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	traceA   = pcommon.TraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	traceB   = pcommon.TraceID([16]byte{16, 15, 14, 13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1})
+)
+
+func startExporter(t *testing.T, cfg *Config, host component.Host) (*localBufferExporter, string) {
+	cfg.Endpoint = testutil.GetAvailableLocalAddress(t)
+	e := newLocalBufferExporter(cfg, exportertest.NewNopSettings())
+	require.NoError(t, e.Start(context.Background(), host))
+	t.Cleanup(func() {
+		assert.NoError(t, e.Shutdown(context.Background()))
+	})
+	return e, "http://" + cfg.Endpoint
+}
+
+func get(t *testing.T, endpoint string, path string, query url.Values) (int, []byte) {
+	resp, err := http.Get(endpoint + path + "?" + query.Encode())
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, body
+}
+
+func newLogs(service string, records ...string) plog.Logs {
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("service.name", service)
+	sl := rl.ScopeLogs().AppendEmpty()
+	for i, body := range records {
+		lr := sl.LogRecords().AppendEmpty()
+		lr.Body().SetStr(body)
+		lr.Attributes().PutInt("index", int64(i))
+		lr.SetTimestamp(pcommon.NewTimestampFromTime(baseTime.Add(time.Duration(i) * time.Minute)))
+	}
+	return ld
+}
+
+func logBodies(t *testing.T, body []byte) []string {
+	ld, err := (&plog.JSONUnmarshaler{}).UnmarshalLogs(body)
+	require.NoError(t, err)
+	var bodies []string
+	for i := 0; i < ld.ResourceLogs().Len(); i++ {
+		rl := ld.ResourceLogs().At(i)
+		for j := 0; j < rl.ScopeLogs().Len(); j++ {
+			records := rl.ScopeLogs().At(j).LogRecords()
+			for k := 0; k < records.Len(); k++ {
+				bodies = append(bodies, records.At(k).Body().Str())
+			}
+		}
+	}
+	return bodies
+}
+
+func TestQueryLogs(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.MaxResults = 3
+	e, endpoint := startExporter(t, cfg, componenttest.NewNopHost())
+
+	require.NoError(t, e.consumeLogs(context.Background(), newLogs("checkout", "c0", "c1", "c2")))
+	require.NoError(t, e.consumeLogs(context.Background(), newLogs("payment", "p0", "p1")))
+
+	tests := []struct {
+		name     string
+		query    url.Values
+		expected []string
+	}{
+		{
+			name:     "limited by max_results",
+			query:    url.Values{},
+			expected: []string{"c0", "c1", "c2"},
+		},
+		{
+			name:     "limit",
+			query:    url.Values{"limit": {"1"}},
+			expected: []string{"c0"},
+		},
+		{
+			name:     "resource attribute",
+			query:    url.Values{"attribute": {"service.name=payment"}},
+			expected: []string{"p0", "p1"},
+		},
+		{
+			name:     "record and resource attributes",
+			query:    url.Values{"attribute": {"service.name=checkout", "index=2"}},
+			expected: []string{"c2"},
+		},
+		{
+			name: "time range",
+			query: url.Values{
+				"start": {baseTime.Add(time.Minute).Format(time.RFC3339)},
+				"end":   {baseTime.Add(time.Minute).Format(time.RFC3339)},
+			},
+			expected: []string{"c1", "p1"},
+		},
+		{
+			name:  "no match",
+			query: url.Values{"attribute": {"service.name=unknown"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, body := get(t, endpoint, logsPath, tt.query)
+			require.Equal(t, http.StatusOK, status, string(body))
+			assert.Equal(t, tt.expected, logBodies(t, body))
+		})
+	}
+
+	status, _ := get(t, endpoint, logsPath, url.Values{"start": {"yesterday"}})
+	assert.Equal(t, http.StatusBadRequest, status)
+	status, _ = get(t, endpoint, logsPath, url.Values{"attribute": {"service.name"}})
+	assert.Equal(t, http.StatusBadRequest, status)
+}
+
+func newTraces(service string, spans map[string]pcommon.TraceID) ptrace.Traces {
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("service.name", service)
+	ss := rs.ScopeSpans().AppendEmpty()
+	for name, traceID := range spans {
+		span := ss.Spans().AppendEmpty()
+		span.SetName(name)
+		span.SetTraceID(traceID)
+	}
+	return td
+}
+
+func TestQueryTrace(t *testing.T) {
+	e, endpoint := startExporter(t, createDefaultConfig().(*Config), componenttest.NewNopHost())
+
+	require.NoError(t, e.consumeTraces(context.Background(), newTraces("frontend", map[string]pcommon.TraceID{"GET /cart": traceA, "GET /": traceB})))
+	require.NoError(t, e.consumeTraces(context.Background(), newTraces("cart", map[string]pcommon.TraceID{"load cart": traceA})))
+
+	status, body := get(t, endpoint, tracesPath+traceA.String(), nil)
+	require.Equal(t, http.StatusOK, status, string(body))
+	td, err := (&ptrace.JSONUnmarshaler{}).UnmarshalTraces(body)
+	require.NoError(t, err)
+	require.Equal(t, 2, td.ResourceSpans().Len())
+	frontend := td.ResourceSpans().At(0)
+	service, _ := frontend.Resource().Attributes().Get("service.name")
+	assert.Equal(t, "frontend", service.Str())
+	require.Equal(t, 1, frontend.ScopeSpans().At(0).Spans().Len())
+	assert.Equal(t, "GET /cart", frontend.ScopeSpans().At(0).Spans().At(0).Name())
+	assert.Equal(t, "load cart", td.ResourceSpans().At(1).ScopeSpans().At(0).Spans().At(0).Name())
+
+	status, _ = get(t, endpoint, tracesPath+"00000000000000000000000000000001", nil)
+	assert.Equal(t, http.StatusNotFound, status)
+	status, _ = get(t, endpoint, tracesPath+"not-a-trace", nil)
+	assert.Equal(t, http.StatusBadRequest, status)
+}
+
+func newMetrics(host string, start int, values ...float64) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("host.name", host)
+	sm := rm.ScopeMetrics().AppendEmpty()
+	m := sm.Metrics().AppendEmpty()
+	m.SetName("cpu.utilization")
+	gauge := m.SetEmptyGauge()
+	for i, v := range values {
+		for _, cpu := range []string{"cpu0", "cpu1"} {
+			dp := gauge.DataPoints().AppendEmpty()
+			dp.Attributes().PutStr("cpu", cpu)
+			dp.SetTimestamp(pcommon.NewTimestampFromTime(baseTime.Add(time.Duration(start+i) * time.Second)))
+			dp.SetDoubleValue(v)
+		}
+	}
+	other := sm.Metrics().AppendEmpty()
+	other.SetName("memory.utilization")
+	other.SetEmptyGauge().DataPoints().AppendEmpty().SetDoubleValue(0.5)
+	return md
+}
+
+func TestQueryMetrics(t *testing.T) {
+	e, endpoint := startExporter(t, createDefaultConfig().(*Config), componenttest.NewNopHost())
+
+	require.NoError(t, e.consumeMetrics(context.Background(), newMetrics("edge-1", 0, 1, 2, 3, 4, 5)))
+	require.NoError(t, e.consumeMetrics(context.Background(), newMetrics("edge-1", 5, 6, 7)))
+	require.NoError(t, e.consumeMetrics(context.Background(), newMetrics("edge-2", 0, 10)))
+
+	status, body := get(t, endpoint, metricPath, url.Values{
+		"name":      {"cpu.utilization"},
+		"attribute": {"host.name=edge-1"},
+		"last":      {"3"},
+	})
+	require.Equal(t, http.StatusOK, status, string(body))
+	md, err := (&pmetric.JSONUnmarshaler{}).UnmarshalMetrics(body)
+	require.NoError(t, err)
+	require.Equal(t, 2, md.ResourceMetrics().Len())
+	for i, cpu := range []string{"cpu0", "cpu1"} {
+		m := md.ResourceMetrics().At(i).ScopeMetrics().At(0).Metrics().At(0)
+		assert.Equal(t, "cpu.utilization", m.Name())
+		dps := m.Gauge().DataPoints()
+		require.Equal(t, 3, dps.Len())
+		for j, expected := range []float64{5, 6, 7} {
+			assert.Equal(t, expected, dps.At(j).DoubleValue())
+			attr, _ := dps.At(j).Attributes().Get("cpu")
+			assert.Equal(t, cpu, attr.Str())
+		}
+	}
+
+	status, body = get(t, endpoint, metricPath, url.Values{"name": {"cpu.utilization"}, "attribute": {"cpu=cpu1"}})
+	require.Equal(t, http.StatusOK, status, string(body))
+	md, err = (&pmetric.JSONUnmarshaler{}).UnmarshalMetrics(body)
+	require.NoError(t, err)
+	assert.Equal(t, 2, md.ResourceMetrics().Len())
+	assert.Equal(t, 8, md.DataPointCount())
+
+	status, _ = get(t, endpoint, metricPath, url.Values{})
+	assert.Equal(t, http.StatusBadRequest, status)
+	status, _ = get(t, endpoint, metricPath, url.Values{"name": {"cpu.utilization"}, "last": {"0"}})
+	assert.Equal(t, http.StatusBadRequest, status)
+}
+
+func TestPersistentStorage(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	storageID := storagetest.NewStorageID("file_storage")
+	cfg.StorageID = &storageID
+	host := storagetest.NewStorageHost().WithFileBackedStorageExtension("file_storage", t.TempDir())
+
+	// Both instances must share the component ID to get the same storage client.
+	set := exportertest.NewNopSettings()
+	e := newLocalBufferExporter(cfg, set)
+	cfg.Endpoint = testutil.GetAvailableLocalAddress(t)
+	require.NoError(t, e.Start(context.Background(), host))
+	require.NoError(t, e.consumeLogs(context.Background(), newLogs("checkout", "before restart")))
+	require.NoError(t, e.Shutdown(context.Background()))
+
+	restarted := newLocalBufferExporter(cfg, set)
+	cfg.Endpoint = testutil.GetAvailableLocalAddress(t)
+	require.NoError(t, restarted.Start(context.Background(), host))
+	t.Cleanup(func() {
+		assert.NoError(t, restarted.Shutdown(context.Background()))
+	})
+	endpoint := "http://" + cfg.Endpoint
+	require.NoError(t, restarted.consumeLogs(context.Background(), newLogs("checkout", "after restart")))
+	status, body := get(t, endpoint, logsPath, nil)
+	require.Equal(t, http.StatusOK, status, string(body))
+	assert.Equal(t, []string{"before restart", "after restart"}, logBodies(t, body))
+}
+
+func TestStartErrors(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	missing := storagetest.NewStorageID("missing")
+	cfg.StorageID = &missing
+	e := newLocalBufferExporter(cfg, exportertest.NewNopSettings())
+	assert.ErrorContains(t, e.Start(context.Background(), storagetest.NewStorageHost()), "storage extension 'test_storage/missing' not found")
+	assert.NoError(t, e.Shutdown(context.Background()))
+
+	nonStorage := storagetest.NewNonStorageID("non_storage")
+	cfg.StorageID = &nonStorage
+	e = newLocalBufferExporter(cfg, exportertest.NewNopSettings())
+	host := storagetest.NewStorageHost().WithNonStorageExtension("non_storage")
+	assert.ErrorContains(t, e.Start(context.Background(), host), "non-storage extension")
+	assert.NoError(t, e.Shutdown(context.Background()))
+}
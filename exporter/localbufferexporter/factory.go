@@ -0,0 +1,118 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package localbufferexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/localbufferexporter"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/localbufferexporter/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sharedcomponent"
+)
+
+const (
+	defaultEndpoint   = "localhost:4319"
+	defaultMaxAge     = 24 * time.Hour
+	defaultMaxSizeMiB = 256
+	defaultMaxResults = 1000
+)
+
+// NewFactory creates a factory for the local buffer exporter.
+func NewFactory() exporter.Factory {
+	return exporter.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		exporter.WithTraces(createTracesExporter, metadata.TracesStability),
+		exporter.WithMetrics(createMetricsExporter, metadata.MetricsStability),
+		exporter.WithLogs(createLogsExporter, metadata.LogsStability))
+}
+
+func createDefaultConfig() component.Config {
+	retention := RetentionConfig{
+		MaxAge:     defaultMaxAge,
+		MaxSizeMiB: defaultMaxSizeMiB,
+	}
+	return &Config{
+		ServerConfig: confighttp.ServerConfig{
+			Endpoint: defaultEndpoint,
+		},
+		Traces:     retention,
+		Metrics:    retention,
+		Logs:       retention,
+		MaxResults: defaultMaxResults,
+	}
+}
+
+func createTracesExporter(
+	ctx context.Context,
+	set exporter.Settings,
+	cfg component.Config,
+) (exporter.Traces, error) {
+	e := getOrCreateExporter(cfg, set)
+	lbe := e.Unwrap().(*localBufferExporter)
+	return exporterhelper.NewTracesExporter(
+		ctx,
+		set,
+		cfg,
+		lbe.consumeTraces,
+		exporterhelper.WithStart(e.Start),
+		exporterhelper.WithShutdown(e.Shutdown),
+		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
+	)
+}
+
+func createMetricsExporter(
+	ctx context.Context,
+	set exporter.Settings,
+	cfg component.Config,
+) (exporter.Metrics, error) {
+	e := getOrCreateExporter(cfg, set)
+	lbe := e.Unwrap().(*localBufferExporter)
+	return exporterhelper.NewMetricsExporter(
+		ctx,
+		set,
+		cfg,
+		lbe.consumeMetrics,
+		exporterhelper.WithStart(e.Start),
+		exporterhelper.WithShutdown(e.Shutdown),
+		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
+	)
+}
+
+func createLogsExporter(
+	ctx context.Context,
+	set exporter.Settings,
+	cfg component.Config,
+) (exporter.Logs, error) {
+	e := getOrCreateExporter(cfg, set)
+	lbe := e.Unwrap().(*localBufferExporter)
+	return exporterhelper.NewLogsExporter(
+		ctx,
+		set,
+		cfg,
+		lbe.consumeLogs,
+		exporterhelper.WithStart(e.Start),
+		exporterhelper.WithShutdown(e.Shutdown),
+		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
+	)
+}
+
+// getOrCreateExporter returns the exporter shared by all the pipelines of a configuration, so
+// that a single query API serves every signal.
+func getOrCreateExporter(cfg component.Config, set exporter.Settings) *sharedcomponent.SharedComponent {
+	return exporters.GetOrAdd(cfg, func() component.Component {
+		return newLocalBufferExporter(cfg.(*Config), set)
+	})
+}
+
+// This is the map of already created local buffer exporters for particular configurations.
+// We maintain this map because the Factory is asked trace, metric and log exporters separately
+// but they must not create separate objects, they must use one exporter object per configuration.
+var exporters = sharedcomponent.NewSharedComponents()
@@ -0,0 +1,308 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package localbufferexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/localbufferexporter"
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+const (
+	logsPath   = "/api/v1/logs"
+	tracesPath = "/api/v1/traces/"
+	metricPath = "/api/v1/metrics"
+
+	defaultLastPoints = 10
+)
+
+var (
+	logsUnmarshaler    = &plog.ProtoUnmarshaler{}
+	tracesUnmarshaler  = &ptrace.ProtoUnmarshaler{}
+	metricsUnmarshaler = &pmetric.ProtoUnmarshaler{}
+)
+
+func (e *localBufferExporter) newQueryHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+logsPath, e.handleLogs)
+	mux.HandleFunc("GET "+tracesPath+"{trace_id}", e.handleTrace)
+	mux.HandleFunc("GET "+metricPath, e.handleMetrics)
+	return mux
+}
+
+// attributeFilter matches telemetry having an attribute, on the record or on its resource,
+// whose string representation equals the expected value.
+type attributeFilter struct {
+	key   string
+	value string
+}
+
+func parseAttributeFilters(query url.Values) ([]attributeFilter, error) {
+	var filters []attributeFilter
+	for _, attr := range query["attribute"] {
+		key, value, ok := strings.Cut(attr, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid attribute filter %q, expected key=value", attr)
+		}
+		filters = append(filters, attributeFilter{key: key, value: value})
+	}
+	return filters, nil
+}
+
+func matchAttributes(filters []attributeFilter, resource, record pcommon.Map) bool {
+	for _, f := range filters {
+		v, ok := record.Get(f.key)
+		if !ok {
+			v, ok = resource.Get(f.key)
+		}
+		if !ok || v.AsString() != f.value {
+			return false
+		}
+	}
+	return true
+}
+
+func parseTime(query url.Values, name string) (pcommon.Timestamp, error) {
+	v := query.Get(name)
+	if v == "" {
+		return 0, nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, v)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %w", name, err)
+	}
+	return pcommon.NewTimestampFromTime(t), nil
+}
+
+func parsePositiveInt(query url.Values, name string, def int) (int, error) {
+	v := query.Get(name)
+	if v == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid %s %q, expected a positive integer", name, v)
+	}
+	return n, nil
+}
+
+// logsQuery holds the parameters of a log search.
+type logsQuery struct {
+	start   pcommon.Timestamp
+	end     pcommon.Timestamp
+	filters []attributeFilter
+	limit   int
+}
+
+func (e *localBufferExporter) parseLogsQuery(query url.Values) (logsQuery, error) {
+	var q logsQuery
+	var err error
+	if q.start, err = parseTime(query, "start"); err != nil {
+		return q, err
+	}
+	if q.end, err = parseTime(query, "end"); err != nil {
+		return q, err
+	}
+	if q.filters, err = parseAttributeFilters(query); err != nil {
+		return q, err
+	}
+	if q.limit, err = parsePositiveInt(query, "limit", e.config.MaxResults); err != nil {
+		return q, err
+	}
+	q.limit = min(q.limit, e.config.MaxResults)
+	return q, nil
+}
+
+func (q logsQuery) matches(resource pcommon.Resource, lr plog.LogRecord) bool {
+	ts := lr.Timestamp()
+	if ts == 0 {
+		ts = lr.ObservedTimestamp()
+	}
+	if q.start != 0 && ts < q.start {
+		return false
+	}
+	if q.end != 0 && ts > q.end {
+		return false
+	}
+	return matchAttributes(q.filters, resource.Attributes(), lr.Attributes())
+}
+
+func (e *localBufferExporter) handleLogs(w http.ResponseWriter, r *http.Request) {
+	q, err := e.parseLogsQuery(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result := plog.NewLogs()
+	count := 0
+	err = e.logs.scan(r.Context(), batchFilter{start: q.start, end: q.end}, func(data []byte) (bool, error) {
+		ld, err := logsUnmarshaler.UnmarshalLogs(data)
+		if err != nil {
+			return false, err
+		}
+		for i := 0; i < ld.ResourceLogs().Len(); i++ {
+			rl := ld.ResourceLogs().At(i)
+			var outRL plog.ResourceLogs
+			hasRL := false
+			for j := 0; j < rl.ScopeLogs().Len(); j++ {
+				sl := rl.ScopeLogs().At(j)
+				var outSL plog.ScopeLogs
+				hasSL := false
+				for k := 0; k < sl.LogRecords().Len(); k++ {
+					lr := sl.LogRecords().At(k)
+					if !q.matches(rl.Resource(), lr) {
+						continue
+					}
+					if !hasRL {
+						hasRL = true
+						outRL = result.ResourceLogs().AppendEmpty()
+						rl.Resource().CopyTo(outRL.Resource())
+						outRL.SetSchemaUrl(rl.SchemaUrl())
+					}
+					if !hasSL {
+						hasSL = true
+						outSL = outRL.ScopeLogs().AppendEmpty()
+						sl.Scope().CopyTo(outSL.Scope())
+						outSL.SetSchemaUrl(sl.SchemaUrl())
+					}
+					lr.CopyTo(outSL.LogRecords().AppendEmpty())
+					count++
+					if count >= q.limit {
+						return false, nil
+					}
+				}
+			}
+		}
+		return true, nil
+	})
+	if err != nil {
+		e.writeError(w, err)
+		return
+	}
+	body, err := (&plog.JSONMarshaler{}).MarshalLogs(result)
+	if err != nil {
+		e.writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(body)
+}
+
+func (e *localBufferExporter) handleTrace(w http.ResponseWriter, r *http.Request) {
+	id, err := hex.DecodeString(r.PathValue("trace_id"))
+	if err != nil || len(id) != 16 {
+		http.Error(w, fmt.Sprintf("invalid trace ID %q, expected 32 hex characters", r.PathValue("trace_id")), http.StatusBadRequest)
+		return
+	}
+	traceID := pcommon.TraceID(id)
+
+	result := ptrace.NewTraces()
+	err = e.traces.scan(r.Context(), batchFilter{traceID: traceID}, func(data []byte) (bool, error) {
+		td, err := tracesUnmarshaler.UnmarshalTraces(data)
+		if err != nil {
+			return false, err
+		}
+		for i := 0; i < td.ResourceSpans().Len(); i++ {
+			rs := td.ResourceSpans().At(i)
+			var outRS ptrace.ResourceSpans
+			hasRS := false
+			for j := 0; j < rs.ScopeSpans().Len(); j++ {
+				ss := rs.ScopeSpans().At(j)
+				var outSS ptrace.ScopeSpans
+				hasSS := false
+				for k := 0; k < ss.Spans().Len(); k++ {
+					span := ss.Spans().At(k)
+					if span.TraceID() != traceID {
+						continue
+					}
+					if !hasRS {
+						hasRS = true
+						outRS = result.ResourceSpans().AppendEmpty()
+						rs.Resource().CopyTo(outRS.Resource())
+						outRS.SetSchemaUrl(rs.SchemaUrl())
+					}
+					if !hasSS {
+						hasSS = true
+						outSS = outRS.ScopeSpans().AppendEmpty()
+						ss.Scope().CopyTo(outSS.Scope())
+						outSS.SetSchemaUrl(ss.SchemaUrl())
+					}
+					span.CopyTo(outSS.Spans().AppendEmpty())
+				}
+			}
+		}
+		return true, nil
+	})
+	if err != nil {
+		e.writeError(w, err)
+		return
+	}
+	if result.SpanCount() == 0 {
+		http.Error(w, fmt.Sprintf("trace %s not found", traceID), http.StatusNotFound)
+		return
+	}
+	body, err := (&ptrace.JSONMarshaler{}).MarshalTraces(result)
+	if err != nil {
+		e.writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(body)
+}
+
+func (e *localBufferExporter) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	name := query.Get("name")
+	if name == "" {
+		http.Error(w, "missing metric name", http.StatusBadRequest)
+		return
+	}
+	filters, err := parseAttributeFilters(query)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	last, err := parsePositiveInt(query, "last", defaultLastPoints)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	series := newSeriesSet(name, filters, last)
+	err = e.metrics.scan(r.Context(), batchFilter{}, func(data []byte) (bool, error) {
+		md, err := metricsUnmarshaler.UnmarshalMetrics(data)
+		if err != nil {
+			return false, err
+		}
+		series.add(md)
+		return true, nil
+	})
+	if err != nil {
+		e.writeError(w, err)
+		return
+	}
+	body, err := (&pmetric.JSONMarshaler{}).MarshalMetrics(series.metrics())
+	if err != nil {
+		e.writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(body)
+}
+
+func (e *localBufferExporter) writeError(w http.ResponseWriter, err error) {
+	e.logger.Warn("Failed to query local buffer", zap.Error(err))
+	http.Error(w, err.Error(), http.StatusInternalServerError)
+}
@@ -0,0 +1,185 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package localbufferexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/localbufferexporter"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatautil"
+)
+
+type dataPoint[P any] interface {
+	Attributes() pcommon.Map
+	Timestamp() pcommon.Timestamp
+	CopyTo(P)
+}
+
+type dataPointSlice[P any] interface {
+	Len() int
+	At(int) P
+	AppendEmpty() P
+	Sort(func(a, b P) bool)
+	RemoveIf(func(P) bool)
+}
+
+// series holds the most recent data points of a single metric stream, identified by its
+// resource, scope, metric name and data point attributes.
+type series struct {
+	metrics pmetric.Metrics
+	metric  pmetric.Metric
+}
+
+// seriesSet collects the last data points of every series of a metric.
+type seriesSet struct {
+	name    string
+	filters []attributeFilter
+	last    int
+
+	index  map[[16]byte]*series
+	series []*series
+}
+
+func newSeriesSet(name string, filters []attributeFilter, last int) *seriesSet {
+	return &seriesSet{
+		name:    name,
+		filters: filters,
+		last:    last,
+		index:   map[[16]byte]*series{},
+	}
+}
+
+func (ss *seriesSet) add(md pmetric.Metrics) {
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		rm := md.ResourceMetrics().At(i)
+		for j := 0; j < rm.ScopeMetrics().Len(); j++ {
+			sm := rm.ScopeMetrics().At(j)
+			for k := 0; k < sm.Metrics().Len(); k++ {
+				m := sm.Metrics().At(k)
+				if m.Name() != ss.name {
+					continue
+				}
+				switch m.Type() {
+				case pmetric.MetricTypeGauge:
+					addDataPoints(ss, rm, sm, m, m.Gauge().DataPoints(), func(dst pmetric.Metric) pmetric.NumberDataPointSlice {
+						return dst.Gauge().DataPoints()
+					})
+				case pmetric.MetricTypeSum:
+					addDataPoints(ss, rm, sm, m, m.Sum().DataPoints(), func(dst pmetric.Metric) pmetric.NumberDataPointSlice {
+						return dst.Sum().DataPoints()
+					})
+				case pmetric.MetricTypeHistogram:
+					addDataPoints(ss, rm, sm, m, m.Histogram().DataPoints(), func(dst pmetric.Metric) pmetric.HistogramDataPointSlice {
+						return dst.Histogram().DataPoints()
+					})
+				case pmetric.MetricTypeExponentialHistogram:
+					addDataPoints(ss, rm, sm, m, m.ExponentialHistogram().DataPoints(), func(dst pmetric.Metric) pmetric.ExponentialHistogramDataPointSlice {
+						return dst.ExponentialHistogram().DataPoints()
+					})
+				case pmetric.MetricTypeSummary:
+					addDataPoints(ss, rm, sm, m, m.Summary().DataPoints(), func(dst pmetric.Metric) pmetric.SummaryDataPointSlice {
+						return dst.Summary().DataPoints()
+					})
+				}
+			}
+		}
+	}
+}
+
+func addDataPoints[P dataPoint[P], S dataPointSlice[P]](ss *seriesSet, rm pmetric.ResourceMetrics, sm pmetric.ScopeMetrics, m pmetric.Metric, src S, points func(pmetric.Metric) S) {
+	for i := 0; i < src.Len(); i++ {
+		dp := src.At(i)
+		if !matchAttributes(ss.filters, rm.Resource().Attributes(), dp.Attributes()) {
+			continue
+		}
+		s := ss.getOrCreate(rm, sm, m, dp.Attributes())
+		dst := points(s.metric)
+		dp.CopyTo(dst.AppendEmpty())
+		// Trim lazily to bound the memory used by long series.
+		if dst.Len() >= 2*ss.last {
+			keepLast(dst, ss.last)
+		}
+	}
+}
+
+// keepLast sorts the data points by timestamp and drops all but the last n.
+func keepLast[P dataPoint[P], S dataPointSlice[P]](points S, n int) {
+	points.Sort(func(a, b P) bool {
+		return a.Timestamp() < b.Timestamp()
+	})
+	drop := points.Len() - n
+	points.RemoveIf(func(P) bool {
+		drop--
+		return drop >= 0
+	})
+}
+
+func (ss *seriesSet) getOrCreate(rm pmetric.ResourceMetrics, sm pmetric.ScopeMetrics, m pmetric.Metric, attrs pcommon.Map) *series {
+	key := pdatautil.Hash(
+		pdatautil.WithMap(rm.Resource().Attributes()),
+		pdatautil.WithString(sm.Scope().Name()),
+		pdatautil.WithString(m.Type().String()),
+		pdatautil.WithMap(attrs),
+	)
+	if s, ok := ss.index[key]; ok {
+		return s
+	}
+
+	s := &series{metrics: pmetric.NewMetrics()}
+	outRM := s.metrics.ResourceMetrics().AppendEmpty()
+	rm.Resource().CopyTo(outRM.Resource())
+	outRM.SetSchemaUrl(rm.SchemaUrl())
+	outSM := outRM.ScopeMetrics().AppendEmpty()
+	sm.Scope().CopyTo(outSM.Scope())
+	outSM.SetSchemaUrl(sm.SchemaUrl())
+	s.metric = outSM.Metrics().AppendEmpty()
+	copyMetricDescriptor(m, s.metric)
+
+	ss.index[key] = s
+	ss.series = append(ss.series, s)
+	return s
+}
+
+// copyMetricDescriptor copies everything but the data points of src to dst.
+func copyMetricDescriptor(src, dst pmetric.Metric) {
+	dst.SetName(src.Name())
+	dst.SetDescription(src.Description())
+	dst.SetUnit(src.Unit())
+	src.Metadata().CopyTo(dst.Metadata())
+	switch src.Type() {
+	case pmetric.MetricTypeGauge:
+		dst.SetEmptyGauge()
+	case pmetric.MetricTypeSum:
+		sum := dst.SetEmptySum()
+		sum.SetAggregationTemporality(src.Sum().AggregationTemporality())
+		sum.SetIsMonotonic(src.Sum().IsMonotonic())
+	case pmetric.MetricTypeHistogram:
+		dst.SetEmptyHistogram().SetAggregationTemporality(src.Histogram().AggregationTemporality())
+	case pmetric.MetricTypeExponentialHistogram:
+		dst.SetEmptyExponentialHistogram().SetAggregationTemporality(src.ExponentialHistogram().AggregationTemporality())
+	case pmetric.MetricTypeSummary:
+		dst.SetEmptySummary()
+	}
+}
+
+// metrics returns the last data points of every series, in the order the series were first seen.
+func (ss *seriesSet) metrics() pmetric.Metrics {
+	result := pmetric.NewMetrics()
+	for _, s := range ss.series {
+		switch s.metric.Type() {
+		case pmetric.MetricTypeGauge:
+			keepLast(s.metric.Gauge().DataPoints(), ss.last)
+		case pmetric.MetricTypeSum:
+			keepLast(s.metric.Sum().DataPoints(), ss.last)
+		case pmetric.MetricTypeHistogram:
+			keepLast(s.metric.Histogram().DataPoints(), ss.last)
+		case pmetric.MetricTypeExponentialHistogram:
+			keepLast(s.metric.ExponentialHistogram().DataPoints(), ss.last)
+		case pmetric.MetricTypeSummary:
+			keepLast(s.metric.Summary().DataPoints(), ss.last)
+		}
+		s.metrics.ResourceMetrics().MoveAndAppendTo(result.ResourceMetrics())
+	}
+	return result
+}
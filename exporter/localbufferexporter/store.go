@@ -0,0 +1,335 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package localbufferexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/localbufferexporter"
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+const (
+	indexKey          = "index"
+	batchKeyPrefix    = "batch_"
+	traceIDsKeyPrefix = "trace_ids_"
+)
+
+func getStorageClient(ctx context.Context, host component.Host, storageID *component.ID, componentID component.ID, signal string) (storage.Client, error) {
+	if storageID == nil {
+		return newMemoryClient(), nil
+	}
+
+	extension, ok := host.GetExtensions()[*storageID]
+	if !ok {
+		return nil, fmt.Errorf("storage extension '%s' not found", storageID)
+	}
+
+	storageExtension, ok := extension.(storage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("non-storage extension '%s' found", storageID)
+	}
+
+	return storageExtension.GetClient(ctx, component.KindExporter, componentID, signal)
+}
+
+// batchEntry describes one stored batch in the index.
+type batchEntry struct {
+	Seq      uint64 `json:"seq"`
+	Received int64  `json:"received"`
+	Size     int64  `json:"size"`
+	// Start and End are the earliest and latest timestamps of the records of the batch, zero
+	// when unknown.
+	Start int64 `json:"start,omitempty"`
+	End   int64 `json:"end,omitempty"`
+}
+
+// batchMeta describes the telemetry of a batch, so that queries skip the batches that can't match.
+type batchMeta struct {
+	start    pcommon.Timestamp
+	end      pcommon.Timestamp
+	traceIDs []pcommon.TraceID
+}
+
+// extend extends the time range of the batch to include ts.
+func (m *batchMeta) extend(ts pcommon.Timestamp) {
+	if ts == 0 {
+		return
+	}
+	if m.start == 0 || ts < m.start {
+		m.start = ts
+	}
+	if ts > m.end {
+		m.end = ts
+	}
+}
+
+// batchFilter selects the batches read by a query. Zero fields don't filter.
+type batchFilter struct {
+	start   pcommon.Timestamp
+	end     pcommon.Timestamp
+	traceID pcommon.TraceID
+}
+
+func batchKey(seq uint64) string {
+	return batchKeyPrefix + strconv.FormatUint(seq, 10)
+}
+
+func traceIDsKey(seq uint64) string {
+	return traceIDsKeyPrefix + strconv.FormatUint(seq, 10)
+}
+
+// signalStore keeps the OTLP encoded batches of one signal in a storage client, together with
+// an index of the stored batches in reception order. The index is the source of truth: batches
+// it does not reference are never read.
+//
+// When it indexes trace IDs, the IDs of the traces of each batch are stored under their own key,
+// and kept in memory, so that looking up a trace only reads the batches holding its spans.
+type signalStore struct {
+	client      storage.Client
+	retention   RetentionConfig
+	indexTraces bool
+	now         func() time.Time
+
+	mu      sync.Mutex
+	entries []batchEntry
+	size    int64
+	nextSeq uint64
+	// traceBatches maps the trace IDs to the sequence numbers of the batches holding their spans,
+	// and batchTraces the sequence numbers to the trace IDs, to update the former on eviction.
+	traceBatches map[pcommon.TraceID][]uint64
+	batchTraces  map[uint64][]pcommon.TraceID
+}
+
+func newSignalStore(ctx context.Context, client storage.Client, retention RetentionConfig, indexTraces bool) (*signalStore, error) {
+	s := &signalStore{
+		client:       client,
+		retention:    retention,
+		indexTraces:  indexTraces,
+		now:          time.Now,
+		traceBatches: map[pcommon.TraceID][]uint64{},
+		batchTraces:  map[uint64][]pcommon.TraceID{},
+	}
+	data, err := client.Get(ctx, indexKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read index: %w", err)
+	}
+	if data == nil {
+		return s, nil
+	}
+	if err = json.Unmarshal(data, &s.entries); err != nil {
+		return nil, fmt.Errorf("failed to decode index: %w", err)
+	}
+	for _, e := range s.entries {
+		s.size += e.Size
+		if e.Seq >= s.nextSeq {
+			s.nextSeq = e.Seq + 1
+		}
+		if !indexTraces {
+			continue
+		}
+		data, err := client.Get(ctx, traceIDsKey(e.Seq))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read trace IDs: %w", err)
+		}
+		s.addTraceIDs(e.Seq, decodeTraceIDs(data))
+	}
+	return s, nil
+}
+
+func encodeTraceIDs(ids []pcommon.TraceID) []byte {
+	data := make([]byte, 0, len(ids)*16)
+	for _, id := range ids {
+		data = append(data, id[:]...)
+	}
+	return data
+}
+
+func decodeTraceIDs(data []byte) []pcommon.TraceID {
+	ids := make([]pcommon.TraceID, 0, len(data)/16)
+	for ; len(data) >= 16; data = data[16:] {
+		ids = append(ids, pcommon.TraceID(data[:16]))
+	}
+	return ids
+}
+
+func (s *signalStore) addTraceIDs(seq uint64, ids []pcommon.TraceID) {
+	s.batchTraces[seq] = ids
+	for _, id := range ids {
+		s.traceBatches[id] = append(s.traceBatches[id], seq)
+	}
+}
+
+func (s *signalStore) removeTraceIDs(seq uint64) {
+	for _, id := range s.batchTraces[seq] {
+		// batches are evicted oldest first, so seq is the first batch of the trace
+		batches := s.traceBatches[id]
+		for len(batches) > 0 && batches[0] <= seq {
+			batches = batches[1:]
+		}
+		if len(batches) == 0 {
+			delete(s.traceBatches, id)
+			continue
+		}
+		s.traceBatches[id] = batches
+	}
+	delete(s.batchTraces, seq)
+}
+
+// append stores data as a new batch and evicts the batches exceeding the retention limits.
+func (s *signalStore) append(ctx context.Context, data []byte, meta batchMeta) error {
+	size := int64(len(data))
+	if size > s.retention.maxBytes() {
+		return consumererror.NewPermanent(fmt.Errorf("batch of %d bytes exceeds the retention size of %d bytes", size, s.retention.maxBytes()))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	entry := batchEntry{Seq: s.nextSeq, Received: now.UnixNano(), Size: size, Start: int64(meta.start), End: int64(meta.end)}
+	entries := append(s.entries, entry)
+	total := s.size + size
+
+	ops := []storage.Operation{storage.SetOperation(batchKey(entry.Seq), data)}
+	if s.indexTraces {
+		ops = append(ops, storage.SetOperation(traceIDsKey(entry.Seq), encodeTraceIDs(meta.traceIDs)))
+	}
+	expiry := now.Add(-s.retention.MaxAge).UnixNano()
+	evicted := 0
+	for _, e := range entries {
+		if e.Received >= expiry && total <= s.retention.maxBytes() {
+			break
+		}
+		ops = append(ops, storage.DeleteOperation(batchKey(e.Seq)))
+		if s.indexTraces {
+			ops = append(ops, storage.DeleteOperation(traceIDsKey(e.Seq)))
+		}
+		total -= e.Size
+		evicted++
+	}
+
+	index, err := json.Marshal(entries[evicted:])
+	if err != nil {
+		return err
+	}
+	ops = append(ops, storage.SetOperation(indexKey, index))
+	if err = s.client.Batch(ctx, ops...); err != nil {
+		return err
+	}
+
+	for _, e := range entries[:evicted] {
+		s.removeTraceIDs(e.Seq)
+	}
+	if s.indexTraces {
+		s.addTraceIDs(entry.Seq, meta.traceIDs)
+	}
+	s.entries = entries[evicted:]
+	s.size = total
+	s.nextSeq++
+	return nil
+}
+
+// scan calls fn with the data of every unexpired batch selected by filter, oldest first, until
+// fn returns false. Batches are only skipped based on the index: fn must still filter the
+// records of the batches it is called with.
+func (s *signalStore) scan(ctx context.Context, filter batchFilter, fn func(data []byte) (bool, error)) error {
+	s.mu.Lock()
+	entries := make([]batchEntry, len(s.entries))
+	copy(entries, s.entries)
+	var traceBatches map[uint64]bool
+	if s.indexTraces && !filter.traceID.IsEmpty() {
+		traceBatches = map[uint64]bool{}
+		for _, seq := range s.traceBatches[filter.traceID] {
+			traceBatches[seq] = true
+		}
+	}
+	s.mu.Unlock()
+
+	expiry := s.now().Add(-s.retention.MaxAge).UnixNano()
+	for _, e := range entries {
+		if e.Received < expiry {
+			continue
+		}
+		if traceBatches != nil && !traceBatches[e.Seq] {
+			continue
+		}
+		if filter.start != 0 && e.End != 0 && e.End < int64(filter.start) {
+			continue
+		}
+		if filter.end != 0 && e.Start != 0 && e.Start > int64(filter.end) {
+			continue
+		}
+		data, err := s.client.Get(ctx, batchKey(e.Seq))
+		if err != nil {
+			return err
+		}
+		// The batch has been evicted since the index was copied.
+		if data == nil {
+			continue
+		}
+		next, err := fn(data)
+		if err != nil || !next {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *signalStore) close(ctx context.Context) error {
+	return s.client.Close(ctx)
+}
+
+// memoryClient is the storage.Client used when no storage extension is configured.
+type memoryClient struct {
+	mu   sync.Mutex
+	data map[string][]byte
+}
+
+func newMemoryClient() *memoryClient {
+	return &memoryClient{data: map[string][]byte{}}
+}
+
+func (c *memoryClient) Get(ctx context.Context, key string) ([]byte, error) {
+	op := storage.GetOperation(key)
+	err := c.Batch(ctx, op)
+	return op.Value, err
+}
+
+func (c *memoryClient) Set(ctx context.Context, key string, value []byte) error {
+	return c.Batch(ctx, storage.SetOperation(key, value))
+}
+
+func (c *memoryClient) Delete(ctx context.Context, key string) error {
+	return c.Batch(ctx, storage.DeleteOperation(key))
+}
+
+func (c *memoryClient) Batch(_ context.Context, ops ...storage.Operation) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, op := range ops {
+		switch op.Type {
+		case storage.Get:
+			op.Value = c.data[op.Key]
+		case storage.Set:
+			c.data[op.Key] = op.Value
+		case storage.Delete:
+			delete(c.data, op.Key)
+		default:
+			return fmt.Errorf("unsupported operation type %d", op.Type)
+		}
+	}
+	return nil
+}
+
+func (c *memoryClient) Close(context.Context) error {
+	return nil
+}
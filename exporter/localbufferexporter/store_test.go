@@ -0,0 +1,165 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package localbufferexporter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/localbufferexporter/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+)
+
+func scanAll(t *testing.T, s *signalStore) []string {
+	return scanFiltered(t, s, batchFilter{})
+}
+
+func scanFiltered(t *testing.T, s *signalStore, filter batchFilter) []string {
+	var batches []string
+	require.NoError(t, s.scan(context.Background(), filter, func(data []byte) (bool, error) {
+		batches = append(batches, string(data))
+		return true, nil
+	}))
+	return batches
+}
+
+func TestSignalStoreRetentionByAge(t *testing.T) {
+	ctx := context.Background()
+	s, err := newSignalStore(ctx, newMemoryClient(), RetentionConfig{MaxAge: time.Minute, MaxSizeMiB: 1}, false)
+	require.NoError(t, err)
+	now := time.Unix(1_000, 0)
+	s.now = func() time.Time { return now }
+
+	require.NoError(t, s.append(ctx, []byte("a"), batchMeta{}))
+	now = now.Add(30 * time.Second)
+	require.NoError(t, s.append(ctx, []byte("b"), batchMeta{}))
+	assert.Equal(t, []string{"a", "b"}, scanAll(t, s))
+
+	// Expired batches are hidden from queries before they are evicted.
+	now = now.Add(45 * time.Second)
+	assert.Equal(t, []string{"b"}, scanAll(t, s))
+
+	require.NoError(t, s.append(ctx, []byte("c"), batchMeta{}))
+	assert.Equal(t, []string{"b", "c"}, scanAll(t, s))
+	assert.Len(t, s.entries, 2)
+	data, err := s.client.Get(ctx, batchKey(0))
+	require.NoError(t, err)
+	assert.Nil(t, data)
+}
+
+func TestSignalStoreRetentionBySize(t *testing.T) {
+	ctx := context.Background()
+	s, err := newSignalStore(ctx, newMemoryClient(), RetentionConfig{MaxAge: time.Hour, MaxSizeMiB: 1}, false)
+	require.NoError(t, err)
+
+	for _, b := range []byte("abcd") {
+		batch := make([]byte, 400<<10)
+		batch[0] = b
+		require.NoError(t, s.append(ctx, batch, batchMeta{}))
+	}
+	batches := scanAll(t, s)
+	require.Len(t, batches, 2)
+	assert.Equal(t, byte('c'), batches[0][0])
+	assert.Equal(t, byte('d'), batches[1][0])
+	assert.Equal(t, int64(800<<10), s.size)
+
+	err = s.append(ctx, make([]byte, 2<<20), batchMeta{})
+	assert.True(t, consumererror.IsPermanent(err))
+}
+
+func TestSignalStoreScanStops(t *testing.T) {
+	ctx := context.Background()
+	s, err := newSignalStore(ctx, newMemoryClient(), RetentionConfig{MaxAge: time.Hour, MaxSizeMiB: 1}, false)
+	require.NoError(t, err)
+	for _, b := range []string{"a", "b", "c"} {
+		require.NoError(t, s.append(ctx, []byte(b), batchMeta{}))
+	}
+
+	var seen []string
+	require.NoError(t, s.scan(ctx, batchFilter{}, func(data []byte) (bool, error) {
+		seen = append(seen, string(data))
+		return len(seen) < 2, nil
+	}))
+	assert.Equal(t, []string{"a", "b"}, seen)
+}
+
+func TestSignalStoreReload(t *testing.T) {
+	ctx := context.Background()
+	client := storagetest.NewInMemoryClient(component.KindExporter, component.NewID(metadata.Type), "logs")
+	retention := RetentionConfig{MaxAge: time.Hour, MaxSizeMiB: 1}
+
+	s, err := newSignalStore(ctx, client, retention, false)
+	require.NoError(t, err)
+	require.NoError(t, s.append(ctx, []byte("a"), batchMeta{}))
+	require.NoError(t, s.append(ctx, []byte("b"), batchMeta{}))
+
+	reloaded, err := newSignalStore(ctx, client, retention, false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, scanAll(t, reloaded))
+	assert.Equal(t, uint64(2), reloaded.nextSeq)
+	assert.Equal(t, int64(2), reloaded.size)
+
+	require.NoError(t, reloaded.append(ctx, []byte("c"), batchMeta{}))
+	assert.Equal(t, []string{"a", "b", "c"}, scanAll(t, reloaded))
+}
+
+func TestSignalStoreTimeRange(t *testing.T) {
+	ctx := context.Background()
+	s, err := newSignalStore(ctx, newMemoryClient(), RetentionConfig{MaxAge: time.Hour, MaxSizeMiB: 1}, false)
+	require.NoError(t, err)
+	require.NoError(t, s.append(ctx, []byte("a"), batchMeta{start: 10, end: 20}))
+	require.NoError(t, s.append(ctx, []byte("b"), batchMeta{start: 30, end: 40}))
+	// Batches without a time range are always read.
+	require.NoError(t, s.append(ctx, []byte("c"), batchMeta{}))
+
+	assert.Equal(t, []string{"a", "b", "c"}, scanFiltered(t, s, batchFilter{}))
+	assert.Equal(t, []string{"b", "c"}, scanFiltered(t, s, batchFilter{start: 25}))
+	assert.Equal(t, []string{"a", "c"}, scanFiltered(t, s, batchFilter{end: 25}))
+	assert.Equal(t, []string{"a", "b", "c"}, scanFiltered(t, s, batchFilter{start: 20, end: 30}))
+	assert.Equal(t, []string{"c"}, scanFiltered(t, s, batchFilter{start: 21, end: 29}))
+}
+
+func TestSignalStoreTraceIndex(t *testing.T) {
+	ctx := context.Background()
+	client := storagetest.NewInMemoryClient(component.KindExporter, component.NewID(metadata.Type), "traces")
+	retention := RetentionConfig{MaxAge: time.Minute, MaxSizeMiB: 1}
+	traceA := pcommon.TraceID([16]byte{1})
+	traceB := pcommon.TraceID([16]byte{2})
+	traceC := pcommon.TraceID([16]byte{3})
+
+	s, err := newSignalStore(ctx, client, retention, true)
+	require.NoError(t, err)
+	now := time.Unix(1_000, 0)
+	s.now = func() time.Time { return now }
+	require.NoError(t, s.append(ctx, []byte("a"), batchMeta{traceIDs: []pcommon.TraceID{traceA}}))
+	now = now.Add(30 * time.Second)
+	require.NoError(t, s.append(ctx, []byte("ab"), batchMeta{traceIDs: []pcommon.TraceID{traceA, traceB}}))
+	require.NoError(t, s.append(ctx, []byte("b"), batchMeta{traceIDs: []pcommon.TraceID{traceB}}))
+
+	assert.Equal(t, []string{"a", "ab"}, scanFiltered(t, s, batchFilter{traceID: traceA}))
+	assert.Equal(t, []string{"ab", "b"}, scanFiltered(t, s, batchFilter{traceID: traceB}))
+	assert.Empty(t, scanFiltered(t, s, batchFilter{traceID: traceC}))
+
+	reloaded, err := newSignalStore(ctx, client, retention, true)
+	require.NoError(t, err)
+	reloaded.now = s.now
+	assert.Equal(t, []string{"ab", "b"}, scanFiltered(t, reloaded, batchFilter{traceID: traceB}))
+
+	// Evicting a batch drops it from the index, and from the storage.
+	now = now.Add(45 * time.Second)
+	require.NoError(t, reloaded.append(ctx, []byte("c"), batchMeta{traceIDs: []pcommon.TraceID{traceC}}))
+	assert.Equal(t, []string{"ab"}, scanFiltered(t, reloaded, batchFilter{traceID: traceA}))
+	assert.Equal(t, []string{"c"}, scanFiltered(t, reloaded, batchFilter{traceID: traceC}))
+	assert.Equal(t, map[pcommon.TraceID][]uint64{traceA: {1}, traceB: {1, 2}, traceC: {3}}, reloaded.traceBatches)
+	data, err := client.Get(ctx, traceIDsKey(0))
+	require.NoError(t, err)
+	assert.Nil(t, data)
+}
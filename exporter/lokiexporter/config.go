@@ -19,6 +19,26 @@ type Config struct {
 	configretry.BackOffConfig `mapstructure:"retry_on_failure"`
 
 	DefaultLabelsEnabled map[string]bool `mapstructure:"default_labels_enabled"`
+
+	// Labels selects the labels of the log records, in addition to the default labels.
+	// When set, the "loki.attribute.labels" and "loki.resource.labels" hints are ignored.
+	Labels []LabelConfig `mapstructure:"labels"`
+
+	// StructuredMetadata sends the attributes that are not labels and the fields of the log
+	// records as structured metadata, and their body as the line. Requires Loki 3 or later.
+	StructuredMetadata bool `mapstructure:"structured_metadata"`
+}
+
+// LabelConfig defines a label computed from the log records with OTTL.
+type LabelConfig struct {
+	// Name is the name of the label. It is normalized like the labels selected by hints.
+	Name string `mapstructure:"name"`
+	// Value is an OTTL value expression in the log context. The label is not set when the
+	// value is nil or empty.
+	Value string `mapstructure:"value"`
+	// Conditions are OTTL conditions in the log context. When set, the label is only set
+	// for the log records matching any of them.
+	Conditions []string `mapstructure:"conditions"`
 }
 
 func (c *Config) Validate() error {
@@ -29,5 +49,19 @@ func (c *Config) Validate() error {
 	if _, err := url.Parse(c.Endpoint); c.Endpoint == "" || err != nil {
 		return fmt.Errorf("\"endpoint\" must be a valid URL")
 	}
+
+	names := map[string]bool{}
+	for _, label := range c.Labels {
+		if label.Name == "" {
+			return fmt.Errorf("\"labels\" must have a name")
+		}
+		if names[label.Name] {
+			return fmt.Errorf("\"labels\" has duplicate name %q", label.Name)
+		}
+		names[label.Name] = true
+		if label.Value == "" {
+			return fmt.Errorf("\"labels\" %q must have a value", label.Name)
+		}
+	}
 	return nil
 }
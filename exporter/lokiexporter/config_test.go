@@ -72,6 +72,11 @@ func TestLoadConfigNewExporter(t *testing.T) {
 					"instance": true,
 					"level":    false,
 				},
+				Labels: []LabelConfig{
+					{Name: "namespace", Value: `resource.attributes["k8s.namespace.name"]`},
+					{Name: "status_class", Value: `"5xx"`, Conditions: []string{`attributes["http.status"] >= 500`}},
+				},
+				StructuredMetadata: true,
 			},
 		},
 	}
@@ -107,6 +112,30 @@ func TestConfigValidate(t *testing.T) {
 			cfg:  &Config{},
 			err:  fmt.Errorf("\"endpoint\" must be a valid URL"),
 		},
+		{
+			desc: "Label without name",
+			cfg: &Config{
+				ClientConfig: confighttp.ClientConfig{Endpoint: "https://loki.example.com"},
+				Labels:       []LabelConfig{{Value: "body"}},
+			},
+			err: fmt.Errorf("\"labels\" must have a name"),
+		},
+		{
+			desc: "Label with duplicate name",
+			cfg: &Config{
+				ClientConfig: confighttp.ClientConfig{Endpoint: "https://loki.example.com"},
+				Labels:       []LabelConfig{{Name: "a", Value: "body"}, {Name: "a", Value: "body"}},
+			},
+			err: fmt.Errorf("\"labels\" has duplicate name \"a\""),
+		},
+		{
+			desc: "Label without value",
+			cfg: &Config{
+				ClientConfig: confighttp.ClientConfig{Endpoint: "https://loki.example.com"},
+				Labels:       []LabelConfig{{Name: "a"}},
+			},
+			err: fmt.Errorf("\"labels\" \"a\" must have a value"),
+		},
 		{
 			desc: "Config is valid",
 			cfg: &Config{
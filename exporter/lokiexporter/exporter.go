@@ -35,6 +35,7 @@ type lokiExporter struct {
 	settings component.TelemetrySettings
 	client   *http.Client
 	wg       sync.WaitGroup
+	labels   *labelRules
 
 	telemetryBuilder *metadata.TelemetryBuilder
 }
@@ -48,15 +49,25 @@ func newExporter(config *Config, settings component.TelemetrySettings) (*lokiExp
 		return nil, err
 	}
 
+	labels, err := newLabelRules(config.Labels, settings)
+	if err != nil {
+		return nil, err
+	}
+
 	return &lokiExporter{
 		config:           config,
 		settings:         settings,
+		labels:           labels,
 		telemetryBuilder: builder,
 	}, nil
 }
 
 func (l *lokiExporter) pushLogData(ctx context.Context, ld plog.Logs) error {
-	requests := loki.LogsToLokiRequests(ld, l.config.DefaultLabelsEnabled)
+	requests := loki.LogsToLokiRequestsWithOptions(ld, loki.Options{
+		DefaultLabelsEnabled: l.config.DefaultLabelsEnabled,
+		Labels:               l.labels.labelsFunc(ctx),
+		StructuredMetadata:   l.config.StructuredMetadata,
+	})
 
 	var errs error
 	for tenant, request := range requests {
@@ -30,8 +30,11 @@ func TestPushLogData(t *testing.T) {
 		hints         map[string]any
 		attrs         map[string]any
 		res           map[string]any
+		labels        []LabelConfig
+		structured    bool
 		expectedLabel string
 		expectedLine  string
+		expectedMeta  push.LabelsAdapter
 	}{
 		{
 			desc: "with attribute to label and regular attribute",
@@ -57,6 +60,35 @@ func TestPushLogData(t *testing.T) {
 			expectedLabel: `{exporter="OTLP", host_name="guarana"}`,
 			expectedLine:  `{"traceid":"01020304000000000000000000000000","resources":{"region.az":"eu-west-1a"}}`,
 		},
+		{
+			desc: "with labels rules and structured metadata",
+			attrs: map[string]any{
+				"http.status": 200,
+				"user.id":     "1234",
+			},
+			res: map[string]any{
+				"host.name": "guarana",
+				"region.az": "eu-west-1a",
+			},
+			hints: map[string]any{
+				"loki.attribute.labels": "user.id",
+			},
+			labels: []LabelConfig{
+				{Name: "host.name", Value: `resource.attributes["host.name"]`},
+				{Name: "status_class", Value: `"5xx"`, Conditions: []string{`attributes["http.status"] >= 500`}},
+				{Name: "region", Value: `resource.attributes["region.missing"]`},
+			},
+			structured:    true,
+			expectedLabel: `{exporter="OTLP", host_name="guarana"}`,
+			expectedLine:  "",
+			expectedMeta: push.LabelsAdapter{
+				{Name: "host_name", Value: "guarana"},
+				{Name: "http_status", Value: "200"},
+				{Name: "region_az", Value: "eu-west-1a"},
+				{Name: "trace_id", Value: "01020304000000000000000000000000"},
+				{Name: "user_id", Value: "1234"},
+			},
+		},
 	}
 	for _, tC := range testCases {
 		t.Run(tC.desc, func(t *testing.T) {
@@ -79,6 +111,8 @@ func TestPushLogData(t *testing.T) {
 				ClientConfig: confighttp.ClientConfig{
 					Endpoint: ts.URL,
 				},
+				Labels:             tC.labels,
+				StructuredMetadata: tC.structured,
 			}
 
 			f := NewFactory()
@@ -118,6 +152,7 @@ func TestPushLogData(t *testing.T) {
 
 			assert.Len(t, actualPushRequest.Streams[0].Entries, 1)
 			assert.Equal(t, tC.expectedLine, actualPushRequest.Streams[0].Entries[0].Line)
+			assert.Equal(t, tC.expectedMeta, actualPushRequest.Streams[0].Entries[0].StructuredMetadata)
 
 			// cleanup
 			err = exp.Shutdown(context.Background())
@@ -0,0 +1,117 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package lokiexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/lokiexporter"
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/prometheus/common/model"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/loki"
+)
+
+// labelRule is a parsed LabelConfig.
+type labelRule struct {
+	name       model.LabelName
+	value      *ottl.ValueExpression[ottllog.TransformContext]
+	conditions *ottl.ConditionSequence[ottllog.TransformContext]
+}
+
+// labelRules selects the labels of the log records. A nil *labelRules selects no labels,
+// leaving the selection to the hints.
+type labelRules struct {
+	rules []labelRule
+}
+
+func newLabelRules(cfgs []LabelConfig, set component.TelemetrySettings) (*labelRules, error) {
+	if len(cfgs) == 0 {
+		return nil, nil
+	}
+	parser, err := ottllog.NewParser(ottlfuncs.StandardConverters[ottllog.TransformContext](), set)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := make([]labelRule, 0, len(cfgs))
+	for _, cfg := range cfgs {
+		value, err := parser.ParseValueExpression(cfg.Value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse value of label %q: %w", cfg.Name, err)
+		}
+		rule := labelRule{name: model.LabelName(cfg.Name), value: value}
+		if len(cfg.Conditions) > 0 {
+			conditions, err := parser.ParseConditions(cfg.Conditions)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse conditions of label %q: %w", cfg.Name, err)
+			}
+			sequence := ottl.NewConditionSequence(conditions, set,
+				ottl.WithLogicOperation[ottllog.TransformContext](ottl.Or),
+				ottl.WithConditionSequenceErrorMode[ottllog.TransformContext](ottl.PropagateError))
+			rule.conditions = &sequence
+		}
+		rules = append(rules, rule)
+	}
+	return &labelRules{rules: rules}, nil
+}
+
+// labelsFunc returns the loki.LabelsFunc evaluating the rules with ctx, or nil if r is nil.
+func (r *labelRules) labelsFunc(ctx context.Context) loki.LabelsFunc {
+	if r == nil {
+		return nil
+	}
+	return func(lr plog.LogRecord, resource pcommon.Resource, scope pcommon.InstrumentationScope) (model.LabelSet, error) {
+		return r.labels(ctx, ottllog.NewTransformContext(lr, scope, resource, plog.NewScopeLogs(), plog.NewResourceLogs()))
+	}
+}
+
+// labels evaluates the rules against a log record. A label is set when any of its conditions
+// matches, or when it has none, and its value is neither nil nor empty.
+func (r *labelRules) labels(ctx context.Context, tCtx ottllog.TransformContext) (model.LabelSet, error) {
+	labels := model.LabelSet{}
+	for _, rule := range r.rules {
+		if rule.conditions != nil {
+			matched, err := rule.conditions.Eval(ctx, tCtx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to evaluate conditions of label %q: %w", rule.name, err)
+			}
+			if !matched {
+				continue
+			}
+		}
+		v, err := rule.value.Eval(ctx, tCtx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to evaluate value of label %q: %w", rule.name, err)
+		}
+		value, err := labelValue(v)
+		if err != nil {
+			return nil, fmt.Errorf("label %q: %w", rule.name, err)
+		}
+		if value != "" {
+			labels[rule.name] = model.LabelValue(value)
+		}
+	}
+	return labels, nil
+}
+
+func labelValue(v any) (string, error) {
+	switch v := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case bool, int64, float64:
+		return fmt.Sprint(v), nil
+	case pcommon.Value:
+		return v.AsString(), nil
+	default:
+		return "", fmt.Errorf("value must evaluate to a string, a number or a boolean, got %T", v)
+	}
+}
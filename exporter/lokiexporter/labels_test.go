@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package lokiexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/lokiexporter"
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func TestNewLabelRules(t *testing.T) {
+	rules, err := newLabelRules(nil, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	assert.Nil(t, rules)
+	assert.Nil(t, rules.labelsFunc(context.Background()))
+
+	_, err = newLabelRules([]LabelConfig{{Name: "a", Value: "not a path("}}, componenttest.NewNopTelemetrySettings())
+	assert.ErrorContains(t, err, `failed to parse value of label "a"`)
+
+	_, err = newLabelRules([]LabelConfig{{Name: "a", Value: "body", Conditions: []string{"body =="}}}, componenttest.NewNopTelemetrySettings())
+	assert.ErrorContains(t, err, `failed to parse conditions of label "a"`)
+}
+
+func TestLabelRules(t *testing.T) {
+	rules, err := newLabelRules([]LabelConfig{
+		{Name: "service", Value: `resource.attributes["service.name"]`},
+		{Name: "severity", Value: "severity_number"},
+		{Name: "sampled", Value: `attributes["sampled"]`},
+		{Name: "error", Value: `"true"`, Conditions: []string{`severity_number >= SEVERITY_NUMBER_ERROR`, `attributes["error"] != nil`}},
+		{Name: "scope", Value: "instrumentation_scope.name"},
+		{Name: "missing", Value: `attributes["missing"]`},
+	}, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+
+	lr := plog.NewLogRecord()
+	lr.SetSeverityNumber(plog.SeverityNumberInfo)
+	lr.Attributes().PutBool("sampled", true)
+	lr.Attributes().PutStr("error", "timeout")
+	res := pcommon.NewResource()
+	res.Attributes().PutStr("service.name", "frontend")
+
+	labels, err := rules.labelsFunc(context.Background())(lr, res, pcommon.NewInstrumentationScope())
+	require.NoError(t, err)
+	assert.Equal(t, model.LabelSet{
+		"service":  "frontend",
+		"severity": "9",
+		"sampled":  "true",
+		"error":    "true",
+	}, labels)
+
+	rules, err = newLabelRules([]LabelConfig{{Name: "attrs", Value: "attributes"}}, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	_, err = rules.labelsFunc(context.Background())(lr, res, pcommon.NewInstrumentationScope())
+	assert.ErrorContains(t, err, `label "attrs": value must evaluate to a string, a number or a boolean, got pcommon.Map`)
+}
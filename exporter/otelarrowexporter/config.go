@@ -102,6 +102,19 @@ type ArrowConfig struct {
 	// Prioritizer is a policy name for how load is distributed
 	// across streams.
 	Prioritizer arrow.PrioritizerName `mapstructure:"prioritizer"`
+
+	// Adaptive scales the number of streams from the observed
+	// round-trip time, bytes in flight and receiver backpressure.
+	// NumStreams is the initial number of streams.
+	Adaptive arrow.AdaptiveConfig `mapstructure:"adaptive"`
+
+	// LargeBatches reserves streams for large batches, to avoid
+	// head-of-line blocking of small batches.
+	LargeBatches arrow.LargeBatchConfig `mapstructure:"large_batches"`
+
+	// SignalWeights multiplies the number of streams of each
+	// signal, to give latency-sensitive signals more streams.
+	SignalWeights arrow.SignalWeightsConfig `mapstructure:"signal_weights"`
 }
 
 var _ component.Config = (*Config)(nil)
@@ -144,6 +157,21 @@ func (cfg *ArrowConfig) Validate() error {
 		return fmt.Errorf("invalid prioritizer: %w", err)
 	}
 
+	if err := cfg.Adaptive.Validate(); err != nil {
+		return fmt.Errorf("adaptive: %w", err)
+	}
+	if cfg.Adaptive.Enabled && (cfg.NumStreams < cfg.Adaptive.MinStreams || cfg.NumStreams > cfg.Adaptive.MaxStreams) {
+		return fmt.Errorf("stream count must be between min_streams and max_streams: %d", cfg.NumStreams)
+	}
+
+	if err := cfg.LargeBatches.Validate(); err != nil {
+		return fmt.Errorf("large_batches: %w", err)
+	}
+
+	if err := cfg.SignalWeights.Validate(); err != nil {
+		return fmt.Errorf("signal_weights: %w", err)
+	}
+
 	// The cfg.PayloadCompression field is validated by the underlying library,
 	// but we only support Zstd or none.
 	switch cfg.PayloadCompression {
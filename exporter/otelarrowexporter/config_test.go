@@ -101,10 +101,37 @@ func TestUnmarshalConfig(t *testing.T) {
 				PayloadCompression: configcompression.TypeZstd,
 				Zstd:               zstd.DefaultEncoderConfig(),
 				Prioritizer:        "leastloaded8",
+				Adaptive: arrow.AdaptiveConfig{
+					Enabled:             true,
+					MinStreams:          1,
+					MaxStreams:          8,
+					Interval:            5 * time.Second,
+					RTTTolerance:        arrow.DefaultRTTTolerance,
+					TargetInFlightBytes: 4 << 20,
+				},
+				LargeBatches: arrow.LargeBatchConfig{
+					MinSize: 1 << 20,
+					Streams: 1,
+				},
+				SignalWeights: arrow.SignalWeightsConfig{
+					Traces:  2,
+					Metrics: 1,
+					Logs:    1,
+				},
 			},
 		}, cfg)
 }
 
+func TestUnmarshalAdaptiveConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "adaptive.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	require.NoError(t, cm.Unmarshal(cfg))
+	require.True(t, cfg.(*Config).Arrow.Adaptive.Enabled)
+	require.NoError(t, component.ValidateConfig(cfg))
+}
+
 func TestArrowConfigValidate(t *testing.T) {
 	settings := func(enabled bool, numStreams int, maxStreamLifetime time.Duration, level zstd.Level) *ArrowConfig {
 		return &ArrowConfig{
@@ -131,6 +158,27 @@ func TestArrowConfigValidate(t *testing.T) {
 	require.Error(t, settings(true, math.MinInt, 10*time.Second, zstd.DefaultLevel).Validate())
 	require.Error(t, settings(true, math.MaxInt, 10*time.Second, zstd.MinLevel-1).Validate())
 	require.Error(t, settings(true, math.MaxInt, 10*time.Second, zstd.MaxLevel+1).Validate())
+
+	adaptive := settings(true, 4, 10*time.Second, zstd.DefaultLevel)
+	adaptive.Adaptive = arrow.AdaptiveConfig{
+		Enabled:      true,
+		MinStreams:   1,
+		MaxStreams:   8,
+		Interval:     time.Second,
+		RTTTolerance: 2,
+	}
+	require.NoError(t, adaptive.Validate())
+	adaptive.NumStreams = 16
+	require.ErrorContains(t, adaptive.Validate(), "stream count must be between min_streams and max_streams")
+	adaptive.NumStreams = 4
+	adaptive.Adaptive.Interval = 0
+	require.ErrorContains(t, adaptive.Validate(), "adaptive: interval must be > 0")
+	adaptive.Adaptive.Interval = time.Second
+	adaptive.LargeBatches = arrow.LargeBatchConfig{MinSize: 1 << 20}
+	require.ErrorContains(t, adaptive.Validate(), "large_batches: streams must be > 0")
+	adaptive.LargeBatches = arrow.LargeBatchConfig{}
+	adaptive.SignalWeights = arrow.SignalWeightsConfig{Logs: -1}
+	require.ErrorContains(t, adaptive.Validate(), "signal_weights: logs must be >= 0")
 }
 
 func TestDefaultConfigValid(t *testing.T) {
@@ -16,6 +16,7 @@ import (
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exporterbatcher"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/pipeline"
 	"google.golang.org/grpc"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/otelarrowexporter/internal/arrow"
@@ -63,6 +64,21 @@ func createDefaultConfig() component.Config {
 			Zstd:        zstd.DefaultEncoderConfig(),
 			Prioritizer: arrow.DefaultPrioritizer,
 
+			Adaptive: arrow.AdaptiveConfig{
+				MinStreams:   1,
+				MaxStreams:   4 * arrow.DefaultNumStreams,
+				Interval:     arrow.DefaultAdaptiveInterval,
+				RTTTolerance: arrow.DefaultRTTTolerance,
+			},
+			LargeBatches: arrow.LargeBatchConfig{
+				Streams: 1,
+			},
+			SignalWeights: arrow.SignalWeightsConfig{
+				Traces:  1,
+				Metrics: 1,
+				Logs:    1,
+			},
+
 			// Note the default payload compression is
 			PayloadCompression: arrow.DefaultPayloadCompression,
 		},
@@ -101,7 +117,7 @@ func createTracesExporter(
 	set exporter.Settings,
 	cfg component.Config,
 ) (exporter.Traces, error) {
-	e, err := newMetadataExporter(cfg, set, createArrowTracesStream)
+	e, err := newMetadataExporter(cfg, set, pipeline.SignalTraces, createArrowTracesStream)
 	if err != nil {
 		return nil, err
 	}
@@ -120,7 +136,7 @@ func createMetricsExporter(
 	set exporter.Settings,
 	cfg component.Config,
 ) (exporter.Metrics, error) {
-	e, err := newMetadataExporter(cfg, set, createArrowMetricsStream)
+	e, err := newMetadataExporter(cfg, set, pipeline.SignalMetrics, createArrowMetricsStream)
 	if err != nil {
 		return nil, err
 	}
@@ -139,7 +155,7 @@ func createLogsExporter(
 	set exporter.Settings,
 	cfg component.Config,
 ) (exporter.Logs, error) {
-	e, err := newMetadataExporter(cfg, set, createArrowLogsStream)
+	e, err := newMetadataExporter(cfg, set, pipeline.SignalLogs, createArrowLogsStream)
 	if err != nil {
 		return nil, err
 	}
@@ -43,6 +43,20 @@ func TestCreateDefaultConfig(t *testing.T) {
 		PayloadCompression: "zstd",
 		Zstd:               zstd.DefaultEncoderConfig(),
 		Prioritizer:        arrow.DefaultPrioritizer,
+		Adaptive: arrow.AdaptiveConfig{
+			MinStreams:   1,
+			MaxStreams:   4 * max(1, runtime.NumCPU()/2),
+			Interval:     10 * time.Second,
+			RTTTolerance: 2,
+		},
+		LargeBatches: arrow.LargeBatchConfig{
+			Streams: 1,
+		},
+		SignalWeights: arrow.SignalWeightsConfig{
+			Traces:  1,
+			Metrics: 1,
+			Logs:    1,
+		},
 	}, ocfg.Arrow)
 }
 
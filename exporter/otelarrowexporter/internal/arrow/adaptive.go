@@ -0,0 +1,244 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package arrow // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/otelarrowexporter/internal/arrow"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+const (
+	// DefaultAdaptiveInterval is the period between two decisions
+	// of the adaptive stream controller.
+	DefaultAdaptiveInterval = 10 * time.Second
+
+	// DefaultRTTTolerance is the ratio between the observed and the
+	// baseline round-trip time above which the destination is
+	// considered congested.
+	DefaultRTTTolerance = 2.0
+)
+
+// AdaptiveConfig configures the automatic scaling of the number of
+// streams.  When enabled, num_streams is the initial number of
+// streams.
+type AdaptiveConfig struct {
+	// Enabled turns on adaptive scaling.
+	Enabled bool `mapstructure:"enabled"`
+
+	// MinStreams is the lower bound on the number of streams.
+	MinStreams int `mapstructure:"min_streams"`
+
+	// MaxStreams is the upper bound on the number of streams.
+	MaxStreams int `mapstructure:"max_streams"`
+
+	// Interval is the period between two scaling decisions.
+	Interval time.Duration `mapstructure:"interval"`
+
+	// RTTTolerance is the ratio of the mean batch round-trip
+	// time to its long-term baseline above which the number of
+	// streams is reduced.
+	RTTTolerance float64 `mapstructure:"rtt_tolerance"`
+
+	// TargetInFlightBytes is the mean number of uncompressed
+	// bytes in flight per stream above which another stream is
+	// added.  Zero means scaling only considers the number of
+	// requests in flight.
+	TargetInFlightBytes int64 `mapstructure:"target_in_flight_bytes"`
+}
+
+var _ component.ConfigValidator = (*AdaptiveConfig)(nil)
+
+// Validate implements component.ConfigValidator
+func (cfg *AdaptiveConfig) Validate() error {
+	if !cfg.Enabled {
+		return nil
+	}
+	var errs error
+	if cfg.MinStreams < 1 {
+		errs = errors.Join(errs, fmt.Errorf("min_streams must be > 0: %d", cfg.MinStreams))
+	}
+	if cfg.MaxStreams < cfg.MinStreams {
+		errs = errors.Join(errs, fmt.Errorf("max_streams must be >= min_streams: %d < %d", cfg.MaxStreams, cfg.MinStreams))
+	}
+	if cfg.Interval <= 0 {
+		errs = errors.Join(errs, fmt.Errorf("interval must be > 0: %v", cfg.Interval))
+	}
+	if cfg.RTTTolerance <= 1 {
+		errs = errors.Join(errs, fmt.Errorf("rtt_tolerance must be > 1: %v", cfg.RTTTolerance))
+	}
+	if cfg.TargetInFlightBytes < 0 {
+		errs = errors.Join(errs, fmt.Errorf("target_in_flight_bytes must be >= 0: %d", cfg.TargetInFlightBytes))
+	}
+	return errs
+}
+
+// LargeBatchConfig reserves streams for large batches, so that they
+// do not cause head-of-line blocking for the small batches that share
+// a stream with them.
+type LargeBatchConfig struct {
+	// MinSize is the uncompressed size in bytes from which a batch
+	// is considered large.  Zero disables the large batch streams.
+	MinSize int `mapstructure:"min_size"`
+
+	// Streams is the number of streams reserved for large batches.
+	// Small batches use the remaining streams.  When there are not
+	// more active streams than this, all batches share all streams.
+	Streams int `mapstructure:"streams"`
+}
+
+var _ component.ConfigValidator = (*LargeBatchConfig)(nil)
+
+// Validate implements component.ConfigValidator
+func (cfg *LargeBatchConfig) Validate() error {
+	if cfg.MinSize < 0 {
+		return fmt.Errorf("min_size must be >= 0: %d", cfg.MinSize)
+	}
+	if cfg.MinSize > 0 && cfg.Streams < 1 {
+		return fmt.Errorf("streams must be > 0: %d", cfg.Streams)
+	}
+	return nil
+}
+
+// SignalWeightsConfig weights the number of streams of each signal,
+// so that latency-sensitive signals are given more streams than the
+// others sent to the same destination.  The number of streams, and
+// the bounds of adaptive scaling, are multiplied by the weight of the
+// signal.  Zero is the same as 1.
+type SignalWeightsConfig struct {
+	// Traces is the weight of the traces streams.
+	Traces int `mapstructure:"traces"`
+
+	// Metrics is the weight of the metrics streams.
+	Metrics int `mapstructure:"metrics"`
+
+	// Logs is the weight of the logs streams.
+	Logs int `mapstructure:"logs"`
+}
+
+var _ component.ConfigValidator = (*SignalWeightsConfig)(nil)
+
+// Validate implements component.ConfigValidator
+func (cfg *SignalWeightsConfig) Validate() error {
+	var errs error
+	if cfg.Traces < 0 {
+		errs = errors.Join(errs, fmt.Errorf("traces must be >= 0: %d", cfg.Traces))
+	}
+	if cfg.Metrics < 0 {
+		errs = errors.Join(errs, fmt.Errorf("metrics must be >= 0: %d", cfg.Metrics))
+	}
+	if cfg.Logs < 0 {
+		errs = errors.Join(errs, fmt.Errorf("logs must be >= 0: %d", cfg.Logs))
+	}
+	return errs
+}
+
+// weight returns the weight of signal.
+func (cfg SignalWeightsConfig) weight(signal pipeline.Signal) int {
+	var w int
+	switch signal {
+	case pipeline.SignalTraces:
+		w = cfg.Traces
+	case pipeline.SignalMetrics:
+		w = cfg.Metrics
+	case pipeline.SignalLogs:
+		w = cfg.Logs
+	}
+	return max(1, w)
+}
+
+// Scale returns the number of streams and the adaptive scaling
+// settings of signal, multiplied by its weight.
+func (cfg SignalWeightsConfig) Scale(signal pipeline.Signal, numStreams int, adaptive AdaptiveConfig) (int, AdaptiveConfig) {
+	w := cfg.weight(signal)
+	adaptive.MinStreams *= w
+	adaptive.MaxStreams *= w
+	return numStreams * w, adaptive
+}
+
+// scaleSample summarizes the observations made by all the streams of
+// an exporter over one interval of the stream controller.
+type scaleSample struct {
+	// batches is the number of batch statuses received.
+	batches int64
+	// rttSum is the sum of the round-trip times of those batches.
+	rttSum time.Duration
+	// backpressure counts the RESOURCE_EXHAUSTED and UNAVAILABLE
+	// statuses, which the receiver's admission controller uses
+	// to push back.
+	backpressure int64
+	// sends is the number of calls to SendAndWait.
+	sends int64
+	// requestsSum and bytesSum are the sums of the number of
+	// requests and of uncompressed bytes in flight as seen by each
+	// call to SendAndWait.
+	requestsSum int64
+	bytesSum    int64
+}
+
+// streamScaler decides the number of active streams from one sample
+// to the next.  Decisions are taken in order of precedence:
+//
+//  1. backpressure from the receiver decreases multiplicatively,
+//  2. a round-trip time above the tolerated baseline removes a stream,
+//  3. saturated streams add a stream,
+//  4. mostly idle streams remove a stream.
+type streamScaler struct {
+	cfg AdaptiveConfig
+
+	// baseRTT is the long-term baseline of the round-trip time.
+	// It follows decreases immediately and increases slowly, so
+	// that a persistent change of the network path is eventually
+	// accepted as the new normal.
+	baseRTT time.Duration
+}
+
+func newStreamScaler(cfg AdaptiveConfig) *streamScaler {
+	return &streamScaler{cfg: cfg}
+}
+
+// next returns the number of active streams for the next interval.
+func (s *streamScaler) next(active int, sample scaleSample) int {
+	return max(s.cfg.MinStreams, min(s.cfg.MaxStreams, s.decide(active, sample)))
+}
+
+func (s *streamScaler) decide(active int, sample scaleSample) int {
+	if sample.backpressure > 0 {
+		return active - max(1, active/4)
+	}
+	if sample.batches > 0 {
+		rtt := sample.rttSum / time.Duration(sample.batches)
+		congested := s.baseRTT > 0 && float64(rtt) > s.cfg.RTTTolerance*float64(s.baseRTT)
+		s.updateBaseRTT(rtt)
+		if congested {
+			return active - 1
+		}
+	}
+	if sample.sends == 0 {
+		return active - 1
+	}
+	requests := float64(sample.requestsSum) / float64(sample.sends) / float64(active)
+	bytes := float64(sample.bytesSum) / float64(sample.sends) / float64(active)
+	target := float64(s.cfg.TargetInFlightBytes)
+
+	switch {
+	case requests > 1 || (target > 0 && bytes > target):
+		// A new batch typically waits behind another one.
+		return active + 1
+	case requests < 0.5 && (target == 0 || bytes < target/4):
+		return active - 1
+	}
+	return active
+}
+
+func (s *streamScaler) updateBaseRTT(rtt time.Duration) {
+	if s.baseRTT == 0 || rtt < s.baseRTT {
+		s.baseRTT = rtt
+		return
+	}
+	s.baseRTT += (rtt - s.baseRTT) / 16
+}
@@ -0,0 +1,226 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package arrow
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+
+	arrowpb "github.com/open-telemetry/otel-arrow/api/experimental/arrow/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+func testAdaptiveConfig() AdaptiveConfig {
+	return AdaptiveConfig{
+		Enabled:             true,
+		MinStreams:          1,
+		MaxStreams:          8,
+		Interval:            DefaultAdaptiveInterval,
+		RTTTolerance:        DefaultRTTTolerance,
+		TargetInFlightBytes: 1 << 20,
+	}
+}
+
+func TestAdaptiveConfigValidate(t *testing.T) {
+	cfg := testAdaptiveConfig()
+	require.NoError(t, cfg.Validate())
+
+	cfg.MinStreams = 0
+	cfg.MaxStreams = -1
+	cfg.Interval = 0
+	cfg.RTTTolerance = 1
+	err := cfg.Validate()
+	assert.ErrorContains(t, err, "min_streams must be > 0")
+	assert.ErrorContains(t, err, "max_streams must be >= min_streams")
+	assert.ErrorContains(t, err, "interval must be > 0")
+	assert.ErrorContains(t, err, "rtt_tolerance must be > 1")
+
+	cfg.Enabled = false
+	assert.NoError(t, cfg.Validate())
+
+	lb := LargeBatchConfig{MinSize: 1 << 20}
+	assert.ErrorContains(t, lb.Validate(), "streams must be > 0")
+}
+
+func TestSignalWeights(t *testing.T) {
+	cfg := SignalWeightsConfig{Traces: 4, Logs: 1}
+	require.NoError(t, cfg.Validate())
+
+	adaptive := testAdaptiveConfig()
+	numStreams, traces := cfg.Scale(pipeline.SignalTraces, 2, adaptive)
+	assert.Equal(t, 8, numStreams)
+	assert.Equal(t, 4, traces.MinStreams)
+	assert.Equal(t, 32, traces.MaxStreams)
+
+	numStreams, logs := cfg.Scale(pipeline.SignalLogs, 2, adaptive)
+	assert.Equal(t, 2, numStreams)
+	assert.Equal(t, adaptive, logs)
+
+	// An unset weight is the same as 1.
+	numStreams, metrics := cfg.Scale(pipeline.SignalMetrics, 2, adaptive)
+	assert.Equal(t, 2, numStreams)
+	assert.Equal(t, adaptive, metrics)
+
+	cfg.Metrics = -1
+	assert.ErrorContains(t, cfg.Validate(), "metrics must be >= 0")
+}
+
+func TestStreamScaler(t *testing.T) {
+	// sample returns the sample of an interval with the given mean
+	// round-trip time and in-flight requests and bytes.
+	sample := func(rtt time.Duration, requests, bytes int64) scaleSample {
+		return scaleSample{
+			batches:     10,
+			rttSum:      10 * rtt,
+			sends:       10,
+			requestsSum: 10 * requests,
+			bytesSum:    10 * bytes,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		baseRTT time.Duration
+		active  int
+		sample  scaleSample
+		want    int
+	}{
+		{
+			name:   "saturated by requests",
+			active: 2,
+			sample: sample(time.Millisecond, 6, 0),
+			want:   3,
+		},
+		{
+			name:   "saturated by bytes",
+			active: 2,
+			sample: sample(time.Millisecond, 2, 4<<20),
+			want:   3,
+		},
+		{
+			name:   "steady",
+			active: 4,
+			sample: sample(time.Millisecond, 3, 1<<20),
+			want:   4,
+		},
+		{
+			name:   "idle",
+			active: 4,
+			sample: sample(time.Millisecond, 1, 1<<10),
+			want:   3,
+		},
+		{
+			name:   "no traffic",
+			active: 4,
+			want:   3,
+		},
+		{
+			name:    "congested",
+			baseRTT: time.Millisecond,
+			active:  4,
+			sample:  sample(3*time.Millisecond, 8, 8<<20),
+			want:    3,
+		},
+		{
+			name:    "backpressure",
+			baseRTT: time.Millisecond,
+			active:  8,
+			sample: func() scaleSample {
+				s := sample(time.Millisecond, 16, 0)
+				s.backpressure = 1
+				return s
+			}(),
+			want: 6,
+		},
+		{
+			name:   "bounded by max",
+			active: 8,
+			sample: sample(time.Millisecond, 16, 0),
+			want:   8,
+		},
+		{
+			name:   "bounded by min",
+			active: 1,
+			want:   1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newStreamScaler(testAdaptiveConfig())
+			s.baseRTT = tt.baseRTT
+			assert.Equal(t, tt.want, s.next(tt.active, tt.sample))
+		})
+	}
+}
+
+func TestStreamScalerBaseRTT(t *testing.T) {
+	s := newStreamScaler(testAdaptiveConfig())
+	s.updateBaseRTT(16 * time.Millisecond)
+	assert.Equal(t, 16*time.Millisecond, s.baseRTT)
+
+	// Decreases are followed immediately, increases slowly.
+	s.updateBaseRTT(8 * time.Millisecond)
+	assert.Equal(t, 8*time.Millisecond, s.baseRTT)
+	s.updateBaseRTT(24 * time.Millisecond)
+	assert.Equal(t, 9*time.Millisecond, s.baseRTT)
+}
+
+func TestStreamMetricsSample(t *testing.T) {
+	ctx := context.Background()
+	ctc := newCommonTestCase(t, NotNoisy)
+	m, err := newStreamMetrics(ctc.telset)
+	require.NoError(t, err)
+
+	m.beginSend(ctx, 100)
+	m.beginSend(ctx, 300)
+	m.endSend(ctx, 100)
+	m.batchStatus(ctx, 2*time.Millisecond, arrowpb.StatusCode_OK)
+	m.batchStatus(ctx, 4*time.Millisecond, arrowpb.StatusCode_OK)
+
+	assert.Equal(t, scaleSample{
+		batches:     2,
+		rttSum:      6 * time.Millisecond,
+		sends:       2,
+		requestsSum: 1 + 2,
+		bytesSum:    100 + 400,
+	}, m.takeSample())
+	assert.Equal(t, scaleSample{}, m.takeSample())
+	assert.Equal(t, int64(1), m.inFlightRequests)
+	assert.Equal(t, int64(300), m.inFlightBytes)
+}
+
+func TestLargeBatchStreams(t *testing.T) {
+	_, dc := newDoneCancel(context.Background())
+	defer dc.cancel()
+
+	lp, state := newBestOfNPrioritizer(dc, 2, 4, 6, pendingRequests, time.Minute, LargeBatchConfig{
+		MinSize: 1000,
+		Streams: 1,
+	})
+	require.Len(t, state, 6)
+
+	rnd := rand.New(rand.NewSource(1))
+	tmp := make([]streamSorter, len(state))
+	small := writeItem{uncompSize: 10}
+	large := writeItem{uncompSize: 1000}
+	for i := 0; i < 100; i++ {
+		assert.Equal(t, 0, lp.streamFor(large, rnd, tmp).index)
+		assert.Contains(t, []int{1, 2, 3}, lp.streamFor(small, rnd, tmp).index)
+	}
+
+	// Inactive streams are never chosen.
+	lp.setActive(2)
+	for i := 0; i < 100; i++ {
+		assert.Equal(t, 0, lp.streamFor(large, rnd, tmp).index)
+		assert.Equal(t, 1, lp.streamFor(small, rnd, tmp).index)
+	}
+
+	// Without enough streams, all batches share them.
+	lp.setActive(1)
+	assert.Equal(t, 0, lp.streamFor(small, rnd, tmp).index)
+}
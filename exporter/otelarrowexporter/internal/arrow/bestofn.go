@@ -8,6 +8,7 @@ import (
 	"math/rand"
 	"runtime"
 	"sort"
+	"sync/atomic"
 	"time"
 
 	"google.golang.org/grpc/codes"
@@ -30,11 +31,19 @@ type bestOfNPrioritizer struct {
 	// state tracks the work being handled by all streams.
 	state []*streamWorkState
 
+	// active is the number of streams, at the start of state,
+	// that receive new work.
+	active atomic.Int64
+
 	// numChoices is the number of streams to consder in each decision.
 	numChoices int
 
 	// loadFunc is the load function.
 	loadFunc loadFunc
+
+	// largeBatches optionally reserves the first active streams
+	// for large batches.
+	largeBatches LargeBatchConfig
 }
 
 type loadFunc func(*streamWorkState) float64
@@ -46,31 +55,35 @@ type streamSorter struct {
 
 var _ streamPrioritizer = &bestOfNPrioritizer{}
 
-func newBestOfNPrioritizer(dc doneCancel, numChoices, numStreams int, lf loadFunc, maxLifetime time.Duration) (*bestOfNPrioritizer, []*streamWorkState) {
+func newBestOfNPrioritizer(dc doneCancel, numChoices, numStreams, maxStreams int, lf loadFunc, maxLifetime time.Duration, largeBatches LargeBatchConfig) (*bestOfNPrioritizer, []*streamWorkState) {
 	var state []*streamWorkState
 
 	// Limit numChoices to the number of streams.
-	numChoices = min(numStreams, numChoices)
+	numChoices = min(maxStreams, numChoices)
 
-	for i := 0; i < numStreams; i++ {
+	for i := 0; i < maxStreams; i++ {
 		ws := &streamWorkState{
+			index:             i,
 			maxStreamLifetime: addJitter(maxLifetime),
-			waiters:           map[int64]chan<- error{},
+			waiters:           map[int64]batchWaiter{},
 			toWrite:           make(chan writeItem, 1),
+			retire:            make(chan struct{}, 1),
 		}
 
 		state = append(state, ws)
 	}
 
 	lp := &bestOfNPrioritizer{
-		doneCancel: dc,
-		input:      make(chan writeItem, runtime.NumCPU()),
-		state:      state,
-		numChoices: numChoices,
-		loadFunc:   lf,
+		doneCancel:   dc,
+		input:        make(chan writeItem, runtime.NumCPU()),
+		state:        state,
+		numChoices:   numChoices,
+		loadFunc:     lf,
+		largeBatches: largeBatches,
 	}
+	lp.active.Store(int64(numStreams))
 
-	for i := 0; i < numStreams; i++ {
+	for i := 0; i < maxStreams; i++ {
 		// TODO It's not clear if/when the the prioritizer can
 		// become a bottleneck.
 		go lp.run()
@@ -85,6 +98,10 @@ func (lp *bestOfNPrioritizer) downgrade(ctx context.Context) {
 	}
 }
 
+func (lp *bestOfNPrioritizer) setActive(n int) {
+	lp.active.Store(int64(n))
+}
+
 func (lp *bestOfNPrioritizer) sendOne(item writeItem, rnd *rand.Rand, tmp []streamSorter) {
 	stream := lp.streamFor(item, rnd, tmp)
 	writeCh := stream.toWrite
@@ -135,23 +152,42 @@ func (lp *bestOfNPrioritizer) nextWriter() streamWriter {
 	}
 }
 
-func (lp *bestOfNPrioritizer) streamFor(_ writeItem, rnd *rand.Rand, tmp []streamSorter) *streamWorkState {
-	// Place all streams into the temporary slice.
-	for idx, item := range lp.state {
+func (lp *bestOfNPrioritizer) streamFor(wri writeItem, rnd *rand.Rand, tmp []streamSorter) *streamWorkState {
+	candidates := lp.candidates(wri)
+	numChoices := min(lp.numChoices, len(candidates))
+
+	// Place all candidate streams into the temporary slice.
+	tmp = tmp[:len(candidates)]
+	for idx, item := range candidates {
 		tmp[idx].work = item
 	}
 	// Select numChoices at random by shifting the selection into the start
 	// of the temporary slice.
-	for i := 0; i < lp.numChoices; i++ {
-		pick := rnd.Intn(lp.numChoices - i)
+	for i := 0; i < numChoices; i++ {
+		pick := rnd.Intn(len(tmp) - i)
 		tmp[i], tmp[i+pick] = tmp[i+pick], tmp[i]
 	}
-	for i := 0; i < lp.numChoices; i++ {
+	for i := 0; i < numChoices; i++ {
 		// TODO: skip channels w/ a pending item (maybe)
 		tmp[i].load = lp.loadFunc(tmp[i].work)
 	}
-	sort.Slice(tmp[0:lp.numChoices], func(i, j int) bool {
+	sort.Slice(tmp[0:numChoices], func(i, j int) bool {
 		return tmp[i].load < tmp[j].load
 	})
 	return tmp[0].work
 }
+
+// candidates returns the active streams that may carry an item.  When
+// large batches are given dedicated streams, these are the first
+// active streams and small batches use the others, so that a small
+// batch never waits behind a large one.
+func (lp *bestOfNPrioritizer) candidates(wri writeItem) []*streamWorkState {
+	active := lp.state[:lp.active.Load()]
+	if lp.largeBatches.MinSize == 0 || len(active) <= lp.largeBatches.Streams {
+		return active
+	}
+	if wri.uncompSize >= lp.largeBatches.MinSize {
+		return active[:lp.largeBatches.Streams]
+	}
+	return active[lp.largeBatches.Streams:]
+}
@@ -72,6 +72,13 @@ type Exporter struct {
 	// forcing Arrow transport.
 	disableDowngrade bool
 
+	// adaptive configures scaling the number of streams between
+	// its min and max, starting at numStreams.
+	adaptive AdaptiveConfig
+
+	// largeBatches configures the streams reserved for large batches.
+	largeBatches LargeBatchConfig
+
 	// telemetry includes logger, tracer, meter.
 	telemetry component.TelemetrySettings
 
@@ -96,6 +103,13 @@ type Exporter struct {
 	// ready prioritizes streams that are ready to send
 	ready streamPrioritizer
 
+	// streams is the work state of every stream that may be used,
+	// in the prioritizer's order.
+	streams []*streamWorkState
+
+	// metrics records the stream telemetry.
+	metrics *streamMetrics
+
 	// doneCancel refers to and cancels the background context of
 	// this exporter.
 	doneCancel
@@ -152,6 +166,8 @@ func NewExporter(
 	numStreams int,
 	prioritizerName PrioritizerName,
 	disableDowngrade bool,
+	adaptive AdaptiveConfig,
+	largeBatches LargeBatchConfig,
 	telemetry component.TelemetrySettings,
 	grpcOptions []grpc.CallOption,
 	newProducer func() arrowRecord.ProducerAPI,
@@ -164,19 +180,35 @@ func NewExporter(
 		numStreams:        numStreams,
 		prioritizerName:   prioritizerName,
 		disableDowngrade:  disableDowngrade,
+		adaptive:          adaptive,
+		largeBatches:      largeBatches,
 		telemetry:         telemetry,
 		grpcOptions:       grpcOptions,
 		newProducer:       newProducer,
 		streamClient:      streamClient,
 		perRPCCredentials: perRPCCredentials,
-		returning:         make(chan *Stream, numStreams),
+		returning:         make(chan *Stream, maxStreams(numStreams, adaptive)),
 		netReporter:       netReporter,
 	}
 }
 
+// maxStreams returns the number of streams that may be used.
+func maxStreams(numStreams int, adaptive AdaptiveConfig) int {
+	if adaptive.Enabled {
+		return max(numStreams, adaptive.MaxStreams)
+	}
+	return numStreams
+}
+
 // Start creates the background context used by all streams and starts
 // a stream controller, which initializes the initial set of streams.
 func (e *Exporter) Start(ctx context.Context) error {
+	var err error
+	e.metrics, err = newStreamMetrics(e.telemetry)
+	if err != nil {
+		return err
+	}
+
 	// this is the background context
 	ctx, e.doneCancel = newDoneCancel(ctx)
 
@@ -186,12 +218,12 @@ func (e *Exporter) Start(ctx context.Context) error {
 	// this is the downgradeable context
 	downCtx, downDc := newDoneCancel(ctx)
 
-	var sws []*streamWorkState
-	e.ready, sws = newStreamPrioritizer(downDc, e.prioritizerName, e.numStreams, e.maxStreamLifetime)
+	e.ready, e.streams = newStreamPrioritizer(downDc, e.prioritizerName, e.numStreams, maxStreams(e.numStreams, e.adaptive), e.maxStreamLifetime, e.largeBatches)
 
-	for _, ws := range sws {
+	for _, ws := range e.streams[:e.numStreams] {
 		e.startArrowStream(downCtx, ws)
 	}
+	e.metrics.streams(ctx, e.numStreams)
 
 	go e.runStreamController(ctx, downCtx, downDc)
 
@@ -211,27 +243,72 @@ func (e *Exporter) startArrowStream(ctx context.Context, ws *streamWorkState) {
 // terminate one at a time and restarts them.  If streams come back with a nil
 // client (meaning that OTel-Arrow was not supported by the endpoint), it will
 // not be restarted.
+//
+// With adaptive scaling, the controller periodically changes the number of
+// active streams.  New streams are started immediately.  Streams that become
+// inactive receive no new work, are closed once the batches routed to them
+// have been answered and are not restarted, so that scaling down does not
+// interrupt batches in flight.
 func (e *Exporter) runStreamController(exportCtx, downCtx context.Context, downDc doneCancel) {
 	defer e.cancel()
 	defer e.wg.Done()
 
-	running := e.numStreams
+	// active is the number of streams, at the start of e.streams,
+	// receiving work.  running tracks the streams that have been
+	// started and did not return.  drains cancels the goroutine
+	// draining an inactive stream that returned.
+	active := e.numStreams
+	running := make([]bool, len(e.streams))
+	numRunning := active
+	for i := 0; i < active; i++ {
+		running[i] = true
+	}
+	drains := make([]context.CancelFunc, len(e.streams))
+	downgrading := false
+
+	var scaler *streamScaler
+	var tickCh <-chan time.Time
+	if e.adaptive.Enabled {
+		scaler = newStreamScaler(e.adaptive)
+		ticker := time.NewTicker(e.adaptive.Interval)
+		defer ticker.Stop()
+		tickCh = ticker.C
+	}
 
 	for {
 		select {
 		case stream := <-e.returning:
+			ws := stream.workState
+			running[ws.index] = false
+			numRunning--
+
 			if stream.client != nil || e.disableDowngrade {
-				// The stream closed or broken.  Restart it.
-				e.startArrowStream(downCtx, stream.workState)
+				if ws.index < active {
+					// The stream closed or broken.  Restart it.
+					e.startArrowStream(downCtx, ws)
+					running[ws.index] = true
+					numRunning++
+					continue
+				}
+				// The stream was scaled down.  Items routed to it
+				// before it became inactive are sent back to the
+				// prioritizer until it is activated again.
+				drainCtx, cancel := context.WithCancel(downCtx)
+				drains[ws.index] = cancel
+				e.wg.Add(1)
+				go func() {
+					defer e.wg.Done()
+					drain(ws.toWrite, drainCtx.Done())
+				}()
 				continue
 			}
 			// Otherwise, the stream never got started.  It was
 			// downgraded and senders will use the standard OTLP path.
-			running--
+			downgrading = true
 
 			// None of the streams were able to connect to
 			// an Arrow endpoint.
-			if running == 0 {
+			if numRunning == 0 {
 				e.telemetry.Logger.Info("could not establish arrow streams, downgrading to standard OTLP export")
 				downDc.cancel()
 				// this call is allowed to block indefinitely,
@@ -240,6 +317,40 @@ func (e *Exporter) runStreamController(exportCtx, downCtx context.Context, downD
 				return
 			}
 
+		case <-tickCh:
+			if downgrading {
+				continue
+			}
+			target := scaler.next(active, e.metrics.takeSample())
+			for i := target; i < active; i++ {
+				if running[i] {
+					select {
+					case e.streams[i].retire <- struct{}{}:
+					default:
+					}
+				}
+			}
+			for ; active < target; active++ {
+				if drains[active] != nil {
+					drains[active]()
+					drains[active] = nil
+				}
+				// A stream scaled down and up again before it
+				// noticed keeps running.
+				select {
+				case <-e.streams[active].retire:
+				default:
+				}
+				if !running[active] {
+					e.startArrowStream(downCtx, e.streams[active])
+					running[active] = true
+					numRunning++
+				}
+			}
+			active = target
+			e.ready.setActive(active)
+			e.metrics.streams(exportCtx, active)
+
 		case <-exportCtx.Done():
 			// We are shutting down.
 			return
@@ -266,7 +377,7 @@ func (e *Exporter) runArrowStream(ctx context.Context, dc doneCancel, state *str
 	defer dc.cancel()
 	producer := e.newProducer()
 
-	stream := newStream(producer, e.ready, e.telemetry, e.netReporter, state)
+	stream := newStream(producer, e.ready, e.telemetry, e.netReporter, e.metrics, state)
 
 	defer func() {
 		if err := producer.Close(); err != nil {
@@ -344,6 +455,9 @@ func (e *Exporter) SendAndWait(ctx context.Context, data any) (bool, error) {
 		md["grpc-timeout"] = grpcutil.EncodeTimeout(time.Until(dead))
 	}
 
+	e.metrics.beginSend(ctx, uncompSize)
+	defer e.metrics.endSend(ctx, uncompSize)
+
 	wri := writeItem{
 		records:     data,
 		md:          md,
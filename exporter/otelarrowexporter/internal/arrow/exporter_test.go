@@ -155,7 +155,7 @@ func newExporterTestCaseCommon(t zaptest.TestingT, pname PrioritizerName, noisy
 		})
 	}
 
-	exp := NewExporter(maxLifetime, numStreams, pname, disableDowngrade, ctc.telset, nil, mockArrowProducer(ctc), ctc.traceClient, ctc.perRPCCredentials, netstats.Noop{})
+	exp := NewExporter(maxLifetime, numStreams, pname, disableDowngrade, AdaptiveConfig{}, LargeBatchConfig{}, ctc.telset, nil, mockArrowProducer(ctc), ctc.traceClient, ctc.perRPCCredentials, netstats.Noop{})
 
 	return &exporterTestCase{
 		commonTestCase: ctc,
@@ -827,6 +827,100 @@ func TestArrowExporterStreamLifetimeAndShutdown(t *testing.T) {
 	}
 }
 
+// TestArrowExporterAdaptiveStreams checks that concurrent senders cause
+// the exporter to open more streams, and that no data is lost when the
+// exporter scales back down, with or without a stream lifetime.
+func TestArrowExporterAdaptiveStreams(t *testing.T) {
+	for _, maxLifetime := range []time.Duration{time.Second / 2, 0} {
+		t.Run(fmt.Sprint("lifetime=", maxLifetime), func(t *testing.T) {
+			testArrowExporterAdaptiveStreams(t, maxLifetime)
+		})
+	}
+}
+
+func testArrowExporterAdaptiveStreams(t *testing.T, maxLifetime time.Duration) {
+	ctc := newCommonTestCase(t, NotNoisy)
+	ctc.requestMetadataCall.AnyTimes().Return(nil, nil)
+	adaptive := AdaptiveConfig{
+		Enabled:      true,
+		MinStreams:   1,
+		MaxStreams:   4,
+		Interval:     100 * time.Millisecond,
+		RTTTolerance: 1000,
+	}
+	exp := NewExporter(maxLifetime, 1, DefaultPrioritizer, false, adaptive, LargeBatchConfig{}, ctc.telset, nil, mockArrowProducer(ctc), ctc.traceClient, ctc.perRPCCredentials, netstats.Noop{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var wg sync.WaitGroup
+	var open, maxOpen, actualCount atomic.Int64
+
+	ctc.traceCall.AnyTimes().DoAndReturn(func(ctx context.Context, opts ...grpc.CallOption) (
+		arrowpb.ArrowTracesService_ArrowTracesClient,
+		error,
+	) {
+		wg.Add(1)
+		channel := newHealthyTestChannel()
+		if n := open.Add(1); n > maxOpen.Load() {
+			maxOpen.Store(n)
+		}
+
+		go func() {
+			defer wg.Done()
+			defer open.Add(-1)
+
+			for data := range channel.sendChannel() {
+				// Slow responses keep several requests in flight.
+				time.Sleep(5 * time.Millisecond)
+				actualCount.Add(1)
+				channel.recv <- statusOKFor(data.BatchId)
+			}
+			close(channel.recv)
+		}()
+
+		return ctc.returnNewStream(channel)(ctx, opts...)
+	})
+
+	require.NoError(t, exp.Start(ctx))
+
+	var expectCount atomic.Int64
+	var senders sync.WaitGroup
+	start := time.Now()
+	for i := 0; i < 8; i++ {
+		senders.Add(1)
+		go func() {
+			defer senders.Done()
+			for time.Since(start) < 2*time.Second {
+				sent, err := exp.SendAndWait(ctx, testdata.GenerateTraces(2))
+				assert.NoError(t, err)
+				assert.True(t, sent)
+				expectCount.Add(1)
+			}
+		}()
+	}
+	senders.Wait()
+	require.Greater(t, maxOpen.Load(), int64(1))
+
+	// Without traffic the exporter scales down to a single stream.
+	require.Eventually(t, func() bool {
+		return open.Load() == 1
+	}, 5*time.Second, 50*time.Millisecond)
+
+	sent, err := exp.SendAndWait(ctx, testdata.GenerateTraces(2))
+	require.NoError(t, err)
+	require.True(t, sent)
+	expectCount.Add(1)
+
+	require.NoError(t, exp.Shutdown(ctx))
+	require.Equal(t, expectCount.Load(), actualCount.Load())
+
+	cancel()
+	wg.Wait()
+
+	require.Empty(t, ctc.observedLogs.All())
+}
+
 func BenchmarkLeastLoadedTwo4(b *testing.B) {
 	benchmarkPrioritizer(b, 4, LeastLoadedTwoPrioritizer)
 }
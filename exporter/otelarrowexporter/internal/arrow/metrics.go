@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package arrow // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/otelarrowexporter/internal/arrow"
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	arrowpb "github.com/open-telemetry/otel-arrow/api/experimental/arrow/v1"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/otelarrowexporter/internal/metadata"
+)
+
+// streamMetrics is shared by all the streams of an exporter.  It
+// records their telemetry and accumulates the sample used by the
+// adaptive stream controller.
+type streamMetrics struct {
+	telemetryBuilder *metadata.TelemetryBuilder
+
+	// lock protects the fields below.
+	lock sync.Mutex
+
+	// inFlightRequests and inFlightBytes count the calls to
+	// SendAndWait that have not returned.
+	inFlightRequests int64
+	inFlightBytes    int64
+
+	// sample accumulates observations until the next call to
+	// takeSample.
+	sample scaleSample
+}
+
+func newStreamMetrics(set component.TelemetrySettings) (*streamMetrics, error) {
+	tb, err := metadata.NewTelemetryBuilder(set)
+	if err != nil {
+		return nil, err
+	}
+	return &streamMetrics{telemetryBuilder: tb}, nil
+}
+
+// beginSend is called when a batch of uncompSize bytes enters SendAndWait.
+func (m *streamMetrics) beginSend(ctx context.Context, uncompSize int) {
+	m.telemetryBuilder.OtelArrowExporterInFlightBytes.Add(ctx, int64(uncompSize))
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.inFlightRequests++
+	m.inFlightBytes += int64(uncompSize)
+	m.sample.sends++
+	m.sample.requestsSum += m.inFlightRequests
+	m.sample.bytesSum += m.inFlightBytes
+}
+
+// endSend is called when a batch started with beginSend returns.
+func (m *streamMetrics) endSend(ctx context.Context, uncompSize int) {
+	m.telemetryBuilder.OtelArrowExporterInFlightBytes.Add(ctx, -int64(uncompSize))
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.inFlightRequests--
+	m.inFlightBytes -= int64(uncompSize)
+}
+
+// batchStatus is called when a stream receives the status of a batch
+// it sent rtt ago.
+func (m *streamMetrics) batchStatus(ctx context.Context, rtt time.Duration, code arrowpb.StatusCode) {
+	m.telemetryBuilder.OtelArrowExporterBatchRtt.Record(ctx, rtt.Milliseconds())
+
+	backpressure := code == arrowpb.StatusCode_RESOURCE_EXHAUSTED || code == arrowpb.StatusCode_UNAVAILABLE
+	if backpressure {
+		m.telemetryBuilder.OtelArrowExporterBackpressure.Add(ctx, 1)
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.sample.batches++
+	m.sample.rttSum += rtt
+	if backpressure {
+		m.sample.backpressure++
+	}
+}
+
+// schemaReset is called when a stream changes the schema of a payload
+// type, which resets the dictionaries built for it.
+func (m *streamMetrics) schemaReset(ctx context.Context, stream int, payloadType arrowpb.ArrowPayloadType) {
+	m.telemetryBuilder.OtelArrowExporterSchemaResets.Add(ctx, 1, metric.WithAttributes(
+		attribute.Int("stream", stream),
+		attribute.String("payload_type", payloadType.String()),
+	))
+}
+
+// streams records the number of streams batches are distributed to.
+func (m *streamMetrics) streams(ctx context.Context, n int) {
+	m.telemetryBuilder.OtelArrowExporterStreams.Record(ctx, int64(n))
+}
+
+// takeSample returns the observations made since the previous call.
+func (m *streamMetrics) takeSample() scaleSample {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	s := m.sample
+	m.sample = scaleSample{}
+	return s
+}
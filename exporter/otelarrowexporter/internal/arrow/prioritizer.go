@@ -42,6 +42,10 @@ type streamPrioritizer interface {
 	// and may block indefinitely.  this allows the prioritizer to
 	// drain its channel(s) until the exporter shuts down.
 	downgrade(context.Context)
+
+	// setActive restricts the choice of streams to the first n
+	// streams.  Streams that are not active receive no new work.
+	setActive(n int)
 }
 
 // streamWriter is the caller's interface to a stream.
@@ -51,7 +55,9 @@ type streamWriter interface {
 	sendAndWait(context.Context, <-chan error, writeItem) error
 }
 
-func newStreamPrioritizer(dc doneCancel, name PrioritizerName, numStreams int, maxLifetime time.Duration) (streamPrioritizer, []*streamWorkState) {
+// newStreamPrioritizer returns a prioritizer for maxStreams streams, of
+// which the first numStreams are initially active.
+func newStreamPrioritizer(dc doneCancel, name PrioritizerName, numStreams, maxStreams int, maxLifetime time.Duration, largeBatches LargeBatchConfig) (streamPrioritizer, []*streamWorkState) {
 	if name == unsetPrioritizer {
 		name = DefaultPrioritizer
 	}
@@ -59,10 +65,10 @@ func newStreamPrioritizer(dc doneCancel, name PrioritizerName, numStreams int, m
 		// error was checked and reported in Validate
 		n, err := strconv.Atoi(string(name[len(llPrefix):]))
 		if err == nil {
-			return newBestOfNPrioritizer(dc, n, numStreams, pendingRequests, maxLifetime)
+			return newBestOfNPrioritizer(dc, n, numStreams, maxStreams, pendingRequests, maxLifetime, largeBatches)
 		}
 	}
-	return newBestOfNPrioritizer(dc, numStreams, numStreams, pendingRequests, maxLifetime)
+	return newBestOfNPrioritizer(dc, maxStreams, numStreams, maxStreams, pendingRequests, maxLifetime, largeBatches)
 }
 
 // pendingRequests is the load function used by leastloadedN.
@@ -56,6 +56,13 @@ type Stream struct {
 	// netReporter provides network-level metrics.
 	netReporter netstats.Interface
 
+	// metrics is shared by the streams of the exporter.
+	metrics *streamMetrics
+
+	// schemas is the last schema ID sent for each payload type,
+	// used to count schema and dictionary resets.
+	schemas map[arrowpb.ArrowPayloadType]string
+
 	// streamWorkState is the interface to prioritizer/balancer, contains
 	// outstanding request (by batch ID) and the write channel used by
 	// the stream.  All of this state will be inherited by the successor
@@ -66,6 +73,9 @@ type Stream struct {
 // streamWorkState contains the state assigned to an Arrow stream.  When
 // a stream shuts down, the work state is handed to the replacement stream.
 type streamWorkState struct {
+	// index is the position of this stream in the prioritizer.
+	index int
+
 	// toWrite is used to pass pending data between a caller, the
 	// prioritizer and a stream.
 	toWrite chan writeItem
@@ -75,11 +85,22 @@ type streamWorkState struct {
 	// per-stream basis.
 	maxStreamLifetime time.Duration
 
+	// retire is signaled by the stream controller when the stream
+	// is scaled down, to close it once it has drained.
+	retire chan struct{}
+
 	// lock protects waiters
 	lock sync.Mutex
 
 	// waiters is the response channel for each active batch.
-	waiters map[int64]chan<- error
+	waiters map[int64]batchWaiter
+}
+
+// batchWaiter is the response channel of an active batch and the time
+// the batch was sent.
+type batchWaiter struct {
+	errCh chan<- error
+	sent  time.Time
 }
 
 // writeItem is passed from the sender (a pipeline consumer) to the
@@ -106,6 +127,7 @@ func newStream(
 	prioritizer streamPrioritizer,
 	telemetry component.TelemetrySettings,
 	netReporter netstats.Interface,
+	metrics *streamMetrics,
 	workState *streamWorkState,
 ) *Stream {
 	tracer := telemetry.TracerProvider.Tracer("otel-arrow-exporter")
@@ -115,6 +137,8 @@ func newStream(
 		telemetry:   telemetry,
 		tracer:      tracer,
 		netReporter: netReporter,
+		metrics:     metrics,
+		schemas:     map[arrowpb.ArrowPayloadType]string{},
 		workState:   workState,
 	}
 }
@@ -125,7 +149,10 @@ func (s *Stream) setBatchChannel(batchID int64, errCh chan<- error) {
 	s.workState.lock.Lock()
 	defer s.workState.lock.Unlock()
 
-	s.workState.waiters[batchID] = errCh
+	s.workState.waiters[batchID] = batchWaiter{
+		errCh: errCh,
+		sent:  time.Now(),
+	}
 }
 
 // logStreamError decides how to log an error.  `where` indicates the
@@ -232,12 +259,12 @@ func (s *Stream) run(ctx context.Context, dc doneCancel, streamClient StreamClie
 
 	// The reader and writer have both finished; respond to any
 	// outstanding waiters.
-	for _, ch := range s.workState.waiters {
+	for _, w := range s.workState.waiters {
 		// Note: the top-level OTLP exporter will retry.
-		ch <- ErrStreamRestarting
+		w.errCh <- ErrStreamRestarting
 	}
 
-	s.workState.waiters = map[int64]chan<- error{}
+	s.workState.waiters = map[int64]batchWaiter{}
 }
 
 // write repeatedly places this stream into the next-available queue, then
@@ -265,6 +292,21 @@ func (s *Stream) write(ctx context.Context) (retErr error) {
 		select {
 		case <-timerCh:
 			return nil
+		case <-s.workState.retire:
+			// The stream was scaled down and receives no new
+			// work.  Send what was already routed to it, then
+			// close the send side: the reader returns once the
+			// batches in flight are answered.
+			for {
+				select {
+				case wri = <-s.workState.toWrite:
+				default:
+					return nil
+				}
+				if err := s.encodeAndSend(wri, &hdrsBuf, hdrsEnc); err != nil {
+					return err
+				}
+			}
 		case wri = <-s.workState.toWrite:
 		case <-ctx.Done():
 			return status.Errorf(codes.Canceled, "stream input: %v", ctx.Err())
@@ -316,6 +358,7 @@ func (s *Stream) encodeAndSend(wri writeItem, hdrsBuf *bytes.Buffer, hdrsEnc *hp
 		wri.errCh <- err
 		return err
 	}
+	s.countSchemaResets(ctx, batch)
 
 	// Optionally include outgoing metadata, if present.
 	if len(wri.md) != 0 {
@@ -358,11 +401,23 @@ func (s *Stream) encodeAndSend(wri writeItem, hdrsBuf *bytes.Buffer, hdrsEnc *hp
 	return nil
 }
 
+// countSchemaResets counts the payload types for which the producer
+// changed schema since the previous batch of this stream.  A new
+// schema also resets the dictionaries built for the payload type.
+func (s *Stream) countSchemaResets(ctx context.Context, batch *arrowpb.BatchArrowRecords) {
+	for _, payload := range batch.ArrowPayloads {
+		if prev, ok := s.schemas[payload.Type]; ok && prev != payload.SchemaId {
+			s.metrics.schemaReset(ctx, s.workState.index, payload.Type)
+		}
+		s.schemas[payload.Type] = payload.SchemaId
+	}
+}
+
 // read repeatedly reads a batch status and releases the consumers waiting for
 // a response.
-func (s *Stream) read(_ context.Context) error {
-	// Note we do not use the context, the stream context might
-	// cancel a call to Recv() but the call to processBatchStatus
+func (s *Stream) read(ctx context.Context) error {
+	// Note we do not use the context for Recv(), the stream context
+	// might cancel a call to Recv() but the call to processBatchStatus
 	// is non-blocking.
 	for {
 		// Note: if the client has called CloseSend() and is waiting for a response from the server.
@@ -374,7 +429,7 @@ func (s *Stream) read(_ context.Context) error {
 			return err
 		}
 
-		if err = s.processBatchStatus(resp); err != nil {
+		if err = s.processBatchStatus(ctx, resp); err != nil {
 			return err
 		}
 	}
@@ -382,30 +437,33 @@ func (s *Stream) read(_ context.Context) error {
 
 // getSenderChannel takes the stream lock and removes the corresonding
 // sender channel.
-func (sws *streamWorkState) getSenderChannel(bstat *arrowpb.BatchStatus) (chan<- error, error) {
+func (sws *streamWorkState) getSenderChannel(bstat *arrowpb.BatchStatus) (batchWaiter, error) {
 	sws.lock.Lock()
 	defer sws.lock.Unlock()
 
-	ch, ok := sws.waiters[bstat.BatchId]
+	w, ok := sws.waiters[bstat.BatchId]
 	if !ok {
 		// Will break the stream.
-		return nil, status.Errorf(codes.Internal, "unrecognized batch ID: %d", bstat.BatchId)
+		return batchWaiter{}, status.Errorf(codes.Internal, "unrecognized batch ID: %d", bstat.BatchId)
 	}
 
 	delete(sws.waiters, bstat.BatchId)
-	return ch, nil
+	return w, nil
 }
 
 // processBatchStatus processes a single response from the server and unblocks the
 // associated sender.
-func (s *Stream) processBatchStatus(ss *arrowpb.BatchStatus) error {
-	ch, ret := s.workState.getSenderChannel(ss)
+func (s *Stream) processBatchStatus(ctx context.Context, ss *arrowpb.BatchStatus) error {
+	w, ret := s.workState.getSenderChannel(ss)
 
-	if ch == nil {
+	if w.errCh == nil {
 		// In case getSenderChannels encounters a problem, the
 		// channel is nil.
 		return ret
 	}
+	ch := w.errCh
+
+	s.metrics.batchStatus(ctx, time.Since(w.sent), ss.StatusCode)
 
 	if ss.StatusCode == arrowpb.StatusCode_OK {
 		ch <- nil
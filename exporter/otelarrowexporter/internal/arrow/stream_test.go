@@ -45,7 +45,7 @@ func newStreamTestCase(t *testing.T, pname PrioritizerName) *streamTestCase {
 	producer := arrowRecordMock.NewMockProducerAPI(ctrl)
 
 	bg, dc := newDoneCancel(context.Background())
-	prio, state := newStreamPrioritizer(dc, pname, 1, 10*time.Second)
+	prio, state := newStreamPrioritizer(dc, pname, 1, 1, 10*time.Second, LargeBatchConfig{})
 
 	ctc := newCommonTestCase(t, NotNoisy)
 	cts := ctc.newMockStream(bg)
@@ -53,7 +53,9 @@ func newStreamTestCase(t *testing.T, pname PrioritizerName) *streamTestCase {
 	// metadata functionality is tested in exporter_test.go
 	ctc.requestMetadataCall.AnyTimes().Return(nil, nil)
 
-	stream := newStream(producer, prio, ctc.telset, netstats.Noop{}, state[0])
+	metrics, err := newStreamMetrics(ctc.telset)
+	require.NoError(t, err)
+	stream := newStream(producer, prio, ctc.telset, netstats.Noop{}, metrics, state[0])
 
 	fromTracesCall := producer.EXPECT().BatchArrowRecordsFromTraces(gomock.Any()).Times(0)
 	fromMetricsCall := producer.EXPECT().BatchArrowRecordsFromMetrics(gomock.Any()).Times(0)
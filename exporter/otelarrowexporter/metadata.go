@@ -20,6 +20,7 @@ import (
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pipeline"
 	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/multierr"
 	"google.golang.org/grpc/metadata"
@@ -36,6 +37,7 @@ var (
 type metadataExporter struct {
 	config   *Config
 	settings exporter.Settings
+	signal   pipeline.Signal
 	scf      streamClientFactory
 	host     component.Host
 
@@ -53,7 +55,7 @@ type metadataExporter struct {
 
 var _ exp = (*metadataExporter)(nil)
 
-func newMetadataExporter(cfg component.Config, set exporter.Settings, streamClientFactory streamClientFactory) (exp, error) {
+func newMetadataExporter(cfg component.Config, set exporter.Settings, signal pipeline.Signal, streamClientFactory streamClientFactory) (exp, error) {
 	oCfg := cfg.(*Config)
 	netReporter, err := netstats.NewExporterNetworkReporter(set)
 	if err != nil {
@@ -75,11 +77,12 @@ func newMetadataExporter(cfg component.Config, set exporter.Settings, streamClie
 	}
 	sort.Strings(mks)
 	if len(mks) == 0 {
-		return newExporter(cfg, set, streamClientFactory, userAgent, netReporter)
+		return newExporter(cfg, set, signal, streamClientFactory, userAgent, netReporter)
 	}
 	return &metadataExporter{
 		config:       oCfg,
 		settings:     set,
+		signal:       signal,
 		scf:          streamClientFactory,
 		metadataKeys: mks,
 		userAgent:    userAgent,
@@ -155,7 +158,7 @@ func (e *metadataExporter) getOrCreateExporter(ctx context.Context, s attribute.
 		return v.(exp), nil
 	}
 
-	newExp, err := newExporter(e.config, e.settings, e.scf, e.userAgent, e.netReporter)
+	newExp, err := newExporter(e.config, e.settings, e.signal, e.scf, e.userAgent, e.netReporter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create exporter: %w", err)
 	}
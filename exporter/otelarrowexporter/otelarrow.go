@@ -21,6 +21,7 @@ import (
 	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+	"go.opentelemetry.io/collector/pipeline"
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
@@ -61,6 +62,10 @@ type baseExporter struct {
 	settings       exporter.Settings
 	netReporter    *netstats.NetworkReporter
 
+	// signal is the signal exported, which determines its
+	// weight in the number of streams.
+	signal pipeline.Signal
+
 	// Default user-agent header.
 	userAgent string
 
@@ -76,7 +81,7 @@ type streamClientFactory func(conn *grpc.ClientConn) arrow.StreamClientFunc
 
 // Crete new exporter and start it. The exporter will begin connecting but
 // this function may return before the connection is established.
-func newExporter(cfg component.Config, set exporter.Settings, streamClientFactory streamClientFactory, userAgent string, netReporter *netstats.NetworkReporter) (exp, error) {
+func newExporter(cfg component.Config, set exporter.Settings, signal pipeline.Signal, streamClientFactory streamClientFactory, userAgent string, netReporter *netstats.NetworkReporter) (exp, error) {
 	oCfg := cfg.(*Config)
 
 	if oCfg.Endpoint == "" {
@@ -86,6 +91,7 @@ func newExporter(cfg component.Config, set exporter.Settings, streamClientFactor
 	return &baseExporter{
 		config:              oCfg,
 		settings:            set,
+		signal:              signal,
 		userAgent:           userAgent,
 		netReporter:         netReporter,
 		streamClientFactory: streamClientFactory,
@@ -162,7 +168,9 @@ func (e *baseExporter) start(ctx context.Context, host component.Host) (err erro
 			arrowCallOpts = append(arrowCallOpts, e.config.Arrow.Zstd.CallOption())
 		}
 
-		e.arrow = arrow.NewExporter(e.config.Arrow.MaxStreamLifetime, e.config.Arrow.NumStreams, e.config.Arrow.Prioritizer, e.config.Arrow.DisableDowngrade, e.settings.TelemetrySettings, arrowCallOpts, func() arrowRecord.ProducerAPI {
+		numStreams, adaptive := e.config.Arrow.SignalWeights.Scale(e.signal, e.config.Arrow.NumStreams, e.config.Arrow.Adaptive)
+
+		e.arrow = arrow.NewExporter(e.config.Arrow.MaxStreamLifetime, numStreams, e.config.Arrow.Prioritizer, e.config.Arrow.DisableDowngrade, adaptive, e.config.Arrow.LargeBatches, e.settings.TelemetrySettings, arrowCallOpts, func() arrowRecord.ProducerAPI {
 			return arrowRecord.NewProducerWithOptions(arrowOpts...)
 		}, e.streamClientFactory(e.clientConn), perRPCCreds, e.netReporter)
 
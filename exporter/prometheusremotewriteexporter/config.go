@@ -52,8 +52,21 @@ type Config struct {
 
 	// SendMetadata controls whether prometheus metadata will be generated and sent
 	SendMetadata bool `mapstructure:"send_metadata"`
+
+	// Protocol is the version of the remote write protocol used to send the metrics.
+	Protocol Protocol `mapstructure:"protocol"`
 }
 
+// Protocol is a version of the Prometheus remote write protocol.
+type Protocol string
+
+const (
+	// ProtocolV1 sends prometheus.WriteRequest messages, as specified by remote write 1.0.
+	ProtocolV1 Protocol = "v1"
+	// ProtocolV2 sends io.prometheus.write.v2.Request messages, as specified by remote write 2.0.
+	ProtocolV2 Protocol = "v2"
+)
+
 type CreatedMetric struct {
 	// Enabled if true the _created metrics could be exported
 	Enabled bool `mapstructure:"enabled"`
@@ -107,6 +120,9 @@ func (cfg *Config) Validate() error {
 			Enabled: false,
 		}
 	}
+	if cfg.Protocol != ProtocolV1 && cfg.Protocol != ProtocolV2 {
+		return fmt.Errorf("unsupported protocol %q, must be %q or %q", cfg.Protocol, ProtocolV1, ProtocolV2)
+	}
 	if cfg.MaxBatchSizeBytes < 0 {
 		return fmt.Errorf("max_batch_byte_size must be greater than 0")
 	}
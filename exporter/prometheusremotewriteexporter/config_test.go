@@ -79,6 +79,7 @@ func TestLoadConfig(t *testing.T) {
 					Enabled: true,
 				},
 				CreatedMetric: &CreatedMetric{Enabled: true},
+				Protocol:      ProtocolV1,
 			},
 		},
 		{
@@ -89,6 +90,10 @@ func TestLoadConfig(t *testing.T) {
 			id:           component.NewIDWithName(metadata.Type, "negative_num_consumers"),
 			errorMessage: "remote write consumer number can't be negative",
 		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_protocol"),
+			errorMessage: `unsupported protocol "v3", must be "v1" or "v2"`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -135,3 +140,17 @@ func TestDisabledTargetInfo(t *testing.T) {
 
 	assert.False(t, cfg.(*Config).TargetInfo.Enabled)
 }
+
+func TestProtocolV2(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+
+	sub, err := cm.Sub(component.NewIDWithName(metadata.Type, "protocol_v2").String())
+	require.NoError(t, err)
+	require.NoError(t, sub.Unmarshal(cfg))
+
+	assert.NoError(t, component.ValidateConfig(cfg))
+	assert.Equal(t, ProtocolV2, cfg.(*Config).Protocol)
+}
@@ -12,6 +12,7 @@ import (
 	"math"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"sync"
 
@@ -19,6 +20,7 @@ import (
 	"github.com/gogo/protobuf/proto"
 	"github.com/golang/snappy"
 	"github.com/prometheus/prometheus/prompb"
+	writev2 "github.com/prometheus/prometheus/prompb/io/prometheus/write/v2"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/config/configretry"
@@ -38,6 +40,7 @@ import (
 type prwTelemetry interface {
 	recordTranslationFailure(ctx context.Context)
 	recordTranslatedTimeSeries(ctx context.Context, numTS int)
+	recordUnwrittenItems(ctx context.Context, itemType string, numItems int)
 }
 
 type prwTelemetryOtel struct {
@@ -53,6 +56,11 @@ func (p *prwTelemetryOtel) recordTranslatedTimeSeries(ctx context.Context, numTS
 	p.telemetryBuilder.ExporterPrometheusremotewriteTranslatedTimeSeries.Add(ctx, int64(numTS), metric.WithAttributes(p.otelAttrs...))
 }
 
+func (p *prwTelemetryOtel) recordUnwrittenItems(ctx context.Context, itemType string, numItems int) {
+	attrs := append([]attribute.KeyValue{attribute.String("type", itemType)}, p.otelAttrs...)
+	p.telemetryBuilder.ExporterPrometheusremotewriteUnwrittenItems.Add(ctx, int64(numItems), metric.WithAttributes(attrs...))
+}
+
 // prwExporter converts OTLP metrics to Prometheus remote write TimeSeries and sends them to a remote endpoint.
 type prwExporter struct {
 	endpointURL          *url.URL
@@ -66,7 +74,9 @@ type prwExporter struct {
 	settings             component.TelemetrySettings
 	retrySettings        configretry.BackOffConfig
 	retryOnHTTP429       bool
-	wal                  *prweWAL
+	protocol             Protocol
+	wal                  *prweWAL[prompb.WriteRequest, *prompb.WriteRequest]
+	walV2                *prweWAL[writev2.Request, *writev2.Request]
 	exporterSettings     prometheusremotewrite.Settings
 	telemetry            prwTelemetry
 	batchTimeSeriesState batchTimeSeriesState
@@ -116,6 +126,7 @@ func newPRWExporter(cfg *Config, set exporter.Settings) (*prwExporter, error) {
 		settings:          set.TelemetrySettings,
 		retrySettings:     cfg.BackOffConfig,
 		retryOnHTTP429:    retryOn429FeatureGate.IsEnabled(),
+		protocol:          cfg.Protocol,
 		exporterSettings: prometheusremotewrite.Settings{
 			Namespace:           cfg.Namespace,
 			ExternalLabels:      sanitizedLabels,
@@ -128,7 +139,11 @@ func newPRWExporter(cfg *Config, set exporter.Settings) (*prwExporter, error) {
 		batchTimeSeriesState: newBatchTimeSericesState(),
 	}
 
-	prwe.wal = newWAL(cfg.WAL, prwe.export)
+	if prwe.protocol == ProtocolV2 {
+		prwe.walV2 = newWAL(cfg.WAL, prwe.exportV2)
+	} else {
+		prwe.wal = newWAL(cfg.WAL, prwe.export)
+	}
 	return prwe, nil
 }
 
@@ -142,6 +157,9 @@ func (prwe *prwExporter) Start(ctx context.Context, host component.Host) (err er
 }
 
 func (prwe *prwExporter) shutdownWALIfEnabled() error {
+	if prwe.walV2 != nil {
+		return prwe.walV2.stop()
+	}
 	if !prwe.walEnabled() {
 		return nil
 	}
@@ -172,6 +190,9 @@ func (prwe *prwExporter) PushMetrics(ctx context.Context, md pmetric.Metrics) er
 	case <-prwe.closeChan:
 		return errors.New("shutdown has been called")
 	default:
+		if prwe.protocol == ProtocolV2 {
+			return prwe.pushMetricsV2(ctx, md)
+		}
 
 		tsMap, err := prometheusremotewrite.FromMetrics(md, prwe.exporterSettings)
 		if err != nil {
@@ -191,6 +212,20 @@ func (prwe *prwExporter) PushMetrics(ctx context.Context, md pmetric.Metrics) er
 	}
 }
 
+// pushMetricsV2 converts metrics to a Prometheus remote write 2.0 request and sends it to the remote endpoint.
+func (prwe *prwExporter) pushMetricsV2(ctx context.Context, md pmetric.Metrics) error {
+	req, err := prometheusremotewrite.FromMetricsV2(md, prwe.exporterSettings)
+	if err != nil {
+		prwe.telemetry.recordTranslationFailure(ctx)
+		prwe.settings.Logger.Debug("failed to translate metrics, exporting remaining metrics", zap.Error(err), zap.Int("translated", len(req.Timeseries)))
+	}
+
+	prwe.telemetry.recordTranslatedTimeSeries(ctx, len(req.Timeseries))
+
+	// Call export even if a conversion error, since there may be points that were successfully converted.
+	return prwe.handleExportV2(ctx, req)
+}
+
 func validateAndSanitizeExternalLabels(cfg *Config) (map[string]string, error) {
 	sanitizedLabels := make(map[string]string)
 	for key, value := range cfg.ExternalLabels {
@@ -214,7 +249,7 @@ func (prwe *prwExporter) handleExport(ctx context.Context, tsMap map[string]*pro
 	if err != nil {
 		return err
 	}
-	if !prwe.walEnabled() {
+	if prwe.wal == nil {
 		// Perform a direct export otherwise.
 		return prwe.export(ctx, requests)
 	}
@@ -227,9 +262,35 @@ func (prwe *prwExporter) handleExport(ctx context.Context, tsMap map[string]*pro
 	return nil
 }
 
+func (prwe *prwExporter) handleExportV2(ctx context.Context, req *writev2.Request) error {
+	// There are no metrics to export, so return.
+	if len(req.Timeseries) == 0 {
+		return nil
+	}
+
+	requests := batchRequestV2(req, prwe.maxBatchSizeBytes)
+	if prwe.walV2 == nil {
+		return prwe.exportV2(ctx, requests)
+	}
+	if err := prwe.walV2.persistToWAL(requests); err != nil {
+		return consumererror.NewPermanent(err)
+	}
+	return nil
+}
+
 // export sends a Snappy-compressed WriteRequest containing TimeSeries to a remote write endpoint in order
 func (prwe *prwExporter) export(ctx context.Context, requests []*prompb.WriteRequest) error {
-	input := make(chan *prompb.WriteRequest, len(requests))
+	return exportConcurrently(ctx, prwe.concurrency, requests, prwe.execute)
+}
+
+// exportV2 sends Snappy-compressed remote write 2.0 requests to a remote write endpoint in order
+func (prwe *prwExporter) exportV2(ctx context.Context, requests []*writev2.Request) error {
+	return exportConcurrently(ctx, prwe.concurrency, requests, prwe.executeV2)
+}
+
+// exportConcurrently executes the requests with at most concurrency workers.
+func exportConcurrently[T any](ctx context.Context, concurrency int, requests []T, execute func(context.Context, T) error) error {
+	input := make(chan T, len(requests))
 	for _, request := range requests {
 		input <- request
 	}
@@ -237,7 +298,7 @@ func (prwe *prwExporter) export(ctx context.Context, requests []*prompb.WriteReq
 
 	var wg sync.WaitGroup
 
-	concurrencyLimit := int(math.Min(float64(prwe.concurrency), float64(len(requests))))
+	concurrencyLimit := int(math.Min(float64(concurrency), float64(len(requests))))
 	wg.Add(concurrencyLimit) // used to wait for workers to be finished
 
 	var mu sync.Mutex
@@ -256,7 +317,7 @@ func (prwe *prwExporter) export(ctx context.Context, requests []*prompb.WriteReq
 					if !ok {
 						return
 					}
-					if errExecute := prwe.execute(ctx, request); errExecute != nil {
+					if errExecute := execute(ctx, request); errExecute != nil {
 						mu.Lock()
 						errs = multierr.Append(errs, consumererror.NewPermanent(errExecute))
 						mu.Unlock()
@@ -276,6 +337,23 @@ func (prwe *prwExporter) execute(ctx context.Context, writeReq *prompb.WriteRequ
 	if errMarshal != nil {
 		return consumererror.NewPermanent(errMarshal)
 	}
+	return prwe.send(ctx, data, remoteWriteContentTypeV1, remoteWriteVersionV1, nil)
+}
+
+func (prwe *prwExporter) executeV2(ctx context.Context, req *writev2.Request) error {
+	data, errMarshal := proto.Marshal(req)
+	if errMarshal != nil {
+		return consumererror.NewPermanent(errMarshal)
+	}
+	sent := countItemsV2(req)
+	return prwe.send(ctx, data, remoteWriteContentTypeV2, remoteWriteVersionV2, func(resp *http.Response) {
+		prwe.recordPartialWrite(ctx, resp, sent)
+	})
+}
+
+// send posts the Snappy-compressed data to the remote write endpoint, retrying if configured.
+// onResponse, if not nil, is called with the response that won't be retried.
+func (prwe *prwExporter) send(ctx context.Context, data []byte, contentType string, version string, onResponse func(*http.Response)) error {
 	// If we don't pass a buffer large enough, Snappy Encode function will not use it and instead will allocate a new buffer.
 	// Therefore we always let Snappy decide the size of the buffer.
 	compressedData := snappy.Encode(nil, data)
@@ -300,8 +378,8 @@ func (prwe *prwExporter) execute(ctx context.Context, writeReq *prompb.WriteRequ
 		// Add necessary headers specified by:
 		// https://cortexmetrics.io/docs/apis/#remote-api
 		req.Header.Add("Content-Encoding", "snappy")
-		req.Header.Set("Content-Type", "application/x-protobuf")
-		req.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+		req.Header.Set("Content-Type", contentType)
+		req.Header.Set("X-Prometheus-Remote-Write-Version", version)
 		req.Header.Set("User-Agent", prwe.userAgentHeader)
 
 		resp, err := prwe.client.Do(req)
@@ -315,6 +393,9 @@ func (prwe *prwExporter) execute(ctx context.Context, writeReq *prompb.WriteRequ
 		// Reference for different behavior according to status code:
 		// https://github.com/prometheus/prometheus/pull/2552/files#diff-ae8db9d16d8057358e49d694522e7186
 		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			if onResponse != nil {
+				onResponse(resp)
+			}
 			return nil
 		}
 
@@ -330,6 +411,9 @@ func (prwe *prwExporter) execute(ctx context.Context, writeReq *prompb.WriteRequ
 			return rerr
 		}
 
+		if onResponse != nil {
+			onResponse(resp)
+		}
 		return backoff.Permanent(consumererror.NewPermanent(rerr))
 	}
 
@@ -356,7 +440,7 @@ func (prwe *prwExporter) execute(ctx context.Context, writeReq *prompb.WriteRequ
 	return err
 }
 
-func (prwe *prwExporter) walEnabled() bool { return prwe.wal != nil }
+func (prwe *prwExporter) walEnabled() bool { return prwe.wal != nil || prwe.walV2 != nil }
 
 func (prwe *prwExporter) turnOnWALIfEnabled(ctx context.Context) error {
 	if !prwe.walEnabled() {
@@ -367,5 +451,66 @@ func (prwe *prwExporter) turnOnWALIfEnabled(ctx context.Context) error {
 		<-prwe.closeChan
 		cancel()
 	}()
+	if prwe.walV2 != nil {
+		return prwe.walV2.run(cancelCtx)
+	}
 	return prwe.wal.run(cancelCtx)
 }
+
+const (
+	remoteWriteContentTypeV1 = "application/x-protobuf"
+	remoteWriteVersionV1     = "0.1.0"
+	remoteWriteContentTypeV2 = "application/x-protobuf;proto=io.prometheus.write.v2.Request"
+	remoteWriteVersionV2     = "2.0.0"
+
+	// Headers of the responses of remote write 2.0 endpoints, with the number of items written.
+	writtenSamplesHeader    = "X-Prometheus-Remote-Write-Samples-Written"
+	writtenHistogramsHeader = "X-Prometheus-Remote-Write-Histograms-Written"
+	writtenExemplarsHeader  = "X-Prometheus-Remote-Write-Exemplars-Written"
+)
+
+// writeStats is the number of items of a remote write 2.0 request.
+type writeStats struct {
+	samples    int
+	histograms int
+	exemplars  int
+}
+
+func countItemsV2(req *writev2.Request) writeStats {
+	var stats writeStats
+	for _, ts := range req.Timeseries {
+		stats.samples += len(ts.Samples)
+		stats.histograms += len(ts.Histograms)
+		stats.exemplars += len(ts.Exemplars)
+	}
+	return stats
+}
+
+// recordPartialWrite compares the number of items the endpoint reported as written with the number of
+// items sent, and records the difference. Endpoints not reporting the number of items written are ignored.
+func (prwe *prwExporter) recordPartialWrite(ctx context.Context, resp *http.Response, sent writeStats) {
+	for _, item := range []struct {
+		itemType string
+		header   string
+		sent     int
+	}{
+		{itemType: "samples", header: writtenSamplesHeader, sent: sent.samples},
+		{itemType: "histograms", header: writtenHistogramsHeader, sent: sent.histograms},
+		{itemType: "exemplars", header: writtenExemplarsHeader, sent: sent.exemplars},
+	} {
+		header := resp.Header.Get(item.header)
+		if header == "" {
+			continue
+		}
+		written, err := strconv.Atoi(header)
+		if err != nil {
+			prwe.settings.Logger.Debug("invalid remote write response header", zap.String("header", item.header), zap.Error(err))
+			continue
+		}
+		if unwritten := item.sent - written; unwritten > 0 {
+			prwe.telemetry.recordUnwrittenItems(ctx, item.itemType, unwritten)
+			prwe.settings.Logger.Warn("remote write endpoint did not write all items",
+				zap.String("type", item.itemType), zap.Int("sent", item.sent), zap.Int("written", written), zap.Int("status", resp.StatusCode))
+		}
+	}
+}
@@ -9,6 +9,8 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"path/filepath"
+	"strconv"
 	"sync"
 	"testing"
 	"time"
@@ -17,6 +19,7 @@ import (
 	"github.com/golang/snappy"
 	"github.com/prometheus/prometheus/model/value"
 	"github.com/prometheus/prometheus/prompb"
+	writev2 "github.com/prometheus/prometheus/prompb/io/prometheus/write/v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
@@ -1174,3 +1177,123 @@ func TestRetries(t *testing.T) {
 		})
 	}
 }
+
+type unwrittenItemsTelemetry struct {
+	prwTelemetry
+	mu        sync.Mutex
+	unwritten map[string]int
+}
+
+func (u *unwrittenItemsTelemetry) recordUnwrittenItems(_ context.Context, itemType string, numItems int) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.unwritten[itemType] += numItems
+}
+
+func newTestExporterV2(t *testing.T, endpoint string, walDir string) *prwExporter {
+	cfg := createDefaultConfig().(*Config)
+	cfg.ClientConfig.Endpoint = endpoint
+	cfg.Protocol = ProtocolV2
+	cfg.TargetInfo.Enabled = false
+	if walDir != "" {
+		cfg.WAL = &WALConfig{Directory: walDir, BufferSize: 1, TruncateFrequency: 10 * time.Millisecond}
+	}
+	require.NoError(t, cfg.Validate())
+
+	prwe, err := newPRWExporter(cfg, exportertest.NewNopSettings())
+	require.NoError(t, err)
+	require.NoError(t, prwe.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, prwe.Shutdown(context.Background()))
+	})
+	return prwe
+}
+
+func decodeRequestV2(t *testing.T, r *http.Request) *writev2.Request {
+	compressed, err := io.ReadAll(r.Body)
+	require.NoError(t, err)
+	data, err := snappy.Decode(nil, compressed)
+	require.NoError(t, err)
+	req := &writev2.Request{}
+	require.NoError(t, proto.Unmarshal(data, req))
+	return req
+}
+
+func TestPushMetricsV2(t *testing.T) {
+	md := getMetricsFromMetricList(
+		validMetrics1[validSum],
+		getExpHistogramMetric("exponential_hist", lbs1, time1, &floatVal1, uint64(2), 2, []uint64{1, 1}),
+	)
+
+	received := make(chan *writev2.Request, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "snappy", r.Header.Get("Content-Encoding"))
+		assert.Equal(t, "application/x-protobuf;proto=io.prometheus.write.v2.Request", r.Header.Get("Content-Type"))
+		assert.Equal(t, "2.0.0", r.Header.Get("X-Prometheus-Remote-Write-Version"))
+		req := decodeRequestV2(t, r)
+		received <- req
+
+		// Report one sample and no exemplar as not written.
+		stats := countItemsV2(req)
+		w.Header().Set(writtenSamplesHeader, strconv.Itoa(stats.samples-1))
+		w.Header().Set(writtenHistogramsHeader, strconv.Itoa(stats.histograms))
+		w.Header().Set(writtenExemplarsHeader, "invalid")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	prwe := newTestExporterV2(t, server.URL, "")
+	telemetry := &unwrittenItemsTelemetry{prwTelemetry: prwe.telemetry, unwritten: map[string]int{}}
+	prwe.telemetry = telemetry
+
+	require.NoError(t, prwe.PushMetrics(context.Background(), md))
+	req := <-received
+	require.Len(t, req.Timeseries, 2)
+	var histograms int
+	for _, ts := range req.Timeseries {
+		histograms += len(ts.Histograms)
+	}
+	assert.Equal(t, 1, histograms)
+	assert.Equal(t, map[string]int{"samples": 1}, telemetry.unwritten)
+}
+
+func TestPushMetricsV2PartialWriteRejected(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set(writtenSamplesHeader, "0")
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	prwe := newTestExporterV2(t, server.URL, "")
+	telemetry := &unwrittenItemsTelemetry{prwTelemetry: prwe.telemetry, unwritten: map[string]int{}}
+	prwe.telemetry = telemetry
+
+	err := prwe.PushMetrics(context.Background(), getMetricsFromMetricList(validMetrics1[validSum]))
+	assert.True(t, consumererror.IsPermanent(err))
+	assert.Equal(t, map[string]int{"samples": 1}, telemetry.unwritten)
+}
+
+func TestPushMetricsV2WithWAL(t *testing.T) {
+	received := make(chan *writev2.Request, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- decodeRequestV2(t, r)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	walDir := t.TempDir()
+	prwe := newTestExporterV2(t, server.URL, walDir)
+	require.Nil(t, prwe.wal)
+	require.NotNil(t, prwe.walV2)
+
+	require.NoError(t, prwe.PushMetrics(context.Background(), getMetricsFromMetricList(validMetrics1[validSum])))
+	select {
+	case req := <-received:
+		require.Len(t, req.Timeseries, 1)
+		assert.Equal(t, []writev2.Sample{{Value: floatVal1, Timestamp: msTime1}}, req.Timeseries[0].Samples)
+	case <-time.After(10 * time.Second):
+		t.Fatal("request not exported from the WAL")
+	}
+	assert.DirExists(t, filepath.Join(walDir, walNameV2))
+	assert.NoDirExists(t, filepath.Join(walDir, walNameV1))
+}
@@ -6,9 +6,11 @@ package prometheusremotewriteexporter // import "github.com/open-telemetry/opent
 import (
 	"errors"
 	"math"
+	"slices"
 	"sort"
 
 	"github.com/prometheus/prometheus/prompb"
+	writev2 "github.com/prometheus/prometheus/prompb/io/prometheus/write/v2"
 )
 
 type batchTimeSeriesState struct {
@@ -120,3 +122,72 @@ func orderBySampleTimestamp(tsArray []prompb.TimeSeries) []prompb.TimeSeries {
 	}
 	return tsArray
 }
+
+// batchRequestV2 splits the remote write 2.0 request into requests smaller than maxBatchByteSize.
+// Each request has its own symbols table, with only the strings of its series.
+func batchRequestV2(req *writev2.Request, maxBatchByteSize int) []*writev2.Request {
+	if req.Size() < maxBatchByteSize {
+		return []*writev2.Request{req}
+	}
+
+	var requests []*writev2.Request
+	symbols := writev2.NewSymbolTable()
+	batch := &writev2.Request{}
+	sizeOfCurrentBatch := symbolsSize(symbols.Symbols())
+	for _, ts := range req.Timeseries {
+		numSymbols := len(symbols.Symbols())
+		series := resymbolize(ts, req.Symbols, &symbols)
+		sizeOfSeries := series.Size() + symbolsSize(symbols.Symbols()[numSymbols:])
+
+		if len(batch.Timeseries) > 0 && sizeOfCurrentBatch+sizeOfSeries >= maxBatchByteSize {
+			// The strings of the series were appended to the symbols, leave them out of this batch.
+			batch.Symbols = slices.Clip(symbols.Symbols()[:numSymbols])
+			requests = append(requests, batch)
+
+			symbols = writev2.NewSymbolTable()
+			batch = &writev2.Request{}
+			series = resymbolize(ts, req.Symbols, &symbols)
+			sizeOfCurrentBatch = symbolsSize(symbols.Symbols())
+			sizeOfSeries = series.Size()
+		}
+
+		batch.Timeseries = append(batch.Timeseries, series)
+		sizeOfCurrentBatch += sizeOfSeries
+	}
+	batch.Symbols = symbols.Symbols()
+	return append(requests, batch)
+}
+
+// resymbolize returns a copy of the series, with its strings referencing symbols instead of from.
+func resymbolize(ts writev2.TimeSeries, from []string, symbols *writev2.SymbolsTable) writev2.TimeSeries {
+	ts.LabelsRefs = resymbolizeRefs(ts.LabelsRefs, from, symbols)
+	ts.Metadata.HelpRef = symbols.Symbolize(from[ts.Metadata.HelpRef])
+	ts.Metadata.UnitRef = symbols.Symbolize(from[ts.Metadata.UnitRef])
+	if len(ts.Exemplars) > 0 {
+		exemplars := make([]writev2.Exemplar, len(ts.Exemplars))
+		for i, e := range ts.Exemplars {
+			e.LabelsRefs = resymbolizeRefs(e.LabelsRefs, from, symbols)
+			exemplars[i] = e
+		}
+		ts.Exemplars = exemplars
+	}
+	return ts
+}
+
+func resymbolizeRefs(refs []uint32, from []string, symbols *writev2.SymbolsTable) []uint32 {
+	out := make([]uint32, len(refs))
+	for i, ref := range refs {
+		out[i] = symbols.Symbolize(from[ref])
+	}
+	return out
+}
+
+// symbolsSize returns the approximate size of the symbols in an encoded request.
+func symbolsSize(symbols []string) int {
+	size := 0
+	for _, s := range symbols {
+		// Field tag and length, which are a single byte each for most symbols.
+		size += len(s) + 2
+	}
+	return size
+}
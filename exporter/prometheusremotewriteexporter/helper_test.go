@@ -7,8 +7,11 @@ import (
 	"math"
 	"testing"
 
+	"github.com/prometheus/prometheus/model/labels"
 	"github.com/prometheus/prometheus/prompb"
+	writev2 "github.com/prometheus/prometheus/prompb/io/prometheus/write/v2"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // Test_batchTimeSeries checks batchTimeSeries return the correct number of requests
@@ -250,3 +253,69 @@ func TestEnsureTimeseriesPointsAreSortedByTimestamp(t *testing.T) {
 		}
 	}
 }
+
+func newTestRequestV2(numSeries int) *writev2.Request {
+	symbols := writev2.NewSymbolTable()
+	req := &writev2.Request{}
+	for i := 0; i < numSeries; i++ {
+		lbls := labels.FromStrings("__name__", "metric", "job", "test", "series", string(rune('a'+i)))
+		req.Timeseries = append(req.Timeseries, writev2.TimeSeries{
+			LabelsRefs: symbols.SymbolizeLabels(lbls, nil),
+			Samples:    []writev2.Sample{{Value: float64(i), Timestamp: int64(i)}},
+			Exemplars: []writev2.Exemplar{{
+				LabelsRefs: symbols.SymbolizeLabels(labels.FromStrings("trace_id", string(rune('a'+i))), nil),
+				Value:      float64(i),
+			}},
+			Metadata: writev2.Metadata{
+				Type:    writev2.Metadata_METRIC_TYPE_GAUGE,
+				HelpRef: symbols.Symbolize("help"),
+				UnitRef: symbols.Symbolize("unit"),
+			},
+		})
+	}
+	req.Symbols = symbols.Symbols()
+	return req
+}
+
+func Test_batchRequestV2(t *testing.T) {
+	req := newTestRequestV2(10)
+
+	requests := batchRequestV2(req, req.Size()+1)
+	require.Len(t, requests, 1)
+	assert.Same(t, req, requests[0])
+
+	maxBatchByteSize := req.Size() / 3
+	requests = batchRequestV2(req, maxBatchByteSize)
+	assert.Greater(t, len(requests), 2)
+
+	b := labels.NewScratchBuilder(0)
+	var got, want []string
+	for _, ts := range req.Timeseries {
+		want = append(want, ts.ToLabels(&b, req.Symbols).String())
+	}
+	for _, batch := range requests {
+		assert.Less(t, batch.Size(), maxBatchByteSize)
+		assert.Equal(t, "", batch.Symbols[0])
+		// Each batch only has the symbols of its series.
+		used := make([]bool, len(batch.Symbols))
+		used[0] = true
+		for _, ts := range batch.Timeseries {
+			got = append(got, ts.ToLabels(&b, batch.Symbols).String())
+			for _, ref := range ts.LabelsRefs {
+				used[ref] = true
+			}
+			for _, e := range ts.Exemplars {
+				for _, ref := range e.LabelsRefs {
+					used[ref] = true
+				}
+				assert.Equal(t, ts.Samples[0].Value, e.Value)
+			}
+			used[ts.Metadata.HelpRef] = true
+			used[ts.Metadata.UnitRef] = true
+			assert.Equal(t, "help", batch.Symbols[ts.Metadata.HelpRef])
+			assert.Equal(t, "unit", batch.Symbols[ts.Metadata.UnitRef])
+		}
+		assert.NotContains(t, used, false)
+	}
+	assert.Equal(t, want, got)
+}
@@ -31,6 +31,7 @@ type TelemetryBuilder struct {
 	meter                                             metric.Meter
 	ExporterPrometheusremotewriteFailedTranslations   metric.Int64Counter
 	ExporterPrometheusremotewriteTranslatedTimeSeries metric.Int64Counter
+	ExporterPrometheusremotewriteUnwrittenItems       metric.Int64Counter
 	meters                                            map[configtelemetry.Level]metric.Meter
 }
 
@@ -66,5 +67,11 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...Teleme
 		metric.WithUnit("1"),
 	)
 	errs = errors.Join(errs, err)
+	builder.ExporterPrometheusremotewriteUnwrittenItems, err = builder.meters[configtelemetry.LevelBasic].Int64Counter(
+		"otelcol_exporter_prometheusremotewrite_unwritten_items",
+		metric.WithDescription("Number of samples, histograms and exemplars sent with remote write 2.0 that the remote endpoint reported as not written"),
+		metric.WithUnit("1"),
+	)
+	errs = errors.Join(errs, err)
 	return &builder, errs
 }
@@ -14,19 +14,27 @@ import (
 
 	"github.com/fsnotify/fsnotify"
 	"github.com/gogo/protobuf/proto"
-	"github.com/prometheus/prometheus/prompb"
+	writev2 "github.com/prometheus/prometheus/prompb/io/prometheus/write/v2"
 	"github.com/tidwall/wal"
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
 )
 
-type prweWAL struct {
+// walRequest is a remote write request stored in the WAL, either a *prompb.WriteRequest
+// or a *writev2.Request.
+type walRequest[T any] interface {
+	*T
+	proto.Message
+}
+
+type prweWAL[T any, PT walRequest[T]] struct {
 	mu        sync.Mutex // mu protects the fields below.
 	wal       *wal.Log
 	walConfig *WALConfig
+	walName   string
 	walPath   string
 
-	exportSink func(ctx context.Context, reqL []*prompb.WriteRequest) error
+	exportSink func(ctx context.Context, reqL []PT) error
 
 	stopOnce  sync.Once
 	stopChan  chan struct{}
@@ -59,24 +67,44 @@ func (wc *WALConfig) truncateFrequency() time.Duration {
 	return defaultWALTruncateFrequency
 }
 
-func newWAL(walConfig *WALConfig, exportSink func(context.Context, []*prompb.WriteRequest) error) *prweWAL {
+func newWAL[T any, PT walRequest[T]](walConfig *WALConfig, exportSink func(context.Context, []PT) error) *prweWAL[T, PT] {
 	if walConfig == nil {
 		// There are cases for which the WAL can be disabled.
 		// TODO: Perhaps log that the WAL wasn't enabled.
 		return nil
 	}
 
-	return &prweWAL{
+	return &prweWAL[T, PT]{
 		exportSink: exportSink,
 		walConfig:  walConfig,
+		walName:    walName[PT](),
 		stopChan:   make(chan struct{}),
 		rWALIndex:  &atomic.Uint64{},
 		wWALIndex:  &atomic.Uint64{},
 	}
 }
 
+const (
+	walNameV1 = "prom_remotewrite"
+	walNameV2 = "prom_remotewrite_v2"
+)
+
+// walName returns the name of the WAL storing the requests of type PT. Each protocol has its
+// own WAL, as the requests of one protocol can't be decoded as requests of the other.
+func walName[PT proto.Message]() string {
+	var req PT
+	if _, ok := any(req).(*writev2.Request); ok {
+		return walNameV2
+	}
+	return walNameV1
+}
+
 func (wc *WALConfig) createWAL() (*wal.Log, string, error) {
-	walPath := filepath.Join(wc.Directory, "prom_remotewrite")
+	return wc.createNamedWAL(walNameV1)
+}
+
+func (wc *WALConfig) createNamedWAL(name string) (*wal.Log, string, error) {
+	walPath := filepath.Join(wc.Directory, name)
 	log, err := wal.Open(walPath, &wal.Options{
 		SegmentCacheSize: wc.bufferSize(),
 		NoCopy:           true,
@@ -93,7 +121,7 @@ var (
 )
 
 // retrieveWALIndices queries the WriteAheadLog for its current first and last indices.
-func (prwe *prweWAL) retrieveWALIndices() (err error) {
+func (prwe *prweWAL[T, PT]) retrieveWALIndices() (err error) {
 	prwe.mu.Lock()
 	defer prwe.mu.Unlock()
 
@@ -102,7 +130,7 @@ func (prwe *prweWAL) retrieveWALIndices() (err error) {
 		return err
 	}
 
-	log, walPath, err := prwe.walConfig.createWAL()
+	log, walPath, err := prwe.walConfig.createNamedWAL(prwe.walName)
 	if err != nil {
 		return err
 	}
@@ -124,7 +152,7 @@ func (prwe *prweWAL) retrieveWALIndices() (err error) {
 	return nil
 }
 
-func (prwe *prweWAL) stop() error {
+func (prwe *prweWAL[T, PT]) stop() error {
 	err := errAlreadyClosed
 	prwe.stopOnce.Do(func() {
 		prwe.mu.Lock()
@@ -137,7 +165,7 @@ func (prwe *prweWAL) stop() error {
 }
 
 // run begins reading from the WAL until prwe.stopChan is closed.
-func (prwe *prweWAL) run(ctx context.Context) (err error) {
+func (prwe *prweWAL[T, PT]) run(ctx context.Context) (err error) {
 	var logger *zap.Logger
 	logger, err = loggerFromContext(ctx)
 	if err != nil {
@@ -181,13 +209,13 @@ func (prwe *prweWAL) run(ctx context.Context) (err error) {
 	return nil
 }
 
-// continuallyPopWALThenExport reads a prompb.WriteRequest proto encoded blob from the WAL, and moves
+// continuallyPopWALThenExport reads a request proto encoded blob from the WAL, and moves
 // the WAL's front index forward until either the read buffer period expires or the maximum
 // buffer size is exceeded. When either of the two conditions are matched, it then exports
 // the requests to the Remote-Write endpoint, and then truncates the head of the WAL to where
 // it last read from.
-func (prwe *prweWAL) continuallyPopWALThenExport(ctx context.Context, signalStart func()) (err error) {
-	var reqL []*prompb.WriteRequest
+func (prwe *prweWAL[T, PT]) continuallyPopWALThenExport(ctx context.Context, signalStart func()) (err error) {
+	var reqL []PT
 	defer func() {
 		// Keeping it within a closure to ensure that the later
 		// updated value of reqL is always flushed to disk.
@@ -219,7 +247,7 @@ func (prwe *prweWAL) continuallyPopWALThenExport(ctx context.Context, signalStar
 		default:
 		}
 
-		var req *prompb.WriteRequest
+		var req PT
 		req, err = prwe.readPrompbFromWAL(ctx, prwe.rWALIndex.Load())
 		if err != nil {
 			return err
@@ -250,7 +278,7 @@ func (prwe *prweWAL) continuallyPopWALThenExport(ctx context.Context, signalStar
 	}
 }
 
-func (prwe *prweWAL) closeWAL() error {
+func (prwe *prweWAL[T, PT]) closeWAL() error {
 	if prwe.wal != nil {
 		err := prwe.wal.Close()
 		prwe.wal = nil
@@ -259,7 +287,7 @@ func (prwe *prweWAL) closeWAL() error {
 	return nil
 }
 
-func (prwe *prweWAL) syncAndTruncateFront() error {
+func (prwe *prweWAL[T, PT]) syncAndTruncateFront() error {
 	prwe.mu.Lock()
 	defer prwe.mu.Unlock()
 
@@ -279,7 +307,7 @@ func (prwe *prweWAL) syncAndTruncateFront() error {
 	return nil
 }
 
-func (prwe *prweWAL) exportThenFrontTruncateWAL(ctx context.Context, reqL []*prompb.WriteRequest) error {
+func (prwe *prweWAL[T, PT]) exportThenFrontTruncateWAL(ctx context.Context, reqL []PT) error {
 	if len(reqL) == 0 {
 		return nil
 	}
@@ -300,7 +328,7 @@ func (prwe *prweWAL) exportThenFrontTruncateWAL(ctx context.Context, reqL []*pro
 // persistToWAL is the routine that'll be hooked into the exporter's receiving side and it'll
 // write them to the Write-Ahead-Log so that shutdowns won't lose data, and that the routine that
 // reads from the WAL can then process the previously serialized requests.
-func (prwe *prweWAL) persistToWAL(requests []*prompb.WriteRequest) error {
+func (prwe *prweWAL[T, PT]) persistToWAL(requests []PT) error {
 	prwe.mu.Lock()
 	defer prwe.mu.Unlock()
 
@@ -318,7 +346,7 @@ func (prwe *prweWAL) persistToWAL(requests []*prompb.WriteRequest) error {
 	return prwe.wal.WriteBatch(batch)
 }
 
-func (prwe *prweWAL) readPrompbFromWAL(ctx context.Context, index uint64) (wreq *prompb.WriteRequest, err error) {
+func (prwe *prweWAL[T, PT]) readPrompbFromWAL(ctx context.Context, index uint64) (wreq PT, err error) {
 	prwe.mu.Lock()
 	defer prwe.mu.Unlock()
 
@@ -343,7 +371,7 @@ func (prwe *prweWAL) readPrompbFromWAL(ctx context.Context, index uint64) (wreq
 
 		protoBlob, err = prwe.wal.Read(index)
 		if err == nil { // The read succeeded.
-			req := new(prompb.WriteRequest)
+			req := PT(new(T))
 			if err = proto.Unmarshal(protoBlob, req); err != nil {
 				return nil, err
 			}
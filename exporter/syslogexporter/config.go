@@ -5,7 +5,9 @@ package syslogexporter // import "github.com/open-telemetry/opentelemetry-collec
 
 import (
 	"errors"
+	"fmt"
 	"strings"
+	"time"
 
 	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/config/configretry"
@@ -19,6 +21,11 @@ var (
 	errUnsupportedNetwork  = errors.New("unsupported network: network is required, only tcp/udp supported")
 	errUnsupportedProtocol = errors.New("unsupported protocol: Only rfc5424 and rfc3164 supported")
 	errOctetCounting       = errors.New("octet counting is only supported for rfc5424 protocol")
+	errFramingTrailer      = errors.New("unsupported non_transparent_framing_trailer: only LF and NUL supported")
+	errFramingConflict     = errors.New("non_transparent_framing_trailer cannot be used with octet counting")
+	errOctetCountingUDP    = errors.New("octet counting is only supported for tcp network")
+	errMaxMessageSize      = errors.New("max_message_size must be larger than the truncation marker")
+	errReconnectBuffer     = errors.New("reconnect_buffer max_size and reconnect_interval must be positive")
 )
 
 // Config defines configuration for Syslog exporter.
@@ -37,6 +44,24 @@ type Config struct {
 	// Wether or not to enable RFC 6587 Octet Counting.
 	EnableOctetCounting bool `mapstructure:"enable_octet_counting"`
 
+	// NonTransparentFramingTrailer is the trailer of the messages sent over TCP without
+	// octet counting, as described in RFC 6587 section 3.4.2.
+	// options: LF, NUL
+	NonTransparentFramingTrailer string `mapstructure:"non_transparent_framing_trailer"`
+
+	// StructuredData adds structured data elements built from log record attributes to
+	// RFC 5424 messages.
+	StructuredData []StructuredDataConfig `mapstructure:"structured_data"`
+
+	// MaxMessageSize is the maximum size in bytes of the messages sent over UDP. Larger
+	// messages are truncated and end with the TruncationMarker. Zero disables truncation.
+	MaxMessageSize int `mapstructure:"max_message_size"`
+	// TruncationMarker replaces the end of truncated messages.
+	TruncationMarker string `mapstructure:"truncation_marker"`
+
+	// ReconnectBuffer keeps messages in memory while the syslog server is unreachable.
+	ReconnectBuffer ReconnectBufferConfig `mapstructure:"reconnect_buffer"`
+
 	// TLSSetting struct exposes TLS client configuration.
 	TLSSetting configtls.ClientConfig `mapstructure:"tls"`
 
@@ -72,6 +97,34 @@ func (cfg *Config) Validate() error {
 		invalidFields = append(invalidFields, errOctetCounting)
 	}
 
+	if cfg.EnableOctetCounting && cfg.Network == string(confignet.TransportTypeUDP) {
+		invalidFields = append(invalidFields, errOctetCountingUDP)
+	}
+
+	switch cfg.NonTransparentFramingTrailer {
+	case "", trailerLF, trailerNUL:
+	default:
+		invalidFields = append(invalidFields, errFramingTrailer)
+	}
+
+	if cfg.EnableOctetCounting && cfg.NonTransparentFramingTrailer != "" {
+		invalidFields = append(invalidFields, errFramingConflict)
+	}
+
+	for _, sd := range cfg.StructuredData {
+		if err := sd.Validate(); err != nil {
+			invalidFields = append(invalidFields, err)
+		}
+	}
+
+	if cfg.MaxMessageSize < 0 || (cfg.MaxMessageSize > 0 && cfg.MaxMessageSize <= len(cfg.TruncationMarker)+1) {
+		invalidFields = append(invalidFields, errMaxMessageSize)
+	}
+
+	if cfg.ReconnectBuffer.Enabled && (cfg.ReconnectBuffer.MaxSize <= 0 || cfg.ReconnectBuffer.ReconnectInterval <= 0) {
+		invalidFields = append(invalidFields, errReconnectBuffer)
+	}
+
 	if len(invalidFields) > 0 {
 		return errors.Join(invalidFields...)
 	}
@@ -79,6 +132,57 @@ func (cfg *Config) Validate() error {
 	return nil
 }
 
+// StructuredDataConfig defines an RFC 5424 structured data element.
+type StructuredDataConfig struct {
+	// ID is the SD-ID of the element, such as "origin" or "meta@32473".
+	ID string `mapstructure:"id"`
+	// Params maps the names of the SD-PARAMs of the element to the names of the log
+	// record attributes holding their values. Missing attributes are omitted.
+	Params map[string]string `mapstructure:"params"`
+}
+
+// Validate checks that the SD-ID and the SD-PARAM names are valid SD-NAMEs.
+func (cfg *StructuredDataConfig) Validate() error {
+	if !isSDName(cfg.ID) {
+		return fmt.Errorf("invalid structured data id %q", cfg.ID)
+	}
+	for name := range cfg.Params {
+		if !isSDName(name) {
+			return fmt.Errorf("invalid structured data param name %q in %q", name, cfg.ID)
+		}
+	}
+	return nil
+}
+
+// isSDName reports whether name is a valid RFC 5424 SD-NAME: 1 to 32 printable US-ASCII
+// characters except '=', SP, ']' and '"'.
+func isSDName(name string) bool {
+	if len(name) == 0 || len(name) > 32 {
+		return false
+	}
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		if c <= ' ' || c > '~' || c == '=' || c == ']' || c == '"' {
+			return false
+		}
+	}
+	return true
+}
+
+// ReconnectBufferConfig defines the in-memory buffer of messages that could not be sent
+// because the connection to the syslog server is down.
+type ReconnectBufferConfig struct {
+	// Enabled turns the buffer on. When disabled, failed sends are returned to the
+	// retry and queue settings of the exporter.
+	Enabled bool `mapstructure:"enabled"`
+	// MaxSize is the maximum size in bytes of the buffered messages. Sends that do not
+	// fit fail.
+	MaxSize int `mapstructure:"max_size"`
+	// ReconnectInterval is the period of the attempts to reconnect and flush the buffer
+	// in the background.
+	ReconnectInterval time.Duration `mapstructure:"reconnect_interval"`
+}
+
 const (
 	// Syslog Network
 	DefaultNetwork = string(confignet.TransportTypeTCP)
@@ -86,4 +190,10 @@ const (
 	DefaultPort = 514
 	// Syslog Protocol
 	DefaultProtocol = "rfc5424"
+	// Marker of truncated messages
+	DefaultTruncationMarker = "..."
+	// Size of the reconnect buffer
+	DefaultReconnectBufferSize = 1 << 20
+	// Period of the attempts to reconnect
+	DefaultReconnectInterval = 5 * time.Second
 )
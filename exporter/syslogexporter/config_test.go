@@ -5,6 +5,7 @@ package syslogexporter
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -57,6 +58,77 @@ func TestValidate(t *testing.T) {
 			},
 			err: "unsupported protocol: Only rfc5424 and rfc3164 supported",
 		},
+		{
+			name: "Conflicting framing",
+			cfg: &Config{
+				Port:                         514,
+				Endpoint:                     "host.domain.com",
+				Network:                      "tcp",
+				Protocol:                     "rfc5424",
+				EnableOctetCounting:          true,
+				NonTransparentFramingTrailer: "CRLF",
+			},
+			err: "unsupported non_transparent_framing_trailer: only LF and NUL supported" + "\n" +
+				"non_transparent_framing_trailer cannot be used with octet counting",
+		},
+		{
+			name: "Octet counting over UDP",
+			cfg: &Config{
+				Port:                514,
+				Endpoint:            "host.domain.com",
+				Network:             "udp",
+				Protocol:            "rfc5424",
+				EnableOctetCounting: true,
+			},
+			err: "octet counting is only supported for tcp network",
+		},
+		{
+			name: "Invalid structured data",
+			cfg: &Config{
+				Port:     514,
+				Endpoint: "host.domain.com",
+				Network:  "tcp",
+				Protocol: "rfc5424",
+				StructuredData: []StructuredDataConfig{
+					{ID: "meta@32473", Params: map[string]string{"sequence Id": "seq"}},
+				},
+			},
+			err: `invalid structured data param name "sequence Id" in "meta@32473"`,
+		},
+		{
+			name: "Truncation and buffer",
+			cfg: &Config{
+				Port:             514,
+				Endpoint:         "host.domain.com",
+				Network:          "udp",
+				Protocol:         "rfc3164",
+				MaxMessageSize:   3,
+				TruncationMarker: "...",
+				ReconnectBuffer:  ReconnectBufferConfig{Enabled: true},
+			},
+			err: "max_message_size must be larger than the truncation marker" + "\n" +
+				"reconnect_buffer max_size and reconnect_interval must be positive",
+		},
+		{
+			name: "Valid",
+			cfg: &Config{
+				Port:                         514,
+				Endpoint:                     "host.domain.com",
+				Network:                      "tcp",
+				Protocol:                     "rfc5424",
+				NonTransparentFramingTrailer: "NUL",
+				StructuredData: []StructuredDataConfig{
+					{ID: "origin", Params: map[string]string{"ip": "client.address"}},
+				},
+				MaxMessageSize:   2048,
+				TruncationMarker: "...",
+				ReconnectBuffer: ReconnectBufferConfig{
+					Enabled:           true,
+					MaxSize:           1 << 20,
+					ReconnectInterval: time.Second,
+				},
+			},
+		},
 	}
 	for _, testInstance := range tests {
 		t.Run(testInstance.name, func(t *testing.T) {
@@ -9,7 +9,10 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
+	"time"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter"
@@ -23,6 +26,11 @@ type syslogexporter struct {
 	logger    *zap.Logger
 	tlsConfig *tls.Config
 	formatter formatter
+	framer    framer
+	sender    *sender
+
+	stopFlush  chan struct{}
+	shutdownWG sync.WaitGroup
 }
 
 func initExporter(cfg *Config, createSettings exporter.Settings) (*syslogexporter, error) {
@@ -39,7 +47,10 @@ func initExporter(cfg *Config, createSettings exporter.Settings) (*syslogexporte
 		config:    cfg,
 		logger:    createSettings.Logger,
 		tlsConfig: loadedTLSConfig,
-		formatter: createFormatter(cfg.Protocol, cfg.EnableOctetCounting),
+		formatter: createFormatter(cfg.Protocol, cfg.StructuredData),
+		framer:    createFramer(cfg),
+		sender:    newSender(createSettings.Logger, cfg, loadedTLSConfig),
+		stopFlush: make(chan struct{}),
 	}
 
 	s.logger.Info("Syslog Exporter configured",
@@ -70,9 +81,46 @@ func newLogsExporter(
 		exporterhelper.WithTimeout(cfg.TimeoutSettings),
 		exporterhelper.WithRetry(cfg.BackOffConfig),
 		exporterhelper.WithQueue(cfg.QueueSettings),
+		exporterhelper.WithStart(s.start),
+		exporterhelper.WithShutdown(s.shutdown),
 	)
 }
 
+func (se *syslogexporter) start(context.Context, component.Host) error {
+	if !se.config.ReconnectBuffer.Enabled {
+		return nil
+	}
+	se.shutdownWG.Add(1)
+	go se.flushBuffer()
+	return nil
+}
+
+// flushBuffer periodically sends the buffered messages, so that they are not held until
+// the next logs are exported.
+func (se *syslogexporter) flushBuffer() {
+	defer se.shutdownWG.Done()
+	ticker := time.NewTicker(se.config.ReconnectBuffer.ReconnectInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), se.config.TimeoutSettings.Timeout)
+			if err := se.sender.Flush(ctx); err != nil {
+				se.logger.Debug("Failed to flush buffered syslog messages", zap.Error(err))
+			}
+			cancel()
+		case <-se.stopFlush:
+			return
+		}
+	}
+}
+
+func (se *syslogexporter) shutdown(context.Context) error {
+	close(se.stopFlush)
+	se.shutdownWG.Wait()
+	return se.sender.close()
+}
+
 func (se *syslogexporter) pushLogsData(ctx context.Context, logs plog.Logs) error {
 	batchMessages := se.config.Network == string(confignet.TransportTypeTCP)
 	var err error
@@ -93,18 +141,13 @@ func (se *syslogexporter) exportBatch(ctx context.Context, logs plog.Logs) error
 			for k := 0; k < scopeLogs.LogRecords().Len(); k++ {
 				logRecord := scopeLogs.LogRecords().At(k)
 				formatted := se.formatter.format(logRecord)
-				payload.WriteString(formatted)
+				payload.WriteString(se.framer.frame(formatted))
 			}
 		}
 	}
 
 	if payload.Len() > 0 {
-		sender, err := connect(ctx, se.logger, se.config, se.tlsConfig)
-		if err != nil {
-			return consumererror.NewLogs(err, logs)
-		}
-		defer sender.close()
-		err = sender.Write(ctx, payload.String())
+		err := se.sender.Write(ctx, payload.String())
 		if err != nil {
 			return consumererror.NewLogs(err, logs)
 		}
@@ -113,12 +156,6 @@ func (se *syslogexporter) exportBatch(ctx context.Context, logs plog.Logs) error
 }
 
 func (se *syslogexporter) exportNonBatch(ctx context.Context, logs plog.Logs) error {
-	sender, err := connect(ctx, se.logger, se.config, se.tlsConfig)
-	if err != nil {
-		return consumererror.NewLogs(err, logs)
-	}
-	defer sender.close()
-
 	errs := []error{}
 	droppedLogs := plog.NewLogs()
 	for i := 0; i < logs.ResourceLogs().Len(); i++ {
@@ -129,8 +166,9 @@ func (se *syslogexporter) exportNonBatch(ctx context.Context, logs plog.Logs) er
 			droppedScopeLogs := droppedResourceLogs.ScopeLogs().AppendEmpty()
 			for k := 0; k < scopeLogs.LogRecords().Len(); k++ {
 				logRecord := scopeLogs.LogRecords().At(k)
-				formatted := se.formatter.format(logRecord)
-				err = sender.Write(ctx, formatted)
+				formatted := se.framer.frame(se.formatter.format(logRecord))
+				formatted = truncate(formatted, se.config.MaxMessageSize, se.config.TruncationMarker)
+				err := se.sender.Write(ctx, formatted)
 				if err != nil {
 					errs = append(errs, err)
 					droppedLogRecord := droppedScopeLogs.LogRecords().AppendEmpty()
@@ -149,6 +149,8 @@ func TestSyslogExportSuccess(t *testing.T) {
 		logs := logRecordsToLogs(buffer)
 		err := test.exp.pushLogsData(context.Background(), logs)
 		assert.NoError(t, err, "could not send message")
+		// The connection is kept open until shutdown.
+		assert.NoError(t, test.exp.shutdown(context.Background()))
 	}()
 	err := test.srv.SetDeadline(time.Now().Add(time.Second * 1))
 	require.NoError(t, err, "cannot set deadline")
@@ -237,3 +239,33 @@ func TestTLSConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestSyslogExportReconnectBuffer(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.EnableOctetCounting = true
+	cfg.ReconnectBuffer.Enabled = true
+	frame := createFramer(cfg).frame(expectedForm)
+	cfg.ReconnectBuffer.MaxSize = 2 * len(frame)
+	test := prepareExporterTest(t, cfg, false)
+	addr := test.srv.Addr().String()
+	// The server is down, the first two exports are buffered and the third one fails.
+	require.NoError(t, test.srv.Close())
+	logs := logRecordsToLogs(exampleLog(t))
+	require.NoError(t, test.exp.pushLogsData(context.Background(), logs))
+	require.NoError(t, test.exp.pushLogsData(context.Background(), logs))
+	assert.ErrorContains(t, test.exp.pushLogsData(context.Background(), logs), "connect")
+
+	srv, err := net.Listen("tcp", addr)
+	require.NoError(t, err)
+	defer srv.Close()
+	go func() {
+		assert.NoError(t, test.exp.sender.Flush(context.Background()))
+		assert.NoError(t, test.exp.shutdown(context.Background()))
+	}()
+	conn, err := srv.Accept()
+	require.NoError(t, err)
+	defer conn.Close()
+	b, err := io.ReadAll(conn)
+	require.NoError(t, err)
+	assert.Equal(t, frame+frame, string(b))
+}
@@ -29,9 +29,14 @@ func createDefaultConfig() component.Config {
 	qs.Enabled = false
 
 	return &Config{
-		Port:            DefaultPort,
-		Network:         DefaultNetwork,
-		Protocol:        DefaultProtocol,
+		Port:             DefaultPort,
+		Network:          DefaultNetwork,
+		Protocol:         DefaultProtocol,
+		TruncationMarker: DefaultTruncationMarker,
+		ReconnectBuffer: ReconnectBufferConfig{
+			MaxSize:           DefaultReconnectBufferSize,
+			ReconnectInterval: DefaultReconnectInterval,
+		},
 		BackOffConfig:   configretry.NewDefaultBackOffConfig(),
 		QueueSettings:   qs,
 		TimeoutSettings: exporterhelper.NewDefaultTimeoutConfig(),
@@ -25,9 +25,14 @@ func TestCreateDefaultConfig(t *testing.T) {
 	cfg := createDefaultConfig()
 
 	assert.Equal(t, &Config{
-		Port:     514,
-		Network:  "tcp",
-		Protocol: "rfc5424",
+		Port:             514,
+		Network:          "tcp",
+		Protocol:         "rfc5424",
+		TruncationMarker: "...",
+		ReconnectBuffer: ReconnectBufferConfig{
+			MaxSize:           1 << 20,
+			ReconnectInterval: 5 * time.Second,
+		},
 		QueueSettings: exporterhelper.QueueConfig{
 			Enabled:      false,
 			NumConsumers: 10,
@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package syslogexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/syslogexporter"
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+const trailerLF = "LF"
+const trailerNUL = "NUL"
+
+// framer delimits the formatted messages sent over a connection.
+type framer interface {
+	frame(msg string) string
+}
+
+func createFramer(cfg *Config) framer {
+	if cfg.Network != "tcp" {
+		// Each datagram holds a single message, octet counting is rejected by Config.Validate.
+		return &nonTransparentFramer{trailer: "\n"}
+	}
+	if cfg.EnableOctetCounting {
+		return &octetCountingFramer{}
+	}
+	if cfg.NonTransparentFramingTrailer == trailerNUL {
+		return &nonTransparentFramer{trailer: "\x00"}
+	}
+	return &nonTransparentFramer{trailer: "\n"}
+}
+
+// octetCountingFramer prefixes messages with their length, as described in RFC 6587 section 3.4.1.
+type octetCountingFramer struct{}
+
+func (f *octetCountingFramer) frame(msg string) string {
+	msg = strings.TrimSuffix(msg, "\n")
+	return strconv.Itoa(len(msg)) + " " + msg
+}
+
+// nonTransparentFramer ends messages with a trailer, as described in RFC 6587 section 3.4.2.
+type nonTransparentFramer struct {
+	trailer string
+}
+
+func (f *nonTransparentFramer) frame(msg string) string {
+	return strings.TrimSuffix(msg, "\n") + f.trailer
+}
+
+// truncate shortens msg to maxSize bytes, replacing its end with marker. The trailing
+// newline of msg, if any, is kept. It does not split UTF-8 encoded characters.
+func truncate(msg string, maxSize int, marker string) string {
+	if maxSize <= 0 || len(msg) <= maxSize {
+		return msg
+	}
+	trailer := ""
+	if strings.HasSuffix(msg, "\n") {
+		trailer = "\n"
+	}
+	end := maxSize - len(marker) - len(trailer)
+	for end > 0 && !utf8.RuneStart(msg[end]) {
+		end--
+	}
+	return msg[:end] + marker + trailer
+}
@@ -0,0 +1,54 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package syslogexporter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFramer(t *testing.T) {
+	msg := "<165>1 2003-08-24T05:14:15.000003Z 192.0.2.1 myproc 8710 - - It's time to make the do-nuts.\n"
+	tests := []struct {
+		name     string
+		cfg      *Config
+		expected string
+	}{
+		{
+			name:     "octet counting",
+			cfg:      &Config{Network: "tcp", EnableOctetCounting: true},
+			expected: "91 " + msg[:len(msg)-1],
+		},
+		{
+			name:     "non-transparent LF",
+			cfg:      &Config{Network: "tcp"},
+			expected: msg,
+		},
+		{
+			name:     "non-transparent NUL",
+			cfg:      &Config{Network: "tcp", NonTransparentFramingTrailer: "NUL"},
+			expected: msg[:len(msg)-1] + "\x00",
+		},
+		{
+			name:     "udp",
+			cfg:      &Config{Network: "udp", EnableOctetCounting: true},
+			expected: msg,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, createFramer(tt.cfg).frame(msg))
+		})
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	assert.Equal(t, "<34>Oct 11 22:14:15 host su: short\n", truncate("<34>Oct 11 22:14:15 host su: short\n", 64, "..."))
+	assert.Equal(t, "<34>Oct 11 22:14:15 host su: a l...\n", truncate("<34>Oct 11 22:14:15 host su: a long message\n", 36, "..."))
+	assert.Equal(t, "<34>Oct 11 22:14:15 host su: a lo...", truncate("<34>Oct 11 22:14:15 host su: a long message", 36, "..."))
+	// The multi-byte character does not fit and is not split.
+	assert.Equal(t, "<34>Oct 11 22:14:15 host su: ab...\n", truncate("<34>Oct 11 22:14:15 host su: abé de\n", 36, "..."))
+	assert.Equal(t, "<34>Oct 11 22:14:15 host su: abé\n", truncate("<34>Oct 11 22:14:15 host su: abé\n", 0, "..."))
+}
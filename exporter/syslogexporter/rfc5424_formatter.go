@@ -5,7 +5,9 @@ package syslogexporter // import "github.com/open-telemetry/opentelemetry-collec
 
 import (
 	"fmt"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"go.opentelemetry.io/collector/pdata/pcommon"
@@ -13,12 +15,12 @@ import (
 )
 
 type rfc5424Formatter struct {
-	octetCounting bool
+	structuredData []StructuredDataConfig
 }
 
-func newRFC5424Formatter(octetCounting bool) *rfc5424Formatter {
+func newRFC5424Formatter(structuredData []StructuredDataConfig) *rfc5424Formatter {
 	return &rfc5424Formatter{
-		octetCounting: octetCounting,
+		structuredData: structuredData,
 	}
 }
 
@@ -33,11 +35,6 @@ func (f *rfc5424Formatter) format(logRecord plog.LogRecord) string {
 	structuredData := f.formatStructuredData(logRecord)
 	messageString := f.formatMessage(logRecord)
 	formatted := fmt.Sprintf("<%s>%s %s %s %s %s %s %s%s\n", priorityString, versionString, timestampString, hostnameString, appnameString, pidString, messageIDString, structuredData, messageString)
-
-	if f.octetCounting {
-		formatted = fmt.Sprintf("%d %s", len(formatted), formatted)
-	}
-
 	return formatted
 }
 
@@ -69,32 +66,62 @@ func (f *rfc5424Formatter) formatMessageID(logRecord plog.LogRecord) string {
 	return getAttributeValueOrDefault(logRecord, msgID, emptyValue)
 }
 
+// formatStructuredData returns the elements of the structured_data attribute, followed by
+// the configured elements that have at least one of their params set.
 func (f *rfc5424Formatter) formatStructuredData(logRecord plog.LogRecord) string {
-	structuredDataAttributeValue, found := logRecord.Attributes().Get(structuredData)
-	if !found {
-		return emptyValue
-	}
-	if structuredDataAttributeValue.Type() != pcommon.ValueTypeMap {
-		return emptyValue
+	var sd strings.Builder
+	if attr, found := logRecord.Attributes().Get(structuredData); found && attr.Type() == pcommon.ValueTypeMap {
+		elements := attr.Map().AsRaw()
+		for _, id := range sortedKeys(elements) {
+			params := map[string]string{}
+			if vval, ok := elements[id].(map[string]any); ok {
+				for k, v := range vval {
+					if vv, ok := v.(string); ok {
+						params[k] = vv
+					}
+				}
+			}
+			writeSDElement(&sd, id, params)
+		}
 	}
 
-	sdElements := []string{}
-	for key, val := range structuredDataAttributeValue.Map().AsRaw() {
-		sdElements = append(sdElements, key)
-		vval, ok := val.(map[string]any)
-		if !ok {
-			continue
-		}
-		for k, v := range vval {
-			vv, ok := v.(string)
-			if !ok {
-				continue
+	for _, element := range f.structuredData {
+		params := map[string]string{}
+		for name, attrName := range element.Params {
+			if v, found := logRecord.Attributes().Get(attrName); found {
+				params[name] = v.AsString()
 			}
-			sdElements = append(sdElements, fmt.Sprintf("%s=\"%s\"", k, vv))
+		}
+		if len(params) > 0 {
+			writeSDElement(&sd, element.ID, params)
 		}
 	}
-	return fmt.Sprint(sdElements)
 
+	if sd.Len() == 0 {
+		return emptyValue
+	}
+	return sd.String()
+}
+
+// sdParamValueEscaper escapes the characters that must be escaped in PARAM-VALUEs.
+var sdParamValueEscaper = strings.NewReplacer(`"`, `\"`, `\`, `\\`, `]`, `\]`)
+
+func writeSDElement(sd *strings.Builder, id string, params map[string]string) {
+	sd.WriteString("[")
+	sd.WriteString(id)
+	for _, name := range sortedKeys(params) {
+		fmt.Fprintf(sd, " %s=\"%s\"", name, sdParamValueEscaper.Replace(params[name]))
+	}
+	sd.WriteString("]")
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
 }
 
 func (f *rfc5424Formatter) formatMessage(logRecord plog.LogRecord) string {
@@ -4,7 +4,6 @@
 package syslogexporter
 
 import (
-	"fmt"
 	"regexp"
 	"strings"
 	"testing"
@@ -29,10 +28,8 @@ func TestRFC5424Formatter(t *testing.T) {
 	require.NoError(t, err)
 	logRecord.SetTimestamp(pcommon.NewTimestampFromTime(timestamp))
 
-	actual := newRFC5424Formatter(false).format(logRecord)
+	actual := newRFC5424Formatter(nil).format(logRecord)
 	assert.Equal(t, expected, actual)
-	octetCounting := newRFC5424Formatter(true).format(logRecord)
-	assert.Equal(t, fmt.Sprintf("%d %s", len(expected), expected), octetCounting)
 
 	expected = "<165>1 2003-10-11T22:14:15.003Z mymachine.example.com evntslog 111 ID47 - BOMAn application event log entry...\n"
 	logRecord = plog.NewLogRecord()
@@ -47,10 +44,8 @@ func TestRFC5424Formatter(t *testing.T) {
 	require.NoError(t, err)
 	logRecord.SetTimestamp(pcommon.NewTimestampFromTime(timestamp))
 
-	actual = newRFC5424Formatter(false).format(logRecord)
+	actual = newRFC5424Formatter(nil).format(logRecord)
 	assert.Equal(t, expected, actual)
-	octetCounting = newRFC5424Formatter(true).format(logRecord)
-	assert.Equal(t, fmt.Sprintf("%d %s", len(expected), expected), octetCounting)
 
 	// Test structured data
 	expectedRegex := "\\<165\\>1 2003-08-24T12:14:15.000003Z 192\\.0\\.2\\.1 myproc 8710 - " +
@@ -76,7 +71,7 @@ func TestRFC5424Formatter(t *testing.T) {
 	require.NoError(t, err)
 	logRecord.SetTimestamp(pcommon.NewTimestampFromTime(timestamp))
 
-	actual = newRFC5424Formatter(false).format(logRecord)
+	actual = newRFC5424Formatter(nil).format(logRecord)
 	assert.NoError(t, err)
 	matched, err := regexp.MatchString(expectedRegex, actual)
 	assert.NoError(t, err)
@@ -93,6 +88,28 @@ func TestRFC5424Formatter(t *testing.T) {
 	require.NoError(t, err)
 	logRecord.SetTimestamp(pcommon.NewTimestampFromTime(timestamp))
 
-	actual = newRFC5424Formatter(false).format(logRecord)
+	actual = newRFC5424Formatter(nil).format(logRecord)
 	assert.Equal(t, expected, actual)
 }
+
+func TestRFC5424FormatterStructuredDataConfig(t *testing.T) {
+	logRecord := plog.NewLogRecord()
+	logRecord.Attributes().PutStr("message", "login")
+	logRecord.Attributes().PutStr("client.address", "192.0.2.1")
+	logRecord.Attributes().PutStr("user.name", `admin "root"`)
+	logRecord.Attributes().PutInt("sequence", 42)
+	sd := logRecord.Attributes().PutEmptyMap("structured_data")
+	sd.PutEmptyMap("timeQuality").PutStr("isSynced", "1")
+	timestamp, err := time.Parse(time.RFC3339Nano, "2003-08-24T05:14:15.000003Z")
+	require.NoError(t, err)
+	logRecord.SetTimestamp(pcommon.NewTimestampFromTime(timestamp))
+
+	f := newRFC5424Formatter([]StructuredDataConfig{
+		{ID: "origin", Params: map[string]string{"ip": "client.address"}},
+		{ID: "auth@32473", Params: map[string]string{"user": "user.name", "seq": "sequence"}},
+		{ID: "missing@32473", Params: map[string]string{"x": "not.set"}},
+	})
+	assert.Equal(t, "<165>1 2003-08-24T05:14:15.000003Z - - - - "+
+		`[timeQuality isSynced="1"][origin ip="192.0.2.1"][auth@32473 seq="42" user="admin \"root\""] login`+"\n",
+		f.format(logRecord))
+}
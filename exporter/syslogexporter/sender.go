@@ -4,11 +4,12 @@
 package syslogexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/syslogexporter"
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
 	"fmt"
+	"io"
 	"net"
-	"strings"
 	"sync"
 
 	"go.opentelemetry.io/collector/config/confignet"
@@ -41,9 +42,13 @@ type sender struct {
 	logger    *zap.Logger
 	mu        sync.Mutex
 	conn      net.Conn
+	// cert is the leaf of the client certificate conn was established with.
+	cert []byte
+	// buffer holds the messages that could not be sent, it is nil when disabled.
+	buffer *messageBuffer
 }
 
-func connect(ctx context.Context, logger *zap.Logger, cfg *Config, tlsConfig *tls.Config) (*sender, error) {
+func newSender(logger *zap.Logger, cfg *Config, tlsConfig *tls.Config) *sender {
 	s := &sender{
 		logger:    logger,
 		network:   cfg.Network,
@@ -51,21 +56,19 @@ func connect(ctx context.Context, logger *zap.Logger, cfg *Config, tlsConfig *tl
 		protocol:  cfg.Protocol,
 		tlsConfig: tlsConfig,
 	}
-
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	err := s.dial(ctx)
-	if err != nil {
-		return nil, err
+	if cfg.ReconnectBuffer.Enabled {
+		s.buffer = &messageBuffer{maxSize: cfg.ReconnectBuffer.MaxSize}
 	}
-	return s, err
+	return s
 }
 
 func (s *sender) close() error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	if s.buffer != nil && s.buffer.len() > 0 {
+		s.logger.Warn("Dropping buffered syslog messages", zap.Int("messages", s.buffer.len()))
+	}
 	if s.conn != nil {
 		err := s.conn.Close()
 		s.conn = nil
@@ -81,6 +84,7 @@ func (s *sender) dial(ctx context.Context) error {
 	}
 	var err error
 	if s.tlsConfig != nil && s.network == string(confignet.TransportTypeTCP) {
+		s.cert = clientCertificate(s.tlsConfig)
 		dialer := tls.Dialer{Config: s.tlsConfig}
 		s.conn, err = dialer.DialContext(ctx, s.network, s.addr)
 	} else {
@@ -90,12 +94,55 @@ func (s *sender) dial(ctx context.Context) error {
 	return err
 }
 
-func (s *sender) Write(ctx context.Context, msgStr string) error {
+// Write sends msg, which is already framed. The messages of the buffer are sent first.
+// When the syslog server cannot be reached, msg is added to the buffer if there is room
+// for it, otherwise the error is returned.
+func (s *sender) Write(ctx context.Context, msg string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	err := s.flush(ctx)
+	if err == nil {
+		err = s.writeOrReconnect(ctx, msg)
+	}
+	if err != nil && s.buffer != nil && s.buffer.add(msg) {
+		s.logger.Debug("Buffered syslog message while the server is unreachable", zap.Error(err))
+		return nil
+	}
+	return err
+}
+
+// Flush sends the messages of the buffer.
+func (s *sender) Flush(ctx context.Context) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	return s.flush(ctx)
+}
+
+func (s *sender) flush(ctx context.Context) error {
+	if s.buffer == nil {
+		return nil
+	}
+	for s.buffer.len() > 0 {
+		if err := s.writeOrReconnect(ctx, s.buffer.peek()); err != nil {
+			return err
+		}
+		s.buffer.pop()
+	}
+	return nil
+}
 
+// writeOrReconnect writes msg to the current connection, and reconnects to write it
+// again when it fails. Connections established with a client certificate that has been
+// reloaded since are replaced.
+func (s *sender) writeOrReconnect(ctx context.Context, msg string) error {
+	if s.conn != nil && s.cert != nil && !bytes.Equal(clientCertificate(s.tlsConfig), s.cert) {
+		s.logger.Info("Reconnecting to use the rotated TLS client certificate")
+		s.conn.Close()
+		s.conn = nil
+	}
 	if s.conn != nil {
-		if err := s.write(msgStr); err == nil {
+		if err := s.write(msg); err == nil {
 			return nil
 		}
 	}
@@ -103,13 +150,41 @@ func (s *sender) Write(ctx context.Context, msgStr string) error {
 		return err
 	}
 
-	return s.write(msgStr)
+	return s.write(msg)
 }
+
 func (s *sender) write(msg string) error {
-	// check if logs contains new line character at the end, if not add it
-	if !strings.HasSuffix(msg, "\n") {
-		msg = fmt.Sprintf("%s%s", msg, "\n")
-	}
-	_, err := fmt.Fprint(s.conn, msg)
+	_, err := io.WriteString(s.conn, msg)
 	return err
 }
+
+// messageBuffer is a FIFO of messages bounded by their total size.
+type messageBuffer struct {
+	maxSize  int
+	size     int
+	messages []string
+}
+
+// add appends msg and reports whether there was room for it.
+func (b *messageBuffer) add(msg string) bool {
+	if b.size+len(msg) > b.maxSize {
+		return false
+	}
+	b.messages = append(b.messages, msg)
+	b.size += len(msg)
+	return true
+}
+
+func (b *messageBuffer) len() int {
+	return len(b.messages)
+}
+
+func (b *messageBuffer) peek() string {
+	return b.messages[0]
+}
+
+func (b *messageBuffer) pop() {
+	b.size -= len(b.messages[0])
+	b.messages[0] = ""
+	b.messages = b.messages[1:]
+}
@@ -0,0 +1,23 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package syslogexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/syslogexporter"
+
+import (
+	"crypto/tls"
+)
+
+// clientCertificate returns the leaf of the client certificate of tlsConfig, or nil when
+// it has none. When `tls::reload_interval` is set, configtls loads the certificate again
+// once the interval has elapsed, so that the returned leaf changes when the certificate
+// is rotated.
+func clientCertificate(tlsConfig *tls.Config) []byte {
+	if tlsConfig == nil || tlsConfig.GetClientCertificate == nil {
+		return nil
+	}
+	cert, err := tlsConfig.GetClientCertificate(&tls.CertificateRequestInfo{})
+	if err != nil || cert == nil || len(cert.Certificate) == 0 {
+		return nil
+	}
+	return cert.Certificate[0]
+}
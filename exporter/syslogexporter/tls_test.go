@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package syslogexporter
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config/configtls"
+)
+
+// writeCertificate writes a self-signed certificate for commonName and its key.
+func writeCertificate(t *testing.T, certFile, keyFile, commonName string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+}
+
+func commonName(t *testing.T, tlsConfig *tls.Config) string {
+	cert := clientCertificate(tlsConfig)
+	require.NotNil(t, cert)
+	leaf, err := x509.ParseCertificate(cert)
+	require.NoError(t, err)
+	return leaf.Subject.CommonName
+}
+
+func TestClientCertificate(t *testing.T) {
+	assert.Nil(t, clientCertificate(nil))
+	assert.Nil(t, clientCertificate(&tls.Config{}))
+
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "client.crt")
+	keyFile := filepath.Join(dir, "client.key")
+	writeCertificate(t, certFile, keyFile, "first")
+
+	cfg := configtls.NewDefaultClientConfig()
+	cfg.CertFile = certFile
+	cfg.KeyFile = keyFile
+	cfg.ReloadInterval = 10 * time.Millisecond
+	tlsConfig, err := cfg.LoadTLSConfig(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "first", commonName(t, tlsConfig))
+
+	// The rotated certificate is returned once configtls reloaded it.
+	newCertFile := filepath.Join(dir, "new.crt")
+	newKeyFile := filepath.Join(dir, "new.key")
+	writeCertificate(t, newCertFile, newKeyFile, "second")
+	require.NoError(t, os.Rename(newCertFile, certFile))
+	require.NoError(t, os.Rename(newKeyFile, keyFile))
+	assert.Eventually(t, func() bool {
+		return commonName(t, tlsConfig) == "second"
+	}, time.Second, 10*time.Millisecond)
+}
@@ -5,6 +5,7 @@ package healthcheckv2extension // import "github.com/open-telemetry/opentelemetr
 
 import (
 	"context"
+	"sync"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componentstatus"
@@ -32,8 +33,16 @@ type healthCheckExtension struct {
 	eventCh       chan *eventSourcePair
 	readyCh       chan struct{}
 	host          component.Host
+
+	// subscribersLock guards subscribers, which are called with every status event the extension receives
+	subscribersLock sync.RWMutex
+	subscribers     map[int]StatusFunc
+	nextSubscriber  int
 }
 
+// StatusFunc is called with the source and the event of a component status change.
+type StatusFunc = func(*componentstatus.InstanceID, *componentstatus.Event)
+
 var _ component.Component = (*healthCheckExtension)(nil)
 var _ extensioncapabilities.ConfigWatcher = (*healthCheckExtension)(nil)
 var _ extensioncapabilities.PipelineWatcher = (*healthCheckExtension)(nil)
@@ -82,6 +91,7 @@ func newExtension(
 		aggregator:    aggregator,
 		eventCh:       make(chan *eventSourcePair),
 		readyCh:       make(chan struct{}),
+		subscribers:   make(map[int]StatusFunc),
 	}
 
 	// Start processing events in the background so that our status watcher doesn't
@@ -127,6 +137,12 @@ func (hc *healthCheckExtension) ComponentStatusChanged(
 	source *componentstatus.InstanceID,
 	event *componentstatus.Event,
 ) {
+	hc.subscribersLock.RLock()
+	for _, fn := range hc.subscribers {
+		fn(source, event)
+	}
+	hc.subscribersLock.RUnlock()
+
 	// There can be late arriving events after shutdown. We need to close
 	// the event channel so that this function doesn't block and we release all
 	// goroutines, but attempting to write to a closed channel will panic; log
@@ -143,6 +159,22 @@ func (hc *healthCheckExtension) ComponentStatusChanged(
 	hc.eventCh <- &eventSourcePair{source: source, event: event}
 }
 
+// SubscribeStatus registers fn to be called with every component status event received by the extension,
+// letting other components, e.g. the failover connector, react to the health of the pipelines. fn is called
+// synchronously and must not block. The returned function removes the subscription.
+func (hc *healthCheckExtension) SubscribeStatus(fn StatusFunc) func() {
+	hc.subscribersLock.Lock()
+	defer hc.subscribersLock.Unlock()
+	id := hc.nextSubscriber
+	hc.nextSubscriber++
+	hc.subscribers[id] = fn
+	return func() {
+		hc.subscribersLock.Lock()
+		defer hc.subscribersLock.Unlock()
+		delete(hc.subscribers, id)
+	}
+}
+
 // NotifyConfig implements the extensioncapabilities.ConfigWatcher interface.
 func (hc *healthCheckExtension) NotifyConfig(ctx context.Context, conf *confmap.Conf) error {
 	var err error
@@ -91,6 +91,29 @@ func TestComponentStatus(t *testing.T) {
 	assert.Equal(t, componentstatus.StatusStopping, st.Status())
 }
 
+func TestSubscribeStatus(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.HTTPConfig.Endpoint = testutil.GetAvailableLocalAddress(t)
+	cfg.GRPCConfig.NetAddr.Endpoint = testutil.GetAvailableLocalAddress(t)
+	cfg.UseV2 = true
+	ext := newExtension(context.Background(), *cfg, extensiontest.NewNopSettings())
+	require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+
+	traces := testhelpers.NewPipelineMetadata("traces")
+	var received []componentstatus.Status
+	unsubscribe := ext.SubscribeStatus(func(_ *componentstatus.InstanceID, event *componentstatus.Event) {
+		received = append(received, event.Status())
+	})
+
+	ext.ComponentStatusChanged(traces.ExporterID, componentstatus.NewEvent(componentstatus.StatusStarting))
+	ext.ComponentStatusChanged(traces.ExporterID, componentstatus.NewRecoverableErrorEvent(assert.AnError))
+	unsubscribe()
+	ext.ComponentStatusChanged(traces.ExporterID, componentstatus.NewEvent(componentstatus.StatusOK))
+
+	assert.Equal(t, []componentstatus.Status{componentstatus.StatusStarting, componentstatus.StatusRecoverableError}, received)
+	require.NoError(t, ext.Shutdown(context.Background()))
+}
+
 func TestNotifyConfig(t *testing.T) {
 	confMap, err := confmaptest.LoadConf(
 		filepath.Join("internal", "http", "testdata", "config.yaml"),
@@ -0,0 +1,32 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package jaegerremotestorageextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/jaegerremotestorageextension"
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configgrpc"
+)
+
+var errInvalidMaxTraces = errors.New("max_traces must be positive")
+
+// Config defines the configuration of the Jaeger remote storage extension.
+type Config struct {
+	configgrpc.ServerConfig `mapstructure:",squash"`
+
+	// MaxTraces is the number of traces held by the in-memory store. The oldest
+	// traces are evicted first.
+	MaxTraces int `mapstructure:"max_traces"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the extension configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.MaxTraces <= 0 {
+		return errInvalidMaxTraces
+	}
+	return nil
+}
@@ -0,0 +1,9 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package jaegerremotestorageextension implements an extension that serves
+// the traces held by the collector through the Jaeger remote storage gRPC API,
+// so that Jaeger Query can read them.
+package jaegerremotestorageextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/jaegerremotestorageextension"
@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package jaegerremotestorageextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/jaegerremotestorageextension"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/jaegertracing/jaeger/proto-gen/storage_v1"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// TracesStore is implemented by the extension. Pipeline components look the extension
+// up in the host by ID and call ConsumeTraces to add traces to its in-memory store.
+type TracesStore interface {
+	extension.Extension
+	ConsumeTraces(ctx context.Context, td ptrace.Traces) error
+}
+
+var _ TracesStore = (*jrsExtension)(nil)
+
+type jrsExtension struct {
+	config    *Config
+	telemetry component.TelemetrySettings
+
+	store  *memoryStore
+	reader Reader
+
+	server     *grpc.Server
+	shutdownWG sync.WaitGroup
+}
+
+func newExtension(cfg *Config, telemetry component.TelemetrySettings) *jrsExtension {
+	store := newMemoryStore(cfg.MaxTraces)
+	return &jrsExtension{
+		config:    cfg,
+		telemetry: telemetry,
+		store:     store,
+		reader:    store,
+	}
+}
+
+func (e *jrsExtension) Start(ctx context.Context, host component.Host) error {
+	server, err := e.config.ToServer(ctx, host, e.telemetry)
+	if err != nil {
+		return err
+	}
+
+	storage := newStorageServer(e.reader)
+	storage_v1.RegisterSpanReaderPluginServer(server, storage)
+	storage_v1.RegisterDependenciesReaderPluginServer(server, storage)
+	storage_v1.RegisterPluginCapabilitiesServer(server, storage)
+
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("jaeger.storage.v1.SpanReaderPlugin", grpc_health_v1.HealthCheckResponse_SERVING)
+	grpc_health_v1.RegisterHealthServer(server, healthServer)
+
+	listener, err := e.config.NetAddr.Listen(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to listen on gRPC port: %w", err)
+	}
+	e.server = server
+
+	e.shutdownWG.Add(1)
+	go func() {
+		defer e.shutdownWG.Done()
+		if errGrpc := e.server.Serve(listener); errGrpc != nil && !errors.Is(errGrpc, grpc.ErrServerStopped) {
+			e.telemetry.Logger.Error("gRPC server failed", zap.Error(errGrpc))
+		}
+	}()
+	return nil
+}
+
+// Shutdown tries to terminate connections gracefully as long as the passed context is valid.
+func (e *jrsExtension) Shutdown(ctx context.Context) error {
+	if e.server == nil {
+		return nil
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		e.server.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-ctx.Done():
+		e.server.Stop()
+		<-stopped
+	case <-stopped:
+	}
+	e.shutdownWG.Wait()
+	return nil
+}
+
+// ConsumeTraces adds the spans of td to the in-memory store.
+func (e *jrsExtension) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	return e.store.ConsumeTraces(ctx, td)
+}
@@ -0,0 +1,47 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package jaegerremotestorageextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/jaegerremotestorageextension"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configgrpc"
+	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/extension"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/jaegerremotestorageextension/internal/metadata"
+)
+
+const (
+	// defaultEndpoint uses the port Jaeger assigns to remote storage.
+	defaultEndpoint  = "localhost:17271"
+	defaultMaxTraces = 10000
+)
+
+// NewFactory creates a factory for the Jaeger remote storage extension.
+func NewFactory() extension.Factory {
+	return extension.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		createExtension,
+		metadata.ExtensionStability,
+	)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		ServerConfig: configgrpc.ServerConfig{
+			NetAddr: confignet.AddrConfig{
+				Endpoint:  defaultEndpoint,
+				Transport: confignet.TransportTypeTCP,
+			},
+		},
+		MaxTraces: defaultMaxTraces,
+	}
+}
+
+func createExtension(_ context.Context, set extension.Settings, cfg component.Config) (extension.Extension, error) {
+	return newExtension(cfg.(*Config), set.TelemetrySettings), nil
+}
@@ -0,0 +1,174 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package jaegerremotestorageextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/jaegerremotestorageextension"
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jaegertracing/jaeger/model"
+	// The storage API uses gogo custom types, which the default gRPC codec cannot marshal.
+	_ "github.com/jaegertracing/jaeger/pkg/gogocodec"
+	"github.com/jaegertracing/jaeger/proto-gen/storage_v1"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/idutils"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/jaeger"
+)
+
+// maxChunkSize is the maximum number of spans sent in a single response message, it
+// matches the chunk size of the Jaeger gRPC storage plugins.
+const maxChunkSize = 1000
+
+// storageServer serves a Reader through the Jaeger remote storage gRPC API.
+type storageServer struct {
+	reader Reader
+}
+
+var (
+	_ storage_v1.SpanReaderPluginServer         = (*storageServer)(nil)
+	_ storage_v1.DependenciesReaderPluginServer = (*storageServer)(nil)
+	_ storage_v1.PluginCapabilitiesServer       = (*storageServer)(nil)
+)
+
+func newStorageServer(reader Reader) *storageServer {
+	return &storageServer{reader: reader}
+}
+
+func (s *storageServer) GetTrace(req *storage_v1.GetTraceRequest, stream storage_v1.SpanReaderPlugin_GetTraceServer) error {
+	td, err := s.reader.GetTrace(stream.Context(), traceIDFromJaeger(req.TraceID))
+	if errors.Is(err, ErrTraceNotFound) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	return sendSpans(td, stream.Send)
+}
+
+func (s *storageServer) GetServices(ctx context.Context, _ *storage_v1.GetServicesRequest) (*storage_v1.GetServicesResponse, error) {
+	services, err := s.reader.GetServices(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &storage_v1.GetServicesResponse{Services: services}, nil
+}
+
+func (s *storageServer) GetOperations(ctx context.Context, req *storage_v1.GetOperationsRequest) (*storage_v1.GetOperationsResponse, error) {
+	operations, err := s.reader.GetOperations(ctx, OperationQuery{
+		ServiceName: req.Service,
+		SpanKind:    req.SpanKind,
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	resp := &storage_v1.GetOperationsResponse{}
+	for _, op := range operations {
+		resp.OperationNames = append(resp.OperationNames, op.Name)
+		resp.Operations = append(resp.Operations, &storage_v1.Operation{
+			Name:     op.Name,
+			SpanKind: op.SpanKind,
+		})
+	}
+	return resp, nil
+}
+
+func (s *storageServer) FindTraces(req *storage_v1.FindTracesRequest, stream storage_v1.SpanReaderPlugin_FindTracesServer) error {
+	if req.Query == nil {
+		return status.Error(codes.InvalidArgument, "missing query")
+	}
+	traceIDs, err := s.reader.FindTraceIDs(stream.Context(), traceQueryFromJaeger(req.Query))
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	for _, traceID := range traceIDs {
+		td, err := s.reader.GetTrace(stream.Context(), traceID)
+		if errors.Is(err, ErrTraceNotFound) {
+			// Evicted since it was found.
+			continue
+		}
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+		if err := sendSpans(td, stream.Send); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *storageServer) FindTraceIDs(ctx context.Context, req *storage_v1.FindTraceIDsRequest) (*storage_v1.FindTraceIDsResponse, error) {
+	if req.Query == nil {
+		return nil, status.Error(codes.InvalidArgument, "missing query")
+	}
+	traceIDs, err := s.reader.FindTraceIDs(ctx, traceQueryFromJaeger(req.Query))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	resp := &storage_v1.FindTraceIDsResponse{
+		TraceIDs: make([]model.TraceID, len(traceIDs)),
+	}
+	for i, traceID := range traceIDs {
+		resp.TraceIDs[i] = traceIDToJaeger(traceID)
+	}
+	return resp, nil
+}
+
+// GetDependencies returns no dependencies, they are not computed from the stored traces.
+func (s *storageServer) GetDependencies(context.Context, *storage_v1.GetDependenciesRequest) (*storage_v1.GetDependenciesResponse, error) {
+	return &storage_v1.GetDependenciesResponse{}, nil
+}
+
+// Capabilities reports that only the primary span reader is implemented.
+func (s *storageServer) Capabilities(context.Context, *storage_v1.CapabilitiesRequest) (*storage_v1.CapabilitiesResponse, error) {
+	return &storage_v1.CapabilitiesResponse{}, nil
+}
+
+// sendSpans converts td to the Jaeger model and sends its spans in chunks.
+func sendSpans(td ptrace.Traces, send func(*storage_v1.SpansResponseChunk) error) error {
+	chunk := make([]model.Span, 0, min(td.SpanCount(), maxChunkSize))
+	for _, batch := range jaeger.ProtoFromTraces(td) {
+		for _, span := range batch.Spans {
+			if span.Process == nil {
+				span.Process = batch.Process
+			}
+			chunk = append(chunk, *span)
+			if len(chunk) == maxChunkSize {
+				if err := send(&storage_v1.SpansResponseChunk{Spans: chunk}); err != nil {
+					return err
+				}
+				chunk = make([]model.Span, 0, maxChunkSize)
+			}
+		}
+	}
+	if len(chunk) == 0 {
+		return nil
+	}
+	return send(&storage_v1.SpansResponseChunk{Spans: chunk})
+}
+
+func traceQueryFromJaeger(query *storage_v1.TraceQueryParameters) TraceQuery {
+	return TraceQuery{
+		ServiceName:   query.ServiceName,
+		OperationName: query.OperationName,
+		Tags:          query.Tags,
+		StartTimeMin:  query.StartTimeMin,
+		StartTimeMax:  query.StartTimeMax,
+		DurationMin:   query.DurationMin,
+		DurationMax:   query.DurationMax,
+		NumTraces:     int(query.NumTraces),
+	}
+}
+
+func traceIDFromJaeger(traceID model.TraceID) pcommon.TraceID {
+	return idutils.UInt64ToTraceID(traceID.High, traceID.Low)
+}
+
+func traceIDToJaeger(traceID pcommon.TraceID) model.TraceID {
+	high, low := idutils.TraceIDToUInt64Pair(traceID)
+	return model.TraceID{High: high, Low: low}
+}
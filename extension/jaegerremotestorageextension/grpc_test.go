@@ -0,0 +1,152 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package jaegerremotestorageextension
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/jaegertracing/jaeger/model"
+	"github.com/jaegertracing/jaeger/proto-gen/storage_v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/testutil"
+)
+
+func startExtension(t *testing.T, maxTraces int) (*jrsExtension, *grpc.ClientConn) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.NetAddr.Endpoint = testutil.GetAvailableLocalAddress(t)
+	cfg.MaxTraces = maxTraces
+	ext := newExtension(cfg, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { assert.NoError(t, ext.Shutdown(context.Background())) })
+
+	conn, err := grpc.NewClient(cfg.NetAddr.Endpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, conn.Close()) })
+	return ext, conn
+}
+
+// receiveSpans reads all the chunks of a span stream.
+func receiveSpans(t *testing.T, recv func() (*storage_v1.SpansResponseChunk, error)) ([]model.Span, error) {
+	var spans []model.Span
+	for {
+		chunk, err := recv()
+		if errors.Is(err, io.EOF) {
+			return spans, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		assert.LessOrEqual(t, len(chunk.Spans), maxChunkSize)
+		spans = append(spans, chunk.Spans...)
+	}
+}
+
+func TestStorageServer(t *testing.T) {
+	ctx := context.Background()
+	ext, conn := startExtension(t, 10)
+	require.NoError(t, ext.ConsumeTraces(ctx, newTestTraces(
+		testSpan{service: "frontend", traceID: 1, name: "GET /", kind: ptrace.SpanKindServer, duration: time.Second},
+		testSpan{service: "backend", traceID: 1, name: "query", kind: ptrace.SpanKindClient, duration: 100 * time.Millisecond, attrs: map[string]string{"db": "users"}},
+		testSpan{service: "frontend", traceID: 2, name: "GET /health", kind: ptrace.SpanKindServer, start: time.Minute, duration: time.Millisecond},
+	)))
+	client := storage_v1.NewSpanReaderPluginClient(conn)
+
+	t.Run("GetTrace", func(t *testing.T) {
+		stream, err := client.GetTrace(ctx, &storage_v1.GetTraceRequest{TraceID: model.NewTraceID(0, 1)})
+		require.NoError(t, err)
+		spans, err := receiveSpans(t, stream.Recv)
+		require.NoError(t, err)
+		require.Len(t, spans, 2)
+		services := map[string]string{}
+		for _, span := range spans {
+			assert.Equal(t, model.NewTraceID(0, 1), span.TraceID)
+			require.NotNil(t, span.Process)
+			services[span.OperationName] = span.Process.ServiceName
+		}
+		assert.Equal(t, map[string]string{"GET /": "frontend", "query": "backend"}, services)
+	})
+
+	t.Run("GetTrace not found", func(t *testing.T) {
+		stream, err := client.GetTrace(ctx, &storage_v1.GetTraceRequest{TraceID: model.NewTraceID(0, 3)})
+		require.NoError(t, err)
+		_, err = receiveSpans(t, stream.Recv)
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("GetServices", func(t *testing.T) {
+		resp, err := client.GetServices(ctx, &storage_v1.GetServicesRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"backend", "frontend"}, resp.Services)
+	})
+
+	t.Run("GetOperations", func(t *testing.T) {
+		resp, err := client.GetOperations(ctx, &storage_v1.GetOperationsRequest{Service: "frontend", SpanKind: "server"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"GET /", "GET /health"}, resp.OperationNames)
+		assert.Equal(t, []*storage_v1.Operation{
+			{Name: "GET /", SpanKind: "server"},
+			{Name: "GET /health", SpanKind: "server"},
+		}, resp.Operations)
+	})
+
+	t.Run("FindTraces", func(t *testing.T) {
+		stream, err := client.FindTraces(ctx, &storage_v1.FindTracesRequest{Query: &storage_v1.TraceQueryParameters{
+			ServiceName: "backend",
+			Tags:        map[string]string{"db": "users"},
+		}})
+		require.NoError(t, err)
+		spans, err := receiveSpans(t, stream.Recv)
+		require.NoError(t, err)
+		assert.Len(t, spans, 2)
+	})
+
+	t.Run("FindTraces without query", func(t *testing.T) {
+		stream, err := client.FindTraces(ctx, &storage_v1.FindTracesRequest{})
+		require.NoError(t, err)
+		_, err = receiveSpans(t, stream.Recv)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("FindTraceIDs", func(t *testing.T) {
+		resp, err := client.FindTraceIDs(ctx, &storage_v1.FindTraceIDsRequest{Query: &storage_v1.TraceQueryParameters{
+			ServiceName: "frontend",
+		}})
+		require.NoError(t, err)
+		assert.Equal(t, []model.TraceID{model.NewTraceID(0, 2), model.NewTraceID(0, 1)}, resp.TraceIDs)
+	})
+
+	t.Run("Capabilities", func(t *testing.T) {
+		resp, err := storage_v1.NewPluginCapabilitiesClient(conn).Capabilities(ctx, &storage_v1.CapabilitiesRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, &storage_v1.CapabilitiesResponse{}, resp)
+	})
+}
+
+func TestStorageServerChunks(t *testing.T) {
+	ctx := context.Background()
+	ext, conn := startExtension(t, 1)
+	spans := make([]testSpan, 2*maxChunkSize+1)
+	for i := range spans {
+		spans[i] = testSpan{service: "svc", traceID: 1, name: "op"}
+	}
+	require.NoError(t, ext.ConsumeTraces(ctx, newTestTraces(spans...)))
+
+	stream, err := storage_v1.NewSpanReaderPluginClient(conn).GetTrace(ctx, &storage_v1.GetTraceRequest{TraceID: model.NewTraceID(0, 1)})
+	require.NoError(t, err)
+	got, err := receiveSpans(t, stream.Recv)
+	require.NoError(t, err)
+	assert.Len(t, got, len(spans))
+}
@@ -0,0 +1,305 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package jaegerremotestorageextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/jaegerremotestorageextension"
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/tracetranslator"
+)
+
+// memoryStore is a Reader holding the spans of the last maxTraces traces. Traces are
+// evicted in the order their first span was received.
+type memoryStore struct {
+	maxTraces int
+
+	mu     sync.RWMutex
+	traces map[pcommon.TraceID]*memoryTrace
+	// ring holds the IDs of the stored traces in the order they were added, next is the
+	// slot of the next trace.
+	ring []pcommon.TraceID
+	next int
+	// operations counts the stored traces reporting each operation of each service.
+	operations map[string]map[Operation]int
+}
+
+type memoryTrace struct {
+	traces ptrace.Traces
+	// start is the start time of the earliest span.
+	start time.Time
+	// operations are the operations the trace reported, per service.
+	operations map[serviceOperation]struct{}
+}
+
+type spanDest struct {
+	trace *memoryTrace
+	spans ptrace.SpanSlice
+}
+
+type serviceOperation struct {
+	service   string
+	operation Operation
+}
+
+var _ Reader = (*memoryStore)(nil)
+
+func newMemoryStore(maxTraces int) *memoryStore {
+	return &memoryStore{
+		maxTraces:  maxTraces,
+		traces:     map[pcommon.TraceID]*memoryTrace{},
+		ring:       make([]pcommon.TraceID, 0, maxTraces),
+		operations: map[string]map[Operation]int{},
+	}
+}
+
+// ConsumeTraces stores a copy of the spans of td, grouped by trace.
+func (s *memoryStore) ConsumeTraces(_ context.Context, td ptrace.Traces) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		service := serviceName(rs.Resource())
+		for j := 0; j < rs.ScopeSpans().Len(); j++ {
+			ss := rs.ScopeSpans().At(j)
+			// The spans of a scope are appended to a single copy of the resource and
+			// scope of each of the traces they belong to.
+			dests := map[pcommon.TraceID]spanDest{}
+			for k := 0; k < ss.Spans().Len(); k++ {
+				span := ss.Spans().At(k)
+				t := s.getOrAdd(span.TraceID())
+				dest, ok := dests[span.TraceID()]
+				// The trace may have been evicted and added again by the spans of this scope.
+				if !ok || dest.trace != t {
+					drs := t.traces.ResourceSpans().AppendEmpty()
+					rs.Resource().CopyTo(drs.Resource())
+					drs.SetSchemaUrl(rs.SchemaUrl())
+					dss := drs.ScopeSpans().AppendEmpty()
+					ss.Scope().CopyTo(dss.Scope())
+					dss.SetSchemaUrl(ss.SchemaUrl())
+					dest = spanDest{trace: t, spans: dss.Spans()}
+					dests[span.TraceID()] = dest
+				}
+				span.CopyTo(dest.spans.AppendEmpty())
+				s.index(t, service, span)
+			}
+		}
+	}
+	return nil
+}
+
+func (s *memoryStore) getOrAdd(traceID pcommon.TraceID) *memoryTrace {
+	if t, ok := s.traces[traceID]; ok {
+		return t
+	}
+	if len(s.ring) < s.maxTraces {
+		s.ring = append(s.ring, traceID)
+	} else {
+		s.evict(s.ring[s.next])
+		s.ring[s.next] = traceID
+	}
+	s.next = (s.next + 1) % s.maxTraces
+
+	t := &memoryTrace{
+		traces:     ptrace.NewTraces(),
+		operations: map[serviceOperation]struct{}{},
+	}
+	s.traces[traceID] = t
+	return t
+}
+
+func (s *memoryStore) evict(traceID pcommon.TraceID) {
+	t := s.traces[traceID]
+	delete(s.traces, traceID)
+	for so := range t.operations {
+		ops := s.operations[so.service]
+		ops[so.operation]--
+		if ops[so.operation] == 0 {
+			delete(ops, so.operation)
+		}
+		if len(ops) == 0 {
+			delete(s.operations, so.service)
+		}
+	}
+}
+
+func (s *memoryStore) index(t *memoryTrace, service string, span ptrace.Span) {
+	start := span.StartTimestamp().AsTime()
+	if t.start.IsZero() || start.Before(t.start) {
+		t.start = start
+	}
+
+	so := serviceOperation{
+		service: service,
+		operation: Operation{
+			Name:     span.Name(),
+			SpanKind: spanKind(span.Kind()),
+		},
+	}
+	if _, ok := t.operations[so]; ok {
+		return
+	}
+	t.operations[so] = struct{}{}
+	ops, ok := s.operations[service]
+	if !ok {
+		ops = map[Operation]int{}
+		s.operations[service] = ops
+	}
+	ops[so.operation]++
+}
+
+func (s *memoryStore) GetTrace(_ context.Context, traceID pcommon.TraceID) (ptrace.Traces, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	t, ok := s.traces[traceID]
+	if !ok {
+		return ptrace.Traces{}, ErrTraceNotFound
+	}
+	// The stored traces keep growing, callers get a copy.
+	td := ptrace.NewTraces()
+	t.traces.CopyTo(td)
+	return td, nil
+}
+
+func (s *memoryStore) GetServices(_ context.Context) ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	services := make([]string, 0, len(s.operations))
+	for service := range s.operations {
+		services = append(services, service)
+	}
+	sort.Strings(services)
+	return services, nil
+}
+
+func (s *memoryStore) GetOperations(_ context.Context, query OperationQuery) ([]Operation, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var operations []Operation
+	for op := range s.operations[query.ServiceName] {
+		if query.SpanKind == "" || query.SpanKind == op.SpanKind {
+			operations = append(operations, op)
+		}
+	}
+	slices.SortFunc(operations, func(a, b Operation) int {
+		if c := strings.Compare(a.Name, b.Name); c != 0 {
+			return c
+		}
+		return strings.Compare(a.SpanKind, b.SpanKind)
+	})
+	return operations, nil
+}
+
+func (s *memoryStore) FindTraceIDs(_ context.Context, query TraceQuery) ([]pcommon.TraceID, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	type match struct {
+		traceID pcommon.TraceID
+		start   time.Time
+	}
+	var matches []match
+	for traceID, t := range s.traces {
+		if matchTrace(t, query) {
+			matches = append(matches, match{traceID: traceID, start: t.start})
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].start.After(matches[j].start)
+	})
+	if query.NumTraces > 0 && len(matches) > query.NumTraces {
+		matches = matches[:query.NumTraces]
+	}
+
+	traceIDs := make([]pcommon.TraceID, len(matches))
+	for i, m := range matches {
+		traceIDs[i] = m.traceID
+	}
+	return traceIDs, nil
+}
+
+func matchTrace(t *memoryTrace, query TraceQuery) bool {
+	if !query.StartTimeMin.IsZero() && t.start.Before(query.StartTimeMin) {
+		return false
+	}
+	if !query.StartTimeMax.IsZero() && t.start.After(query.StartTimeMax) {
+		return false
+	}
+	rss := t.traces.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		if query.ServiceName != "" && serviceName(rs.Resource()) != query.ServiceName {
+			continue
+		}
+		for j := 0; j < rs.ScopeSpans().Len(); j++ {
+			spans := rs.ScopeSpans().At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				if matchSpan(rs.Resource(), spans.At(k), query) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func matchSpan(resource pcommon.Resource, span ptrace.Span, query TraceQuery) bool {
+	if query.OperationName != "" && span.Name() != query.OperationName {
+		return false
+	}
+	duration := span.EndTimestamp().AsTime().Sub(span.StartTimestamp().AsTime())
+	if query.DurationMin != 0 && duration < query.DurationMin {
+		return false
+	}
+	if query.DurationMax != 0 && duration > query.DurationMax {
+		return false
+	}
+	for key, value := range query.Tags {
+		v, ok := span.Attributes().Get(key)
+		if !ok {
+			v, ok = resource.Attributes().Get(key)
+		}
+		if !ok || v.AsString() != value {
+			return false
+		}
+	}
+	return true
+}
+
+func serviceName(resource pcommon.Resource) string {
+	if service, ok := resource.Attributes().Get(conventions.AttributeServiceName); ok {
+		return service.AsString()
+	}
+	return tracetranslator.ResourceNoServiceName
+}
+
+// spanKind returns the Jaeger name of a span kind.
+func spanKind(kind ptrace.SpanKind) string {
+	switch kind {
+	case ptrace.SpanKindClient:
+		return string(tracetranslator.OpenTracingSpanKindClient)
+	case ptrace.SpanKindServer:
+		return string(tracetranslator.OpenTracingSpanKindServer)
+	case ptrace.SpanKindProducer:
+		return string(tracetranslator.OpenTracingSpanKindProducer)
+	case ptrace.SpanKindConsumer:
+		return string(tracetranslator.OpenTracingSpanKindConsumer)
+	case ptrace.SpanKindInternal:
+		return string(tracetranslator.OpenTracingSpanKindInternal)
+	}
+	return string(tracetranslator.OpenTracingSpanKindUnspecified)
+}
@@ -0,0 +1,154 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package jaegerremotestorageextension
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+var baseTime = time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+
+type testSpan struct {
+	service  string
+	traceID  byte
+	name     string
+	kind     ptrace.SpanKind
+	start    time.Duration
+	duration time.Duration
+	attrs    map[string]string
+}
+
+// newTestTraces returns the given spans, one resource per span.
+func newTestTraces(spans ...testSpan) ptrace.Traces {
+	td := ptrace.NewTraces()
+	for i, s := range spans {
+		rs := td.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().PutStr("service.name", s.service)
+		span := rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+		span.SetTraceID(pcommon.TraceID{15: s.traceID})
+		span.SetSpanID(pcommon.SpanID{7: byte(i + 1)})
+		span.SetName(s.name)
+		span.SetKind(s.kind)
+		span.SetStartTimestamp(pcommon.NewTimestampFromTime(baseTime.Add(s.start)))
+		span.SetEndTimestamp(pcommon.NewTimestampFromTime(baseTime.Add(s.start + s.duration)))
+		for k, v := range s.attrs {
+			span.Attributes().PutStr(k, v)
+		}
+	}
+	return td
+}
+
+func TestMemoryStore(t *testing.T) {
+	ctx := context.Background()
+	s := newMemoryStore(10)
+	require.NoError(t, s.ConsumeTraces(ctx, newTestTraces(
+		testSpan{service: "frontend", traceID: 1, name: "GET /", kind: ptrace.SpanKindServer, duration: time.Second},
+		testSpan{service: "backend", traceID: 1, name: "query", kind: ptrace.SpanKindClient, duration: 100 * time.Millisecond, attrs: map[string]string{"db": "users"}},
+		testSpan{service: "frontend", traceID: 2, name: "GET /health", kind: ptrace.SpanKindServer, start: time.Minute, duration: time.Millisecond},
+	)))
+	// Spans of a stored trace are added to it.
+	require.NoError(t, s.ConsumeTraces(ctx, newTestTraces(
+		testSpan{service: "backend", traceID: 1, name: "query", kind: ptrace.SpanKindClient, duration: 10 * time.Millisecond},
+	)))
+
+	td, err := s.GetTrace(ctx, pcommon.TraceID{15: 1})
+	require.NoError(t, err)
+	assert.Equal(t, 3, td.SpanCount())
+	_, err = s.GetTrace(ctx, pcommon.TraceID{15: 3})
+	assert.ErrorIs(t, err, ErrTraceNotFound)
+
+	services, err := s.GetServices(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"backend", "frontend"}, services)
+
+	ops, err := s.GetOperations(ctx, OperationQuery{ServiceName: "frontend"})
+	require.NoError(t, err)
+	assert.Equal(t, []Operation{{Name: "GET /", SpanKind: "server"}, {Name: "GET /health", SpanKind: "server"}}, ops)
+	ops, err = s.GetOperations(ctx, OperationQuery{ServiceName: "backend", SpanKind: "server"})
+	require.NoError(t, err)
+	assert.Empty(t, ops)
+
+	tests := []struct {
+		name  string
+		query TraceQuery
+		want  []byte
+	}{
+		{
+			name: "all, most recent first",
+			want: []byte{2, 1},
+		},
+		{
+			name:  "limit",
+			query: TraceQuery{NumTraces: 1},
+			want:  []byte{2},
+		},
+		{
+			name:  "service",
+			query: TraceQuery{ServiceName: "backend"},
+			want:  []byte{1},
+		},
+		{
+			name:  "operation of another service",
+			query: TraceQuery{ServiceName: "frontend", OperationName: "query"},
+		},
+		{
+			name:  "duration",
+			query: TraceQuery{DurationMin: 50 * time.Millisecond, DurationMax: 500 * time.Millisecond},
+			want:  []byte{1},
+		},
+		{
+			name:  "span tag",
+			query: TraceQuery{Tags: map[string]string{"db": "users"}},
+			want:  []byte{1},
+		},
+		{
+			name:  "resource tag",
+			query: TraceQuery{Tags: map[string]string{"service.name": "frontend", "db": "users"}},
+		},
+		{
+			name:  "start time",
+			query: TraceQuery{StartTimeMin: baseTime.Add(time.Second)},
+			want:  []byte{2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			traceIDs, err := s.FindTraceIDs(ctx, tt.query)
+			require.NoError(t, err)
+			var got []byte
+			for _, traceID := range traceIDs {
+				got = append(got, traceID[15])
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMemoryStoreEviction(t *testing.T) {
+	ctx := context.Background()
+	s := newMemoryStore(2)
+	for i := byte(1); i <= 3; i++ {
+		require.NoError(t, s.ConsumeTraces(ctx, newTestTraces(
+			testSpan{service: "svc", traceID: i, name: "op" + string('0'+i), start: time.Duration(i) * time.Second},
+		)))
+	}
+
+	_, err := s.GetTrace(ctx, pcommon.TraceID{15: 1})
+	assert.ErrorIs(t, err, ErrTraceNotFound)
+	traceIDs, err := s.FindTraceIDs(ctx, TraceQuery{})
+	require.NoError(t, err)
+	assert.Equal(t, []pcommon.TraceID{{15: 3}, {15: 2}}, traceIDs)
+
+	// The operations of evicted traces are forgotten.
+	ops, err := s.GetOperations(ctx, OperationQuery{ServiceName: "svc"})
+	require.NoError(t, err)
+	assert.Equal(t, []Operation{{Name: "op2", SpanKind: ""}, {Name: "op3", SpanKind: ""}}, ops)
+}
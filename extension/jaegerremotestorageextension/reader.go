@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package jaegerremotestorageextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/jaegerremotestorageextension"
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// ErrTraceNotFound is returned by Reader.GetTrace when the trace is not held by the reader.
+var ErrTraceNotFound = errors.New("trace not found")
+
+// Reader is the interface of the trace stores served through the Jaeger remote storage API.
+// Implementations work on pdata; the conversion to the Jaeger model is done by the extension.
+type Reader interface {
+	// GetTrace returns all the spans of a trace, or ErrTraceNotFound.
+	GetTrace(ctx context.Context, traceID pcommon.TraceID) (ptrace.Traces, error)
+
+	// GetServices returns the names of the services that reported spans.
+	GetServices(ctx context.Context) ([]string, error)
+
+	// GetOperations returns the operations of a service, optionally restricted to a span kind.
+	GetOperations(ctx context.Context, query OperationQuery) ([]Operation, error)
+
+	// FindTraceIDs returns the IDs of the traces matching the query, most recent first.
+	FindTraceIDs(ctx context.Context, query TraceQuery) ([]pcommon.TraceID, error)
+}
+
+// OperationQuery selects the operations returned by Reader.GetOperations.
+type OperationQuery struct {
+	ServiceName string
+	// SpanKind is a Jaeger span kind, such as "server" or "client". Empty matches all kinds.
+	SpanKind string
+}
+
+// Operation is a span name reported by a service for a span kind.
+type Operation struct {
+	Name     string
+	SpanKind string
+}
+
+// TraceQuery selects the traces returned by Reader.FindTraceIDs. A trace matches when
+// it started within the time range and one of its spans matches all the other criteria.
+// Zero values match everything.
+type TraceQuery struct {
+	ServiceName   string
+	OperationName string
+	// Tags must all be found in the attributes of the span or of its resource.
+	Tags         map[string]string
+	StartTimeMin time.Time
+	StartTimeMax time.Time
+	DurationMin  time.Duration
+	DurationMax  time.Duration
+	// NumTraces limits the number of traces returned.
+	NumTraces int
+}
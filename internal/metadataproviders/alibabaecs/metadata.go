@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package alibabaecs // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/metadataproviders/alibabaecs"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/metadataproviders/internal"
+)
+
+const (
+	// DefaultEndpoint is the endpoint of the Alibaba Cloud ECS instance metadata service,
+	// see https://www.alibabacloud.com/help/en/ecs/user-guide/view-instance-metadata
+	DefaultEndpoint = "http://100.100.100.200"
+
+	metadataPath = "/latest/dynamic/instance-identity/document"
+)
+
+// Provider gets metadata from the Alibaba Cloud ECS instance metadata service.
+type Provider interface {
+	Metadata(context.Context) (*InstanceIdentityDocument, error)
+}
+
+type alibabaECSProviderImpl struct {
+	endpoint string
+	client   *http.Client
+}
+
+// NewProvider creates a new metadata provider querying endpoint, or the
+// DefaultEndpoint when empty.
+func NewProvider(endpoint string) Provider {
+	if endpoint == "" {
+		endpoint = DefaultEndpoint
+	}
+	return &alibabaECSProviderImpl{
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		client:   &http.Client{},
+	}
+}
+
+// InstanceIdentityDocument is the Alibaba Cloud ECS instance identity document format
+type InstanceIdentityDocument struct {
+	InstanceID     string `json:"instance-id"`
+	InstanceType   string `json:"instance-type"`
+	RegionID       string `json:"region-id"`
+	ZoneID         string `json:"zone-id"`
+	OwnerAccountID string `json:"owner-account-id"`
+	ImageID        string `json:"image-id"`
+}
+
+// Metadata queries the metadata service and parses its output
+func (p *alibabaECSProviderImpl) Metadata(ctx context.Context) (*InstanceIdentityDocument, error) {
+	body, err := internal.GetMetadata(ctx, p.client, p.endpoint+metadataPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var document *InstanceIdentityDocument
+	if err = json.Unmarshal(body, &document); err != nil {
+		return nil, fmt.Errorf("failed to decode Alibaba Cloud ECS instance identity document: %w", err)
+	}
+	if document == nil {
+		return nil, errors.New("empty Alibaba Cloud ECS instance identity document")
+	}
+	return document, nil
+}
@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package alibabaecs
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewProvider(t *testing.T) {
+	provider := NewProvider("")
+	require.NotNil(t, provider)
+	assert.Equal(t, DefaultEndpoint, provider.(*alibabaECSProviderImpl).endpoint)
+}
+
+func TestQueryEndpointFailed(t *testing.T) {
+	ts := httptest.NewServer(http.NotFoundHandler())
+	defer ts.Close()
+
+	_, err := NewProvider(ts.URL).Metadata(context.Background())
+	assert.Error(t, err)
+}
+
+func TestQueryEndpointMalformed(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, err := fmt.Fprintln(w, "{")
+		assert.NoError(t, err)
+	}))
+	defer ts.Close()
+
+	_, err := NewProvider(ts.URL).Metadata(context.Background())
+	assert.Error(t, err)
+}
+
+func TestQueryEndpointCorrect(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != metadataPath {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, err := w.Write([]byte(`{
+  "zone-id": "cn-hangzhou-i",
+  "serial-number": "4acd2b47-b328-4762-852f-998****",
+  "instance-id": "i-bp1iw9mv7uaaxxxx",
+  "region-id": "cn-hangzhou",
+  "private-ipv4": "192.168.0.1",
+  "owner-account-id": "1609****",
+  "mac": "00:16:3e:**:**:**",
+  "image-id": "aliyun_3_x64_20G_alibase_2021****.vhd",
+  "instance-type": "ecs.g6e.large"
+}`))
+		assert.NoError(t, err)
+	}))
+	defer ts.Close()
+
+	document, err := NewProvider(ts.URL).Metadata(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &InstanceIdentityDocument{
+		InstanceID:     "i-bp1iw9mv7uaaxxxx",
+		InstanceType:   "ecs.g6e.large",
+		RegionID:       "cn-hangzhou",
+		ZoneID:         "cn-hangzhou-i",
+		OwnerAccountID: "1609****",
+		ImageID:        "aliyun_3_x64_20G_alibase_2021****.vhd",
+	}, document)
+}
@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package digitalocean // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/metadataproviders/digitalocean"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/metadataproviders/internal"
+)
+
+const (
+	// DefaultEndpoint is the endpoint of the DigitalOcean droplet metadata service,
+	// see https://docs.digitalocean.com/reference/api/metadata-api/
+	DefaultEndpoint = "http://169.254.169.254"
+
+	metadataPath = "/metadata/v1.json"
+)
+
+// Provider gets metadata from the DigitalOcean droplet metadata service.
+type Provider interface {
+	Metadata(context.Context) (*DropletMetadata, error)
+}
+
+type digitalOceanProviderImpl struct {
+	endpoint string
+	client   *http.Client
+}
+
+// NewProvider creates a new metadata provider querying endpoint, or the
+// DefaultEndpoint when empty.
+func NewProvider(endpoint string) Provider {
+	if endpoint == "" {
+		endpoint = DefaultEndpoint
+	}
+	return &digitalOceanProviderImpl{
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		client:   &http.Client{},
+	}
+}
+
+// DropletMetadata is the DigitalOcean droplet metadata response format
+type DropletMetadata struct {
+	DropletID int64  `json:"droplet_id"`
+	Hostname  string `json:"hostname"`
+	Region    string `json:"region"`
+}
+
+// Metadata queries the metadata service and parses its output
+func (p *digitalOceanProviderImpl) Metadata(ctx context.Context) (*DropletMetadata, error) {
+	body, err := internal.GetMetadata(ctx, p.client, p.endpoint+metadataPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var metadata *DropletMetadata
+	if err = json.Unmarshal(body, &metadata); err != nil {
+		return nil, fmt.Errorf("failed to decode DigitalOcean metadata: %w", err)
+	}
+	if metadata == nil {
+		return nil, errors.New("empty DigitalOcean metadata")
+	}
+	return metadata, nil
+}
@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package digitalocean
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewProvider(t *testing.T) {
+	provider := NewProvider("")
+	require.NotNil(t, provider)
+	assert.Equal(t, DefaultEndpoint, provider.(*digitalOceanProviderImpl).endpoint)
+}
+
+func TestQueryEndpointFailed(t *testing.T) {
+	ts := httptest.NewServer(http.NotFoundHandler())
+	defer ts.Close()
+
+	_, err := NewProvider(ts.URL).Metadata(context.Background())
+	assert.Error(t, err)
+}
+
+func TestQueryEndpointMalformed(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, err := fmt.Fprintln(w, "{")
+		assert.NoError(t, err)
+	}))
+	defer ts.Close()
+
+	_, err := NewProvider(ts.URL).Metadata(context.Background())
+	assert.Error(t, err)
+}
+
+func TestQueryEndpointCorrect(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != metadataPath {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, err := w.Write([]byte(`{
+  "droplet_id": 2756294,
+  "hostname": "sample-droplet",
+  "region": "nyc3",
+  "public_keys": ["ssh-rsa AAAA"],
+  "interfaces": {}
+}`))
+		assert.NoError(t, err)
+	}))
+	defer ts.Close()
+
+	metadata, err := NewProvider(ts.URL).Metadata(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &DropletMetadata{
+		DropletID: 2756294,
+		Hostname:  "sample-droplet",
+		Region:    "nyc3",
+	}, metadata)
+}
@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package hetzner // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/metadataproviders/hetzner"
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/metadataproviders/internal"
+)
+
+const (
+	// DefaultEndpoint is the endpoint of the Hetzner Cloud server metadata service,
+	// see https://docs.hetzner.cloud/#server-metadata
+	DefaultEndpoint = "http://169.254.169.254"
+
+	metadataPath = "/hetzner/v1/metadata/"
+)
+
+// Provider gets metadata from the Hetzner Cloud server metadata service.
+type Provider interface {
+	Metadata(context.Context) (*ServerMetadata, error)
+}
+
+type hetznerProviderImpl struct {
+	endpoint string
+	client   *http.Client
+}
+
+// NewProvider creates a new metadata provider querying endpoint, or the
+// DefaultEndpoint when empty.
+func NewProvider(endpoint string) Provider {
+	if endpoint == "" {
+		endpoint = DefaultEndpoint
+	}
+	return &hetznerProviderImpl{
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		client:   &http.Client{},
+	}
+}
+
+// ServerMetadata is the metadata of a Hetzner Cloud server
+type ServerMetadata struct {
+	InstanceID       string
+	Hostname         string
+	Region           string
+	AvailabilityZone string
+}
+
+// Metadata queries the metadata service for each field of ServerMetadata, which
+// are served as plain text
+func (p *hetznerProviderImpl) Metadata(ctx context.Context) (*ServerMetadata, error) {
+	metadata := &ServerMetadata{}
+	for key, field := range map[string]*string{
+		"instance-id":       &metadata.InstanceID,
+		"hostname":          &metadata.Hostname,
+		"region":            &metadata.Region,
+		"availability-zone": &metadata.AvailabilityZone,
+	} {
+		body, err := internal.GetMetadata(ctx, p.client, p.endpoint+metadataPath+key, nil)
+		if err != nil {
+			return nil, err
+		}
+		*field = strings.TrimSpace(string(body))
+	}
+	return metadata, nil
+}
@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package hetzner
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMetadataServer(t *testing.T, values map[string]string) *httptest.Server {
+	mux := http.NewServeMux()
+	for key, value := range values {
+		mux.HandleFunc(metadataPath+key, func(w http.ResponseWriter, _ *http.Request) {
+			_, err := w.Write([]byte(value))
+			assert.NoError(t, err)
+		})
+	}
+	return httptest.NewServer(mux)
+}
+
+func TestNewProvider(t *testing.T) {
+	provider := NewProvider("")
+	require.NotNil(t, provider)
+	assert.Equal(t, DefaultEndpoint, provider.(*hetznerProviderImpl).endpoint)
+}
+
+func TestQueryEndpointFailed(t *testing.T) {
+	ts := newMetadataServer(t, map[string]string{
+		"instance-id": "42",
+		"hostname":    "web-1",
+	})
+	defer ts.Close()
+
+	_, err := NewProvider(ts.URL).Metadata(context.Background())
+	assert.Error(t, err)
+}
+
+func TestQueryEndpointCorrect(t *testing.T) {
+	ts := newMetadataServer(t, map[string]string{
+		"instance-id":       "42\n",
+		"hostname":          "web-1",
+		"region":            "eu-central",
+		"availability-zone": "fsn1-dc14",
+	})
+	defer ts.Close()
+
+	metadata, err := NewProvider(ts.URL).Metadata(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &ServerMetadata{
+		InstanceID:       "42",
+		Hostname:         "web-1",
+		Region:           "eu-central",
+		AvailabilityZone: "fsn1-dc14",
+	}, metadata)
+}
@@ -0,0 +1,42 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetMetadata(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Metadata-Flavor") != "test" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_, err := w.Write([]byte("value"))
+		assert.NoError(t, err)
+	}))
+	defer ts.Close()
+
+	body, err := GetMetadata(context.Background(), &http.Client{}, ts.URL, map[string]string{"Metadata-Flavor": "test"})
+	require.NoError(t, err)
+	assert.Equal(t, "value", string(body))
+
+	_, err = GetMetadata(context.Background(), &http.Client{}, ts.URL, nil)
+	assert.ErrorContains(t, err, "403 Forbidden")
+}
+
+func TestGetMetadataUnreachable(t *testing.T) {
+	ts := httptest.NewServer(http.NotFoundHandler())
+	url := ts.URL
+	ts.Close()
+
+	_, err := GetMetadata(context.Background(), &http.Client{}, url, nil)
+	assert.Error(t, err)
+}
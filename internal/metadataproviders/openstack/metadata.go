@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openstack // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/metadataproviders/openstack"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/metadataproviders/internal"
+)
+
+const (
+	// DefaultEndpoint is the endpoint of the OpenStack metadata service,
+	// see https://docs.openstack.org/nova/latest/user/metadata.html
+	DefaultEndpoint = "http://169.254.169.254"
+
+	metadataPath     = "/openstack/latest/meta_data.json"
+	instanceTypePath = "/latest/meta-data/instance-type"
+)
+
+// Provider gets metadata from the OpenStack metadata service.
+type Provider interface {
+	Metadata(context.Context) (*Metadata, error)
+}
+
+type openstackProviderImpl struct {
+	endpoint string
+	client   *http.Client
+}
+
+// NewProvider creates a new metadata provider querying endpoint, or the
+// DefaultEndpoint when empty.
+func NewProvider(endpoint string) Provider {
+	if endpoint == "" {
+		endpoint = DefaultEndpoint
+	}
+	return &openstackProviderImpl{
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		client:   &http.Client{},
+	}
+}
+
+// Metadata is the OpenStack metadata service response format
+type Metadata struct {
+	UUID             string `json:"uuid"`
+	Name             string `json:"name"`
+	Hostname         string `json:"hostname"`
+	AvailabilityZone string `json:"availability_zone"`
+	ProjectID        string `json:"project_id"`
+	// InstanceType is the flavor of the instance, which is only available from
+	// the EC2 compatible metadata API.
+	InstanceType string `json:"-"`
+}
+
+// Metadata queries the metadata service and parses its output
+func (p *openstackProviderImpl) Metadata(ctx context.Context) (*Metadata, error) {
+	body, err := internal.GetMetadata(ctx, p.client, p.endpoint+metadataPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var metadata *Metadata
+	if err = json.Unmarshal(body, &metadata); err != nil {
+		return nil, fmt.Errorf("failed to decode OpenStack metadata: %w", err)
+	}
+	if metadata == nil {
+		return nil, errors.New("empty OpenStack metadata")
+	}
+
+	// The EC2 compatible API can be disabled by the operator of the cloud
+	if instanceType, err := internal.GetMetadata(ctx, p.client, p.endpoint+instanceTypePath, nil); err == nil {
+		metadata.InstanceType = strings.TrimSpace(string(instanceType))
+	}
+
+	return metadata, nil
+}
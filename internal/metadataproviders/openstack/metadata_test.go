@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openstack
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const metadataJSON = `{
+  "uuid": "d8e02d56-2648-49a3-bf97-6be8f1204f38",
+  "name": "web-1",
+  "hostname": "web-1.novalocal",
+  "availability_zone": "nova",
+  "project_id": "f7ac731cc11f40efbc03a9f9e1d1d21f",
+  "launch_index": 0
+}`
+
+func TestNewProvider(t *testing.T) {
+	provider := NewProvider("")
+	require.NotNil(t, provider)
+	assert.Equal(t, DefaultEndpoint, provider.(*openstackProviderImpl).endpoint)
+}
+
+func TestQueryEndpointFailed(t *testing.T) {
+	ts := httptest.NewServer(http.NotFoundHandler())
+	defer ts.Close()
+
+	_, err := NewProvider(ts.URL).Metadata(context.Background())
+	assert.Error(t, err)
+}
+
+func TestQueryEndpointMalformed(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, err := fmt.Fprintln(w, "{")
+		assert.NoError(t, err)
+	}))
+	defer ts.Close()
+
+	_, err := NewProvider(ts.URL).Metadata(context.Background())
+	assert.Error(t, err)
+}
+
+func TestQueryEndpointCorrect(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(metadataPath, func(w http.ResponseWriter, _ *http.Request) {
+		_, err := w.Write([]byte(metadataJSON))
+		assert.NoError(t, err)
+	})
+	mux.HandleFunc(instanceTypePath, func(w http.ResponseWriter, _ *http.Request) {
+		_, err := w.Write([]byte("m1.small"))
+		assert.NoError(t, err)
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	metadata, err := NewProvider(ts.URL + "/").Metadata(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &Metadata{
+		UUID:             "d8e02d56-2648-49a3-bf97-6be8f1204f38",
+		Name:             "web-1",
+		Hostname:         "web-1.novalocal",
+		AvailabilityZone: "nova",
+		ProjectID:        "f7ac731cc11f40efbc03a9f9e1d1d21f",
+		InstanceType:     "m1.small",
+	}, metadata)
+}
+
+func TestQueryEndpointWithoutEC2API(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(metadataPath, func(w http.ResponseWriter, _ *http.Request) {
+		_, err := w.Write([]byte(metadataJSON))
+		assert.NoError(t, err)
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	metadata, err := NewProvider(ts.URL).Metadata(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "d8e02d56-2648-49a3-bf97-6be8f1204f38", metadata.UUID)
+	assert.Empty(t, metadata.InstanceType)
+}
@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package oraclecloud // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/metadataproviders/oraclecloud"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/metadataproviders/internal"
+)
+
+const (
+	// DefaultEndpoint is the endpoint of the Oracle Cloud Infrastructure instance metadata service,
+	// see https://docs.oracle.com/en-us/iaas/Content/Compute/Tasks/gettingmetadata.htm
+	DefaultEndpoint = "http://169.254.169.254"
+
+	metadataPath = "/opc/v2/instance/"
+)
+
+// Provider gets metadata from the Oracle Cloud Infrastructure instance metadata service.
+type Provider interface {
+	Metadata(context.Context) (*ComputeMetadata, error)
+}
+
+type oracleCloudProviderImpl struct {
+	endpoint string
+	client   *http.Client
+}
+
+// NewProvider creates a new metadata provider querying endpoint, or the
+// DefaultEndpoint when empty.
+func NewProvider(endpoint string) Provider {
+	if endpoint == "" {
+		endpoint = DefaultEndpoint
+	}
+	return &oracleCloudProviderImpl{
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		client:   &http.Client{},
+	}
+}
+
+// ComputeMetadata is the Oracle Cloud Infrastructure instance metadata response format
+type ComputeMetadata struct {
+	ID                  string `json:"id"`
+	DisplayName         string `json:"displayName"`
+	Hostname            string `json:"hostname"`
+	CanonicalRegionName string `json:"canonicalRegionName"`
+	AvailabilityDomain  string `json:"availabilityDomain"`
+	FaultDomain         string `json:"faultDomain"`
+	CompartmentID       string `json:"compartmentId"`
+	TenantID            string `json:"tenantId"`
+	Shape               string `json:"shape"`
+}
+
+// Metadata queries the metadata service and parses its output
+func (p *oracleCloudProviderImpl) Metadata(ctx context.Context) (*ComputeMetadata, error) {
+	// The v2 endpoint requires this header, which prevents server-side request forgeries
+	headers := map[string]string{"Authorization": "Bearer Oracle"}
+	body, err := internal.GetMetadata(ctx, p.client, p.endpoint+metadataPath, headers)
+	if err != nil {
+		return nil, err
+	}
+
+	var metadata *ComputeMetadata
+	if err = json.Unmarshal(body, &metadata); err != nil {
+		return nil, fmt.Errorf("failed to decode Oracle Cloud metadata: %w", err)
+	}
+	if metadata == nil {
+		return nil, errors.New("empty Oracle Cloud metadata")
+	}
+	return metadata, nil
+}
@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package oraclecloud
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewProvider(t *testing.T) {
+	provider := NewProvider("")
+	require.NotNil(t, provider)
+	assert.Equal(t, DefaultEndpoint, provider.(*oracleCloudProviderImpl).endpoint)
+}
+
+func TestQueryEndpointFailed(t *testing.T) {
+	ts := httptest.NewServer(http.NotFoundHandler())
+	defer ts.Close()
+
+	_, err := NewProvider(ts.URL).Metadata(context.Background())
+	assert.Error(t, err)
+}
+
+func TestQueryEndpointMalformed(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, err := fmt.Fprintln(w, "{")
+		assert.NoError(t, err)
+	}))
+	defer ts.Close()
+
+	_, err := NewProvider(ts.URL).Metadata(context.Background())
+	assert.Error(t, err)
+}
+
+func TestQueryEndpointCorrect(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != metadataPath || r.Header.Get("Authorization") != "Bearer Oracle" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, err := w.Write([]byte(`{
+  "availabilityDomain": "Uocm:PHX-AD-1",
+  "faultDomain": "FAULT-DOMAIN-2",
+  "compartmentId": "ocid1.compartment.oc1..aaaa",
+  "tenantId": "ocid1.tenancy.oc1..bbbb",
+  "displayName": "web-1",
+  "hostname": "web-1",
+  "id": "ocid1.instance.oc1.phx.cccc",
+  "region": "phx",
+  "canonicalRegionName": "us-phoenix-1",
+  "shape": "VM.Standard.E4.Flex"
+}`))
+		assert.NoError(t, err)
+	}))
+	defer ts.Close()
+
+	metadata, err := NewProvider(ts.URL).Metadata(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &ComputeMetadata{
+		ID:                  "ocid1.instance.oc1.phx.cccc",
+		DisplayName:         "web-1",
+		Hostname:            "web-1",
+		CanonicalRegionName: "us-phoenix-1",
+		AvailabilityDomain:  "Uocm:PHX-AD-1",
+		FaultDomain:         "FAULT-DOMAIN-2",
+		CompartmentID:       "ocid1.compartment.oc1..aaaa",
+		TenantID:            "ocid1.tenancy.oc1..bbbb",
+		Shape:               "VM.Standard.E4.Flex",
+	}, metadata)
+}
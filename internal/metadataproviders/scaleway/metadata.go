@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package scaleway // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/metadataproviders/scaleway"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/metadataproviders/internal"
+)
+
+const (
+	// DefaultEndpoint is the endpoint of the Scaleway instance metadata service,
+	// see https://www.scaleway.com/en/docs/compute/instances/
+	DefaultEndpoint = "http://169.254.42.42"
+
+	metadataPath = "/conf?format=json"
+)
+
+// Provider gets metadata from the Scaleway instance metadata service.
+type Provider interface {
+	Metadata(context.Context) (*InstanceMetadata, error)
+}
+
+type scalewayProviderImpl struct {
+	endpoint string
+	client   *http.Client
+}
+
+// NewProvider creates a new metadata provider querying endpoint, or the
+// DefaultEndpoint when empty.
+func NewProvider(endpoint string) Provider {
+	if endpoint == "" {
+		endpoint = DefaultEndpoint
+	}
+	return &scalewayProviderImpl{
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		client:   &http.Client{},
+	}
+}
+
+// Location is the location of a Scaleway instance
+type Location struct {
+	ZoneID string `json:"zone_id"`
+}
+
+// InstanceMetadata is the Scaleway instance metadata response format
+type InstanceMetadata struct {
+	ID             string   `json:"id"`
+	Name           string   `json:"name"`
+	Hostname       string   `json:"hostname"`
+	CommercialType string   `json:"commercial_type"`
+	Organization   string   `json:"organization"`
+	Project        string   `json:"project"`
+	Location       Location `json:"location"`
+}
+
+// Region returns the region of the zone of the instance, e.g. fr-par for the fr-par-1 zone
+func (m *InstanceMetadata) Region() string {
+	if i := strings.LastIndexByte(m.Location.ZoneID, '-'); i > 0 {
+		return m.Location.ZoneID[:i]
+	}
+	return ""
+}
+
+// Metadata queries the metadata service and parses its output
+func (p *scalewayProviderImpl) Metadata(ctx context.Context) (*InstanceMetadata, error) {
+	body, err := internal.GetMetadata(ctx, p.client, p.endpoint+metadataPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var metadata *InstanceMetadata
+	if err = json.Unmarshal(body, &metadata); err != nil {
+		return nil, fmt.Errorf("failed to decode Scaleway metadata: %w", err)
+	}
+	if metadata == nil {
+		return nil, errors.New("empty Scaleway metadata")
+	}
+	return metadata, nil
+}
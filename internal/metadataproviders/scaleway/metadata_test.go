@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package scaleway
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewProvider(t *testing.T) {
+	provider := NewProvider("")
+	require.NotNil(t, provider)
+	assert.Equal(t, DefaultEndpoint, provider.(*scalewayProviderImpl).endpoint)
+}
+
+func TestQueryEndpointFailed(t *testing.T) {
+	ts := httptest.NewServer(http.NotFoundHandler())
+	defer ts.Close()
+
+	_, err := NewProvider(ts.URL).Metadata(context.Background())
+	assert.Error(t, err)
+}
+
+func TestQueryEndpointMalformed(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, err := fmt.Fprintln(w, "{")
+		assert.NoError(t, err)
+	}))
+	defer ts.Close()
+
+	_, err := NewProvider(ts.URL).Metadata(context.Background())
+	assert.Error(t, err)
+}
+
+func TestQueryEndpointCorrect(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/conf" || r.URL.Query().Get("format") != "json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, err := w.Write([]byte(`{
+  "id": "5a8f5a3c-3c1c-4a4e-8f8f-2b8c1e7d9f10",
+  "name": "web-1",
+  "hostname": "web-1",
+  "commercial_type": "DEV1-S",
+  "organization": "0e6a9f2c-1111-2222-3333-444455556666",
+  "project": "7c1d2e3f-aaaa-bbbb-cccc-ddddeeeeffff",
+  "location": {"zone_id": "fr-par-1", "platform_id": "14"}
+}`))
+		assert.NoError(t, err)
+	}))
+	defer ts.Close()
+
+	metadata, err := NewProvider(ts.URL).Metadata(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &InstanceMetadata{
+		ID:             "5a8f5a3c-3c1c-4a4e-8f8f-2b8c1e7d9f10",
+		Name:           "web-1",
+		Hostname:       "web-1",
+		CommercialType: "DEV1-S",
+		Organization:   "0e6a9f2c-1111-2222-3333-444455556666",
+		Project:        "7c1d2e3f-aaaa-bbbb-cccc-ddddeeeeffff",
+		Location:       Location{ZoneID: "fr-par-1"},
+	}, metadata)
+	assert.Equal(t, "fr-par", metadata.Region())
+}
+
+func TestRegion(t *testing.T) {
+	assert.Equal(t, "nl-ams", (&InstanceMetadata{Location: Location{ZoneID: "nl-ams-3"}}).Region())
+	assert.Empty(t, (&InstanceMetadata{}).Region())
+}
@@ -51,6 +51,17 @@ func (c *Condition[K]) Eval(ctx context.Context, tCtx K) (bool, error) {
 	return c.condition.Eval(ctx, tCtx)
 }
 
+// ValueExpression represents an expression that resolves to a value. The expression can be a literal,
+// a path within the context, a converter invocation or a math expression.
+type ValueExpression[K any] struct {
+	getter Getter[K]
+}
+
+// Eval evaluates the expression for the given TransformContext and returns the value it resolves to.
+func (e *ValueExpression[K]) Eval(ctx context.Context, tCtx K) (any, error) {
+	return e.getter.Get(ctx, tCtx)
+}
+
 // Parser provides the means to parse OTTL StatementSequence and Conditions given a specific set of functions,
 // a PathExpressionParser, and an EnumParser.
 type Parser[K any] struct {
@@ -195,8 +206,49 @@ func (p *Parser[K]) ParseCondition(condition string) (*Condition[K], error) {
 	}, nil
 }
 
+// ParseValueExpressions parses string expressions into a ValueExpression slice ready for execution.
+// Returns a slice of ValueExpression and a nil error on successful parsing.
+// If parsing fails, returns nil and an error containing each error per failed expression.
+func (p *Parser[K]) ParseValueExpressions(expressions []string) ([]*ValueExpression[K], error) {
+	parsedExpressions := make([]*ValueExpression[K], 0, len(expressions))
+	var parseErrs []error
+
+	for _, expression := range expressions {
+		pe, err := p.ParseValueExpression(expression)
+		if err != nil {
+			parseErrs = append(parseErrs, fmt.Errorf("unable to parse OTTL value expression %q: %w", expression, err))
+			continue
+		}
+		parsedExpressions = append(parsedExpressions, pe)
+	}
+
+	if len(parseErrs) > 0 {
+		return nil, errors.Join(parseErrs...)
+	}
+
+	return parsedExpressions, nil
+}
+
+// ParseValueExpression parses a single string expression into a ValueExpression ready for execution.
+// Returns a ValueExpression and a nil error on successful parsing.
+// If parsing fails, returns nil and an error.
+func (p *Parser[K]) ParseValueExpression(expression string) (*ValueExpression[K], error) {
+	parsed, err := parseValueExpression(expression)
+	if err != nil {
+		return nil, err
+	}
+	getter, err := p.newGetter(*parsed)
+	if err != nil {
+		return nil, err
+	}
+	return &ValueExpression[K]{
+		getter: getter,
+	}, nil
+}
+
 var parser = newParser[parsedStatement]()
 var conditionParser = newParser[booleanExpression]()
+var valueExpressionParser = newParser[value]()
 
 func parseStatement(raw string) (*parsedStatement, error) {
 	parsed, err := parser.ParseString("", raw)
@@ -226,6 +278,20 @@ func parseCondition(raw string) (*booleanExpression, error) {
 	return parsed, nil
 }
 
+func parseValueExpression(raw string) (*value, error) {
+	parsed, err := valueExpressionParser.ParseString("", raw)
+
+	if err != nil {
+		return nil, fmt.Errorf("value expression has invalid syntax: %w", err)
+	}
+	err = parsed.checkForCustomError()
+	if err != nil {
+		return nil, err
+	}
+
+	return parsed, nil
+}
+
 // newParser returns a parser that can be used to read a string into a parsedStatement. An error will be returned if the string
 // is not formatted for the DSL.
 func newParser[G any]() *participle.Parser[G] {
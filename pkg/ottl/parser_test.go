@@ -14,6 +14,7 @@ import (
 
 	"github.com/alecthomas/participle/v2/lexer"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottltest"
@@ -2190,6 +2191,70 @@ func Test_ParseConditions_Error(t *testing.T) {
 	}
 }
 
+func Test_ParseValueExpressions_Error(t *testing.T) {
+	expressions := []string{
+		`Int(`,
+		`"foo`,
+		`set(name, "foo")`,
+	}
+
+	p, _ := NewParser(
+		CreateFactoryMap[any](),
+		testParsePath[any],
+		componenttest.NewNopTelemetrySettings(),
+		WithEnumParser[any](testParseEnum),
+	)
+
+	_, err := p.ParseValueExpressions(expressions)
+
+	assert.Error(t, err)
+
+	var e interface{ Unwrap() []error }
+	if errors.As(err, &e) {
+		uw := e.Unwrap()
+		assert.Len(t, uw, len(expressions), "ParseValueExpressions didn't return an error per expression")
+
+		for i, expressionErr := range uw {
+			assert.ErrorContains(t, expressionErr, fmt.Sprintf("unable to parse OTTL value expression %q", expressions[i]))
+		}
+	} else {
+		assert.Fail(t, "ParseValueExpressions didn't return an error per expression")
+	}
+}
+
+func Test_ValueExpression_Eval(t *testing.T) {
+	tests := []struct {
+		expression string
+		expected   any
+	}{
+		{`"foo"`, "foo"},
+		{`1`, int64(1)},
+		{`1.5`, 1.5},
+		{`true`, true},
+		{`nil`, nil},
+		{`1 + 2 * 3`, int64(7)},
+		{`[1, 2]`, []any{int64(1), int64(2)}},
+	}
+
+	p, _ := NewParser(
+		CreateFactoryMap[any](),
+		testParsePath[any],
+		componenttest.NewNopTelemetrySettings(),
+		WithEnumParser[any](testParseEnum),
+	)
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			expr, err := p.ParseValueExpression(tt.expression)
+			require.NoError(t, err)
+
+			result, err := expr.Eval(context.Background(), nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
 // This test doesn't validate parser results, simply checks whether the parse succeeds or not.
 // It's a fast way to check a large range of possible syntaxes.
 func Test_parseStatement(t *testing.T) {
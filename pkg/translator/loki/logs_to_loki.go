@@ -48,6 +48,28 @@ const (
 // to make this decision, as it includes all of the errors that were encountered,
 // as well as the number of items dropped and submitted.
 func LogsToLokiRequests(ld plog.Logs, defaultLabelsEnabled map[string]bool) map[string]PushRequest {
+	return LogsToLokiRequestsWithOptions(ld, Options{DefaultLabelsEnabled: defaultLabelsEnabled})
+}
+
+// LabelsFunc returns the labels of a log record. The label names are normalized by the caller.
+type LabelsFunc func(lr plog.LogRecord, resource pcommon.Resource, scope pcommon.InstrumentationScope) (model.LabelSet, error)
+
+// Options configures the conversion of logs into Loki push requests.
+type Options struct {
+	// DefaultLabelsEnabled enables or disables the default labels: exporter, job, instance and level.
+	// A default label missing from the map is enabled.
+	DefaultLabelsEnabled map[string]bool
+	// Labels, when set, selects the labels of the log records in addition to the default labels.
+	// The "loki.attribute.labels" and "loki.resource.labels" hints are then ignored.
+	Labels LabelsFunc
+	// StructuredMetadata sends the body of the log records as the line, and their fields and the
+	// attributes that are not labels as structured metadata, the way the Loki OTLP endpoint
+	// stores them. The "loki.format" hint is then ignored. Requires Loki 3 or later.
+	StructuredMetadata bool
+}
+
+// LogsToLokiRequestsWithOptions is LogsToLokiRequests with the conversion configured by opts.
+func LogsToLokiRequestsWithOptions(ld plog.Logs, opts Options) map[string]PushRequest {
 	groups := map[string]pushRequestGroup{}
 
 	rls := ld.ResourceLogs()
@@ -70,7 +92,7 @@ func LogsToLokiRequests(ld plog.Logs, defaultLabelsEnabled map[string]bool) map[
 					groups[tenant] = group
 				}
 
-				entry, err := LogToLokiEntry(log, resource, scope, defaultLabelsEnabled)
+				entry, err := LogToLokiEntryWithOptions(log, resource, scope, opts)
 				if err != nil {
 					// Couldn't convert so dropping log.
 					group.report.Errors = append(group.report.Errors, fmt.Errorf("failed to convert, dropping log: %w", err))
@@ -122,6 +144,11 @@ type PushEntry struct {
 
 // LogToLokiEntry converts LogRecord into Loki log entry enriched with normalized labels
 func LogToLokiEntry(lr plog.LogRecord, rl pcommon.Resource, scope pcommon.InstrumentationScope, defaultLabelsEnabled map[string]bool) (*PushEntry, error) {
+	return LogToLokiEntryWithOptions(lr, rl, scope, Options{DefaultLabelsEnabled: defaultLabelsEnabled})
+}
+
+// LogToLokiEntryWithOptions is LogToLokiEntry with the conversion configured by opts.
+func LogToLokiEntryWithOptions(lr plog.LogRecord, rl pcommon.Resource, scope pcommon.InstrumentationScope, opts Options) (*PushEntry, error) {
 	// we may remove attributes, so change only our version
 	log := plog.NewLogRecord()
 	lr.CopyTo(log)
@@ -130,21 +157,45 @@ func LogToLokiEntry(lr plog.LogRecord, rl pcommon.Resource, scope pcommon.Instru
 	resource := pcommon.NewResource()
 	rl.CopyTo(resource)
 
-	if enabled, ok := defaultLabelsEnabled[levelLabel]; !ok || enabled {
-		// adds level attribute from log.severityNumber
-		addLogLevelAttributeAndHint(log)
+	levelEnabled := true
+	if enabled, ok := opts.DefaultLabelsEnabled[levelLabel]; ok {
+		levelEnabled = enabled
 	}
 
-	format := getFormatFromFormatHint(log.Attributes(), resource.Attributes())
-
-	mergedLabels := convertAttributesAndMerge(log.Attributes(), resource.Attributes(), defaultLabelsEnabled)
-	// remove the attributes that were promoted to labels
-	removeAttributes(log.Attributes(), mergedLabels)
-	removeAttributes(resource.Attributes(), mergedLabels)
+	var mergedLabels model.LabelSet
+	if opts.Labels != nil {
+		selected, err := opts.Labels(lr, rl, scope)
+		if err != nil {
+			return nil, err
+		}
+		mergedLabels = getDefaultLabels(resource.Attributes(), opts.DefaultLabelsEnabled)
+		if level, ok := logLevel(log); ok && levelEnabled {
+			mergedLabels[model.LabelName(levelLabel)] = model.LabelValue(level)
+		}
+		mergedLabels = mergedLabels.Merge(selected)
+		// the selected labels may be computed from any attribute, only the hints are removed
+		removeAttributes(log.Attributes(), model.LabelSet{})
+		removeAttributes(resource.Attributes(), model.LabelSet{})
+	} else {
+		if levelEnabled {
+			// adds level attribute from log.severityNumber
+			addLogLevelAttributeAndHint(log)
+		}
+		mergedLabels = convertAttributesAndMerge(log.Attributes(), resource.Attributes(), opts.DefaultLabelsEnabled)
+		// remove the attributes that were promoted to labels
+		removeAttributes(log.Attributes(), mergedLabels)
+		removeAttributes(resource.Attributes(), mergedLabels)
+	}
 
-	entry, err := convertLogToLokiEntry(log, resource, format, scope)
-	if err != nil {
-		return nil, err
+	var entry *push.Entry
+	if opts.StructuredMetadata {
+		entry = convertLogToStructuredMetadataEntry(log, resource, scope)
+	} else {
+		format := getFormatFromFormatHint(lr.Attributes(), rl.Attributes())
+		var err error
+		if entry, err = convertLogToLokiEntry(log, resource, format, scope); err != nil {
+			return nil, err
+		}
 	}
 
 	labels := model.LabelSet{}
@@ -201,6 +252,17 @@ type pushRequestGroup struct {
 	report  *PushReport
 }
 
+// logLevel returns the level attribute of the log record, or the level of its severity number.
+func logLevel(log plog.LogRecord) (string, bool) {
+	if log.SeverityNumber() == plog.SeverityNumberUnspecified {
+		return "", false
+	}
+	if level, found := log.Attributes().Get(levelAttributeName); found {
+		return level.AsString(), true
+	}
+	return severityNumberToLevel[log.SeverityNumber().String()], true
+}
+
 func addLogLevelAttributeAndHint(log plog.LogRecord) {
 	if log.SeverityNumber() == plog.SeverityNumberUnspecified {
 		return
@@ -65,7 +65,9 @@ func PushRequestToLogs(pushRequest *push.PushRequest, keepTimestamp bool) (plog.
 	return logs, lastErr
 }
 
-// ConvertEntryToLogRecord converts loki log entry to otlp log record
+// ConvertEntryToLogRecord converts loki log entry to otlp log record. The structured metadata
+// of the entry are mapped back to the fields and attributes of the log record, the reverse of
+// the mapping used by the Loki OTLP endpoint.
 func ConvertEntryToLogRecord(entry *push.Entry, lr *plog.LogRecord, labelSet model.LabelSet, keepTimestamp bool) {
 	observedTimestamp := pcommon.NewTimestampFromTime(time.Now())
 	lr.SetObservedTimestamp(observedTimestamp)
@@ -78,4 +80,5 @@ func ConvertEntryToLogRecord(entry *push.Entry, lr *plog.LogRecord, labelSet mod
 	for key, value := range labelSet {
 		lr.Attributes().PutStr(string(key), string(value))
 	}
+	convertStructuredMetadataToLogRecord(entry.StructuredMetadata, lr)
 }
@@ -77,6 +77,38 @@ func TestPushRequestToLogs(t *testing.T) {
 				},
 			}),
 		},
+		{
+			name: "Should map structured metadata to attributes",
+			pushRequest: &push.PushRequest{
+				Streams: []push.Stream{
+					{
+						Labels: "{label1=\"value1\"}",
+						Entries: []push.Entry{
+							{
+								Timestamp: time.Unix(0, 1676888496000000000),
+								Line:      "logline 1",
+								StructuredMetadata: push.LabelsAdapter{
+									{Name: "thread_name", Value: "main"},
+									{Name: "trace_id", Value: "not a trace id"},
+								},
+							},
+						},
+					},
+				},
+			},
+			keepTimestamp: true,
+			expected: generateLogs([]Log{
+				{
+					Timestamp: 1676888496000000000,
+					Body:      pcommon.NewValueStr("logline 1"),
+					Attributes: map[string]any{
+						"label1":      "value1",
+						"thread_name": "main",
+						"trace_id":    "not a trace id",
+					},
+				},
+			}),
+		},
 	}
 
 	for _, tt := range testCases {
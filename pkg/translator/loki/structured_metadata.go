@@ -0,0 +1,143 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package loki // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/loki"
+
+import (
+	"encoding/hex"
+	"sort"
+	"strconv"
+
+	"github.com/grafana/loki/pkg/push"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	prometheustranslator "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheus"
+)
+
+// Names of the structured metadata holding the fields of the log records, as used by
+// the Loki OTLP endpoint.
+const (
+	metadataTraceID           = "trace_id"
+	metadataSpanID            = "span_id"
+	metadataFlags             = "flags"
+	metadataSeverityText      = "severity_text"
+	metadataSeverityNumber    = "severity_number"
+	metadataObservedTimestamp = "observed_timestamp"
+	metadataScopeName         = "scope_name"
+	metadataScopeVersion      = "scope_version"
+)
+
+// convertLogToStructuredMetadataEntry converts a log record the way the Loki OTLP endpoint
+// does: the body is the line, and the fields, the attributes of the record, of its scope
+// and of its resource are structured metadata. Attribute names are normalized, and the
+// attributes of the record take precedence over the ones of the scope and resource.
+func convertLogToStructuredMetadataEntry(lr plog.LogRecord, res pcommon.Resource, scope pcommon.InstrumentationScope) *push.Entry {
+	metadata := map[string]string{}
+	putAttributes := func(attrs pcommon.Map) {
+		attrs.Range(func(k string, v pcommon.Value) bool {
+			if isHint(k) {
+				return true
+			}
+			metadata[prometheustranslator.NormalizeLabel(k)] = v.AsString()
+			return true
+		})
+	}
+	putAttributes(res.Attributes())
+	putAttributes(scope.Attributes())
+	if scope.Name() != "" {
+		metadata[metadataScopeName] = scope.Name()
+	}
+	if scope.Version() != "" {
+		metadata[metadataScopeVersion] = scope.Version()
+	}
+	putAttributes(lr.Attributes())
+
+	if traceID := lr.TraceID(); !traceID.IsEmpty() {
+		metadata[metadataTraceID] = hex.EncodeToString(traceID[:])
+	}
+	if spanID := lr.SpanID(); !spanID.IsEmpty() {
+		metadata[metadataSpanID] = hex.EncodeToString(spanID[:])
+	}
+	if lr.Flags() != 0 {
+		metadata[metadataFlags] = strconv.FormatUint(uint64(lr.Flags()), 10)
+	}
+	if lr.SeverityText() != "" {
+		metadata[metadataSeverityText] = lr.SeverityText()
+	}
+	if lr.SeverityNumber() != plog.SeverityNumberUnspecified {
+		metadata[metadataSeverityNumber] = strconv.Itoa(int(lr.SeverityNumber()))
+	}
+	if lr.ObservedTimestamp() != 0 {
+		metadata[metadataObservedTimestamp] = strconv.FormatUint(uint64(lr.ObservedTimestamp()), 10)
+	}
+
+	names := make([]string, 0, len(metadata))
+	for name := range metadata {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	structuredMetadata := make(push.LabelsAdapter, 0, len(names))
+	for _, name := range names {
+		structuredMetadata = append(structuredMetadata, push.LabelAdapter{Name: name, Value: metadata[name]})
+	}
+
+	return &push.Entry{
+		Timestamp:          timestampFromLogRecord(lr),
+		Line:               lr.Body().AsString(),
+		StructuredMetadata: structuredMetadata,
+	}
+}
+
+// convertStructuredMetadataToLogRecord is the reverse of convertLogToStructuredMetadataEntry.
+// The structured metadata holding fields are set on the log record when they can be parsed,
+// the others become attributes.
+func convertStructuredMetadataToLogRecord(structuredMetadata push.LabelsAdapter, lr *plog.LogRecord) {
+	for _, m := range structuredMetadata {
+		if !setLogRecordField(m.Name, m.Value, lr) {
+			lr.Attributes().PutStr(m.Name, m.Value)
+		}
+	}
+}
+
+func setLogRecordField(name string, value string, lr *plog.LogRecord) bool {
+	switch name {
+	case metadataTraceID:
+		var traceID pcommon.TraceID
+		if b, err := hex.DecodeString(value); err == nil && len(b) == len(traceID) {
+			copy(traceID[:], b)
+			lr.SetTraceID(traceID)
+			return true
+		}
+	case metadataSpanID:
+		var spanID pcommon.SpanID
+		if b, err := hex.DecodeString(value); err == nil && len(b) == len(spanID) {
+			copy(spanID[:], b)
+			lr.SetSpanID(spanID)
+			return true
+		}
+	case metadataFlags:
+		if flags, err := strconv.ParseUint(value, 10, 32); err == nil {
+			lr.SetFlags(plog.LogRecordFlags(flags))
+			return true
+		}
+	case metadataObservedTimestamp:
+		if ts, err := strconv.ParseUint(value, 10, 64); err == nil {
+			lr.SetObservedTimestamp(pcommon.Timestamp(ts))
+			return true
+		}
+	case metadataSeverityText:
+		lr.SetSeverityText(value)
+		return true
+	case metadataSeverityNumber:
+		if number, err := strconv.ParseInt(value, 10, 32); err == nil {
+			lr.SetSeverityNumber(plog.SeverityNumber(number))
+			return true
+		}
+	}
+	return false
+}
+
+func isHint(name string) bool {
+	return name == hintAttributes || name == hintResources || name == hintTenant || name == hintFormat
+}
@@ -0,0 +1,134 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package loki // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/loki"
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/grafana/loki/pkg/push"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func structuredMetadataLog() (plog.LogRecord, pcommon.Resource, pcommon.InstrumentationScope) {
+	lr := plog.NewLogRecord()
+	lr.SetTimestamp(pcommon.NewTimestampFromTime(time.Unix(0, 1676888496000000000)))
+	lr.SetObservedTimestamp(1676888497000000000)
+	lr.Body().SetStr("hello world")
+	lr.SetTraceID([16]byte{1, 2, 3, 4})
+	lr.SetSpanID([8]byte{5, 6, 7, 8})
+	lr.SetFlags(plog.DefaultLogRecordFlags.WithIsSampled(true))
+	lr.SetSeverityText("Information")
+	lr.SetSeverityNumber(plog.SeverityNumberInfo)
+	lr.Attributes().PutStr("thread.name", "main")
+	lr.Attributes().PutInt("http.status", 200)
+	lr.Attributes().PutStr(hintAttributes, "thread.name")
+
+	res := pcommon.NewResource()
+	res.Attributes().PutStr("service.name", "frontend")
+	res.Attributes().PutStr("k8s.pod.uid", "c2c9-4b5a")
+	res.Attributes().PutStr("thread.name", "overridden")
+
+	scope := pcommon.NewInstrumentationScope()
+	scope.SetName("io.opentelemetry.test")
+	scope.SetVersion("1.0.0")
+	return lr, res, scope
+}
+
+func TestLogToLokiEntryWithStructuredMetadata(t *testing.T) {
+	lr, res, scope := structuredMetadataLog()
+
+	entry, err := LogToLokiEntryWithOptions(lr, res, scope, Options{StructuredMetadata: true})
+	require.NoError(t, err)
+
+	assert.Equal(t, model.LabelSet{
+		"exporter":    "OTLP",
+		"job":         "frontend",
+		"level":       "INFO",
+		"thread_name": "main",
+	}, entry.Labels)
+	assert.Equal(t, "hello world", entry.Entry.Line)
+	assert.Equal(t, time.Unix(0, 1676888496000000000), entry.Entry.Timestamp)
+	assert.Equal(t, push.LabelsAdapter{
+		{Name: "flags", Value: "1"},
+		{Name: "http_status", Value: "200"},
+		{Name: "k8s_pod_uid", Value: "c2c9-4b5a"},
+		{Name: "observed_timestamp", Value: "1676888497000000000"},
+		{Name: "scope_name", Value: "io.opentelemetry.test"},
+		{Name: "scope_version", Value: "1.0.0"},
+		{Name: "service_name", Value: "frontend"},
+		{Name: "severity_number", Value: "9"},
+		{Name: "severity_text", Value: "Information"},
+		{Name: "span_id", Value: "0506070800000000"},
+		{Name: "trace_id", Value: "01020304000000000000000000000000"},
+	}, entry.Entry.StructuredMetadata)
+
+	// the original log record is not modified
+	_, found := lr.Attributes().Get(hintAttributes)
+	assert.True(t, found)
+}
+
+func TestLogToLokiEntryWithLabelsFunc(t *testing.T) {
+	lr, res, scope := structuredMetadataLog()
+
+	entry, err := LogToLokiEntryWithOptions(lr, res, scope, Options{
+		DefaultLabelsEnabled: map[string]bool{exporterLabel: false},
+		Labels: func(_ plog.LogRecord, resource pcommon.Resource, _ pcommon.InstrumentationScope) (model.LabelSet, error) {
+			uid, _ := resource.Attributes().Get("k8s.pod.uid")
+			return model.LabelSet{"k8s.pod.uid": model.LabelValue(uid.Str())}, nil
+		},
+		StructuredMetadata: true,
+	})
+	require.NoError(t, err)
+
+	// the hints are ignored, and the attributes are kept in the structured metadata
+	assert.Equal(t, model.LabelSet{
+		"job":         "frontend",
+		"level":       "INFO",
+		"k8s_pod_uid": "c2c9-4b5a",
+	}, entry.Labels)
+	assert.Contains(t, entry.Entry.StructuredMetadata, push.LabelAdapter{Name: "thread_name", Value: "main"})
+	assert.Contains(t, entry.Entry.StructuredMetadata, push.LabelAdapter{Name: "k8s_pod_uid", Value: "c2c9-4b5a"})
+
+	_, err = LogToLokiEntryWithOptions(lr, res, scope, Options{
+		Labels: func(plog.LogRecord, pcommon.Resource, pcommon.InstrumentationScope) (model.LabelSet, error) {
+			return nil, errors.New("boom")
+		},
+	})
+	assert.EqualError(t, err, "boom")
+}
+
+func TestStructuredMetadataRoundTrip(t *testing.T) {
+	lr, res, scope := structuredMetadataLog()
+	entry, err := LogToLokiEntryWithOptions(lr, res, scope, Options{StructuredMetadata: true})
+	require.NoError(t, err)
+
+	got := plog.NewLogRecord()
+	ConvertEntryToLogRecord(entry.Entry, &got, entry.Labels, true)
+
+	assert.Equal(t, lr.Timestamp(), got.Timestamp())
+	assert.Equal(t, lr.ObservedTimestamp(), got.ObservedTimestamp())
+	assert.Equal(t, lr.TraceID(), got.TraceID())
+	assert.Equal(t, lr.SpanID(), got.SpanID())
+	assert.Equal(t, lr.Flags(), got.Flags())
+	assert.Equal(t, lr.SeverityText(), got.SeverityText())
+	assert.Equal(t, lr.SeverityNumber(), got.SeverityNumber())
+	assert.Equal(t, "hello world", got.Body().Str())
+	assert.Equal(t, map[string]any{
+		"exporter":      "OTLP",
+		"job":           "frontend",
+		"level":         "INFO",
+		"thread_name":   "main",
+		"http_status":   "200",
+		"k8s_pod_uid":   "c2c9-4b5a",
+		"service_name":  "frontend",
+		"scope_name":    "io.opentelemetry.test",
+		"scope_version": "1.0.0",
+	}, got.Attributes().AsRaw())
+}
@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parquet // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/parquet"
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/apache/arrow/go/v17/parquet/compress"
+)
+
+// Compression is the codec used to compress the column chunks of a Parquet file.
+type Compression string
+
+const (
+	CompressionZstd   Compression = "zstd"
+	CompressionSnappy Compression = "snappy"
+	CompressionGzip   Compression = "gzip"
+	CompressionNone   Compression = "none"
+
+	defaultRowGroupSize = 65536
+)
+
+// Config defines how telemetry is encoded into Parquet files.
+type Config struct {
+	// Compression is the codec of the column chunks: zstd, snappy, gzip or none. Defaults to zstd.
+	Compression Compression `mapstructure:"compression"`
+	// RowGroupSize is the maximum number of rows of a row group. Rows are buffered in memory
+	// until a row group is full or the file is closed. Defaults to 65536.
+	RowGroupSize int `mapstructure:"row_group_size"`
+	// PromotedResourceAttributes are the resource attributes copied to their own top-level
+	// "resource_<name>" column, in addition to the resource_attributes map column.
+	PromotedResourceAttributes []string `mapstructure:"promoted_resource_attributes"`
+	// PromotedAttributes are the log record, span or data point attributes copied to their own
+	// top-level "attribute_<name>" column, in addition to the attributes map column.
+	PromotedAttributes []string `mapstructure:"promoted_attributes"`
+}
+
+// NewDefaultConfig returns the default Parquet encoding configuration.
+func NewDefaultConfig() Config {
+	return Config{
+		Compression:  CompressionZstd,
+		RowGroupSize: defaultRowGroupSize,
+	}
+}
+
+// Validate checks that the configuration is valid.
+func (c Config) Validate() error {
+	var errs error
+	if _, err := c.Compression.codec(); err != nil {
+		errs = errors.Join(errs, err)
+	}
+	if c.RowGroupSize <= 0 {
+		errs = errors.Join(errs, errors.New("row_group_size must be positive"))
+	}
+	if _, err := c.promotedColumns(); err != nil {
+		errs = errors.Join(errs, err)
+	}
+	return errs
+}
+
+func (c Compression) codec() (compress.Compression, error) {
+	switch c {
+	case CompressionZstd:
+		return compress.Codecs.Zstd, nil
+	case CompressionSnappy:
+		return compress.Codecs.Snappy, nil
+	case CompressionGzip:
+		return compress.Codecs.Gzip, nil
+	case CompressionNone:
+		return compress.Codecs.Uncompressed, nil
+	default:
+		return compress.Codecs.Uncompressed, fmt.Errorf("unsupported compression %q, must be one of zstd, snappy, gzip or none", c)
+	}
+}
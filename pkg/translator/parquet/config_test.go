@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parquet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigValidate(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		modify func(*Config)
+		err    string
+	}{
+		{
+			name:   "default",
+			modify: func(*Config) {},
+		},
+		{
+			name: "promoted attributes",
+			modify: func(cfg *Config) {
+				cfg.Compression = CompressionSnappy
+				cfg.PromotedResourceAttributes = []string{"service.name"}
+				cfg.PromotedAttributes = []string{"service.name", "http.route"}
+			},
+		},
+		{
+			name:   "unsupported compression",
+			modify: func(cfg *Config) { cfg.Compression = "lzo" },
+			err:    `unsupported compression "lzo", must be one of zstd, snappy, gzip or none`,
+		},
+		{
+			name:   "invalid row group size",
+			modify: func(cfg *Config) { cfg.RowGroupSize = 0 },
+			err:    "row_group_size must be positive",
+		},
+		{
+			name:   "empty promoted attribute",
+			modify: func(cfg *Config) { cfg.PromotedAttributes = []string{""} },
+			err:    "promoted attribute names must not be empty",
+		},
+		{
+			name:   "duplicate promoted attribute",
+			modify: func(cfg *Config) { cfg.PromotedAttributes = []string{"http.route", "http_route"} },
+			err:    `promoted attribute "http_route" conflicts with column "attribute_http_route"`,
+		},
+		{
+			name:   "promoted attribute conflicting with a column",
+			modify: func(cfg *Config) { cfg.PromotedResourceAttributes = []string{"attributes"} },
+			err:    `promoted attribute "attributes" conflicts with column "resource_attributes"`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewDefaultConfig()
+			tc.modify(&cfg)
+			err := cfg.Validate()
+			if tc.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.err)
+			}
+		})
+	}
+}
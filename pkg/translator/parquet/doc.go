@@ -0,0 +1,6 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package parquet converts OTLP logs, traces and metrics into Apache Parquet files
+// with a stable columnar schema.
+package parquet // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/parquet"
@@ -0,0 +1,390 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parquet // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/parquet"
+
+import (
+	"github.com/apache/arrow/go/v17/arrow"
+	"github.com/apache/arrow/go/v17/arrow/array"
+	"github.com/apache/arrow/go/v17/arrow/memory"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// Encoder converts OTLP data into Arrow records matching the schema of their table.
+// Records are written to Parquet files with a Writer.
+type Encoder struct {
+	promoted []promotedColumn
+	schemas  map[Table]*arrow.Schema
+	mem      memory.Allocator
+}
+
+// NewEncoder creates an Encoder for the given configuration.
+func NewEncoder(cfg Config) (*Encoder, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	// Error checked in Validate()
+	promoted, _ := cfg.promotedColumns()
+	e := &Encoder{
+		promoted: promoted,
+		schemas:  make(map[Table]*arrow.Schema),
+		mem:      memory.DefaultAllocator,
+	}
+	for _, table := range allTables {
+		e.schemas[table] = newSchema(table, promoted)
+	}
+	return e, nil
+}
+
+// Schema returns the schema of the table.
+func (e *Encoder) Schema(table Table) *arrow.Schema {
+	return e.schemas[table]
+}
+
+// EncodeLogs converts the log records into a record of the logs table.
+// The caller must release the record.
+func (e *Encoder) EncodeLogs(ld plog.Logs) arrow.Record {
+	b := e.newRecordBuilder(TableLogs)
+	defer b.Release()
+
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		sls := rl.ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			sl := sls.At(j)
+			lrs := sl.LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				lr := lrs.At(k)
+				b.startRow(rl.Resource(), sl.Scope(), lr.Attributes())
+				b.appendTimestamp(lr.Timestamp())
+				b.appendTimestamp(lr.ObservedTimestamp())
+				b.next().(*array.Int32Builder).Append(int32(lr.SeverityNumber()))
+				b.appendString(lr.SeverityText())
+				b.appendString(lr.Body().AsString())
+				b.appendOptionalString(lr.TraceID().String())
+				b.appendOptionalString(lr.SpanID().String())
+				b.next().(*array.Uint32Builder).Append(uint32(lr.Flags()))
+				b.endRow(rl.Resource(), lr.Attributes())
+			}
+		}
+	}
+	return b.NewRecord()
+}
+
+// EncodeTraces converts the spans into a record of the spans table.
+// The caller must release the record.
+func (e *Encoder) EncodeTraces(td ptrace.Traces) arrow.Record {
+	b := e.newRecordBuilder(TableSpans)
+	defer b.Release()
+
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		sss := rs.ScopeSpans()
+		for j := 0; j < sss.Len(); j++ {
+			ss := sss.At(j)
+			spans := ss.Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				b.startRow(rs.Resource(), ss.Scope(), span.Attributes())
+				b.appendString(span.TraceID().String())
+				b.appendString(span.SpanID().String())
+				b.appendOptionalString(span.ParentSpanID().String())
+				b.appendString(span.TraceState().AsRaw())
+				b.appendString(span.Name())
+				b.appendString(span.Kind().String())
+				b.appendTimestamp(span.StartTimestamp())
+				b.appendTimestamp(span.EndTimestamp())
+				b.appendDuration(span.StartTimestamp(), span.EndTimestamp())
+				b.appendString(span.Status().Code().String())
+				b.appendString(span.Status().Message())
+				appendEvents(b.next().(*array.ListBuilder), span.Events())
+				appendLinks(b.next().(*array.ListBuilder), span.Links())
+				b.endRow(rs.Resource(), span.Attributes())
+			}
+		}
+	}
+	return b.NewRecord()
+}
+
+// EncodeMetrics converts the data points into records of the metric tables. Only the tables
+// with at least one data point are part of the result. The caller must release the records.
+func (e *Encoder) EncodeMetrics(md pmetric.Metrics) map[Table]arrow.Record {
+	builders := make(map[Table]*recordBuilder)
+	builder := func(table Table) *recordBuilder {
+		b, ok := builders[table]
+		if !ok {
+			b = e.newRecordBuilder(table)
+			builders[table] = b
+		}
+		return b
+	}
+
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		res := rm.Resource()
+		sms := rm.ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			sm := sms.At(j)
+			scope := sm.Scope()
+			metrics := sm.Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				metric := metrics.At(k)
+				//exhaustive:enforce
+				switch metric.Type() {
+				case pmetric.MetricTypeGauge:
+					dps := metric.Gauge().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						b := builder(TableGauge)
+						dp := dps.At(l)
+						b.startMetricRow(res, scope, metric, dp.Attributes(), dp.StartTimestamp(), dp.Timestamp(), dp.Flags())
+						b.appendNumberValue(dp)
+						b.endRow(res, dp.Attributes())
+					}
+				case pmetric.MetricTypeSum:
+					dps := metric.Sum().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						b := builder(TableSum)
+						dp := dps.At(l)
+						b.startMetricRow(res, scope, metric, dp.Attributes(), dp.StartTimestamp(), dp.Timestamp(), dp.Flags())
+						b.appendNumberValue(dp)
+						b.appendString(metric.Sum().AggregationTemporality().String())
+						b.next().(*array.BooleanBuilder).Append(metric.Sum().IsMonotonic())
+						b.endRow(res, dp.Attributes())
+					}
+				case pmetric.MetricTypeHistogram:
+					dps := metric.Histogram().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						b := builder(TableHistogram)
+						dp := dps.At(l)
+						b.startMetricRow(res, scope, metric, dp.Attributes(), dp.StartTimestamp(), dp.Timestamp(), dp.Flags())
+						b.next().(*array.Uint64Builder).Append(dp.Count())
+						b.appendOptionalFloat64(dp.Sum(), dp.HasSum())
+						b.appendOptionalFloat64(dp.Min(), dp.HasMin())
+						b.appendOptionalFloat64(dp.Max(), dp.HasMax())
+						b.appendUint64s(dp.BucketCounts().AsRaw())
+						b.appendFloat64s(dp.ExplicitBounds().AsRaw())
+						b.appendString(metric.Histogram().AggregationTemporality().String())
+						b.endRow(res, dp.Attributes())
+					}
+				case pmetric.MetricTypeExponentialHistogram:
+					dps := metric.ExponentialHistogram().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						b := builder(TableExponentialHistogram)
+						dp := dps.At(l)
+						b.startMetricRow(res, scope, metric, dp.Attributes(), dp.StartTimestamp(), dp.Timestamp(), dp.Flags())
+						b.next().(*array.Uint64Builder).Append(dp.Count())
+						b.appendOptionalFloat64(dp.Sum(), dp.HasSum())
+						b.appendOptionalFloat64(dp.Min(), dp.HasMin())
+						b.appendOptionalFloat64(dp.Max(), dp.HasMax())
+						b.next().(*array.Int32Builder).Append(dp.Scale())
+						b.next().(*array.Uint64Builder).Append(dp.ZeroCount())
+						b.next().(*array.Float64Builder).Append(dp.ZeroThreshold())
+						b.next().(*array.Int32Builder).Append(dp.Positive().Offset())
+						b.appendUint64s(dp.Positive().BucketCounts().AsRaw())
+						b.next().(*array.Int32Builder).Append(dp.Negative().Offset())
+						b.appendUint64s(dp.Negative().BucketCounts().AsRaw())
+						b.appendString(metric.ExponentialHistogram().AggregationTemporality().String())
+						b.endRow(res, dp.Attributes())
+					}
+				case pmetric.MetricTypeSummary:
+					dps := metric.Summary().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						b := builder(TableSummary)
+						dp := dps.At(l)
+						b.startMetricRow(res, scope, metric, dp.Attributes(), dp.StartTimestamp(), dp.Timestamp(), dp.Flags())
+						b.next().(*array.Uint64Builder).Append(dp.Count())
+						b.next().(*array.Float64Builder).Append(dp.Sum())
+						appendQuantileValues(b.next().(*array.ListBuilder), dp.QuantileValues())
+						b.endRow(res, dp.Attributes())
+					}
+				case pmetric.MetricTypeEmpty:
+				}
+			}
+		}
+	}
+
+	records := make(map[Table]arrow.Record, len(builders))
+	for table, b := range builders {
+		records[table] = b.NewRecord()
+		b.Release()
+	}
+	return records
+}
+
+// recordBuilder appends the columns of a row one after the other, in the order of the schema.
+type recordBuilder struct {
+	*array.RecordBuilder
+	promoted []promotedColumn
+	col      int
+}
+
+func (e *Encoder) newRecordBuilder(table Table) *recordBuilder {
+	return &recordBuilder{
+		RecordBuilder: array.NewRecordBuilder(e.mem, e.schemas[table]),
+		promoted:      e.promoted,
+	}
+}
+
+func (b *recordBuilder) next() array.Builder {
+	builder := b.Field(b.col)
+	b.col++
+	return builder
+}
+
+// startRow appends the common columns of a new row.
+func (b *recordBuilder) startRow(resource pcommon.Resource, scope pcommon.InstrumentationScope, attrs pcommon.Map) {
+	b.col = 0
+	appendAttributes(b.next().(*array.MapBuilder), resource.Attributes())
+	b.appendString(scope.Name())
+	b.appendString(scope.Version())
+	appendAttributes(b.next().(*array.MapBuilder), attrs)
+}
+
+func (b *recordBuilder) startMetricRow(resource pcommon.Resource, scope pcommon.InstrumentationScope, metric pmetric.Metric, attrs pcommon.Map, start, ts pcommon.Timestamp, flags pmetric.DataPointFlags) {
+	b.startRow(resource, scope, attrs)
+	b.appendString(metric.Name())
+	b.appendString(metric.Description())
+	b.appendString(metric.Unit())
+	b.appendTimestamp(start)
+	b.appendTimestamp(ts)
+	b.next().(*array.Uint32Builder).Append(uint32(flags))
+}
+
+// endRow appends the promoted columns that end every row.
+func (b *recordBuilder) endRow(resource pcommon.Resource, attrs pcommon.Map) {
+	for _, column := range b.promoted {
+		from := attrs
+		if column.resource {
+			from = resource.Attributes()
+		}
+		if v, ok := from.Get(column.key); ok {
+			b.next().(*array.StringBuilder).Append(v.AsString())
+		} else {
+			b.next().AppendNull()
+		}
+	}
+}
+
+func (b *recordBuilder) appendString(s string) {
+	b.next().(*array.StringBuilder).Append(s)
+}
+
+// appendOptionalString appends the string, or null if it's empty.
+func (b *recordBuilder) appendOptionalString(s string) {
+	if s == "" {
+		b.next().AppendNull()
+		return
+	}
+	b.appendString(s)
+}
+
+// appendTimestamp appends the timestamp, or null if it's unset.
+func (b *recordBuilder) appendTimestamp(ts pcommon.Timestamp) {
+	appendTimestamp(b.next().(*array.TimestampBuilder), ts)
+}
+
+// appendDuration appends the duration in nanoseconds, or null if one of the timestamps is unset.
+func (b *recordBuilder) appendDuration(start, end pcommon.Timestamp) {
+	if start == 0 || end == 0 {
+		b.next().AppendNull()
+		return
+	}
+	b.next().(*array.Int64Builder).Append(int64(end) - int64(start))
+}
+
+func (b *recordBuilder) appendOptionalFloat64(v float64, ok bool) {
+	if !ok {
+		b.next().AppendNull()
+		return
+	}
+	b.next().(*array.Float64Builder).Append(v)
+}
+
+func (b *recordBuilder) appendNumberValue(dp pmetric.NumberDataPoint) {
+	switch dp.ValueType() {
+	case pmetric.NumberDataPointValueTypeDouble:
+		b.appendOptionalFloat64(dp.DoubleValue(), true)
+		b.next().AppendNull()
+	case pmetric.NumberDataPointValueTypeInt:
+		b.next().AppendNull()
+		b.next().(*array.Int64Builder).Append(dp.IntValue())
+	default:
+		b.next().AppendNull()
+		b.next().AppendNull()
+	}
+}
+
+func (b *recordBuilder) appendUint64s(values []uint64) {
+	lb := b.next().(*array.ListBuilder)
+	lb.Append(true)
+	lb.ValueBuilder().(*array.Uint64Builder).AppendValues(values, nil)
+}
+
+func (b *recordBuilder) appendFloat64s(values []float64) {
+	lb := b.next().(*array.ListBuilder)
+	lb.Append(true)
+	lb.ValueBuilder().(*array.Float64Builder).AppendValues(values, nil)
+}
+
+func appendTimestamp(b *array.TimestampBuilder, ts pcommon.Timestamp) {
+	if ts == 0 {
+		b.AppendNull()
+		return
+	}
+	b.Append(arrow.Timestamp(ts))
+}
+
+// appendAttributes appends the attributes as a map of strings. Values that aren't strings are
+// converted with AsString, e.g. slices and maps are encoded as JSON.
+func appendAttributes(b *array.MapBuilder, attrs pcommon.Map) {
+	b.Append(true)
+	keys := b.KeyBuilder().(*array.StringBuilder)
+	items := b.ItemBuilder().(*array.StringBuilder)
+	attrs.Range(func(k string, v pcommon.Value) bool {
+		keys.Append(k)
+		items.Append(v.AsString())
+		return true
+	})
+}
+
+func appendEvents(b *array.ListBuilder, events ptrace.SpanEventSlice) {
+	b.Append(true)
+	sb := b.ValueBuilder().(*array.StructBuilder)
+	for i := 0; i < events.Len(); i++ {
+		event := events.At(i)
+		sb.Append(true)
+		appendTimestamp(sb.FieldBuilder(0).(*array.TimestampBuilder), event.Timestamp())
+		sb.FieldBuilder(1).(*array.StringBuilder).Append(event.Name())
+		appendAttributes(sb.FieldBuilder(2).(*array.MapBuilder), event.Attributes())
+	}
+}
+
+func appendLinks(b *array.ListBuilder, links ptrace.SpanLinkSlice) {
+	b.Append(true)
+	sb := b.ValueBuilder().(*array.StructBuilder)
+	for i := 0; i < links.Len(); i++ {
+		link := links.At(i)
+		sb.Append(true)
+		sb.FieldBuilder(0).(*array.StringBuilder).Append(link.TraceID().String())
+		sb.FieldBuilder(1).(*array.StringBuilder).Append(link.SpanID().String())
+		sb.FieldBuilder(2).(*array.StringBuilder).Append(link.TraceState().AsRaw())
+		appendAttributes(sb.FieldBuilder(3).(*array.MapBuilder), link.Attributes())
+	}
+}
+
+func appendQuantileValues(b *array.ListBuilder, quantiles pmetric.SummaryDataPointValueAtQuantileSlice) {
+	b.Append(true)
+	sb := b.ValueBuilder().(*array.StructBuilder)
+	for i := 0; i < quantiles.Len(); i++ {
+		q := quantiles.At(i)
+		sb.Append(true)
+		sb.FieldBuilder(0).(*array.Float64Builder).Append(q.Quantile())
+		sb.FieldBuilder(1).(*array.Float64Builder).Append(q.Value())
+	}
+}
@@ -0,0 +1,321 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parquet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+const (
+	testTime    = pcommon.Timestamp(1700000000000000000)
+	testTimeStr = "2023-11-14 22:13:20Z"
+)
+
+var (
+	testTraceID = pcommon.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+	testSpanID  = pcommon.SpanID{1, 2, 3, 4, 5, 6, 7, 8}
+)
+
+func newTestMarshaler(t *testing.T) *Marshaler {
+	cfg := NewDefaultConfig()
+	cfg.PromotedResourceAttributes = []string{"service.name"}
+	cfg.PromotedAttributes = []string{"http.route"}
+	m, err := NewMarshaler(cfg)
+	require.NoError(t, err)
+	return m
+}
+
+func fillResourceAndScope(resource pcommon.Resource, scope pcommon.InstrumentationScope) {
+	resource.Attributes().PutStr("service.name", "checkout")
+	resource.Attributes().PutInt("process.pid", 42)
+	scope.SetName("github.com/example/checkout")
+	scope.SetVersion("v1.2.3")
+}
+
+func commonColumns(attrs []any, route any) map[string]any {
+	return map[string]any{
+		"resource_attributes": []any{
+			map[string]any{"key": "service.name", "value": "checkout"},
+			map[string]any{"key": "process.pid", "value": "42"},
+		},
+		"scope_name":            "github.com/example/checkout",
+		"scope_version":         "v1.2.3",
+		"attributes":            attrs,
+		"resource_service_name": "checkout",
+		"attribute_http_route":  route,
+	}
+}
+
+func withColumns(row map[string]any, columns map[string]any) map[string]any {
+	for k, v := range columns {
+		row[k] = v
+	}
+	return row
+}
+
+func TestEncodeLogs(t *testing.T) {
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	sl := rl.ScopeLogs().AppendEmpty()
+	fillResourceAndScope(rl.Resource(), sl.Scope())
+
+	lr := sl.LogRecords().AppendEmpty()
+	lr.SetTimestamp(testTime)
+	lr.SetObservedTimestamp(testTime)
+	lr.SetSeverityNumber(plog.SeverityNumberError)
+	lr.SetSeverityText("ERROR")
+	lr.Body().SetStr("payment failed")
+	lr.SetTraceID(testTraceID)
+	lr.SetSpanID(testSpanID)
+	lr.SetFlags(plog.DefaultLogRecordFlags.WithIsSampled(true))
+	lr.Attributes().PutStr("http.route", "/pay")
+
+	lr = sl.LogRecords().AppendEmpty()
+	lr.Body().SetEmptyMap().PutBool("ok", true)
+
+	data, err := newTestMarshaler(t).MarshalLogs(ld)
+	require.NoError(t, err)
+	assert.Equal(t, []map[string]any{
+		withColumns(commonColumns([]any{map[string]any{"key": "http.route", "value": "/pay"}}, "/pay"), map[string]any{
+			"time":            testTimeStr,
+			"observed_time":   testTimeStr,
+			"severity_number": float64(17),
+			"severity_text":   "ERROR",
+			"body":            "payment failed",
+			"trace_id":        "0102030405060708090a0b0c0d0e0f10",
+			"span_id":         "0102030405060708",
+			"flags":           float64(1),
+		}),
+		withColumns(commonColumns([]any{}, nil), map[string]any{
+			"time":            nil,
+			"observed_time":   nil,
+			"severity_number": float64(0),
+			"severity_text":   "",
+			"body":            `{"ok":true}`,
+			"trace_id":        nil,
+			"span_id":         nil,
+			"flags":           float64(0),
+		}),
+	}, readRows(t, data))
+}
+
+func TestEncodeTraces(t *testing.T) {
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	ss := rs.ScopeSpans().AppendEmpty()
+	fillResourceAndScope(rs.Resource(), ss.Scope())
+
+	span := ss.Spans().AppendEmpty()
+	span.SetTraceID(testTraceID)
+	span.SetSpanID(testSpanID)
+	span.SetParentSpanID(pcommon.SpanID{8, 7, 6, 5, 4, 3, 2, 1})
+	span.TraceState().FromRaw("vendor=value")
+	span.SetName("POST /pay")
+	span.SetKind(ptrace.SpanKindServer)
+	span.SetStartTimestamp(testTime)
+	span.SetEndTimestamp(testTime + 1500)
+	span.Status().SetCode(ptrace.StatusCodeError)
+	span.Status().SetMessage("card declined")
+	span.Attributes().PutStr("http.route", "/pay")
+	event := span.Events().AppendEmpty()
+	event.SetName("exception")
+	event.SetTimestamp(testTime + 1000)
+	event.Attributes().PutStr("exception.type", "CardDeclined")
+	link := span.Links().AppendEmpty()
+	link.SetTraceID(testTraceID)
+	link.SetSpanID(testSpanID)
+
+	data, err := newTestMarshaler(t).MarshalTraces(td)
+	require.NoError(t, err)
+	assert.Equal(t, []map[string]any{
+		withColumns(commonColumns([]any{map[string]any{"key": "http.route", "value": "/pay"}}, "/pay"), map[string]any{
+			"trace_id":       "0102030405060708090a0b0c0d0e0f10",
+			"span_id":        "0102030405060708",
+			"parent_span_id": "0807060504030201",
+			"trace_state":    "vendor=value",
+			"name":           "POST /pay",
+			"kind":           "Server",
+			"start_time":     testTimeStr,
+			"end_time":       "2023-11-14 22:13:20.0000015Z",
+			"duration_ns":    float64(1500),
+			"status_code":    "Error",
+			"status_message": "card declined",
+			"events": []any{map[string]any{
+				"time":       "2023-11-14 22:13:20.000001Z",
+				"name":       "exception",
+				"attributes": []any{map[string]any{"key": "exception.type", "value": "CardDeclined"}},
+			}},
+			"links": []any{map[string]any{
+				"trace_id":    "0102030405060708090a0b0c0d0e0f10",
+				"span_id":     "0102030405060708",
+				"trace_state": "",
+				"attributes":  []any{},
+			}},
+		}),
+	}, readRows(t, data))
+}
+
+func TestEncodeMetrics(t *testing.T) {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	sm := rm.ScopeMetrics().AppendEmpty()
+	fillResourceAndScope(rm.Resource(), sm.Scope())
+
+	gauge := sm.Metrics().AppendEmpty()
+	gauge.SetName("queue.size")
+	gauge.SetUnit("1")
+	dp := gauge.SetEmptyGauge().DataPoints().AppendEmpty()
+	dp.SetTimestamp(testTime)
+	dp.SetIntValue(7)
+
+	sum := sm.Metrics().AppendEmpty()
+	sum.SetName("http.requests")
+	sum.SetDescription("Number of requests")
+	sum.SetEmptySum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	sum.Sum().SetIsMonotonic(true)
+	dp = sum.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(testTime)
+	dp.SetTimestamp(testTime)
+	dp.SetDoubleValue(12.5)
+	dp.Attributes().PutStr("http.route", "/pay")
+
+	histogram := sm.Metrics().AppendEmpty()
+	histogram.SetName("http.duration")
+	histogram.SetEmptyHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	hdp := histogram.Histogram().DataPoints().AppendEmpty()
+	hdp.SetTimestamp(testTime)
+	hdp.SetCount(3)
+	hdp.SetSum(1.5)
+	hdp.SetMax(1)
+	hdp.BucketCounts().FromRaw([]uint64{1, 2})
+	hdp.ExplicitBounds().FromRaw([]float64{0.5})
+
+	expHistogram := sm.Metrics().AppendEmpty()
+	expHistogram.SetName("http.duration.exp")
+	expHistogram.SetEmptyExponentialHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	edp := expHistogram.ExponentialHistogram().DataPoints().AppendEmpty()
+	edp.SetTimestamp(testTime)
+	edp.SetCount(4)
+	edp.SetScale(2)
+	edp.SetZeroCount(1)
+	edp.Positive().SetOffset(-1)
+	edp.Positive().BucketCounts().FromRaw([]uint64{1, 2})
+	edp.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+
+	summary := sm.Metrics().AppendEmpty()
+	summary.SetName("gc.pause")
+	sdp := summary.SetEmptySummary().DataPoints().AppendEmpty()
+	sdp.SetTimestamp(testTime)
+	sdp.SetCount(2)
+	sdp.SetSum(3)
+	q := sdp.QuantileValues().AppendEmpty()
+	q.SetQuantile(0.99)
+	q.SetValue(2.5)
+
+	files, err := newTestMarshaler(t).MarshalMetrics(md)
+	require.NoError(t, err)
+	require.Len(t, files, len(MetricTables))
+
+	metricColumns := func(name, description, unit string, start any, attrs []any, route any) map[string]any {
+		return withColumns(commonColumns(attrs, route), map[string]any{
+			"metric_name":        name,
+			"metric_description": description,
+			"metric_unit":        unit,
+			"start_time":         start,
+			"time":               testTimeStr,
+			"flags":              float64(0),
+		})
+	}
+	routeAttrs := []any{map[string]any{"key": "http.route", "value": "/pay"}}
+
+	assert.Equal(t, []map[string]any{
+		withColumns(metricColumns("queue.size", "", "1", nil, []any{}, nil), map[string]any{
+			"value_double": nil,
+			"value_int":    float64(7),
+		}),
+	}, readRows(t, files[TableGauge]))
+
+	assert.Equal(t, []map[string]any{
+		withColumns(metricColumns("http.requests", "Number of requests", "", testTimeStr, routeAttrs, "/pay"), map[string]any{
+			"value_double":            12.5,
+			"value_int":               nil,
+			"aggregation_temporality": "Cumulative",
+			"is_monotonic":            true,
+		}),
+	}, readRows(t, files[TableSum]))
+
+	assert.Equal(t, []map[string]any{
+		withColumns(metricColumns("http.duration", "", "", nil, []any{}, nil), map[string]any{
+			"count":                   float64(3),
+			"sum":                     1.5,
+			"min":                     nil,
+			"max":                     float64(1),
+			"bucket_counts":           []any{float64(1), float64(2)},
+			"explicit_bounds":         []any{0.5},
+			"aggregation_temporality": "Delta",
+		}),
+	}, readRows(t, files[TableHistogram]))
+
+	expRow := withColumns(metricColumns("http.duration.exp", "", "", nil, []any{}, nil), map[string]any{
+		"count":                   float64(4),
+		"sum":                     nil,
+		"min":                     nil,
+		"max":                     nil,
+		"scale":                   float64(2),
+		"zero_count":              float64(1),
+		"zero_threshold":          float64(0),
+		"positive_offset":         float64(-1),
+		"positive_bucket_counts":  []any{float64(1), float64(2)},
+		"negative_offset":         float64(0),
+		"negative_bucket_counts":  []any{},
+		"aggregation_temporality": "Delta",
+	})
+	expRow["flags"] = float64(1)
+	assert.Equal(t, []map[string]any{expRow}, readRows(t, files[TableExponentialHistogram]))
+
+	assert.Equal(t, []map[string]any{
+		withColumns(metricColumns("gc.pause", "", "", nil, []any{}, nil), map[string]any{
+			"count":           float64(2),
+			"sum":             float64(3),
+			"quantile_values": []any{map[string]any{"quantile": 0.99, "value": 2.5}},
+		}),
+	}, readRows(t, files[TableSummary]))
+}
+
+func TestEncodeMetricsOnlyNonEmptyTables(t *testing.T) {
+	md := pmetric.NewMetrics()
+	metric := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	metric.SetEmptyGauge().DataPoints().AppendEmpty().SetDoubleValue(1)
+	md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().AppendEmpty().SetEmptySum()
+
+	encoder, err := NewEncoder(NewDefaultConfig())
+	require.NoError(t, err)
+	records := encoder.EncodeMetrics(md)
+	require.Len(t, records, 1)
+	assert.EqualValues(t, 1, records[TableGauge].NumRows())
+	records[TableGauge].Release()
+}
+
+func TestSchemaPromotedColumns(t *testing.T) {
+	cfg := NewDefaultConfig()
+	cfg.PromotedResourceAttributes = []string{"k8s.namespace.name"}
+	cfg.PromotedAttributes = []string{"http.route"}
+	encoder, err := NewEncoder(cfg)
+	require.NoError(t, err)
+
+	for _, table := range allTables {
+		schema := encoder.Schema(table)
+		fields := schema.Fields()
+		assert.Equal(t, "resource_attributes", fields[0].Name)
+		assert.Equal(t, "resource_k8s_namespace_name", fields[len(fields)-2].Name)
+		assert.Equal(t, "attribute_http_route", fields[len(fields)-1].Name)
+	}
+}
@@ -0,0 +1,218 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parquet // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/parquet"
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/apache/arrow/go/v17/arrow"
+)
+
+// Table identifies the kind of rows of a Parquet file. Each table has its own schema.
+type Table string
+
+const (
+	TableLogs                 Table = "logs"
+	TableSpans                Table = "spans"
+	TableGauge                Table = "gauge"
+	TableSum                  Table = "sum"
+	TableHistogram            Table = "histogram"
+	TableExponentialHistogram Table = "exponential_histogram"
+	TableSummary              Table = "summary"
+)
+
+// MetricTables are the tables the data points of metrics are split into, one per metric type.
+var MetricTables = []Table{TableGauge, TableSum, TableHistogram, TableExponentialHistogram, TableSummary}
+
+var allTables = append([]Table{TableLogs, TableSpans}, MetricTables...)
+
+const (
+	resourceColumnPrefix  = "resource_"
+	attributeColumnPrefix = "attribute_"
+)
+
+var invalidColumnCharsRegexp = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+var (
+	timestampType  = arrow.FixedWidthTypes.Timestamp_ns
+	attributesType = arrow.MapOf(arrow.BinaryTypes.String, arrow.BinaryTypes.String)
+)
+
+func field(name string, dataType arrow.DataType, nullable bool) arrow.Field {
+	return arrow.Field{Name: name, Type: dataType, Nullable: nullable}
+}
+
+var commonFields = []arrow.Field{
+	field("resource_attributes", attributesType, false),
+	field("scope_name", arrow.BinaryTypes.String, false),
+	field("scope_version", arrow.BinaryTypes.String, false),
+	field("attributes", attributesType, false),
+}
+
+var logFields = []arrow.Field{
+	field("time", timestampType, true),
+	field("observed_time", timestampType, true),
+	field("severity_number", arrow.PrimitiveTypes.Int32, false),
+	field("severity_text", arrow.BinaryTypes.String, false),
+	field("body", arrow.BinaryTypes.String, false),
+	field("trace_id", arrow.BinaryTypes.String, true),
+	field("span_id", arrow.BinaryTypes.String, true),
+	field("flags", arrow.PrimitiveTypes.Uint32, false),
+}
+
+var spanFields = []arrow.Field{
+	field("trace_id", arrow.BinaryTypes.String, false),
+	field("span_id", arrow.BinaryTypes.String, false),
+	field("parent_span_id", arrow.BinaryTypes.String, true),
+	field("trace_state", arrow.BinaryTypes.String, false),
+	field("name", arrow.BinaryTypes.String, false),
+	field("kind", arrow.BinaryTypes.String, false),
+	field("start_time", timestampType, true),
+	field("end_time", timestampType, true),
+	field("duration_ns", arrow.PrimitiveTypes.Int64, true),
+	field("status_code", arrow.BinaryTypes.String, false),
+	field("status_message", arrow.BinaryTypes.String, false),
+	field("events", arrow.ListOf(arrow.StructOf(
+		field("time", timestampType, true),
+		field("name", arrow.BinaryTypes.String, false),
+		field("attributes", attributesType, false),
+	)), false),
+	field("links", arrow.ListOf(arrow.StructOf(
+		field("trace_id", arrow.BinaryTypes.String, false),
+		field("span_id", arrow.BinaryTypes.String, false),
+		field("trace_state", arrow.BinaryTypes.String, false),
+		field("attributes", attributesType, false),
+	)), false),
+}
+
+var metricFields = []arrow.Field{
+	field("metric_name", arrow.BinaryTypes.String, false),
+	field("metric_description", arrow.BinaryTypes.String, false),
+	field("metric_unit", arrow.BinaryTypes.String, false),
+	field("start_time", timestampType, true),
+	field("time", timestampType, true),
+	field("flags", arrow.PrimitiveTypes.Uint32, false),
+}
+
+var (
+	numberValueFields = []arrow.Field{
+		field("value_double", arrow.PrimitiveTypes.Float64, true),
+		field("value_int", arrow.PrimitiveTypes.Int64, true),
+	}
+	sumFields = []arrow.Field{
+		field("aggregation_temporality", arrow.BinaryTypes.String, false),
+		field("is_monotonic", arrow.FixedWidthTypes.Boolean, false),
+	}
+	histogramFields = []arrow.Field{
+		field("count", arrow.PrimitiveTypes.Uint64, false),
+		field("sum", arrow.PrimitiveTypes.Float64, true),
+		field("min", arrow.PrimitiveTypes.Float64, true),
+		field("max", arrow.PrimitiveTypes.Float64, true),
+		field("bucket_counts", arrow.ListOf(arrow.PrimitiveTypes.Uint64), false),
+		field("explicit_bounds", arrow.ListOf(arrow.PrimitiveTypes.Float64), false),
+		field("aggregation_temporality", arrow.BinaryTypes.String, false),
+	}
+	exponentialHistogramFields = []arrow.Field{
+		field("count", arrow.PrimitiveTypes.Uint64, false),
+		field("sum", arrow.PrimitiveTypes.Float64, true),
+		field("min", arrow.PrimitiveTypes.Float64, true),
+		field("max", arrow.PrimitiveTypes.Float64, true),
+		field("scale", arrow.PrimitiveTypes.Int32, false),
+		field("zero_count", arrow.PrimitiveTypes.Uint64, false),
+		field("zero_threshold", arrow.PrimitiveTypes.Float64, false),
+		field("positive_offset", arrow.PrimitiveTypes.Int32, false),
+		field("positive_bucket_counts", arrow.ListOf(arrow.PrimitiveTypes.Uint64), false),
+		field("negative_offset", arrow.PrimitiveTypes.Int32, false),
+		field("negative_bucket_counts", arrow.ListOf(arrow.PrimitiveTypes.Uint64), false),
+		field("aggregation_temporality", arrow.BinaryTypes.String, false),
+	}
+	summaryFields = []arrow.Field{
+		field("count", arrow.PrimitiveTypes.Uint64, false),
+		field("sum", arrow.PrimitiveTypes.Float64, false),
+		field("quantile_values", arrow.ListOf(arrow.StructOf(
+			field("quantile", arrow.PrimitiveTypes.Float64, false),
+			field("value", arrow.PrimitiveTypes.Float64, false),
+		)), false),
+	}
+)
+
+// tableFields returns the columns specific to the table, which follow the common columns.
+func tableFields(table Table) []arrow.Field {
+	switch table {
+	case TableLogs:
+		return logFields
+	case TableSpans:
+		return spanFields
+	case TableGauge:
+		return concatFields(metricFields, numberValueFields)
+	case TableSum:
+		return concatFields(metricFields, numberValueFields, sumFields)
+	case TableHistogram:
+		return concatFields(metricFields, histogramFields)
+	case TableExponentialHistogram:
+		return concatFields(metricFields, exponentialHistogramFields)
+	case TableSummary:
+		return concatFields(metricFields, summaryFields)
+	default:
+		return nil
+	}
+}
+
+func concatFields(fields ...[]arrow.Field) []arrow.Field {
+	var all []arrow.Field
+	for _, f := range fields {
+		all = append(all, f...)
+	}
+	return all
+}
+
+// promotedColumn is an attribute copied to its own top-level column.
+type promotedColumn struct {
+	name     string
+	key      string
+	resource bool
+}
+
+// promotedColumns returns the promoted columns, in the order they are appended to every schema.
+func (c Config) promotedColumns() ([]promotedColumn, error) {
+	names := map[string]bool{}
+	for _, table := range allTables {
+		for _, f := range concatFields(commonFields, tableFields(table)) {
+			names[f.Name] = true
+		}
+	}
+
+	var columns []promotedColumn
+	var errs error
+	add := func(key, prefix string, resource bool) {
+		if key == "" {
+			errs = errors.Join(errs, errors.New("promoted attribute names must not be empty"))
+			return
+		}
+		name := prefix + invalidColumnCharsRegexp.ReplaceAllString(key, "_")
+		if names[name] {
+			errs = errors.Join(errs, fmt.Errorf("promoted attribute %q conflicts with column %q", key, name))
+			return
+		}
+		names[name] = true
+		columns = append(columns, promotedColumn{name: name, key: key, resource: resource})
+	}
+	for _, key := range c.PromotedResourceAttributes {
+		add(key, resourceColumnPrefix, true)
+	}
+	for _, key := range c.PromotedAttributes {
+		add(key, attributeColumnPrefix, false)
+	}
+	return columns, errs
+}
+
+func newSchema(table Table, promoted []promotedColumn) *arrow.Schema {
+	fields := concatFields(commonFields, tableFields(table))
+	for _, column := range promoted {
+		fields = append(fields, field(column.name, arrow.BinaryTypes.String, true))
+	}
+	return arrow.NewSchema(fields, nil)
+}
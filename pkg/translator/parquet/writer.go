@@ -0,0 +1,144 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parquet // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/parquet"
+
+import (
+	"bytes"
+	"errors"
+	"io"
+
+	"github.com/apache/arrow/go/v17/arrow"
+	pq "github.com/apache/arrow/go/v17/parquet"
+	"github.com/apache/arrow/go/v17/parquet/pqarrow"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// Writer writes the records of a table to a Parquet file. Rows are buffered in memory until
+// the current row group is full, so a Writer must be closed to write the last row group and
+// the footer of the file.
+type Writer struct {
+	fw *pqarrow.FileWriter
+	cw *countingWriter
+}
+
+// NewWriter creates a Writer of records with the given schema. Closing the Writer also
+// closes w if it implements io.Closer.
+func NewWriter(w io.Writer, schema *arrow.Schema, cfg Config) (*Writer, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	// Error checked in Validate()
+	codec, _ := cfg.Compression.codec()
+	props := pq.NewWriterProperties(
+		pq.WithCompression(codec),
+		pq.WithMaxRowGroupLength(int64(cfg.RowGroupSize)),
+	)
+	cw := &countingWriter{w: w}
+	fw, err := pqarrow.NewFileWriter(schema, cw, props, pqarrow.NewArrowWriterProperties(pqarrow.WithStoreSchema()))
+	if err != nil {
+		return nil, err
+	}
+	return &Writer{fw: fw, cw: cw}, nil
+}
+
+// Write appends the rows of the record to the file.
+func (w *Writer) Write(rec arrow.Record) error {
+	return w.fw.WriteBuffered(rec)
+}
+
+// Size returns an estimate of the size of the file: the bytes already written plus the
+// compressed size of the buffered row group.
+func (w *Writer) Size() int64 {
+	return w.cw.n + w.fw.RowGroupTotalCompressedBytes()
+}
+
+// Close flushes the buffered rows and writes the footer of the file.
+func (w *Writer) Close() error {
+	return w.fw.Close()
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+func (c *countingWriter) Close() error {
+	if closer, ok := c.w.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// Marshaler encodes OTLP data into complete, self-contained Parquet files.
+type Marshaler struct {
+	cfg     Config
+	encoder *Encoder
+}
+
+// NewMarshaler creates a Marshaler for the given configuration.
+func NewMarshaler(cfg Config) (*Marshaler, error) {
+	encoder, err := NewEncoder(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &Marshaler{cfg: cfg, encoder: encoder}, nil
+}
+
+// MarshalLogs encodes the log records into a Parquet file of the logs table.
+func (m *Marshaler) MarshalLogs(ld plog.Logs) ([]byte, error) {
+	rec := m.encoder.EncodeLogs(ld)
+	defer rec.Release()
+	return m.marshal(rec)
+}
+
+// MarshalTraces encodes the spans into a Parquet file of the spans table.
+func (m *Marshaler) MarshalTraces(td ptrace.Traces) ([]byte, error) {
+	rec := m.encoder.EncodeTraces(td)
+	defer rec.Release()
+	return m.marshal(rec)
+}
+
+// MarshalMetrics encodes the data points into one Parquet file per metric table. Only the
+// tables with at least one data point are part of the result.
+func (m *Marshaler) MarshalMetrics(md pmetric.Metrics) (map[Table][]byte, error) {
+	records := m.encoder.EncodeMetrics(md)
+	files := make(map[Table][]byte, len(records))
+	var errs error
+	for table, rec := range records {
+		buf, err := m.marshal(rec)
+		rec.Release()
+		if err != nil {
+			errs = errors.Join(errs, err)
+			continue
+		}
+		files[table] = buf
+	}
+	if errs != nil {
+		return nil, errs
+	}
+	return files, nil
+}
+
+func (m *Marshaler) marshal(rec arrow.Record) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	w, err := NewWriter(buf, rec.Schema(), m.cfg)
+	if err != nil {
+		return nil, err
+	}
+	if err = w.Write(rec); err != nil {
+		return nil, errors.Join(err, w.Close())
+	}
+	if err = w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,98 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parquet
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow/go/v17/arrow/array"
+	"github.com/apache/arrow/go/v17/arrow/memory"
+	pq "github.com/apache/arrow/go/v17/parquet"
+	"github.com/apache/arrow/go/v17/parquet/file"
+	"github.com/apache/arrow/go/v17/parquet/pqarrow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+// readRows reads back a Parquet file, returning its rows as decoded JSON objects.
+func readRows(t *testing.T, data []byte) []map[string]any {
+	tbl, err := pqarrow.ReadTable(context.Background(), bytes.NewReader(data), pq.NewReaderProperties(memory.DefaultAllocator), pqarrow.ArrowReadProperties{}, memory.DefaultAllocator)
+	require.NoError(t, err)
+	defer tbl.Release()
+
+	tr := array.NewTableReader(tbl, -1)
+	defer tr.Release()
+	var rows []map[string]any
+	for tr.Next() {
+		buf := &bytes.Buffer{}
+		require.NoError(t, array.RecordToJSON(tr.Record(), buf))
+		scanner := bufio.NewScanner(buf)
+		for scanner.Scan() {
+			row := map[string]any{}
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &row))
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+func testLogs(n int) plog.Logs {
+	ld := plog.NewLogs()
+	lrs := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	for i := 0; i < n; i++ {
+		lrs.AppendEmpty().Body().SetStr(fmt.Sprintf("log record %d: %s", i, strings.Repeat("lorem ipsum dolor sit amet ", 10)))
+	}
+	return ld
+}
+
+func TestWriterRowGroups(t *testing.T) {
+	cfg := NewDefaultConfig()
+	cfg.RowGroupSize = 10
+	encoder, err := NewEncoder(cfg)
+	require.NoError(t, err)
+
+	buf := &bytes.Buffer{}
+	w, err := NewWriter(buf, encoder.Schema(TableLogs), cfg)
+	require.NoError(t, err)
+
+	for i := 0; i < 5; i++ {
+		rec := encoder.EncodeLogs(testLogs(5))
+		require.NoError(t, w.Write(rec))
+		rec.Release()
+	}
+	assert.Positive(t, w.Size())
+	require.NoError(t, w.Close())
+
+	reader, err := file.NewParquetReader(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	defer reader.Close()
+	assert.Equal(t, 3, reader.NumRowGroups())
+	assert.EqualValues(t, 25, reader.NumRows())
+	assert.Len(t, readRows(t, buf.Bytes()), 25)
+}
+
+func TestWriterCompression(t *testing.T) {
+	sizes := map[Compression]int{}
+	for _, compression := range []Compression{CompressionZstd, CompressionSnappy, CompressionGzip, CompressionNone} {
+		cfg := NewDefaultConfig()
+		cfg.Compression = compression
+		m, err := NewMarshaler(cfg)
+		require.NoError(t, err)
+
+		data, err := m.MarshalLogs(testLogs(1000))
+		require.NoError(t, err)
+		assert.Len(t, readRows(t, data), 1000)
+		sizes[compression] = len(data)
+	}
+	assert.Less(t, sizes[CompressionZstd], sizes[CompressionNone])
+	assert.Less(t, sizes[CompressionSnappy], sizes[CompressionNone])
+	assert.Less(t, sizes[CompressionGzip], sizes[CompressionNone])
+}
@@ -507,9 +507,28 @@ func (c *prometheusConverter) addTimeSeriesIfNeeded(lbls []prompb.Label, startTi
 
 // addResourceTargetInfo converts the resource to the target info metric.
 func addResourceTargetInfo(resource pcommon.Resource, settings Settings, timestamp pcommon.Timestamp, converter *prometheusConverter) {
-	if settings.DisableTargetInfo || timestamp == 0 {
+	if timestamp == 0 {
 		return
 	}
+	labels := targetInfoLabels(resource, settings)
+	if labels == nil {
+		return
+	}
+
+	sample := &prompb.Sample{
+		Value: float64(1),
+		// convert ns to ms
+		Timestamp: convertTimeStamp(timestamp),
+	}
+	converter.addSample(sample, labels)
+}
+
+// targetInfoLabels returns the labels of the target info metric of the resource,
+// or nil if the target info metric shouldn't be generated.
+func targetInfoLabels(resource pcommon.Resource, settings Settings) []prompb.Label {
+	if settings.DisableTargetInfo {
+		return nil
+	}
 
 	attributes := resource.Attributes()
 	identifyingAttrs := []string{
@@ -526,7 +545,7 @@ func addResourceTargetInfo(resource pcommon.Resource, settings Settings, timesta
 	}
 	if nonIdentifyingAttrsCount == 0 {
 		// If we only have job + instance, then target_info isn't useful, so don't add it.
-		return
+		return nil
 	}
 
 	name := prometheustranslator.TargetInfoMetricName
@@ -545,15 +564,9 @@ func addResourceTargetInfo(resource pcommon.Resource, settings Settings, timesta
 
 	if !haveIdentifier {
 		// We need at least one identifying label to generate target_info.
-		return
+		return nil
 	}
-
-	sample := &prompb.Sample{
-		Value: float64(1),
-		// convert ns to ms
-		Timestamp: convertTimeStamp(timestamp),
-	}
-	converter.addSample(sample, labels)
+	return labels
 }
 
 // convertTimeStamp converts OTLP timestamp in ns to timestamp in ms
@@ -0,0 +1,406 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewrite // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheusremotewrite"
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/value"
+	"github.com/prometheus/prometheus/prompb"
+	writev2 "github.com/prometheus/prometheus/prompb/io/prometheus/write/v2"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/multierr"
+
+	prometheustranslator "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheus"
+)
+
+// FromMetricsV2 converts pmetric.Metrics to a Prometheus remote write 2.0 request.
+// The strings of the request are interned in its symbols table. Exponential histograms are converted
+// to native histograms, and the metadata, exemplars and created timestamps are sent with each series,
+// so the ExportCreatedMetric and SendMetadata settings are ignored.
+func FromMetricsV2(md pmetric.Metrics, settings Settings) (*writev2.Request, error) {
+	c := newPrometheusConverterV2()
+	errs := c.fromMetrics(md, settings)
+	return c.request(), errs
+}
+
+// metadataV2 is the metadata of a remote write 2.0 series, before its strings are interned.
+type metadataV2 struct {
+	metricType writev2.Metadata_MetricType
+	help       string
+	unit       string
+}
+
+// timeSeriesV2 is a remote write 2.0 series, before its strings are interned.
+type timeSeriesV2 struct {
+	labels           []prompb.Label
+	metadata         metadataV2
+	createdTimestamp int64
+	samples          []writev2.Sample
+	histograms       []writev2.Histogram
+	exemplars        []prompb.Exemplar
+}
+
+// prometheusConverterV2 converts from OTel write format to Prometheus remote write 2.0 format.
+type prometheusConverterV2 struct {
+	unique    map[uint64]*timeSeriesV2
+	conflicts map[uint64][]*timeSeriesV2
+}
+
+func newPrometheusConverterV2() *prometheusConverterV2 {
+	return &prometheusConverterV2{
+		unique:    map[uint64]*timeSeriesV2{},
+		conflicts: map[uint64][]*timeSeriesV2{},
+	}
+}
+
+// fromMetrics converts pmetric.Metrics to Prometheus remote write 2.0 series.
+func (c *prometheusConverterV2) fromMetrics(md pmetric.Metrics, settings Settings) (errs error) {
+	resourceMetricsSlice := md.ResourceMetrics()
+	for i := 0; i < resourceMetricsSlice.Len(); i++ {
+		resourceMetrics := resourceMetricsSlice.At(i)
+		resource := resourceMetrics.Resource()
+		scopeMetricsSlice := resourceMetrics.ScopeMetrics()
+		// keep track of the most recent timestamp in the ResourceMetrics for
+		// use with the "target" info metric
+		var mostRecentTimestamp pcommon.Timestamp
+		for j := 0; j < scopeMetricsSlice.Len(); j++ {
+			metricSlice := scopeMetricsSlice.At(j).Metrics()
+
+			for k := 0; k < metricSlice.Len(); k++ {
+				metric := metricSlice.At(k)
+				mostRecentTimestamp = maxTimestamp(mostRecentTimestamp, mostRecentTimestampInMetric(metric))
+
+				if !isValidAggregationTemporality(metric) {
+					errs = multierr.Append(errs, fmt.Errorf("invalid temporality and type combination for metric %q", metric.Name()))
+					continue
+				}
+
+				promName := prometheustranslator.BuildCompliantName(metric, settings.Namespace, settings.AddMetricSuffixes)
+				meta := metadataV2{
+					// The metric types of both protocols have the same values.
+					metricType: writev2.Metadata_MetricType(otelMetricTypeToPromMetricType(metric)),
+					help:       metric.Description(),
+					unit:       metric.Unit(),
+				}
+
+				// handle individual metrics based on type
+				//exhaustive:enforce
+				switch metric.Type() {
+				case pmetric.MetricTypeGauge:
+					dataPoints := metric.Gauge().DataPoints()
+					if dataPoints.Len() == 0 {
+						errs = multierr.Append(errs, fmt.Errorf("empty data points. %s is dropped", metric.Name()))
+						break
+					}
+					c.addNumberDataPoints(dataPoints, resource, settings, promName, meta, false)
+				case pmetric.MetricTypeSum:
+					dataPoints := metric.Sum().DataPoints()
+					if dataPoints.Len() == 0 {
+						errs = multierr.Append(errs, fmt.Errorf("empty data points. %s is dropped", metric.Name()))
+						break
+					}
+					c.addNumberDataPoints(dataPoints, resource, settings, promName, meta, metric.Sum().IsMonotonic())
+				case pmetric.MetricTypeHistogram:
+					dataPoints := metric.Histogram().DataPoints()
+					if dataPoints.Len() == 0 {
+						errs = multierr.Append(errs, fmt.Errorf("empty data points. %s is dropped", metric.Name()))
+						break
+					}
+					c.addHistogramDataPoints(dataPoints, resource, settings, promName, meta)
+				case pmetric.MetricTypeExponentialHistogram:
+					dataPoints := metric.ExponentialHistogram().DataPoints()
+					if dataPoints.Len() == 0 {
+						errs = multierr.Append(errs, fmt.Errorf("empty data points. %s is dropped", metric.Name()))
+						break
+					}
+					errs = multierr.Append(errs, c.addExponentialHistogramDataPoints(dataPoints, resource, settings, promName, meta))
+				case pmetric.MetricTypeSummary:
+					dataPoints := metric.Summary().DataPoints()
+					if dataPoints.Len() == 0 {
+						errs = multierr.Append(errs, fmt.Errorf("empty data points. %s is dropped", metric.Name()))
+						break
+					}
+					c.addSummaryDataPoints(dataPoints, resource, settings, promName, meta)
+				default:
+					errs = multierr.Append(errs, errors.New("unsupported metric type"))
+				}
+			}
+		}
+		c.addResourceTargetInfo(resource, settings, mostRecentTimestamp)
+	}
+
+	return
+}
+
+// addNumberDataPoints adds the data points of gauges and sums. The start timestamps of the data points are
+// sent as created timestamps for counters only.
+func (c *prometheusConverterV2) addNumberDataPoints(dataPoints pmetric.NumberDataPointSlice,
+	resource pcommon.Resource, settings Settings, name string, meta metadataV2, isCounter bool) {
+	for x := 0; x < dataPoints.Len(); x++ {
+		pt := dataPoints.At(x)
+		lbls := createAttributes(
+			resource,
+			pt.Attributes(),
+			settings.ExternalLabels,
+			nil,
+			true,
+			model.MetricNameLabel,
+			name,
+		)
+		sample := writev2.Sample{
+			Timestamp: convertTimeStamp(pt.Timestamp()),
+		}
+		switch pt.ValueType() {
+		case pmetric.NumberDataPointValueTypeInt:
+			sample.Value = float64(pt.IntValue())
+		case pmetric.NumberDataPointValueTypeDouble:
+			sample.Value = pt.DoubleValue()
+		}
+		if pt.Flags().NoRecordedValue() {
+			sample.Value = math.Float64frombits(value.StaleNaN)
+		}
+
+		var createdTimestamp int64
+		if isCounter {
+			createdTimestamp = convertStartTimeStamp(pt.StartTimestamp())
+		}
+		ts := c.getOrCreateTimeSeries(lbls, meta, createdTimestamp)
+		ts.samples = append(ts.samples, sample)
+		if isCounter {
+			ts.exemplars = append(ts.exemplars, getPromExemplars(pt)...)
+		}
+	}
+}
+
+func (c *prometheusConverterV2) addHistogramDataPoints(dataPoints pmetric.HistogramDataPointSlice,
+	resource pcommon.Resource, settings Settings, baseName string, meta metadataV2) {
+	for x := 0; x < dataPoints.Len(); x++ {
+		pt := dataPoints.At(x)
+		timestamp := convertTimeStamp(pt.Timestamp())
+		createdTimestamp := convertStartTimeStamp(pt.StartTimestamp())
+		baseLabels := createAttributes(resource, pt.Attributes(), settings.ExternalLabels, nil, false)
+		addSample := func(v float64, lbls []prompb.Label) *timeSeriesV2 {
+			if pt.Flags().NoRecordedValue() {
+				v = math.Float64frombits(value.StaleNaN)
+			}
+			ts := c.getOrCreateTimeSeries(lbls, meta, createdTimestamp)
+			ts.samples = append(ts.samples, writev2.Sample{Value: v, Timestamp: timestamp})
+			return ts
+		}
+
+		// If the sum is unset, it indicates the _sum metric point should be
+		// omitted
+		if pt.HasSum() {
+			addSample(pt.Sum(), createLabels(baseName+sumStr, baseLabels))
+		}
+		addSample(float64(pt.Count()), createLabels(baseName+countStr, baseLabels))
+
+		// cumulative count for conversion to cumulative histogram
+		var cumulativeCount uint64
+		type bucket struct {
+			ts    *timeSeriesV2
+			bound float64
+		}
+		var buckets []bucket
+		// process each bound, based on histograms proto definition, # of buckets = # of explicit bounds + 1
+		for i := 0; i < pt.ExplicitBounds().Len() && i < pt.BucketCounts().Len(); i++ {
+			bound := pt.ExplicitBounds().At(i)
+			cumulativeCount += pt.BucketCounts().At(i)
+			boundStr := strconv.FormatFloat(bound, 'f', -1, 64)
+			ts := addSample(float64(cumulativeCount), createLabels(baseName+bucketStr, baseLabels, leStr, boundStr))
+			buckets = append(buckets, bucket{ts: ts, bound: bound})
+		}
+		ts := addSample(float64(pt.Count()), createLabels(baseName+bucketStr, baseLabels, leStr, pInfStr))
+		buckets = append(buckets, bucket{ts: ts, bound: math.Inf(1)})
+
+		// Each exemplar is attached to the series of the smallest bucket it fits in.
+		sort.SliceStable(buckets, func(i, j int) bool { return buckets[i].bound < buckets[j].bound })
+		for _, exemplar := range getPromExemplars(pt) {
+			for _, b := range buckets {
+				if exemplar.Value <= b.bound {
+					b.ts.exemplars = append(b.ts.exemplars, exemplar)
+					break
+				}
+			}
+		}
+	}
+}
+
+// addExponentialHistogramDataPoints converts exponential histograms to native histograms.
+func (c *prometheusConverterV2) addExponentialHistogramDataPoints(dataPoints pmetric.ExponentialHistogramDataPointSlice,
+	resource pcommon.Resource, settings Settings, baseName string, meta metadataV2) error {
+	for x := 0; x < dataPoints.Len(); x++ {
+		pt := dataPoints.At(x)
+		lbls := createAttributes(
+			resource,
+			pt.Attributes(),
+			settings.ExternalLabels,
+			nil,
+			true,
+			model.MetricNameLabel,
+			baseName,
+		)
+
+		histogram, err := exponentialToNativeHistogram(pt)
+		if err != nil {
+			return err
+		}
+
+		ts := c.getOrCreateTimeSeries(lbls, meta, convertStartTimeStamp(pt.StartTimestamp()))
+		ts.histograms = append(ts.histograms, writev2.FromIntHistogram(histogram.Timestamp, histogram.ToIntHistogram()))
+		ts.exemplars = append(ts.exemplars, getPromExemplars(pt)...)
+	}
+
+	return nil
+}
+
+func (c *prometheusConverterV2) addSummaryDataPoints(dataPoints pmetric.SummaryDataPointSlice,
+	resource pcommon.Resource, settings Settings, baseName string, meta metadataV2) {
+	for x := 0; x < dataPoints.Len(); x++ {
+		pt := dataPoints.At(x)
+		timestamp := convertTimeStamp(pt.Timestamp())
+		createdTimestamp := convertStartTimeStamp(pt.StartTimestamp())
+		baseLabels := createAttributes(resource, pt.Attributes(), settings.ExternalLabels, nil, false)
+		addSample := func(v float64, lbls []prompb.Label) {
+			if pt.Flags().NoRecordedValue() {
+				v = math.Float64frombits(value.StaleNaN)
+			}
+			ts := c.getOrCreateTimeSeries(lbls, meta, createdTimestamp)
+			ts.samples = append(ts.samples, writev2.Sample{Value: v, Timestamp: timestamp})
+		}
+
+		addSample(pt.Sum(), createLabels(baseName+sumStr, baseLabels))
+		addSample(float64(pt.Count()), createLabels(baseName+countStr, baseLabels))
+		for i := 0; i < pt.QuantileValues().Len(); i++ {
+			qt := pt.QuantileValues().At(i)
+			percentileStr := strconv.FormatFloat(qt.Quantile(), 'f', -1, 64)
+			addSample(qt.Value(), createLabels(baseName, baseLabels, quantileStr, percentileStr))
+		}
+	}
+}
+
+// addResourceTargetInfo converts the resource to the target info metric.
+func (c *prometheusConverterV2) addResourceTargetInfo(resource pcommon.Resource, settings Settings, timestamp pcommon.Timestamp) {
+	if timestamp == 0 {
+		return
+	}
+	labels := targetInfoLabels(resource, settings)
+	if labels == nil {
+		return
+	}
+
+	ts := c.getOrCreateTimeSeries(labels, metadataV2{metricType: writev2.Metadata_METRIC_TYPE_GAUGE}, 0)
+	ts.samples = append(ts.samples, writev2.Sample{Value: 1, Timestamp: convertTimeStamp(timestamp)})
+}
+
+// getOrCreateTimeSeries returns the series corresponding to the label set and created timestamp,
+// creating it if needed. Data points with the same labels but different start timestamps are
+// sent as different series, so that each keeps its created timestamp.
+func (c *prometheusConverterV2) getOrCreateTimeSeries(lbls []prompb.Label, meta metadataV2, createdTimestamp int64) *timeSeriesV2 {
+	h := timeSeriesSignature(lbls)
+	ts := c.unique[h]
+	if ts == nil {
+		ts = &timeSeriesV2{labels: lbls, metadata: meta, createdTimestamp: createdTimestamp}
+		c.unique[h] = ts
+		return ts
+	}
+	if ts.isSameSeries(lbls, createdTimestamp) {
+		return ts
+	}
+	for _, cTS := range c.conflicts[h] {
+		if cTS.isSameSeries(lbls, createdTimestamp) {
+			return cTS
+		}
+	}
+	ts = &timeSeriesV2{labels: lbls, metadata: meta, createdTimestamp: createdTimestamp}
+	c.conflicts[h] = append(c.conflicts[h], ts)
+	return ts
+}
+
+func (ts *timeSeriesV2) isSameSeries(lbls []prompb.Label, createdTimestamp int64) bool {
+	if ts.createdTimestamp != createdTimestamp || len(ts.labels) != len(lbls) {
+		return false
+	}
+	for i, l := range ts.labels {
+		if l.Name != lbls[i].Name || l.Value != lbls[i].Value {
+			return false
+		}
+	}
+	return true
+}
+
+// request interns the strings of the series in a symbols table and returns the resulting request.
+func (c *prometheusConverterV2) request() *writev2.Request {
+	conflicts := 0
+	for _, ts := range c.conflicts {
+		conflicts += len(ts)
+	}
+	symbols := writev2.NewSymbolTable()
+	req := &writev2.Request{
+		Timeseries: make([]writev2.TimeSeries, 0, len(c.unique)+conflicts),
+	}
+	for _, ts := range c.unique {
+		req.Timeseries = append(req.Timeseries, ts.symbolize(&symbols))
+	}
+	for _, cTS := range c.conflicts {
+		for _, ts := range cTS {
+			req.Timeseries = append(req.Timeseries, ts.symbolize(&symbols))
+		}
+	}
+	req.Symbols = symbols.Symbols()
+	return req
+}
+
+// symbolize returns the series with its strings interned in symbols.
+func (ts *timeSeriesV2) symbolize(symbols *writev2.SymbolsTable) writev2.TimeSeries {
+	// Prometheus requires samples to be sorted by timestamp.
+	sort.SliceStable(ts.samples, func(i, j int) bool { return ts.samples[i].Timestamp < ts.samples[j].Timestamp })
+	sort.SliceStable(ts.histograms, func(i, j int) bool { return ts.histograms[i].Timestamp < ts.histograms[j].Timestamp })
+
+	out := writev2.TimeSeries{
+		LabelsRefs: symbolizeLabels(symbols, ts.labels),
+		Samples:    ts.samples,
+		Histograms: ts.histograms,
+		Metadata: writev2.Metadata{
+			Type:    ts.metadata.metricType,
+			HelpRef: symbols.Symbolize(ts.metadata.help),
+			UnitRef: symbols.Symbolize(ts.metadata.unit),
+		},
+		CreatedTimestamp: ts.createdTimestamp,
+	}
+	for _, e := range ts.exemplars {
+		sort.Sort(ByLabelName(e.Labels))
+		out.Exemplars = append(out.Exemplars, writev2.Exemplar{
+			LabelsRefs: symbolizeLabels(symbols, e.Labels),
+			Value:      e.Value,
+			Timestamp:  e.Timestamp,
+		})
+	}
+	return out
+}
+
+// symbolizeLabels returns the references to the names and values of the sorted labels.
+func symbolizeLabels(symbols *writev2.SymbolsTable, lbls []prompb.Label) []uint32 {
+	refs := make([]uint32, 0, 2*len(lbls))
+	for _, l := range lbls {
+		refs = append(refs, symbols.Symbolize(l.Name), symbols.Symbolize(l.Value))
+	}
+	return refs
+}
+
+// convertStartTimeStamp converts an OTLP start timestamp in ns to a created timestamp in ms,
+// zero meaning unset in both cases.
+func convertStartTimeStamp(timestamp pcommon.Timestamp) int64 {
+	if timestamp == 0 {
+		return 0
+	}
+	return convertTimeStamp(timestamp)
+}
@@ -0,0 +1,266 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewrite
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/histogram"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/model/metadata"
+	"github.com/prometheus/prometheus/prompb"
+	writev2 "github.com/prometheus/prometheus/prompb/io/prometheus/write/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+var (
+	v2StartTime = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	v2Time      = v2StartTime.Add(time.Minute)
+)
+
+func newTestMetricsV2() pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", "checkout")
+	rm.Resource().Attributes().PutStr("host.name", "host-1")
+	metrics := rm.ScopeMetrics().AppendEmpty().Metrics()
+
+	gauge := metrics.AppendEmpty()
+	gauge.SetName("queue_length")
+	gauge.SetDescription("Length of the queue")
+	gauge.SetEmptyGauge().DataPoints().AppendEmpty()
+	gauge.Gauge().DataPoints().At(0).SetIntValue(3)
+	gauge.Gauge().DataPoints().At(0).SetTimestamp(pcommon.NewTimestampFromTime(v2Time))
+
+	sum := metrics.AppendEmpty()
+	sum.SetName("requests")
+	sum.SetUnit("1")
+	sum.SetEmptySum().SetIsMonotonic(true)
+	sum.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	dp := sum.Sum().DataPoints().AppendEmpty()
+	dp.SetDoubleValue(10)
+	dp.Attributes().PutStr("code", "200")
+	dp.SetStartTimestamp(pcommon.NewTimestampFromTime(v2StartTime))
+	dp.SetTimestamp(pcommon.NewTimestampFromTime(v2Time))
+	ex := dp.Exemplars().AppendEmpty()
+	ex.SetDoubleValue(1)
+	ex.SetTimestamp(pcommon.NewTimestampFromTime(v2Time))
+	ex.SetTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+
+	hist := metrics.AppendEmpty()
+	hist.SetName("latency")
+	hist.SetEmptyHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	hdp := hist.Histogram().DataPoints().AppendEmpty()
+	hdp.SetStartTimestamp(pcommon.NewTimestampFromTime(v2StartTime))
+	hdp.SetTimestamp(pcommon.NewTimestampFromTime(v2Time))
+	hdp.SetCount(3)
+	hdp.SetSum(12)
+	hdp.ExplicitBounds().FromRaw([]float64{1, 5})
+	hdp.BucketCounts().FromRaw([]uint64{1, 1, 1})
+	hex := hdp.Exemplars().AppendEmpty()
+	hex.SetDoubleValue(4)
+	hex.SetTimestamp(pcommon.NewTimestampFromTime(v2Time))
+
+	expHist := metrics.AppendEmpty()
+	expHist.SetName("size")
+	expHist.SetEmptyExponentialHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	edp := expHist.ExponentialHistogram().DataPoints().AppendEmpty()
+	edp.SetStartTimestamp(pcommon.NewTimestampFromTime(v2StartTime))
+	edp.SetTimestamp(pcommon.NewTimestampFromTime(v2Time))
+	edp.SetScale(2)
+	edp.SetCount(7)
+	edp.SetSum(30)
+	edp.SetZeroCount(1)
+	edp.Positive().SetOffset(3)
+	edp.Positive().BucketCounts().FromRaw([]uint64{2, 0, 4})
+	edp.Exemplars().AppendEmpty().SetDoubleValue(5)
+
+	summary := metrics.AppendEmpty()
+	summary.SetName("duration")
+	summary.SetEmptySummary()
+	sdp := summary.Summary().DataPoints().AppendEmpty()
+	sdp.SetStartTimestamp(pcommon.NewTimestampFromTime(v2StartTime))
+	sdp.SetTimestamp(pcommon.NewTimestampFromTime(v2Time))
+	sdp.SetCount(2)
+	sdp.SetSum(4)
+	q := sdp.QuantileValues().AppendEmpty()
+	q.SetQuantile(0.5)
+	q.SetValue(2)
+	return md
+}
+
+// seriesByLabels returns the series of the request, keyed by their desymbolized labels.
+func seriesByLabels(t *testing.T, req *writev2.Request) map[string]writev2.TimeSeries {
+	b := labels.NewScratchBuilder(0)
+	series := make(map[string]writev2.TimeSeries, len(req.Timeseries))
+	for _, ts := range req.Timeseries {
+		key := ts.ToLabels(&b, req.Symbols).String()
+		require.NotContains(t, series, key)
+		series[key] = ts
+	}
+	return series
+}
+
+func TestFromMetricsV2(t *testing.T) {
+	req, err := FromMetricsV2(newTestMetricsV2(), Settings{})
+	require.NoError(t, err)
+	require.NotEmpty(t, req.Symbols)
+	assert.Equal(t, "", req.Symbols[0])
+
+	series := seriesByLabels(t, req)
+	assert.ElementsMatch(t, []string{
+		`{__name__="queue_length", job="checkout"}`,
+		`{__name__="requests", code="200", job="checkout"}`,
+		`{__name__="latency_sum", job="checkout"}`,
+		`{__name__="latency_count", job="checkout"}`,
+		`{__name__="latency_bucket", job="checkout", le="1"}`,
+		`{__name__="latency_bucket", job="checkout", le="5"}`,
+		`{__name__="latency_bucket", job="checkout", le="+Inf"}`,
+		`{__name__="size", job="checkout"}`,
+		`{__name__="duration_sum", job="checkout"}`,
+		`{__name__="duration_count", job="checkout"}`,
+		`{__name__="duration", job="checkout", quantile="0.5"}`,
+		`{__name__="target_info", host_name="host-1", job="checkout"}`,
+	}, keys(series))
+
+	startMs := v2StartTime.UnixMilli()
+	timeMs := v2Time.UnixMilli()
+
+	gauge := series[`{__name__="queue_length", job="checkout"}`]
+	assert.Equal(t, []writev2.Sample{{Value: 3, Timestamp: timeMs}}, gauge.Samples)
+	assert.Equal(t, metadata.Metadata{Type: model.MetricTypeGauge, Help: "Length of the queue"}, gauge.ToMetadata(req.Symbols))
+	assert.Zero(t, gauge.CreatedTimestamp)
+
+	counter := series[`{__name__="requests", code="200", job="checkout"}`]
+	assert.Equal(t, []writev2.Sample{{Value: 10, Timestamp: timeMs}}, counter.Samples)
+	assert.Equal(t, metadata.Metadata{Type: model.MetricTypeCounter, Unit: "1"}, counter.ToMetadata(req.Symbols))
+	assert.Equal(t, startMs, counter.CreatedTimestamp)
+	require.Len(t, counter.Exemplars, 1)
+	b := labels.NewScratchBuilder(0)
+	exemplar := counter.Exemplars[0].ToExemplar(&b, req.Symbols)
+	assert.Equal(t, `{trace_id="0102030405060708090a0b0c0d0e0f10"}`, exemplar.Labels.String())
+	assert.Equal(t, float64(1), exemplar.Value)
+	assert.Equal(t, timeMs, exemplar.Ts)
+
+	for _, le := range []string{"1", "+Inf"} {
+		bucket := series[`{__name__="latency_bucket", job="checkout", le="`+le+`"}`]
+		assert.Empty(t, bucket.Exemplars, le)
+		assert.Equal(t, startMs, bucket.CreatedTimestamp)
+		assert.Equal(t, writev2.Metadata_METRIC_TYPE_HISTOGRAM, bucket.Metadata.Type)
+	}
+	bucket := series[`{__name__="latency_bucket", job="checkout", le="5"}`]
+	assert.Equal(t, []writev2.Sample{{Value: 2, Timestamp: timeMs}}, bucket.Samples)
+	require.Len(t, bucket.Exemplars, 1)
+	assert.Equal(t, float64(4), bucket.Exemplars[0].Value)
+
+	native := series[`{__name__="size", job="checkout"}`]
+	assert.Empty(t, native.Samples)
+	assert.Equal(t, startMs, native.CreatedTimestamp)
+	assert.Len(t, native.Exemplars, 1)
+	require.Len(t, native.Histograms, 1)
+	assert.Equal(t, timeMs, native.Histograms[0].Timestamp)
+	assert.Equal(t, &histogram.Histogram{
+		Schema:          2,
+		ZeroThreshold:   defaultZeroThreshold,
+		ZeroCount:       1,
+		Count:           7,
+		Sum:             30,
+		PositiveSpans:   []histogram.Span{{Offset: 4, Length: 3}},
+		NegativeSpans:   []histogram.Span{},
+		PositiveBuckets: []int64{2, -2, 4},
+	}, native.Histograms[0].ToIntHistogram())
+
+	quantile := series[`{__name__="duration", job="checkout", quantile="0.5"}`]
+	assert.Equal(t, []writev2.Sample{{Value: 2, Timestamp: timeMs}}, quantile.Samples)
+	assert.Equal(t, writev2.Metadata_METRIC_TYPE_SUMMARY, quantile.Metadata.Type)
+	assert.Equal(t, startMs, quantile.CreatedTimestamp)
+
+	targetInfo := series[`{__name__="target_info", host_name="host-1", job="checkout"}`]
+	assert.Equal(t, []writev2.Sample{{Value: 1, Timestamp: timeMs}}, targetInfo.Samples)
+}
+
+func TestFromMetricsV2SplitsSeriesByCreatedTimestamp(t *testing.T) {
+	md := pmetric.NewMetrics()
+	metric := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	metric.SetName("requests")
+	metric.SetEmptySum().SetIsMonotonic(true)
+	metric.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	for i, start := range []time.Time{v2StartTime, v2StartTime, v2Time} {
+		dp := metric.Sum().DataPoints().AppendEmpty()
+		dp.SetIntValue(int64(i))
+		dp.SetStartTimestamp(pcommon.NewTimestampFromTime(start))
+		dp.SetTimestamp(pcommon.NewTimestampFromTime(v2Time.Add(time.Duration(2-i) * time.Second)))
+	}
+
+	req, err := FromMetricsV2(md, Settings{DisableTargetInfo: true})
+	require.NoError(t, err)
+	require.Len(t, req.Timeseries, 2)
+	for _, ts := range req.Timeseries {
+		switch ts.CreatedTimestamp {
+		case v2StartTime.UnixMilli():
+			// Samples are sorted by timestamp.
+			assert.Equal(t, []writev2.Sample{
+				{Value: 1, Timestamp: v2Time.Add(time.Second).UnixMilli()},
+				{Value: 0, Timestamp: v2Time.Add(2 * time.Second).UnixMilli()},
+			}, ts.Samples)
+		case v2Time.UnixMilli():
+			assert.Equal(t, []writev2.Sample{{Value: 2, Timestamp: v2Time.UnixMilli()}}, ts.Samples)
+		default:
+			t.Errorf("unexpected created timestamp %d", ts.CreatedTimestamp)
+		}
+	}
+}
+
+func TestFromMetricsV2InvalidMetrics(t *testing.T) {
+	md := pmetric.NewMetrics()
+	metrics := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	metrics.AppendEmpty().SetName("empty_gauge")
+	metrics.At(0).SetEmptyGauge()
+	delta := metrics.AppendEmpty()
+	delta.SetName("delta")
+	delta.SetEmptySum().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	delta.Sum().DataPoints().AppendEmpty()
+
+	req, err := FromMetricsV2(md, Settings{})
+	assert.Error(t, err)
+	assert.Empty(t, req.Timeseries)
+}
+
+func TestFromMetricsV2PayloadSize(t *testing.T) {
+	payload := createExportRequest(5, 10, 10, 10, 2)
+
+	tsMap, err := FromMetrics(payload.Metrics(), Settings{})
+	require.NoError(t, err)
+	v1 := &prompb.WriteRequest{}
+	for _, ts := range tsMap {
+		v1.Timeseries = append(v1.Timeseries, *ts)
+	}
+
+	v2, err := FromMetricsV2(payload.Metrics(), Settings{})
+	require.NoError(t, err)
+	assert.Less(t, v2.Size(), v1.Size())
+}
+
+func keys[V any](m map[string]V) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	return out
+}
+
+func BenchmarkFromMetricsV2(b *testing.B) {
+	payload := createExportRequest(5, 1000, 1000, 20, 5)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		req, err := FromMetricsV2(payload.Metrics(), Settings{})
+		require.NoError(b, err)
+		require.NotNil(b, req)
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cardinalityguardprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/cardinalityguardprocessor"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// Action defines what the processor does with the data points of new series exceeding the series limit of their metric.
+type Action string
+
+const (
+	// ActionDrop drops the data points of the new series.
+	ActionDrop Action = "drop"
+	// ActionStripAttributes removes the attribute with the most distinct values from the data points of the metric.
+	ActionStripAttributes Action = "strip_attributes"
+	// ActionOverflow replaces the attributes of the data points of the new series with `otel.metric.overflow=true`.
+	ActionOverflow Action = "overflow"
+)
+
+const (
+	defaultMaxSeries     = 2000
+	defaultResetInterval = time.Hour
+)
+
+// Config defines the configuration for the processor.
+type Config struct {
+	// MaxSeries is the maximum number of series of each metric.
+	MaxSeries int `mapstructure:"max_series"`
+
+	// Limits overrides MaxSeries, by metric name.
+	Limits map[string]int `mapstructure:"limits"`
+
+	// Action is what the processor does with the data points of new series exceeding the series limit of their metric:
+	// `drop`, `strip_attributes` or `overflow` (default).
+	Action Action `mapstructure:"action"`
+
+	// ResetInterval is the interval at which the tracked series are forgotten, so that series which are no
+	// longer reported make room for new ones.
+	ResetInterval time.Duration `mapstructure:"reset_interval"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	var errs error
+	if cfg.MaxSeries <= 0 {
+		errs = errors.Join(errs, errors.New("max_series must be positive"))
+	}
+	for metric, limit := range cfg.Limits {
+		if limit <= 0 {
+			errs = errors.Join(errs, fmt.Errorf("limits: metric %q: limit must be positive", metric))
+		}
+	}
+	switch cfg.Action {
+	case ActionDrop, ActionStripAttributes, ActionOverflow:
+	default:
+		errs = errors.Join(errs, fmt.Errorf("unsupported action %q, must be %q, %q or %q", cfg.Action, ActionDrop, ActionStripAttributes, ActionOverflow))
+	}
+	if cfg.ResetInterval <= 0 {
+		errs = errors.Join(errs, errors.New("reset_interval must be positive"))
+	}
+	return errs
+}
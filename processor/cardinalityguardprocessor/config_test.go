@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cardinalityguardprocessor
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/cardinalityguardprocessor/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		id           component.ID
+		expected     component.Config
+		errorMessage string
+	}{
+		{
+			id: component.NewIDWithName(metadata.Type, ""),
+			expected: &Config{
+				MaxSeries:     defaultMaxSeries,
+				Action:        ActionOverflow,
+				ResetInterval: defaultResetInterval,
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "full"),
+			expected: &Config{
+				MaxSeries:     500,
+				Limits:        map[string]int{"http.server.request.duration": 5000},
+				Action:        ActionStripAttributes,
+				ResetInterval: 10 * time.Minute,
+			},
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_max_series"),
+			errorMessage: "max_series must be positive",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_limit"),
+			errorMessage: `limits: metric "http.server.request.duration": limit must be positive`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_action"),
+			errorMessage: `unsupported action "block", must be "drop", "strip_attributes" or "overflow"`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_reset_interval"),
+			errorMessage: "reset_interval must be positive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+			require.NoError(t, err)
+
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, sub.Unmarshal(cfg))
+
+			if tt.expected == nil {
+				assert.EqualError(t, component.ValidateConfig(cfg), tt.errorMessage)
+				return
+			}
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
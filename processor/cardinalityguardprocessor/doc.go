@@ -0,0 +1,7 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package cardinalityguardprocessor limits the number of series of each metric.
+package cardinalityguardprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/cardinalityguardprocessor"
@@ -0,0 +1,141 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cardinalityguardprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/cardinalityguardprocessor"
+
+import (
+	"sort"
+	"sync"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatautil"
+)
+
+// maxTrackedAttributes caps the number of attributes whose distinct values are tracked for each metric,
+// so that attributes with dynamic keys don't grow the state unbounded.
+const maxTrackedAttributes = 128
+
+// guard tracks the series of the metrics until the next reset.
+type guard struct {
+	mu        sync.Mutex
+	maxSeries int
+	limits    map[string]int
+	metrics   map[string]*metricState
+}
+
+func newGuard(maxSeries int, limits map[string]int) *guard {
+	return &guard{
+		maxSeries: maxSeries,
+		limits:    limits,
+		metrics:   map[string]*metricState{},
+	}
+}
+
+// metric returns the state of the metric, the lock must be held.
+func (g *guard) metric(name string) *metricState {
+	state, ok := g.metrics[name]
+	if !ok {
+		limit, ok := g.limits[name]
+		if !ok {
+			limit = g.maxSeries
+		}
+		state = &metricState{
+			limit:    limit,
+			series:   map[uint64]struct{}{},
+			values:   map[string]map[uint64]struct{}{},
+			stripped: map[string]struct{}{},
+		}
+		g.metrics[name] = state
+	}
+	return state
+}
+
+// reset forgets the tracked series and the stripped attributes of all the metrics.
+func (g *guard) reset() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.metrics = map[string]*metricState{}
+}
+
+// metricState is the state of a metric, bounded by its limit: at most limit series, and limit+1 distinct values,
+// enough to tell the attributes exceeding the limit on their own, for each of at most maxTrackedAttributes attributes.
+type metricState struct {
+	limit  int
+	series map[uint64]struct{}
+	// values are the hashes of the distinct values of the data point attributes, by key.
+	values map[string]map[uint64]struct{}
+	// stripped are the attributes removed from the data points of the metric.
+	stripped map[string]struct{}
+	// exceeded is whether the metric exceeded its limit, so that it's only reported once.
+	exceeded bool
+}
+
+// observe tracks the distinct values of the attributes of a data point.
+func (s *metricState) observe(attrs pcommon.Map) {
+	attrs.Range(func(k string, v pcommon.Value) bool {
+		values, ok := s.values[k]
+		if !ok {
+			if len(s.values) >= maxTrackedAttributes {
+				return true
+			}
+			values = map[uint64]struct{}{}
+			s.values[k] = values
+		}
+		if len(values) <= s.limit {
+			values[pdatautil.Hash64(pdatautil.WithValue(v))] = struct{}{}
+		}
+		return true
+	})
+}
+
+// admit reports whether the series fits the limit of the metric, and tracks it if it does.
+func (s *metricState) admit(series uint64) bool {
+	if _, ok := s.series[series]; ok {
+		return true
+	}
+	if len(s.series) >= s.limit {
+		return false
+	}
+	s.series[series] = struct{}{}
+	return true
+}
+
+// strip selects the attribute with the most distinct values to be stripped from the data points of the metric.
+// The tracked series keep counting towards the limit until the next reset, as they were sent with the attribute.
+func (s *metricState) strip() (string, bool) {
+	top := s.topAttributes(1)
+	if len(top) == 0 {
+		return "", false
+	}
+	key := top[0].key
+	s.stripped[key] = struct{}{}
+	delete(s.values, key)
+	return key, true
+}
+
+// isStripped reports whether the attribute is stripped from the data points of the metric.
+func (s *metricState) isStripped(key string) bool {
+	_, ok := s.stripped[key]
+	return ok
+}
+
+type attributeCardinality struct {
+	key    string
+	values int
+}
+
+// topAttributes returns at most n attributes of the metric with the most distinct values.
+func (s *metricState) topAttributes(n int) []attributeCardinality {
+	top := make([]attributeCardinality, 0, len(s.values))
+	for key, values := range s.values {
+		top = append(top, attributeCardinality{key: key, values: len(values)})
+	}
+	sort.Slice(top, func(i, j int) bool {
+		if top[i].values != top[j].values {
+			return top[i].values > top[j].values
+		}
+		return top[i].key < top[j].key
+	})
+	return top[:min(n, len(top))]
+}
@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cardinalityguardprocessor
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestMetricState(t *testing.T) {
+	g := newGuard(3, map[string]int{"requests": 10})
+	assert.Equal(t, 10, g.metric("requests").limit)
+	state := g.metric("latency")
+	assert.Equal(t, 3, state.limit)
+
+	for i := 0; i < 5; i++ {
+		attrs := pcommon.NewMap()
+		attrs.PutStr("method", "GET")
+		attrs.PutInt("user_id", int64(i))
+		state.observe(attrs)
+	}
+	assert.Equal(t, []attributeCardinality{{key: "user_id", values: 4}, {key: "method", values: 1}}, state.topAttributes(5),
+		"distinct values are capped past the limit")
+	assert.Equal(t, []attributeCardinality{{key: "user_id", values: 4}}, state.topAttributes(1))
+
+	assert.True(t, state.admit(1))
+	assert.True(t, state.admit(2))
+	assert.True(t, state.admit(3))
+	assert.True(t, state.admit(1), "tracked series are admitted")
+	assert.False(t, state.admit(4))
+
+	key, ok := state.strip()
+	assert.True(t, ok)
+	assert.Equal(t, "user_id", key)
+	assert.True(t, state.isStripped("user_id"))
+	assert.Len(t, state.series, 3, "tracked series keep counting towards the limit")
+	assert.False(t, state.admit(4))
+
+	key, ok = state.strip()
+	assert.True(t, ok)
+	assert.Equal(t, "method", key)
+	_, ok = state.strip()
+	assert.False(t, ok, "no attributes left to strip")
+
+	g.reset()
+	assert.NotSame(t, state, g.metric("latency"))
+	assert.Empty(t, g.metric("latency").stripped)
+}
+
+func TestMetricStateTrackedAttributesCap(t *testing.T) {
+	state := newGuard(1, nil).metric("requests")
+	attrs := pcommon.NewMap()
+	for i := 0; i < maxTrackedAttributes+10; i++ {
+		attrs.PutInt(fmt.Sprintf("attr%d", i), 1)
+	}
+	state.observe(attrs)
+	assert.Len(t, state.values, maxTrackedAttributes)
+}
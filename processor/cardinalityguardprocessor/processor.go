@@ -0,0 +1,278 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cardinalityguardprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/cardinalityguardprocessor"
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/aggregateutil"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatautil"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/cardinalityguardprocessor/internal/metadata"
+)
+
+// overflowAttribute is the attribute of the series the data points of new series are folded into,
+// as defined by the cardinality limits of the OpenTelemetry metrics SDK.
+const overflowAttribute = "otel.metric.overflow"
+
+// reportedAttributes is the number of attributes with the most distinct values logged when a metric exceeds its limit.
+const reportedAttributes = 3
+
+type cardinalityGuardProcessor struct {
+	cfg       *Config
+	logger    *zap.Logger
+	guard     *guard
+	telemetry *metadata.TelemetryBuilder
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func newCardinalityGuardProcessor(set processor.Settings, cfg *Config) (*cardinalityGuardProcessor, error) {
+	telemetry, err := metadata.NewTelemetryBuilder(set.TelemetrySettings)
+	if err != nil {
+		return nil, err
+	}
+	return &cardinalityGuardProcessor{
+		cfg:       cfg,
+		logger:    set.Logger,
+		guard:     newGuard(cfg.MaxSeries, cfg.Limits),
+		telemetry: telemetry,
+	}, nil
+}
+
+func (p *cardinalityGuardProcessor) start(_ context.Context, _ component.Host) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	p.cancel = cancel
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		ticker := time.NewTicker(p.cfg.ResetInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				p.guard.reset()
+			}
+		}
+	}()
+	return nil
+}
+
+func (p *cardinalityGuardProcessor) shutdown(context.Context) error {
+	if p.cancel != nil {
+		p.cancel()
+	}
+	p.wg.Wait()
+	return nil
+}
+
+func (p *cardinalityGuardProcessor) processMetrics(ctx context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	md.ResourceMetrics().RemoveIf(func(rm pmetric.ResourceMetrics) bool {
+		rm.ScopeMetrics().RemoveIf(func(sm pmetric.ScopeMetrics) bool {
+			// The series of a data point are identified by its resource, scope and attributes.
+			h := pdatautil.Hash(
+				pdatautil.WithMap(rm.Resource().Attributes()),
+				pdatautil.WithString(sm.Scope().Name()),
+				pdatautil.WithString(sm.Scope().Version()),
+			)
+			scope := string(h[:])
+			sm.Metrics().RemoveIf(func(m pmetric.Metric) bool {
+				return p.guardMetric(ctx, m, scope) == 0
+			})
+			return sm.Metrics().Len() == 0
+		})
+		return rm.ScopeMetrics().Len() == 0
+	})
+	if md.ResourceMetrics().Len() == 0 {
+		return md, processorhelper.ErrSkipProcessingData
+	}
+	return md, nil
+}
+
+// guardMetric enforces the series limit of the metric on its data points, and returns the number of data points left.
+func (p *cardinalityGuardProcessor) guardMetric(ctx context.Context, m pmetric.Metric, scope string) int {
+	var left int
+	var collapsed bool
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		left, collapsed = guardDataPoints(ctx, p, m.Name(), scope, m.Gauge().DataPoints())
+	case pmetric.MetricTypeSum:
+		left, collapsed = guardDataPoints(ctx, p, m.Name(), scope, m.Sum().DataPoints())
+	case pmetric.MetricTypeHistogram:
+		left, collapsed = guardDataPoints(ctx, p, m.Name(), scope, m.Histogram().DataPoints())
+	case pmetric.MetricTypeExponentialHistogram:
+		left, collapsed = guardDataPoints(ctx, p, m.Name(), scope, m.ExponentialHistogram().DataPoints())
+	case pmetric.MetricTypeSummary:
+		left, collapsed = guardDataPoints(ctx, p, m.Name(), scope, m.Summary().DataPoints())
+	case pmetric.MetricTypeEmpty:
+	}
+	// Aggregating the data points doesn't remove metrics, as it leaves at least one data point.
+	if collapsed {
+		aggregate(m)
+	}
+	return left
+}
+
+type dataPoint interface {
+	Attributes() pcommon.Map
+}
+
+type dataPointSlice[DP dataPoint] interface {
+	Len() int
+	At(int) DP
+	RemoveIf(func(DP) bool)
+}
+
+// guardDataPoints enforces the series limit of the metric on its data points, and returns the number of data points
+// left, and whether the attributes of some of them were stripped or replaced, so that they may share their series.
+func guardDataPoints[DP dataPoint](ctx context.Context, p *cardinalityGuardProcessor, name string, scope string, dps dataPointSlice[DP]) (int, bool) {
+	collapsed := false
+	p.guard.mu.Lock()
+	state := p.guard.metric(name)
+	// The attributes are stripped from all the data points before their series are admitted, so that the
+	// data points of a batch are consistent.
+	for i := 0; i < dps.Len(); i++ {
+		attrs := dps.At(i).Attributes()
+		if len(state.stripped) > 0 {
+			n := attrs.Len()
+			attrs.RemoveIf(func(k string, _ pcommon.Value) bool { return state.isStripped(k) })
+			collapsed = collapsed || attrs.Len() != n
+		}
+		state.observe(attrs)
+	}
+
+	limited := excess(state, scope, dps)
+	if limited > 0 && !state.exceeded {
+		state.exceeded = true
+		p.reportExceeded(ctx, name, state)
+	}
+	if p.cfg.Action == ActionStripAttributes {
+		for excess(state, scope, dps) > 0 {
+			key, ok := state.strip()
+			if !ok {
+				break
+			}
+			p.logger.Warn("Stripping attribute from metric",
+				zap.String("metric", name),
+				zap.String("attribute", key))
+			p.telemetry.ProcessorCardinalityguardStrippedAttributes.Add(ctx, 1,
+				metric.WithAttributes(attribute.String("metric", name), attribute.String("attribute", key)))
+			for i := 0; i < dps.Len(); i++ {
+				collapsed = dps.At(i).Attributes().Remove(key) || collapsed
+			}
+		}
+	}
+
+	dps.RemoveIf(func(dp DP) bool {
+		attrs := dp.Attributes()
+		if state.admit(seriesOf(scope, attrs)) {
+			return false
+		}
+		if p.cfg.Action == ActionOverflow {
+			attrs.Clear()
+			attrs.PutBool(overflowAttribute, true)
+			collapsed = true
+			return false
+		}
+		// With the strip_attributes action, the series only differ by their resource or scope, whose attributes
+		// can't be stripped.
+		return true
+	})
+	p.guard.mu.Unlock()
+
+	if limited > 0 {
+		p.telemetry.ProcessorCardinalityguardLimitedDataPoints.Add(ctx, int64(limited), metric.WithAttributes(attribute.String("metric", name)))
+	}
+	return dps.Len(), collapsed
+}
+
+// excess returns the number of data points whose series don't fit the limit of the metric, the lock must be held.
+func excess[DP dataPoint](state *metricState, scope string, dps dataPointSlice[DP]) int {
+	n := 0
+	added := map[uint64]struct{}{}
+	for i := 0; i < dps.Len(); i++ {
+		series := seriesOf(scope, dps.At(i).Attributes())
+		if _, ok := state.series[series]; ok {
+			continue
+		}
+		if _, ok := added[series]; ok {
+			continue
+		}
+		if len(state.series)+len(added) < state.limit {
+			added[series] = struct{}{}
+			continue
+		}
+		n++
+	}
+	return n
+}
+
+// aggregate merges the data points of the metric which belong to the same series at the same time: sums and
+// histograms are added up, gauges keep the highest value, and only the first data point of summaries is kept,
+// as their quantiles can't be merged.
+func aggregate(m pmetric.Metric) {
+	if m.Type() == pmetric.MetricTypeSummary {
+		seen := map[uint64]struct{}{}
+		m.Summary().DataPoints().RemoveIf(func(dp pmetric.SummaryDataPoint) bool {
+			key := pdatautil.Hash64(pdatautil.WithMap(dp.Attributes()), pdatautil.WithString(dp.Timestamp().String()))
+			if _, ok := seen[key]; ok {
+				return true
+			}
+			seen[key] = struct{}{}
+			return false
+		})
+		return
+	}
+
+	aggregationType := aggregateutil.Sum
+	if m.Type() == pmetric.MetricTypeGauge {
+		aggregationType = aggregateutil.Max
+	}
+	ag := aggregateutil.AggGroups{}
+	merged := pmetric.NewMetric()
+	aggregateutil.CopyMetricDetails(m, merged)
+	m.Metadata().MoveTo(merged.Metadata())
+	aggregateutil.GroupDataPoints(m, &ag)
+	aggregateutil.MergeDataPoints(merged, aggregationType, ag)
+	merged.MoveTo(m)
+}
+
+func seriesOf(scope string, attrs pcommon.Map) uint64 {
+	return pdatautil.Hash64(pdatautil.WithString(scope), pdatautil.WithMap(attrs))
+}
+
+// reportExceeded reports the metric exceeding its limit, and the attributes with the most distinct values.
+func (p *cardinalityGuardProcessor) reportExceeded(ctx context.Context, name string, state *metricState) {
+	top := state.topAttributes(reportedAttributes)
+	attributes := make([]string, len(top))
+	for i, a := range top {
+		attributes[i] = fmt.Sprintf("%s=%d", a.key, a.values)
+	}
+	p.logger.Warn("Metric exceeded its series limit",
+		zap.String("metric", name),
+		zap.Int("limit", state.limit),
+		zap.String("action", string(p.cfg.Action)),
+		zap.Strings("top_attributes", attributes))
+
+	offending := ""
+	if len(top) > 0 {
+		offending = top[0].key
+	}
+	p.telemetry.ProcessorCardinalityguardExceededLimits.Add(ctx, 1,
+		metric.WithAttributes(attribute.String("metric", name), attribute.String("attribute", offending)))
+}
@@ -0,0 +1,278 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cardinalityguardprocessor
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.opentelemetry.io/collector/processor/processortest"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func newTestProcessor(t *testing.T, cfg *Config) *cardinalityGuardProcessor {
+	if cfg.Action == "" {
+		cfg.Action = ActionOverflow
+	}
+	if cfg.ResetInterval == 0 {
+		cfg.ResetInterval = defaultResetInterval
+	}
+	require.NoError(t, cfg.Validate())
+	p, err := newCardinalityGuardProcessor(processortest.NewNopSettings(), cfg)
+	require.NoError(t, err)
+	return p
+}
+
+// newMetrics returns a gauge with a data point for each user, of alternating methods.
+func newMetrics(name string, users ...int64) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", "checkout")
+	m := rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName(name)
+	dps := m.SetEmptyGauge().DataPoints()
+	for i, user := range users {
+		dp := dps.AppendEmpty()
+		dp.SetIntValue(user)
+		dp.Attributes().PutStr("method", []string{"GET", "POST"}[i%2])
+		dp.Attributes().PutInt("user_id", user)
+	}
+	return md
+}
+
+func dataPointAttributes(md pmetric.Metrics) []map[string]any {
+	var attrs []map[string]any
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		sms := md.ResourceMetrics().At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			ms := sms.At(j).Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				dps := ms.At(k).Gauge().DataPoints()
+				for l := 0; l < dps.Len(); l++ {
+					attrs = append(attrs, dps.At(l).Attributes().AsRaw())
+				}
+			}
+		}
+	}
+	return attrs
+}
+
+// dataPointValues returns the values of the gauge and sum data points, by their JSON encoded attributes.
+func dataPointValues(md pmetric.Metrics) map[string]int64 {
+	values := map[string]int64{}
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		sms := md.ResourceMetrics().At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			ms := sms.At(j).Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				var dps pmetric.NumberDataPointSlice
+				switch ms.At(k).Type() {
+				case pmetric.MetricTypeGauge:
+					dps = ms.At(k).Gauge().DataPoints()
+				case pmetric.MetricTypeSum:
+					dps = ms.At(k).Sum().DataPoints()
+				default:
+					continue
+				}
+				for l := 0; l < dps.Len(); l++ {
+					attrs, _ := json.Marshal(dps.At(l).Attributes().AsRaw())
+					values[string(attrs)] = dps.At(l).IntValue()
+				}
+			}
+		}
+	}
+	return values
+}
+
+func TestActionDrop(t *testing.T) {
+	p := newTestProcessor(t, &Config{MaxSeries: 3, Action: ActionDrop})
+
+	md, err := p.processMetrics(context.Background(), newMetrics("requests", 1, 2, 3, 4, 5))
+	require.NoError(t, err)
+	assert.Equal(t, []map[string]any{
+		{"method": "GET", "user_id": int64(1)},
+		{"method": "POST", "user_id": int64(2)},
+		{"method": "GET", "user_id": int64(3)},
+	}, dataPointAttributes(md))
+
+	md, err = p.processMetrics(context.Background(), newMetrics("requests", 1, 2, 3))
+	require.NoError(t, err)
+	assert.Len(t, dataPointAttributes(md), 3, "tracked series are kept")
+
+	_, err = p.processMetrics(context.Background(), newMetrics("requests", 6))
+	assert.ErrorIs(t, err, processorhelper.ErrSkipProcessingData)
+
+	md, err = p.processMetrics(context.Background(), newMetrics("latency", 6))
+	require.NoError(t, err)
+	assert.Len(t, dataPointAttributes(md), 1, "metrics are limited separately")
+
+	p.guard.reset()
+	md, err = p.processMetrics(context.Background(), newMetrics("requests", 6))
+	require.NoError(t, err)
+	assert.Len(t, dataPointAttributes(md), 1, "series are forgotten on reset")
+}
+
+func TestActionOverflow(t *testing.T) {
+	p := newTestProcessor(t, &Config{MaxSeries: 2, Action: ActionOverflow})
+
+	md, err := p.processMetrics(context.Background(), newMetrics("requests", 1, 2, 3, 4, 5))
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []map[string]any{
+		{"method": "GET", "user_id": int64(1)},
+		{"method": "POST", "user_id": int64(2)},
+		{"otel.metric.overflow": true},
+	}, dataPointAttributes(md), "the data points of the new series are aggregated into a single one")
+	assert.Equal(t, int64(5), dataPointValues(md)[`{"otel.metric.overflow":true}`], "gauges keep the highest value")
+	assert.Equal(t, "checkout", md.ResourceMetrics().At(0).Resource().Attributes().AsRaw()["service.name"])
+
+	md = newMetrics("requests", 1, 3, 4)
+	sum := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	sum.Gauge().DataPoints().MoveAndAppendTo(sum.SetEmptySum().DataPoints())
+	md, err = p.processMetrics(context.Background(), md)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{
+		`{"method":"GET","user_id":1}`:  1,
+		`{"otel.metric.overflow":true}`: 7,
+	}, dataPointValues(md), "sums are added up")
+}
+
+func TestActionStripAttributes(t *testing.T) {
+	p := newTestProcessor(t, &Config{MaxSeries: 3, Action: ActionStripAttributes})
+
+	// The attribute is stripped from all the data points of the batch, which are aggregated.
+	md, err := p.processMetrics(context.Background(), newMetrics("requests", 1, 2, 3, 4, 5))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{
+		`{"method":"GET"}`:  5,
+		`{"method":"POST"}`: 4,
+	}, dataPointValues(md))
+
+	md, err = p.processMetrics(context.Background(), newMetrics("requests", 1, 6))
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []map[string]any{
+		{"method": "GET"},
+		{"method": "POST"},
+	}, dataPointAttributes(md), "stripped attributes are removed from all the data points of the metric")
+}
+
+func TestActionStripAttributesTrackedSeries(t *testing.T) {
+	p := newTestProcessor(t, &Config{MaxSeries: 3, Action: ActionStripAttributes})
+
+	md, err := p.processMetrics(context.Background(), newMetrics("requests", 1, 2))
+	require.NoError(t, err)
+	assert.Len(t, dataPointAttributes(md), 2)
+
+	// The 2 series sent before user_id was stripped keep counting towards the limit, leaving room for a single one.
+	md, err = p.processMetrics(context.Background(), newMetrics("requests", 3, 4, 5))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{`{}`: 5}, dataPointValues(md))
+}
+
+func TestActionStripAttributesWithoutAttributes(t *testing.T) {
+	p := newTestProcessor(t, &Config{MaxSeries: 1, Action: ActionStripAttributes})
+
+	md := pmetric.NewMetrics()
+	for _, service := range []string{"checkout", "cart"} {
+		rm := md.ResourceMetrics().AppendEmpty()
+		rm.Resource().Attributes().PutStr("service.name", service)
+		m := rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+		m.SetName("requests")
+		m.SetEmptySum().DataPoints().AppendEmpty().SetIntValue(1)
+	}
+	md, err := p.processMetrics(context.Background(), md)
+	require.NoError(t, err)
+	require.Equal(t, 1, md.ResourceMetrics().Len(), "series only differing by their resource are dropped")
+	assert.Equal(t, "checkout", md.ResourceMetrics().At(0).Resource().Attributes().AsRaw()["service.name"])
+}
+
+func TestLimits(t *testing.T) {
+	p := newTestProcessor(t, &Config{MaxSeries: 1, Limits: map[string]int{"requests": 3}, Action: ActionDrop})
+
+	md, err := p.processMetrics(context.Background(), newMetrics("requests", 1, 2, 3, 4))
+	require.NoError(t, err)
+	assert.Len(t, dataPointAttributes(md), 3)
+
+	md, err = p.processMetrics(context.Background(), newMetrics("latency", 1, 2, 3, 4))
+	require.NoError(t, err)
+	assert.Len(t, dataPointAttributes(md), 1)
+}
+
+func TestMetricTypes(t *testing.T) {
+	p := newTestProcessor(t, &Config{MaxSeries: 1, Action: ActionDrop})
+
+	md := pmetric.NewMetrics()
+	ms := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	sum := ms.AppendEmpty()
+	sum.SetName("sum")
+	histogram := ms.AppendEmpty()
+	histogram.SetName("histogram")
+	exponentialHistogram := ms.AppendEmpty()
+	exponentialHistogram.SetName("exponential_histogram")
+	summary := ms.AppendEmpty()
+	summary.SetName("summary")
+	for _, user := range []string{"alice", "bob"} {
+		sum.SetEmptySum()
+		sum.Sum().DataPoints().AppendEmpty().Attributes().PutStr("user", user)
+		histogram.SetEmptyHistogram()
+		histogram.Histogram().DataPoints().AppendEmpty().Attributes().PutStr("user", user)
+		exponentialHistogram.SetEmptyExponentialHistogram()
+		exponentialHistogram.ExponentialHistogram().DataPoints().AppendEmpty().Attributes().PutStr("user", user)
+		summary.SetEmptySummary()
+		summary.Summary().DataPoints().AppendEmpty().Attributes().PutStr("user", user)
+	}
+
+	md, err := p.processMetrics(context.Background(), md)
+	require.NoError(t, err)
+	assert.Equal(t, 4, md.MetricCount())
+	assert.Equal(t, 4, md.DataPointCount())
+}
+
+func TestTelemetry(t *testing.T) {
+	tel := setupTestTelemetry()
+	p, err := newCardinalityGuardProcessor(tel.NewSettings(), &Config{
+		MaxSeries:     2,
+		Action:        ActionStripAttributes,
+		ResetInterval: defaultResetInterval,
+	})
+	require.NoError(t, err)
+
+	_, err = p.processMetrics(context.Background(), newMetrics("requests", 1, 2, 3, 4))
+	require.NoError(t, err)
+
+	sum := func(attrs attribute.Set, value int64) metricdata.Sum[int64] {
+		return metricdata.Sum[int64]{
+			Temporality: metricdata.CumulativeTemporality,
+			IsMonotonic: true,
+			DataPoints:  []metricdata.DataPoint[int64]{{Attributes: attrs, Value: value}},
+		}
+	}
+	offending := attribute.NewSet(attribute.String("metric", "requests"), attribute.String("attribute", "user_id"))
+	tel.assertMetrics(t, []metricdata.Metrics{
+		{
+			Name:        "otelcol_processor_cardinalityguard_limited_data_points",
+			Description: "Number of data points of new series exceeding the series limit of their metric, by metric.",
+			Unit:        "{datapoints}",
+			Data:        sum(attribute.NewSet(attribute.String("metric", "requests")), 2),
+		},
+		{
+			Name:        "otelcol_processor_cardinalityguard_exceeded_limits",
+			Description: "Number of times a metric exceeded its series limit, at most once per reset interval, by metric and attribute with the most distinct values.",
+			Unit:        "{limits}",
+			Data:        sum(offending, 1),
+		},
+		{
+			Name:        "otelcol_processor_cardinalityguard_stripped_attributes",
+			Description: "Number of attributes selected to be stripped from a metric, by metric and attribute.",
+			Unit:        "{attributes}",
+			Data:        sum(offending, 1),
+		},
+	})
+	require.NoError(t, tel.Shutdown(context.Background()))
+}
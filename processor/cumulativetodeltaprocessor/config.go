@@ -25,12 +25,26 @@ type Config struct {
 	//   - drop: don't send the first point, but store it for subsequent delta calculations
 	InitialValue tracking.InitialValue `mapstructure:"initial_value"`
 
+	// DetectStartTimeResets treats a change of the start timestamp of the points of a series as a reset of the
+	// series. Otherwise, points with a different start timestamp belong to different series.
+	DetectStartTimeResets bool `mapstructure:"detect_start_time_resets"`
+
+	// ResetValue determines how to handle the first datapoint after a reset, detected by a change of its start timestamp
+	// when DetectStartTimeResets is set. Valid values:
+	//
+	//   - keep: (default) send the point, whose value is the delta since its start time
+	//   - drop: don't send the point, but store it for subsequent delta calculations
+	ResetValue tracking.ResetValue `mapstructure:"reset_value"`
+
 	// Include specifies a filter on the metrics that should be converted.
 	// Exclude specifies a filter on the metrics that should not be converted.
 	// If neither `include` nor `exclude` are set, all metrics will be converted.
 	// Cannot be used with deprecated Metrics config option.
 	Include MatchMetrics `mapstructure:"include"`
 	Exclude MatchMetrics `mapstructure:"exclude"`
+
+	// ConvertSummaries converts the count and sum of summaries to deltas. Otherwise, summaries are left unchanged.
+	ConvertSummaries bool `mapstructure:"convert_summaries"`
 }
 
 type MatchMetrics struct {
@@ -52,5 +66,8 @@ func (config *Config) Validate() error {
 		(len(config.Exclude.MatchType) > 0 && len(config.Exclude.Metrics) == 0) {
 		return fmt.Errorf("metrics must be supplied if match_type is set")
 	}
+	if config.ResetValue == tracking.ResetValueDrop && !config.DetectStartTimeResets {
+		return fmt.Errorf("reset_value requires detect_start_time_resets")
+	}
 	return nil
 }
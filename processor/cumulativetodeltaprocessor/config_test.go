@@ -108,6 +108,23 @@ func TestLoadConfig(t *testing.T) {
 				InitialValue: tracking.InitialValueDrop,
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "reset_drop"),
+			expected: &Config{
+				DetectStartTimeResets: true,
+				ResetValue:            tracking.ResetValueDrop,
+			},
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "reset_drop_without_detection"),
+			errorMessage: "reset_value requires detect_start_time_resets",
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "convert_summaries"),
+			expected: &Config{
+				ConvertSummaries: true,
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -186,14 +186,14 @@ func TestMetricIdentity_IsSupportedMetricType(t *testing.T) {
 			fields: fields{
 				MetricType: pmetric.MetricTypeExponentialHistogram,
 			},
-			want: false,
+			want: true,
 		},
 		{
 			name: "summary",
 			fields: fields{
 				MetricType: pmetric.MetricTypeSummary,
 			},
-			want: false,
+			want: true,
 		},
 	}
 	for _, tt := range tests {
@@ -53,6 +53,35 @@ func (i *InitialValue) UnmarshalText(text []byte) error {
 	return nil
 }
 
+type ResetValue int
+
+const (
+	ResetValueKeep ResetValue = iota
+	ResetValueDrop
+)
+
+func (r *ResetValue) String() string {
+	switch *r {
+	case ResetValueKeep:
+		return "keep"
+	case ResetValueDrop:
+		return "drop"
+	}
+	return "unknown"
+}
+
+func (r *ResetValue) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "keep":
+		*r = ResetValueKeep
+	case "drop":
+		*r = ResetValueDrop
+	default:
+		return fmt.Errorf("unknown reset_value: %s", text)
+	}
+	return nil
+}
+
 var identityBufferPool = sync.Pool{
 	New: func() any {
 		return bytes.NewBuffer(make([]byte, initialBytes))
@@ -61,22 +90,31 @@ var identityBufferPool = sync.Pool{
 
 type State struct {
 	sync.Mutex
-	PrevPoint ValuePoint
+	// StartTimestamp is the start timestamp of the points of the series, a change of which signals a reset.
+	StartTimestamp pcommon.Timestamp
+	PrevPoint      ValuePoint
 }
 
 type DeltaValue struct {
-	StartTimestamp pcommon.Timestamp
-	FloatValue     float64
-	IntValue       int64
-	HistogramValue *HistogramPoint
+	StartTimestamp            pcommon.Timestamp
+	FloatValue                float64
+	IntValue                  int64
+	HistogramValue            *HistogramPoint
+	ExponentialHistogramValue *ExponentialHistogramPoint
+	SummaryValue              *SummaryPoint
 }
 
-func NewMetricTracker(ctx context.Context, logger *zap.Logger, maxStaleness time.Duration, initalValue InitialValue) *MetricTracker {
+// NewMetricTracker returns a tracker converting cumulative points to deltas. When detectStartTimeResets
+// is set, a change of the start timestamp of a series is a reset, handled according to resetValue.
+// Otherwise, points with a different start timestamp belong to a different series.
+func NewMetricTracker(ctx context.Context, logger *zap.Logger, maxStaleness time.Duration, initalValue InitialValue, detectStartTimeResets bool, resetValue ResetValue) *MetricTracker {
 	t := &MetricTracker{
-		logger:       logger,
-		maxStaleness: maxStaleness,
-		initialValue: initalValue,
-		startTime:    pcommon.NewTimestampFromTime(time.Now()),
+		logger:                logger,
+		maxStaleness:          maxStaleness,
+		initialValue:          initalValue,
+		detectStartTimeResets: detectStartTimeResets,
+		resetValue:            resetValue,
+		startTime:             pcommon.NewTimestampFromTime(time.Now()),
 	}
 	if maxStaleness > 0 {
 		go t.sweeper(ctx, t.removeStale)
@@ -85,11 +123,13 @@ func NewMetricTracker(ctx context.Context, logger *zap.Logger, maxStaleness time
 }
 
 type MetricTracker struct {
-	logger       *zap.Logger
-	maxStaleness time.Duration
-	states       sync.Map
-	initialValue InitialValue
-	startTime    pcommon.Timestamp
+	logger                *zap.Logger
+	maxStaleness          time.Duration
+	states                sync.Map
+	initialValue          InitialValue
+	detectStartTimeResets bool
+	resetValue            ResetValue
+	startTime             pcommon.Timestamp
 }
 
 func (t *MetricTracker) Convert(in MetricPoint) (out DeltaValue, valid bool) {
@@ -108,23 +148,23 @@ func (t *MetricTracker) Convert(in MetricPoint) (out DeltaValue, valid bool) {
 
 	b := identityBufferPool.Get().(*bytes.Buffer)
 	b.Reset()
-	metricID.Write(b)
+	if t.detectStartTimeResets {
+		// A change of the start timestamp is a reset of the series, not a new series.
+		seriesID := metricID
+		seriesID.StartTimestamp = 0
+		seriesID.Write(b)
+	} else {
+		metricID.Write(b)
+	}
 	hashableID := b.String()
 	identityBufferPool.Put(b)
 
 	s, ok := t.states.LoadOrStore(hashableID, &State{
-		PrevPoint: metricPoint,
+		StartTimestamp: metricID.StartTimestamp,
+		PrevPoint:      metricPoint,
 	})
 	if !ok {
-		switch metricID.MetricType {
-		case pmetric.MetricTypeHistogram:
-			val := metricPoint.HistogramValue.Clone()
-			out.HistogramValue = &val
-		case pmetric.MetricTypeSum:
-			out.IntValue = metricPoint.IntValue
-			out.FloatValue = metricPoint.FloatValue
-		case pmetric.MetricTypeEmpty, pmetric.MetricTypeGauge, pmetric.MetricTypeExponentialHistogram, pmetric.MetricTypeSummary:
-		}
+		out = initialDelta(metricID, metricPoint)
 		switch t.initialValue {
 		case InitialValueAuto:
 			if metricID.StartTimestamp < t.startTime || metricPoint.ObservedTimestamp == metricID.StartTimestamp {
@@ -139,12 +179,22 @@ func (t *MetricTracker) Convert(in MetricPoint) (out DeltaValue, valid bool) {
 		return
 	}
 
-	valid = true
-
 	state := s.(*State)
 	state.Lock()
 	defer state.Unlock()
 
+	// A change of the start timestamp signals that the cumulative value was reset,
+	// the point then holds the delta since its start timestamp.
+	if metricID.StartTimestamp != state.StartTimestamp {
+		out = initialDelta(metricID, metricPoint)
+		out.StartTimestamp = metricID.StartTimestamp
+		valid = t.resetValue == ResetValueKeep
+		state.StartTimestamp = metricID.StartTimestamp
+		state.PrevPoint = metricPoint
+		return
+	}
+
+	valid = true
 	out.StartTimestamp = state.PrevPoint.ObservedTimestamp
 
 	switch metricID.MetricType {
@@ -171,6 +221,43 @@ func (t *MetricTracker) Convert(in MetricPoint) (out DeltaValue, valid bool) {
 		}
 
 		out.HistogramValue = &delta
+	case pmetric.MetricTypeExponentialHistogram:
+		value := metricPoint.ExponentialHistogramValue
+		prevValue := state.PrevPoint.ExponentialHistogramValue
+		if math.IsNaN(value.Sum) {
+			value.Sum = prevValue.Sum
+		}
+
+		if value.ZeroThreshold != prevValue.ZeroThreshold {
+			valid = false
+		}
+
+		delta := value.Clone()
+
+		// Calculate deltas unless histogram count was reset
+		if valid && delta.Count >= prevValue.Count && delta.ZeroCount >= prevValue.ZeroCount {
+			if d, ok := subtractExponentialHistogram(*value, *prevValue); ok {
+				delta = d
+			}
+		}
+
+		out.ExponentialHistogramValue = &delta
+	case pmetric.MetricTypeSummary:
+		value := metricPoint.SummaryValue
+		prevValue := state.PrevPoint.SummaryValue
+		if math.IsNaN(value.Sum) {
+			value.Sum = prevValue.Sum
+		}
+
+		delta := *value
+
+		// Calculate deltas unless summary count was reset
+		if delta.Count >= prevValue.Count {
+			delta.Count -= prevValue.Count
+			delta.Sum -= prevValue.Sum
+		}
+
+		out.SummaryValue = &delta
 	case pmetric.MetricTypeSum:
 		if metricID.IsFloatVal() {
 			value := metricPoint.FloatValue
@@ -195,13 +282,58 @@ func (t *MetricTracker) Convert(in MetricPoint) (out DeltaValue, valid bool) {
 
 			out.IntValue = delta
 		}
-	case pmetric.MetricTypeEmpty, pmetric.MetricTypeGauge, pmetric.MetricTypeExponentialHistogram, pmetric.MetricTypeSummary:
+	case pmetric.MetricTypeEmpty, pmetric.MetricTypeGauge:
 	}
 
 	state.PrevPoint = metricPoint
 	return
 }
 
+// initialDelta returns the delta of the first point of a series, which is its value.
+func initialDelta(metricID MetricIdentity, metricPoint ValuePoint) (out DeltaValue) {
+	switch metricID.MetricType {
+	case pmetric.MetricTypeHistogram:
+		val := metricPoint.HistogramValue.Clone()
+		out.HistogramValue = &val
+	case pmetric.MetricTypeExponentialHistogram:
+		val := metricPoint.ExponentialHistogramValue.Clone()
+		out.ExponentialHistogramValue = &val
+	case pmetric.MetricTypeSummary:
+		val := *metricPoint.SummaryValue
+		out.SummaryValue = &val
+	case pmetric.MetricTypeSum:
+		out.IntValue = metricPoint.IntValue
+		out.FloatValue = metricPoint.FloatValue
+	case pmetric.MetricTypeEmpty, pmetric.MetricTypeGauge:
+	}
+	return out
+}
+
+// subtractExponentialHistogram returns the delta between two exponential histograms of a series. As the scale
+// of a cumulative histogram decreases when it grows, both are first downscaled to the lowest of their scales.
+// It returns false if some bucket count decreased, as after a reset.
+func subtractExponentialHistogram(value, prevValue ExponentialHistogramPoint) (ExponentialHistogramPoint, bool) {
+	scale := min(value.Scale, prevValue.Scale)
+	delta := ExponentialHistogramPoint{
+		Count:         value.Count - prevValue.Count,
+		Sum:           value.Sum - prevValue.Sum,
+		Scale:         scale,
+		ZeroCount:     value.ZeroCount - prevValue.ZeroCount,
+		ZeroThreshold: value.ZeroThreshold,
+	}
+
+	var ok bool
+	positive := value.Positive.Downscale(value.Scale - scale)
+	if delta.Positive, ok = positive.Subtract(prevValue.Positive.Downscale(prevValue.Scale - scale)); !ok {
+		return value, false
+	}
+	negative := value.Negative.Downscale(value.Scale - scale)
+	if delta.Negative, ok = negative.Subtract(prevValue.Negative.Downscale(prevValue.Scale - scale)); !ok {
+		return value, false
+	}
+	return delta, true
+}
+
 func (t *MetricTracker) removeStale(staleBefore pcommon.Timestamp) {
 	t.states.Range(func(key, value any) bool {
 		s := value.(*State)
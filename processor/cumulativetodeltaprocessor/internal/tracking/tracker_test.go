@@ -194,7 +194,7 @@ func TestMetricTracker_Convert(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.initValue.String(), func(t *testing.T) {
-			m := NewMetricTracker(context.Background(), zap.NewNop(), 0, tt.initValue)
+			m := NewMetricTracker(context.Background(), zap.NewNop(), 0, tt.initValue, false, ResetValueKeep)
 
 			miSum := miSum
 			miSum.StartTimestamp = tt.metricStartTime
@@ -231,7 +231,7 @@ func TestMetricTracker_Convert(t *testing.T) {
 	}
 
 	t.Run("Invalid metric identity", func(t *testing.T) {
-		m := NewMetricTracker(context.Background(), zap.NewNop(), 0, InitialValueAuto)
+		m := NewMetricTracker(context.Background(), zap.NewNop(), 0, InitialValueAuto, false, ResetValueKeep)
 		invalidID := miIntSum
 		invalidID.MetricType = pmetric.MetricTypeGauge
 		_, valid := m.Convert(MetricPoint{
@@ -248,6 +248,185 @@ func TestMetricTracker_Convert(t *testing.T) {
 	})
 }
 
+func TestMetricTracker_ConvertResetByStartTimestamp(t *testing.T) {
+	future := time.Now().Add(1 * time.Hour)
+	mi := MetricIdentity{
+		Resource:               pcommon.NewResource(),
+		InstrumentationLibrary: pcommon.NewInstrumentationScope(),
+		MetricType:             pmetric.MetricTypeSum,
+		MetricIsMonotonic:      true,
+		MetricValueType:        pmetric.NumberDataPointValueTypeInt,
+		Attributes:             pcommon.NewMap(),
+		StartTimestamp:         pcommon.NewTimestampFromTime(future),
+	}
+	restarted := mi
+	restarted.StartTimestamp = pcommon.NewTimestampFromTime(future.Add(2 * time.Minute))
+
+	for _, resetValue := range []ResetValue{ResetValueKeep, ResetValueDrop} {
+		t.Run(resetValue.String(), func(t *testing.T) {
+			m := NewMetricTracker(context.Background(), zap.NewNop(), 0, InitialValueKeep, true, resetValue)
+
+			_, valid := m.Convert(MetricPoint{Identity: mi, Value: ValuePoint{
+				ObservedTimestamp: pcommon.NewTimestampFromTime(future.Add(time.Minute)),
+				IntValue:          100,
+			}})
+			require.True(t, valid)
+
+			// The value is higher than the previous one, but the series was restarted.
+			out, valid := m.Convert(MetricPoint{Identity: restarted, Value: ValuePoint{
+				ObservedTimestamp: pcommon.NewTimestampFromTime(future.Add(3 * time.Minute)),
+				IntValue:          150,
+			}})
+			if resetValue == ResetValueKeep {
+				require.True(t, valid)
+				assert.Equal(t, restarted.StartTimestamp, out.StartTimestamp)
+				assert.Equal(t, int64(150), out.IntValue)
+			} else {
+				assert.False(t, valid)
+			}
+
+			out, valid = m.Convert(MetricPoint{Identity: restarted, Value: ValuePoint{
+				ObservedTimestamp: pcommon.NewTimestampFromTime(future.Add(4 * time.Minute)),
+				IntValue:          160,
+			}})
+			require.True(t, valid)
+			assert.Equal(t, pcommon.NewTimestampFromTime(future.Add(3*time.Minute)), out.StartTimestamp)
+			assert.Equal(t, int64(10), out.IntValue)
+		})
+	}
+}
+
+func TestMetricTracker_ConvertStartTimestampChangeWithoutResetDetection(t *testing.T) {
+	future := time.Now().Add(1 * time.Hour)
+	mi := MetricIdentity{
+		Resource:               pcommon.NewResource(),
+		InstrumentationLibrary: pcommon.NewInstrumentationScope(),
+		MetricType:             pmetric.MetricTypeSum,
+		MetricIsMonotonic:      true,
+		MetricValueType:        pmetric.NumberDataPointValueTypeInt,
+		Attributes:             pcommon.NewMap(),
+		StartTimestamp:         pcommon.NewTimestampFromTime(future),
+	}
+	restarted := mi
+	restarted.StartTimestamp = pcommon.NewTimestampFromTime(future.Add(2 * time.Minute))
+
+	m := NewMetricTracker(context.Background(), zap.NewNop(), 0, InitialValueDrop, false, ResetValueKeep)
+	_, valid := m.Convert(MetricPoint{Identity: mi, Value: ValuePoint{
+		ObservedTimestamp: pcommon.NewTimestampFromTime(future.Add(time.Minute)),
+		IntValue:          100,
+	}})
+	require.False(t, valid)
+
+	// The point with another start timestamp is the first point of another series.
+	_, valid = m.Convert(MetricPoint{Identity: restarted, Value: ValuePoint{
+		ObservedTimestamp: pcommon.NewTimestampFromTime(future.Add(3 * time.Minute)),
+		IntValue:          150,
+	}})
+	require.False(t, valid)
+
+	out, valid := m.Convert(MetricPoint{Identity: mi, Value: ValuePoint{
+		ObservedTimestamp: pcommon.NewTimestampFromTime(future.Add(4 * time.Minute)),
+		IntValue:          120,
+	}})
+	require.True(t, valid)
+	assert.Equal(t, int64(20), out.IntValue)
+}
+
+func TestMetricTracker_ConvertExponentialHistogram(t *testing.T) {
+	future := time.Now().Add(1 * time.Hour)
+	mi := MetricIdentity{
+		Resource:               pcommon.NewResource(),
+		InstrumentationLibrary: pcommon.NewInstrumentationScope(),
+		MetricType:             pmetric.MetricTypeExponentialHistogram,
+		MetricIsMonotonic:      true,
+		MetricValueType:        pmetric.NumberDataPointValueTypeInt,
+		Attributes:             pcommon.NewMap(),
+	}
+	m := NewMetricTracker(context.Background(), zap.NewNop(), 0, InitialValueDrop, false, ResetValueKeep)
+
+	_, valid := m.Convert(MetricPoint{Identity: mi, Value: ValuePoint{
+		ObservedTimestamp: pcommon.NewTimestampFromTime(future),
+		ExponentialHistogramValue: &ExponentialHistogramPoint{
+			Count:     6,
+			Sum:       20,
+			Scale:     2,
+			ZeroCount: 1,
+			Positive:  ExponentialHistogramBuckets{Offset: 4, BucketCounts: []uint64{1, 2, 2}},
+		},
+	}})
+	require.False(t, valid)
+
+	// The scale was decreased to fit the new values, the previous buckets are merged before being subtracted.
+	out, valid := m.Convert(MetricPoint{Identity: mi, Value: ValuePoint{
+		ObservedTimestamp: pcommon.NewTimestampFromTime(future.Add(time.Minute)),
+		ExponentialHistogramValue: &ExponentialHistogramPoint{
+			Count:     10,
+			Sum:       50,
+			Scale:     1,
+			ZeroCount: 2,
+			Positive:  ExponentialHistogramBuckets{Offset: 2, BucketCounts: []uint64{4, 2, 1}},
+			Negative:  ExponentialHistogramBuckets{Offset: 0, BucketCounts: []uint64{1}},
+		},
+	}})
+	require.True(t, valid)
+	assert.Equal(t, pcommon.NewTimestampFromTime(future), out.StartTimestamp)
+	assert.Equal(t, &ExponentialHistogramPoint{
+		Count:     4,
+		Sum:       30,
+		Scale:     1,
+		ZeroCount: 1,
+		Positive:  ExponentialHistogramBuckets{Offset: 2, BucketCounts: []uint64{1, 0, 1}},
+		Negative:  ExponentialHistogramBuckets{Offset: 0, BucketCounts: []uint64{1}},
+	}, out.ExponentialHistogramValue)
+
+	// A bucket count decreased, the value is sent as is.
+	reset := &ExponentialHistogramPoint{
+		Count:     13,
+		Sum:       55,
+		Scale:     1,
+		ZeroCount: 2,
+		Positive:  ExponentialHistogramBuckets{Offset: 2, BucketCounts: []uint64{1, 9, 1}},
+		Negative:  ExponentialHistogramBuckets{Offset: 0, BucketCounts: []uint64{1}},
+	}
+	out, valid = m.Convert(MetricPoint{Identity: mi, Value: ValuePoint{
+		ObservedTimestamp:         pcommon.NewTimestampFromTime(future.Add(2 * time.Minute)),
+		ExponentialHistogramValue: reset,
+	}})
+	require.True(t, valid)
+	assert.Equal(t, reset, out.ExponentialHistogramValue)
+}
+
+func TestMetricTracker_ConvertSummary(t *testing.T) {
+	future := time.Now().Add(1 * time.Hour)
+	mi := MetricIdentity{
+		Resource:               pcommon.NewResource(),
+		InstrumentationLibrary: pcommon.NewInstrumentationScope(),
+		MetricType:             pmetric.MetricTypeSummary,
+		MetricIsMonotonic:      true,
+		MetricValueType:        pmetric.NumberDataPointValueTypeInt,
+		Attributes:             pcommon.NewMap(),
+	}
+	m := NewMetricTracker(context.Background(), zap.NewNop(), 0, InitialValueKeep, false, ResetValueKeep)
+
+	points := []struct {
+		value SummaryPoint
+		want  SummaryPoint
+	}{
+		{value: SummaryPoint{Count: 10, Sum: 100}, want: SummaryPoint{Count: 10, Sum: 100}},
+		{value: SummaryPoint{Count: 15, Sum: 160}, want: SummaryPoint{Count: 5, Sum: 60}},
+		{value: SummaryPoint{Count: 3, Sum: 30}, want: SummaryPoint{Count: 3, Sum: 30}},
+	}
+	for i, point := range points {
+		value := point.value
+		out, valid := m.Convert(MetricPoint{Identity: mi, Value: ValuePoint{
+			ObservedTimestamp: pcommon.NewTimestampFromTime(future.Add(time.Duration(i) * time.Minute)),
+			SummaryValue:      &value,
+		}})
+		require.True(t, valid)
+		assert.Equal(t, point.want, *out.SummaryValue)
+	}
+}
+
 func Test_metricTracker_removeStale(t *testing.T) {
 	currentTime := pcommon.Timestamp(100)
 	freshPoint := ValuePoint{
@@ -6,10 +6,12 @@ package tracking // import "github.com/open-telemetry/opentelemetry-collector-co
 import "go.opentelemetry.io/collector/pdata/pcommon"
 
 type ValuePoint struct {
-	ObservedTimestamp pcommon.Timestamp
-	FloatValue        float64
-	IntValue          int64
-	HistogramValue    *HistogramPoint
+	ObservedTimestamp         pcommon.Timestamp
+	FloatValue                float64
+	IntValue                  int64
+	HistogramValue            *HistogramPoint
+	ExponentialHistogramValue *ExponentialHistogramPoint
+	SummaryValue              *SummaryPoint
 }
 
 type HistogramPoint struct {
@@ -28,3 +30,75 @@ func (point *HistogramPoint) Clone() HistogramPoint {
 		Buckets: bucketValues,
 	}
 }
+
+type ExponentialHistogramPoint struct {
+	Count         uint64
+	Sum           float64
+	Scale         int32
+	ZeroCount     uint64
+	ZeroThreshold float64
+	Positive      ExponentialHistogramBuckets
+	Negative      ExponentialHistogramBuckets
+}
+
+type ExponentialHistogramBuckets struct {
+	Offset       int32
+	BucketCounts []uint64
+}
+
+func (point *ExponentialHistogramPoint) Clone() ExponentialHistogramPoint {
+	clone := *point
+	clone.Positive = point.Positive.Clone()
+	clone.Negative = point.Negative.Clone()
+	return clone
+}
+
+func (buckets *ExponentialHistogramBuckets) Clone() ExponentialHistogramBuckets {
+	bucketCounts := make([]uint64, len(buckets.BucketCounts))
+	copy(bucketCounts, buckets.BucketCounts)
+
+	return ExponentialHistogramBuckets{
+		Offset:       buckets.Offset,
+		BucketCounts: bucketCounts,
+	}
+}
+
+// Downscale returns the buckets merged into the buckets of a scale lower by the given number of steps.
+// Each step merges pairs of adjacent buckets.
+func (buckets *ExponentialHistogramBuckets) Downscale(by int32) ExponentialHistogramBuckets {
+	if by == 0 || len(buckets.BucketCounts) == 0 {
+		return buckets.Clone()
+	}
+	offset := buckets.Offset >> by
+	last := (buckets.Offset + int32(len(buckets.BucketCounts)) - 1) >> by
+	bucketCounts := make([]uint64, last-offset+1)
+	for i, count := range buckets.BucketCounts {
+		bucketCounts[((buckets.Offset+int32(i))>>by)-offset] += count
+	}
+	return ExponentialHistogramBuckets{
+		Offset:       offset,
+		BucketCounts: bucketCounts,
+	}
+}
+
+// Subtract returns the buckets minus the previous buckets, both being of the same scale.
+// It returns false if some previous bucket count is higher than the matching one, as after a reset.
+func (buckets *ExponentialHistogramBuckets) Subtract(prev ExponentialHistogramBuckets) (ExponentialHistogramBuckets, bool) {
+	delta := buckets.Clone()
+	for i, prevCount := range prev.BucketCounts {
+		if prevCount == 0 {
+			continue
+		}
+		index := prev.Offset + int32(i) - delta.Offset
+		if index < 0 || int(index) >= len(delta.BucketCounts) || delta.BucketCounts[index] < prevCount {
+			return buckets.Clone(), false
+		}
+		delta.BucketCounts[index] -= prevCount
+	}
+	return delta, true
+}
+
+type SummaryPoint struct {
+	Count uint64
+	Sum   float64
+}
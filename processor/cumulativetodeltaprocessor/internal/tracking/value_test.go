@@ -0,0 +1,98 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tracking
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExponentialHistogramBuckets_Downscale(t *testing.T) {
+	tests := []struct {
+		name    string
+		buckets ExponentialHistogramBuckets
+		by      int32
+		want    ExponentialHistogramBuckets
+	}{
+		{
+			name:    "same scale",
+			buckets: ExponentialHistogramBuckets{Offset: 3, BucketCounts: []uint64{1, 2, 3}},
+			want:    ExponentialHistogramBuckets{Offset: 3, BucketCounts: []uint64{1, 2, 3}},
+		},
+		{
+			name:    "one step",
+			buckets: ExponentialHistogramBuckets{Offset: 3, BucketCounts: []uint64{1, 2, 3}},
+			by:      1,
+			want:    ExponentialHistogramBuckets{Offset: 1, BucketCounts: []uint64{1, 5}},
+		},
+		{
+			name:    "two steps",
+			buckets: ExponentialHistogramBuckets{Offset: 3, BucketCounts: []uint64{1, 2, 3}},
+			by:      2,
+			want:    ExponentialHistogramBuckets{Offset: 0, BucketCounts: []uint64{1, 5}},
+		},
+		{
+			name:    "negative offset",
+			buckets: ExponentialHistogramBuckets{Offset: -3, BucketCounts: []uint64{1, 2, 3, 4}},
+			by:      1,
+			want:    ExponentialHistogramBuckets{Offset: -2, BucketCounts: []uint64{1, 5, 4}},
+		},
+		{
+			name:    "empty",
+			buckets: ExponentialHistogramBuckets{Offset: 5},
+			by:      1,
+			want:    ExponentialHistogramBuckets{Offset: 5, BucketCounts: []uint64{}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.buckets.Downscale(tt.by))
+		})
+	}
+}
+
+func TestExponentialHistogramBuckets_Subtract(t *testing.T) {
+	tests := []struct {
+		name    string
+		buckets ExponentialHistogramBuckets
+		prev    ExponentialHistogramBuckets
+		want    ExponentialHistogramBuckets
+		wantOk  bool
+	}{
+		{
+			name:    "same range",
+			buckets: ExponentialHistogramBuckets{Offset: 1, BucketCounts: []uint64{3, 5}},
+			prev:    ExponentialHistogramBuckets{Offset: 1, BucketCounts: []uint64{1, 2}},
+			want:    ExponentialHistogramBuckets{Offset: 1, BucketCounts: []uint64{2, 3}},
+			wantOk:  true,
+		},
+		{
+			name:    "wider range",
+			buckets: ExponentialHistogramBuckets{Offset: 0, BucketCounts: []uint64{1, 3, 5}},
+			prev:    ExponentialHistogramBuckets{Offset: 1, BucketCounts: []uint64{2, 0, 0}},
+			want:    ExponentialHistogramBuckets{Offset: 0, BucketCounts: []uint64{1, 1, 5}},
+			wantOk:  true,
+		},
+		{
+			name:    "decreased count",
+			buckets: ExponentialHistogramBuckets{Offset: 1, BucketCounts: []uint64{3, 1}},
+			prev:    ExponentialHistogramBuckets{Offset: 1, BucketCounts: []uint64{1, 2}},
+			want:    ExponentialHistogramBuckets{Offset: 1, BucketCounts: []uint64{3, 1}},
+		},
+		{
+			name:    "missing bucket",
+			buckets: ExponentialHistogramBuckets{Offset: 1, BucketCounts: []uint64{3, 5}},
+			prev:    ExponentialHistogramBuckets{Offset: 0, BucketCounts: []uint64{1}},
+			want:    ExponentialHistogramBuckets{Offset: 1, BucketCounts: []uint64{3, 5}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.buckets.Subtract(tt.prev)
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -15,19 +15,21 @@ import (
 )
 
 type cumulativeToDeltaProcessor struct {
-	includeFS       filterset.FilterSet
-	excludeFS       filterset.FilterSet
-	logger          *zap.Logger
-	deltaCalculator *tracking.MetricTracker
-	cancelFunc      context.CancelFunc
+	includeFS        filterset.FilterSet
+	excludeFS        filterset.FilterSet
+	logger           *zap.Logger
+	deltaCalculator  *tracking.MetricTracker
+	convertSummaries bool
+	cancelFunc       context.CancelFunc
 }
 
 func newCumulativeToDeltaProcessor(config *Config, logger *zap.Logger) *cumulativeToDeltaProcessor {
 	ctx, cancel := context.WithCancel(context.Background())
 	p := &cumulativeToDeltaProcessor{
-		logger:          logger,
-		deltaCalculator: tracking.NewMetricTracker(ctx, logger, config.MaxStaleness, config.InitialValue),
-		cancelFunc:      cancel,
+		logger:           logger,
+		deltaCalculator:  tracking.NewMetricTracker(ctx, logger, config.MaxStaleness, config.InitialValue, config.DetectStartTimeResets, config.ResetValue),
+		convertSummaries: config.ConvertSummaries,
+		cancelFunc:       cancel,
 	}
 	if len(config.Include.Metrics) > 0 {
 		p.includeFS, _ = filterset.CreateFilterSet(config.Include.Metrics, &config.Include.Config)
@@ -93,7 +95,50 @@ func (ctdp *cumulativeToDeltaProcessor) processMetrics(_ context.Context, md pme
 
 					ms.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
 					return ms.DataPoints().Len() == 0
-				case pmetric.MetricTypeEmpty, pmetric.MetricTypeGauge, pmetric.MetricTypeExponentialHistogram, pmetric.MetricTypeSummary:
+				case pmetric.MetricTypeExponentialHistogram:
+					ms := m.ExponentialHistogram()
+					if ms.AggregationTemporality() != pmetric.AggregationTemporalityCumulative {
+						return false
+					}
+
+					if ms.DataPoints().Len() == 0 {
+						return false
+					}
+
+					baseIdentity := tracking.MetricIdentity{
+						Resource:               rm.Resource(),
+						InstrumentationLibrary: ilm.Scope(),
+						MetricType:             m.Type(),
+						MetricName:             m.Name(),
+						MetricUnit:             m.Unit(),
+						MetricIsMonotonic:      true,
+						MetricValueType:        pmetric.NumberDataPointValueTypeInt,
+					}
+
+					ctdp.convertExponentialHistogramDataPoints(ms.DataPoints(), baseIdentity)
+
+					ms.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+					return ms.DataPoints().Len() == 0
+				case pmetric.MetricTypeSummary:
+					// Summaries don't have an aggregation temporality, their count and sum are always cumulative.
+					ms := m.Summary()
+					if !ctdp.convertSummaries || ms.DataPoints().Len() == 0 {
+						return false
+					}
+
+					baseIdentity := tracking.MetricIdentity{
+						Resource:               rm.Resource(),
+						InstrumentationLibrary: ilm.Scope(),
+						MetricType:             m.Type(),
+						MetricName:             m.Name(),
+						MetricUnit:             m.Unit(),
+						MetricIsMonotonic:      true,
+						MetricValueType:        pmetric.NumberDataPointValueTypeInt,
+					}
+
+					ctdp.convertSummaryDataPoints(ms.DataPoints(), baseIdentity)
+					return ms.DataPoints().Len() == 0
+				case pmetric.MetricTypeEmpty, pmetric.MetricTypeGauge:
 					fallthrough
 				default:
 					return false
@@ -200,3 +245,98 @@ func (ctdp *cumulativeToDeltaProcessor) convertHistogramDataPoints(in any, baseI
 		})
 	}
 }
+
+func (ctdp *cumulativeToDeltaProcessor) convertExponentialHistogramDataPoints(dps pmetric.ExponentialHistogramDataPointSlice, baseIdentity tracking.MetricIdentity) {
+	dps.RemoveIf(func(dp pmetric.ExponentialHistogramDataPoint) bool {
+		id := baseIdentity
+		id.StartTimestamp = dp.StartTimestamp()
+		id.Attributes = dp.Attributes()
+
+		if dp.Flags().NoRecordedValue() {
+			// drop points with no value
+			return true
+		}
+
+		point := tracking.ValuePoint{
+			ObservedTimestamp: dp.Timestamp(),
+			ExponentialHistogramValue: &tracking.ExponentialHistogramPoint{
+				Count:         dp.Count(),
+				Sum:           dp.Sum(),
+				Scale:         dp.Scale(),
+				ZeroCount:     dp.ZeroCount(),
+				ZeroThreshold: dp.ZeroThreshold(),
+				Positive: tracking.ExponentialHistogramBuckets{
+					Offset:       dp.Positive().Offset(),
+					BucketCounts: dp.Positive().BucketCounts().AsRaw(),
+				},
+				Negative: tracking.ExponentialHistogramBuckets{
+					Offset:       dp.Negative().Offset(),
+					BucketCounts: dp.Negative().BucketCounts().AsRaw(),
+				},
+			},
+		}
+
+		trackingPoint := tracking.MetricPoint{
+			Identity: id,
+			Value:    point,
+		}
+		delta, valid := ctdp.deltaCalculator.Convert(trackingPoint)
+		if !valid {
+			return true
+		}
+
+		value := delta.ExponentialHistogramValue
+		dp.SetStartTimestamp(delta.StartTimestamp)
+		dp.SetCount(value.Count)
+		if dp.HasSum() && !math.IsNaN(dp.Sum()) {
+			dp.SetSum(value.Sum)
+		}
+		dp.SetScale(value.Scale)
+		dp.SetZeroCount(value.ZeroCount)
+		dp.Positive().SetOffset(value.Positive.Offset)
+		dp.Positive().BucketCounts().FromRaw(value.Positive.BucketCounts)
+		dp.Negative().SetOffset(value.Negative.Offset)
+		dp.Negative().BucketCounts().FromRaw(value.Negative.BucketCounts)
+		dp.RemoveMin()
+		dp.RemoveMax()
+		return false
+	})
+}
+
+func (ctdp *cumulativeToDeltaProcessor) convertSummaryDataPoints(dps pmetric.SummaryDataPointSlice, baseIdentity tracking.MetricIdentity) {
+	dps.RemoveIf(func(dp pmetric.SummaryDataPoint) bool {
+		id := baseIdentity
+		id.StartTimestamp = dp.StartTimestamp()
+		id.Attributes = dp.Attributes()
+
+		if dp.Flags().NoRecordedValue() {
+			// drop points with no value
+			return true
+		}
+
+		point := tracking.ValuePoint{
+			ObservedTimestamp: dp.Timestamp(),
+			SummaryValue: &tracking.SummaryPoint{
+				Count: dp.Count(),
+				Sum:   dp.Sum(),
+			},
+		}
+
+		trackingPoint := tracking.MetricPoint{
+			Identity: id,
+			Value:    point,
+		}
+		delta, valid := ctdp.deltaCalculator.Convert(trackingPoint)
+		if !valid {
+			return true
+		}
+
+		// Quantiles are computed over the whole lifetime of the summary and cannot be converted, they are left unchanged.
+		dp.SetStartTimestamp(delta.StartTimestamp)
+		dp.SetCount(delta.SummaryValue.Count)
+		if !math.IsNaN(dp.Sum()) {
+			dp.SetSum(delta.SummaryValue.Sum)
+		}
+		return false
+	})
+}
@@ -20,6 +20,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterset"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/cumulativetodeltaprocessor/internal/tracking"
 )
 
 var (
@@ -534,6 +535,165 @@ func TestCumulativeToDeltaProcessor(t *testing.T) {
 	}
 }
 
+func TestCumulativeToDeltaProcessorExponentialHistogram(t *testing.T) {
+	next := new(consumertest.MetricsSink)
+	cfg := &Config{InitialValue: tracking.InitialValueDrop}
+	mgp, err := NewFactory().CreateMetrics(context.Background(), processortest.NewNopSettings(), cfg, next)
+	require.NoError(t, err)
+	require.NoError(t, mgp.Start(context.Background(), nil))
+
+	md := pmetric.NewMetrics()
+	hist := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetEmptyExponentialHistogram()
+	hist.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	now := time.Now()
+	for i, count := range []uint64{5, 9} {
+		dp := hist.DataPoints().AppendEmpty()
+		dp.SetTimestamp(pcommon.NewTimestampFromTime(now.Add(time.Duration(i) * time.Minute)))
+		dp.SetCount(count)
+		dp.SetSum(float64(count * 10))
+		dp.SetMin(1)
+		dp.SetMax(100)
+		dp.SetZeroCount(1)
+		dp.Positive().SetOffset(int32(4 - 2*i))
+		if i == 0 {
+			dp.SetScale(2)
+			dp.Positive().BucketCounts().FromRaw([]uint64{1, 1, 2})
+		} else {
+			dp.SetScale(1)
+			dp.Positive().BucketCounts().FromRaw([]uint64{3, 3, 2})
+		}
+	}
+
+	require.NoError(t, mgp.ConsumeMetrics(context.Background(), md))
+	require.Len(t, next.AllMetrics(), 1)
+
+	got := next.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).ExponentialHistogram()
+	assert.Equal(t, pmetric.AggregationTemporalityDelta, got.AggregationTemporality())
+	require.Equal(t, 1, got.DataPoints().Len())
+	dp := got.DataPoints().At(0)
+	assert.Equal(t, pcommon.NewTimestampFromTime(now), dp.StartTimestamp())
+	assert.Equal(t, uint64(4), dp.Count())
+	assert.Equal(t, 40.0, dp.Sum())
+	assert.Equal(t, int32(1), dp.Scale())
+	assert.Equal(t, uint64(0), dp.ZeroCount())
+	assert.Equal(t, int32(2), dp.Positive().Offset())
+	assert.Equal(t, []uint64{1, 1, 2}, dp.Positive().BucketCounts().AsRaw())
+	assert.False(t, dp.HasMin())
+	assert.False(t, dp.HasMax())
+
+	require.NoError(t, mgp.Shutdown(context.Background()))
+}
+
+func TestCumulativeToDeltaProcessorSummary(t *testing.T) {
+	next := new(consumertest.MetricsSink)
+	cfg := &Config{InitialValue: tracking.InitialValueDrop, ConvertSummaries: true}
+	mgp, err := NewFactory().CreateMetrics(context.Background(), processortest.NewNopSettings(), cfg, next)
+	require.NoError(t, err)
+	require.NoError(t, mgp.Start(context.Background(), nil))
+
+	md := pmetric.NewMetrics()
+	summary := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetEmptySummary()
+	now := time.Now()
+	for i, count := range []uint64{10, 15} {
+		dp := summary.DataPoints().AppendEmpty()
+		dp.SetTimestamp(pcommon.NewTimestampFromTime(now.Add(time.Duration(i) * time.Minute)))
+		dp.SetCount(count)
+		dp.SetSum(float64(count * 2))
+		quantile := dp.QuantileValues().AppendEmpty()
+		quantile.SetQuantile(0.99)
+		quantile.SetValue(3)
+	}
+
+	require.NoError(t, mgp.ConsumeMetrics(context.Background(), md))
+	require.Len(t, next.AllMetrics(), 1)
+
+	got := next.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Summary()
+	require.Equal(t, 1, got.DataPoints().Len())
+	dp := got.DataPoints().At(0)
+	assert.Equal(t, pcommon.NewTimestampFromTime(now), dp.StartTimestamp())
+	assert.Equal(t, uint64(5), dp.Count())
+	assert.Equal(t, 10.0, dp.Sum())
+	assert.Equal(t, 3.0, dp.QuantileValues().At(0).Value())
+
+	require.NoError(t, mgp.Shutdown(context.Background()))
+}
+
+func TestCumulativeToDeltaProcessorSummaryNotConverted(t *testing.T) {
+	next := new(consumertest.MetricsSink)
+	cfg := &Config{InitialValue: tracking.InitialValueDrop}
+	mgp, err := NewFactory().CreateMetrics(context.Background(), processortest.NewNopSettings(), cfg, next)
+	require.NoError(t, err)
+	require.NoError(t, mgp.Start(context.Background(), nil))
+
+	md := pmetric.NewMetrics()
+	summary := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetEmptySummary()
+	for _, count := range []uint64{10, 15} {
+		dp := summary.DataPoints().AppendEmpty()
+		dp.SetCount(count)
+	}
+	expected := pmetric.NewMetrics()
+	md.CopyTo(expected)
+
+	require.NoError(t, mgp.ConsumeMetrics(context.Background(), md))
+	require.Len(t, next.AllMetrics(), 1)
+	assert.Equal(t, expected, next.AllMetrics()[0])
+
+	require.NoError(t, mgp.Shutdown(context.Background()))
+}
+
+func TestCumulativeToDeltaProcessorResetByStartTimestamp(t *testing.T) {
+	tests := []struct {
+		resetValue tracking.ResetValue
+		want       []float64
+	}{
+		{
+			resetValue: tracking.ResetValueKeep,
+			want:       []float64{20, 150, 10},
+		},
+		{
+			resetValue: tracking.ResetValueDrop,
+			want:       []float64{20, 10},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.resetValue.String(), func(t *testing.T) {
+			next := new(consumertest.MetricsSink)
+			cfg := &Config{InitialValue: tracking.InitialValueDrop, DetectStartTimeResets: true, ResetValue: tt.resetValue}
+			mgp, err := NewFactory().CreateMetrics(context.Background(), processortest.NewNopSettings(), cfg, next)
+			require.NoError(t, err)
+			require.NoError(t, mgp.Start(context.Background(), nil))
+
+			md := pmetric.NewMetrics()
+			sum := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetEmptySum()
+			sum.SetIsMonotonic(true)
+			sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+			now := time.Now()
+			restart := now.Add(2 * time.Minute)
+			for i, point := range []struct {
+				start time.Time
+				value float64
+			}{{now, 100}, {now, 120}, {restart, 150}, {restart, 160}} {
+				dp := sum.DataPoints().AppendEmpty()
+				dp.SetStartTimestamp(pcommon.NewTimestampFromTime(point.start))
+				dp.SetTimestamp(pcommon.NewTimestampFromTime(now.Add(time.Duration(i+1) * time.Minute)))
+				dp.SetDoubleValue(point.value)
+			}
+
+			require.NoError(t, mgp.ConsumeMetrics(context.Background(), md))
+			require.Len(t, next.AllMetrics(), 1)
+
+			dps := next.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints()
+			var got []float64
+			for i := 0; i < dps.Len(); i++ {
+				got = append(got, dps.At(i).DoubleValue())
+			}
+			assert.Equal(t, tt.want, got)
+
+			require.NoError(t, mgp.Shutdown(context.Background()))
+		})
+	}
+}
+
 func generateTestSumMetrics(tm testSumMetric) pmetric.Metrics {
 	md := pmetric.NewMetrics()
 	now := time.Now()
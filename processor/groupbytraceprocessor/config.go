@@ -4,6 +4,8 @@
 package groupbytraceprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbytraceprocessor"
 
 import (
+	"errors"
+	"fmt"
 	"time"
 )
 
@@ -33,4 +35,41 @@ type Config struct {
 	// Default: false.
 	// Not yet implemented, and an error will be returned when this option is used.
 	StoreOnDisk bool `mapstructure:"store_on_disk"`
+
+	// MaxBytes is the maximum size, in bytes, of the spans kept in the internal storage. It is split
+	// evenly among the workers, so it must be at least NumWorkers. When the limit is exceeded, the oldest traces are released before the wait duration,
+	// with the groupbytrace.partial attribute set on their spans.
+	// Default: 0, meaning that the storage is only limited by the number of traces.
+	MaxBytes int `mapstructure:"max_bytes"`
+
+	// Completeness configures the release of traces that look complete before the wait duration.
+	Completeness CompletenessConfig `mapstructure:"completeness"`
+}
+
+// CompletenessConfig configures the heuristics used to release traces that look complete
+// before the wait duration. The wait duration remains the maximum time a trace is kept.
+type CompletenessConfig struct {
+	// Enabled tells the processor to release a trace as soon as its root span has ended and
+	// the parent span of every span is part of the trace.
+	// Default: false.
+	Enabled bool `mapstructure:"enabled"`
+
+	// QuietPeriod tells the processor to release a trace once no new spans have been received
+	// for it for the specified duration.
+	// Default: 0, meaning that traces are not released based on the time since their last span.
+	QuietPeriod time.Duration `mapstructure:"quiet_period"`
+}
+
+// Validate checks if the processor configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.MaxBytes < 0 {
+		return errors.New("max_bytes must not be negative")
+	}
+	if cfg.MaxBytes > 0 && cfg.MaxBytes < cfg.NumWorkers {
+		return fmt.Errorf("max_bytes must be at least num_workers (%d), since it is split among the workers", cfg.NumWorkers)
+	}
+	if cfg.Completeness.QuietPeriod < 0 {
+		return errors.New("completeness quiet_period must not be negative")
+	}
+	return nil
 }
@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package groupbytraceprocessor
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbytraceprocessor/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id       component.ID
+		expected component.Config
+	}{
+		{
+			id: component.NewIDWithName(metadata.Type, "custom"),
+			expected: &Config{
+				NumTraces:    1000,
+				NumWorkers:   defaultNumWorkers,
+				WaitDuration: 10 * time.Second,
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "completeness"),
+			expected: &Config{
+				NumTraces:    defaultNumTraces,
+				NumWorkers:   defaultNumWorkers,
+				WaitDuration: 30 * time.Second,
+				MaxBytes:     512 * 1024 * 1024,
+				Completeness: CompletenessConfig{
+					Enabled:     true,
+					QuietPeriod: 5 * time.Second,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cfg := createDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, sub.Unmarshal(cfg))
+
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
+
+func TestValidateConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.MaxBytes = -1
+	assert.EqualError(t, cfg.Validate(), "max_bytes must not be negative")
+
+	cfg = createDefaultConfig().(*Config)
+	cfg.NumWorkers = 4
+	cfg.MaxBytes = 3
+	assert.EqualError(t, cfg.Validate(), "max_bytes must be at least num_workers (4), since it is split among the workers")
+
+	cfg = createDefaultConfig().(*Config)
+	cfg.Completeness.QuietPeriod = -time.Second
+	assert.EqualError(t, cfg.Validate(), "completeness quiet_period must not be negative")
+}
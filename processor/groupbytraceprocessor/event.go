@@ -32,6 +32,12 @@ const (
 
 	// traceID to be removed
 	traceRemoved
+
+	// traceID that didn't receive new spans for the quiet period
+	traceQuiet
+
+	// traceID evicted from the ring buffer, to be removed and released as partial
+	traceEvicted
 )
 
 var (
@@ -76,6 +82,8 @@ type eventMachine struct {
 	onTraceExpired  func(traceID pcommon.TraceID, worker *eventMachineWorker) error
 	onTraceReleased func(rss []ptrace.ResourceSpans) error
 	onTraceRemoved  func(traceID pcommon.TraceID) error
+	onTraceQuiet    func(traceID pcommon.TraceID, worker *eventMachineWorker) error
+	onTraceEvicted  func(traceID pcommon.TraceID) error
 
 	onError func(event)
 
@@ -206,6 +214,38 @@ func (em *eventMachine) handleEvent(e event, w *eventMachineWorker) {
 		em.handleEventWithObservability("onTraceRemoved", func() error {
 			return em.onTraceRemoved(payload)
 		})
+	case traceQuiet:
+		if em.onTraceQuiet == nil {
+			em.logger.Debug("onTraceQuiet not set, skipping event")
+			em.callOnError(e)
+			return
+		}
+		payload, ok := e.payload.(pcommon.TraceID)
+		if !ok {
+			// the payload had an unexpected type!
+			em.callOnError(e)
+			return
+		}
+
+		em.handleEventWithObservability("onTraceQuiet", func() error {
+			return em.onTraceQuiet(payload, w)
+		})
+	case traceEvicted:
+		if em.onTraceEvicted == nil {
+			em.logger.Debug("onTraceEvicted not set, skipping event")
+			em.callOnError(e)
+			return
+		}
+		payload, ok := e.payload.(pcommon.TraceID)
+		if !ok {
+			// the payload had an unexpected type!
+			em.callOnError(e)
+			return
+		}
+
+		em.handleEventWithObservability("onTraceEvicted", func() error {
+			return em.onTraceEvicted(payload)
+		})
 	default:
 		em.logger.Info("unknown event type", zap.Any("event", e.typ))
 		em.callOnError(e)
@@ -307,6 +347,9 @@ type eventMachineWorker struct {
 	// the ring buffer holds the IDs for all the in-flight traces
 	buffer *ringBuffer
 
+	// the tracker holds the state of the in-flight traces, when they might be released early
+	tracker *traceTracker
+
 	events chan event
 }
 
@@ -79,6 +79,30 @@ func TestEventCallback(t *testing.T) {
 				}
 			},
 		},
+		{
+			casename: "onTraceQuiet",
+			typ:      traceQuiet,
+			payload:  pcommon.TraceID([16]byte{1, 2, 3, 4}),
+			registerCallback: func(em *eventMachine, wg *sync.WaitGroup) {
+				em.onTraceQuiet = func(quiet pcommon.TraceID, _ *eventMachineWorker) error {
+					wg.Done()
+					assert.Equal(t, pcommon.TraceID([16]byte{1, 2, 3, 4}), quiet)
+					return nil
+				}
+			},
+		},
+		{
+			casename: "onTraceEvicted",
+			typ:      traceEvicted,
+			payload:  pcommon.TraceID([16]byte{1, 2, 3, 4}),
+			registerCallback: func(em *eventMachine, wg *sync.WaitGroup) {
+				em.onTraceEvicted = func(evicted pcommon.TraceID) error {
+					wg.Done()
+					assert.Equal(t, pcommon.TraceID([16]byte{1, 2, 3, 4}), evicted)
+					return nil
+				}
+			},
+		},
 	} {
 		t.Run(tt.casename, func(t *testing.T) {
 			// prepare
@@ -128,6 +152,14 @@ func TestEventCallbackNotSet(t *testing.T) {
 			casename: "onTraceRemoved",
 			typ:      traceRemoved,
 		},
+		{
+			casename: "onTraceQuiet",
+			typ:      traceQuiet,
+		},
+		{
+			casename: "onTraceEvicted",
+			typ:      traceEvicted,
+		},
 	} {
 		t.Run(tt.casename, func(t *testing.T) {
 			// prepare
@@ -198,6 +230,24 @@ func TestEventInvalidPayload(t *testing.T) {
 				}
 			},
 		},
+		{
+			casename: "onTraceQuiet",
+			typ:      traceQuiet,
+			registerCallback: func(em *eventMachine, _ *sync.WaitGroup) {
+				em.onTraceQuiet = func(_ pcommon.TraceID, _ *eventMachineWorker) error {
+					return nil
+				}
+			},
+		},
+		{
+			casename: "onTraceEvicted",
+			typ:      traceEvicted,
+			registerCallback: func(em *eventMachine, _ *sync.WaitGroup) {
+				em.onTraceEvicted = func(_ pcommon.TraceID) error {
+					return nil
+				}
+			},
+		},
 	} {
 		t.Run(tt.casename, func(t *testing.T) {
 			// prepare
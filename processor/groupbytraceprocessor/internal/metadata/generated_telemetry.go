@@ -30,10 +30,13 @@ func Tracer(settings component.TelemetrySettings) trace.Tracer {
 type TelemetryBuilder struct {
 	meter                                   metric.Meter
 	ProcessorGroupbytraceConfNumTraces      metric.Int64Gauge
+	ProcessorGroupbytraceEarlyReleases      metric.Int64Counter
 	ProcessorGroupbytraceEventLatency       metric.Int64Histogram
 	ProcessorGroupbytraceIncompleteReleases metric.Int64Counter
 	ProcessorGroupbytraceNumEventsInQueue   metric.Int64Gauge
 	ProcessorGroupbytraceNumTracesInMemory  metric.Int64Gauge
+	ProcessorGroupbytracePartialReleases    metric.Int64Counter
+	ProcessorGroupbytraceSpansEvicted       metric.Int64Counter
 	ProcessorGroupbytraceSpansReleased      metric.Int64Counter
 	ProcessorGroupbytraceTracesEvicted      metric.Int64Counter
 	ProcessorGroupbytraceTracesReleased     metric.Int64Counter
@@ -66,6 +69,12 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...Teleme
 		metric.WithUnit("1"),
 	)
 	errs = errors.Join(errs, err)
+	builder.ProcessorGroupbytraceEarlyReleases, err = builder.meters[configtelemetry.LevelBasic].Int64Counter(
+		"otelcol_processor_groupbytrace_early_releases",
+		metric.WithDescription("Traces released before the wait duration, because they looked complete or did not receive new spans for the quiet period"),
+		metric.WithUnit("1"),
+	)
+	errs = errors.Join(errs, err)
 	builder.ProcessorGroupbytraceEventLatency, err = builder.meters[configtelemetry.LevelBasic].Int64Histogram(
 		"otelcol_processor_groupbytrace_event_latency",
 		metric.WithDescription("How long the queue events are taking to be processed"),
@@ -91,6 +100,18 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...Teleme
 		metric.WithUnit("1"),
 	)
 	errs = errors.Join(errs, err)
+	builder.ProcessorGroupbytracePartialReleases, err = builder.meters[configtelemetry.LevelBasic].Int64Counter(
+		"otelcol_processor_groupbytrace_partial_releases",
+		metric.WithDescription("Partial traces released because the internal storage exceeded its maximum size"),
+		metric.WithUnit("1"),
+	)
+	errs = errors.Join(errs, err)
+	builder.ProcessorGroupbytraceSpansEvicted, err = builder.meters[configtelemetry.LevelBasic].Int64Counter(
+		"otelcol_processor_groupbytrace_spans_evicted",
+		metric.WithDescription("Spans of the traces evicted from the internal buffer"),
+		metric.WithUnit("1"),
+	)
+	errs = errors.Join(errs, err)
 	builder.ProcessorGroupbytraceSpansReleased, err = builder.meters[configtelemetry.LevelBasic].Int64Counter(
 		"otelcol_processor_groupbytrace_spans_released",
 		metric.WithDescription("Spans released to the next consumer"),
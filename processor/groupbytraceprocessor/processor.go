@@ -31,7 +31,9 @@ import (
 // ConsumeTraces -> eventMachine.consume(trace) -> event(traceReceived) -> onTraceReceived -> AfterFunc(duration, event(traceExpired)) -> onTraceExpired
 // async markAsReleased -> event(traceReleased) -> onTraceReleased -> nextConsumer
 // Each worker in the eventMachine also uses a ring buffer to hold the in-flight trace IDs, so that we don't hold more than the given maximum number
-// of traces in memory/storage. Items that are evicted from the buffer are discarded without warning.
+// of traces in memory/storage. Items that are evicted from the buffer are released right away, flagged as partial.
+// When enabled, each worker also tracks the state of its in-flight traces, releasing traces that look complete, that
+// didn't receive spans for a quiet period, or, flagged as partial, the oldest traces once the storage exceeds its maximum size.
 type groupByTraceProcessor struct {
 	nextConsumer     consumer.Traces
 	config           Config
@@ -46,8 +48,14 @@ type groupByTraceProcessor struct {
 
 var _ processor.Traces = (*groupByTraceProcessor)(nil)
 
+var sizer = &ptrace.ProtoMarshaler{}
+
 const bufferSize = 10_000
 
+// partialAttributeKey is set on the spans of traces released before the wait duration because the
+// storage exceeded its maximum size or its maximum number of traces.
+const partialAttributeKey = "groupbytrace.partial"
+
 // newGroupByTraceProcessor returns a new processor.
 func newGroupByTraceProcessor(set processor.Settings, nextConsumer consumer.Traces, config Config) *groupByTraceProcessor {
 	telemetryBuilder, err := metadata.NewTelemetryBuilder(set.TelemetrySettings)
@@ -71,6 +79,16 @@ func newGroupByTraceProcessor(set processor.Settings, nextConsumer consumer.Trac
 	eventMachine.onTraceExpired = sp.onTraceExpired
 	eventMachine.onTraceReleased = sp.onTraceReleased
 	eventMachine.onTraceRemoved = sp.onTraceRemoved
+	eventMachine.onTraceQuiet = sp.onTraceQuiet
+	eventMachine.onTraceEvicted = sp.onTraceEvicted
+
+	if config.MaxBytes > 0 || config.Completeness.Enabled || config.Completeness.QuietPeriod > 0 {
+		// round up, so that the workers share at least the configured maximum size
+		maxBytesPerWorker := (config.MaxBytes + config.NumWorkers - 1) / config.NumWorkers
+		for _, worker := range eventMachine.workers {
+			worker.tracker = newTraceTracker(maxBytesPerWorker)
+		}
+	}
 
 	return sp
 }
@@ -113,6 +131,10 @@ func (sp *groupByTraceProcessor) onTraceReceived(trace tracesWithID, worker *eve
 			return fmt.Errorf("couldn't add spans to existing trace: %w", err)
 		}
 
+		if worker.tracker != nil {
+			sp.track(trace, worker, nil)
+		}
+
 		// we are done with this trace, move on
 		return nil
 	}
@@ -123,15 +145,15 @@ func (sp *groupByTraceProcessor) onTraceReceived(trace tracesWithID, worker *eve
 	// place the trace ID in the buffer, and check if an item had to be evicted
 	evicted := worker.buffer.put(traceID)
 	if !evicted.IsEmpty() {
-		// delete from the storage
+		if worker.tracker != nil {
+			worker.tracker.remove(evicted)
+		}
+
+		// release the evicted trace as partial and delete it from the storage
 		worker.fire(event{
-			typ:     traceRemoved,
+			typ:     traceEvicted,
 			payload: evicted,
 		})
-		sp.telemetryBuilder.ProcessorGroupbytraceTracesEvicted.Add(context.Background(), 1)
-
-		sp.logger.Info("trace evicted: in order to avoid this in the future, adjust the wait duration and/or number of traces to keep in memory",
-			zap.Stringer("traceID", evicted))
 	}
 
 	// we have the traceID in the memory, place the spans in the storage too
@@ -141,13 +163,88 @@ func (sp *groupByTraceProcessor) onTraceReceived(trace tracesWithID, worker *eve
 
 	sp.logger.Debug("scheduled to release trace", zap.Duration("duration", sp.config.WaitDuration))
 
-	time.AfterFunc(sp.config.WaitDuration, func() {
+	expireTimer := time.AfterFunc(sp.config.WaitDuration, func() {
 		// if the event machine has stopped, it will just discard the event
 		worker.fire(event{
 			typ:     traceExpired,
 			payload: traceID,
 		})
 	})
+
+	if worker.tracker != nil {
+		sp.track(trace, worker, expireTimer)
+	}
+	return nil
+}
+
+// track records the received spans in the worker's tracker, releasing the trace if it looks complete,
+// and the oldest traces if the storage exceeds its maximum size.
+func (sp *groupByTraceProcessor) track(trace tracesWithID, worker *eventMachineWorker, expireTimer *time.Timer) {
+	traceID := trace.id
+	var size int
+	if sp.config.MaxBytes > 0 {
+		size = sizer.TracesSize(trace.td)
+	}
+	state := worker.tracker.add(traceID, trace.td, size, time.Now())
+	if expireTimer != nil {
+		state.expireTimer = expireTimer
+	}
+
+	switch {
+	case sp.config.Completeness.Enabled && state.complete():
+		sp.logger.Debug("trace looks complete, releasing it", zap.Stringer("traceID", traceID))
+		sp.telemetryBuilder.ProcessorGroupbytraceEarlyReleases.Add(context.Background(), 1)
+		sp.releaseEarly(traceID, worker, false)
+	case sp.config.Completeness.QuietPeriod > 0:
+		if state.quietTimer == nil {
+			state.quietTimer = time.AfterFunc(sp.config.Completeness.QuietPeriod, func() {
+				worker.fire(event{
+					typ:     traceQuiet,
+					payload: traceID,
+				})
+			})
+		} else {
+			state.quietTimer.Reset(sp.config.Completeness.QuietPeriod)
+		}
+	}
+
+	for worker.tracker.overLimit() {
+		oldest, _ := worker.tracker.oldest()
+		sp.logger.Info("trace released partially: in order to avoid this in the future, adjust the wait duration and/or the maximum size of the storage",
+			zap.Stringer("traceID", oldest))
+		sp.telemetryBuilder.ProcessorGroupbytracePartialReleases.Add(context.Background(), 1)
+		sp.releaseEarly(oldest, worker, true)
+	}
+}
+
+// releaseEarly releases the trace before its wait duration expired.
+func (sp *groupByTraceProcessor) releaseEarly(traceID pcommon.TraceID, worker *eventMachineWorker, partial bool) {
+	worker.buffer.delete(traceID)
+	worker.tracker.remove(traceID)
+
+	// this might block, but we don't need to wait
+	go func() {
+		_ = sp.markAsReleased(traceID, worker.fire, partial)
+	}()
+}
+
+func (sp *groupByTraceProcessor) onTraceQuiet(traceID pcommon.TraceID, worker *eventMachineWorker) error {
+	if worker.tracker == nil {
+		return nil
+	}
+	state, ok := worker.tracker.get(traceID)
+	if !ok {
+		// the trace has been released already
+		return nil
+	}
+	if time.Since(state.lastReceived) < sp.config.Completeness.QuietPeriod {
+		// spans were received in the meantime, the timer has been reset
+		return nil
+	}
+
+	sp.logger.Debug("no new spans received for the trace, releasing it", zap.Stringer("traceID", traceID))
+	sp.telemetryBuilder.ProcessorGroupbytraceEarlyReleases.Add(context.Background(), 1)
+	sp.releaseEarly(traceID, worker, false)
 	return nil
 }
 
@@ -164,17 +261,20 @@ func (sp *groupByTraceProcessor) onTraceExpired(traceID pcommon.TraceID, worker
 
 	// delete from the map and erase its memory entry
 	worker.buffer.delete(traceID)
+	if worker.tracker != nil {
+		worker.tracker.remove(traceID)
+	}
 
 	// this might block, but we don't need to wait
 	sp.logger.Debug("marking the trace as released", zap.Stringer("traceID", traceID))
 	go func() {
-		_ = sp.markAsReleased(traceID, worker.fire)
+		_ = sp.markAsReleased(traceID, worker.fire, false)
 	}()
 
 	return nil
 }
 
-func (sp *groupByTraceProcessor) markAsReleased(traceID pcommon.TraceID, fire func(...event)) error {
+func (sp *groupByTraceProcessor) markAsReleased(traceID pcommon.TraceID, fire func(...event), partial bool) error {
 	// #get is a potentially blocking operation
 	trace, err := sp.st.get(traceID)
 	if err != nil {
@@ -185,6 +285,10 @@ func (sp *groupByTraceProcessor) markAsReleased(traceID pcommon.TraceID, fire fu
 		return fmt.Errorf("the trace %q couldn't be found at the storage", traceID)
 	}
 
+	if partial {
+		markAsPartial(trace)
+	}
+
 	// signal that the trace is ready to be released
 	sp.logger.Debug("trace marked as released", zap.Stringer("traceID", traceID))
 
@@ -200,6 +304,19 @@ func (sp *groupByTraceProcessor) markAsReleased(traceID pcommon.TraceID, fire fu
 	return nil
 }
 
+// markAsPartial flags the spans of a trace released before all of its spans might have been received.
+func markAsPartial(rss []ptrace.ResourceSpans) {
+	for _, rs := range rss {
+		ilss := rs.ScopeSpans()
+		for i := 0; i < ilss.Len(); i++ {
+			spans := ilss.At(i).Spans()
+			for j := 0; j < spans.Len(); j++ {
+				spans.At(j).Attributes().PutBool(partialAttributeKey, true)
+			}
+		}
+	}
+}
+
 func (sp *groupByTraceProcessor) onTraceReleased(rss []ptrace.ResourceSpans) error {
 	trace := ptrace.NewTraces()
 	for _, rs := range rss {
@@ -232,6 +349,34 @@ func (sp *groupByTraceProcessor) onTraceRemoved(traceID pcommon.TraceID) error {
 	return nil
 }
 
+// onTraceEvicted removes a trace evicted from the ring buffer from the storage and releases it, flagged as partial.
+func (sp *groupByTraceProcessor) onTraceEvicted(traceID pcommon.TraceID) error {
+	trace, err := sp.st.delete(traceID)
+	if err != nil {
+		return fmt.Errorf("couldn't delete trace %q from the storage: %w", traceID, err)
+	}
+
+	if trace == nil {
+		return fmt.Errorf("trace %q not found at the storage", traceID)
+	}
+
+	spans := 0
+	for _, rs := range trace {
+		ilss := rs.ScopeSpans()
+		for i := 0; i < ilss.Len(); i++ {
+			spans += ilss.At(i).Spans().Len()
+		}
+	}
+	sp.telemetryBuilder.ProcessorGroupbytraceTracesEvicted.Add(context.Background(), 1)
+	sp.telemetryBuilder.ProcessorGroupbytraceSpansEvicted.Add(context.Background(), int64(spans))
+
+	sp.logger.Warn("trace evicted and released partially: in order to avoid this in the future, adjust the wait duration and/or number of traces to keep in memory",
+		zap.Stringer("traceID", traceID), zap.Int("spans", spans))
+
+	markAsPartial(trace)
+	return sp.onTraceReleased(trace)
+}
+
 func (sp *groupByTraceProcessor) addSpans(traceID pcommon.TraceID, trace ptrace.Traces) error {
 	sp.logger.Debug("creating trace at the storage", zap.Stringer("traceID", traceID))
 	return sp.st.createOrAppend(traceID, trace)
@@ -20,6 +20,7 @@ import (
 	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/processor/processortest"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/batchpersignal"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbytraceprocessor/internal/metadata"
@@ -81,19 +82,22 @@ func TestInternalCacheLimit(t *testing.T) {
 		// badly influence the testing experience
 		WaitDuration: 50 * time.Millisecond,
 
-		// we create 6 traces, only 5 should be at the storage in the end
+		// we create 6 traces, only 5 fit at the storage, so the first one is evicted
 		NumTraces: 5,
 
 		NumWorkers: 1,
 	}
 
-	wg.Add(5) // 5 traces are expected to be received
+	wg.Add(6) // all traces are expected to be received
 
-	var receivedTraceIDs []pcommon.TraceID
+	var receivedTraceIDs, partialTraceIDs []pcommon.TraceID
 	mockProcessor := &mockProcessor{}
 	mockProcessor.onTraces = func(_ context.Context, received ptrace.Traces) error {
-		traceID := received.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).TraceID()
-		receivedTraceIDs = append(receivedTraceIDs, traceID)
+		span := received.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+		receivedTraceIDs = append(receivedTraceIDs, span.TraceID())
+		if _, ok := span.Attributes().Get(partialAttributeKey); ok {
+			partialTraceIDs = append(partialTraceIDs, span.TraceID())
+		}
 		wg.Done()
 		return nil
 	}
@@ -126,15 +130,142 @@ func TestInternalCacheLimit(t *testing.T) {
 	wg.Wait()
 
 	// verify
-	assert.Len(t, receivedTraceIDs, 5)
+	assert.Len(t, receivedTraceIDs, 6)
 
-	for i := 5; i > 0; i-- { // last 5 traces
-		traceID := pcommon.TraceID(traceIDs[i])
-		assert.Contains(t, receivedTraceIDs, traceID)
+	for _, traceID := range traceIDs {
+		assert.Contains(t, receivedTraceIDs, pcommon.TraceID(traceID))
+	}
+
+	// the first trace should have been evicted and released as partial
+	assert.Equal(t, []pcommon.TraceID{traceIDs[0]}, partialTraceIDs)
+}
+
+func TestCompleteTraceIsReleasedEarly(t *testing.T) {
+	// prepare
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4})
+	received := make(chan ptrace.Traces, 1)
+	config := Config{
+		// long enough for the test to fail if the trace isn't released early
+		WaitDuration: time.Hour,
+		NumTraces:    10,
+		NumWorkers:   1,
+		Completeness: CompletenessConfig{Enabled: true},
+	}
+	next := &mockProcessor{onTraces: func(_ context.Context, td ptrace.Traces) error {
+		received <- td
+		return nil
+	}}
+
+	p := newGroupByTraceProcessor(processortest.NewNopSettings(), next, config)
+	p.st = newMemoryStorage(p.telemetryBuilder)
+	ctx := context.Background()
+	assert.NoError(t, p.Start(ctx, nil))
+	defer func() {
+		assert.NoError(t, p.Shutdown(ctx))
+	}()
+
+	// test
+	// the child span arrives first, the trace is missing its parent
+	assert.NoError(t, p.ConsumeTraces(ctx, tracesWithSpan(traceID, pcommon.SpanID([8]byte{2}), pcommon.SpanID([8]byte{1}))))
+	select {
+	case <-received:
+		t.Fatal("the trace was released before being complete")
+	case <-time.After(50 * time.Millisecond):
+	}
+	assert.NoError(t, p.ConsumeTraces(ctx, tracesWithSpan(traceID, pcommon.SpanID([8]byte{1}), pcommon.NewSpanIDEmpty())))
+
+	// verify
+	select {
+	case td := <-received:
+		assert.Equal(t, 2, td.SpanCount())
+	case <-time.After(5 * time.Second):
+		t.Fatal("the complete trace wasn't released")
+	}
+}
+
+func TestQuietTraceIsReleasedEarly(t *testing.T) {
+	// prepare
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4})
+	received := make(chan ptrace.Traces, 1)
+	config := Config{
+		WaitDuration: time.Hour,
+		NumTraces:    10,
+		NumWorkers:   1,
+		Completeness: CompletenessConfig{QuietPeriod: 10 * time.Millisecond},
 	}
+	next := &mockProcessor{onTraces: func(_ context.Context, td ptrace.Traces) error {
+		received <- td
+		return nil
+	}}
+
+	p := newGroupByTraceProcessor(processortest.NewNopSettings(), next, config)
+	p.st = newMemoryStorage(p.telemetryBuilder)
+	ctx := context.Background()
+	assert.NoError(t, p.Start(ctx, nil))
+	defer func() {
+		assert.NoError(t, p.Shutdown(ctx))
+	}()
 
-	// the first trace should have been evicted
-	assert.NotContains(t, receivedTraceIDs, traceIDs[0])
+	// test
+	assert.NoError(t, p.ConsumeTraces(ctx, tracesWithSpan(traceID, pcommon.SpanID([8]byte{2}), pcommon.SpanID([8]byte{1}))))
+
+	// verify
+	select {
+	case td := <-received:
+		assert.Equal(t, 1, td.SpanCount())
+		_, partial := td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes().Get(partialAttributeKey)
+		assert.False(t, partial)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the trace wasn't released after the quiet period")
+	}
+}
+
+func TestStorageSizeLimitReleasesPartialTraces(t *testing.T) {
+	// prepare
+	traceIDs := []pcommon.TraceID{{1, 2, 3, 4}, {2, 3, 4, 5}, {3, 4, 5, 6}}
+	size := sizer.TracesSize(tracesWithSpan(traceIDs[0], pcommon.SpanID([8]byte{2}), pcommon.SpanID([8]byte{1})))
+	received := make(chan ptrace.Traces, len(traceIDs))
+	config := Config{
+		WaitDuration: time.Hour,
+		NumTraces:    10,
+		NumWorkers:   1,
+		// room for two traces
+		MaxBytes: 2 * size,
+	}
+	next := &mockProcessor{onTraces: func(_ context.Context, td ptrace.Traces) error {
+		received <- td
+		return nil
+	}}
+
+	p := newGroupByTraceProcessor(processortest.NewNopSettings(), next, config)
+	p.st = newMemoryStorage(p.telemetryBuilder)
+	ctx := context.Background()
+	assert.NoError(t, p.Start(ctx, nil))
+	defer func() {
+		assert.NoError(t, p.Shutdown(ctx))
+	}()
+
+	// test
+	for _, traceID := range traceIDs {
+		assert.NoError(t, p.ConsumeTraces(ctx, tracesWithSpan(traceID, pcommon.SpanID([8]byte{2}), pcommon.SpanID([8]byte{1}))))
+	}
+
+	// verify
+	select {
+	case td := <-received:
+		span := td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+		assert.Equal(t, traceIDs[0], span.TraceID())
+		partial, ok := span.Attributes().Get(partialAttributeKey)
+		require.True(t, ok)
+		assert.True(t, partial.Bool())
+	case <-time.After(5 * time.Second):
+		t.Fatal("the oldest trace wasn't released")
+	}
+	select {
+	case td := <-received:
+		t.Fatalf("unexpected trace released: %v", td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).TraceID())
+	case <-time.After(50 * time.Millisecond):
+	}
 }
 
 func TestProcessorCapabilities(t *testing.T) {
@@ -212,7 +343,7 @@ func TestTraceDisappearedFromStorageBeforeReleasing(t *testing.T) {
 
 	// test
 	// we trigger this manually, instead of waiting the whole duration
-	err = p.markAsReleased(traceID, p.eventMachine.workers[workerIndexForTraceID(traceID, config.NumWorkers)].fire)
+	err = p.markAsReleased(traceID, p.eventMachine.workers[workerIndexForTraceID(traceID, config.NumWorkers)].fire, false)
 
 	// verify
 	assert.Error(t, err)
@@ -250,7 +381,7 @@ func TestTraceErrorFromStorageWhileReleasing(t *testing.T) {
 
 	// test
 	// we trigger this manually, instead of waiting the whole duration
-	err = p.markAsReleased(traceID, p.eventMachine.workers[workerIndexForTraceID(traceID, config.NumWorkers)].fire)
+	err = p.markAsReleased(traceID, p.eventMachine.workers[workerIndexForTraceID(traceID, config.NumWorkers)].fire, false)
 
 	// verify
 	assert.ErrorIs(t, err, expectedError)
@@ -441,6 +572,50 @@ func TestTraceNotFoundWhileRemovingTrace(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestEvictedTraceIsReleasedAsPartial(t *testing.T) {
+	// prepare
+	config := Config{
+		WaitDuration: time.Second, // we are not waiting for this whole time
+		NumTraces:    8,
+		NumWorkers:   4,
+	}
+	core, logs := observer.New(zap.WarnLevel)
+	set := processortest.NewNopSettings()
+	set.Logger = zap.New(core)
+	received := make(chan ptrace.Traces, 1)
+	next := &mockProcessor{onTraces: func(_ context.Context, td ptrace.Traces) error {
+		received <- td
+		return nil
+	}}
+
+	p := newGroupByTraceProcessor(set, next, config)
+	require.NotNil(t, p)
+	p.st = newMemoryStorage(p.telemetryBuilder)
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4})
+	require.NoError(t, p.st.createOrAppend(traceID, tracesWithSpan(traceID, pcommon.SpanID([8]byte{1}), pcommon.NewSpanIDEmpty())))
+
+	// test
+	err := p.onTraceEvicted(traceID)
+
+	// verify
+	assert.NoError(t, err)
+	trace, err := p.st.get(traceID)
+	assert.NoError(t, err)
+	assert.Nil(t, trace)
+	require.Equal(t, 1, logs.Len())
+	assert.Equal(t, int64(1), logs.All()[0].ContextMap()["spans"])
+
+	select {
+	case td := <-received:
+		require.Equal(t, 1, td.SpanCount())
+		partial, ok := td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes().Get(partialAttributeKey)
+		require.True(t, ok)
+		assert.True(t, partial.Bool())
+	case <-time.After(5 * time.Second):
+		t.Fatal("the evicted trace wasn't released")
+	}
+}
+
 func TestTracesAreDispatchedInIndividualBatches(t *testing.T) {
 	// prepare
 	wg := &sync.WaitGroup{}
@@ -664,6 +839,17 @@ func (b *blockingConsumer) ConsumeTraces(context.Context, ptrace.Traces) error {
 	return nil
 }
 
+func tracesWithSpan(traceID pcommon.TraceID, spanID pcommon.SpanID, parentSpanID pcommon.SpanID) ptrace.Traces {
+	traces := ptrace.NewTraces()
+	span := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetTraceID(traceID)
+	span.SetSpanID(spanID)
+	span.SetParentSpanID(parentSpanID)
+	span.SetStartTimestamp(pcommon.Timestamp(1))
+	span.SetEndTimestamp(pcommon.Timestamp(2))
+	return traces
+}
+
 func simpleTraces() ptrace.Traces {
 	return simpleTracesWithID(pcommon.TraceID([16]byte{1, 2, 3, 4}))
 }
@@ -25,6 +25,13 @@ func newRingBuffer(size int) *ringBuffer {
 func (r *ringBuffer) put(traceID pcommon.TraceID) pcommon.TraceID {
 	// calculates the item in the ring that we'll store the trace
 	r.index = (r.index + 1) % r.size
+	// traces released before their wait duration leave gaps in the ring, which are filled before
+	// evicting any in-flight trace
+	if len(r.idToIndex) < r.size {
+		for !r.ids[r.index].IsEmpty() {
+			r.index = (r.index + 1) % r.size
+		}
+	}
 
 	// see if the ring has an item already
 	evicted := r.ids[r.index]
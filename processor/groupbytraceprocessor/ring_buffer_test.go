@@ -37,6 +37,31 @@ func TestRingBufferCapacity(t *testing.T) {
 	assert.False(t, buffer.contains(traceIDs[0]))
 }
 
+func TestRingBufferFillsGapsBeforeEvicting(t *testing.T) {
+	// prepare
+	buffer := newRingBuffer(3)
+	traceIDs := []pcommon.TraceID{
+		pcommon.TraceID([16]byte{1, 2, 3, 4}),
+		pcommon.TraceID([16]byte{2, 3, 4, 5}),
+		pcommon.TraceID([16]byte{3, 4, 5, 6}),
+		pcommon.TraceID([16]byte{4, 5, 6, 7}),
+	}
+	for _, traceID := range traceIDs[:3] {
+		buffer.put(traceID)
+	}
+	// the second trace is released before the others
+	buffer.delete(traceIDs[1])
+
+	// test
+	evicted := buffer.put(traceIDs[3])
+
+	// verify
+	assert.True(t, evicted.IsEmpty())
+	for _, traceID := range []pcommon.TraceID{traceIDs[0], traceIDs[2], traceIDs[3]} {
+		assert.True(t, buffer.contains(traceID))
+	}
+}
+
 func TestDeleteFromBuffer(t *testing.T) {
 	// prepare
 	buffer := newRingBuffer(2)
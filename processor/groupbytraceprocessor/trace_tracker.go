@@ -0,0 +1,131 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package groupbytraceprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbytraceprocessor"
+
+import (
+	"container/list"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// traceTracker keeps the state of the in-flight traces of a worker, so that traces can be released
+// before the wait duration when they look complete or when the storage exceeds its maximum size.
+// Like the ring buffer, it's only accessed from the worker's goroutine.
+type traceTracker struct {
+	traces map[pcommon.TraceID]*traceState
+	// order holds the trace IDs, from the oldest to the newest
+	order    *list.List
+	bytes    int
+	maxBytes int
+}
+
+type traceState struct {
+	element *list.Element
+	size    int
+
+	spanIDs map[pcommon.SpanID]struct{}
+	// missingParents holds the parent span IDs referenced by spans of the trace that weren't received yet
+	missingParents map[pcommon.SpanID]struct{}
+	rootEnded      bool
+	lastReceived   time.Time
+
+	expireTimer *time.Timer
+	quietTimer  *time.Timer
+}
+
+func newTraceTracker(maxBytes int) *traceTracker {
+	return &traceTracker{
+		traces:   make(map[pcommon.TraceID]*traceState),
+		order:    list.New(),
+		maxBytes: maxBytes,
+	}
+}
+
+// add records the spans received for the given trace, returning the up-to-date state of the trace.
+func (t *traceTracker) add(traceID pcommon.TraceID, td ptrace.Traces, size int, now time.Time) *traceState {
+	state, ok := t.traces[traceID]
+	if !ok {
+		state = &traceState{
+			element:        t.order.PushBack(traceID),
+			spanIDs:        make(map[pcommon.SpanID]struct{}),
+			missingParents: make(map[pcommon.SpanID]struct{}),
+		}
+		t.traces[traceID] = state
+	}
+	state.size += size
+	state.lastReceived = now
+	t.bytes += size
+
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		ilss := rss.At(i).ScopeSpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				state.addSpan(spans.At(k))
+			}
+		}
+	}
+	return state
+}
+
+func (t *traceTracker) get(traceID pcommon.TraceID) (*traceState, bool) {
+	state, ok := t.traces[traceID]
+	return state, ok
+}
+
+// remove forgets the given trace, stopping its pending timers.
+func (t *traceTracker) remove(traceID pcommon.TraceID) {
+	state, ok := t.traces[traceID]
+	if !ok {
+		return
+	}
+	if state.expireTimer != nil {
+		state.expireTimer.Stop()
+	}
+	if state.quietTimer != nil {
+		state.quietTimer.Stop()
+	}
+	t.order.Remove(state.element)
+	t.bytes -= state.size
+	delete(t.traces, traceID)
+}
+
+// oldest returns the ID of the trace that has been tracked for the longest time.
+func (t *traceTracker) oldest() (pcommon.TraceID, bool) {
+	front := t.order.Front()
+	if front == nil {
+		return pcommon.NewTraceIDEmpty(), false
+	}
+	return front.Value.(pcommon.TraceID), true
+}
+
+// overLimit returns whether the tracked traces exceed the maximum size.
+func (t *traceTracker) overLimit() bool {
+	return t.maxBytes > 0 && t.bytes > t.maxBytes && t.order.Len() > 0
+}
+
+func (s *traceState) addSpan(span ptrace.Span) {
+	s.spanIDs[span.SpanID()] = struct{}{}
+	delete(s.missingParents, span.SpanID())
+
+	parentID := span.ParentSpanID()
+	if parentID.IsEmpty() {
+		if span.EndTimestamp() != 0 {
+			s.rootEnded = true
+		}
+		return
+	}
+	if _, ok := s.spanIDs[parentID]; !ok {
+		s.missingParents[parentID] = struct{}{}
+	}
+}
+
+// complete returns whether the trace looks complete: its root span has ended and the parent
+// of every span is part of the trace.
+func (s *traceState) complete() bool {
+	return s.rootEnded && len(s.missingParents) == 0
+}
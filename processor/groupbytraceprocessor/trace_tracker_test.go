@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package groupbytraceprocessor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestTraceTrackerCompleteness(t *testing.T) {
+	// prepare
+	tracker := newTraceTracker(0)
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4})
+
+	// test and verify
+	state := tracker.add(traceID, tracesWithSpan(traceID, pcommon.SpanID([8]byte{3}), pcommon.SpanID([8]byte{2})), 0, time.Now())
+	assert.False(t, state.complete())
+
+	state = tracker.add(traceID, tracesWithSpan(traceID, pcommon.SpanID([8]byte{1}), pcommon.NewSpanIDEmpty()), 0, time.Now())
+	assert.False(t, state.complete(), "the parent of the first span is missing")
+
+	state = tracker.add(traceID, tracesWithSpan(traceID, pcommon.SpanID([8]byte{2}), pcommon.SpanID([8]byte{1})), 0, time.Now())
+	assert.True(t, state.complete())
+}
+
+func TestTraceTrackerRootNotEnded(t *testing.T) {
+	// prepare
+	tracker := newTraceTracker(0)
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4})
+	td := tracesWithSpan(traceID, pcommon.SpanID([8]byte{1}), pcommon.NewSpanIDEmpty())
+	td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).SetEndTimestamp(0)
+
+	// test
+	state := tracker.add(traceID, td, 0, time.Now())
+
+	// verify
+	assert.False(t, state.complete())
+}
+
+func TestTraceTrackerSizeLimit(t *testing.T) {
+	// prepare
+	tracker := newTraceTracker(10)
+	first := pcommon.TraceID([16]byte{1})
+	second := pcommon.TraceID([16]byte{2})
+
+	// test and verify
+	tracker.add(first, ptrace.NewTraces(), 6, time.Now())
+	assert.False(t, tracker.overLimit())
+
+	tracker.add(second, ptrace.NewTraces(), 6, time.Now())
+	assert.True(t, tracker.overLimit())
+
+	oldest, ok := tracker.oldest()
+	assert.True(t, ok)
+	assert.Equal(t, first, oldest)
+
+	tracker.remove(first)
+	assert.False(t, tracker.overLimit())
+	oldest, ok = tracker.oldest()
+	assert.True(t, ok)
+	assert.Equal(t, second, oldest)
+
+	tracker.remove(second)
+	_, ok = tracker.oldest()
+	assert.False(t, ok)
+	assert.Zero(t, tracker.bytes)
+}
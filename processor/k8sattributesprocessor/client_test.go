@@ -26,6 +26,8 @@ type fakeClient struct {
 	NodeInformer       cache.SharedInformer
 	Namespaces         map[string]*kube.Namespace
 	Nodes              map[string]*kube.Node
+	Services           map[string]*kube.Service
+	ServiceIPs         map[string]*kube.Service
 	StopCh             chan struct{}
 }
 
@@ -41,6 +43,8 @@ func newFakeClient(_ component.TelemetrySettings, _ k8sconfig.APIConfig, rules k
 	ls, fs := selectors()
 	return &fakeClient{
 		Pods:               map[kube.PodIdentifier]*kube.Pod{},
+		Services:           map[string]*kube.Service{},
+		ServiceIPs:         map[string]*kube.Service{},
 		Rules:              rules,
 		Filters:            filters,
 		Associations:       associations,
@@ -69,6 +73,17 @@ func (f *fakeClient) GetNode(nodeName string) (*kube.Node, bool) {
 	return node, ok
 }
 
+// GetService looks up FakeClient.Services map by "namespace/name".
+func (f *fakeClient) GetService(namespace string, name string) (*kube.Service, bool) {
+	svc, ok := f.Services[namespace+"/"+name]
+	return svc, ok
+}
+
+func (f *fakeClient) GetServiceByIP(ip string) (*kube.Service, bool) {
+	svc, ok := f.ServiceIPs[ip]
+	return svc, ok
+}
+
 // Start is a noop for FakeClient.
 func (f *fakeClient) Start() {
 	if f.Informer != nil {
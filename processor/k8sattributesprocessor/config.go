@@ -46,6 +46,10 @@ type Config struct {
 	// Exclude section allows to define names of pod that should be
 	// ignored while tagging.
 	Exclude ExcludeConfig `mapstructure:"exclude"`
+
+	// Peer section allows tagging client spans with the metadata of the
+	// pod or service they call.
+	Peer PeerConfig `mapstructure:"peer"`
 }
 
 func (cfg *Config) Validate() error {
@@ -66,8 +70,12 @@ func (cfg *Config) Validate() error {
 
 		switch f.From {
 		case "", kube.MetadataFromPod, kube.MetadataFromNamespace, kube.MetadataFromNode:
+		case kube.MetadataFromService:
+			if !cfg.Peer.Enabled {
+				return fmt.Errorf("extracting labels and annotations from service requires peer::enabled")
+			}
 		default:
-			return fmt.Errorf("%s is not a valid choice for From. Must be one of: pod, namespace, node", f.From)
+			return fmt.Errorf("%s is not a valid choice for From. Must be one of: pod, namespace, node, service", f.From)
 		}
 
 		if f.Regex != "" {
@@ -103,6 +111,7 @@ func (cfg *Config) Validate() error {
 			conventions.AttributeK8SJobName, conventions.AttributeK8SJobUID,
 			conventions.AttributeK8SCronJobName,
 			conventions.AttributeK8SNodeName, conventions.AttributeK8SNodeUID,
+			conventions.AttributeHostType, conventions.AttributeCloudAvailabilityZone, conventions.AttributeCloudRegion,
 			conventions.AttributeK8SContainerName, conventions.AttributeContainerID,
 			conventions.AttributeContainerImageName, conventions.AttributeContainerImageTag,
 			containerImageRepoDigests, clusterUID:
@@ -145,7 +154,8 @@ type ExtractConfig struct {
 	//   k8s.statefulset.name, k8s.statefulset.uid,
 	//   k8s.container.name, container.id, container.image.name,
 	//   container.image.tag, container.image.repo_digests
-	//   k8s.cluster.uid
+	//   k8s.cluster.uid, k8s.node.uid,
+	//   host.type, cloud.availability_zone, cloud.region
 	//
 	// Specifying anything other than these values will result in an error.
 	// By default, the following fields are extracted and added to spans, metrics and logs as resource attributes:
@@ -229,7 +239,8 @@ type FieldExtractConfig struct {
 	Regex string `mapstructure:"regex"`
 
 	// From represents the source of the labels/annotations.
-	// Allowed values are "pod", "namespace", and "node". The default is pod.
+	// Allowed values are "pod", "namespace", "node" and "service". The default is pod.
+	// Service labels/annotations are only added to peers, and require peer::enabled.
 	From string `mapstructure:"from"`
 }
 
@@ -327,3 +338,17 @@ type PodAssociationSourceConfig struct {
 	// e.g. ip, pod_uid, k8s.pod.ip
 	Name string `mapstructure:"name"`
 }
+
+// PeerConfig allows tagging client spans with the metadata of their peer:
+// the pod or service they call. The peer is identified by the first of the
+// AddressAttributes of the span, which can hold an IP address or the DNS
+// name of a service.
+type PeerConfig struct {
+	// Enabled enables the tagging of client spans. It requires watching services.
+	Enabled bool `mapstructure:"enabled"`
+
+	// AddressAttributes are the span attributes holding the address of the peer,
+	// in order of preference. The default is server.address, net.peer.name,
+	// network.peer.address, net.sock.peer.addr and net.peer.ip.
+	AddressAttributes []string `mapstructure:"address_attributes"`
+}
@@ -151,9 +151,36 @@ func TestLoadConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "peer"),
+			expected: &Config{
+				APIConfig:   k8sconfig.APIConfig{AuthType: k8sconfig.AuthTypeKubeConfig},
+				Passthrough: false,
+				Extract: ExtractConfig{
+					Metadata: []string{"k8s.pod.name", "k8s.node.name", "host.type", "cloud.availability_zone", "cloud.region"},
+					Labels: []FieldExtractConfig{
+						{TagName: "team", Key: "team", From: kube.MetadataFromService},
+						{KeyRegex: "topology.*", From: kube.MetadataFromNode},
+					},
+				},
+				Exclude: ExcludeConfig{
+					Pods: []ExcludePodConfig{
+						{Name: "jaeger-agent"},
+						{Name: "jaeger-collector"},
+					},
+				},
+				Peer: PeerConfig{
+					Enabled:           true,
+					AddressAttributes: []string{"server.address", "network.peer.address"},
+				},
+			},
+		},
 		{
 			id: component.NewIDWithName(metadata.Type, "too_many_sources"),
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "service_labels_without_peer"),
+		},
 		{
 			id:            component.NewIDWithName(metadata.Type, "deprecated-regex"),
 			disallowRegex: true,
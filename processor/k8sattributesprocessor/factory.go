@@ -165,5 +165,7 @@ func createProcessorOpts(cfg component.Config) []option {
 
 	opts = append(opts, withExcludes(oCfg.Exclude))
 
+	opts = append(opts, withPeer(oCfg.Peer))
+
 	return opts
 }
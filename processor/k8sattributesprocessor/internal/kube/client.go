@@ -47,6 +47,7 @@ type WatchClient struct {
 	namespaceInformer  cache.SharedInformer
 	nodeInformer       cache.SharedInformer
 	replicasetInformer cache.SharedInformer
+	serviceInformer    cache.SharedInformer
 	replicasetRegex    *regexp.Regexp
 	cronJobRegex       *regexp.Regexp
 	deleteQueue        []deleteRequest
@@ -72,6 +73,13 @@ type WatchClient struct {
 	// Key is replicaset uid
 	ReplicaSets map[string]*ReplicaSet
 
+	// A map containing Service related data, used to identify the peers of spans.
+	// Key is service namespace and name, separated by a slash
+	Services map[string]*Service
+
+	// A map containing the Services by their cluster IPs
+	ServiceIPs map[string]*Service
+
 	telemetryBuilder *metadata.TelemetryBuilder
 }
 
@@ -106,6 +114,8 @@ func New(set component.TelemetrySettings, apiCfg k8sconfig.APIConfig, rules Extr
 	c.Namespaces = map[string]*Namespace{}
 	c.Nodes = map[string]*Node{}
 	c.ReplicaSets = map[string]*ReplicaSet{}
+	c.Services = map[string]*Service{}
+	c.ServiceIPs = map[string]*Service{}
 	if newClientSet == nil {
 		newClientSet = k8sconfig.MakeClient
 	}
@@ -181,7 +191,24 @@ func New(set component.TelemetrySettings, apiCfg k8sconfig.APIConfig, rules Extr
 		}
 	}
 
-	if c.extractNodeLabelsAnnotations() || c.extractNodeUID() {
+	if rules.Services {
+		c.serviceInformer = newServiceSharedInformer(c.kc, c.Filters.Namespace)
+		err = c.serviceInformer.SetTransform(
+			func(object any) (any, error) {
+				originalService, success := object.(*api_v1.Service)
+				if !success { // means this is a cache.DeletedFinalStateUnknown, in which case we do nothing
+					return object, nil
+				}
+
+				return removeUnnecessaryServiceData(originalService, c.Rules), nil
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if c.extractNodeLabelsAnnotations() || c.extractNodeUID() || c.extractNodeTopology() {
 		c.nodeInformer = k8sconfig.NewNodeSharedInformer(c.kc, c.Filters.Node, 5*time.Minute)
 	}
 
@@ -222,6 +249,18 @@ func (c *WatchClient) Start() {
 		go c.replicasetInformer.Run(c.stopCh)
 	}
 
+	if c.serviceInformer != nil {
+		_, err = c.serviceInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    c.handleServiceAdd,
+			UpdateFunc: c.handleServiceUpdate,
+			DeleteFunc: c.handleServiceDelete,
+		})
+		if err != nil {
+			c.logger.Error("error adding event handler to service informer", zap.Error(err))
+		}
+		go c.serviceInformer.Run(c.stopCh)
+	}
+
 	if c.nodeInformer != nil {
 		_, err = c.nodeInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
 			AddFunc:    c.handleNodeAdd,
@@ -339,6 +378,39 @@ func (c *WatchClient) handleNodeDelete(obj any) {
 	}
 }
 
+func (c *WatchClient) handleServiceAdd(obj any) {
+	c.telemetryBuilder.OtelsvcK8sServiceAdded.Add(context.Background(), 1)
+	if service, ok := obj.(*api_v1.Service); ok {
+		c.addOrUpdateService(service)
+	} else {
+		c.logger.Error("object received was not of type api_v1.Service", zap.Any("received", obj))
+	}
+}
+
+func (c *WatchClient) handleServiceUpdate(_, newService any) {
+	c.telemetryBuilder.OtelsvcK8sServiceUpdated.Add(context.Background(), 1)
+	if service, ok := newService.(*api_v1.Service); ok {
+		c.addOrUpdateService(service)
+	} else {
+		c.logger.Error("object received was not of type api_v1.Service", zap.Any("received", newService))
+	}
+}
+
+func (c *WatchClient) handleServiceDelete(obj any) {
+	c.telemetryBuilder.OtelsvcK8sServiceDeleted.Add(context.Background(), 1)
+	if service, ok := ignoreDeletedFinalStateUnknown(obj).(*api_v1.Service); ok {
+		c.m.Lock()
+		key := serviceKey(service.Namespace, service.Name)
+		if s, ok := c.Services[key]; ok {
+			c.removeServiceIPs(s)
+			delete(c.Services, key)
+		}
+		c.m.Unlock()
+	} else {
+		c.logger.Error("object received was not of type api_v1.Service", zap.Any("received", obj))
+	}
+}
+
 func (c *WatchClient) deleteLoop(interval time.Duration, gracePeriod time.Duration) {
 	// This loop runs after N seconds and deletes pods from cache.
 	// It iterates over the delete queue and deletes all that aren't
@@ -405,6 +477,28 @@ func (c *WatchClient) GetNamespace(namespace string) (*Namespace, bool) {
 	return nil, false
 }
 
+// GetService takes a namespace and a name and returns the service object they identify.
+func (c *WatchClient) GetService(namespace string, name string) (*Service, bool) {
+	c.m.RLock()
+	service, ok := c.Services[serviceKey(namespace, name)]
+	c.m.RUnlock()
+	if ok {
+		return service, ok
+	}
+	return nil, false
+}
+
+// GetServiceByIP takes a cluster IP and returns the service object the IP is associated with.
+func (c *WatchClient) GetServiceByIP(ip string) (*Service, bool) {
+	c.m.RLock()
+	service, ok := c.ServiceIPs[ip]
+	c.m.RUnlock()
+	if ok {
+		return service, ok
+	}
+	return nil, false
+}
+
 // GetNode takes a node name and returns the node object the node name is associated with.
 func (c *WatchClient) GetNode(nodeName string) (*Node, bool) {
 	c.m.RLock()
@@ -723,6 +817,53 @@ func (c *WatchClient) extractNodeAttributes(node *api_v1.Node) map[string]string
 		r.extractFromNodeMetadata(node.Annotations, tags, "k8s.node.annotations.%s")
 	}
 
+	if c.Rules.NodeInstanceType {
+		if v := firstLabel(node.Labels, api_v1.LabelInstanceTypeStable, api_v1.LabelInstanceType); v != "" {
+			tags[conventions.AttributeHostType] = v
+		}
+	}
+
+	if c.Rules.NodeZone {
+		if v := firstLabel(node.Labels, api_v1.LabelTopologyZone, api_v1.LabelFailureDomainBetaZone); v != "" {
+			tags[conventions.AttributeCloudAvailabilityZone] = v
+		}
+	}
+
+	if c.Rules.NodeRegion {
+		if v := firstLabel(node.Labels, api_v1.LabelTopologyRegion, api_v1.LabelFailureDomainBetaRegion); v != "" {
+			tags[conventions.AttributeCloudRegion] = v
+		}
+	}
+
+	return tags
+}
+
+// firstLabel returns the value of the first of keys set in labels. It allows
+// falling back to deprecated well-known labels.
+func firstLabel(labels map[string]string, keys ...string) string {
+	for _, key := range keys {
+		if v, ok := labels[key]; ok && v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func (c *WatchClient) extractServiceAttributes(service *api_v1.Service) map[string]string {
+	tags := map[string]string{
+		conventions.AttributeK8SNamespaceName: service.Namespace,
+		tagServiceName:                        service.Name,
+		tagServiceUID:                         string(service.UID),
+	}
+
+	for _, r := range c.Rules.Labels {
+		r.extractFromServiceMetadata(service.Labels, tags, "k8s.service.labels.%s")
+	}
+
+	for _, r := range c.Rules.Annotations {
+		r.extractFromServiceMetadata(service.Annotations, tags, "k8s.service.annotations.%s")
+	}
+
 	return tags
 }
 
@@ -965,6 +1106,81 @@ func (c *WatchClient) extractNodeUID() bool {
 	return c.Rules.NodeUID
 }
 
+func (c *WatchClient) extractNodeTopology() bool {
+	return c.Rules.NodeInstanceType || c.Rules.NodeZone || c.Rules.NodeRegion
+}
+
+func (c *WatchClient) addOrUpdateService(service *api_v1.Service) {
+	newService := &Service{
+		Name:       service.Name,
+		Namespace:  service.Namespace,
+		ServiceUID: string(service.UID),
+	}
+	// Headless services have no cluster IP
+	clusterIPs := service.Spec.ClusterIPs
+	if len(clusterIPs) == 0 && service.Spec.ClusterIP != "" {
+		clusterIPs = []string{service.Spec.ClusterIP}
+	}
+	for _, ip := range clusterIPs {
+		if ip != "" && ip != api_v1.ClusterIPNone {
+			newService.ClusterIPs = append(newService.ClusterIPs, ip)
+		}
+	}
+	newService.Attributes = c.extractServiceAttributes(service)
+
+	c.m.Lock()
+	if service.Name != "" {
+		key := serviceKey(service.Namespace, service.Name)
+		if s, ok := c.Services[key]; ok {
+			c.removeServiceIPs(s)
+		}
+		c.Services[key] = newService
+		for _, ip := range newService.ClusterIPs {
+			c.ServiceIPs[ip] = newService
+		}
+	}
+	c.m.Unlock()
+}
+
+// removeServiceIPs removes the cluster IPs of service from the ServiceIPs map,
+// unless they were reassigned to another service. It must be called with the lock held.
+func (c *WatchClient) removeServiceIPs(service *Service) {
+	for _, ip := range service.ClusterIPs {
+		if s, ok := c.ServiceIPs[ip]; ok && s == service {
+			delete(c.ServiceIPs, ip)
+		}
+	}
+}
+
+// This function removes all data from the Service except what is required by extraction rules and lookups
+func removeUnnecessaryServiceData(service *api_v1.Service, rules ExtractionRules) *api_v1.Service {
+	transformedService := api_v1.Service{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      service.GetName(),
+			Namespace: service.GetNamespace(),
+			UID:       service.GetUID(),
+		},
+		Spec: api_v1.ServiceSpec{
+			ClusterIP:  service.Spec.ClusterIP,
+			ClusterIPs: service.Spec.ClusterIPs,
+		},
+	}
+
+	if len(rules.Labels) > 0 {
+		transformedService.Labels = service.Labels
+	}
+
+	if len(rules.Annotations) > 0 {
+		transformedService.Annotations = service.Annotations
+	}
+
+	return &transformedService
+}
+
+func serviceKey(namespace string, name string) string {
+	return namespace + "/" + name
+}
+
 func (c *WatchClient) addOrUpdateNode(node *api_v1.Node) {
 	newNode := &Node{
 		Name:    node.Name,
@@ -1311,6 +1311,204 @@ func TestNodeExtractionRules(t *testing.T) {
 	}
 }
 
+func TestNodeTopologyExtractionRules(t *testing.T) {
+	c, _ := newTestClientWithRulesAndFilters(t, Filters{})
+
+	testCases := []struct {
+		name       string
+		rules      ExtractionRules
+		labels     map[string]string
+		attributes map[string]string
+	}{
+		{
+			name: "stable-labels",
+			rules: ExtractionRules{
+				NodeInstanceType: true,
+				NodeZone:         true,
+				NodeRegion:       true,
+			},
+			labels: map[string]string{
+				"node.kubernetes.io/instance-type": "m5.large",
+				"topology.kubernetes.io/zone":      "us-east-1a",
+				"topology.kubernetes.io/region":    "us-east-1",
+			},
+			attributes: map[string]string{
+				"host.type":               "m5.large",
+				"cloud.availability_zone": "us-east-1a",
+				"cloud.region":            "us-east-1",
+			},
+		},
+		{
+			name: "beta-labels",
+			rules: ExtractionRules{
+				NodeInstanceType: true,
+				NodeZone:         true,
+				NodeRegion:       true,
+			},
+			labels: map[string]string{
+				"beta.kubernetes.io/instance-type":         "n1-standard-4",
+				"failure-domain.beta.kubernetes.io/zone":   "europe-west1-b",
+				"failure-domain.beta.kubernetes.io/region": "europe-west1",
+			},
+			attributes: map[string]string{
+				"host.type":               "n1-standard-4",
+				"cloud.availability_zone": "europe-west1-b",
+				"cloud.region":            "europe-west1",
+			},
+		},
+		{
+			name: "disabled",
+			labels: map[string]string{
+				"node.kubernetes.io/instance-type": "m5.large",
+				"topology.kubernetes.io/zone":      "us-east-1a",
+			},
+			attributes: map[string]string{},
+		},
+		{
+			name: "missing-labels",
+			rules: ExtractionRules{
+				NodeInstanceType: true,
+				NodeZone:         true,
+				NodeRegion:       true,
+			},
+			attributes: map[string]string{},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c.Rules = tc.rules
+			node := &api_v1.Node{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:   "k8s-node-example",
+					Labels: tc.labels,
+				},
+			}
+			c.handleNodeAdd(node)
+			n, ok := c.GetNode(node.Name)
+			require.True(t, ok)
+			assert.Equal(t, tc.attributes, n.Attributes)
+		})
+	}
+}
+
+func TestServiceAddUpdateDelete(t *testing.T) {
+	c, _ := newTestClient(t)
+	c.Rules = ExtractionRules{
+		Services: true,
+		Labels: []FieldExtractionRule{{
+			KeyRegex: regexp.MustCompile("^(?:team)$"),
+			From:     MetadataFromService,
+		}},
+	}
+
+	service := &api_v1.Service{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "checkout",
+			Namespace: "shop",
+			UID:       "aaaaaaaa-bbbb-cccc-dddd-eeeeeeeeeeee",
+			Labels: map[string]string{
+				"team": "payments",
+				"tier": "backend",
+			},
+		},
+		Spec: api_v1.ServiceSpec{
+			ClusterIP:  "10.96.0.10",
+			ClusterIPs: []string{"10.96.0.10", "fd00::10"},
+		},
+	}
+	c.handleServiceAdd(service)
+
+	got, ok := c.GetService("shop", "checkout")
+	require.True(t, ok)
+	assert.Equal(t, []string{"10.96.0.10", "fd00::10"}, got.ClusterIPs)
+	assert.Equal(t, map[string]string{
+		"k8s.namespace.name":      "shop",
+		"k8s.service.name":        "checkout",
+		"k8s.service.uid":         "aaaaaaaa-bbbb-cccc-dddd-eeeeeeeeeeee",
+		"k8s.service.labels.team": "payments",
+	}, got.Attributes)
+	for _, ip := range []string{"10.96.0.10", "fd00::10"} {
+		byIP, ok := c.GetServiceByIP(ip)
+		require.True(t, ok)
+		assert.Same(t, got, byIP)
+	}
+
+	// A service in another namespace with the same name is a different service
+	_, ok = c.GetService("default", "checkout")
+	assert.False(t, ok)
+
+	// Updating the cluster IPs removes the previous ones
+	updated := service.DeepCopy()
+	updated.Spec.ClusterIP = "10.96.0.11"
+	updated.Spec.ClusterIPs = []string{"10.96.0.11"}
+	c.handleServiceUpdate(service, updated)
+	_, ok = c.GetServiceByIP("10.96.0.10")
+	assert.False(t, ok)
+	_, ok = c.GetServiceByIP("fd00::10")
+	assert.False(t, ok)
+	got, ok = c.GetServiceByIP("10.96.0.11")
+	require.True(t, ok)
+	assert.Equal(t, "checkout", got.Name)
+
+	// Headless services can only be looked up by name
+	headless := &api_v1.Service{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "db",
+			Namespace: "shop",
+		},
+		Spec: api_v1.ServiceSpec{
+			ClusterIP:  api_v1.ClusterIPNone,
+			ClusterIPs: []string{api_v1.ClusterIPNone},
+		},
+	}
+	c.handleServiceAdd(headless)
+	got, ok = c.GetService("shop", "db")
+	require.True(t, ok)
+	assert.Empty(t, got.ClusterIPs)
+	assert.Len(t, c.ServiceIPs, 1)
+
+	c.handleServiceDelete(cache.DeletedFinalStateUnknown{Obj: updated})
+	_, ok = c.GetService("shop", "checkout")
+	assert.False(t, ok)
+	_, ok = c.GetServiceByIP("10.96.0.11")
+	assert.False(t, ok)
+
+	c.handleServiceDelete(headless)
+	assert.Empty(t, c.Services)
+	assert.Empty(t, c.ServiceIPs)
+}
+
+func TestRemoveUnnecessaryServiceData(t *testing.T) {
+	service := &api_v1.Service{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:        "checkout",
+			Namespace:   "shop",
+			UID:         "uid",
+			Labels:      map[string]string{"team": "payments"},
+			Annotations: map[string]string{"owner": "alice"},
+		},
+		Spec: api_v1.ServiceSpec{
+			ClusterIP: "10.96.0.10",
+			Ports:     []api_v1.ServicePort{{Port: 8080}},
+		},
+	}
+
+	got := removeUnnecessaryServiceData(service, ExtractionRules{Services: true})
+	assert.Equal(t, "checkout", got.Name)
+	assert.Equal(t, "shop", got.Namespace)
+	assert.Equal(t, "10.96.0.10", got.Spec.ClusterIP)
+	assert.Empty(t, got.Spec.Ports)
+	assert.Nil(t, got.Labels)
+	assert.Nil(t, got.Annotations)
+
+	got = removeUnnecessaryServiceData(service, ExtractionRules{
+		Services: true,
+		Labels:   []FieldExtractionRule{{Key: "team", From: MetadataFromService}},
+	})
+	assert.Equal(t, service.Labels, got.Labels)
+	assert.Nil(t, got.Annotations)
+}
+
 func TestFilters(t *testing.T) {
 	testCases := []struct {
 		name    string
@@ -40,6 +40,24 @@ func Test_newKubeSystemSharedInformer(t *testing.T) {
 	assert.NotNil(t, informer)
 }
 
+func Test_newServiceSharedInformer(t *testing.T) {
+	client, err := newFakeAPIClientset(k8sconfig.APIConfig{})
+	require.NoError(t, err)
+	informer := newServiceSharedInformer(client, "testns")
+	assert.NotNil(t, informer)
+}
+
+func Test_serviceListAndWatchFuncs(t *testing.T) {
+	c, err := newFakeAPIClientset(k8sconfig.APIConfig{})
+	assert.NoError(t, err)
+	list, err := serviceListFuncWithSelectors(c, "test-ns")(metav1.ListOptions{})
+	assert.NoError(t, err)
+	assert.NotNil(t, list)
+	watch, err := serviceWatchFuncWithSelectors(c, "test-ns")(metav1.ListOptions{})
+	assert.NoError(t, err)
+	assert.NotNil(t, watch)
+}
+
 func Test_informerListFuncWithSelectors(t *testing.T) {
 	ls, fs, err := selectorsFromFilters(Filters{
 		Fields: []FieldFilter{
@@ -23,12 +23,16 @@ const (
 	tagStartTime            = "k8s.pod.start_time"
 	tagHostName             = "k8s.pod.hostname"
 	tagClusterUID           = "k8s.cluster.uid"
+	tagServiceName          = "k8s.service.name"
+	tagServiceUID           = "k8s.service.uid"
 	// MetadataFromPod is used to specify to extract metadata/labels/annotations from pod
 	MetadataFromPod = "pod"
 	// MetadataFromNamespace is used to specify to extract metadata/labels/annotations from namespace
 	MetadataFromNamespace = "namespace"
 	// MetadataFromNode is used to specify to extract metadata/labels/annotations from node
-	MetadataFromNode       = "node"
+	MetadataFromNode = "node"
+	// MetadataFromService is used to specify to extract metadata/labels/annotations from service
+	MetadataFromService    = "service"
 	PodIdentifierMaxLength = 4
 
 	ResourceSource   = "resource_attribute"
@@ -91,6 +95,8 @@ type Client interface {
 	GetPod(PodIdentifier) (*Pod, bool)
 	GetNamespace(string) (*Namespace, bool)
 	GetNode(string) (*Node, bool)
+	GetService(namespace string, name string) (*Service, bool)
+	GetServiceByIP(string) (*Service, bool)
 	Start()
 	Stop()
 }
@@ -160,6 +166,15 @@ type Node struct {
 	Attributes map[string]string
 }
 
+// Service represents a kubernetes service.
+type Service struct {
+	Name       string
+	Namespace  string
+	ServiceUID string
+	ClusterIPs []string
+	Attributes map[string]string
+}
+
 type deleteRequest struct {
 	// id is identifier (IP address or Pod UID) of pod to remove from pods map
 	id PodIdentifier
@@ -213,6 +228,9 @@ type ExtractionRules struct {
 	StatefulSetName           bool
 	Node                      bool
 	NodeUID                   bool
+	NodeInstanceType          bool
+	NodeZone                  bool
+	NodeRegion                bool
 	StartTime                 bool
 	ContainerName             bool
 	ContainerID               bool
@@ -221,6 +239,10 @@ type ExtractionRules struct {
 	ContainerImageTag         bool
 	ClusterUID                bool
 
+	// Services enables watching the services, so that they can be looked up
+	// by name or cluster IP.
+	Services bool
+
 	Annotations []FieldExtractionRule
 	Labels      []FieldExtractionRule
 }
@@ -262,10 +284,11 @@ type FieldExtractionRule struct {
 	// Full value is extracted when no regexp is provided.
 	Regex *regexp.Regexp
 	// From determines the kubernetes object the field should be retrieved from.
-	// Currently only three values are supported,
+	// Currently only four values are supported,
 	//  - pod
 	//  - namespace
 	//  - node
+	//  - service
 	From string
 }
 
@@ -288,6 +311,12 @@ func (r *FieldExtractionRule) extractFromNodeMetadata(metadata map[string]string
 	}
 }
 
+func (r *FieldExtractionRule) extractFromServiceMetadata(metadata map[string]string, tags map[string]string, formatter string) {
+	if r.From == MetadataFromService {
+		r.extractFromMetadata(metadata, tags, formatter)
+	}
+}
+
 func (r *FieldExtractionRule) extractFromMetadata(metadata map[string]string, tags map[string]string, formatter string) {
 	if r.KeyRegex != nil {
 		for k, v := range metadata {
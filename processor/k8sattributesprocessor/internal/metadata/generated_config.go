@@ -27,10 +27,13 @@ func (rac *ResourceAttributeConfig) Unmarshal(parser *confmap.Conf) error {
 
 // ResourceAttributesConfig provides config for k8sattributes resource attributes.
 type ResourceAttributesConfig struct {
+	CloudAvailabilityZone     ResourceAttributeConfig `mapstructure:"cloud.availability_zone"`
+	CloudRegion               ResourceAttributeConfig `mapstructure:"cloud.region"`
 	ContainerID               ResourceAttributeConfig `mapstructure:"container.id"`
 	ContainerImageName        ResourceAttributeConfig `mapstructure:"container.image.name"`
 	ContainerImageRepoDigests ResourceAttributeConfig `mapstructure:"container.image.repo_digests"`
 	ContainerImageTag         ResourceAttributeConfig `mapstructure:"container.image.tag"`
+	HostType                  ResourceAttributeConfig `mapstructure:"host.type"`
 	K8sClusterUID             ResourceAttributeConfig `mapstructure:"k8s.cluster.uid"`
 	K8sContainerName          ResourceAttributeConfig `mapstructure:"k8s.container.name"`
 	K8sCronjobName            ResourceAttributeConfig `mapstructure:"k8s.cronjob.name"`
@@ -56,6 +59,12 @@ type ResourceAttributesConfig struct {
 
 func DefaultResourceAttributesConfig() ResourceAttributesConfig {
 	return ResourceAttributesConfig{
+		CloudAvailabilityZone: ResourceAttributeConfig{
+			Enabled: false,
+		},
+		CloudRegion: ResourceAttributeConfig{
+			Enabled: false,
+		},
 		ContainerID: ResourceAttributeConfig{
 			Enabled: false,
 		},
@@ -68,6 +77,9 @@ func DefaultResourceAttributesConfig() ResourceAttributesConfig {
 		ContainerImageTag: ResourceAttributeConfig{
 			Enabled: true,
 		},
+		HostType: ResourceAttributeConfig{
+			Enabled: false,
+		},
 		K8sClusterUID: ResourceAttributeConfig{
 			Enabled: false,
 		},
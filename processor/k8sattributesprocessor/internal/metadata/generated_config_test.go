@@ -24,10 +24,13 @@ func TestResourceAttributesConfig(t *testing.T) {
 		{
 			name: "all_set",
 			want: ResourceAttributesConfig{
+				CloudAvailabilityZone:     ResourceAttributeConfig{Enabled: true},
+				CloudRegion:               ResourceAttributeConfig{Enabled: true},
 				ContainerID:               ResourceAttributeConfig{Enabled: true},
 				ContainerImageName:        ResourceAttributeConfig{Enabled: true},
 				ContainerImageRepoDigests: ResourceAttributeConfig{Enabled: true},
 				ContainerImageTag:         ResourceAttributeConfig{Enabled: true},
+				HostType:                  ResourceAttributeConfig{Enabled: true},
 				K8sClusterUID:             ResourceAttributeConfig{Enabled: true},
 				K8sContainerName:          ResourceAttributeConfig{Enabled: true},
 				K8sCronjobName:            ResourceAttributeConfig{Enabled: true},
@@ -54,10 +57,13 @@ func TestResourceAttributesConfig(t *testing.T) {
 		{
 			name: "none_set",
 			want: ResourceAttributesConfig{
+				CloudAvailabilityZone:     ResourceAttributeConfig{Enabled: false},
+				CloudRegion:               ResourceAttributeConfig{Enabled: false},
 				ContainerID:               ResourceAttributeConfig{Enabled: false},
 				ContainerImageName:        ResourceAttributeConfig{Enabled: false},
 				ContainerImageRepoDigests: ResourceAttributeConfig{Enabled: false},
 				ContainerImageTag:         ResourceAttributeConfig{Enabled: false},
+				HostType:                  ResourceAttributeConfig{Enabled: false},
 				K8sClusterUID:             ResourceAttributeConfig{Enabled: false},
 				K8sContainerName:          ResourceAttributeConfig{Enabled: false},
 				K8sCronjobName:            ResourceAttributeConfig{Enabled: false},
@@ -13,10 +13,13 @@ func TestResourceBuilder(t *testing.T) {
 		t.Run(tt, func(t *testing.T) {
 			cfg := loadResourceAttributesConfig(t, tt)
 			rb := NewResourceBuilder(cfg)
+			rb.SetCloudAvailabilityZone("cloud.availability_zone-val")
+			rb.SetCloudRegion("cloud.region-val")
 			rb.SetContainerID("container.id-val")
 			rb.SetContainerImageName("container.image.name-val")
 			rb.SetContainerImageRepoDigests([]any{"container.image.repo_digests-item1", "container.image.repo_digests-item2"})
 			rb.SetContainerImageTag("container.image.tag-val")
+			rb.SetHostType("host.type-val")
 			rb.SetK8sClusterUID("k8s.cluster.uid-val")
 			rb.SetK8sContainerName("k8s.container.name-val")
 			rb.SetK8sCronjobName("k8s.cronjob.name-val")
@@ -46,7 +49,7 @@ func TestResourceBuilder(t *testing.T) {
 			case "default":
 				assert.Equal(t, 8, res.Attributes().Len())
 			case "all_set":
-				assert.Equal(t, 25, res.Attributes().Len())
+				assert.Equal(t, 28, res.Attributes().Len())
 			case "none_set":
 				assert.Equal(t, 0, res.Attributes().Len())
 				return
@@ -54,7 +57,17 @@ func TestResourceBuilder(t *testing.T) {
 				assert.Failf(t, "unexpected test case: %s", tt)
 			}
 
-			val, ok := res.Attributes().Get("container.id")
+			val, ok := res.Attributes().Get("cloud.availability_zone")
+			assert.Equal(t, tt == "all_set", ok)
+			if ok {
+				assert.EqualValues(t, "cloud.availability_zone-val", val.Str())
+			}
+			val, ok = res.Attributes().Get("cloud.region")
+			assert.Equal(t, tt == "all_set", ok)
+			if ok {
+				assert.EqualValues(t, "cloud.region-val", val.Str())
+			}
+			val, ok = res.Attributes().Get("container.id")
 			assert.Equal(t, tt == "all_set", ok)
 			if ok {
 				assert.EqualValues(t, "container.id-val", val.Str())
@@ -74,6 +87,11 @@ func TestResourceBuilder(t *testing.T) {
 			if ok {
 				assert.EqualValues(t, "container.image.tag-val", val.Str())
 			}
+			val, ok = res.Attributes().Get("host.type")
+			assert.Equal(t, tt == "all_set", ok)
+			if ok {
+				assert.EqualValues(t, "host.type-val", val.Str())
+			}
 			val, ok = res.Attributes().Get("k8s.cluster.uid")
 			assert.Equal(t, tt == "all_set", ok)
 			if ok {
@@ -43,6 +43,9 @@ type TelemetryBuilder struct {
 	OtelsvcK8sReplicasetAdded   metric.Int64Counter
 	OtelsvcK8sReplicasetDeleted metric.Int64Counter
 	OtelsvcK8sReplicasetUpdated metric.Int64Counter
+	OtelsvcK8sServiceAdded      metric.Int64Counter
+	OtelsvcK8sServiceDeleted    metric.Int64Counter
+	OtelsvcK8sServiceUpdated    metric.Int64Counter
 	meters                      map[configtelemetry.Level]metric.Meter
 }
 
@@ -150,5 +153,23 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...Teleme
 		metric.WithUnit("1"),
 	)
 	errs = errors.Join(errs, err)
+	builder.OtelsvcK8sServiceAdded, err = builder.meters[configtelemetry.LevelBasic].Int64Counter(
+		"otelcol_otelsvc_k8s_service_added",
+		metric.WithDescription("Number of Service add events received"),
+		metric.WithUnit("1"),
+	)
+	errs = errors.Join(errs, err)
+	builder.OtelsvcK8sServiceDeleted, err = builder.meters[configtelemetry.LevelBasic].Int64Counter(
+		"otelcol_otelsvc_k8s_service_deleted",
+		metric.WithDescription("Number of Service delete events received"),
+		metric.WithUnit("1"),
+	)
+	errs = errors.Join(errs, err)
+	builder.OtelsvcK8sServiceUpdated, err = builder.meters[configtelemetry.LevelBasic].Int64Counter(
+		"otelcol_otelsvc_k8s_service_updated",
+		metric.WithDescription("Number of Service update events received"),
+		metric.WithUnit("1"),
+	)
+	errs = errors.Join(errs, err)
 	return &builder, errs
 }
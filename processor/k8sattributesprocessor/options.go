@@ -105,6 +105,15 @@ func enabledAttributes() (attributes []string) {
 	if defaultConfig.K8sNodeUID.Enabled {
 		attributes = append(attributes, conventions.AttributeK8SNodeUID)
 	}
+	if defaultConfig.HostType.Enabled {
+		attributes = append(attributes, conventions.AttributeHostType)
+	}
+	if defaultConfig.CloudAvailabilityZone.Enabled {
+		attributes = append(attributes, conventions.AttributeCloudAvailabilityZone)
+	}
+	if defaultConfig.CloudRegion.Enabled {
+		attributes = append(attributes, conventions.AttributeCloudRegion)
+	}
 	if defaultConfig.K8sPodHostname.Enabled {
 		attributes = append(attributes, specPodHostName)
 	}
@@ -181,6 +190,12 @@ func withExtractMetadata(fields ...string) option {
 				p.rules.Node = true
 			case conventions.AttributeK8SNodeUID:
 				p.rules.NodeUID = true
+			case conventions.AttributeHostType:
+				p.rules.NodeInstanceType = true
+			case conventions.AttributeCloudAvailabilityZone:
+				p.rules.NodeZone = true
+			case conventions.AttributeCloudRegion:
+				p.rules.NodeRegion = true
 			case conventions.AttributeContainerID:
 				p.rules.ContainerID = true
 			case conventions.AttributeContainerImageName:
@@ -381,3 +396,18 @@ func withExcludes(podExclude ExcludeConfig) option {
 		return nil
 	}
 }
+
+// withPeer allows tagging client spans with the metadata of the pod or service they call.
+func withPeer(peer PeerConfig) option {
+	return func(p *kubernetesprocessor) error {
+		if !peer.Enabled {
+			return nil
+		}
+		p.rules.Services = true
+		p.peerAddressAttributes = peer.AddressAttributes
+		if len(p.peerAddressAttributes) == 0 {
+			p.peerAddressAttributes = defaultPeerAddressAttributes
+		}
+		return nil
+	}
+}
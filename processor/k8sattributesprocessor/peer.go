@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package k8sattributesprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/k8sattributesprocessor"
+
+import (
+	"net"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	conventions "go.opentelemetry.io/collector/semconv/v1.8.0"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/k8sattributesprocessor/internal/kube"
+)
+
+const (
+	k8sAttributePrefix  = "k8s."
+	peerAttributePrefix = "k8s.peer."
+)
+
+var defaultPeerAddressAttributes = []string{
+	"server.address",
+	conventions.AttributeNetPeerName,
+	"network.peer.address",
+	"net.sock.peer.addr",
+	conventions.AttributeNetPeerIP,
+}
+
+// processPeers adds the metadata of the pod or service called by the client spans
+// of rs to their attributes.
+func (kp *kubernetesprocessor) processPeers(rs ptrace.ResourceSpans) {
+	namespace := stringAttributeFromMap(rs.Resource().Attributes(), conventions.AttributeK8SNamespaceName)
+	sss := rs.ScopeSpans()
+	for i := 0; i < sss.Len(); i++ {
+		spans := sss.At(i).Spans()
+		for j := 0; j < spans.Len(); j++ {
+			span := spans.At(j)
+			if span.Kind() != ptrace.SpanKindClient {
+				continue
+			}
+			address := kp.peerAddress(span.Attributes())
+			if address == "" {
+				continue
+			}
+			for key, val := range kp.getPeerAttributes(address, namespace) {
+				if _, found := span.Attributes().Get(key); !found {
+					span.Attributes().PutStr(key, val)
+				}
+			}
+		}
+	}
+}
+
+// peerAddress returns the first non empty peer address attribute, without its port.
+func (kp *kubernetesprocessor) peerAddress(attrs pcommon.Map) string {
+	for _, name := range kp.peerAddressAttributes {
+		address := stringAttributeFromMap(attrs, name)
+		if address == "" {
+			continue
+		}
+		if host, _, err := net.SplitHostPort(address); err == nil {
+			return host
+		}
+		return address
+	}
+	return ""
+}
+
+// getPeerAttributes returns the peer attributes of the pod or service with the given address.
+// An address that is not an IP is resolved as the DNS name of a service, relative to namespace.
+func (kp *kubernetesprocessor) getPeerAttributes(address string, namespace string) map[string]string {
+	if net.ParseIP(address) != nil {
+		if pod, ok := kp.kc.GetPod(kube.PodIdentifier{kube.PodIdentifierAttributeFromConnection(address)}); ok {
+			return peerAttributes(pod.Attributes)
+		}
+		if service, ok := kp.kc.GetServiceByIP(address); ok {
+			return peerAttributes(service.Attributes)
+		}
+		return nil
+	}
+
+	// The DNS name of a service is <service>, <service>.<namespace> or
+	// <service>.<namespace>.svc[.<cluster domain>].
+	parts := strings.Split(strings.TrimSuffix(address, "."), ".")
+	name := parts[0]
+	if len(parts) > 1 {
+		if len(parts) > 2 && parts[2] != "svc" {
+			return nil
+		}
+		namespace = parts[1]
+	}
+	if namespace == "" {
+		return nil
+	}
+	if service, ok := kp.kc.GetService(namespace, name); ok {
+		return peerAttributes(service.Attributes)
+	}
+	return nil
+}
+
+// peerAttributes renames the k8s.* attributes to k8s.peer.* and prefixes the others with k8s.peer.
+func peerAttributes(attrs map[string]string) map[string]string {
+	peer := make(map[string]string, len(attrs))
+	for key, val := range attrs {
+		peer[peerAttributePrefix+strings.TrimPrefix(key, k8sAttributePrefix)] = val
+	}
+	return peer
+}
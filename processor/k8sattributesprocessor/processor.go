@@ -37,6 +37,9 @@ type kubernetesprocessor struct {
 	filters           kube.Filters
 	podAssociations   []kube.Association
 	podIgnore         kube.Excludes
+	// peerAddressAttributes are the span attributes identifying the peers
+	// of client spans. Peers are not tagged when empty.
+	peerAddressAttributes []string
 }
 
 func (kp *kubernetesprocessor) initKubeClient(set component.TelemetrySettings, kubeClient kube.ClientProvider) error {
@@ -92,6 +95,9 @@ func (kp *kubernetesprocessor) processTraces(ctx context.Context, td ptrace.Trac
 	rss := td.ResourceSpans()
 	for i := 0; i < rss.Len(); i++ {
 		kp.processResource(ctx, rss.At(i).Resource())
+		if len(kp.peerAddressAttributes) > 0 && !kp.passthroughMode {
+			kp.processPeers(rss.At(i))
+		}
 	}
 
 	return td, nil
@@ -1564,6 +1564,159 @@ func Test_intFromAttribute(t *testing.T) {
 	}
 }
 
+func TestTracesProcessorPeerAttributes(t *testing.T) {
+	next := new(consumertest.TracesSink)
+	var kp *kubernetesprocessor
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.Peer.Enabled = true
+	p, err := newTracesProcessor(
+		cfg,
+		next,
+		withExtractKubernetesProcessorInto(&kp),
+	)
+	require.NoError(t, err)
+	err = p.Start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+	assert.True(t, kp.rules.Services)
+	kc := kp.kc.(*fakeClient)
+
+	kc.Pods[newPodIdentifier("connection", "", "10.0.0.5")] = &kube.Pod{
+		Name: "checkout-6d9f",
+		Attributes: map[string]string{
+			conventions.AttributeK8SPodName:        "checkout-6d9f",
+			conventions.AttributeK8SNamespaceName:  "shop",
+			conventions.AttributeK8SDeploymentName: "checkout",
+			"team":                                 "payments",
+		},
+	}
+	checkout := &kube.Service{
+		Name:       "checkout",
+		Namespace:  "shop",
+		ClusterIPs: []string{"10.96.0.10"},
+		Attributes: map[string]string{
+			conventions.AttributeK8SNamespaceName: "shop",
+			"k8s.service.name":                    "checkout",
+			"k8s.service.labels.team":             "payments",
+		},
+	}
+	kc.Services["shop/checkout"] = checkout
+	kc.ServiceIPs["10.96.0.10"] = checkout
+
+	servicePeerAttrs := map[string]string{
+		"k8s.peer.namespace.name":      "shop",
+		"k8s.peer.service.name":        "checkout",
+		"k8s.peer.service.labels.team": "payments",
+	}
+
+	testCases := []struct {
+		name          string
+		kind          ptrace.SpanKind
+		resourceNS    string
+		spanAttrs     map[string]string
+		expectedAttrs map[string]string
+	}{
+		{
+			name:      "pod IP",
+			kind:      ptrace.SpanKindClient,
+			spanAttrs: map[string]string{"network.peer.address": "10.0.0.5"},
+			expectedAttrs: map[string]string{
+				"k8s.peer.pod.name":        "checkout-6d9f",
+				"k8s.peer.namespace.name":  "shop",
+				"k8s.peer.deployment.name": "checkout",
+				"k8s.peer.team":            "payments",
+			},
+		},
+		{
+			name:          "service cluster IP",
+			kind:          ptrace.SpanKindClient,
+			spanAttrs:     map[string]string{"net.sock.peer.addr": "10.96.0.10"},
+			expectedAttrs: servicePeerAttrs,
+		},
+		{
+			name:          "service DNS name",
+			kind:          ptrace.SpanKindClient,
+			spanAttrs:     map[string]string{"server.address": "checkout.shop.svc.cluster.local"},
+			expectedAttrs: servicePeerAttrs,
+		},
+		{
+			name:          "service name with port",
+			kind:          ptrace.SpanKindClient,
+			spanAttrs:     map[string]string{conventions.AttributeNetPeerName: "checkout.shop:8080"},
+			expectedAttrs: servicePeerAttrs,
+		},
+		{
+			name:          "service name in the namespace of the resource",
+			kind:          ptrace.SpanKindClient,
+			resourceNS:    "shop",
+			spanAttrs:     map[string]string{"server.address": "checkout"},
+			expectedAttrs: servicePeerAttrs,
+		},
+		{
+			name:          "external host",
+			kind:          ptrace.SpanKindClient,
+			spanAttrs:     map[string]string{"server.address": "api.example.com"},
+			expectedAttrs: map[string]string{},
+		},
+		{
+			name:          "server span",
+			kind:          ptrace.SpanKindServer,
+			spanAttrs:     map[string]string{"server.address": "checkout.shop"},
+			expectedAttrs: map[string]string{},
+		},
+		{
+			name: "existing attributes are kept",
+			kind: ptrace.SpanKindClient,
+			spanAttrs: map[string]string{
+				"server.address":        "checkout.shop",
+				"k8s.peer.service.name": "custom",
+			},
+			expectedAttrs: map[string]string{
+				"k8s.peer.namespace.name":      "shop",
+				"k8s.peer.service.name":        "custom",
+				"k8s.peer.service.labels.team": "payments",
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			traces := ptrace.NewTraces()
+			rs := traces.ResourceSpans().AppendEmpty()
+			if tc.resourceNS != "" {
+				rs.Resource().Attributes().PutStr(conventions.AttributeK8SNamespaceName, tc.resourceNS)
+			}
+			span := rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+			span.SetKind(tc.kind)
+			for k, v := range tc.spanAttrs {
+				span.Attributes().PutStr(k, v)
+			}
+
+			assert.NoError(t, p.ConsumeTraces(context.Background(), traces))
+			require.Len(t, next.AllTraces(), i+1)
+
+			attrs := next.AllTraces()[i].ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes()
+			for k, v := range tc.expectedAttrs {
+				got, ok := attrs.Get(k)
+				if assert.True(t, ok, k) {
+					assert.Equal(t, v, got.Str())
+				}
+			}
+			assert.Equal(t, len(tc.spanAttrs)+len(tc.expectedAttrs)-countExisting(tc.spanAttrs, tc.expectedAttrs), attrs.Len())
+		})
+	}
+}
+
+// countExisting returns the number of expected attributes that the span already had.
+func countExisting(spanAttrs map[string]string, expected map[string]string) int {
+	n := 0
+	for k := range expected {
+		if _, ok := spanAttrs[k]; ok {
+			n++
+		}
+	}
+	return n
+}
+
 var _ componentstatus.Reporter = (*nopHost)(nil)
 
 type nopHost struct {
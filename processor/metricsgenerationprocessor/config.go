@@ -26,6 +26,21 @@ const (
 
 	// operationFieldName is the mapstructure field name for Operation field
 	operationFieldName = "operation"
+
+	// expressionFieldName is the mapstructure field name for Expression field
+	expressionFieldName = "expression"
+
+	// joinFieldName is the mapstructure field name for Join field
+	joinFieldName = "join"
+
+	// inputsFieldName is the mapstructure field name for Inputs field
+	inputsFieldName = "inputs"
+
+	// outputTypeFieldName is the mapstructure field name for Output.Type field
+	outputTypeFieldName = "output::type"
+
+	// outputTemporalityFieldName is the mapstructure field name for Output.Temporality field
+	outputTemporalityFieldName = "output::temporality"
 )
 
 // Config defines the configuration for the processor.
@@ -56,6 +71,46 @@ type Rule struct {
 
 	// A constant number by which the first operand will be scaled. A required field if the type is scale.
 	ScaleBy float64 `mapstructure:"scale_by"`
+
+	// The arithmetic expression computing the new metric, for example `(disk.read + disk.write) / cpu.time * 100`.
+	// Its operands are the names of the inputs, or of metrics. A required field if the type is expression.
+	Expression string `mapstructure:"expression"`
+
+	// The operands of the expression that need an alias or filtered data points.
+	Inputs []Input `mapstructure:"inputs"`
+
+	// How the data points of the operands are joined on their attributes: inner (default) or left.
+	Join JoinType `mapstructure:"join"`
+
+	// The value of the operands missing from a left join.
+	DefaultValue float64 `mapstructure:"default_value"`
+
+	// The type of the metric generated by an expression.
+	Output Output `mapstructure:"output"`
+}
+
+// Input is an operand of an expression.
+type Input struct {
+	// Name of the operand in the expression. Defaults to the metric name.
+	Name string `mapstructure:"name"`
+
+	// Metric whose data points are the values of the operand. This is a required field.
+	Metric string `mapstructure:"metric"`
+
+	// Only the data points with these attribute values are used. These attributes are not joined on.
+	Attributes map[string]string `mapstructure:"attributes"`
+}
+
+// Output defines the type of the metric generated by an expression.
+type Output struct {
+	// Type of the metric: gauge (default) or sum.
+	Type OutputType `mapstructure:"type"`
+
+	// Aggregation temporality of a sum: cumulative (default) or delta.
+	Temporality Temporality `mapstructure:"temporality"`
+
+	// Whether a sum is monotonic.
+	Monotonic bool `mapstructure:"monotonic"`
 }
 
 type GenerationType string
@@ -67,9 +122,12 @@ const (
 
 	// Generates a new metric scaling the value of s given metric with a provided constant
 	scale GenerationType = "scale"
+
+	// Generates a new metric evaluating an arithmetic expression over any number of metrics
+	expressionType GenerationType = "expression"
 )
 
-var generationTypes = map[GenerationType]struct{}{calculate: {}, scale: {}}
+var generationTypes = map[GenerationType]struct{}{calculate: {}, scale: {}, expressionType: {}}
 
 func (gt GenerationType) isValid() bool {
 	_, ok := generationTypes[gt]
@@ -131,6 +189,30 @@ var operationTypeKeys = func() []string {
 	return ret
 }
 
+type JoinType string
+
+const (
+	// Generates data points for the attribute sets present in all the operands
+	innerJoin JoinType = "inner"
+
+	// Generates data points for the attribute sets of the first operand, using the default value for the others
+	leftJoin JoinType = "left"
+)
+
+type OutputType string
+
+const (
+	gaugeOutput OutputType = "gauge"
+	sumOutput   OutputType = "sum"
+)
+
+type Temporality string
+
+const (
+	cumulativeTemporality Temporality = "cumulative"
+	deltaTemporality      Temporality = "delta"
+)
+
 // Validate checks whether the input configuration has all of the required fields for the processor.
 // An error is returned if there are any invalid inputs.
 func (config *Config) Validate() error {
@@ -147,6 +229,13 @@ func (config *Config) Validate() error {
 			return fmt.Errorf("%q must be in %q", typeFieldName, generationTypeKeys())
 		}
 
+		if rule.Type == expressionType {
+			if err := rule.validateExpression(); err != nil {
+				return err
+			}
+			continue
+		}
+
 		if rule.Metric1 == "" {
 			return fmt.Errorf("missing required field %q", metric1FieldName)
 		}
@@ -165,3 +254,49 @@ func (config *Config) Validate() error {
 	}
 	return nil
 }
+
+func (rule Rule) validateExpression() error {
+	if rule.Expression == "" {
+		return fmt.Errorf("missing required field %q for generation type %q", expressionFieldName, expressionType)
+	}
+	if _, _, err := parseExpression(rule.Expression); err != nil {
+		return fmt.Errorf("invalid %q: %w", expressionFieldName, err)
+	}
+
+	switch rule.Join {
+	case "", innerJoin, leftJoin:
+	default:
+		return fmt.Errorf("%q must be in %q", joinFieldName, []JoinType{innerJoin, leftJoin})
+	}
+
+	names := map[string]struct{}{}
+	for _, input := range rule.Inputs {
+		if input.Metric == "" {
+			return fmt.Errorf("missing required field %q in %q", "metric", inputsFieldName)
+		}
+		name := input.name()
+		if _, ok := names[name]; ok {
+			return fmt.Errorf("duplicate name %q in %q", name, inputsFieldName)
+		}
+		names[name] = struct{}{}
+	}
+
+	switch rule.Output.Type {
+	case "", gaugeOutput, sumOutput:
+	default:
+		return fmt.Errorf("%q must be in %q", outputTypeFieldName, []OutputType{gaugeOutput, sumOutput})
+	}
+	switch rule.Output.Temporality {
+	case "", cumulativeTemporality, deltaTemporality:
+	default:
+		return fmt.Errorf("%q must be in %q", outputTemporalityFieldName, []Temporality{cumulativeTemporality, deltaTemporality})
+	}
+	return nil
+}
+
+func (input Input) name() string {
+	if input.Name == "" {
+		return input.Metric
+	}
+	return input.Name
+}
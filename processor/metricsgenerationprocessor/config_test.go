@@ -75,6 +75,45 @@ func TestLoadConfig(t *testing.T) {
 			id:           component.NewIDWithName(metadata.Type, "invalid_operation"),
 			errorMessage: fmt.Sprintf("%q must be in %q", operationFieldName, operationTypeKeys()),
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "expression"),
+			expected: &Config{
+				Rules: []Rule{
+					{
+						Name:       "disk.io.per_cpu",
+						Unit:       "percent",
+						Type:       "expression",
+						Expression: "(read + write) / system.cpu.time * 100",
+						Join:       "left",
+						Inputs: []Input{
+							{Name: "read", Metric: "disk.io", Attributes: map[string]string{"direction": "read"}},
+							{Name: "write", Metric: "disk.io", Attributes: map[string]string{"direction": "write"}},
+						},
+						Output: Output{Type: "sum", Temporality: "delta"},
+					},
+				},
+			},
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "missing_expression"),
+			errorMessage: fmt.Sprintf("missing required field %q for generation type %q", expressionFieldName, expressionType),
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_expression"),
+			errorMessage: fmt.Sprintf("invalid %q: missing closing parenthesis at position 18", expressionFieldName),
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_join"),
+			errorMessage: fmt.Sprintf("%q must be in %q", joinFieldName, []JoinType{innerJoin, leftJoin}),
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "missing_input_metric"),
+			errorMessage: fmt.Sprintf("missing required field %q in %q", "metric", inputsFieldName),
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_output_type"),
+			errorMessage: fmt.Sprintf("%q must be in %q", outputTypeFieldName, []OutputType{gaugeOutput, sumOutput}),
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,221 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metricsgenerationprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/metricsgenerationprocessor"
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var errDivideByZero = errors.New("divide by zero")
+
+// expression is a parsed arithmetic expression over named operands.
+type expression interface {
+	eval(values map[string]float64) (float64, error)
+}
+
+type numberExpression float64
+
+func (e numberExpression) eval(map[string]float64) (float64, error) {
+	return float64(e), nil
+}
+
+type variableExpression string
+
+func (e variableExpression) eval(values map[string]float64) (float64, error) {
+	v, ok := values[string(e)]
+	if !ok {
+		return 0, fmt.Errorf("missing value of %q", string(e))
+	}
+	return v, nil
+}
+
+type negateExpression struct {
+	operand expression
+}
+
+func (e negateExpression) eval(values map[string]float64) (float64, error) {
+	v, err := e.operand.eval(values)
+	return -v, err
+}
+
+type binaryExpression struct {
+	operator    byte
+	left, right expression
+}
+
+func (e binaryExpression) eval(values map[string]float64) (float64, error) {
+	left, err := e.left.eval(values)
+	if err != nil {
+		return 0, err
+	}
+	right, err := e.right.eval(values)
+	if err != nil {
+		return 0, err
+	}
+	switch e.operator {
+	case '+':
+		return left + right, nil
+	case '-':
+		return left - right, nil
+	case '*':
+		return left * right, nil
+	default:
+		if right == 0 {
+			return 0, errDivideByZero
+		}
+		return left / right, nil
+	}
+}
+
+// parseExpression parses an arithmetic expression made of numbers, operands, the +, -, *
+// and / operators and parentheses. It returns the expression and the names of its operands
+// in order of first appearance. Operand names are made of letters, digits, `_` and `.`,
+// and must not start with a digit; other names can be written between double quotes.
+func parseExpression(s string) (expression, []string, error) {
+	p := &expressionParser{input: s}
+	e, err := p.parseSum()
+	if err != nil {
+		return nil, nil, err
+	}
+	p.skipSpaces()
+	if p.pos < len(p.input) {
+		return nil, nil, fmt.Errorf("unexpected %q at position %d", p.input[p.pos], p.pos)
+	}
+	return e, p.variables, nil
+}
+
+type expressionParser struct {
+	input     string
+	pos       int
+	variables []string
+}
+
+func (p *expressionParser) skipSpaces() {
+	for p.pos < len(p.input) && unicode.IsSpace(rune(p.input[p.pos])) {
+		p.pos++
+	}
+}
+
+// peek returns the next character after spaces, or 0 at the end of the input.
+func (p *expressionParser) peek() byte {
+	p.skipSpaces()
+	if p.pos < len(p.input) {
+		return p.input[p.pos]
+	}
+	return 0
+}
+
+func (p *expressionParser) parseSum() (expression, error) {
+	left, err := p.parseProduct()
+	if err != nil {
+		return nil, err
+	}
+	for op := p.peek(); op == '+' || op == '-'; op = p.peek() {
+		p.pos++
+		right, err := p.parseProduct()
+		if err != nil {
+			return nil, err
+		}
+		left = binaryExpression{operator: op, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *expressionParser) parseProduct() (expression, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for op := p.peek(); op == '*' || op == '/'; op = p.peek() {
+		p.pos++
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = binaryExpression{operator: op, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *expressionParser) parseUnary() (expression, error) {
+	if p.peek() == '-' {
+		p.pos++
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return negateExpression{operand: operand}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *expressionParser) parsePrimary() (expression, error) {
+	c := p.peek()
+	switch {
+	case c == 0:
+		return nil, errors.New("unexpected end of expression")
+	case c == '(':
+		p.pos++
+		e, err := p.parseSum()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ')' {
+			return nil, fmt.Errorf("missing closing parenthesis at position %d", p.pos)
+		}
+		p.pos++
+		return e, nil
+	case c == '"':
+		end := strings.IndexByte(p.input[p.pos+1:], '"')
+		if end < 0 {
+			return nil, fmt.Errorf("missing closing quote at position %d", p.pos)
+		}
+		name := p.input[p.pos+1 : p.pos+1+end]
+		if name == "" {
+			return nil, fmt.Errorf("empty operand name at position %d", p.pos)
+		}
+		p.pos += end + 2
+		return p.variable(name), nil
+	case c == '.' || isDigit(c):
+		start := p.pos
+		for p.pos < len(p.input) && (isDigit(p.input[p.pos]) || p.input[p.pos] == '.') {
+			p.pos++
+		}
+		v, err := strconv.ParseFloat(p.input[start:p.pos], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q at position %d", p.input[start:p.pos], start)
+		}
+		return numberExpression(v), nil
+	case isNameStart(c):
+		start := p.pos
+		for p.pos < len(p.input) && (isNameStart(p.input[p.pos]) || isDigit(p.input[p.pos]) || p.input[p.pos] == '.') {
+			p.pos++
+		}
+		return p.variable(p.input[start:p.pos]), nil
+	default:
+		return nil, fmt.Errorf("unexpected %q at position %d", c, p.pos)
+	}
+}
+
+func (p *expressionParser) variable(name string) expression {
+	for _, v := range p.variables {
+		if v == name {
+			return variableExpression(name)
+		}
+	}
+	p.variables = append(p.variables, name)
+	return variableExpression(name)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
@@ -0,0 +1,249 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metricsgenerationprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/metricsgenerationprocessor"
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+)
+
+// operand is an operand of an expression, resolved from the inputs of its rule.
+type operand struct {
+	name       string
+	metric     string
+	attributes map[string]string
+}
+
+// operandPoint is the data point giving the value of an operand for an attribute set.
+type operandPoint struct {
+	value float64
+	point pmetric.NumberDataPoint
+}
+
+// operandValues are the values of an operand, by attribute set.
+type operandValues struct {
+	points map[string]operandPoint
+	// scalar is true when the operand has a single data point without attributes,
+	// which is joined with every attribute set.
+	scalar bool
+}
+
+func (v *operandValues) lookup(key string) (operandPoint, bool) {
+	if v.scalar {
+		key = ""
+	}
+	p, ok := v.points[key]
+	return p, ok
+}
+
+// buildOperands resolves the operands of an expression: each operand is either an input
+// or, when no input has its name, the metric with that name.
+func buildOperands(variables []string, inputs []Input) []operand {
+	operands := make([]operand, len(variables))
+	for i, name := range variables {
+		operands[i] = operand{name: name, metric: name}
+		for _, input := range inputs {
+			if input.name() == name {
+				operands[i] = operand{name: name, metric: input.Metric, attributes: input.Attributes}
+				break
+			}
+		}
+	}
+	return operands
+}
+
+type metricWithScope struct {
+	metric pmetric.Metric
+	scope  pmetric.ScopeMetrics
+}
+
+func getNameToMetricWithScopeMap(rm pmetric.ResourceMetrics) map[string]metricWithScope {
+	ilms := rm.ScopeMetrics()
+	metricMap := make(map[string]metricWithScope)
+
+	for i := 0; i < ilms.Len(); i++ {
+		ilm := ilms.At(i)
+		metricSlice := ilm.Metrics()
+		for j := 0; j < metricSlice.Len(); j++ {
+			metric := metricSlice.At(j)
+			metricMap[metric.Name()] = metricWithScope{metric: metric, scope: ilm}
+		}
+	}
+	return metricMap
+}
+
+// collectOperandValues returns the values of the data points of metric having the attribute
+// values of op, keyed by their other attributes. Of the data points with the same attributes,
+// the latest one is used.
+func collectOperandValues(metric pmetric.Metric, op operand) (*operandValues, error) {
+	var dataPoints pmetric.NumberDataPointSlice
+	switch metricType := metric.Type(); metricType {
+	case pmetric.MetricTypeGauge:
+		dataPoints = metric.Gauge().DataPoints()
+	case pmetric.MetricTypeSum:
+		dataPoints = metric.Sum().DataPoints()
+	default:
+		return nil, fmt.Errorf("Calculations are only supported on gauge or sum metric types. Given metric '%s' is of type `%s`", metric.Name(), metricType.String())
+	}
+
+	values := &operandValues{points: map[string]operandPoint{}}
+	for i := 0; i < dataPoints.Len(); i++ {
+		dp := dataPoints.At(i)
+		if !matchAttributes(dp.Attributes(), op.attributes) {
+			continue
+		}
+		key := attributesKey(dp.Attributes(), op.attributes)
+		if existing, ok := values.points[key]; ok && existing.point.Timestamp() > dp.Timestamp() {
+			continue
+		}
+		var value float64
+		switch dp.ValueType() {
+		case pmetric.NumberDataPointValueTypeDouble:
+			value = dp.DoubleValue()
+		case pmetric.NumberDataPointValueTypeInt:
+			value = float64(dp.IntValue())
+		}
+		values.points[key] = operandPoint{value: value, point: dp}
+	}
+	_, hasEmptyKey := values.points[""]
+	values.scalar = len(values.points) == 1 && hasEmptyKey
+	return values, nil
+}
+
+func matchAttributes(attrs pcommon.Map, filter map[string]string) bool {
+	for k, v := range filter {
+		attr, ok := attrs.Get(k)
+		if !ok || attr.AsString() != v {
+			return false
+		}
+	}
+	return true
+}
+
+// attributesKey identifies an attribute set, ignoring the filtered attributes.
+func attributesKey(attrs pcommon.Map, filter map[string]string) string {
+	pairs := make([]string, 0, attrs.Len())
+	attrs.Range(func(k string, v pcommon.Value) bool {
+		if _, ok := filter[k]; !ok {
+			pairs = append(pairs, k+"\x00"+v.AsString())
+		}
+		return true
+	})
+	sort.Strings(pairs)
+	return strings.Join(pairs, "\x00")
+}
+
+// generateExpressionMetric evaluates the expression of rule for each attribute set of the
+// joined operands, and adds the new metric to the scope of the metric of the first operand.
+func generateExpressionMetric(rm pmetric.ResourceMetrics, rule internalRule, logger *zap.Logger) {
+	nameToMetricMap := getNameToMetricWithScopeMap(rm)
+
+	values := make([]*operandValues, len(rule.operands))
+	for i, op := range rule.operands {
+		m, ok := nameToMetricMap[op.metric]
+		if !ok {
+			if rule.join == leftJoin && i > 0 {
+				continue
+			}
+			logger.Debug("Missing metric", zap.String("metric_name", op.metric))
+			return
+		}
+		v, err := collectOperandValues(m.metric, op)
+		if err != nil {
+			logger.Debug(err.Error())
+			return
+		}
+		values[i] = v
+	}
+
+	// The data points are generated for the attribute sets of the base operand.
+	base := 0
+	if rule.join != leftJoin {
+		for i, v := range values {
+			if !v.scalar {
+				base = i
+				break
+			}
+		}
+	}
+	keys := make([]string, 0, len(values[base].points))
+	for key := range values[base].points {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	newMetric := pmetric.NewMetric()
+	var dataPoints pmetric.NumberDataPointSlice
+	if rule.output.Type == sumOutput {
+		sum := newMetric.SetEmptySum()
+		sum.SetIsMonotonic(rule.output.Monotonic)
+		if rule.output.Temporality == deltaTemporality {
+			sum.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+		} else {
+			sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		}
+		dataPoints = sum.DataPoints()
+	} else {
+		dataPoints = newMetric.SetEmptyGauge().DataPoints()
+	}
+
+	for _, key := range keys {
+		basePoint := values[base].points[key].point
+		timestamp, startTimestamp := basePoint.Timestamp(), basePoint.StartTimestamp()
+		operandValues := make(map[string]float64, len(values))
+		joined := true
+		for i, v := range values {
+			name := rule.operands[i].name
+			var p operandPoint
+			found := false
+			if v != nil {
+				p, found = v.lookup(key)
+			}
+			if !found {
+				if rule.join == leftJoin {
+					operandValues[name] = rule.defaultValue
+					continue
+				}
+				joined = false
+				break
+			}
+			operandValues[name] = p.value
+			if p.point.Timestamp() > timestamp {
+				timestamp = p.point.Timestamp()
+			}
+			if start := p.point.StartTimestamp(); start != 0 && (startTimestamp == 0 || start < startTimestamp) {
+				startTimestamp = start
+			}
+		}
+		if !joined {
+			continue
+		}
+
+		value, err := rule.expression.eval(operandValues)
+		if err != nil {
+			logger.Debug(fmt.Sprintf("Failed to evaluate the expression of metric %s: %s", rule.name, err))
+			continue
+		}
+
+		dp := dataPoints.AppendEmpty()
+		basePoint.Attributes().CopyTo(dp.Attributes())
+		dp.Attributes().RemoveIf(func(k string, _ pcommon.Value) bool {
+			_, filtered := rule.operands[base].attributes[k]
+			return filtered
+		})
+		dp.SetTimestamp(timestamp)
+		dp.SetStartTimestamp(startTimestamp)
+		dp.SetDoubleValue(value)
+	}
+
+	// Only create a new metric if valid data points were calculated successfully
+	if dataPoints.Len() > 0 {
+		appendMetric(nameToMetricMap[rule.operands[0].metric].scope, newMetric, rule.name, rule.unit)
+	}
+}
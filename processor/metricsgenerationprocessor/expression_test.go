@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metricsgenerationprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseExpression(t *testing.T) {
+	values := map[string]float64{
+		"disk.io.read":    30,
+		"disk.io.write":   10,
+		"system.cpu.time": 8,
+		"metric-1":        2,
+	}
+	tests := []struct {
+		expression string
+		variables  []string
+		expected   float64
+	}{
+		{
+			expression: "(disk.io.read + disk.io.write) / system.cpu.time * 100",
+			variables:  []string{"disk.io.read", "disk.io.write", "system.cpu.time"},
+			expected:   500,
+		},
+		{
+			expression: "disk.io.read - disk.io.write - 5",
+			variables:  []string{"disk.io.read", "disk.io.write"},
+			expected:   15,
+		},
+		{
+			expression: "disk.io.read + disk.io.write * 2",
+			variables:  []string{"disk.io.read", "disk.io.write"},
+			expected:   50,
+		},
+		{
+			expression: "-disk.io.write + disk.io.write / 4 + .5",
+			variables:  []string{"disk.io.write"},
+			expected:   -7,
+		},
+		{
+			expression: `"metric-1" * 1.5`,
+			variables:  []string{"metric-1"},
+			expected:   3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			e, variables, err := parseExpression(tt.expression)
+			require.NoError(t, err)
+			assert.Equal(t, tt.variables, variables)
+			v, err := e.eval(values)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, v)
+		})
+	}
+}
+
+func TestParseExpressionErrors(t *testing.T) {
+	tests := []struct {
+		expression   string
+		errorMessage string
+	}{
+		{expression: "", errorMessage: "unexpected end of expression"},
+		{expression: "a +", errorMessage: "unexpected end of expression"},
+		{expression: "(a + b", errorMessage: "missing closing parenthesis at position 6"},
+		{expression: "a b", errorMessage: `unexpected 'b' at position 2`},
+		{expression: `"a + b`, errorMessage: "missing closing quote at position 0"},
+		{expression: `"" + b`, errorMessage: "empty operand name at position 0"},
+		{expression: "1.2.3", errorMessage: `invalid number "1.2.3" at position 0`},
+		{expression: "a % b", errorMessage: `unexpected '%' at position 2`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			_, _, err := parseExpression(tt.expression)
+			assert.EqualError(t, err, tt.errorMessage)
+		})
+	}
+}
+
+func TestEvalDivideByZero(t *testing.T) {
+	e, _, err := parseExpression("a / (b - 1)")
+	require.NoError(t, err)
+	_, err = e.eval(map[string]float64{"a": 1, "b": 1})
+	assert.ErrorIs(t, err, errDivideByZero)
+}
@@ -53,9 +53,9 @@ func createMetricsProcessor(
 
 // buildInternalConfig constructs the internal metric generation rules
 func buildInternalConfig(config *Config) []internalRule {
-	internalRules := make([]internalRule, len(config.Rules))
+	internalRules := make([]internalRule, 0, len(config.Rules))
 
-	for i, rule := range config.Rules {
+	for _, rule := range config.Rules {
 		customRule := internalRule{
 			name:      rule.Name,
 			unit:      rule.Unit,
@@ -65,7 +65,19 @@ func buildInternalConfig(config *Config) []internalRule {
 			operation: string(rule.Operation),
 			scaleBy:   rule.ScaleBy,
 		}
-		internalRules[i] = customRule
+		if rule.Type == expressionType {
+			expr, variables, err := parseExpression(rule.Expression)
+			if err != nil {
+				// Invalid expressions are rejected when validating the config
+				continue
+			}
+			customRule.expression = expr
+			customRule.operands = buildOperands(variables, rule.Inputs)
+			customRule.join = rule.Join
+			customRule.defaultValue = rule.DefaultValue
+			customRule.output = rule.Output
+		}
+		internalRules = append(internalRules, customRule)
 	}
 	return internalRules
 }
@@ -24,6 +24,13 @@ type internalRule struct {
 	metric2   string
 	operation string
 	scaleBy   float64
+
+	// Fields of the expression rules
+	expression   expression
+	operands     []operand
+	join         JoinType
+	defaultValue float64
+	output       Output
 }
 
 func newMetricsGenerationProcessor(rules []internalRule, logger *zap.Logger) *metricsGenerationProcessor {
@@ -47,6 +54,11 @@ func (mgp *metricsGenerationProcessor) processMetrics(_ context.Context, md pmet
 		nameToMetricMap := getNameToMetricMap(rm)
 
 		for _, rule := range mgp.rules {
+			if rule.ruleType == string(expressionType) {
+				generateExpressionMetric(rm, rule, mgp.logger)
+				continue
+			}
+
 			operand2 := float64(0)
 			_, ok := nameToMetricMap[rule.metric1]
 			if !ok {
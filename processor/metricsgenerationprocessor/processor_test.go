@@ -402,6 +402,7 @@ func TestGoldenFileMetrics(t *testing.T) {
 	// result_metric_types: These tests are to ensure the created metric's type is correct
 	// metric2_zero_value: These tests are to ensure metrics are created properly when the second metric's (metric2)
 	// value is 0.
+	// expression_metrics: These tests are to ensure expressions are evaluated on the joined data points of their inputs.
 	testCaseNames := []goldenTestCases{
 		{
 			name:    "sum_gauge_metric",
@@ -475,6 +476,26 @@ func TestGoldenFileMetrics(t *testing.T) {
 			name:    "metric2_zero_percent",
 			testDir: "metric2_zero_value",
 		},
+		{
+			name:    "expression_inner_join",
+			testDir: "expression_metrics",
+		},
+		{
+			name:    "expression_inner_join_missing_attributes",
+			testDir: "expression_metrics",
+		},
+		{
+			name:    "expression_left_join",
+			testDir: "expression_metrics",
+		},
+		{
+			name:    "expression_missing_metric",
+			testDir: "expression_metrics",
+		},
+		{
+			name:    "expression_sum_output",
+			testDir: "expression_metrics",
+		},
 	}
 
 	for _, testCase := range testCaseNames {
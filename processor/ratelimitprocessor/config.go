@@ -0,0 +1,155 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ratelimitprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/ratelimitprocessor"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+)
+
+// Action defines what the processor does with the telemetry of a tenant exceeding its limits.
+type Action string
+
+const (
+	// ActionDrop drops the telemetry of the tenant.
+	ActionDrop Action = "drop"
+	// ActionSample keeps a random sample of the telemetry of the tenant, fitting its remaining limits.
+	ActionSample Action = "sample"
+	// ActionError returns a retryable error to the receiver, so that clients send the telemetry again later.
+	// As the whole request is refused, it requires the tenant to be determined for the whole request.
+	ActionError Action = "error"
+)
+
+const (
+	defaultReloadInterval = 30 * time.Second
+	defaultMaxTenants     = 10000
+)
+
+// Config defines the configuration for the processor.
+type Config struct {
+	// Tenant defines how the tenant of the telemetry is determined.
+	Tenant TenantConfig `mapstructure:"tenant"`
+
+	// DefaultLimits are the limits of the tenants which don't have limits of their own.
+	DefaultLimits Limits `mapstructure:"default_limits"`
+
+	// Limits are the limits of the tenants, by tenant.
+	Limits map[string]Limits `mapstructure:"limits"`
+
+	// LimitsFile is the path of a YAML file holding `default_limits` and `limits`, which take precedence
+	// over the ones of the configuration. The file is reloaded when it changes.
+	LimitsFile string `mapstructure:"limits_file"`
+
+	// ReloadInterval is the interval at which the limits file is checked for changes,
+	// and the state of the idle tenants is released.
+	ReloadInterval time.Duration `mapstructure:"reload_interval"`
+
+	// MaxTenants is the number of tenants whose state is tracked. Once reached, the new tenants without
+	// limits of their own share the default limits until idle tenants are released.
+	MaxTenants int `mapstructure:"max_tenants"`
+
+	// Action is what the processor does with the telemetry of a tenant exceeding its limits:
+	// `drop` (default), `sample` or `error`.
+	Action Action `mapstructure:"action"`
+}
+
+// TenantConfig defines how the tenant of the telemetry is determined. Exactly one of
+// FromContext, FromAuth and Expression must be set.
+type TenantConfig struct {
+	// FromContext is the key of the client metadata holding the tenant, e.g. X-Scope-OrgID.
+	// Receivers must be configured with `include_metadata: true` for it to be available.
+	FromContext string `mapstructure:"from_context"`
+
+	// FromAuth is the attribute holding the tenant, set by the authenticator extension of the receiver.
+	FromAuth string `mapstructure:"from_auth"`
+
+	// Expression is an OTTL value expression on the resource context providing the tenant,
+	// e.g. attributes["tenant.id"].
+	Expression string `mapstructure:"expression"`
+
+	// Default is the tenant of the telemetry for which the tenant cannot be determined.
+	Default string `mapstructure:"default"`
+}
+
+// Limits are the limits of a tenant, enforced by token buckets. Zero rates are not limited.
+type Limits struct {
+	// ItemsPerSecond is the number of spans, log records or data points accepted per second.
+	ItemsPerSecond float64 `mapstructure:"items_per_second"`
+	// ItemsBurst is the number of items that can be accepted at once, defaults to ItemsPerSecond.
+	ItemsBurst float64 `mapstructure:"items_burst"`
+	// BytesPerSecond is the size of the telemetry accepted per second, in its protobuf encoding.
+	BytesPerSecond float64 `mapstructure:"bytes_per_second"`
+	// BytesBurst is the size of the telemetry that can be accepted at once, defaults to BytesPerSecond.
+	BytesBurst float64 `mapstructure:"bytes_burst"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	var errs error
+
+	sources := 0
+	for _, source := range []string{cfg.Tenant.FromContext, cfg.Tenant.FromAuth, cfg.Tenant.Expression} {
+		if source != "" {
+			sources++
+		}
+	}
+	if sources != 1 {
+		errs = errors.Join(errs, errors.New("tenant: exactly one of from_context, from_auth and expression must be set"))
+	}
+	if cfg.Tenant.Expression != "" {
+		if _, err := newResourceExpression(cfg.Tenant.Expression, component.TelemetrySettings{Logger: zap.NewNop()}); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("tenant: expression: %w", err))
+		}
+	}
+
+	if err := cfg.DefaultLimits.validate(); err != nil {
+		errs = errors.Join(errs, fmt.Errorf("default_limits: %w", err))
+	}
+	for tenant, limits := range cfg.Limits {
+		if err := limits.validate(); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("limits: tenant %q: %w", tenant, err))
+		}
+	}
+
+	if cfg.ReloadInterval <= 0 {
+		errs = errors.Join(errs, errors.New("reload_interval must be positive"))
+	}
+	if cfg.MaxTenants <= 0 {
+		errs = errors.Join(errs, errors.New("max_tenants must be positive"))
+	}
+
+	switch cfg.Action {
+	case ActionDrop, ActionSample:
+	case ActionError:
+		if cfg.Tenant.Expression != "" {
+			errs = errors.Join(errs, fmt.Errorf("action %q requires the tenant to be set from_context or from_auth, since requests are refused as a whole", ActionError))
+		}
+	default:
+		errs = errors.Join(errs, fmt.Errorf("unsupported action %q, must be %q, %q or %q", cfg.Action, ActionDrop, ActionSample, ActionError))
+	}
+	return errs
+}
+
+func (l Limits) validate() error {
+	var errs error
+	if l.ItemsPerSecond < 0 {
+		errs = errors.Join(errs, errors.New("items_per_second must not be negative"))
+	}
+	if l.ItemsBurst < 0 {
+		errs = errors.Join(errs, errors.New("items_burst must not be negative"))
+	}
+	if l.BytesPerSecond < 0 {
+		errs = errors.Join(errs, errors.New("bytes_per_second must not be negative"))
+	}
+	if l.BytesBurst < 0 {
+		errs = errors.Join(errs, errors.New("bytes_burst must not be negative"))
+	}
+	return errs
+}
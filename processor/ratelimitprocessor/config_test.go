@@ -0,0 +1,121 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ratelimitprocessor
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/ratelimitprocessor/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		id           component.ID
+		expected     component.Config
+		errorMessage string
+	}{
+		{
+			id:           component.NewIDWithName(metadata.Type, ""),
+			errorMessage: "tenant: exactly one of from_context, from_auth and expression must be set",
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "full"),
+			expected: &Config{
+				Tenant: TenantConfig{
+					FromContext: "X-Scope-OrgID",
+					Default:     "anonymous",
+				},
+				DefaultLimits: Limits{
+					ItemsPerSecond: 1000,
+					BytesPerSecond: 1048576,
+				},
+				Limits: map[string]Limits{
+					"acme": {
+						ItemsPerSecond: 10000,
+						ItemsBurst:     20000,
+						BytesPerSecond: 10485760,
+						BytesBurst:     20971520,
+					},
+				},
+				LimitsFile:     "/etc/otelcol/limits.yaml",
+				ReloadInterval: time.Minute,
+				MaxTenants:     1000,
+				Action:         ActionSample,
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "expression"),
+			expected: &Config{
+				Tenant: TenantConfig{
+					Expression: `attributes["tenant.id"]`,
+				},
+				ReloadInterval: defaultReloadInterval,
+				MaxTenants:     defaultMaxTenants,
+				Action:         ActionSample,
+			},
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "error_with_expression"),
+			errorMessage: `action "error" requires the tenant to be set from_context or from_auth, since requests are refused as a whole`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "missing_tenant"),
+			errorMessage: "tenant: exactly one of from_context, from_auth and expression must be set",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "multiple_tenant_sources"),
+			errorMessage: "tenant: exactly one of from_context, from_auth and expression must be set",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_expression"),
+			errorMessage: `tenant: expression: value expression has invalid syntax: 1:12: unexpected token "<EOF>" (expected (<string> | <int>) "]")`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "negative_limits"),
+			errorMessage: "default_limits: items_per_second must not be negative\n" + `limits: tenant "acme": bytes_burst must not be negative`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_action"),
+			errorMessage: `unsupported action "block", must be "drop", "sample" or "error"`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_reload_interval"),
+			errorMessage: "reload_interval must be positive",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_max_tenants"),
+			errorMessage: "max_tenants must be positive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+			require.NoError(t, err)
+
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, sub.Unmarshal(cfg))
+
+			if tt.expected == nil {
+				assert.EqualError(t, component.ValidateConfig(cfg), tt.errorMessage)
+				return
+			}
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
@@ -0,0 +1,7 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package ratelimitprocessor enforces per-tenant rate limits on the telemetry.
+package ratelimitprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/ratelimitprocessor"
@@ -0,0 +1,258 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ratelimitprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/ratelimitprocessor"
+
+import (
+	"errors"
+	"fmt"
+	"hash/maphash"
+	"os"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/confmap"
+	"gopkg.in/yaml.v3"
+)
+
+// bucket is a token bucket, refilled at rate tokens per second up to burst tokens.
+type bucket struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// newBucket returns a full bucket, or nil if the rate is not limited.
+func newBucket(rate, burst float64, now time.Time) *bucket {
+	if rate == 0 {
+		return nil
+	}
+	if burst == 0 {
+		burst = rate
+	}
+	return &bucket{rate: rate, burst: burst, tokens: burst, last: now}
+}
+
+func (b *bucket) refill(now time.Time) {
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens = min(b.burst, b.tokens+elapsed*b.rate)
+		b.last = now
+	}
+}
+
+// fraction returns the fraction of n that the available tokens cover, at most 1. When the bucket is full,
+// n is covered even if it exceeds the burst: its tokens are taken into debt, repaid by the next refills,
+// so that batches larger than the burst are accepted at the rate instead of never being.
+func (b *bucket) fraction(now time.Time, n int) float64 {
+	if b == nil || n == 0 {
+		return 1
+	}
+	b.refill(now)
+	if b.tokens >= b.burst {
+		return 1
+	}
+	return max(0, min(1, b.tokens/float64(n)))
+}
+
+// take removes n tokens from the bucket.
+func (b *bucket) take(n float64) {
+	if b != nil {
+		b.tokens -= n
+	}
+}
+
+func (b *bucket) full(now time.Time) bool {
+	if b == nil {
+		return true
+	}
+	b.refill(now)
+	return b.tokens >= b.burst
+}
+
+// tenantState holds the token buckets of a tenant.
+type tenantState struct {
+	items *bucket
+	bytes *bucket
+}
+
+func newTenantState(limits Limits, now time.Time) *tenantState {
+	return &tenantState{
+		items: newBucket(limits.ItemsPerSecond, limits.ItemsBurst, now),
+		bytes: newBucket(limits.BytesPerSecond, limits.BytesBurst, now),
+	}
+}
+
+// fraction returns the fraction of the items and bytes that the limits of the tenant allow to accept.
+func (s *tenantState) fraction(now time.Time, items, bytes int) float64 {
+	return min(s.items.fraction(now, items), s.bytes.fraction(now, bytes))
+}
+
+func (s *tenantState) take(items, bytes float64) {
+	s.items.take(items)
+	s.bytes.take(bytes)
+}
+
+// withLimits returns the state of the tenant with new limits, keeping the tokens it consumed,
+// so that reloading doesn't refill its buckets.
+func (s *tenantState) withLimits(limits Limits, now time.Time) *tenantState {
+	next := newTenantState(limits, now)
+	if s.items != nil && next.items != nil {
+		s.items.refill(now)
+		next.items.tokens = min(next.items.burst, s.items.tokens)
+	}
+	if s.bytes != nil && next.bytes != nil {
+		s.bytes.refill(now)
+		next.bytes.tokens = min(next.bytes.burst, s.bytes.tokens)
+	}
+	return next
+}
+
+func (s *tenantState) full(now time.Time) bool {
+	return s.items.full(now) && s.bytes.full(now)
+}
+
+// overflowTenant is the tenant under which the telemetry of the tenants sharing the overflow states is reported.
+const overflowTenant = "_overflow"
+
+// overflowStates is the number of states shared by the untracked tenants, which are spread among them by
+// the hash of their name, so that a noisy tenant only affects the few tenants sharing its state.
+const overflowStates = 64
+
+// limiter holds the limits and the state of the tenants.
+type limiter struct {
+	mu            sync.Mutex
+	defaultLimits Limits
+	limits        map[string]Limits
+	maxTenants    int
+	tenants       map[string]*tenantState
+	// overflow holds the states shared by the new tenants once maxTenants tenants are tracked,
+	// limited by the default limits, by the hash of the tenant.
+	overflow [overflowStates]*tenantState
+	seed     maphash.Seed
+	now      func() time.Time
+}
+
+func newLimiter(defaultLimits Limits, limits map[string]Limits, maxTenants int) *limiter {
+	return &limiter{
+		defaultLimits: defaultLimits,
+		limits:        limits,
+		maxTenants:    maxTenants,
+		tenants:       make(map[string]*tenantState),
+		seed:          maphash.MakeSeed(),
+		now:           time.Now,
+	}
+}
+
+// tenant returns the state of the tenant, or one of the overflow states, and true, if the tenant is not
+// tracked yet, maxTenants tenants already are and it has no limits of its own. The lock must be held.
+func (l *limiter) tenant(name string) (*tenantState, bool) {
+	s, ok := l.tenants[name]
+	if ok {
+		return s, false
+	}
+	if _, configured := l.limits[name]; !configured && len(l.tenants) >= l.maxTenants {
+		i := maphash.String(l.seed, name) % overflowStates
+		if l.overflow[i] == nil {
+			l.overflow[i] = newTenantState(l.defaultLimits, l.now())
+		}
+		return l.overflow[i], true
+	}
+	s = newTenantState(l.limitsOf(name), l.now())
+	l.tenants[name] = s
+	return s, false
+}
+
+func (l *limiter) limitsOf(tenant string) Limits {
+	if limits, ok := l.limits[tenant]; ok {
+		return limits
+	}
+	return l.defaultLimits
+}
+
+// setLimits replaces the limits of the tenants, rebuilding the buckets of the known tenants with their new limits.
+func (l *limiter) setLimits(defaultLimits Limits, limits map[string]Limits) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.defaultLimits = defaultLimits
+	l.limits = limits
+	now := l.now()
+	for name, s := range l.tenants {
+		l.tenants[name] = s.withLimits(l.limitsOf(name), now)
+	}
+	for i, s := range l.overflow {
+		if s != nil {
+			l.overflow[i] = s.withLimits(defaultLimits, now)
+		}
+	}
+}
+
+// sweep releases the state of the tenants whose buckets are full, which is the same as the one of new tenants.
+func (l *limiter) sweep() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	for name, s := range l.tenants {
+		if s.full(now) {
+			delete(l.tenants, name)
+		}
+	}
+	for i, s := range l.overflow {
+		if s != nil && s.full(now) {
+			l.overflow[i] = nil
+		}
+	}
+}
+
+// limitsFile is the content of a limits file.
+type limitsFile struct {
+	DefaultLimits *Limits           `mapstructure:"default_limits"`
+	Limits        map[string]Limits `mapstructure:"limits"`
+}
+
+// loadLimitsFile reads and validates the limits file at path.
+func loadLimitsFile(path string) (*limitsFile, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var raw map[string]any
+	if err = yaml.Unmarshal(content, &raw); err != nil {
+		return nil, err
+	}
+	lf := &limitsFile{}
+	if err = confmap.NewFromStringMap(raw).Unmarshal(lf); err != nil {
+		return nil, err
+	}
+
+	var errs error
+	if lf.DefaultLimits != nil {
+		if err = lf.DefaultLimits.validate(); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("default_limits: %w", err))
+		}
+	}
+	for tenant, limits := range lf.Limits {
+		if err = limits.validate(); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("limits: tenant %q: %w", tenant, err))
+		}
+	}
+	if errs != nil {
+		return nil, errs
+	}
+	return lf, nil
+}
+
+// merge returns the limits of the configuration overridden by the ones of the file.
+func (lf *limitsFile) merge(defaultLimits Limits, limits map[string]Limits) (Limits, map[string]Limits) {
+	if lf.DefaultLimits != nil {
+		defaultLimits = *lf.DefaultLimits
+	}
+	merged := make(map[string]Limits, len(limits)+len(lf.Limits))
+	for tenant, l := range limits {
+		merged[tenant] = l
+	}
+	for tenant, l := range lf.Limits {
+		merged[tenant] = l
+	}
+	return defaultLimits, merged
+}
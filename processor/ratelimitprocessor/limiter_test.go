@@ -0,0 +1,157 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ratelimitprocessor
+
+import (
+	"hash/maphash"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBucket(t *testing.T) {
+	now := time.Now()
+	b := newBucket(10, 20, now)
+	assert.Equal(t, 1.0, b.fraction(now, 20))
+	assert.True(t, b.full(now))
+
+	b.take(10)
+	assert.Equal(t, 0.5, b.fraction(now, 20))
+	b.take(10)
+	assert.Equal(t, 0.0, b.fraction(now, 10))
+	assert.False(t, b.full(now))
+
+	// Tokens are refilled at the rate, up to the burst.
+	assert.Equal(t, 0.5, b.fraction(now.Add(500*time.Millisecond), 10))
+	assert.Equal(t, 1.0, b.fraction(now.Add(time.Second), 10))
+	assert.True(t, b.full(now.Add(time.Hour)))
+}
+
+func TestBucketDebt(t *testing.T) {
+	now := time.Now()
+	b := newBucket(10, 20, now)
+
+	// A batch larger than the burst is accepted when the bucket is full, its tokens being taken into debt.
+	assert.Equal(t, 1.0, b.fraction(now, 40))
+	b.take(40)
+	assert.Equal(t, 0.0, b.fraction(now.Add(2*time.Second), 10), "the debt is repaid first")
+	assert.Equal(t, 1.0, b.fraction(now.Add(3*time.Second), 10))
+	assert.True(t, b.full(now.Add(4*time.Second)))
+}
+
+func TestBucketDefaultBurst(t *testing.T) {
+	now := time.Now()
+	assert.Nil(t, newBucket(0, 100, now), "zero rates are not limited")
+	var unlimited *bucket
+	assert.Equal(t, 1.0, unlimited.fraction(now, 1_000_000))
+
+	b := newBucket(10, 0, now)
+	assert.Equal(t, 10.0, b.burst)
+	assert.Equal(t, 10.0, b.tokens)
+}
+
+func TestLimiter(t *testing.T) {
+	now := time.Now()
+	l := newLimiter(Limits{ItemsPerSecond: 10}, map[string]Limits{"acme": {ItemsPerSecond: 100, BytesPerSecond: 1000}}, 10)
+	l.now = func() time.Time { return now }
+
+	assert.Equal(t, 1.0, stateOf(l, "other").fraction(now, 20, 1_000_000))
+	assert.Equal(t, 1.0, stateOf(l, "acme").fraction(now, 20, 1000))
+	stateOf(l, "acme").take(0, 500)
+	assert.Equal(t, 0.5, stateOf(l, "acme").fraction(now, 20, 1000))
+
+	stateOf(l, "acme").take(80, 500)
+	l.setLimits(Limits{ItemsPerSecond: 10}, map[string]Limits{"acme": {ItemsPerSecond: 50}})
+	acme := stateOf(l, "acme")
+	assert.Equal(t, 20.0, acme.items.tokens, "consumed tokens are kept across limit changes")
+	assert.Nil(t, acme.bytes)
+
+	l.sweep()
+	assert.Contains(t, l.tenants, "acme")
+	assert.NotContains(t, l.tenants, "other")
+
+	now = now.Add(time.Second)
+	l.sweep()
+	assert.Empty(t, l.tenants)
+}
+
+func TestLimiterMaxTenants(t *testing.T) {
+	now := time.Now()
+	l := newLimiter(Limits{ItemsPerSecond: 10}, map[string]Limits{"acme": {ItemsPerSecond: 100}}, 2)
+	l.now = func() time.Time { return now }
+
+	stateOf(l, "a").take(10, 0)
+	stateOf(l, "b").take(10, 0)
+	assert.Len(t, l.tenants, 2)
+
+	// New tenants share the overflow states by the hash of their name, except the ones with limits of their own.
+	overflow, isOverflow := l.tenant("c")
+	assert.True(t, isOverflow)
+	assert.Equal(t, 10.0, overflow.items.burst)
+	same, other := overflowPeers(l, "c")
+	assert.Same(t, overflow, stateOf(l, same))
+	assert.NotSame(t, overflow, stateOf(l, other))
+	_, isOverflow = l.tenant("acme")
+	assert.False(t, isOverflow)
+	assert.Len(t, l.tenants, 3)
+	overflow.take(10, 0)
+	assert.Equal(t, 10.0, stateOf(l, other).items.tokens, "tenants of other overflow states are not affected")
+
+	l.setLimits(Limits{ItemsPerSecond: 20}, map[string]Limits{"acme": {ItemsPerSecond: 100}})
+	assert.Equal(t, 0.0, stateOf(l, "c").items.tokens, "consumed tokens of the overflow states are kept across limit changes")
+	assert.Equal(t, 20.0, stateOf(l, "c").items.burst)
+
+	now = now.Add(time.Minute)
+	l.sweep()
+	assert.Empty(t, l.tenants)
+	assert.Equal(t, [overflowStates]*tenantState{}, l.overflow)
+	assert.NotSame(t, overflow, stateOf(l, "c"))
+	assert.Contains(t, l.tenants, "c")
+}
+
+func stateOf(l *limiter, tenant string) *tenantState {
+	s, _ := l.tenant(tenant)
+	return s
+}
+
+// overflowPeers returns a tenant sharing the overflow state of the given tenant, and one which doesn't.
+func overflowPeers(l *limiter, tenant string) (same, other string) {
+	index := maphash.String(l.seed, tenant) % overflowStates
+	for i := 0; same == "" || other == ""; i++ {
+		candidate := strconv.Itoa(i)
+		switch {
+		case maphash.String(l.seed, candidate)%overflowStates == index:
+			if same == "" {
+				same = candidate
+			}
+		case other == "":
+			other = candidate
+		}
+	}
+	return same, other
+}
+
+func TestLoadLimitsFile(t *testing.T) {
+	lf, err := loadLimitsFile(filepath.Join("testdata", "limits.yaml"))
+	require.NoError(t, err)
+	defaultLimits, limits := lf.merge(Limits{ItemsPerSecond: 10}, map[string]Limits{
+		"acme":   {ItemsPerSecond: 10},
+		"globex": {ItemsPerSecond: 20},
+	})
+	assert.Equal(t, Limits{ItemsPerSecond: 5}, defaultLimits)
+	assert.Equal(t, map[string]Limits{
+		"acme":   {ItemsPerSecond: 100},
+		"globex": {ItemsPerSecond: 20},
+	}, limits)
+
+	_, err = loadLimitsFile(filepath.Join("testdata", "invalid_limits.yaml"))
+	assert.EqualError(t, err, `limits: tenant "acme": items_per_second must not be negative`)
+
+	_, err = loadLimitsFile(filepath.Join("testdata", "missing.yaml"))
+	assert.Error(t, err)
+}
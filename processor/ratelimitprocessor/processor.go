@@ -0,0 +1,405 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ratelimitprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/ratelimitprocessor"
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"os"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/ratelimitprocessor/internal/metadata"
+)
+
+type rateLimitProcessor struct {
+	cfg       *Config
+	logger    *zap.Logger
+	resolver  *tenantResolver
+	limiter   *limiter
+	telemetry *metadata.TelemetryBuilder
+
+	// modTime and size identify the version of the limits file last loaded.
+	modTime time.Time
+	size    int64
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func newRateLimitProcessor(set processor.Settings, cfg *Config) (*rateLimitProcessor, error) {
+	resolver, err := newTenantResolver(cfg.Tenant, set.TelemetrySettings)
+	if err != nil {
+		return nil, err
+	}
+	telemetry, err := metadata.NewTelemetryBuilder(set.TelemetrySettings)
+	if err != nil {
+		return nil, err
+	}
+	return &rateLimitProcessor{
+		cfg:       cfg,
+		logger:    set.Logger,
+		resolver:  resolver,
+		limiter:   newLimiter(cfg.DefaultLimits, cfg.Limits, cfg.MaxTenants),
+		telemetry: telemetry,
+	}, nil
+}
+
+func (p *rateLimitProcessor) start(_ context.Context, _ component.Host) error {
+	if p.cfg.LimitsFile != "" {
+		if _, err := p.reloadLimits(); err != nil {
+			return fmt.Errorf("failed to load limits file: %w", err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p.cancel = cancel
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		ticker := time.NewTicker(p.cfg.ReloadInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if p.cfg.LimitsFile != "" {
+					if reloaded, err := p.reloadLimits(); err != nil {
+						p.logger.Warn("Failed to reload limits file, keeping the previous limits", zap.String("path", p.cfg.LimitsFile), zap.Error(err))
+					} else if reloaded {
+						p.logger.Info("Reloaded limits file", zap.String("path", p.cfg.LimitsFile))
+					}
+				}
+				p.limiter.sweep()
+			}
+		}
+	}()
+	return nil
+}
+
+func (p *rateLimitProcessor) shutdown(context.Context) error {
+	if p.cancel != nil {
+		p.cancel()
+	}
+	p.wg.Wait()
+	return nil
+}
+
+// reloadLimits loads the limits file if it changed since it was last loaded, and reports whether it did.
+func (p *rateLimitProcessor) reloadLimits() (bool, error) {
+	info, err := os.Stat(p.cfg.LimitsFile)
+	if err != nil {
+		return false, err
+	}
+	if info.ModTime().Equal(p.modTime) && info.Size() == p.size {
+		return false, nil
+	}
+	lf, err := loadLimitsFile(p.cfg.LimitsFile)
+	if err != nil {
+		return false, err
+	}
+	p.limiter.setLimits(lf.merge(p.cfg.DefaultLimits, p.cfg.Limits))
+	p.modTime = info.ModTime()
+	p.size = info.Size()
+	return true, nil
+}
+
+// group is the telemetry of a tenant.
+type group[T any] struct {
+	tenant string
+	data   T
+}
+
+// signal provides the operations the processor needs on telemetry of type T.
+type signal[T any] struct {
+	items func(T) int
+	size  func(T) int
+	// sample keeps a random fraction of the items.
+	sample func(T, float64)
+}
+
+// limit enforces the limits of the tenants on the groups, and returns the accepted groups. With the error
+// action, whose tenant is the one of the whole request, the error to return for the refused request.
+func limit[T any](ctx context.Context, p *rateLimitProcessor, groups []group[T], s signal[T]) (accepted []group[T], err error) {
+	items := make([]int, len(groups))
+	bytes := make([]int, len(groups))
+	fractions := make([]float64, len(groups))
+	tenants := make([]string, len(groups))
+	for i, g := range groups {
+		items[i] = s.items(g.data)
+		bytes[i] = s.size(g.data)
+	}
+
+	p.limiter.mu.Lock()
+	now := p.limiter.now()
+	for i, g := range groups {
+		state, overflow := p.limiter.tenant(g.tenant)
+		tenants[i] = g.tenant
+		if overflow {
+			tenants[i] = overflowTenant
+		}
+		// The tokens are taken group by group, as the tenants sharing the overflow state share its tokens.
+		fractions[i] = state.fraction(now, items[i], bytes[i])
+		switch {
+		case fractions[i] >= 1:
+			state.take(float64(items[i]), float64(bytes[i]))
+		case p.cfg.Action == ActionSample:
+			state.take(fractions[i]*float64(items[i]), fractions[i]*float64(bytes[i]))
+		}
+	}
+	p.limiter.mu.Unlock()
+
+	accepted = groups[:0]
+	for i, g := range groups {
+		switch {
+		case fractions[i] >= 1:
+			p.record(ctx, tenants[i], items[i], bytes[i], 0, 0)
+			accepted = append(accepted, g)
+		case p.cfg.Action == ActionSample && fractions[i] > 0:
+			s.sample(g.data, fractions[i])
+			keptItems, keptBytes := s.items(g.data), s.size(g.data)
+			p.record(ctx, tenants[i], keptItems, keptBytes, items[i]-keptItems, bytes[i]-keptBytes)
+			if keptItems > 0 {
+				accepted = append(accepted, g)
+			}
+		default:
+			p.record(ctx, tenants[i], 0, 0, items[i], bytes[i])
+			if p.cfg.Action == ActionError {
+				err = errors.Join(err, fmt.Errorf("tenant %q exceeded its rate limits", tenants[i]))
+			}
+		}
+	}
+	return accepted, err
+}
+
+func (p *rateLimitProcessor) record(ctx context.Context, tenant string, acceptedItems, acceptedBytes, refusedItems, refusedBytes int) {
+	attrs := metric.WithAttributes(attribute.String("tenant", tenant))
+	if acceptedItems > 0 {
+		p.telemetry.ProcessorRatelimitAcceptedItems.Add(ctx, int64(acceptedItems), attrs)
+		p.telemetry.ProcessorRatelimitAcceptedBytes.Add(ctx, int64(acceptedBytes), attrs)
+	}
+	if refusedItems > 0 {
+		p.telemetry.ProcessorRatelimitRefusedItems.Add(ctx, int64(refusedItems), attrs)
+		p.telemetry.ProcessorRatelimitRefusedBytes.Add(ctx, int64(refusedBytes), attrs)
+	}
+}
+
+// tenantOf returns the tenant of the telemetry of a resource.
+func (p *rateLimitProcessor) tenantOf(ctx context.Context, rt resourceTelemetry) string {
+	tenant, err := p.resolver.fromResource(ctx, rt)
+	if err != nil {
+		p.logger.Debug("Failed to evaluate the tenant expression, using the default tenant", zap.Error(err))
+		return p.cfg.Tenant.Default
+	}
+	return tenant
+}
+
+var tracesSignal = signal[ptrace.Traces]{
+	items: func(td ptrace.Traces) int { return td.SpanCount() },
+	size:  (&ptrace.ProtoMarshaler{}).TracesSize,
+	sample: func(td ptrace.Traces, fraction float64) {
+		// Spans are sampled by trace ID, so that the kept traces are complete.
+		threshold := uint64(fraction * math.MaxUint64)
+		td.ResourceSpans().RemoveIf(func(rs ptrace.ResourceSpans) bool {
+			rs.ScopeSpans().RemoveIf(func(ss ptrace.ScopeSpans) bool {
+				ss.Spans().RemoveIf(func(span ptrace.Span) bool {
+					traceID := span.TraceID()
+					return binary.BigEndian.Uint64(traceID[8:]) >= threshold
+				})
+				return ss.Spans().Len() == 0
+			})
+			return rs.ScopeSpans().Len() == 0
+		})
+	},
+}
+
+func (p *rateLimitProcessor) processTraces(ctx context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+	var groups []group[ptrace.Traces]
+	if !p.resolver.perResource() {
+		groups = []group[ptrace.Traces]{{tenant: p.resolver.fromContext(ctx), data: td}}
+	} else {
+		byTenant := map[string]int{}
+		for i := 0; i < td.ResourceSpans().Len(); i++ {
+			rs := td.ResourceSpans().At(i)
+			tenant := p.tenantOf(ctx, rs)
+			index, ok := byTenant[tenant]
+			if !ok {
+				index = len(groups)
+				byTenant[tenant] = index
+				groups = append(groups, group[ptrace.Traces]{tenant: tenant, data: ptrace.NewTraces()})
+			}
+			rs.MoveTo(groups[index].data.ResourceSpans().AppendEmpty())
+		}
+	}
+
+	accepted, err := limit(ctx, p, groups, tracesSignal)
+	if err != nil {
+		return td, err
+	}
+	if len(accepted) == 0 {
+		return td, processorhelper.ErrSkipProcessingData
+	}
+	return mergeTraces(accepted), nil
+}
+
+func mergeTraces(groups []group[ptrace.Traces]) ptrace.Traces {
+	if len(groups) == 1 {
+		return groups[0].data
+	}
+	out := ptrace.NewTraces()
+	for _, g := range groups {
+		g.data.ResourceSpans().MoveAndAppendTo(out.ResourceSpans())
+	}
+	return out
+}
+
+var logsSignal = signal[plog.Logs]{
+	items: func(ld plog.Logs) int { return ld.LogRecordCount() },
+	size:  (&plog.ProtoMarshaler{}).LogsSize,
+	sample: func(ld plog.Logs, fraction float64) {
+		ld.ResourceLogs().RemoveIf(func(rl plog.ResourceLogs) bool {
+			rl.ScopeLogs().RemoveIf(func(sl plog.ScopeLogs) bool {
+				sl.LogRecords().RemoveIf(func(plog.LogRecord) bool {
+					return rand.Float64() >= fraction
+				})
+				return sl.LogRecords().Len() == 0
+			})
+			return rl.ScopeLogs().Len() == 0
+		})
+	},
+}
+
+func (p *rateLimitProcessor) processLogs(ctx context.Context, ld plog.Logs) (plog.Logs, error) {
+	var groups []group[plog.Logs]
+	if !p.resolver.perResource() {
+		groups = []group[plog.Logs]{{tenant: p.resolver.fromContext(ctx), data: ld}}
+	} else {
+		byTenant := map[string]int{}
+		for i := 0; i < ld.ResourceLogs().Len(); i++ {
+			rl := ld.ResourceLogs().At(i)
+			tenant := p.tenantOf(ctx, rl)
+			index, ok := byTenant[tenant]
+			if !ok {
+				index = len(groups)
+				byTenant[tenant] = index
+				groups = append(groups, group[plog.Logs]{tenant: tenant, data: plog.NewLogs()})
+			}
+			rl.MoveTo(groups[index].data.ResourceLogs().AppendEmpty())
+		}
+	}
+
+	accepted, err := limit(ctx, p, groups, logsSignal)
+	if err != nil {
+		return ld, err
+	}
+	if len(accepted) == 0 {
+		return ld, processorhelper.ErrSkipProcessingData
+	}
+	return mergeLogs(accepted), nil
+}
+
+func mergeLogs(groups []group[plog.Logs]) plog.Logs {
+	if len(groups) == 1 {
+		return groups[0].data
+	}
+	out := plog.NewLogs()
+	for _, g := range groups {
+		g.data.ResourceLogs().MoveAndAppendTo(out.ResourceLogs())
+	}
+	return out
+}
+
+var metricsSignal = signal[pmetric.Metrics]{
+	items: func(md pmetric.Metrics) int { return md.DataPointCount() },
+	size:  (&pmetric.ProtoMarshaler{}).MetricsSize,
+	sample: func(md pmetric.Metrics, fraction float64) {
+		md.ResourceMetrics().RemoveIf(func(rm pmetric.ResourceMetrics) bool {
+			rm.ScopeMetrics().RemoveIf(func(sm pmetric.ScopeMetrics) bool {
+				sm.Metrics().RemoveIf(func(m pmetric.Metric) bool {
+					return sampleDataPoints(m, fraction) == 0
+				})
+				return sm.Metrics().Len() == 0
+			})
+			return rm.ScopeMetrics().Len() == 0
+		})
+	},
+}
+
+// sampleDataPoints keeps a random fraction of the data points of the metric, and returns the number of kept data points.
+func sampleDataPoints(m pmetric.Metric, fraction float64) int {
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		m.Gauge().DataPoints().RemoveIf(func(pmetric.NumberDataPoint) bool { return rand.Float64() >= fraction })
+		return m.Gauge().DataPoints().Len()
+	case pmetric.MetricTypeSum:
+		m.Sum().DataPoints().RemoveIf(func(pmetric.NumberDataPoint) bool { return rand.Float64() >= fraction })
+		return m.Sum().DataPoints().Len()
+	case pmetric.MetricTypeHistogram:
+		m.Histogram().DataPoints().RemoveIf(func(pmetric.HistogramDataPoint) bool { return rand.Float64() >= fraction })
+		return m.Histogram().DataPoints().Len()
+	case pmetric.MetricTypeExponentialHistogram:
+		m.ExponentialHistogram().DataPoints().RemoveIf(func(pmetric.ExponentialHistogramDataPoint) bool { return rand.Float64() >= fraction })
+		return m.ExponentialHistogram().DataPoints().Len()
+	case pmetric.MetricTypeSummary:
+		m.Summary().DataPoints().RemoveIf(func(pmetric.SummaryDataPoint) bool { return rand.Float64() >= fraction })
+		return m.Summary().DataPoints().Len()
+	case pmetric.MetricTypeEmpty:
+	}
+	return 0
+}
+
+func (p *rateLimitProcessor) processMetrics(ctx context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	var groups []group[pmetric.Metrics]
+	if !p.resolver.perResource() {
+		groups = []group[pmetric.Metrics]{{tenant: p.resolver.fromContext(ctx), data: md}}
+	} else {
+		byTenant := map[string]int{}
+		for i := 0; i < md.ResourceMetrics().Len(); i++ {
+			rm := md.ResourceMetrics().At(i)
+			tenant := p.tenantOf(ctx, rm)
+			index, ok := byTenant[tenant]
+			if !ok {
+				index = len(groups)
+				byTenant[tenant] = index
+				groups = append(groups, group[pmetric.Metrics]{tenant: tenant, data: pmetric.NewMetrics()})
+			}
+			rm.MoveTo(groups[index].data.ResourceMetrics().AppendEmpty())
+		}
+	}
+
+	accepted, err := limit(ctx, p, groups, metricsSignal)
+	if err != nil {
+		return md, err
+	}
+	if len(accepted) == 0 {
+		return md, processorhelper.ErrSkipProcessingData
+	}
+	return mergeMetrics(accepted), nil
+}
+
+func mergeMetrics(groups []group[pmetric.Metrics]) pmetric.Metrics {
+	if len(groups) == 1 {
+		return groups[0].data
+	}
+	out := pmetric.NewMetrics()
+	for _, g := range groups {
+		g.data.ResourceMetrics().MoveAndAppendTo(out.ResourceMetrics())
+	}
+	return out
+}
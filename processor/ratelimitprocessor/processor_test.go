@@ -0,0 +1,345 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ratelimitprocessor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.opentelemetry.io/collector/processor/processortest"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+)
+
+func newTestProcessor(t *testing.T, cfg *Config) *rateLimitProcessor {
+	if cfg.ReloadInterval == 0 {
+		cfg.ReloadInterval = defaultReloadInterval
+	}
+	if cfg.MaxTenants == 0 {
+		cfg.MaxTenants = defaultMaxTenants
+	}
+	if cfg.Action == "" {
+		cfg.Action = ActionDrop
+	}
+	require.NoError(t, cfg.Validate())
+	p, err := newRateLimitProcessor(processortest.NewNopSettings(), cfg)
+	require.NoError(t, err)
+	now := time.Now()
+	p.limiter.now = func() time.Time { return now }
+	return p
+}
+
+func newTraces(spans int, traceIDs ...[16]byte) ptrace.Traces {
+	td := ptrace.NewTraces()
+	ss := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty()
+	for i := 0; i < spans; i++ {
+		span := ss.Spans().AppendEmpty()
+		span.SetName("span")
+		if i < len(traceIDs) {
+			span.SetTraceID(traceIDs[i])
+		}
+	}
+	return td
+}
+
+func newLogs(records int) plog.Logs {
+	ld := plog.NewLogs()
+	sl := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty()
+	for i := 0; i < records; i++ {
+		sl.LogRecords().AppendEmpty().Body().SetStr("record")
+	}
+	return ld
+}
+
+func contextWithMetadata(tenant string) context.Context {
+	return client.NewContext(context.Background(), client.Info{
+		Metadata: client.NewMetadata(map[string][]string{"X-Scope-OrgID": {tenant}}),
+	})
+}
+
+func TestProcessTracesFromContext(t *testing.T) {
+	p := newTestProcessor(t, &Config{
+		Tenant:        TenantConfig{FromContext: "X-Scope-OrgID", Default: "anonymous"},
+		DefaultLimits: Limits{ItemsPerSecond: 10},
+		Limits:        map[string]Limits{"acme": {ItemsPerSecond: 100}},
+	})
+
+	_, err := p.processTraces(contextWithMetadata("globex"), newTraces(8))
+	require.NoError(t, err)
+	_, err = p.processTraces(contextWithMetadata("globex"), newTraces(8))
+	assert.ErrorIs(t, err, processorhelper.ErrSkipProcessingData, "globex exceeded its limits")
+
+	td, err := p.processTraces(contextWithMetadata("acme"), newTraces(50))
+	require.NoError(t, err)
+	assert.Equal(t, 50, td.SpanCount())
+
+	_, err = p.processTraces(context.Background(), newTraces(8))
+	require.NoError(t, err)
+	_, err = p.processTraces(context.Background(), newTraces(8))
+	assert.ErrorIs(t, err, processorhelper.ErrSkipProcessingData, "requests without a tenant share the default tenant")
+}
+
+type authData map[string]any
+
+func (a authData) GetAttribute(name string) any {
+	return a[name]
+}
+
+func (a authData) GetAttributeNames() []string {
+	names := make([]string, 0, len(a))
+	for name := range a {
+		names = append(names, name)
+	}
+	return names
+}
+
+func TestProcessLogsFromAuth(t *testing.T) {
+	p := newTestProcessor(t, &Config{
+		Tenant:        TenantConfig{FromAuth: "tenant"},
+		DefaultLimits: Limits{BytesPerSecond: 100},
+	})
+	ctx := client.NewContext(context.Background(), client.Info{Auth: authData{"tenant": "acme"}})
+
+	_, err := p.processLogs(ctx, newLogs(1))
+	require.NoError(t, err)
+	_, err = p.processLogs(ctx, newLogs(100))
+	assert.ErrorIs(t, err, processorhelper.ErrSkipProcessingData)
+
+	assert.Contains(t, p.limiter.tenants, "acme")
+}
+
+func TestProcessMetricsExpression(t *testing.T) {
+	p := newTestProcessor(t, &Config{
+		Tenant:        TenantConfig{Expression: `attributes["tenant.id"]`, Default: "unknown"},
+		DefaultLimits: Limits{ItemsPerSecond: 4},
+	})
+
+	md := pmetric.NewMetrics()
+	for _, tenant := range []string{"acme", "globex", "acme", ""} {
+		rm := md.ResourceMetrics().AppendEmpty()
+		if tenant != "" {
+			rm.Resource().Attributes().PutStr("tenant.id", tenant)
+		}
+		dps := rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetEmptyGauge().DataPoints()
+		dps.AppendEmpty().SetIntValue(1)
+		dps.AppendEmpty().SetIntValue(2)
+	}
+
+	// acme has 4 data points, exceeding its remaining tokens, while the others fit their limits.
+	stateOf(p.limiter, "acme").take(1, 0)
+	md, err := p.processMetrics(context.Background(), md)
+	require.NoError(t, err)
+	require.Equal(t, 2, md.ResourceMetrics().Len())
+	tenant, _ := md.ResourceMetrics().At(0).Resource().Attributes().Get("tenant.id")
+	assert.Equal(t, "globex", tenant.Str())
+	assert.Equal(t, 0, md.ResourceMetrics().At(1).Resource().Attributes().Len())
+	assert.Contains(t, p.limiter.tenants, "unknown")
+}
+
+func TestActionSample(t *testing.T) {
+	p := newTestProcessor(t, &Config{
+		Tenant:        TenantConfig{FromContext: "X-Scope-OrgID"},
+		DefaultLimits: Limits{ItemsPerSecond: 100},
+		Action:        ActionSample,
+	})
+	ctx := contextWithMetadata("acme")
+	_, err := p.processTraces(ctx, newTraces(50))
+	require.NoError(t, err)
+
+	// Spans are sampled by trace ID, a quarter of the tokens being available.
+	kept := [16]byte{}
+	dropped := [16]byte{8: 0xff}
+	traceIDs := make([][16]byte, 0, 200)
+	for i := 0; i < 100; i++ {
+		traceIDs = append(traceIDs, kept, dropped)
+	}
+	td, err := p.processTraces(ctx, newTraces(200, traceIDs...))
+	require.NoError(t, err)
+	assert.Equal(t, 100, td.SpanCount())
+	for i := 0; i < td.SpanCount(); i++ {
+		assert.Equal(t, kept, [16]byte(td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(i).TraceID()))
+	}
+
+	_, err = p.processTraces(ctx, newTraces(10))
+	assert.ErrorIs(t, err, processorhelper.ErrSkipProcessingData, "no tokens are left")
+
+	p = newTestProcessor(t, &Config{
+		Tenant:        TenantConfig{FromContext: "X-Scope-OrgID"},
+		DefaultLimits: Limits{ItemsPerSecond: 100},
+		Action:        ActionSample,
+	})
+	_, err = p.processLogs(ctx, newLogs(50))
+	require.NoError(t, err)
+	ld, err := p.processLogs(ctx, newLogs(200))
+	require.NoError(t, err)
+	assert.Greater(t, ld.LogRecordCount(), 0)
+	assert.Less(t, ld.LogRecordCount(), 200)
+}
+
+func TestActionError(t *testing.T) {
+	p := newTestProcessor(t, &Config{
+		Tenant:        TenantConfig{FromContext: "X-Scope-OrgID"},
+		DefaultLimits: Limits{ItemsPerSecond: 10},
+		Action:        ActionError,
+	})
+
+	_, err := p.processLogs(contextWithMetadata("globex"), newLogs(8))
+	require.NoError(t, err)
+
+	// The request exceeding the limits of its tenant is refused as a whole.
+	_, err = p.processLogs(contextWithMetadata("globex"), newLogs(5))
+	assert.EqualError(t, err, `tenant "globex" exceeded its rate limits`)
+	assert.False(t, consumererror.IsPermanent(err))
+
+	// The tokens of acme were not consumed by the refused telemetry.
+	acme, err := p.processLogs(contextWithMetadata("acme"), newLogs(5))
+	require.NoError(t, err)
+	assert.Equal(t, 5, acme.LogRecordCount())
+}
+
+func TestMaxTenants(t *testing.T) {
+	tel := setupTestTelemetry()
+	cfg := &Config{
+		Tenant:         TenantConfig{FromContext: "X-Scope-OrgID"},
+		DefaultLimits:  Limits{ItemsPerSecond: 10},
+		ReloadInterval: defaultReloadInterval,
+		MaxTenants:     1,
+		Action:         ActionDrop,
+	}
+	p, err := newRateLimitProcessor(tel.NewSettings(), cfg)
+	require.NoError(t, err)
+
+	_, err = p.processLogs(contextWithMetadata("acme"), newLogs(8))
+	require.NoError(t, err)
+	// globex and a tenant with the same overflow state share the default limits, and are reported as a single tenant.
+	same, _ := overflowPeers(p.limiter, "globex")
+	_, err = p.processLogs(contextWithMetadata("globex"), newLogs(8))
+	require.NoError(t, err)
+	_, err = p.processLogs(contextWithMetadata(same), newLogs(8))
+	assert.ErrorIs(t, err, processorhelper.ErrSkipProcessingData)
+	assert.Len(t, p.limiter.tenants, 1)
+
+	var md metricdata.ResourceMetrics
+	require.NoError(t, tel.reader.Collect(context.Background(), &md))
+	attrs := attribute.NewSet(attribute.String("tenant", overflowTenant))
+	metricdatatest.AssertEqual(t, metricdata.Metrics{
+		Name:        "otelcol_processor_ratelimit_refused_items",
+		Description: "Number of spans, log records or data points refused by the processor for exceeding the limits of their tenant, by tenant.",
+		Unit:        "{items}",
+		Data: metricdata.Sum[int64]{
+			Temporality: metricdata.CumulativeTemporality,
+			IsMonotonic: true,
+			DataPoints:  []metricdata.DataPoint[int64]{{Attributes: attrs, Value: 8}},
+		},
+	}, tel.getMetric("otelcol_processor_ratelimit_refused_items", md), metricdatatest.IgnoreTimestamp())
+	require.NoError(t, tel.Shutdown(context.Background()))
+}
+
+func TestLimitsFileReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "limits.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("default_limits:\n  items_per_second: 1\n"), 0600))
+
+	p := newTestProcessor(t, &Config{
+		Tenant:         TenantConfig{FromContext: "X-Scope-OrgID"},
+		LimitsFile:     path,
+		ReloadInterval: time.Millisecond,
+	})
+	// Consumed tokens are kept across reloads, the buckets must be refilled at the new rate.
+	p.limiter.now = time.Now
+	require.NoError(t, p.start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, p.shutdown(context.Background())) }()
+
+	_, err := p.processLogs(contextWithMetadata("acme"), newLogs(1))
+	require.NoError(t, err)
+	_, err = p.processLogs(contextWithMetadata("acme"), newLogs(5))
+	assert.ErrorIs(t, err, processorhelper.ErrSkipProcessingData)
+
+	require.NoError(t, os.WriteFile(path, []byte("default_limits:\n  items_per_second: 100\n"), 0600))
+	require.NoError(t, os.Chtimes(path, time.Now(), time.Now().Add(time.Second)))
+	assert.Eventually(t, func() bool {
+		_, err := p.processLogs(contextWithMetadata("acme"), newLogs(5))
+		return err == nil
+	}, time.Second, time.Millisecond)
+}
+
+func TestStartFailsOnInvalidLimitsFile(t *testing.T) {
+	p := newTestProcessor(t, &Config{
+		Tenant:     TenantConfig{FromContext: "X-Scope-OrgID"},
+		LimitsFile: filepath.Join("testdata", "invalid_limits.yaml"),
+	})
+	err := p.start(context.Background(), componenttest.NewNopHost())
+	assert.EqualError(t, err, `failed to load limits file: limits: tenant "acme": items_per_second must not be negative`)
+	assert.NoError(t, p.shutdown(context.Background()))
+}
+
+func TestTelemetry(t *testing.T) {
+	tel := setupTestTelemetry()
+	cfg := &Config{
+		Tenant:         TenantConfig{FromContext: "X-Scope-OrgID"},
+		DefaultLimits:  Limits{ItemsPerSecond: 10},
+		ReloadInterval: defaultReloadInterval,
+		MaxTenants:     defaultMaxTenants,
+		Action:         ActionDrop,
+	}
+	p, err := newRateLimitProcessor(tel.NewSettings(), cfg)
+	require.NoError(t, err)
+
+	accepted := newLogs(8)
+	acceptedSize := (&plog.ProtoMarshaler{}).LogsSize(accepted)
+	refused := newLogs(5)
+	refusedSize := (&plog.ProtoMarshaler{}).LogsSize(refused)
+	_, err = p.processLogs(contextWithMetadata("acme"), accepted)
+	require.NoError(t, err)
+	_, err = p.processLogs(contextWithMetadata("acme"), refused)
+	require.ErrorIs(t, err, processorhelper.ErrSkipProcessingData)
+
+	attrs := attribute.NewSet(attribute.String("tenant", "acme"))
+	sum := func(value int) metricdata.Sum[int64] {
+		return metricdata.Sum[int64]{
+			Temporality: metricdata.CumulativeTemporality,
+			IsMonotonic: true,
+			DataPoints:  []metricdata.DataPoint[int64]{{Attributes: attrs, Value: int64(value)}},
+		}
+	}
+	tel.assertMetrics(t, []metricdata.Metrics{
+		{
+			Name:        "otelcol_processor_ratelimit_accepted_items",
+			Description: "Number of spans, log records or data points accepted by the processor, by tenant.",
+			Unit:        "{items}",
+			Data:        sum(8),
+		},
+		{
+			Name:        "otelcol_processor_ratelimit_accepted_bytes",
+			Description: "Size of the telemetry accepted by the processor, by tenant.",
+			Unit:        "By",
+			Data:        sum(acceptedSize),
+		},
+		{
+			Name:        "otelcol_processor_ratelimit_refused_items",
+			Description: "Number of spans, log records or data points refused by the processor for exceeding the limits of their tenant, by tenant.",
+			Unit:        "{items}",
+			Data:        sum(5),
+		},
+		{
+			Name:        "otelcol_processor_ratelimit_refused_bytes",
+			Description: "Size of the telemetry refused by the processor for exceeding the limits of its tenant, by tenant.",
+			Unit:        "By",
+			Data:        sum(refusedSize),
+		},
+	})
+	require.NoError(t, tel.Shutdown(context.Background()))
+}
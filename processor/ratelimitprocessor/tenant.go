@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ratelimitprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/ratelimitprocessor"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlresource"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+)
+
+func newResourceExpression(expression string, set component.TelemetrySettings) (*ottl.ValueExpression[ottlresource.TransformContext], error) {
+	parser, err := ottlresource.NewParser(ottlfuncs.StandardConverters[ottlresource.TransformContext](), set)
+	if err != nil {
+		return nil, err
+	}
+	return parser.ParseValueExpression(expression)
+}
+
+// tenantResolver determines the tenant of the telemetry.
+type tenantResolver struct {
+	cfg        TenantConfig
+	expression *ottl.ValueExpression[ottlresource.TransformContext]
+}
+
+func newTenantResolver(cfg TenantConfig, set component.TelemetrySettings) (*tenantResolver, error) {
+	r := &tenantResolver{cfg: cfg}
+	if cfg.Expression != "" {
+		var err error
+		if r.expression, err = newResourceExpression(cfg.Expression, set); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// perResource reports whether the tenant is determined for each resource rather than for the whole request.
+func (r *tenantResolver) perResource() bool {
+	return r.expression != nil
+}
+
+// fromContext returns the tenant of a request, from the client information of its context.
+func (r *tenantResolver) fromContext(ctx context.Context) string {
+	info := client.FromContext(ctx)
+	switch {
+	case r.cfg.FromContext != "":
+		if values := info.Metadata.Get(r.cfg.FromContext); len(values) > 0 && values[0] != "" {
+			return values[0]
+		}
+	case r.cfg.FromAuth != "":
+		if info.Auth == nil {
+			break
+		}
+		switch v := info.Auth.GetAttribute(r.cfg.FromAuth).(type) {
+		case nil:
+		case string:
+			if v != "" {
+				return v
+			}
+		default:
+			return fmt.Sprint(v)
+		}
+	}
+	return r.cfg.Default
+}
+
+// resourceTelemetry is the telemetry of a resource, e.g. ptrace.ResourceSpans.
+type resourceTelemetry interface {
+	Resource() pcommon.Resource
+	SchemaUrl() string
+	SetSchemaUrl(v string)
+}
+
+// fromResource returns the tenant of the telemetry of a resource, evaluating the expression.
+func (r *tenantResolver) fromResource(ctx context.Context, rt resourceTelemetry) (string, error) {
+	val, err := r.expression.Eval(ctx, ottlresource.NewTransformContext(rt.Resource(), rt))
+	if err != nil {
+		return "", err
+	}
+	switch v := val.(type) {
+	case nil:
+	case string:
+		if v != "" {
+			return v, nil
+		}
+	case pcommon.Value:
+		if s := v.AsString(); s != "" {
+			return s, nil
+		}
+	default:
+		return fmt.Sprint(v), nil
+	}
+	return r.cfg.Default, nil
+}
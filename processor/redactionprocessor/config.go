@@ -3,6 +3,13 @@
 
 package redactionprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/redactionprocessor"
 
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/config/configopaque"
+)
+
 type Config struct {
 
 	// AllowAllKeys is a flag to allow all span attribute keys. Setting this
@@ -24,6 +31,15 @@ type Config struct {
 	// allowed span attributes. Values that match are masked
 	BlockedValues []string `mapstructure:"blocked_values"`
 
+	// Detectors is a list of names of built-in detectors of sensitive values,
+	// applied like the BlockedValues. Possible values are `credit_card`,
+	// `iban`, `jwt`, `aws_access_key`, `email`, `ipv4` and `ipv6`.
+	Detectors []string `mapstructure:"detectors"`
+
+	// Replacement controls how the blocked values are replaced. By default
+	// they are masked with `****`.
+	Replacement ReplacementConfig `mapstructure:"replacement"`
+
 	// Summary controls the verbosity level of the diagnostic attributes that
 	// the processor adds to the spans when it redacts or masks other
 	// attributes. In some contexts a list of redacted attributes leaks
@@ -31,3 +47,48 @@ type Config struct {
 	// configuration. Possible values are `debug`, `info`, and `silent`.
 	Summary string `mapstructure:"summary"`
 }
+
+// ReplacementConfig defines how the blocked values are replaced.
+type ReplacementConfig struct {
+	// Strategy is the replacement strategy. Possible values are:
+	// - `mask` replaces the values with `****`
+	// - `hmac` replaces the values with their HMAC-SHA256 computed with Key,
+	//   so that the same values have the same replacement
+	// - `partial_mask` masks the letters and digits of the values, except the
+	//   last KeepLast ones, and keeps the other characters
+	// - `hash` replaces the values with their SHA-256 hash, computed with Salt
+	Strategy string `mapstructure:"strategy"`
+
+	// Key is the key of the HMAC. Required for the `hmac` strategy.
+	Key configopaque.String `mapstructure:"key"`
+
+	// Salt is prepended to the values before hashing them with the `hash`
+	// strategy.
+	Salt configopaque.String `mapstructure:"salt"`
+
+	// KeepLast is the number of letters and digits kept at the end of the
+	// values by the `partial_mask` strategy. Defaults to 4.
+	KeepLast *int `mapstructure:"keep_last"`
+}
+
+// Validate checks the replacement strategy and the detector names.
+func (config *Config) Validate() error {
+	switch config.Replacement.Strategy {
+	case "", maskStrategy, partialMaskStrategy, hashStrategy:
+	case hmacStrategy:
+		if config.Replacement.Key == "" {
+			return errors.New("replacement key must be set for the hmac strategy")
+		}
+	default:
+		return fmt.Errorf("unknown replacement strategy %q", config.Replacement.Strategy)
+	}
+	if config.Replacement.KeepLast != nil && *config.Replacement.KeepLast < 0 {
+		return errors.New("replacement keep_last must not be negative")
+	}
+	for _, name := range config.Detectors {
+		if _, ok := detectors[name]; !ok {
+			return fmt.Errorf("unknown detector %q", name)
+		}
+	}
+	return nil
+}
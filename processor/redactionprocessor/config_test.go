@@ -18,9 +18,11 @@ import (
 func TestLoadConfig(t *testing.T) {
 	t.Parallel()
 
+	keepLast := 2
 	tests := []struct {
-		id       component.ID
-		expected component.Config
+		id          component.ID
+		expected    component.Config
+		expectedErr string
 	}{
 		{
 			id: component.NewIDWithName(metadata.Type, ""),
@@ -36,6 +38,40 @@ func TestLoadConfig(t *testing.T) {
 			id:       component.NewIDWithName(metadata.Type, "empty"),
 			expected: createDefaultConfig(),
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "tokenization"),
+			expected: &Config{
+				AllowAllKeys: true,
+				Detectors:    []string{"credit_card", "email"},
+				Replacement: ReplacementConfig{
+					Strategy: hmacStrategy,
+					Key:      "my-secret-key",
+				},
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "partial_mask"),
+			expected: &Config{
+				AllowAllKeys: true,
+				Detectors:    []string{"credit_card"},
+				Replacement: ReplacementConfig{
+					Strategy: partialMaskStrategy,
+					KeepLast: &keepLast,
+				},
+			},
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "unknown_detector"),
+			expectedErr: `unknown detector "phone_number"`,
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "unknown_strategy"),
+			expectedErr: `unknown replacement strategy "encrypt"`,
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "missing_hmac_key"),
+			expectedErr: "replacement key must be set for the hmac strategy",
+		},
 	}
 
 	for _, tt := range tests {
@@ -50,6 +86,10 @@ func TestLoadConfig(t *testing.T) {
 			require.NoError(t, err)
 			require.NoError(t, sub.Unmarshal(cfg))
 
+			if tt.expectedErr != "" {
+				assert.EqualError(t, component.ValidateConfig(cfg), tt.expectedErr)
+				return
+			}
 			assert.NoError(t, component.ValidateConfig(cfg))
 			assert.Equal(t, tt.expected, cfg)
 		})
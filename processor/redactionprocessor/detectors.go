@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package redactionprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/redactionprocessor"
+
+import (
+	"net/netip"
+	"regexp"
+	"strings"
+)
+
+// matcher finds the blocked values in a string
+type matcher struct {
+	re *regexp.Regexp
+	// valid checks the matches of re, when they can't be validated by a
+	// regular expression only. A nil valid accepts all the matches.
+	valid func(match string) bool
+}
+
+// find returns the index pairs of the valid matches of m in s
+func (m matcher) find(s string) [][]int {
+	matches := m.re.FindAllStringIndex(s, -1)
+	if m.valid == nil {
+		return matches
+	}
+	valid := matches[:0]
+	for _, loc := range matches {
+		if m.valid(s[loc[0]:loc[1]]) {
+			valid = append(valid, loc)
+		}
+	}
+	return valid
+}
+
+// detectors are the built-in matchers of sensitive values, by name
+var detectors = map[string]matcher{
+	"credit_card": {
+		re:    regexp.MustCompile(`\b(?:\d[ -]?){12,18}\d\b`),
+		valid: isValidCreditCard,
+	},
+	"iban": {
+		re:    regexp.MustCompile(`\b[A-Z]{2}\d{2}(?: ?[A-Z0-9]){11,30}\b`),
+		valid: isValidIBAN,
+	},
+	"jwt": {
+		re: regexp.MustCompile(`\beyJ[A-Za-z0-9_-]+\.eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`),
+	},
+	"aws_access_key": {
+		re: regexp.MustCompile(`\b(?:AKIA|ASIA|ABIA|ACCA)[A-Z0-9]{16}\b`),
+	},
+	"email": {
+		re: regexp.MustCompile(`\b[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}\b`),
+	},
+	"ipv4": {
+		re: regexp.MustCompile(`\b(?:(?:25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)\.){3}(?:25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)\b`),
+	},
+	"ipv6": {
+		re:    regexp.MustCompile(`(?:[0-9A-Fa-f]{0,4}:){2,7}(?:(?:\d{1,3}\.){3}\d{1,3}|[0-9A-Fa-f]{0,4})`),
+		valid: isValidIPv6,
+	},
+}
+
+// isValidCreditCard checks the digits of a card number with the Luhn algorithm
+func isValidCreditCard(number string) bool {
+	digits := strings.Map(func(r rune) rune {
+		if r == ' ' || r == '-' {
+			return -1
+		}
+		return r
+	}, number)
+	if len(digits) < 13 || len(digits) > 19 {
+		return false
+	}
+	sum := 0
+	for i := 0; i < len(digits); i++ {
+		d := int(digits[len(digits)-1-i] - '0')
+		if i%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	return sum%10 == 0
+}
+
+// isValidIBAN checks the check digits of an IBAN
+func isValidIBAN(iban string) bool {
+	iban = strings.ReplaceAll(iban, " ", "")
+	if len(iban) < 15 || len(iban) > 34 {
+		return false
+	}
+	// The first four characters are moved to the end, and the letters are
+	// replaced with two digits, A being 10. The resulting number modulo 97
+	// must be 1.
+	remainder := 0
+	for _, r := range iban[4:] + iban[:4] {
+		switch {
+		case r >= '0' && r <= '9':
+			remainder = (remainder*10 + int(r-'0')) % 97
+		case r >= 'A' && r <= 'Z':
+			remainder = (remainder*100 + int(r-'A'+10)) % 97
+		default:
+			return false
+		}
+	}
+	return remainder == 1
+}
+
+func isValidIPv6(s string) bool {
+	addr, err := netip.ParseAddr(s)
+	return err == nil && addr.Is6()
+}
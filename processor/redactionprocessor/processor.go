@@ -24,8 +24,10 @@ type redaction struct {
 	allowList map[string]string
 	// Attribute keys ignored in a span
 	ignoreList map[string]string
-	// Attribute values blocked in a span
-	blockRegexList map[string]*regexp.Regexp
+	// Matchers of the values blocked in a span
+	blockList []matcher
+	// Replacement of the blocked values
+	replace replaceFunc
 	// Redaction processor configuration
 	config *Config
 	// Logger
@@ -36,18 +38,19 @@ type redaction struct {
 func newRedaction(ctx context.Context, config *Config, logger *zap.Logger) (*redaction, error) {
 	allowList := makeAllowList(config)
 	ignoreList := makeIgnoreList(config)
-	blockRegexList, err := makeBlockRegexList(ctx, config)
+	blockList, err := makeBlockList(ctx, config)
 	if err != nil {
 		// TODO: Placeholder for an error metric in the next PR
 		return nil, fmt.Errorf("failed to process block list: %w", err)
 	}
 
 	return &redaction{
-		allowList:      allowList,
-		ignoreList:     ignoreList,
-		blockRegexList: blockRegexList,
-		config:         config,
-		logger:         logger,
+		allowList:  allowList,
+		ignoreList: ignoreList,
+		blockList:  blockList,
+		replace:    makeReplaceFunc(config.Replacement),
+		config:     config,
+		logger:     logger,
 	}, nil
 }
 
@@ -93,6 +96,14 @@ func (s *redaction) processResourceSpan(ctx context.Context, rs ptrace.ResourceS
 
 			// Attributes can also be part of span
 			s.processAttrs(ctx, spanAttrs)
+
+			// Span names and events can also hold blocked values
+			if name, masked := s.maskValue(span.Name()); masked {
+				span.SetName(name)
+			}
+			for e := 0; e < span.Events().Len(); e++ {
+				s.processAttrs(ctx, span.Events().At(e).Attributes())
+			}
 		}
 	}
 }
@@ -109,6 +120,13 @@ func (s *redaction) processResourceLog(ctx context.Context, rl plog.ResourceLogs
 		for k := 0; k < ils.LogRecords().Len(); k++ {
 			log := ils.LogRecords().At(k)
 			s.processAttrs(ctx, log.Attributes())
+
+			// String bodies can also hold blocked values
+			if log.Body().Type() == pcommon.ValueTypeStr {
+				if body, masked := s.maskValue(log.Body().Str()); masked {
+					log.Body().SetStr(body)
+				}
+			}
 		}
 	}
 }
@@ -187,20 +205,9 @@ func (s *redaction) processAttrs(_ context.Context, attributes pcommon.Map) {
 		}
 
 		// Mask any blocked values for the other attributes
-		strVal := value.Str()
-		var matched bool
-		for _, compiledRE := range s.blockRegexList {
-			match := compiledRE.MatchString(strVal)
-			if match {
-				if !matched {
-					matched = true
-					toBlock = append(toBlock, k)
-				}
-
-				maskedValue := compiledRE.ReplaceAllString(strVal, "****")
-				value.SetStr(maskedValue)
-				strVal = maskedValue
-			}
+		if maskedValue, masked := s.maskValue(value.Str()); masked {
+			toBlock = append(toBlock, k)
+			value.SetStr(maskedValue)
 		}
 		return true
 	})
@@ -215,6 +222,36 @@ func (s *redaction) processAttrs(_ context.Context, attributes pcommon.Map) {
 	s.addMetaAttrs(ignoring, attributes, "", ignoredKeyCount)
 }
 
+// maskValue replaces the blocked values in value. It returns the new value and
+// whether any blocked value was found. Overlapping blocked values are replaced
+// once, as a whole.
+func (s *redaction) maskValue(value string) (string, bool) {
+	var matches [][]int
+	for _, m := range s.blockList {
+		matches = append(matches, m.find(value)...)
+	}
+	if len(matches) == 0 {
+		return value, false
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i][0] < matches[j][0]
+	})
+
+	var b strings.Builder
+	end := 0
+	for i := 0; i < len(matches); {
+		start, stop := matches[i][0], matches[i][1]
+		for i++; i < len(matches) && matches[i][0] < stop; i++ {
+			stop = max(stop, matches[i][1])
+		}
+		b.WriteString(value[end:start])
+		b.WriteString(s.replace(value[start:stop]))
+		end = stop
+	}
+	b.WriteString(value[end:])
+	return b.String(), true
+}
+
 // addMetaAttrs adds diagnostic information about redacted or masked attribute keys
 func (s *redaction) addMetaAttrs(redactedAttrs []string, attributes pcommon.Map, valuesAttr, countAttr string) {
 	redactedCount := int64(len(redactedAttrs))
@@ -282,16 +319,24 @@ func makeIgnoreList(c *Config) map[string]string {
 	return ignoreList
 }
 
-// makeBlockRegexList precompiles all the blocked regex patterns
-func makeBlockRegexList(_ context.Context, config *Config) (map[string]*regexp.Regexp, error) {
-	blockRegexList := make(map[string]*regexp.Regexp, len(config.BlockedValues))
+// makeBlockList precompiles all the blocked regex patterns and adds the
+// enabled detectors
+func makeBlockList(_ context.Context, config *Config) ([]matcher, error) {
+	blockList := make([]matcher, 0, len(config.BlockedValues)+len(config.Detectors))
 	for _, pattern := range config.BlockedValues {
 		re, err := regexp.Compile(pattern)
 		if err != nil {
 			// TODO: Placeholder for an error metric in the next PR
 			return nil, fmt.Errorf("error compiling regex in block list: %w", err)
 		}
-		blockRegexList[pattern] = re
+		blockList = append(blockList, matcher{re: re})
+	}
+	for _, name := range config.Detectors {
+		detector, ok := detectors[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown detector %q", name)
+		}
+		blockList = append(blockList, detector)
 	}
-	return blockRegexList, nil
+	return blockList, nil
 }
@@ -505,6 +505,97 @@ func TestProcessAttrsAppliedTwice(t *testing.T) {
 	assert.Equal(t, int64(2), val.Int())
 }
 
+// TestRedactSpanNamesEventsAndLogBodies validates that the processor masks
+// the blocked values of span names, span event attributes and log bodies
+func TestRedactSpanNamesEventsAndLogBodies(t *testing.T) {
+	config := &Config{
+		AllowedKeys: []string{"user"},
+		Detectors:   []string{"email"},
+		Summary:     "debug",
+	}
+	ctx := context.Background()
+	processor, err := newRedaction(ctx, config, zaptest.NewLogger(t))
+	require.NoError(t, err)
+
+	traces := ptrace.NewTraces()
+	span := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetName("GET /users/john@example.com")
+	event := span.Events().AppendEmpty()
+	event.Attributes().PutStr("user", "john@example.com")
+	event.Attributes().PutStr("password", "secret")
+	_, err = processor.processTraces(ctx, traces)
+	require.NoError(t, err)
+
+	assert.Equal(t, "GET /users/****", span.Name())
+	assert.Equal(t, map[string]any{
+		"user":           "****",
+		redactedKeys:     "password",
+		redactedKeyCount: int64(1),
+		maskedValues:     "user",
+		maskedValueCount: int64(1),
+	}, event.Attributes().AsRaw())
+
+	logs := plog.NewLogs()
+	logRecords := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	strBody := logRecords.AppendEmpty()
+	strBody.Body().SetStr("login of john@example.com failed")
+	intBody := logRecords.AppendEmpty()
+	intBody.Body().SetInt(5)
+	_, err = processor.processLogs(ctx, logs)
+	require.NoError(t, err)
+
+	assert.Equal(t, "login of **** failed", strBody.Body().Str())
+	assert.Equal(t, int64(5), intBody.Body().Int())
+}
+
+// TestReplacementStrategy validates that the blocked values are replaced
+// with the configured strategy, and that overlapping matches are replaced once
+func TestReplacementStrategy(t *testing.T) {
+	config := &Config{
+		AllowAllKeys:  true,
+		BlockedValues: []string{"4[0-9]{12}(?:[0-9]{3})?"},
+		Detectors:     []string{"credit_card"},
+		Replacement:   ReplacementConfig{Strategy: partialMaskStrategy},
+	}
+	masked := map[string]pcommon.Value{
+		"name":   pcommon.NewValueStr("placeholder 4111111111111111"),
+		"spaced": pcommon.NewValueStr("card 5500 0055 5555 5559 used"),
+	}
+
+	outTraces := runTest(t, nil, nil, masked, nil, config)
+
+	attr := outTraces.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes()
+	name, _ := attr.Get("name")
+	assert.Equal(t, "placeholder ************1111", name.Str())
+	spaced, _ := attr.Get("spaced")
+	assert.Equal(t, "card **** **** **** 5559 used", spaced.Str())
+}
+
+// TestHMACReplacementIsConsistent validates that the same values have the
+// same tokens, so that they can still be joined
+func TestHMACReplacementIsConsistent(t *testing.T) {
+	config := &Config{
+		AllowAllKeys: true,
+		Detectors:    []string{"email"},
+		Replacement:  ReplacementConfig{Strategy: hmacStrategy, Key: "key"},
+	}
+	masked := map[string]pcommon.Value{
+		"from": pcommon.NewValueStr("john@example.com"),
+		"to":   pcommon.NewValueStr("to john@example.com"),
+		"cc":   pcommon.NewValueStr("jane@example.com"),
+	}
+
+	outTraces := runTest(t, nil, nil, masked, nil, config)
+
+	attr := outTraces.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes()
+	from, _ := attr.Get("from")
+	to, _ := attr.Get("to")
+	cc, _ := attr.Get("cc")
+	assert.Len(t, from.Str(), 64)
+	assert.Equal(t, "to "+from.Str(), to.Str())
+	assert.NotEqual(t, from.Str(), cc.Str())
+}
+
 // runTest transforms the test input data and passes it through the processor
 func runTest(
 	t *testing.T,
@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package redactionprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/redactionprocessor"
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"unicode"
+)
+
+const (
+	maskStrategy        = "mask"
+	hmacStrategy        = "hmac"
+	partialMaskStrategy = "partial_mask"
+	hashStrategy        = "hash"
+
+	mask            = "****"
+	defaultKeepLast = 4
+)
+
+// replaceFunc returns the replacement of a blocked value
+type replaceFunc func(value string) string
+
+// makeReplaceFunc returns the replaceFunc of the configured strategy
+func makeReplaceFunc(c ReplacementConfig) replaceFunc {
+	switch c.Strategy {
+	case hmacStrategy:
+		key := []byte(c.Key)
+		return func(value string) string {
+			h := hmac.New(sha256.New, key)
+			h.Write([]byte(value))
+			return hex.EncodeToString(h.Sum(nil))
+		}
+	case hashStrategy:
+		salt := string(c.Salt)
+		return func(value string) string {
+			sum := sha256.Sum256([]byte(salt + value))
+			return hex.EncodeToString(sum[:])
+		}
+	case partialMaskStrategy:
+		keepLast := defaultKeepLast
+		if c.KeepLast != nil {
+			keepLast = *c.KeepLast
+		}
+		return func(value string) string {
+			return partialMask(value, keepLast)
+		}
+	default:
+		return func(string) string {
+			return mask
+		}
+	}
+}
+
+// partialMask replaces the letters and digits of value with `*`, except the
+// last keepLast ones, so that the format of the value is preserved.
+// For example, 4111-1111-1111-1111 becomes ****-****-****-1111.
+func partialMask(value string, keepLast int) string {
+	runes := []rune(value)
+	kept := 0
+	for i := len(runes) - 1; i >= 0; i-- {
+		if !unicode.IsLetter(runes[i]) && !unicode.IsDigit(runes[i]) {
+			continue
+		}
+		if kept < keepLast {
+			kept++
+			continue
+		}
+		runes[i] = '*'
+	}
+	return string(runes)
+}
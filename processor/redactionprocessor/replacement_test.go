@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package redactionprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestReplaceFunc validates the replacement strategies
+func TestReplaceFunc(t *testing.T) {
+	keepLast := 2
+	tests := []struct {
+		name     string
+		config   ReplacementConfig
+		value    string
+		expected string
+	}{
+		{
+			name:     "default",
+			value:    "4111111111111111",
+			expected: "****",
+		},
+		{
+			name:     "mask",
+			config:   ReplacementConfig{Strategy: maskStrategy},
+			value:    "4111111111111111",
+			expected: "****",
+		},
+		{
+			name:     "hmac",
+			config:   ReplacementConfig{Strategy: hmacStrategy, Key: "key"},
+			value:    "The quick brown fox jumps over the lazy dog",
+			expected: "f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8",
+		},
+		{
+			name:     "hash",
+			config:   ReplacementConfig{Strategy: hashStrategy},
+			value:    "abc",
+			expected: "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad",
+		},
+		{
+			name:     "salted hash",
+			config:   ReplacementConfig{Strategy: hashStrategy, Salt: "a"},
+			value:    "bc",
+			expected: "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad",
+		},
+		{
+			name:     "partial mask",
+			config:   ReplacementConfig{Strategy: partialMaskStrategy},
+			value:    "4111-1111-1111-1234",
+			expected: "****-****-****-1234",
+		},
+		{
+			name:     "partial mask keep last",
+			config:   ReplacementConfig{Strategy: partialMaskStrategy, KeepLast: &keepLast},
+			value:    "john.doe@example.com",
+			expected: "****.***@*******.*om",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, makeReplaceFunc(tt.config)(tt.value))
+		})
+	}
+}
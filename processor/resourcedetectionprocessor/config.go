@@ -4,9 +4,13 @@
 package resourcedetectionprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor"
 
 import (
+	"errors"
+	"time"
+
 	"go.opentelemetry.io/collector/config/confighttp"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/alibabaecs"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/aws/ec2"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/aws/ecs"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/aws/eks"
@@ -15,11 +19,16 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/azure"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/azure/aks"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/consul"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/digitalocean"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/docker"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/gcp"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/heroku"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/hetzner"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/k8snode"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/openshift"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/openstack"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/oraclecloud"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/scaleway"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/system"
 )
 
@@ -37,12 +46,30 @@ type Config struct {
 	// HTTP client settings for the detector
 	// Timeout default is 5s
 	confighttp.ClientConfig `mapstructure:",squash"`
+	// RefreshInterval is the interval at which the resource is detected again, so that
+	// changes such as an instance resize are picked up. Refreshing is disabled when 0.
+	RefreshInterval time.Duration `mapstructure:"refresh_interval"`
+	// DetectorTimeout bounds the detection of each detector, so that a hanging detector
+	// doesn't prevent the following ones from running. The whole detection is then bounded by
+	// DetectorTimeout times the number of detectors. Defaults to the timeout.
+	DetectorTimeout time.Duration `mapstructure:"detector_timeout"`
 	// Attributes is an allowlist of attributes to add.
 	// If a supplied attribute is not a valid attribute of a supplied detector it will be ignored.
 	// Deprecated: Please use detector's resource_attributes config instead
 	Attributes []string `mapstructure:"attributes"`
 }
 
+// Validate checks the configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.RefreshInterval < 0 {
+		return errors.New("refresh_interval must not be negative")
+	}
+	if cfg.DetectorTimeout < 0 {
+		return errors.New("detector_timeout must not be negative")
+	}
+	return nil
+}
+
 // DetectorConfig contains user-specified configurations unique to all individual detectors
 type DetectorConfig struct {
 	// EC2Config contains user-specified configurations for the EC2 detector
@@ -86,6 +113,24 @@ type DetectorConfig struct {
 
 	// K8SNode contains user-specified configurations for the K8SNode detector
 	K8SNodeConfig k8snode.Config `mapstructure:"k8snode"`
+
+	// AlibabaECSConfig contains user-specified configurations for the Alibaba Cloud ECS detector
+	AlibabaECSConfig alibabaecs.Config `mapstructure:"alibabaecs"`
+
+	// DigitalOceanConfig contains user-specified configurations for the DigitalOcean detector
+	DigitalOceanConfig digitalocean.Config `mapstructure:"digitalocean"`
+
+	// HetznerConfig contains user-specified configurations for the Hetzner detector
+	HetznerConfig hetzner.Config `mapstructure:"hetzner"`
+
+	// OpenStackConfig contains user-specified configurations for the OpenStack detector
+	OpenStackConfig openstack.Config `mapstructure:"openstack"`
+
+	// OracleCloudConfig contains user-specified configurations for the Oracle Cloud detector
+	OracleCloudConfig oraclecloud.Config `mapstructure:"oraclecloud"`
+
+	// ScalewayConfig contains user-specified configurations for the Scaleway detector
+	ScalewayConfig scaleway.Config `mapstructure:"scaleway"`
 }
 
 func detectorCreateDefaultConfig() DetectorConfig {
@@ -104,6 +149,12 @@ func detectorCreateDefaultConfig() DetectorConfig {
 		SystemConfig:           system.CreateDefaultConfig(),
 		OpenShiftConfig:        openshift.CreateDefaultConfig(),
 		K8SNodeConfig:          k8snode.CreateDefaultConfig(),
+		AlibabaECSConfig:       alibabaecs.CreateDefaultConfig(),
+		DigitalOceanConfig:     digitalocean.CreateDefaultConfig(),
+		HetznerConfig:          hetzner.CreateDefaultConfig(),
+		OpenStackConfig:        openstack.CreateDefaultConfig(),
+		OracleCloudConfig:      oraclecloud.CreateDefaultConfig(),
+		ScalewayConfig:         scaleway.CreateDefaultConfig(),
 	}
 }
 
@@ -137,6 +188,18 @@ func (d *DetectorConfig) GetConfigFromType(detectorType internal.DetectorType) i
 		return d.OpenShiftConfig
 	case k8snode.TypeStr:
 		return d.K8SNodeConfig
+	case alibabaecs.TypeStr:
+		return d.AlibabaECSConfig
+	case digitalocean.TypeStr:
+		return d.DigitalOceanConfig
+	case hetzner.TypeStr:
+		return d.HetznerConfig
+	case openstack.TypeStr:
+		return d.OpenStackConfig
+	case oraclecloud.TypeStr:
+		return d.OracleCloudConfig
+	case scaleway.TypeStr:
+		return d.ScalewayConfig
 	default:
 		return nil
 	}
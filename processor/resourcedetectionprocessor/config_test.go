@@ -51,6 +51,10 @@ func TestLoadConfig(t *testing.T) {
 		ResourceAttributes: system.CreateDefaultConfig().ResourceAttributes,
 	}
 
+	openstackConfig := detectorCreateDefaultConfig()
+	openstackConfig.OpenStackConfig.Endpoint = "http://localhost:8080"
+	openstackConfig.OpenStackConfig.ResourceAttributes.HostType.Enabled = false
+
 	resourceAttributesConfig := detectorCreateDefaultConfig()
 	ec2ResourceAttributesConfig := ec2.CreateDefaultConfig()
 	ec2ResourceAttributesConfig.ResourceAttributes.HostName.Enabled = false
@@ -75,6 +79,15 @@ func TestLoadConfig(t *testing.T) {
 				Override:       false,
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "openstack"),
+			expected: &Config{
+				Detectors:      []string{"env", "openstack"},
+				DetectorConfig: openstackConfig,
+				ClientConfig:   cfg,
+				Override:       false,
+			},
+		},
 		{
 			id: component.NewIDWithName(metadata.Type, "gcp"),
 			expected: &Config{
@@ -130,6 +143,21 @@ func TestLoadConfig(t *testing.T) {
 				DetectorConfig: resourceAttributesConfig,
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "refresh"),
+			expected: &Config{
+				Detectors:       []string{"env", "ec2"},
+				ClientConfig:    cfg,
+				Override:        false,
+				RefreshInterval: 10 * time.Minute,
+				DetectorTimeout: time.Second,
+				DetectorConfig:  detectorCreateDefaultConfig(),
+			},
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "negative_refresh_interval"),
+			errorMessage: "refresh_interval must not be negative",
+		},
 		{
 			id:           component.NewIDWithName(metadata.Type, "invalid"),
 			errorMessage: "hostname_sources contains invalid value: \"invalid_source\"",
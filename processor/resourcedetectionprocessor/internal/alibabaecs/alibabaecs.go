@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package alibabaecs // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/alibabaecs"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/processor"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/metadataproviders/alibabaecs"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/alibabaecs/internal/metadata"
+)
+
+const (
+	// TypeStr is type of detector.
+	TypeStr = "alibabaecs"
+)
+
+var _ internal.Detector = (*Detector)(nil)
+
+// Detector is a Alibaba Cloud ECS metadata detector
+type Detector struct {
+	provider alibabaecs.Provider
+	logger   *zap.Logger
+	rb       *metadata.ResourceBuilder
+}
+
+// NewDetector creates a new Alibaba Cloud ECS metadata detector
+func NewDetector(p processor.Settings, dcfg internal.DetectorConfig) (internal.Detector, error) {
+	cfg := dcfg.(Config)
+	return &Detector{
+		provider: alibabaecs.NewProvider(cfg.Endpoint),
+		logger:   p.Logger,
+		rb:       metadata.NewResourceBuilder(cfg.ResourceAttributes),
+	}, nil
+}
+
+// Detect detects Alibaba Cloud ECS metadata and returns a resource with the available ones
+func (d *Detector) Detect(ctx context.Context) (resource pcommon.Resource, schemaURL string, err error) {
+	meta, err := d.provider.Metadata(ctx)
+	if err != nil {
+		d.logger.Debug("Alibaba Cloud ECS detector metadata retrieval failed", zap.Error(err))
+		// return an empty Resource and no error
+		return pcommon.NewResource(), "", nil
+	}
+
+	d.rb.SetCloudProvider(conventions.AttributeCloudProviderAlibabaCloud)
+	d.rb.SetCloudPlatform(conventions.AttributeCloudPlatformAlibabaCloudECS)
+	d.rb.SetCloudRegion(meta.RegionID)
+	d.rb.SetCloudAvailabilityZone(meta.ZoneID)
+	d.rb.SetCloudAccountID(meta.OwnerAccountID)
+	d.rb.SetHostID(meta.InstanceID)
+	d.rb.SetHostType(meta.InstanceType)
+	d.rb.SetHostImageID(meta.ImageID)
+
+	return d.rb.Emit(), conventions.SchemaURL, nil
+}
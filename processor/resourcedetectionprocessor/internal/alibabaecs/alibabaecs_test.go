@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package alibabaecs
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/processor/processortest"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+)
+
+func newMetadataServer(t *testing.T) *httptest.Server {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/latest/dynamic/instance-identity/document" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, err := w.Write([]byte(`{"zone-id": "cn-hangzhou-i", "instance-id": "i-bp1iw9mv7uaaxxxx", "region-id": "cn-hangzhou", "owner-account-id": "1609****", "image-id": "aliyun_3_x64_20G_alibase_2021****.vhd", "instance-type": "ecs.g6e.large"}`))
+		assert.NoError(t, err)
+	}))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func TestNewDetector(t *testing.T) {
+	d, err := NewDetector(processortest.NewNopSettings(), CreateDefaultConfig())
+	require.NoError(t, err)
+	assert.NotNil(t, d)
+}
+
+func TestDetect(t *testing.T) {
+	cfg := CreateDefaultConfig()
+	cfg.Endpoint = newMetadataServer(t).URL
+	d, err := NewDetector(processortest.NewNopSettings(), cfg)
+	require.NoError(t, err)
+
+	res, schemaURL, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, conventions.SchemaURL, schemaURL)
+	assert.Equal(t, map[string]any{
+		"cloud.provider":          "alibaba_cloud",
+		"cloud.platform":          "alibaba_cloud_ecs",
+		"cloud.region":            "cn-hangzhou",
+		"cloud.availability_zone": "cn-hangzhou-i",
+		"cloud.account.id":        "1609****",
+		"host.id":                 "i-bp1iw9mv7uaaxxxx",
+		"host.type":               "ecs.g6e.large",
+		"host.image.id":           "aliyun_3_x64_20G_alibase_2021****.vhd",
+	}, res.Attributes().AsRaw())
+}
+
+func TestDetectDisabledAttribute(t *testing.T) {
+	cfg := CreateDefaultConfig()
+	cfg.Endpoint = newMetadataServer(t).URL
+	cfg.ResourceAttributes.HostImageID.Enabled = false
+	d, err := NewDetector(processortest.NewNopSettings(), cfg)
+	require.NoError(t, err)
+
+	res, _, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	_, ok := res.Attributes().Get("host.image.id")
+	assert.False(t, ok)
+	assert.Equal(t, 7, res.Attributes().Len())
+}
+
+func TestDetectUnavailable(t *testing.T) {
+	ts := httptest.NewServer(http.NotFoundHandler())
+	defer ts.Close()
+
+	cfg := CreateDefaultConfig()
+	cfg.Endpoint = ts.URL
+	d, err := NewDetector(processortest.NewNopSettings(), cfg)
+	require.NoError(t, err)
+
+	res, schemaURL, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, schemaURL)
+	assert.Equal(t, 0, res.Attributes().Len())
+}
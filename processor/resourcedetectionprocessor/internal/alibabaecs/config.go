@@ -0,0 +1,21 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package alibabaecs // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/alibabaecs"
+
+import (
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/alibabaecs/internal/metadata"
+)
+
+type Config struct {
+	// Endpoint is the endpoint of the Alibaba Cloud ECS instance metadata service. It defaults to the
+	// endpoint of the cloud, and can be set to a local stand-in of the service.
+	Endpoint           string                            `mapstructure:"endpoint"`
+	ResourceAttributes metadata.ResourceAttributesConfig `mapstructure:"resource_attributes"`
+}
+
+func CreateDefaultConfig() Config {
+	return Config{
+		ResourceAttributes: metadata.DefaultResourceAttributesConfig(),
+	}
+}
@@ -0,0 +1,21 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package digitalocean // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/digitalocean"
+
+import (
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/digitalocean/internal/metadata"
+)
+
+type Config struct {
+	// Endpoint is the endpoint of the DigitalOcean droplet metadata service. It defaults to the
+	// endpoint of the cloud, and can be set to a local stand-in of the service.
+	Endpoint           string                            `mapstructure:"endpoint"`
+	ResourceAttributes metadata.ResourceAttributesConfig `mapstructure:"resource_attributes"`
+}
+
+func CreateDefaultConfig() Config {
+	return Config{
+		ResourceAttributes: metadata.DefaultResourceAttributesConfig(),
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package digitalocean // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/digitalocean"
+
+import (
+	"context"
+	"strconv"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/processor"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/metadataproviders/digitalocean"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/digitalocean/internal/metadata"
+)
+
+const (
+	// TypeStr is type of detector.
+	TypeStr = "digitalocean"
+
+	// cloudProvider is not a well-known value of cloud.provider
+	cloudProvider = "digitalocean"
+)
+
+var _ internal.Detector = (*Detector)(nil)
+
+// Detector is a DigitalOcean metadata detector
+type Detector struct {
+	provider digitalocean.Provider
+	logger   *zap.Logger
+	rb       *metadata.ResourceBuilder
+}
+
+// NewDetector creates a new DigitalOcean metadata detector
+func NewDetector(p processor.Settings, dcfg internal.DetectorConfig) (internal.Detector, error) {
+	cfg := dcfg.(Config)
+	return &Detector{
+		provider: digitalocean.NewProvider(cfg.Endpoint),
+		logger:   p.Logger,
+		rb:       metadata.NewResourceBuilder(cfg.ResourceAttributes),
+	}, nil
+}
+
+// Detect detects DigitalOcean metadata and returns a resource with the available ones
+func (d *Detector) Detect(ctx context.Context) (resource pcommon.Resource, schemaURL string, err error) {
+	meta, err := d.provider.Metadata(ctx)
+	if err != nil {
+		d.logger.Debug("DigitalOcean detector metadata retrieval failed", zap.Error(err))
+		// return an empty Resource and no error
+		return pcommon.NewResource(), "", nil
+	}
+
+	d.rb.SetCloudProvider(cloudProvider)
+	d.rb.SetCloudRegion(meta.Region)
+	d.rb.SetHostID(strconv.FormatInt(meta.DropletID, 10))
+	d.rb.SetHostName(meta.Hostname)
+
+	return d.rb.Emit(), conventions.SchemaURL, nil
+}
@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package digitalocean
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/processor/processortest"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+)
+
+func newMetadataServer(t *testing.T) *httptest.Server {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/metadata/v1.json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, err := w.Write([]byte(`{"droplet_id": 2756294, "hostname": "sample-droplet", "region": "nyc3"}`))
+		assert.NoError(t, err)
+	}))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func TestNewDetector(t *testing.T) {
+	d, err := NewDetector(processortest.NewNopSettings(), CreateDefaultConfig())
+	require.NoError(t, err)
+	assert.NotNil(t, d)
+}
+
+func TestDetect(t *testing.T) {
+	cfg := CreateDefaultConfig()
+	cfg.Endpoint = newMetadataServer(t).URL
+	d, err := NewDetector(processortest.NewNopSettings(), cfg)
+	require.NoError(t, err)
+
+	res, schemaURL, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, conventions.SchemaURL, schemaURL)
+	assert.Equal(t, map[string]any{
+		"cloud.provider": "digitalocean",
+		"cloud.region":   "nyc3",
+		"host.id":        "2756294",
+		"host.name":      "sample-droplet",
+	}, res.Attributes().AsRaw())
+}
+
+func TestDetectDisabledAttribute(t *testing.T) {
+	cfg := CreateDefaultConfig()
+	cfg.Endpoint = newMetadataServer(t).URL
+	cfg.ResourceAttributes.HostName.Enabled = false
+	d, err := NewDetector(processortest.NewNopSettings(), cfg)
+	require.NoError(t, err)
+
+	res, _, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	_, ok := res.Attributes().Get("host.name")
+	assert.False(t, ok)
+	assert.Equal(t, 3, res.Attributes().Len())
+}
+
+func TestDetectUnavailable(t *testing.T) {
+	ts := httptest.NewServer(http.NotFoundHandler())
+	defer ts.Close()
+
+	cfg := CreateDefaultConfig()
+	cfg.Endpoint = ts.URL
+	d, err := NewDetector(processortest.NewNopSettings(), cfg)
+	require.NoError(t, err)
+
+	res, schemaURL, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, schemaURL)
+	assert.Equal(t, 0, res.Attributes().Len())
+}
@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package hetzner
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/processor/processortest"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+)
+
+func newMetadataServer(t *testing.T) *httptest.Server {
+	mux := http.NewServeMux()
+	for key, value := range map[string]string{
+		"instance-id":       "42",
+		"hostname":          "web-1",
+		"region":            "eu-central",
+		"availability-zone": "fsn1-dc14",
+	} {
+		mux.HandleFunc("/hetzner/v1/metadata/"+key, func(w http.ResponseWriter, _ *http.Request) {
+			_, err := w.Write([]byte(value))
+			assert.NoError(t, err)
+		})
+	}
+	ts := httptest.NewServer(mux)
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func TestNewDetector(t *testing.T) {
+	d, err := NewDetector(processortest.NewNopSettings(), CreateDefaultConfig())
+	require.NoError(t, err)
+	assert.NotNil(t, d)
+}
+
+func TestDetect(t *testing.T) {
+	cfg := CreateDefaultConfig()
+	cfg.Endpoint = newMetadataServer(t).URL
+	d, err := NewDetector(processortest.NewNopSettings(), cfg)
+	require.NoError(t, err)
+
+	res, schemaURL, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, conventions.SchemaURL, schemaURL)
+	assert.Equal(t, map[string]any{
+		"cloud.provider":          "hetzner",
+		"cloud.region":            "eu-central",
+		"cloud.availability_zone": "fsn1-dc14",
+		"host.id":                 "42",
+		"host.name":               "web-1",
+	}, res.Attributes().AsRaw())
+}
+
+func TestDetectDisabledAttribute(t *testing.T) {
+	cfg := CreateDefaultConfig()
+	cfg.Endpoint = newMetadataServer(t).URL
+	cfg.ResourceAttributes.CloudAvailabilityZone.Enabled = false
+	d, err := NewDetector(processortest.NewNopSettings(), cfg)
+	require.NoError(t, err)
+
+	res, _, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	_, ok := res.Attributes().Get("cloud.availability_zone")
+	assert.False(t, ok)
+	assert.Equal(t, 4, res.Attributes().Len())
+}
+
+func TestDetectUnavailable(t *testing.T) {
+	ts := httptest.NewServer(http.NotFoundHandler())
+	defer ts.Close()
+
+	cfg := CreateDefaultConfig()
+	cfg.Endpoint = ts.URL
+	d, err := NewDetector(processortest.NewNopSettings(), cfg)
+	require.NoError(t, err)
+
+	res, schemaURL, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, schemaURL)
+	assert.Equal(t, 0, res.Attributes().Len())
+}
@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openstack // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/openstack"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/processor"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/metadataproviders/openstack"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/openstack/internal/metadata"
+)
+
+const (
+	// TypeStr is type of detector.
+	TypeStr = "openstack"
+
+	// cloudProvider is not a well-known value of cloud.provider
+	cloudProvider = "openstack"
+)
+
+var _ internal.Detector = (*Detector)(nil)
+
+// Detector is a OpenStack metadata detector
+type Detector struct {
+	provider openstack.Provider
+	logger   *zap.Logger
+	rb       *metadata.ResourceBuilder
+}
+
+// NewDetector creates a new OpenStack metadata detector
+func NewDetector(p processor.Settings, dcfg internal.DetectorConfig) (internal.Detector, error) {
+	cfg := dcfg.(Config)
+	return &Detector{
+		provider: openstack.NewProvider(cfg.Endpoint),
+		logger:   p.Logger,
+		rb:       metadata.NewResourceBuilder(cfg.ResourceAttributes),
+	}, nil
+}
+
+// Detect detects OpenStack metadata and returns a resource with the available ones
+func (d *Detector) Detect(ctx context.Context) (resource pcommon.Resource, schemaURL string, err error) {
+	meta, err := d.provider.Metadata(ctx)
+	if err != nil {
+		d.logger.Debug("OpenStack detector metadata retrieval failed", zap.Error(err))
+		// return an empty Resource and no error
+		return pcommon.NewResource(), "", nil
+	}
+
+	d.rb.SetCloudProvider(cloudProvider)
+	d.rb.SetCloudAvailabilityZone(meta.AvailabilityZone)
+	d.rb.SetCloudAccountID(meta.ProjectID)
+	d.rb.SetHostID(meta.UUID)
+	d.rb.SetHostName(meta.Hostname)
+	d.rb.SetHostType(meta.InstanceType)
+
+	return d.rb.Emit(), conventions.SchemaURL, nil
+}
@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openstack
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/processor/processortest"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+)
+
+func newMetadataServer(t *testing.T) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/openstack/latest/meta_data.json", func(w http.ResponseWriter, _ *http.Request) {
+		_, err := w.Write([]byte(`{"uuid": "d8e02d56-2648-49a3-bf97-6be8f1204f38", "name": "web-1", "hostname": "web-1.novalocal", "availability_zone": "nova", "project_id": "f7ac731cc11f40efbc03a9f9e1d1d21f"}`))
+		assert.NoError(t, err)
+	})
+	mux.HandleFunc("/latest/meta-data/instance-type", func(w http.ResponseWriter, _ *http.Request) {
+		_, err := w.Write([]byte("m1.small"))
+		assert.NoError(t, err)
+	})
+	ts := httptest.NewServer(mux)
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func TestNewDetector(t *testing.T) {
+	d, err := NewDetector(processortest.NewNopSettings(), CreateDefaultConfig())
+	require.NoError(t, err)
+	assert.NotNil(t, d)
+}
+
+func TestDetect(t *testing.T) {
+	cfg := CreateDefaultConfig()
+	cfg.Endpoint = newMetadataServer(t).URL
+	d, err := NewDetector(processortest.NewNopSettings(), cfg)
+	require.NoError(t, err)
+
+	res, schemaURL, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, conventions.SchemaURL, schemaURL)
+	assert.Equal(t, map[string]any{
+		"cloud.provider":          "openstack",
+		"cloud.availability_zone": "nova",
+		"cloud.account.id":        "f7ac731cc11f40efbc03a9f9e1d1d21f",
+		"host.id":                 "d8e02d56-2648-49a3-bf97-6be8f1204f38",
+		"host.name":               "web-1.novalocal",
+		"host.type":               "m1.small",
+	}, res.Attributes().AsRaw())
+}
+
+func TestDetectDisabledAttribute(t *testing.T) {
+	cfg := CreateDefaultConfig()
+	cfg.Endpoint = newMetadataServer(t).URL
+	cfg.ResourceAttributes.HostName.Enabled = false
+	d, err := NewDetector(processortest.NewNopSettings(), cfg)
+	require.NoError(t, err)
+
+	res, _, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	_, ok := res.Attributes().Get("host.name")
+	assert.False(t, ok)
+	assert.Equal(t, 5, res.Attributes().Len())
+}
+
+func TestDetectUnavailable(t *testing.T) {
+	ts := httptest.NewServer(http.NotFoundHandler())
+	defer ts.Close()
+
+	cfg := CreateDefaultConfig()
+	cfg.Endpoint = ts.URL
+	d, err := NewDetector(processortest.NewNopSettings(), cfg)
+	require.NoError(t, err)
+
+	res, schemaURL, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, schemaURL)
+	assert.Equal(t, 0, res.Attributes().Len())
+}
@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package oraclecloud // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/oraclecloud"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/processor"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/metadataproviders/oraclecloud"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/oraclecloud/internal/metadata"
+)
+
+const (
+	// TypeStr is type of detector.
+	TypeStr = "oraclecloud"
+
+	// cloudProvider is not a well-known value of cloud.provider
+	cloudProvider = "oracle_cloud"
+)
+
+var _ internal.Detector = (*Detector)(nil)
+
+// Detector is a Oracle Cloud metadata detector
+type Detector struct {
+	provider oraclecloud.Provider
+	logger   *zap.Logger
+	rb       *metadata.ResourceBuilder
+}
+
+// NewDetector creates a new Oracle Cloud metadata detector
+func NewDetector(p processor.Settings, dcfg internal.DetectorConfig) (internal.Detector, error) {
+	cfg := dcfg.(Config)
+	return &Detector{
+		provider: oraclecloud.NewProvider(cfg.Endpoint),
+		logger:   p.Logger,
+		rb:       metadata.NewResourceBuilder(cfg.ResourceAttributes),
+	}, nil
+}
+
+// Detect detects Oracle Cloud metadata and returns a resource with the available ones
+func (d *Detector) Detect(ctx context.Context) (resource pcommon.Resource, schemaURL string, err error) {
+	meta, err := d.provider.Metadata(ctx)
+	if err != nil {
+		d.logger.Debug("Oracle Cloud detector metadata retrieval failed", zap.Error(err))
+		// return an empty Resource and no error
+		return pcommon.NewResource(), "", nil
+	}
+
+	d.rb.SetCloudProvider(cloudProvider)
+	d.rb.SetCloudRegion(meta.CanonicalRegionName)
+	d.rb.SetCloudAvailabilityZone(meta.AvailabilityDomain)
+	d.rb.SetCloudAccountID(meta.TenantID)
+	d.rb.SetHostID(meta.ID)
+	d.rb.SetHostName(meta.Hostname)
+	d.rb.SetHostType(meta.Shape)
+	d.rb.SetOciCompartmentID(meta.CompartmentID)
+	d.rb.SetOciFaultDomain(meta.FaultDomain)
+
+	return d.rb.Emit(), conventions.SchemaURL, nil
+}
@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package oraclecloud
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/processor/processortest"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+)
+
+func newMetadataServer(t *testing.T) *httptest.Server {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/opc/v2/instance/" || r.Header.Get("Authorization") != "Bearer Oracle" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, err := w.Write([]byte(`{"id": "ocid1.instance.oc1.phx.cccc", "hostname": "web-1", "canonicalRegionName": "us-phoenix-1", "availabilityDomain": "Uocm:PHX-AD-1", "faultDomain": "FAULT-DOMAIN-2", "compartmentId": "ocid1.compartment.oc1..aaaa", "tenantId": "ocid1.tenancy.oc1..bbbb", "shape": "VM.Standard.E4.Flex"}`))
+		assert.NoError(t, err)
+	}))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func TestNewDetector(t *testing.T) {
+	d, err := NewDetector(processortest.NewNopSettings(), CreateDefaultConfig())
+	require.NoError(t, err)
+	assert.NotNil(t, d)
+}
+
+func TestDetect(t *testing.T) {
+	cfg := CreateDefaultConfig()
+	cfg.Endpoint = newMetadataServer(t).URL
+	d, err := NewDetector(processortest.NewNopSettings(), cfg)
+	require.NoError(t, err)
+
+	res, schemaURL, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, conventions.SchemaURL, schemaURL)
+	assert.Equal(t, map[string]any{
+		"cloud.provider":          "oracle_cloud",
+		"cloud.region":            "us-phoenix-1",
+		"cloud.availability_zone": "Uocm:PHX-AD-1",
+		"cloud.account.id":        "ocid1.tenancy.oc1..bbbb",
+		"host.id":                 "ocid1.instance.oc1.phx.cccc",
+		"host.name":               "web-1",
+		"host.type":               "VM.Standard.E4.Flex",
+		"oci.compartment.id":      "ocid1.compartment.oc1..aaaa",
+		"oci.fault_domain":        "FAULT-DOMAIN-2",
+	}, res.Attributes().AsRaw())
+}
+
+func TestDetectDisabledAttribute(t *testing.T) {
+	cfg := CreateDefaultConfig()
+	cfg.Endpoint = newMetadataServer(t).URL
+	cfg.ResourceAttributes.OciFaultDomain.Enabled = false
+	d, err := NewDetector(processortest.NewNopSettings(), cfg)
+	require.NoError(t, err)
+
+	res, _, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	_, ok := res.Attributes().Get("oci.fault_domain")
+	assert.False(t, ok)
+	assert.Equal(t, 8, res.Attributes().Len())
+}
+
+func TestDetectUnavailable(t *testing.T) {
+	ts := httptest.NewServer(http.NotFoundHandler())
+	defer ts.Close()
+
+	cfg := CreateDefaultConfig()
+	cfg.Endpoint = ts.URL
+	d, err := NewDetector(processortest.NewNopSettings(), cfg)
+	require.NoError(t, err)
+
+	res, schemaURL, err := d.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, schemaURL)
+	assert.Equal(t, 0, res.Attributes().Len())
+}
@@ -7,9 +7,12 @@ package internal // import "github.com/open-telemetry/opentelemetry-collector-co
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"reflect"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"go.opentelemetry.io/collector/pdata/pcommon"
@@ -85,9 +88,23 @@ type ResourceProvider struct {
 	logger           *zap.Logger
 	timeout          time.Duration
 	detectors        []Detector
-	detectedResource *resourceResult
+	detectedResource atomic.Pointer[resourceResult]
 	once             sync.Once
 	attributesToKeep map[string]struct{}
+
+	// detected holds the last successful result of each detector, which is
+	// kept when the detector fails during a refresh.
+	detected []*resourceResult
+	// detecting is set while a call to a detector is in flight, including a call
+	// abandoned after the timeout, so that a hanging detector is not called again.
+	detecting []atomic.Bool
+
+	refreshLock sync.Mutex
+	// refreshers is the number of callers of StartRefreshing which didn't call
+	// StopRefreshing yet.
+	refreshers  int
+	stopRefresh context.CancelFunc
+	refreshDone chan struct{}
 }
 
 type resourceResult struct {
@@ -96,53 +113,193 @@ type resourceResult struct {
 	err       error
 }
 
+// NewResourceProvider creates a provider merging the resources of detectors. The detection
+// of each detector is bounded by timeout, when set.
 func NewResourceProvider(logger *zap.Logger, timeout time.Duration, attributesToKeep map[string]struct{}, detectors ...Detector) *ResourceProvider {
 	return &ResourceProvider{
 		logger:           logger,
 		timeout:          timeout,
 		detectors:        detectors,
 		attributesToKeep: attributesToKeep,
+		detected:         make([]*resourceResult, len(detectors)),
+		detecting:        make([]atomic.Bool, len(detectors)),
 	}
 }
 
 func (p *ResourceProvider) Get(ctx context.Context, client *http.Client) (resource pcommon.Resource, schemaURL string, err error) {
 	p.once.Do(func() {
 		var cancel context.CancelFunc
-		ctx, cancel = context.WithTimeout(ctx, client.Timeout)
+		ctx, cancel = p.withDetectionTimeout(ctx, client)
 		defer cancel()
-		p.detectResource(ctx)
+
+		p.logger.Info("began detecting resource information")
+		detected, droppedAttributes := p.detectResource(ctx)
+		p.logger.Info("detected resource information", zap.Any("resource", detected.resource.Attributes().AsRaw()))
+		if len(droppedAttributes) > 0 {
+			p.logger.Info("dropped resource information", zap.Strings("resource keys", droppedAttributes))
+		}
+		p.detectedResource.Store(detected)
 	})
 
-	return p.detectedResource.resource, p.detectedResource.schemaURL, p.detectedResource.err
+	detected := p.detectedResource.Load()
+	return detected.resource, detected.schemaURL, detected.err
+}
+
+// Current returns the last detected resource. It must be called after Get.
+func (p *ResourceProvider) Current() (resource pcommon.Resource, schemaURL string) {
+	detected := p.detectedResource.Load()
+	return detected.resource, detected.schemaURL
+}
+
+// StartRefreshing detects the resource again every interval, until StopRefreshing is
+// called as many times as StartRefreshing. The detectors are given the values of ctx.
+// It must be called after Get.
+func (p *ResourceProvider) StartRefreshing(ctx context.Context, client *http.Client, interval time.Duration) {
+	p.refreshLock.Lock()
+	defer p.refreshLock.Unlock()
+
+	p.refreshers++
+	if p.refreshers > 1 {
+		return
+	}
+
+	ctx, p.stopRefresh = context.WithCancel(context.WithoutCancel(ctx))
+	done := make(chan struct{})
+	p.refreshDone = done
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				p.refresh(ctx, client)
+			}
+		}
+	}()
+}
+
+// StopRefreshing stops the refreshes started by StartRefreshing, once all of its callers
+// called StopRefreshing, and waits for the current refresh to return.
+func (p *ResourceProvider) StopRefreshing() {
+	p.refreshLock.Lock()
+	defer p.refreshLock.Unlock()
+
+	if p.refreshers == 0 {
+		return
+	}
+	p.refreshers--
+	if p.refreshers > 0 {
+		return
+	}
+	p.stopRefresh()
+	<-p.refreshDone
+}
+
+// refresh detects the resource again and swaps it in, logging the changes.
+func (p *ResourceProvider) refresh(ctx context.Context, client *http.Client) {
+	ctx, cancel := p.withDetectionTimeout(ctx, client)
+	defer cancel()
+
+	detected, _ := p.detectResource(ctx)
+	if errors.Is(ctx.Err(), context.Canceled) {
+		// The refreshes were stopped during the detection
+		return
+	}
+
+	previous := p.detectedResource.Load()
+	previousAttributes := previous.resource.Attributes().AsRaw()
+	attributes := detected.resource.Attributes().AsRaw()
+	if reflect.DeepEqual(previousAttributes, attributes) && previous.schemaURL == detected.schemaURL {
+		p.logger.Debug("resource information did not change")
+		return
+	}
+
+	p.logger.Info("detected resource information changed",
+		zap.Any("previous_resource", previousAttributes),
+		zap.Any("resource", attributes))
+	p.detectedResource.Store(detected)
 }
 
-func (p *ResourceProvider) detectResource(ctx context.Context) {
-	p.detectedResource = &resourceResult{}
+// withDetectionTimeout bounds the detection by all the detectors. When each detector is bounded by
+// the timeout of the provider, every detector is given its whole timeout, so that a hanging detector
+// doesn't use up the time of the following ones. Otherwise the detection is bounded by the timeout of
+// client, unless it has none, like http.Client.
+func (p *ResourceProvider) withDetectionTimeout(ctx context.Context, client *http.Client) (context.Context, context.CancelFunc) {
+	timeout := client.Timeout
+	if p.timeout > 0 {
+		timeout = p.timeout * time.Duration(len(p.detectors))
+	}
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
 
+// detectResource merges the resources of all the detectors. A detector which fails, or
+// detects an empty resource, keeps its previous result. It returns the attributes dropped by the attributes filter.
+func (p *ResourceProvider) detectResource(ctx context.Context) (*resourceResult, []string) {
 	res := pcommon.NewResource()
 	mergedSchemaURL := ""
 
-	p.logger.Info("began detecting resource information")
-
-	for _, detector := range p.detectors {
-		r, schemaURL, err := detector.Detect(ctx)
+	for i, detector := range p.detectors {
+		r, schemaURL, err := p.detect(ctx, i, detector)
 		if err != nil {
 			p.logger.Warn("failed to detect resource", zap.Error(err))
+			if p.detected[i] == nil {
+				continue
+			}
+			r, schemaURL = p.detected[i].resource, p.detected[i].schemaURL
+		} else if IsEmptyResource(r) && p.detected[i] != nil {
+			// Most detectors report an unreachable metadata service with an
+			// empty resource rather than an error.
+			r, schemaURL = p.detected[i].resource, p.detected[i].schemaURL
 		} else {
-			mergedSchemaURL = MergeSchemaURL(mergedSchemaURL, schemaURL)
-			MergeResource(res, r, false)
+			p.detected[i] = &resourceResult{resource: r, schemaURL: schemaURL}
 		}
+		mergedSchemaURL = MergeSchemaURL(mergedSchemaURL, schemaURL)
+		MergeResource(res, r, false)
 	}
 
 	droppedAttributes := filterAttributes(res.Attributes(), p.attributesToKeep)
 
-	p.logger.Info("detected resource information", zap.Any("resource", res.Attributes().AsRaw()))
-	if len(droppedAttributes) > 0 {
-		p.logger.Info("dropped resource information", zap.Strings("resource keys", droppedAttributes))
+	return &resourceResult{resource: res, schemaURL: mergedSchemaURL}, droppedAttributes
+}
+
+// detect runs the detector at index i, giving up after the timeout of the provider so
+// that a hanging detector doesn't block the following ones. The detector is skipped while
+// a previous call, abandoned after the timeout, didn't return.
+func (p *ResourceProvider) detect(ctx context.Context, i int, detector Detector) (pcommon.Resource, string, error) {
+	if p.timeout <= 0 {
+		return detector.Detect(ctx)
 	}
+	if !p.detecting[i].CompareAndSwap(false, true) {
+		return pcommon.Resource{}, "", errors.New("previous detection did not complete yet")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
 
-	p.detectedResource.resource = res
-	p.detectedResource.schemaURL = mergedSchemaURL
+	type detection struct {
+		resource  pcommon.Resource
+		schemaURL string
+		err       error
+	}
+	detected := make(chan detection, 1)
+	go func() {
+		defer p.detecting[i].Store(false)
+		r, schemaURL, err := detector.Detect(ctx)
+		detected <- detection{resource: r, schemaURL: schemaURL, err: err}
+	}()
+
+	select {
+	case d := <-detected:
+		return d.resource, d.schemaURL, d.err
+	case <-ctx.Done():
+		return pcommon.Resource{}, "", fmt.Errorf("detection did not complete: %w", ctx.Err())
+	}
 }
 
 func MergeSchemaURL(currentSchemaURL string, newSchemaURL string) string {
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"net/http"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -19,6 +20,7 @@ import (
 	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/processor/processortest"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
 )
 
 type MockDetector struct {
@@ -317,3 +319,198 @@ func TestFilterAttributes_NoAttributes(t *testing.T) {
 
 	assert.Empty(t, droppedAttributes)
 }
+
+// sequenceDetector detects the given resources in turn, and then the last one.
+type sequenceDetector struct {
+	mu         sync.Mutex
+	detections []map[string]any
+	errs       []error
+	calls      int
+}
+
+func (d *sequenceDetector) Detect(_ context.Context) (pcommon.Resource, string, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	i := min(d.calls, len(d.detections)-1)
+	d.calls++
+	res := pcommon.NewResource()
+	if err := res.Attributes().FromRaw(d.detections[i]); err != nil {
+		return res, "", err
+	}
+	if i < len(d.errs) && d.errs[i] != nil {
+		return pcommon.NewResource(), "", d.errs[i]
+	}
+	return res, "", nil
+}
+
+func (d *sequenceDetector) numCalls() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.calls
+}
+
+func TestDetectResource_DetectorTimeout(t *testing.T) {
+	hanging := NewMockParallelDetector()
+	hanging.On("Detect").Return(pcommon.NewResource(), nil)
+	defer close(hanging.ch)
+
+	md := &MockDetector{}
+	res := pcommon.NewResource()
+	res.Attributes().PutStr("a", "1")
+	md.On("Detect").Return(res, nil)
+
+	p := NewResourceProvider(zap.NewNop(), 10*time.Millisecond, nil, hanging, md)
+	got, _, err := p.Get(context.Background(), &http.Client{Timeout: time.Second})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"a": "1"}, got.Attributes().AsRaw())
+}
+
+// delayedDetector detects its attributes after a delay, unless the context is done first.
+type delayedDetector struct {
+	delay      time.Duration
+	attributes map[string]string
+}
+
+func (d *delayedDetector) Detect(ctx context.Context) (pcommon.Resource, string, error) {
+	select {
+	case <-ctx.Done():
+		return pcommon.NewResource(), "", ctx.Err()
+	case <-time.After(d.delay):
+	}
+	res := pcommon.NewResource()
+	for k, v := range d.attributes {
+		res.Attributes().PutStr(k, v)
+	}
+	return res, "", nil
+}
+
+func TestDetectResource_DefaultTimeoutWithHangingFirstDetector(t *testing.T) {
+	hanging := &hangingDetector{release: make(chan struct{})}
+	defer close(hanging.release)
+	d := &delayedDetector{delay: 10 * time.Millisecond, attributes: map[string]string{"a": "1"}}
+
+	// By default, the timeout of each detector is the timeout of the client.
+	client := &http.Client{Timeout: 50 * time.Millisecond}
+	p := NewResourceProvider(zap.NewNop(), client.Timeout, nil, hanging, d)
+	got, _, err := p.Get(context.Background(), client)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"a": "1"}, got.Attributes().AsRaw())
+}
+
+// hangingDetector blocks until released, ignoring the context.
+type hangingDetector struct {
+	calls   atomic.Int32
+	release chan struct{}
+}
+
+func (d *hangingDetector) Detect(_ context.Context) (pcommon.Resource, string, error) {
+	d.calls.Add(1)
+	<-d.release
+	return pcommon.NewResource(), "", nil
+}
+
+func TestResourceProvider_RefreshSkipsHangingDetector(t *testing.T) {
+	hanging := &hangingDetector{release: make(chan struct{})}
+	d := &sequenceDetector{detections: []map[string]any{{"a": "1"}}}
+
+	p := NewResourceProvider(zap.NewNop(), 10*time.Millisecond, nil, hanging, d)
+	_, _, err := p.Get(context.Background(), http.DefaultClient)
+	require.NoError(t, err)
+
+	p.StartRefreshing(context.Background(), http.DefaultClient, time.Millisecond)
+	assert.Eventually(t, func() bool {
+		return d.numCalls() > 3
+	}, time.Second, time.Millisecond)
+	p.StopRefreshing()
+
+	// The hanging call is not repeated, so that no goroutine accumulates.
+	assert.Equal(t, int32(1), hanging.calls.Load())
+	close(hanging.release)
+	assert.Eventually(t, func() bool {
+		return !p.detecting[0].Load()
+	}, time.Second, time.Millisecond)
+}
+
+func TestResourceProvider_Refresh(t *testing.T) {
+	d := &sequenceDetector{detections: []map[string]any{
+		{"host.type": "m5.large"},
+		{"host.type": "m5.xlarge"},
+	}}
+	core, logs := observer.New(zap.InfoLevel)
+	p := NewResourceProvider(zap.New(core), time.Second, nil, d)
+
+	got, _, err := p.Get(context.Background(), http.DefaultClient)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"host.type": "m5.large"}, got.Attributes().AsRaw())
+
+	p.StartRefreshing(context.Background(), http.DefaultClient, time.Millisecond)
+	assert.Eventually(t, func() bool {
+		current, _ := p.Current()
+		return current.Attributes().AsRaw()["host.type"] == "m5.xlarge"
+	}, time.Second, time.Millisecond)
+	p.StopRefreshing()
+
+	// The initial resource returned by Get is not modified
+	assert.Equal(t, map[string]any{"host.type": "m5.large"}, got.Attributes().AsRaw())
+
+	changes := logs.FilterMessage("detected resource information changed").All()
+	require.Len(t, changes, 1)
+	assert.Equal(t, map[string]any{"host.type": "m5.large"}, changes[0].ContextMap()["previous_resource"])
+	assert.Equal(t, map[string]any{"host.type": "m5.xlarge"}, changes[0].ContextMap()["resource"])
+
+	assertNoMoreDetections(t, d)
+}
+
+func TestResourceProvider_RefreshKeepsPreviousResultOfFailingDetectors(t *testing.T) {
+	failing := &sequenceDetector{
+		detections: []map[string]any{{"a": "1"}, {}},
+		errs:       []error{nil, errors.New("unavailable")},
+	}
+	empty := &sequenceDetector{detections: []map[string]any{{"b": "2"}, {}}}
+	changing := &sequenceDetector{detections: []map[string]any{{"c": "3"}, {"c": "4"}}}
+	p := NewResourceProvider(zap.NewNop(), time.Second, nil, failing, empty, changing)
+
+	_, _, err := p.Get(context.Background(), http.DefaultClient)
+	require.NoError(t, err)
+
+	p.StartRefreshing(context.Background(), http.DefaultClient, time.Millisecond)
+	defer p.StopRefreshing()
+	assert.Eventually(t, func() bool {
+		current, _ := p.Current()
+		return current.Attributes().AsRaw()["c"] == "4"
+	}, time.Second, time.Millisecond)
+
+	current, _ := p.Current()
+	assert.Equal(t, map[string]any{"a": "1", "b": "2", "c": "4"}, current.Attributes().AsRaw())
+}
+
+func TestResourceProvider_RefreshStopsWithLastCaller(t *testing.T) {
+	d := &sequenceDetector{detections: []map[string]any{{"a": "1"}}}
+	p := NewResourceProvider(zap.NewNop(), time.Second, nil, d)
+	_, _, err := p.Get(context.Background(), http.DefaultClient)
+	require.NoError(t, err)
+
+	p.StartRefreshing(context.Background(), http.DefaultClient, time.Millisecond)
+	p.StartRefreshing(context.Background(), http.DefaultClient, time.Millisecond)
+
+	p.StopRefreshing()
+	calls := d.numCalls()
+	assert.Eventually(t, func() bool {
+		return d.numCalls() > calls
+	}, time.Second, time.Millisecond)
+
+	p.StopRefreshing()
+	assertNoMoreDetections(t, d)
+
+	// Extra calls are ignored
+	p.StopRefreshing()
+}
+
+// assertNoMoreDetections checks that d is not called anymore, once the detection
+// which may have been abandoned by a stopped refresh returned.
+func assertNoMoreDetections(t *testing.T, d *sequenceDetector) {
+	time.Sleep(5 * time.Millisecond)
+	calls := d.numCalls()
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, calls, d.numCalls())
+}
@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package scaleway // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/scaleway"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/processor"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/metadataproviders/scaleway"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/scaleway/internal/metadata"
+)
+
+const (
+	// TypeStr is type of detector.
+	TypeStr = "scaleway"
+
+	// cloudProvider is not a well-known value of cloud.provider
+	cloudProvider = "scaleway"
+)
+
+var _ internal.Detector = (*Detector)(nil)
+
+// Detector is a Scaleway metadata detector
+type Detector struct {
+	provider scaleway.Provider
+	logger   *zap.Logger
+	rb       *metadata.ResourceBuilder
+}
+
+// NewDetector creates a new Scaleway metadata detector
+func NewDetector(p processor.Settings, dcfg internal.DetectorConfig) (internal.Detector, error) {
+	cfg := dcfg.(Config)
+	return &Detector{
+		provider: scaleway.NewProvider(cfg.Endpoint),
+		logger:   p.Logger,
+		rb:       metadata.NewResourceBuilder(cfg.ResourceAttributes),
+	}, nil
+}
+
+// Detect detects Scaleway metadata and returns a resource with the available ones
+func (d *Detector) Detect(ctx context.Context) (resource pcommon.Resource, schemaURL string, err error) {
+	meta, err := d.provider.Metadata(ctx)
+	if err != nil {
+		d.logger.Debug("Scaleway detector metadata retrieval failed", zap.Error(err))
+		// return an empty Resource and no error
+		return pcommon.NewResource(), "", nil
+	}
+
+	d.rb.SetCloudProvider(cloudProvider)
+	d.rb.SetCloudRegion(meta.Region())
+	d.rb.SetCloudAvailabilityZone(meta.Location.ZoneID)
+	d.rb.SetCloudAccountID(meta.Project)
+	d.rb.SetHostID(meta.ID)
+	d.rb.SetHostName(meta.Hostname)
+	d.rb.SetHostType(meta.CommercialType)
+	d.rb.SetScalewayOrganizationID(meta.Organization)
+
+	return d.rb.Emit(), conventions.SchemaURL, nil
+}
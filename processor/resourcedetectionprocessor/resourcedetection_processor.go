@@ -5,10 +5,10 @@ package resourcedetectionprocessor // import "github.com/open-telemetry/opentele
 
 import (
 	"context"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/confighttp"
-	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
@@ -18,9 +18,9 @@ import (
 
 type resourceDetectionProcessor struct {
 	provider           *internal.ResourceProvider
-	resource           pcommon.Resource
-	schemaURL          string
 	override           bool
+	refreshInterval    time.Duration
+	refreshing         bool
 	httpClientSettings confighttp.ClientConfig
 	telemetrySettings  component.TelemetrySettings
 }
@@ -29,43 +29,60 @@ type resourceDetectionProcessor struct {
 func (rdp *resourceDetectionProcessor) Start(ctx context.Context, host component.Host) error {
 	client, _ := rdp.httpClientSettings.ToClient(ctx, host, rdp.telemetrySettings)
 	ctx = internal.ContextWithClient(ctx, client)
-	var err error
-	rdp.resource, rdp.schemaURL, err = rdp.provider.Get(ctx, client)
-	return err
+	if _, _, err := rdp.provider.Get(ctx, client); err != nil {
+		return err
+	}
+	if rdp.refreshInterval > 0 {
+		rdp.provider.StartRefreshing(ctx, client, rdp.refreshInterval)
+		rdp.refreshing = true
+	}
+	return nil
+}
+
+// Shutdown is invoked during service shutdown.
+func (rdp *resourceDetectionProcessor) Shutdown(context.Context) error {
+	if rdp.refreshing {
+		rdp.provider.StopRefreshing()
+		rdp.refreshing = false
+	}
+	return nil
 }
 
 // processTraces implements the ProcessTracesFunc type.
 func (rdp *resourceDetectionProcessor) processTraces(_ context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+	resource, schemaURL := rdp.provider.Current()
 	rs := td.ResourceSpans()
 	for i := 0; i < rs.Len(); i++ {
 		rss := rs.At(i)
-		rss.SetSchemaUrl(internal.MergeSchemaURL(rss.SchemaUrl(), rdp.schemaURL))
+		rss.SetSchemaUrl(internal.MergeSchemaURL(rss.SchemaUrl(), schemaURL))
 		res := rss.Resource()
-		internal.MergeResource(res, rdp.resource, rdp.override)
+		internal.MergeResource(res, resource, rdp.override)
 	}
 	return td, nil
 }
 
 // processMetrics implements the ProcessMetricsFunc type.
 func (rdp *resourceDetectionProcessor) processMetrics(_ context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	resource, schemaURL := rdp.provider.Current()
 	rm := md.ResourceMetrics()
 	for i := 0; i < rm.Len(); i++ {
 		rss := rm.At(i)
-		rss.SetSchemaUrl(internal.MergeSchemaURL(rss.SchemaUrl(), rdp.schemaURL))
+		rss.SetSchemaUrl(internal.MergeSchemaURL(rss.SchemaUrl(), schemaURL))
 		res := rss.Resource()
-		internal.MergeResource(res, rdp.resource, rdp.override)
+		internal.MergeResource(res, resource, rdp.override)
 	}
 	return md, nil
 }
 
 // processLogs implements the ProcessLogsFunc type.
 func (rdp *resourceDetectionProcessor) processLogs(_ context.Context, ld plog.Logs) (plog.Logs, error) {
+	resource, schemaURL := rdp.provider.Current()
 	rl := ld.ResourceLogs()
 	for i := 0; i < rl.Len(); i++ {
 		rss := rl.At(i)
-		rss.SetSchemaUrl(internal.MergeSchemaURL(rss.SchemaUrl(), rdp.schemaURL))
+		rss.SetSchemaUrl(internal.MergeSchemaURL(rss.SchemaUrl(), schemaURL))
 		res := rss.Resource()
-		internal.MergeResource(res, rdp.resource, rdp.override)
+		internal.MergeResource(res, resource, rdp.override)
 	}
 	return ld, nil
 }
@@ -263,6 +263,47 @@ func TestResourceProcessor(t *testing.T) {
 	}
 }
 
+func TestResourceProcessorRefresh(t *testing.T) {
+	factory := &factory{providers: map[component.ID]*internal.ResourceProvider{}}
+
+	md := &MockDetector{}
+	initial := pcommon.NewResource()
+	initial.Attributes().PutStr("host.type", "m5.large")
+	md.On("Detect").Return(initial, nil).Once()
+	resized := pcommon.NewResource()
+	resized.Attributes().PutStr("host.type", "m5.xlarge")
+	md.On("Detect").Return(resized, nil)
+	factory.resourceProviderFactory = internal.NewProviderFactory(
+		map[internal.DetectorType]internal.DetectorFactory{"mock": func(processor.Settings, internal.DetectorConfig) (internal.Detector, error) {
+			return md, nil
+		}})
+
+	cfg := &Config{
+		Override:        true,
+		Detectors:       []string{"mock"},
+		ClientConfig:    confighttp.ClientConfig{Timeout: time.Second},
+		RefreshInterval: time.Millisecond,
+	}
+
+	sink := new(consumertest.TracesSink)
+	rtp, err := factory.createTracesProcessor(context.Background(), processortest.NewNopSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, rtp.Start(context.Background(), componenttest.NewNopHost()))
+
+	consumeHostType := func() any {
+		td := ptrace.NewTraces()
+		td.ResourceSpans().AppendEmpty()
+		require.NoError(t, rtp.ConsumeTraces(context.Background(), td))
+		traces := sink.AllTraces()
+		return traces[len(traces)-1].ResourceSpans().At(0).Resource().Attributes().AsRaw()["host.type"]
+	}
+	assert.Eventually(t, func() bool {
+		return consumeHostType() == "m5.xlarge"
+	}, time.Second, time.Millisecond)
+
+	require.NoError(t, rtp.Shutdown(context.Background()))
+}
+
 func benchmarkConsumeTraces(b *testing.B, cfg *Config) {
 	factory := NewFactory()
 	sink := new(consumertest.TracesSink)
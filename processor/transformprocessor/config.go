@@ -15,6 +15,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/common"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/logs"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/metrics"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/state"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/traces"
 )
 
@@ -41,7 +42,14 @@ type Config struct {
 	LogStatements    []common.ContextStatements `mapstructure:"log_statements"`
 
 	FlattenData bool `mapstructure:"flatten_data"`
-	logger      *zap.Logger
+
+	// LookupTables are the tables, by name, which statements can query with the `Lookup` function.
+	LookupTables map[string]state.LookupTableConfig `mapstructure:"lookup_tables"`
+	// Caches are the key-value caches, by name, which statements can write with the `remember`
+	// function and read with the `Recall` function.
+	Caches map[string]state.CacheConfig `mapstructure:"caches"`
+
+	logger *zap.Logger
 }
 
 var _ component.Config = (*Config)(nil)
@@ -53,8 +61,10 @@ func (c *Config) Validate() error {
 		c.logger.Sugar().Infof("Metric conversion functions use metric context since %s is enabled. If your statements are not parsing, check if you're using the metrics conversion functions via the datapoint context.", metrics.UseConvertBetweenSumAndGaugeMetricContext.ID())
 	}
 
+	s := c.newState(zap.NewNop())
+
 	if len(c.TraceStatements) > 0 {
-		pc, err := common.NewTraceParserCollection(component.TelemetrySettings{Logger: zap.NewNop()}, common.WithSpanParser(traces.SpanFunctions()), common.WithSpanEventParser(traces.SpanEventFunctions()))
+		pc, err := common.NewTraceParserCollection(component.TelemetrySettings{Logger: zap.NewNop()}, common.WithSpanParser(traces.SpanFunctions(s)), common.WithSpanEventParser(traces.SpanEventFunctions(s)))
 		if err != nil {
 			return err
 		}
@@ -67,7 +77,7 @@ func (c *Config) Validate() error {
 	}
 
 	if len(c.MetricStatements) > 0 {
-		pc, err := common.NewMetricParserCollection(component.TelemetrySettings{Logger: zap.NewNop()}, common.WithMetricParser(metrics.MetricFunctions()), common.WithDataPointParser(metrics.DataPointFunctions()))
+		pc, err := common.NewMetricParserCollection(component.TelemetrySettings{Logger: zap.NewNop()}, common.WithMetricParser(metrics.MetricFunctions(s)), common.WithDataPointParser(metrics.DataPointFunctions(s)))
 		if err != nil {
 			return err
 		}
@@ -80,7 +90,7 @@ func (c *Config) Validate() error {
 	}
 
 	if len(c.LogStatements) > 0 {
-		pc, err := common.NewLogParserCollection(component.TelemetrySettings{Logger: zap.NewNop()}, common.WithLogParser(logs.LogFunctions()))
+		pc, err := common.NewLogParserCollection(component.TelemetrySettings{Logger: zap.NewNop()}, common.WithLogParser(logs.LogFunctions(s)))
 		if err != nil {
 			return err
 		}
@@ -98,3 +108,8 @@ func (c *Config) Validate() error {
 
 	return errors
 }
+
+// newState creates the lookup tables and caches of a processor.
+func (c *Config) newState(logger *zap.Logger) *state.State {
+	return state.New(c.LookupTables, c.Caches, logger)
+}
@@ -6,6 +6,7 @@ package transformprocessor
 import (
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"go.opentelemetry.io/collector/component"
@@ -15,6 +16,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/common"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/state"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -125,6 +127,43 @@ func TestLoadConfig(t *testing.T) {
 				LogStatements:    []common.ContextStatements{},
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "lookup_tables_and_caches"),
+			expected: &Config{
+				ErrorMode:        ottl.PropagateError,
+				TraceStatements:  []common.ContextStatements{},
+				MetricStatements: []common.ContextStatements{},
+				LogStatements: []common.ContextStatements{
+					{
+						Context: "log",
+						Statements: []string{
+							`set(attributes["team"], Lookup("owners", resource.attributes["service.name"], "team"))`,
+							`remember("sessions", attributes["session.id"], attributes["user.id"]) where attributes["user.id"] != nil`,
+							`set(attributes["user.id"], Recall("sessions", attributes["session.id"])) where attributes["user.id"] == nil`,
+						},
+					},
+				},
+				LookupTables: map[string]state.LookupTableConfig{
+					"owners": {
+						Path:           "/etc/otelcol/owners.csv",
+						Key:            "service",
+						ReloadInterval: 30 * time.Second,
+					},
+				},
+				Caches: map[string]state.CacheConfig{
+					"sessions": {
+						MaxSize: 1000,
+						TTL:     time.Hour,
+					},
+				},
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "unknown_lookup_table"),
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "invalid_cache"),
+		},
 		{
 			id: component.NewIDWithName(metadata.Type, "bad_syntax_trace"),
 		},
@@ -5,9 +5,12 @@ package transformprocessor
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/consumer/consumertest"
@@ -19,6 +22,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/common"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/state"
 )
 
 func TestFactory_Type(t *testing.T) {
@@ -272,6 +276,50 @@ func TestFactoryCreateLogProcessor(t *testing.T) {
 	}
 }
 
+func TestFactoryCreateLogs_LookupTablesAndCaches(t *testing.T) {
+	tablePath := filepath.Join(t.TempDir(), "owners.csv")
+	require.NoError(t, os.WriteFile(tablePath, []byte("service,team\ncheckout,payments\n"), 0600))
+
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	oCfg := cfg.(*Config)
+	oCfg.LookupTables = map[string]state.LookupTableConfig{
+		"owners": {Path: tablePath},
+	}
+	oCfg.Caches = map[string]state.CacheConfig{
+		"sessions": {},
+	}
+	oCfg.LogStatements = []common.ContextStatements{
+		{
+			Context: "log",
+			Statements: []string{
+				`set(attributes["team"], Lookup("owners", resource.attributes["service.name"], "team"))`,
+				`remember("sessions", attributes["session.id"], attributes["user.id"]) where attributes["user.id"] != nil`,
+				`set(attributes["user.id"], Recall("sessions", attributes["session.id"])) where attributes["user.id"] == nil`,
+			},
+		},
+	}
+	sink := new(consumertest.LogsSink)
+	lp, err := factory.CreateLogs(context.Background(), processortest.NewNopSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, lp.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { assert.NoError(t, lp.Shutdown(context.Background())) }()
+
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("service.name", "checkout")
+	logs := rl.ScopeLogs().AppendEmpty().LogRecords()
+	login := logs.AppendEmpty()
+	login.Attributes().PutStr("session.id", "abc")
+	login.Attributes().PutStr("user.id", "42")
+	logs.AppendEmpty().Attributes().PutStr("session.id", "abc")
+	require.NoError(t, lp.ConsumeLogs(context.Background(), ld))
+
+	logs = sink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	assert.Equal(t, map[string]any{"session.id": "abc", "user.id": "42", "team": "payments"}, logs.At(0).Attributes().AsRaw())
+	assert.Equal(t, map[string]any{"session.id": "abc", "user.id": "42", "team": "payments"}, logs.At(1).Attributes().AsRaw())
+}
+
 func TestFactoryCreateResourceProcessor(t *testing.T) {
 	tests := []struct {
 		name       string
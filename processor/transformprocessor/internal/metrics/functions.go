@@ -10,6 +10,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoint"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlmetric"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/state"
 )
 
 var UseConvertBetweenSumAndGaugeMetricContext = featuregate.GlobalRegistry().MustRegister(
@@ -18,7 +19,7 @@ var UseConvertBetweenSumAndGaugeMetricContext = featuregate.GlobalRegistry().Mus
 	featuregate.WithRegisterDescription("When enabled will use metric context for conversion between sum and gauge"),
 )
 
-func DataPointFunctions() map[string]ottl.Factory[ottldatapoint.TransformContext] {
+func DataPointFunctions(s *state.State) map[string]ottl.Factory[ottldatapoint.TransformContext] {
 	functions := ottlfuncs.StandardFuncs[ottldatapoint.TransformContext]()
 
 	datapointFunctions := ottl.CreateFactoryMap[ottldatapoint.TransformContext](
@@ -39,10 +40,14 @@ func DataPointFunctions() map[string]ottl.Factory[ottldatapoint.TransformContext
 		functions[k] = v
 	}
 
+	for _, f := range state.Functions[ottldatapoint.TransformContext](s) {
+		functions[f.Name()] = f
+	}
+
 	return functions
 }
 
-func MetricFunctions() map[string]ottl.Factory[ottlmetric.TransformContext] {
+func MetricFunctions(s *state.State) map[string]ottl.Factory[ottlmetric.TransformContext] {
 	functions := ottlfuncs.StandardFuncs[ottlmetric.TransformContext]()
 
 	metricFunctions := ottl.CreateFactoryMap(
@@ -68,5 +73,9 @@ func MetricFunctions() map[string]ottl.Factory[ottlmetric.TransformContext] {
 		functions[k] = v
 	}
 
+	for _, f := range state.Functions[ottlmetric.TransformContext](s) {
+		functions[f.Name()] = f
+	}
+
 	return functions
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoint"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlmetric"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/state"
 )
 
 func Test_DataPointFunctions(t *testing.T) {
@@ -45,7 +46,10 @@ func Test_DataPointFunctions(t *testing.T) {
 				expected["convert_gauge_to_sum"] = newConvertDatapointGaugeToSumFactory()
 			}
 
-			actual := DataPointFunctions()
+			for _, f := range state.Functions[ottldatapoint.TransformContext](nil) {
+				expected[f.Name()] = f
+			}
+			actual := DataPointFunctions(nil)
 
 			require.Equal(t, len(expected), len(actual))
 			for k := range actual {
@@ -69,7 +73,10 @@ func Test_MetricFunctions(t *testing.T) {
 	expected["scale_metric"] = newScaleMetricFactory()
 	expected["convert_exponential_histogram_to_histogram"] = newconvertExponentialHistToExplicitHistFactory()
 
-	actual := MetricFunctions()
+	for _, f := range state.Functions[ottlmetric.TransformContext](nil) {
+		expected[f.Name()] = f
+	}
+	actual := MetricFunctions(nil)
 	require.Equal(t, len(expected), len(actual))
 	for k := range actual {
 		assert.Contains(t, expected, k)
@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package state // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/state"
+
+import (
+	"bytes"
+	"container/list"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// cache is a key-value cache bounded in size, whose entries expire after a TTL.
+type cache struct {
+	maxSize int
+	ttl     time.Duration
+	now     func() time.Time
+
+	mu sync.Mutex
+	// entries holds the elements of lru by key.
+	entries map[string]*list.Element
+	// lru holds the entries from the most to the least recently used.
+	lru *list.List
+}
+
+type cacheEntry struct {
+	key     string
+	value   any
+	expires time.Time
+}
+
+func newCache(cfg CacheConfig) *cache {
+	c := &cache{
+		maxSize: cfg.MaxSize,
+		ttl:     cfg.TTL,
+		now:     time.Now,
+		entries: map[string]*list.Element{},
+		lru:     list.New(),
+	}
+	if c.maxSize == 0 {
+		c.maxSize = defaultCacheMaxSize
+	}
+	if c.ttl == 0 {
+		c.ttl = defaultCacheTTL
+	}
+	return c
+}
+
+// set stores a copy of value under key, evicting the least recently used entry if the cache is full.
+func (c *cache) set(key string, value any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := &cacheEntry{key: key, value: copyValue(value), expires: c.now().Add(c.ttl)}
+	if elem, ok := c.entries[key]; ok {
+		elem.Value = entry
+		c.lru.MoveToFront(elem)
+		return
+	}
+	if c.lru.Len() >= c.maxSize {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+	}
+	c.entries[key] = c.lru.PushFront(entry)
+}
+
+// get returns the value stored under key, unless it expired.
+func (c *cache) get(key string) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := elem.Value.(*cacheEntry)
+	if !c.now().Before(entry.expires) {
+		c.lru.Remove(elem)
+		delete(c.entries, key)
+		return nil, false
+	}
+	c.lru.MoveToFront(elem)
+	return entry.value, true
+}
+
+func (c *cache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
+
+// copyValue copies the values referencing data which can be modified after being stored.
+func copyValue(val any) any {
+	switch v := val.(type) {
+	case pcommon.Map:
+		m := pcommon.NewMap()
+		v.CopyTo(m)
+		return m
+	case pcommon.Slice:
+		s := pcommon.NewSlice()
+		v.CopyTo(s)
+		return s
+	case pcommon.Value:
+		c := pcommon.NewValueEmpty()
+		v.CopyTo(c)
+		return c
+	case []byte:
+		return bytes.Clone(v)
+	}
+	return val
+}
@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package state
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	c := newCache(CacheConfig{MaxSize: 2})
+	c.set("a", "1")
+	c.set("b", "2")
+	_, ok := c.get("a")
+	assert.True(t, ok)
+
+	c.set("c", "3")
+	assert.Equal(t, 2, c.len())
+	_, ok = c.get("b")
+	assert.False(t, ok, "least recently used entry must be evicted")
+	val, ok := c.get("a")
+	assert.True(t, ok)
+	assert.Equal(t, "1", val)
+	val, ok = c.get("c")
+	assert.True(t, ok)
+	assert.Equal(t, "3", val)
+}
+
+func TestCacheExpiresEntries(t *testing.T) {
+	now := time.Unix(0, 0)
+	c := newCache(CacheConfig{TTL: time.Minute})
+	c.now = func() time.Time { return now }
+
+	c.set("a", "1")
+	now = now.Add(30 * time.Second)
+	c.set("b", "2")
+
+	now = now.Add(30 * time.Second)
+	_, ok := c.get("a")
+	assert.False(t, ok, "entry must expire after its TTL")
+	assert.Equal(t, 1, c.len())
+	val, ok := c.get("b")
+	assert.True(t, ok)
+	assert.Equal(t, "2", val)
+
+	c.set("b", "3")
+	now = now.Add(45 * time.Second)
+	val, ok = c.get("b")
+	assert.True(t, ok, "storing an entry again must renew its TTL")
+	assert.Equal(t, "3", val)
+}
+
+func TestCacheCopiesValues(t *testing.T) {
+	c := newCache(CacheConfig{})
+	m := pcommon.NewMap()
+	m.PutStr("user.id", "42")
+	c.set("session", m)
+	m.PutStr("user.id", "43")
+
+	val, ok := c.get("session")
+	assert.True(t, ok)
+	assert.Equal(t, map[string]any{"user.id": "42"}, val.(pcommon.Map).AsRaw())
+}
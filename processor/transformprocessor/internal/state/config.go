@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package state // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/state"
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"go.uber.org/multierr"
+)
+
+const (
+	FormatCSV  = "csv"
+	FormatJSON = "json"
+
+	defaultReloadInterval = time.Minute
+	defaultCacheMaxSize   = 10000
+	defaultCacheTTL       = 10 * time.Minute
+)
+
+// LookupTableConfig defines a lookup table loaded from a file.
+type LookupTableConfig struct {
+	// Path is the path of the CSV or JSON file holding the table.
+	Path string `mapstructure:"path"`
+	// Format is the format of the file, `csv` or `json`.
+	// It defaults to the extension of Path.
+	Format string `mapstructure:"format"`
+	// Key is the column holding the keys of the rows.
+	// It defaults to the first column of CSV files, and is required for JSON files holding an array of objects.
+	Key string `mapstructure:"key"`
+	// ReloadInterval is how often the file is checked for changes. It defaults to 1 minute.
+	ReloadInterval time.Duration `mapstructure:"reload_interval"`
+}
+
+func (c LookupTableConfig) Validate() error {
+	var errs error
+	if c.Path == "" {
+		errs = multierr.Append(errs, errors.New("path must be specified"))
+	}
+	if format := c.format(); format != FormatCSV && format != FormatJSON {
+		errs = multierr.Append(errs, fmt.Errorf("unsupported format %q, must be %q or %q", format, FormatCSV, FormatJSON))
+	}
+	if c.ReloadInterval < 0 {
+		errs = multierr.Append(errs, errors.New("reload_interval must not be negative"))
+	}
+	return errs
+}
+
+func (c LookupTableConfig) format() string {
+	if c.Format != "" {
+		return c.Format
+	}
+	return strings.TrimPrefix(strings.ToLower(filepath.Ext(c.Path)), ".")
+}
+
+func (c LookupTableConfig) reloadInterval() time.Duration {
+	if c.ReloadInterval == 0 {
+		return defaultReloadInterval
+	}
+	return c.ReloadInterval
+}
+
+// CacheConfig defines a key-value cache shared by the records going through the processor.
+type CacheConfig struct {
+	// MaxSize is the maximum number of entries of the cache. The least recently used entries
+	// are evicted when it is reached. It defaults to 10000.
+	MaxSize int `mapstructure:"max_size"`
+	// TTL is how long an entry is kept after being stored. It defaults to 10 minutes.
+	TTL time.Duration `mapstructure:"ttl"`
+}
+
+func (c CacheConfig) Validate() error {
+	var errs error
+	if c.MaxSize < 0 {
+		errs = multierr.Append(errs, errors.New("max_size must not be negative"))
+	}
+	if c.TTL < 0 {
+		errs = multierr.Append(errs, errors.New("ttl must not be negative"))
+	}
+	return errs
+}
@@ -0,0 +1,132 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package state // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/state"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+// Functions returns the OTTL functions reading and writing the lookup tables and caches of s.
+func Functions[K any](s *State) []ottl.Factory[K] {
+	return []ottl.Factory[K]{
+		newLookupFactory[K](s),
+		newRememberFactory[K](s),
+		newRecallFactory[K](s),
+	}
+}
+
+type lookupArguments[K any] struct {
+	Table  string
+	Key    ottl.StringLikeGetter[K]
+	Column ottl.Optional[string]
+}
+
+func newLookupFactory[K any](s *State) ottl.Factory[K] {
+	return ottl.NewFactory("Lookup", &lookupArguments[K]{}, func(_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+		args, ok := oArgs.(*lookupArguments[K])
+		if !ok {
+			return nil, fmt.Errorf("LookupFactory args must be of type *lookupArguments[K]")
+		}
+		table, err := s.table(args.Table)
+		if err != nil {
+			return nil, err
+		}
+		return lookup(table, args.Key, args.Column), nil
+	})
+}
+
+// lookup returns the value of column in the row of table with the given key,
+// or the whole row when column is not set. It returns nil when there is no such row or column.
+func lookup[K any](table *lookupTable, key ottl.StringLikeGetter[K], column ottl.Optional[string]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (any, error) {
+		k, err := key.Get(ctx, tCtx)
+		if err != nil || k == nil {
+			return nil, err
+		}
+		r, ok := table.get(*k)
+		if !ok {
+			return nil, nil
+		}
+		if column.IsEmpty() {
+			m := pcommon.NewMap()
+			if err := m.FromRaw(r); err != nil {
+				return nil, err
+			}
+			return m, nil
+		}
+		return r[column.Get()], nil
+	}
+}
+
+type rememberArguments[K any] struct {
+	Cache string
+	Key   ottl.StringLikeGetter[K]
+	Value ottl.Getter[K]
+}
+
+func newRememberFactory[K any](s *State) ottl.Factory[K] {
+	return ottl.NewFactory("remember", &rememberArguments[K]{}, func(_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+		args, ok := oArgs.(*rememberArguments[K])
+		if !ok {
+			return nil, fmt.Errorf("RememberFactory args must be of type *rememberArguments[K]")
+		}
+		c, err := s.cache(args.Cache)
+		if err != nil {
+			return nil, err
+		}
+		return remember(c, args.Key, args.Value), nil
+	})
+}
+
+// remember stores value in c under key. Nothing is stored when key or value is nil.
+func remember[K any](c *cache, key ottl.StringLikeGetter[K], value ottl.Getter[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (any, error) {
+		k, err := key.Get(ctx, tCtx)
+		if err != nil || k == nil {
+			return nil, err
+		}
+		val, err := value.Get(ctx, tCtx)
+		if err != nil || val == nil {
+			return nil, err
+		}
+		c.set(*k, val)
+		return nil, nil
+	}
+}
+
+type recallArguments[K any] struct {
+	Cache string
+	Key   ottl.StringLikeGetter[K]
+}
+
+func newRecallFactory[K any](s *State) ottl.Factory[K] {
+	return ottl.NewFactory("Recall", &recallArguments[K]{}, func(_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+		args, ok := oArgs.(*recallArguments[K])
+		if !ok {
+			return nil, fmt.Errorf("RecallFactory args must be of type *recallArguments[K]")
+		}
+		c, err := s.cache(args.Cache)
+		if err != nil {
+			return nil, err
+		}
+		return recall(c, args.Key), nil
+	})
+}
+
+// recall returns the value stored in c under key, or nil when there is none.
+func recall[K any](c *cache, key ottl.StringLikeGetter[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (any, error) {
+		k, err := key.Get(ctx, tCtx)
+		if err != nil || k == nil {
+			return nil, err
+		}
+		val, _ := c.get(*k)
+		return val, nil
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package state
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+)
+
+func newTestParser(t *testing.T, s *State) ottl.Parser[ottllog.TransformContext] {
+	functions := ottlfuncs.StandardFuncs[ottllog.TransformContext]()
+	for _, f := range Functions[ottllog.TransformContext](s) {
+		functions[f.Name()] = f
+	}
+	parser, err := ottllog.NewParser(functions, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	return parser
+}
+
+func executeStatements(t *testing.T, parser ottl.Parser[ottllog.TransformContext], statements []string, record plog.LogRecord) {
+	parsed, err := parser.ParseStatements(statements)
+	require.NoError(t, err)
+	for _, statement := range parsed {
+		_, _, err = statement.Execute(context.Background(), ottllog.NewTransformContext(record, pcommon.NewInstrumentationScope(), pcommon.NewResource(), plog.NewScopeLogs(), plog.NewResourceLogs()))
+		require.NoError(t, err)
+	}
+}
+
+func TestLookup(t *testing.T) {
+	s := New(map[string]LookupTableConfig{
+		"owners": {Path: filepath.Join("testdata", "owners.json")},
+	}, nil, zap.NewNop())
+	require.NoError(t, s.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, s.Shutdown(context.Background())) }()
+	parser := newTestParser(t, s)
+
+	tests := []struct {
+		name      string
+		statement string
+		expected  map[string]any
+	}{
+		{
+			name:      "column",
+			statement: `set(attributes["team"], Lookup("owners", attributes["service"], "team"))`,
+			expected:  map[string]any{"service": "frontend", "team": "web"},
+		},
+		{
+			name:      "numeric column",
+			statement: `set(attributes["tier"], Lookup("owners", attributes["service"], "tier"))`,
+			expected:  map[string]any{"service": "frontend", "tier": int64(2)},
+		},
+		{
+			name:      "array column",
+			statement: `set(attributes["oncall"], Lookup("owners", attributes["service"], "oncall"))`,
+			expected:  map[string]any{"service": "frontend", "oncall": []any{"alice", "bob"}},
+		},
+		{
+			name:      "whole row",
+			statement: `merge_maps(attributes, Lookup("owners", attributes["service"]), "insert")`,
+			expected:  map[string]any{"service": "frontend", "team": "web", "tier": int64(2), "oncall": []any{"alice", "bob"}},
+		},
+		{
+			name:      "unknown column",
+			statement: `set(attributes["team"], Lookup("owners", attributes["service"], "owner"))`,
+			expected:  map[string]any{"service": "frontend"},
+		},
+		{
+			name:      "unknown key",
+			statement: `set(attributes["team"], Lookup("owners", "backend", "team"))`,
+			expected:  map[string]any{"service": "frontend"},
+		},
+		{
+			name:      "nil key",
+			statement: `set(attributes["team"], Lookup("owners", attributes["missing"], "team"))`,
+			expected:  map[string]any{"service": "frontend"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			record := plog.NewLogRecord()
+			record.Attributes().PutStr("service", "frontend")
+			executeStatements(t, parser, []string{tt.statement}, record)
+			assert.Equal(t, tt.expected, record.Attributes().AsRaw())
+		})
+	}
+}
+
+func TestRememberAndRecall(t *testing.T) {
+	s := New(nil, map[string]CacheConfig{"sessions": {}}, zap.NewNop())
+	parser := newTestParser(t, s)
+
+	login := plog.NewLogRecord()
+	login.Attributes().PutStr("session.id", "abc")
+	login.Attributes().PutInt("user.id", 42)
+	executeStatements(t, parser, []string{
+		`remember("sessions", attributes["session.id"], attributes["user.id"]) where attributes["user.id"] != nil`,
+	}, login)
+
+	request := plog.NewLogRecord()
+	request.Attributes().PutStr("session.id", "abc")
+	other := plog.NewLogRecord()
+	other.Attributes().PutStr("session.id", "def")
+	for _, record := range []plog.LogRecord{request, other} {
+		executeStatements(t, parser, []string{
+			`set(attributes["user.id"], Recall("sessions", attributes["session.id"]))`,
+		}, record)
+	}
+	assert.Equal(t, map[string]any{"session.id": "abc", "user.id": int64(42)}, request.Attributes().AsRaw())
+	assert.Equal(t, map[string]any{"session.id": "def"}, other.Attributes().AsRaw())
+}
+
+func TestFunctionsUnknownName(t *testing.T) {
+	s := New(nil, nil, zap.NewNop())
+	parser := newTestParser(t, s)
+
+	_, err := parser.ParseStatement(`set(attributes["team"], Lookup("owners", attributes["service"], "team"))`)
+	assert.ErrorContains(t, err, `unknown lookup table "owners"`)
+	_, err = parser.ParseStatement(`remember("sessions", attributes["session.id"], attributes["user.id"])`)
+	assert.ErrorContains(t, err, `unknown cache "sessions"`)
+	_, err = parser.ParseStatement(`set(attributes["user.id"], Recall("sessions", attributes["session.id"]))`)
+	assert.ErrorContains(t, err, `unknown cache "sessions"`)
+}
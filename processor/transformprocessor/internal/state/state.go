@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package state holds the lookup tables and caches which OTTL statements of the
+// transform processor can read and write across records.
+package state // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/state"
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+)
+
+// State holds the lookup tables and caches of a processor.
+type State struct {
+	logger *zap.Logger
+	tables map[string]*lookupTable
+	caches map[string]*cache
+
+	stopReloading context.CancelFunc
+	reloading     sync.WaitGroup
+}
+
+// New creates the lookup tables and caches with the given configurations.
+// The lookup tables are loaded by Start.
+func New(tables map[string]LookupTableConfig, caches map[string]CacheConfig, logger *zap.Logger) *State {
+	s := &State{
+		logger: logger,
+		tables: make(map[string]*lookupTable, len(tables)),
+		caches: make(map[string]*cache, len(caches)),
+	}
+	for name, cfg := range tables {
+		s.tables[name] = newLookupTable(name, cfg)
+	}
+	for name, cfg := range caches {
+		s.caches[name] = newCache(cfg)
+	}
+	return s
+}
+
+// Start loads the lookup tables and reloads them when their file changes.
+func (s *State) Start(_ context.Context, _ component.Host) error {
+	for name, table := range s.tables {
+		if _, err := table.reload(); err != nil {
+			return fmt.Errorf("failed to load lookup table %q: %w", name, err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.stopReloading = cancel
+	for _, table := range s.tables {
+		s.reloading.Add(1)
+		go s.reloadPeriodically(ctx, table)
+	}
+	return nil
+}
+
+// Shutdown stops reloading the lookup tables.
+func (s *State) Shutdown(context.Context) error {
+	if s.stopReloading != nil {
+		s.stopReloading()
+	}
+	s.reloading.Wait()
+	return nil
+}
+
+func (s *State) reloadPeriodically(ctx context.Context, table *lookupTable) {
+	defer s.reloading.Done()
+
+	ticker := time.NewTicker(table.cfg.reloadInterval())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			reloaded, err := table.reload()
+			if err != nil {
+				s.logger.Error("failed to reload lookup table, keeping its previous content", zap.String("table", table.name), zap.Error(err))
+				continue
+			}
+			if reloaded {
+				s.logger.Info("reloaded lookup table", zap.String("table", table.name))
+			}
+		}
+	}
+}
+
+func (s *State) table(name string) (*lookupTable, error) {
+	if s != nil {
+		if table, ok := s.tables[name]; ok {
+			return table, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown lookup table %q", name)
+}
+
+func (s *State) cache(name string) (*cache, error) {
+	if s != nil {
+		if c, ok := s.caches[name]; ok {
+			return c, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown cache %q", name)
+}
@@ -0,0 +1,60 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package state
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.uber.org/zap"
+)
+
+func TestStartReloadsChangedTables(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "owners.csv")
+	require.NoError(t, os.WriteFile(path, []byte("service,team\ncheckout,payments\n"), 0600))
+
+	s := New(map[string]LookupTableConfig{
+		"owners": {Path: path, ReloadInterval: time.Millisecond},
+	}, nil, zap.NewNop())
+	require.NoError(t, s.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, s.Shutdown(context.Background())) }()
+
+	table, err := s.table("owners")
+	require.NoError(t, err)
+	r, ok := table.get("checkout")
+	require.True(t, ok)
+	assert.Equal(t, "payments", r["team"])
+
+	require.NoError(t, os.WriteFile(path, []byte("service,team\ncheckout,billing\n"), 0600))
+	require.NoError(t, os.Chtimes(path, time.Now(), time.Now().Add(time.Second)))
+	assert.Eventually(t, func() bool {
+		r, ok := table.get("checkout")
+		return ok && r["team"] == "billing"
+	}, time.Second, time.Millisecond)
+}
+
+func TestStartFailsOnInvalidTable(t *testing.T) {
+	s := New(map[string]LookupTableConfig{
+		"owners": {Path: filepath.Join("testdata", "missing.csv")},
+	}, nil, zap.NewNop())
+	err := s.Start(context.Background(), componenttest.NewNopHost())
+	assert.ErrorContains(t, err, `failed to load lookup table "owners"`)
+	assert.NoError(t, s.Shutdown(context.Background()))
+}
+
+func TestConfigValidate(t *testing.T) {
+	assert.NoError(t, LookupTableConfig{Path: "owners.csv"}.Validate())
+	assert.NoError(t, LookupTableConfig{Path: "owners.txt", Format: FormatJSON}.Validate())
+	assert.EqualError(t, LookupTableConfig{}.Validate(), `path must be specified; unsupported format "", must be "csv" or "json"`)
+	assert.EqualError(t, LookupTableConfig{Path: "owners.csv", ReloadInterval: -time.Second}.Validate(), "reload_interval must not be negative")
+
+	assert.NoError(t, CacheConfig{}.Validate())
+	assert.EqualError(t, CacheConfig{MaxSize: -1, TTL: -time.Second}.Validate(), "max_size must not be negative; ttl must not be negative")
+}
@@ -0,0 +1,180 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package state // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/state"
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync/atomic"
+	"time"
+)
+
+type row = map[string]any
+
+// lookupTable holds the rows of a lookup table file, by key.
+type lookupTable struct {
+	name string
+	cfg  LookupTableConfig
+	rows atomic.Pointer[map[string]row]
+
+	// modTime and size identify the version of the file last loaded. They are only
+	// accessed by the goroutine reloading the table.
+	modTime time.Time
+	size    int64
+}
+
+func newLookupTable(name string, cfg LookupTableConfig) *lookupTable {
+	t := &lookupTable{name: name, cfg: cfg}
+	t.rows.Store(&map[string]row{})
+	return t
+}
+
+// get returns the row with the given key.
+func (t *lookupTable) get(key string) (row, bool) {
+	r, ok := (*t.rows.Load())[key]
+	return r, ok
+}
+
+// reload loads the file of the table if it changed since it was last loaded.
+// The previous rows are kept when the file can't be loaded.
+func (t *lookupTable) reload() (bool, error) {
+	info, err := os.Stat(t.cfg.Path)
+	if err != nil {
+		return false, err
+	}
+	if info.ModTime().Equal(t.modTime) && info.Size() == t.size {
+		return false, nil
+	}
+
+	data, err := os.ReadFile(t.cfg.Path)
+	if err != nil {
+		return false, err
+	}
+	var rows map[string]row
+	switch t.cfg.format() {
+	case FormatCSV:
+		rows, err = parseCSV(data, t.cfg.Key)
+	case FormatJSON:
+		rows, err = parseJSON(data, t.cfg.Key)
+	default:
+		err = fmt.Errorf("unsupported format %q", t.cfg.format())
+	}
+	if err != nil {
+		return false, err
+	}
+
+	t.rows.Store(&rows)
+	t.modTime = info.ModTime()
+	t.size = info.Size()
+	return true, nil
+}
+
+// parseCSV parses a CSV file whose first line holds the names of the columns.
+func parseCSV(data []byte, key string) (map[string]row, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("missing header line")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	keyColumn := 0
+	if key != "" {
+		keyColumn = -1
+		for i, column := range header {
+			if column == key {
+				keyColumn = i
+				break
+			}
+		}
+		if keyColumn == -1 {
+			return nil, fmt.Errorf("key column %q not found in header", key)
+		}
+	}
+
+	rows := map[string]row{}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		r := make(row, len(header))
+		for i, column := range header {
+			r[column] = record[i]
+		}
+		rows[record[keyColumn]] = r
+	}
+}
+
+// parseJSON parses a JSON file holding either an object of rows by key, or an array
+// of rows holding their key in the key field.
+func parseJSON(data []byte, key string) (map[string]row, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var content any
+	if err := decoder.Decode(&content); err != nil {
+		return nil, err
+	}
+
+	rows := map[string]row{}
+	switch content := normalizeJSON(content).(type) {
+	case map[string]any:
+		for k, v := range content {
+			r, ok := v.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("row %q is not an object", k)
+			}
+			rows[k] = r
+		}
+	case []any:
+		if key == "" {
+			return nil, errors.New("key must be specified for arrays of rows")
+		}
+		for i, v := range content {
+			r, ok := v.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("row %d is not an object", i)
+			}
+			k, ok := r[key]
+			if !ok {
+				return nil, fmt.Errorf("row %d has no %q field", i, key)
+			}
+			rows[fmt.Sprint(k)] = r
+		}
+	default:
+		return nil, errors.New("content must be an object or an array of rows")
+	}
+	return rows, nil
+}
+
+// normalizeJSON converts the numbers of decoded JSON values to int64 or float64.
+func normalizeJSON(val any) any {
+	switch v := val.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	case map[string]any:
+		for k, e := range v {
+			v[k] = normalizeJSON(e)
+		}
+	case []any:
+		for i, e := range v {
+			v[i] = normalizeJSON(e)
+		}
+	}
+	return val
+}
@@ -0,0 +1,149 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package state
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookupTableReload(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      LookupTableConfig
+		expected map[string]row
+	}{
+		{
+			name: "csv",
+			cfg:  LookupTableConfig{Path: filepath.Join("testdata", "owners.csv")},
+			expected: map[string]row{
+				"checkout": {"service": "checkout", "team": "payments", "tier": "1"},
+				"frontend": {"service": "frontend", "team": "web", "tier": "2"},
+			},
+		},
+		{
+			name: "csv with key column",
+			cfg:  LookupTableConfig{Path: filepath.Join("testdata", "owners.csv"), Key: "team"},
+			expected: map[string]row{
+				"payments": {"service": "checkout", "team": "payments", "tier": "1"},
+				"web":      {"service": "frontend", "team": "web", "tier": "2"},
+			},
+		},
+		{
+			name: "json object",
+			cfg:  LookupTableConfig{Path: filepath.Join("testdata", "owners.json")},
+			expected: map[string]row{
+				"checkout": {"team": "payments", "tier": int64(1)},
+				"frontend": {"team": "web", "tier": int64(2), "oncall": []any{"alice", "bob"}},
+			},
+		},
+		{
+			name: "json array",
+			cfg:  LookupTableConfig{Path: filepath.Join("testdata", "owners_array.json"), Key: "service"},
+			expected: map[string]row{
+				"checkout": {"service": "checkout", "team": "payments", "tier": int64(1)},
+				"frontend": {"service": "frontend", "team": "web", "tier": 2.5},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := newLookupTable("owners", tt.cfg)
+			reloaded, err := table.reload()
+			require.NoError(t, err)
+			assert.True(t, reloaded)
+			assert.Equal(t, tt.expected, *table.rows.Load())
+
+			reloaded, err = table.reload()
+			require.NoError(t, err)
+			assert.False(t, reloaded, "unchanged file must not be reloaded")
+		})
+	}
+}
+
+func TestLookupTableReloadErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		cfg     LookupTableConfig
+		err     string
+	}{
+		{
+			name:    "empty csv",
+			content: "",
+			cfg:     LookupTableConfig{Format: FormatCSV},
+			err:     "missing header line",
+		},
+		{
+			name:    "unknown csv key column",
+			content: "service,team\ncheckout,payments\n",
+			cfg:     LookupTableConfig{Format: FormatCSV, Key: "owner"},
+			err:     `key column "owner" not found in header`,
+		},
+		{
+			name:    "json array without key",
+			content: `[{"service": "checkout"}]`,
+			cfg:     LookupTableConfig{Format: FormatJSON},
+			err:     "key must be specified for arrays of rows",
+		},
+		{
+			name:    "json row without key",
+			content: `[{"team": "payments"}]`,
+			cfg:     LookupTableConfig{Format: FormatJSON, Key: "service"},
+			err:     `row 0 has no "service" field`,
+		},
+		{
+			name:    "json row not an object",
+			content: `{"checkout": "payments"}`,
+			cfg:     LookupTableConfig{Format: FormatJSON},
+			err:     `row "checkout" is not an object`,
+		},
+		{
+			name:    "json scalar",
+			content: `"payments"`,
+			cfg:     LookupTableConfig{Format: FormatJSON},
+			err:     "content must be an object or an array of rows",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.cfg.Path = filepath.Join(t.TempDir(), "table")
+			require.NoError(t, os.WriteFile(tt.cfg.Path, []byte(tt.content), 0600))
+
+			_, err := newLookupTable("owners", tt.cfg).reload()
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+func TestLookupTableReloadKeepsPreviousRows(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "owners.csv")
+	require.NoError(t, os.WriteFile(path, []byte("service,team\ncheckout,payments\n"), 0600))
+
+	table := newLookupTable("owners", LookupTableConfig{Path: path})
+	_, err := table.reload()
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(path, []byte("service,team\ncheckout\n"), 0600))
+	require.NoError(t, os.Chtimes(path, time.Now(), time.Now().Add(time.Second)))
+	_, err = table.reload()
+	assert.Error(t, err)
+	r, ok := table.get("checkout")
+	require.True(t, ok)
+	assert.Equal(t, "payments", r["team"])
+
+	require.NoError(t, os.WriteFile(path, []byte("service,team\ncheckout,billing\n"), 0600))
+	require.NoError(t, os.Chtimes(path, time.Now(), time.Now().Add(2*time.Second)))
+	reloaded, err := table.reload()
+	require.NoError(t, err)
+	assert.True(t, reloaded)
+	r, ok = table.get("checkout")
+	require.True(t, ok)
+	assert.Equal(t, "billing", r["team"])
+}
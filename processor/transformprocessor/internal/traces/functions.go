@@ -8,17 +8,25 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanevent"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/state"
 )
 
-func SpanFunctions() map[string]ottl.Factory[ottlspan.TransformContext] {
+func SpanFunctions(s *state.State) map[string]ottl.Factory[ottlspan.TransformContext] {
 	// No trace-only functions yet.
 	m := ottlfuncs.StandardFuncs[ottlspan.TransformContext]()
 	isRootSpanFactory := ottlfuncs.NewIsRootSpanFactory()
 	m[isRootSpanFactory.Name()] = isRootSpanFactory
+	for _, f := range state.Functions[ottlspan.TransformContext](s) {
+		m[f.Name()] = f
+	}
 	return m
 }
 
-func SpanEventFunctions() map[string]ottl.Factory[ottlspanevent.TransformContext] {
+func SpanEventFunctions(s *state.State) map[string]ottl.Factory[ottlspanevent.TransformContext] {
 	// No trace-only functions yet.
-	return ottlfuncs.StandardFuncs[ottlspanevent.TransformContext]()
+	m := ottlfuncs.StandardFuncs[ottlspanevent.TransformContext]()
+	for _, f := range state.Functions[ottlspanevent.TransformContext](s) {
+		m[f.Name()] = f
+	}
+	return m
 }
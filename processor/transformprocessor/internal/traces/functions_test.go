@@ -12,13 +12,17 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanevent"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/state"
 )
 
 func Test_SpanFunctions(t *testing.T) {
 	expected := ottlfuncs.StandardFuncs[ottlspan.TransformContext]()
 	isRootSpanFactory := ottlfuncs.NewIsRootSpanFactory()
 	expected[isRootSpanFactory.Name()] = isRootSpanFactory
-	actual := SpanFunctions()
+	for _, f := range state.Functions[ottlspan.TransformContext](nil) {
+		expected[f.Name()] = f
+	}
+	actual := SpanFunctions(nil)
 	require.Equal(t, len(expected), len(actual))
 	for k := range actual {
 		assert.Contains(t, expected, k)
@@ -27,7 +31,10 @@ func Test_SpanFunctions(t *testing.T) {
 
 func Test_SpanEventFunctions(t *testing.T) {
 	expected := ottlfuncs.StandardFuncs[ottlspanevent.TransformContext]()
-	actual := SpanEventFunctions()
+	for _, f := range state.Functions[ottlspanevent.TransformContext](nil) {
+		expected[f.Name()] = f
+	}
+	actual := SpanEventFunctions(nil)
 	require.Equal(t, len(expected), len(actual))
 	for k := range actual {
 		assert.Contains(t, expected, k)
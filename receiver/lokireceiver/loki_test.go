@@ -348,6 +348,35 @@ func TestSendingPushRequestToGRPCEndpoint(t *testing.T) {
 				},
 			}),
 		},
+		{
+			name: "Sending logs with structured metadata to grpc endpoint",
+			body: &push.PushRequest{
+				Streams: []push.Stream{
+					{
+						Labels: "{foo=\"bar\"}",
+						Entries: []push.Entry{
+							{
+								Timestamp: time.Unix(0, 1676888496000000000),
+								Line:      "logline 2",
+								StructuredMetadata: push.LabelsAdapter{
+									{Name: "pod_uid", Value: "c2c9-4b5a"},
+								},
+							},
+						},
+					},
+				},
+			},
+			expected: generateLogs([]Log{
+				{
+					Timestamp: 1676888496000000000,
+					Attributes: map[string]any{
+						"foo":     "bar",
+						"pod_uid": "c2c9-4b5a",
+					},
+					Body: pcommon.NewValueStr("logline 2"),
+				},
+			}),
+		},
 	}
 
 	for i, tt := range tests {